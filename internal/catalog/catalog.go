@@ -23,11 +23,31 @@ type Entry struct {
 	Name     string
 	Curated  *service.Service
 	Registry *registry.ServerResponse
+
+	// Locale selects the translated registry strings DisplayName and
+	// Description prefer. Empty means the registry's default strings.
+	Locale string
 }
 
-// Catalog holds a merged collection of entries from all sources.
+// Catalog holds a merged collection of entries from all sources. Entries are
+// sorted by name once, when the catalog is built, and never change after.
 type Catalog struct {
 	entries []Entry
+	keys    []searchKey // parallel to entries
+	byName  map[string]int
+}
+
+// searchKey holds the lower-cased text an entry is matched against. It is
+// computed once in Merge so that filtering on every keystroke does not
+// lower-case thousands of descriptions again.
+type searchKey struct {
+	name string
+	text string // name, display name and description, NUL-separated
+}
+
+func newSearchKey(e Entry) searchKey {
+	text := strings.ToLower(e.Name + "\x00" + e.DisplayName() + "\x00" + e.Description())
+	return searchKey{name: text[:strings.IndexByte(text, 0)], text: text}
 }
 
 // FromCurated converts a curated service into a catalog entry.
@@ -66,14 +86,46 @@ func FromRegistrySlice(servers []registry.ServerResponse) []Entry {
 	return entries
 }
 
+// Localize sets locale on every entry, so their display strings prefer its
+// translations, and returns entries. Call it before Merge, which indexes the
+// localized text for search.
+func Localize(entries []Entry, locale string) []Entry {
+	for i := range entries {
+		entries[i].Locale = locale
+	}
+	return entries
+}
+
+// RegistryName returns the name of the registry a registry entry was read
+// from, or "" for curated entries.
+func (e Entry) RegistryName() string {
+	if e.Source != SourceRegistry {
+		return ""
+	}
+	if e.Registry != nil && e.Registry.Origin != "" {
+		return e.Registry.Origin
+	}
+	return registry.OfficialName
+}
+
+// SourceLabel returns the entry's source for display: "curated",
+// "registry" for the official registry, or "registry (<name>)" for the
+// others.
+func (e Entry) SourceLabel() string {
+	if name := e.RegistryName(); name != "" && name != registry.OfficialName {
+		return string(e.Source) + " (" + name + ")"
+	}
+	return string(e.Source)
+}
+
 // DisplayName returns a human-friendly name for the entry.
 func (e Entry) DisplayName() string {
 	if e.Source == SourceCurated && e.Curated != nil {
 		return e.Curated.Name
 	}
 	if e.Registry != nil {
-		if e.Registry.Server.Title != "" {
-			return e.Registry.Server.Title
+		if title := e.Registry.Server.Localized(e.Locale).Title; title != "" {
+			return title
 		}
 		return e.Registry.Server.Name
 	}
@@ -86,7 +138,24 @@ func (e Entry) Description() string {
 		return e.Curated.Description
 	}
 	if e.Registry != nil {
-		return e.Registry.Server.Description
+		return e.Registry.Server.Localized(e.Locale).Description
+	}
+	return ""
+}
+
+// SetupNotes returns the setup notes a curated service ships, if any.
+func (e Entry) SetupNotes() string {
+	if e.Source == SourceCurated && e.Curated != nil {
+		return strings.TrimSpace(e.Curated.Setup)
+	}
+	return ""
+}
+
+// DocsURL returns the page with a curated service's setup instructions, if
+// available.
+func (e Entry) DocsURL() string {
+	if e.Source == SourceCurated && e.Curated != nil {
+		return strings.TrimSpace(e.Curated.DocsURL)
 	}
 	return ""
 }
@@ -134,11 +203,12 @@ func (e Entry) WebsiteURL() string {
 	return ""
 }
 
-// HasRemotes reports whether this entry has remote (HTTP/SSE) transports.
+// HasRemotes reports whether this entry has remote (HTTP/SSE/WebSocket)
+// transports.
 func (e Entry) HasRemotes() bool {
 	if e.Source == SourceCurated && e.Curated != nil {
 		t := strings.ToLower(e.Curated.Transport)
-		return t == "http" || t == "sse"
+		return t == "http" || t == "sse" || t == "websocket"
 	}
 	if e.Registry != nil {
 		return len(e.Registry.Server.Remotes) > 0
@@ -261,37 +331,52 @@ func envVarsFromRegistry(resp *registry.ServerResponse) []service.EnvVar {
 }
 
 // Merge creates a catalog from curated and registry entries. On
-// case-insensitive name collision, curated entries take precedence.
+// case-insensitive name collision, curated entries take precedence, then
+// the registry entry that comes first, so registry entries are expected in
+// registry priority order.
 func Merge(curated, reg []Entry) *Catalog {
-	seen := make(map[string]bool)
-	var merged []Entry
+	byName := make(map[string]int, len(curated)+len(reg))
+	merged := make([]Entry, 0, len(curated)+len(reg))
+	keys := make([]searchKey, 0, len(curated)+len(reg))
+
+	for _, group := range [][]Entry{curated, reg} {
+		for _, e := range group {
+			key := newSearchKey(e)
+			if _, ok := byName[key.name]; ok {
+				continue
+			}
+			byName[key.name] = len(merged)
+			merged = append(merged, e)
+			keys = append(keys, key)
+		}
+	}
 
-	for _, e := range curated {
-		key := strings.ToLower(e.Name)
-		seen[key] = true
-		merged = append(merged, e)
+	order := make([]int, len(merged))
+	for i := range order {
+		order[i] = i
 	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return keys[order[a]].name < keys[order[b]].name
+	})
 
-	for _, e := range reg {
-		key := strings.ToLower(e.Name)
-		if seen[key] {
-			continue
-		}
-		seen[key] = true
-		merged = append(merged, e)
+	c := &Catalog{
+		entries: make([]Entry, len(merged)),
+		keys:    make([]searchKey, len(merged)),
+		byName:  byName,
+	}
+	for i, from := range order {
+		c.entries[i] = merged[from]
+		c.keys[i] = keys[from]
+		byName[keys[from].name] = i
 	}
 
-	return &Catalog{entries: merged}
+	return c
 }
 
-// All returns all entries sorted by name.
+// All returns all entries sorted by name. The slice is shared with the
+// catalog and must be treated as read-only; appending to it is safe.
 func (c *Catalog) All() []Entry {
-	cp := make([]Entry, len(c.entries))
-	copy(cp, c.entries)
-	sort.Slice(cp, func(i, j int) bool {
-		return strings.ToLower(cp[i].Name) < strings.ToLower(cp[j].Name)
-	})
-	return cp
+	return c.entries[:len(c.entries):len(c.entries)]
 }
 
 // BySource returns entries matching the given source, sorted by name.
@@ -302,9 +387,6 @@ func (c *Catalog) BySource(source Source) []Entry {
 			filtered = append(filtered, e)
 		}
 	}
-	sort.Slice(filtered, func(i, j int) bool {
-		return strings.ToLower(filtered[i].Name) < strings.ToLower(filtered[j].Name)
-	})
 	return filtered
 }
 
@@ -316,28 +398,21 @@ func (c *Catalog) Search(query string) []Entry {
 	}
 	q := strings.ToLower(query)
 	var results []Entry
-	for _, e := range c.entries {
-		if strings.Contains(strings.ToLower(e.Name), q) ||
-			strings.Contains(strings.ToLower(e.DisplayName()), q) ||
-			strings.Contains(strings.ToLower(e.Description()), q) {
-			results = append(results, e)
+	for i, key := range c.keys {
+		if strings.Contains(key.text, q) {
+			results = append(results, c.entries[i])
 		}
 	}
-	sort.Slice(results, func(i, j int) bool {
-		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
-	})
 	return results
 }
 
 // Find performs a case-insensitive exact match on entry name.
 func (c *Catalog) Find(name string) (Entry, bool) {
-	target := strings.ToLower(name)
-	for _, e := range c.entries {
-		if strings.ToLower(e.Name) == target {
-			return e, true
-		}
+	i, ok := c.byName[strings.ToLower(name)]
+	if !ok {
+		return Entry{}, false
 	}
-	return Entry{}, false
+	return c.entries[i], true
 }
 
 // Count returns the number of entries in the catalog.
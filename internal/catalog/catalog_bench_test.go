@@ -0,0 +1,116 @@
+package catalog
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// largeRegistrySize is the registry size the performance budgets are set
+// for, several times the size of the public MCP registry today.
+const largeRegistrySize = 10000
+
+// frameBudgetNanos is one frame at 60 Hz. Filtering the service list runs on
+// every keystroke in the TUI, so it has to fit in a frame.
+const frameBudgetNanos = 16_000_000
+
+func largeCatalogEntries(n int) (curated, reg []Entry) {
+	for i := 0; i < 20; i++ {
+		curated = append(curated, FromCurated(sampleService(fmt.Sprintf("curated-%02d", i), "Curated service for testing")))
+	}
+
+	for i := 0; i < n; i++ {
+		reg = append(reg, FromRegistry(sampleRegistryServer(
+			fmt.Sprintf("io.github.example-%05d/mcp-server", i),
+			fmt.Sprintf("Example Server %05d", i),
+			fmt.Sprintf("MCP server number %d that exposes issues, pull requests, and Deployment logs to AI assistants", i),
+		)))
+	}
+
+	return curated, reg
+}
+
+func BenchmarkMerge(b *testing.B) {
+	curated, reg := largeCatalogEntries(largeRegistrySize)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		Merge(curated, reg)
+	}
+}
+
+func BenchmarkAll(b *testing.B) {
+	cat := Merge(largeCatalogEntries(largeRegistrySize))
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cat.All()
+	}
+}
+
+func BenchmarkSearch(b *testing.B) {
+	cat := Merge(largeCatalogEntries(largeRegistrySize))
+
+	for _, query := range []string{"deployment", "Example-0999", "no-such-server"} {
+		b.Run(query, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				cat.Search(query)
+			}
+		})
+	}
+}
+
+func TestAllAllocationBudget(t *testing.T) {
+	cat := Merge(largeCatalogEntries(largeRegistrySize))
+
+	if allocs := testing.AllocsPerRun(10, func() { cat.All() }); allocs != 0 {
+		t.Fatalf("expected All() not to allocate, got %.0f allocations", allocs)
+	}
+}
+
+func TestSearchAllocationBudget(t *testing.T) {
+	cat := Merge(largeCatalogEntries(largeRegistrySize))
+
+	// Only the growing result slice may allocate, not one string per entry.
+	allocs := testing.AllocsPerRun(10, func() { cat.Search("example-0999") })
+	if allocs > 10 {
+		t.Fatalf("expected at most 10 allocations per search, got %.0f", allocs)
+	}
+}
+
+func TestMergeMemoryBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("measures allocations over many runs")
+	}
+
+	curated, reg := largeCatalogEntries(largeRegistrySize)
+	result := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Merge(curated, reg)
+		}
+	})
+
+	perEntry := result.AllocedBytesPerOp() / int64(len(curated)+len(reg))
+	if perEntry > 1024 {
+		t.Fatalf("expected Merge to use at most 1 KiB per entry, got %d bytes", perEntry)
+	}
+}
+
+func TestSearchFrameBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("measures wall-clock time")
+	}
+
+	cat := Merge(largeCatalogEntries(largeRegistrySize))
+	result := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			cat.Search("deployment")
+		}
+	})
+
+	if result.NsPerOp() > frameBudgetNanos {
+		t.Fatalf("expected search over %d entries within %dms, took %s", largeRegistrySize, frameBudgetNanos/1_000_000, result.T/time.Duration(result.N))
+	}
+}
@@ -145,6 +145,51 @@ func TestDisplayNameRegistryFallbackToName(t *testing.T) {
 	}
 }
 
+func TestLocalizedEntriesPreferTranslations(t *testing.T) {
+	resp := sampleRegistryServer("ns/sentry", "Sentry", "Error tracking")
+	resp.Server.Meta = &registry.ServerMeta{
+		PublisherProvided: &registry.PublisherMeta{
+			Localizations: map[string]registry.Localization{
+				"de": {Title: "Sentry DE", Description: "Fehlerverfolgung"},
+			},
+		},
+	}
+
+	entries := Localize(FromRegistrySlice([]registry.ServerResponse{resp}), "de_DE.UTF-8")
+	if entries[0].DisplayName() != "Sentry DE" || entries[0].Description() != "Fehlerverfolgung" {
+		t.Fatalf("expected German strings, got %q / %q", entries[0].DisplayName(), entries[0].Description())
+	}
+
+	c := Merge(nil, entries)
+	if got := c.Search("fehler"); len(got) != 1 {
+		t.Fatalf("expected search to match the translated description, got %d results", len(got))
+	}
+
+	fallback := Localize(FromRegistrySlice([]registry.ServerResponse{resp}), "fr")
+	if fallback[0].Description() != "Error tracking" {
+		t.Fatalf("expected default description for a missing locale, got %q", fallback[0].Description())
+	}
+}
+
+func TestSourceLabelNamesOtherRegistries(t *testing.T) {
+	official := FromRegistry(sampleRegistryServer("ns/sentry", "Sentry", "Error tracking"))
+	if official.RegistryName() != registry.OfficialName || official.SourceLabel() != "registry" {
+		t.Fatalf("expected official registry entry, got name=%q label=%q", official.RegistryName(), official.SourceLabel())
+	}
+
+	resp := sampleRegistryServer("corp/sentry", "Sentry", "Error tracking")
+	resp.Origin = "corp"
+	corp := FromRegistry(resp)
+	if corp.RegistryName() != "corp" || corp.SourceLabel() != "registry (corp)" {
+		t.Fatalf("expected corp registry entry, got name=%q label=%q", corp.RegistryName(), corp.SourceLabel())
+	}
+
+	curated := FromCurated(sampleService("sentry", "Error tracking"))
+	if curated.RegistryName() != "" || curated.SourceLabel() != "curated" {
+		t.Fatalf("expected curated entry, got name=%q label=%q", curated.RegistryName(), curated.SourceLabel())
+	}
+}
+
 func TestDescriptionCurated(t *testing.T) {
 	entry := FromCurated(sampleService("sentry", "Error tracking"))
 	if entry.Description() != "Error tracking" {
@@ -159,6 +204,27 @@ func TestDescriptionRegistry(t *testing.T) {
 	}
 }
 
+func TestSetupNotesCurated(t *testing.T) {
+	svc := sampleService("sentry", "Error tracking")
+	svc.Setup = "  Create an auth token with project:read.\n"
+	svc.DocsURL = "https://docs.example.com/sentry"
+	entry := FromCurated(svc)
+
+	if entry.SetupNotes() != "Create an auth token with project:read." {
+		t.Fatalf("unexpected setup notes %q", entry.SetupNotes())
+	}
+	if entry.DocsURL() != "https://docs.example.com/sentry" {
+		t.Fatalf("unexpected docs URL %q", entry.DocsURL())
+	}
+}
+
+func TestSetupNotesRegistryEmpty(t *testing.T) {
+	entry := FromRegistry(sampleRegistryServer("ns/sentry", "Sentry", "Error tracking"))
+	if entry.SetupNotes() != "" || entry.DocsURL() != "" {
+		t.Fatalf("expected no setup notes, got %q and %q", entry.SetupNotes(), entry.DocsURL())
+	}
+}
+
 func TestEnvVarsCurated(t *testing.T) {
 	svc := sampleService("sentry", "Error tracking")
 	entry := FromCurated(svc)
@@ -546,15 +612,22 @@ func TestAllSorted(t *testing.T) {
 	}
 }
 
-func TestAllReturnsCopy(t *testing.T) {
-	curated := []Entry{FromCurated(sampleService("sentry", "Error tracking"))}
+func TestAllAppendsDoNotShareStorage(t *testing.T) {
+	curated := []Entry{
+		FromCurated(sampleService("alpha", "First")),
+		FromCurated(sampleService("sentry", "Error tracking")),
+	}
 	cat := Merge(curated, nil)
 
-	all := cat.All()
-	all[0].Name = "mutated"
+	first := append(cat.All(), FromCurated(sampleService("first", "")))
+	second := append(cat.All(), FromCurated(sampleService("second", "")))
+
+	if first[2].Name != "first" || second[2].Name != "second" {
+		t.Fatalf("expected independent appends, got %q and %q", first[2].Name, second[2].Name)
+	}
 
-	if cat.entries[0].Name == "mutated" {
-		t.Fatal("All() should return a copy, not a reference")
+	if cat.Count() != 2 {
+		t.Fatalf("expected catalog to keep 2 entries, got %d", cat.Count())
 	}
 }
 
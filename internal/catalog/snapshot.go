@@ -0,0 +1,43 @@
+package catalog
+
+import "sync"
+
+// Snapshots hands out catalogs by reference. A catalog never changes after
+// Merge, so every screen and callback can share the same one. Each held
+// catalog is tagged with the version of the inputs it was built from: Load
+// builds a new catalog only when asked for a newer version, and replaces the
+// held one instead of modifying it, so a screen still showing the previous
+// catalog keeps a consistent view until it loads again.
+type Snapshots struct {
+	mu    sync.Mutex
+	byKey map[string]snapshot
+}
+
+type snapshot struct {
+	catalog *Catalog
+	version uint64
+}
+
+// Load returns the catalog held for key when it was built for version or a
+// newer one. Otherwise it calls build and holds the result for version. A
+// failed build leaves the held catalog in place.
+func (s *Snapshots) Load(key string, version uint64, build func() (*Catalog, error)) (*Catalog, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if held, ok := s.byKey[key]; ok && held.version >= version {
+		return held.catalog, nil
+	}
+
+	cat, err := build()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.byKey == nil {
+		s.byKey = make(map[string]snapshot)
+	}
+	s.byKey[key] = snapshot{catalog: cat, version: version}
+
+	return cat, nil
+}
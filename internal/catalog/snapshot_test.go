@@ -0,0 +1,60 @@
+package catalog
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSnapshotsLoadSharesCatalogUntilVersionChanges(t *testing.T) {
+	var snapshots Snapshots
+	builds := 0
+	build := func() (*Catalog, error) {
+		builds++
+		return Merge([]Entry{FromCurated(sampleService("alpha", "Alpha"))}, nil), nil
+	}
+
+	first, err := snapshots.Load("curated", 1, build)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second, _ := snapshots.Load("curated", 1, build)
+	if first != second || builds != 1 {
+		t.Fatalf("expected the same catalog from one build, got %d builds", builds)
+	}
+
+	third, _ := snapshots.Load("curated", 2, build)
+	if third == first || builds != 2 {
+		t.Fatalf("expected a new catalog for a newer version, got %d builds", builds)
+	}
+
+	if first.Count() != 1 {
+		t.Fatalf("expected the previous catalog to stay intact, got %d entries", first.Count())
+	}
+
+	if _, err := snapshots.Load("all", 2, build); err != nil || builds != 3 {
+		t.Fatalf("expected keys to be held separately, got %d builds", builds)
+	}
+}
+
+func TestSnapshotsLoadKeepsCatalogWhenBuildFails(t *testing.T) {
+	var snapshots Snapshots
+	held, _ := snapshots.Load("curated", 1, func() (*Catalog, error) {
+		return Merge(nil, nil), nil
+	})
+
+	_, err := snapshots.Load("curated", 2, func() (*Catalog, error) {
+		return nil, errors.New("load failed")
+	})
+	if err == nil {
+		t.Fatal("expected build error")
+	}
+
+	again, err := snapshots.Load("curated", 1, func() (*Catalog, error) {
+		t.Fatal("expected held catalog to be reused")
+		return nil, nil
+	})
+	if err != nil || again != held {
+		t.Fatalf("expected held catalog after failed rebuild, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/andreagrandi/mcp-wire/internal/format"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/webhook"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	registerCommand(newAuthCmd)
+}
+
+func newAuthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage OAuth tokens from the built-in sign-in",
+		Long: `auth manages the OAuth tokens mcp-wire obtains with install --builtin-oauth
+for targets that cannot run the OAuth login themselves. Each token is
+stored with its refresh token as the credential MCP_WIRE_OAUTH_<SERVICE>,
+where "creds list" shows when it expires.`,
+	}
+
+	cmd.AddCommand(newAuthRefreshCmd())
+
+	return cmd
+}
+
+func newAuthRefreshCmd() *cobra.Command {
+	var targetSlugs []string
+
+	cmd := &cobra.Command{
+		Use:   "refresh <service>",
+		Short: "Refresh the stored OAuth token of a service",
+		Long: `refresh exchanges the stored refresh token of a service for a new access
+token, stores it, and writes it to every target without its own OAuth login
+that has the service configured. install --builtin-oauth refreshes an
+expired token by itself; refresh renews it ahead of time, or after the
+server revoked the old one.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			services, err := loadServices()
+			if err != nil {
+				return fmt.Errorf("load services: %w", err)
+			}
+
+			svc, err := findServiceDefinitionByName(services, args[0])
+			if err != nil {
+				return err
+			}
+
+			targetDefinitions, err := resolveInstallTargets(targetSlugs)
+			if err != nil {
+				return err
+			}
+
+			if _, err := applyProjectDir(cmd, targetDefinitions); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			resolver, store := newCredentialSources()
+			stored, found := loadOAuthToken(store, svc.Name)
+			if !found {
+				return fmt.Errorf("no OAuth token is stored for %q; install it with --builtin-oauth to sign in", svc.Name)
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), builtinOAuthTimeout)
+			defer cancel()
+
+			token, err := refreshBuiltinOAuth(ctx, svc, stored)
+			if err != nil {
+				return fmt.Errorf("refresh the OAuth token of %q: %w", svc.Name, err)
+			}
+
+			output := cmd.OutOrStdout()
+			saveOAuthToken(output, store, svc.Name, token)
+			fmt.Fprintf(output, "Refreshed the OAuth token of %s (expires %s).\n", svc.Name, format.Timestamp(token.ExpiresAt))
+
+			resolvedEnv, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{
+				noPrompt: true,
+				input:    cmd.InOrStdin(),
+				output:   output,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := applyRegistrySubstitutions(&svc, resolvedEnv, resolver); err != nil {
+				return err
+			}
+
+			authenticated := withOAuthToken(svc, token)
+			var failures []error
+			for _, targetDefinition := range targetDefinitions {
+				if _, ownLogin := targetDefinition.(target.AuthTarget); ownLogin || !targetDefinition.IsInstalled() {
+					continue
+				}
+
+				for _, scope := range configuredScopes(targetDefinition, svc.Name) {
+					err := writeServiceEntry(targetDefinition, authenticated, resolvedEnv, scope)
+					emitWebhookEvent(webhook.EventAuth, svc.Name, targetDefinition, scope, err)
+					if err != nil {
+						fmt.Fprintf(output, "  %s (%s): update failed (%v)\n", targetDefinition.Name(), scope, err)
+						failures = append(failures, fmt.Errorf("target %q: %w", targetDefinition.Slug(), err))
+						continue
+					}
+
+					fmt.Fprintf(output, "  %s (%s): token updated\n", targetDefinition.Name(), scope)
+				}
+			}
+
+			if len(failures) > 0 {
+				return fmt.Errorf("refreshed the OAuth token of %q but failed to write it to one or more targets: %w", svc.Name, errors.Join(failures...))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Only update specific target slug(s) or @group(s); can be repeated")
+	addProjectDirFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/oauth"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+// configuredInstallTarget is a fakeInstallTarget that reports services as
+// configured.
+type configuredInstallTarget struct {
+	*fakeInstallTarget
+	configured []string
+}
+
+func (t *configuredInstallTarget) List() ([]string, error) {
+	return t.configured, nil
+}
+
+func TestAuthRefreshWritesNewTokenToConfiguredTargets(t *testing.T) {
+	claudeTarget := &configuredInstallTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Claude Code", slug: "claude", installed: true},
+		configured:        []string{"jira"},
+	}
+	otherTarget := &configuredInstallTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Other", slug: "other", installed: true},
+	}
+	authTarget := &fakeAuthInstallTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Codex CLI", slug: "codex", installed: true},
+	}
+	setUpBuiltinOAuthInstall(t, claudeTarget, otherTarget, authTarget)
+	fileSource := storeBuiltinOAuthToken(t, oauth.Token{AccessToken: "stored-1", RefreshToken: "refresh-1"})
+
+	overrideRefreshBuiltinOAuth(t, func(_ context.Context, _ service.Service, token oauth.Token) (oauth.Token, error) {
+		if token.AccessToken != "stored-1" {
+			t.Errorf("expected the stored token to be refreshed, got %#v", token)
+		}
+
+		return oauth.Token{AccessToken: "access-2", RefreshToken: "refresh-1", ExpiresAt: builtinOAuthNow.Add(time.Hour)}, nil
+	})
+
+	output, err := executeRootCommand(t, "auth", "refresh", "jira", "--target", "claude", "--target", "other", "--target", "codex")
+	if err != nil {
+		t.Fatalf("expected refresh to succeed: %v\n%s", err, output)
+	}
+
+	if got := claudeTarget.lastService.Headers["Authorization"]; claudeTarget.installCalls != 1 || got != "Bearer access-2" {
+		t.Fatalf("expected Claude entry to be rewritten with the new token, got %d installs and %q", claudeTarget.installCalls, got)
+	}
+
+	if otherTarget.installCalls != 0 || authTarget.installCalls != 0 {
+		t.Fatalf("expected targets without the service or with their own login to be left alone, got %d and %d installs", otherTarget.installCalls, authTarget.installCalls)
+	}
+
+	stored, found := parseOAuthToken(fileSource.stored["MCP_WIRE_OAUTH_JIRA"])
+	if !found || stored.AccessToken != "access-2" {
+		t.Fatalf("expected the refreshed token to be stored, got %q", fileSource.stored["MCP_WIRE_OAUTH_JIRA"])
+	}
+
+	if !strings.Contains(output, "Claude Code (user): token updated") {
+		t.Fatalf("expected update outcome, got %q", output)
+	}
+}
+
+func TestAuthRefreshFailsWithoutStoredToken(t *testing.T) {
+	claudeTarget := &fakeInstallTarget{name: "Claude Code", slug: "claude", installed: true}
+	setUpBuiltinOAuthInstall(t, claudeTarget)
+
+	_, err := executeRootCommand(t, "auth", "refresh", "jira", "--target", "claude")
+	if err == nil || !strings.Contains(err.Error(), `no OAuth token is stored for "jira"`) {
+		t.Fatalf("expected missing token error, got %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	registerCommand(newBatchCmd)
+}
+
+// batchOperation is one operation read by batch: an install, uninstall, or
+// update of one service, with the settings the matching command takes as
+// flags.
+type batchOperation struct {
+	// ID is copied to the result, so callers can match results to the
+	// operations they sent.
+	ID      string   `json:"id,omitempty"`
+	Action  string   `json:"action"` // "install", "uninstall", or "update"
+	Service string   `json:"service"`
+	Targets []string `json:"targets,omitempty"`
+	Scope   string   `json:"scope,omitempty"`
+	// Env holds credential values for the service, used ahead of every
+	// credential source and never saved.
+	Env map[string]string `json:"env,omitempty"`
+	// Settings are extra keys for the target config entry, as --set-raw.
+	Settings     map[string]json.RawMessage `json:"settings,omitempty"`
+	ProjectDir   string                     `json:"project_dir,omitempty"`
+	CodexProfile string                     `json:"codex_profile,omitempty"`
+	AllowSystem  bool                       `json:"allow_system,omitempty"`
+	Force        bool                       `json:"force,omitempty"`
+	Supervised   bool                       `json:"supervised,omitempty"`
+	Verify       bool                       `json:"verify,omitempty"`
+	KeepEmpty    bool                       `json:"keep_empty,omitempty"`
+	DryRun       bool                       `json:"dry_run,omitempty"`
+}
+
+// batchResult is the line batch prints for each operation.
+type batchResult struct {
+	// Operation is the position of the operation in the input, from 1.
+	Operation int    `json:"operation"`
+	ID        string `json:"id,omitempty"`
+	Action    string `json:"action"`
+	Service   string `json:"service"`
+	Status    string `json:"status"` // "ok" or "failed"
+	// Report is the result of an install or uninstall, as printed by
+	// install --json and uninstall --json.
+	Report *operationReport `json:"report,omitempty"`
+	// Output is the text an update printed.
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func newBatchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "batch",
+		Short: "Apply a stream of JSON operations read from stdin",
+		Long: `batch reads JSON operations from stdin, one after another, applies each
+in turn, and prints one JSON result line per operation as soon as it is done.
+It lets scripts install, uninstall, and update many services from a single
+process instead of running mcp-wire once per change.
+
+Each operation is an object such as:
+
+  {"id": "1", "action": "install", "service": "sentry", "targets": ["claude"],
+   "scope": "user", "env": {"SENTRY_TOKEN": "..."}}
+
+action is "install", "uninstall", or "update" (upgrade --yes). The other
+fields are the flags of that command: targets, scope, project_dir,
+codex_profile, allow_system, force, supervised, verify, keep_empty, dry_run,
+and settings (as --set-raw). env gives credential values for the operation;
+they are used ahead of every credential source and never saved. Operations
+never prompt.
+
+A failed operation does not stop the ones after it; batch exits non-zero when
+any failed. Input that is not valid JSON ends the batch.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			decoder := json.NewDecoder(cmd.InOrStdin())
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+
+			count, failed := 0, 0
+			for {
+				var raw json.RawMessage
+				if err := decoder.Decode(&raw); err != nil {
+					if errors.Is(err, io.EOF) {
+						break
+					}
+
+					cmd.SilenceUsage = true
+					return fmt.Errorf("read operation %d: %w", count+1, err)
+				}
+
+				count++
+				result := runBatchOperation(cmd, count, raw)
+				if result.Status != "ok" {
+					failed++
+				}
+
+				if err := encoder.Encode(result); err != nil {
+					return fmt.Errorf("write result: %w", err)
+				}
+			}
+
+			if failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d of %d operations failed", failed, count)
+			}
+
+			return nil
+		},
+	}
+}
+
+func runBatchOperation(cmd *cobra.Command, index int, raw json.RawMessage) batchResult {
+	result := batchResult{Operation: index, Status: "failed"}
+
+	var operation batchOperation
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&operation); err != nil {
+		result.Error = fmt.Sprintf("parse operation: %v", err)
+		return result
+	}
+
+	result.ID = operation.ID
+	result.Action = operation.Action
+	result.Service = operation.Service
+
+	args, err := batchArguments(operation)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	if len(operation.Env) > 0 {
+		restore := overrideBatchCredentials(operation.Env)
+		defer restore()
+	}
+
+	var stdout bytes.Buffer
+	root := newRootCommand()
+	root.SetArgs(append(args, batchSuppressArguments(cmd)...))
+	root.SetIn(strings.NewReader(""))
+	root.SetOut(&stdout)
+	root.SetErr(cmd.ErrOrStderr())
+	root.SetContext(cmd.Context())
+	root.SilenceUsage = true
+	root.SilenceErrors = true
+
+	runErr := root.Execute()
+
+	if operation.Action == "update" {
+		result.Output = stdout.String()
+	} else if stdout.Len() > 0 {
+		var report operationReport
+		if err := json.Unmarshal(stdout.Bytes(), &report); err == nil {
+			result.Report = &report
+		}
+	}
+
+	if runErr != nil {
+		result.Error = runErr.Error()
+		return result
+	}
+
+	result.Status = "ok"
+	return result
+}
+
+// batchArguments returns the command line that applies operation.
+func batchArguments(operation batchOperation) ([]string, error) {
+	service := strings.TrimSpace(operation.Service)
+	if service == "" {
+		return nil, errors.New("operation has no service")
+	}
+
+	var args []string
+	switch operation.Action {
+	case "install", "uninstall":
+		args = []string{operation.Action, service, "--output", outputFormatJSON}
+	case "update":
+		args = []string{"upgrade", service, "--yes"}
+	default:
+		return nil, fmt.Errorf("unknown action %q; use install, uninstall, or update", operation.Action)
+	}
+
+	if operation.Action == "install" {
+		args = append(args, "--no-prompt")
+	}
+
+	for _, slug := range operation.Targets {
+		args = append(args, "--target", slug)
+	}
+
+	for _, flag := range []struct{ name, value string }{
+		{"scope", operation.Scope},
+		{"project-dir", operation.ProjectDir},
+		{"codex-profile", operation.CodexProfile},
+	} {
+		if flag.value != "" {
+			args = append(args, "--"+flag.name, flag.value)
+		}
+	}
+
+	for _, flag := range []struct {
+		name string
+		set  bool
+	}{
+		{"allow-system", operation.AllowSystem},
+		{"force", operation.Force},
+		{"supervised", operation.Supervised},
+		{"verify", operation.Verify},
+		{"keep-empty", operation.KeepEmpty},
+		{"dry-run", operation.DryRun},
+	} {
+		if flag.set {
+			args = append(args, "--"+flag.name)
+		}
+	}
+
+	keys := make([]string, 0, len(operation.Settings))
+	for key := range operation.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		args = append(args, "--set-raw", key+"="+string(operation.Settings[key]))
+	}
+
+	return args, nil
+}
+
+// batchSuppressArguments passes the --suppress codes given to batch on to
+// each operation.
+func batchSuppressArguments(cmd *cobra.Command) []string {
+	codes, err := cmd.Flags().GetStringSlice("suppress")
+	if err != nil || len(codes) == 0 {
+		return nil
+	}
+
+	return []string{"--suppress", strings.Join(codes, ",")}
+}
+
+// overrideBatchCredentials makes the credential values of one operation
+// answer lookups ahead of every source until the returned function is
+// called.
+func overrideBatchCredentials(env map[string]string) func() {
+	original := newCredentialSources
+	newCredentialSources = func() (*credential.Resolver, credential.Source) {
+		resolver, store := original()
+		resolver.Use(credentialOverrides(env, "batch"))
+		return resolver, store
+	}
+
+	return func() { newCredentialSources = original }
+}
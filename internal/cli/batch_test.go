@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func TestBatchCommandAppliesOperationsInOrder(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	alpha := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha", installed: true}
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo-service": {
+				Name:      "demo-service",
+				Transport: "sse",
+				URL:       "https://example.com/mcp",
+				Env:       []service.EnvVar{{Name: "DEMO_TOKEN", Required: true}},
+			},
+		}, nil
+	}
+	lookupTarget = func(slug string) (targetpkg.Target, bool) { return alpha, slug == "alpha" }
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{values: map[string]string{}} }
+	newCredentialFileSource = func(string) credential.Source { return &testCredentialSource{values: map[string]string{}} }
+
+	input := `{"id": "first", "action": "install", "service": "demo-service", "targets": ["alpha"], "env": {"DEMO_TOKEN": "batch-token"}}
+{"action": "install", "service": "demo-service", "targets": ["alpha"]}
+{"action": "reinstall", "service": "demo-service"}
+{"action": "uninstall", "service": "demo-service", "targets": ["alpha"], "supervised": true}
+{"action": "install", "service": "demo-service", "target": "alpha"}
+`
+
+	var stdout, stderr bytes.Buffer
+	root := newRootCommand()
+	root.SetIn(strings.NewReader(input))
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	root.SetArgs([]string{"batch"})
+
+	err := root.Execute()
+	if err == nil || err.Error() != "4 of 5 operations failed" {
+		t.Fatalf("expected four failed operations, got %v", err)
+	}
+
+	var results []batchResult
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		var result batchResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			t.Fatalf("expected one JSON result per line, got %q: %v", line, err)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) != 5 {
+		t.Fatalf("expected five results, got %d: %q", len(results), stdout.String())
+	}
+
+	first := results[0]
+	if first.ID != "first" || first.Status != "ok" || first.Report == nil || first.Report.Status != "success" {
+		t.Fatalf("unexpected first result %+v", first)
+	}
+
+	if alpha.installCalls != 1 || alpha.lastEnv["DEMO_TOKEN"] != "batch-token" {
+		t.Fatalf("expected the batch credential to be used once, got %d %v", alpha.installCalls, alpha.lastEnv)
+	}
+
+	if second := results[1]; second.Status != "failed" || !strings.Contains(second.Error, "DEMO_TOKEN") {
+		t.Fatalf("expected the credential to apply to its own operation only, got %+v", second)
+	}
+
+	if third := results[2]; third.Operation != 3 || !strings.Contains(third.Error, `unknown action "reinstall"`) {
+		t.Fatalf("unexpected third result %+v", third)
+	}
+
+	if fourth := results[3]; !strings.Contains(fourth.Error, "unknown flag: --supervised") {
+		t.Fatalf("expected install-only settings to be rejected for uninstall, got %+v", fourth)
+	}
+
+	if fifth := results[4]; !strings.Contains(fifth.Error, `unknown field "target"`) {
+		t.Fatalf("expected unknown fields to be rejected, got %+v", fifth)
+	}
+}
+
+func TestBatchArgumentsMapSettingsToFlags(t *testing.T) {
+	args, err := batchArguments(batchOperation{
+		Action:     "install",
+		Service:    "demo@1.2.0",
+		Targets:    []string{"claude", "@work"},
+		Scope:      "project",
+		ProjectDir: "/src/app",
+		Force:      true,
+		Settings:   map[string]json.RawMessage{"timeout": json.RawMessage(`30`), "alwaysAllow": json.RawMessage(`["read"]`)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "install demo@1.2.0 --output json --no-prompt --target claude --target @work --scope project --project-dir /src/app --force --set-raw alwaysAllow=[\"read\"] --set-raw timeout=30"
+	if got := strings.Join(args, " "); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	args, err = batchArguments(batchOperation{Action: "update", Service: "demo", DryRun: true})
+	if err != nil || strings.Join(args, " ") != "upgrade demo --yes --dry-run" {
+		t.Fatalf("unexpected update arguments %q (%v)", args, err)
+	}
+}
@@ -7,34 +7,42 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var clearRegistryCache = registry.ClearDefaultCache
+var clearRegistryCache = registry.ClearCache
 
 func init() {
+	registerCommand(newCacheCmd)
+}
+
+func newCacheCmd() *cobra.Command {
 	cacheCmd := &cobra.Command{
 		Use:   "cache",
 		Short: "Manage local cache data",
 	}
 
 	cacheCmd.AddCommand(newCacheClearCmd())
-	rootCmd.AddCommand(cacheCmd)
+	return cacheCmd
 }
 
 func newCacheClearCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "clear",
 		Short: "Clear local registry cache",
+		Long:  "Clear the local cache of every enabled registry.",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			path, removed, err := clearRegistryCache()
-			if err != nil {
-				return err
-			}
-
-			if removed {
-				fmt.Fprintf(cmd.OutOrStdout(), "Registry cache cleared: %s\n", path)
-				return nil
+			for _, name := range registrySourceNames() {
+				path, removed, err := clearRegistryCache(name)
+				if err != nil {
+					return err
+				}
+
+				if removed {
+					fmt.Fprintf(cmd.OutOrStdout(), "Registry cache cleared: %s\n", path)
+					continue
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Registry cache already empty: %s\n", path)
 			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "Registry cache already empty: %s\n", path)
 			return nil
 		},
 	}
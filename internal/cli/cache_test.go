@@ -10,7 +10,7 @@ func TestCacheClearCommandReportsCleared(t *testing.T) {
 	original := clearRegistryCache
 	t.Cleanup(func() { clearRegistryCache = original })
 
-	clearRegistryCache = func() (string, bool, error) {
+	clearRegistryCache = func(string) (string, bool, error) {
 		return "/tmp/registry-cache.json", true, nil
 	}
 
@@ -28,7 +28,7 @@ func TestCacheClearCommandReportsAlreadyEmpty(t *testing.T) {
 	original := clearRegistryCache
 	t.Cleanup(func() { clearRegistryCache = original })
 
-	clearRegistryCache = func() (string, bool, error) {
+	clearRegistryCache = func(string) (string, bool, error) {
 		return "/tmp/registry-cache.json", false, nil
 	}
 
@@ -46,7 +46,7 @@ func TestCacheClearCommandReturnsError(t *testing.T) {
 	original := clearRegistryCache
 	t.Cleanup(func() { clearRegistryCache = original })
 
-	clearRegistryCache = func() (string, bool, error) {
+	clearRegistryCache = func(string) (string, bool, error) {
 		return "", false, errors.New("boom")
 	}
 
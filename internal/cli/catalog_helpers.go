@@ -3,12 +3,14 @@ package cli
 import (
 	"fmt"
 	"io"
+	"os"
 	"sort"
 	"strings"
 
 	"github.com/andreagrandi/mcp-wire/internal/catalog"
 	"github.com/andreagrandi/mcp-wire/internal/registry"
 	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/tui"
 )
 
 var loadRegistryCache = defaultLoadRegistryCache
@@ -20,11 +22,30 @@ func defaultLoadRegistryCache() []registry.ServerResponse {
 
 var fetchServerLatest = defaultFetchServerLatest
 
-func defaultFetchServerLatest(serverName string) (*registry.ServerResponse, error) {
-	client := registry.NewClient()
+func defaultFetchServerLatest(baseURL string, serverName string) (*registry.ServerResponse, error) {
+	client := registry.NewClientWithBaseURL(baseURL)
 	return client.GetServerLatest(serverName)
 }
 
+var fetchServerVersion = defaultFetchServerVersion
+
+func defaultFetchServerVersion(baseURL string, serverName string, version string) (*registry.ServerResponse, error) {
+	client := registry.NewClientWithBaseURL(baseURL)
+	return client.GetServerVersion(serverName, version)
+}
+
+var fetchServerVersions = defaultFetchServerVersions
+
+func defaultFetchServerVersions(baseURL string, serverName string) ([]registry.ServerResponse, error) {
+	client := registry.NewClientWithBaseURL(baseURL)
+	resp, err := client.ListServerVersions(serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Servers, nil
+}
+
 // refreshRegistryEntry fetches the latest version details for a registry
 // catalog entry. It returns the updated entry on success, or the original
 // entry unchanged on network/API errors (graceful degradation).
@@ -33,16 +54,115 @@ func refreshRegistryEntry(entry catalog.Entry) catalog.Entry {
 		return entry
 	}
 
-	resp, err := fetchServerLatest(entry.Registry.Server.Name)
+	resp, err := fetchServerLatest(registryBaseURL(entry.RegistryName()), entry.Registry.Server.Name)
 	if err != nil || resp == nil {
 		return entry
 	}
 
+	resp.Origin = entry.Registry.Origin
+
 	return catalog.Entry{
 		Source:   catalog.SourceRegistry,
 		Name:     entry.Name,
 		Registry: resp,
+		Locale:   entry.Locale,
+	}
+}
+
+// findRegistryEntry looks name up in the registry catalog. It reports false
+// when the registry feature is off or the catalog has no such server.
+func findRegistryEntry(name string) (catalog.Entry, bool) {
+	cfg, err := loadConfig()
+	if err != nil || !cfg.IsFeatureEnabled("registry") {
+		return catalog.Entry{}, false
+	}
+
+	cat, err := loadCatalog("registry", true)
+	if err != nil {
+		return catalog.Entry{}, false
+	}
+
+	return cat.Find(name)
+}
+
+// pinRegistryEntry fetches the given version of a registry catalog entry.
+// Unlike refreshRegistryEntry it fails when the version cannot be fetched:
+// installing another version than the one asked for would be wrong.
+func pinRegistryEntry(entry catalog.Entry, version string) (catalog.Entry, error) {
+	if entry.Source != catalog.SourceRegistry || entry.Registry == nil {
+		return entry, fmt.Errorf("%q is not a registry service; only registry services can be pinned to a version", entry.Name)
+	}
+
+	resp, err := fetchServerVersion(registryBaseURL(entry.RegistryName()), entry.Registry.Server.Name, version)
+	if err != nil {
+		return entry, fmt.Errorf("fetch %s@%s from the registry: %w", entry.Registry.Server.Name, version, err)
+	}
+
+	if resp == nil {
+		return entry, fmt.Errorf("registry has no version %q of %s", version, entry.Registry.Server.Name)
 	}
+
+	resp.Origin = entry.Registry.Origin
+
+	return catalog.Entry{
+		Source:   catalog.SourceRegistry,
+		Name:     entry.Name,
+		Registry: resp,
+		Locale:   entry.Locale,
+	}, nil
+}
+
+// splitServiceVersion splits "ns/server@1.2.3" into the service name and
+// the requested version. Without a version, or with an empty one, the name
+// is returned unchanged.
+func splitServiceVersion(name string) (string, string) {
+	at := strings.LastIndex(name, "@")
+	if at <= 0 || strings.TrimSpace(name[at+1:]) == "" {
+		return name, ""
+	}
+
+	return strings.TrimSpace(name[:at]), strings.TrimSpace(name[at+1:])
+}
+
+// registryBrowsePageSize is how many servers the TUI registry browse screen
+// fetches per page.
+const registryBrowsePageSize = 30
+
+// tuiBrowseRegistry fetches one page of the live official registry for the
+// TUI browse screen, bypassing the cached snapshot.
+func tuiBrowseRegistry(query string, cursor string) (tui.RegistryPage, error) {
+	resp, err := listRegistryServers(registry.ListOptions{
+		Limit:  registryBrowsePageSize,
+		Cursor: cursor,
+		Search: query,
+	})
+	if err != nil {
+		return tui.RegistryPage{}, err
+	}
+
+	return tui.RegistryPage{
+		Entries:    catalog.Localize(catalog.FromRegistrySlice(resp.Servers), userLocale()),
+		NextCursor: resp.Metadata.NextCursor,
+	}, nil
+}
+
+// userLocale returns the locale registry descriptions are shown in: the
+// "locale" setting, else the first of LC_ALL, LC_MESSAGES and LANG that is
+// set.
+func userLocale() string {
+	if cfg, err := loadConfig(); err == nil {
+		if locale := cfg.Locale(); locale != "" {
+			return locale
+		}
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			return value
+		}
+	}
+
+	return ""
 }
 
 func loadCatalog(source string, registryEnabled bool) (*catalog.Catalog, error) {
@@ -60,12 +180,35 @@ func loadCatalog(source string, registryEnabled bool) (*catalog.Catalog, error)
 
 	if registryEnabled && (source == "registry" || source == "all") {
 		servers := loadRegistryCache()
-		registryEntries = catalog.FromRegistrySlice(servers)
+		registryEntries = catalog.Localize(catalog.FromRegistrySlice(servers), userLocale())
 	}
 
 	return catalog.Merge(curatedEntries, registryEntries), nil
 }
 
+// tuiCatalogs holds the catalog the TUI last showed for each source.
+var tuiCatalogs catalog.Snapshots
+
+// loadCatalogSnapshot returns the catalog for source, shared by reference
+// with every screen that loaded it before. It is rebuilt only once the
+// background registry sync has published new servers since, so curated
+// services are read once per session.
+func loadCatalogSnapshot(source string, registryEnabled bool) (*catalog.Catalog, error) {
+	key := source
+	var version uint64
+	if registryEnabled {
+		key += "+registry"
+		if source != "curated" {
+			ensureRegistrySyncStarted(true)
+			version = registryServersVersion()
+		}
+	}
+
+	return tuiCatalogs.Load(key, version, func() (*catalog.Catalog, error) {
+		return loadCatalog(source, registryEnabled)
+	})
+}
+
 func printCatalogEntries(output io.Writer, entries []catalog.Entry, showMarkers bool) {
 	fmt.Fprintln(output, "Available services:")
 
@@ -146,7 +289,11 @@ func validateSource(source string) error {
 func printRegistryTrustSummary(output io.Writer, entry catalog.Entry) {
 	fmt.Fprintln(output)
 	fmt.Fprintln(output, "Registry Service Information:")
-	fmt.Fprintf(output, "  Source:    %s (community, not vetted by mcp-wire)\n", entry.Source)
+	if name := entry.RegistryName(); name != registry.OfficialName {
+		fmt.Fprintf(output, "  Source:    %s (%s registry, not vetted by mcp-wire)\n", entry.Source, name)
+	} else {
+		fmt.Fprintf(output, "  Source:    %s (community, not vetted by mcp-wire)\n", entry.Source)
+	}
 	if installType := entry.InstallType(); installType != "" {
 		fmt.Fprintf(output, "  Install:   %s\n", installType)
 	}
@@ -197,31 +344,51 @@ func catalogEntryToService(entry catalog.Entry) (service.Service, bool) {
 	return service.Service{}, false
 }
 
+// registryRemoteTransport maps a registry remote to the service transport
+// that installs it, or "" when mcp-wire cannot install it. A remote with a
+// ws:// or wss:// URL is a WebSocket one whatever type it declares.
+func registryRemoteTransport(remote registry.Transport) string {
+	url := strings.ToLower(strings.TrimSpace(remote.URL))
+	if strings.HasPrefix(url, "ws://") || strings.HasPrefix(url, "wss://") {
+		return "websocket"
+	}
+
+	switch strings.ToLower(remote.Type) {
+	case "streamable-http":
+		return "http"
+	case "sse":
+		return "sse"
+	case "websocket", "ws":
+		return "websocket"
+	default:
+		return ""
+	}
+}
+
 func registryRemoteToService(entry catalog.Entry) (service.Service, bool) {
 	if entry.Registry == nil || len(entry.Registry.Server.Remotes) == 0 {
 		return service.Service{}, false
 	}
 
+	// HTTP and SSE remotes are preferred, since every target supports them;
+	// a WebSocket remote is used when the server offers nothing else.
 	var remote registry.Transport
-	found := false
+	transport := ""
 	for _, r := range entry.Registry.Server.Remotes {
-		t := strings.ToLower(r.Type)
-		if t == "streamable-http" || t == "sse" {
-			remote = r
-			found = true
+		t := registryRemoteTransport(r)
+		if t == "http" || t == "sse" {
+			remote, transport = r, t
 			break
 		}
+		if t == "websocket" && transport == "" {
+			remote, transport = r, t
+		}
 	}
 
-	if !found {
+	if transport == "" {
 		return service.Service{}, false
 	}
 
-	transport := strings.ToLower(remote.Type)
-	if transport == "streamable-http" {
-		transport = "http"
-	}
-
 	var envVars []service.EnvVar
 	seen := map[string]int{} // name -> index in envVars
 
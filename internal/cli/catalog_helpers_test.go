@@ -74,6 +74,35 @@ func TestLoadCatalogCuratedOnly(t *testing.T) {
 	}
 }
 
+func TestLoadCatalogSnapshotSharesCatalogAcrossLoads(t *testing.T) {
+	stubLoadServicesForCatalog(t)
+	tuiCatalogs = catalog.Snapshots{}
+	t.Cleanup(func() { tuiCatalogs = catalog.Snapshots{} })
+
+	first, err := loadCatalogSnapshot("curated", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second, err := loadCatalogSnapshot("curated", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first != second {
+		t.Fatal("expected the same catalog to be shared between loads")
+	}
+
+	all, err := loadCatalogSnapshot("all", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if all == first {
+		t.Fatal("expected each source to have its own catalog")
+	}
+}
+
 func TestLoadCatalogAllWithRegistryEnabled(t *testing.T) {
 	stubLoadServicesForCatalog(t)
 	stubLoadRegistryCache(t, fakeRegistryServers())
@@ -750,6 +779,48 @@ func TestRegistryRemoteToServiceSSE(t *testing.T) {
 	}
 }
 
+func TestRegistryRemoteToServiceWebSocket(t *testing.T) {
+	entry := catalog.Entry{
+		Source: catalog.SourceRegistry,
+		Name:   "ws-server",
+		Registry: &registry.ServerResponse{
+			Server: registry.ServerJSON{
+				Name: "ws-server",
+				Remotes: []registry.Transport{
+					{Type: "websocket", URL: "wss://example.com/mcp"},
+				},
+			},
+		},
+	}
+
+	svc, ok := registryRemoteToService(entry)
+	if !ok {
+		t.Fatal("expected websocket remote to convert successfully")
+	}
+
+	if svc.Transport != "websocket" || svc.URL != "wss://example.com/mcp" {
+		t.Fatalf("expected websocket transport at wss://example.com/mcp, got %q at %q", svc.Transport, svc.URL)
+	}
+
+	// A ws:// URL marks a WebSocket remote whatever type it declares, and
+	// an HTTP remote is preferred when both are offered.
+	entry.Registry.Server.Remotes = []registry.Transport{
+		{Type: "custom", URL: "ws://localhost:9000"},
+		{Type: "streamable-http", URL: "https://example.com/mcp"},
+	}
+
+	svc, ok = registryRemoteToService(entry)
+	if !ok || svc.Transport != "http" {
+		t.Fatalf("expected the http remote to be preferred, got %q (ok=%v)", svc.Transport, ok)
+	}
+
+	entry.Registry.Server.Remotes = entry.Registry.Server.Remotes[:1]
+	svc, ok = registryRemoteToService(entry)
+	if !ok || svc.Transport != "websocket" {
+		t.Fatalf("expected a ws:// remote to convert to websocket, got %q (ok=%v)", svc.Transport, ok)
+	}
+}
+
 func TestRegistryRemoteToServiceNoRemotes(t *testing.T) {
 	entry := catalog.Entry{
 		Source: catalog.SourceRegistry,
@@ -1666,7 +1737,7 @@ func TestRefreshRegistryEntryUpdatesOnSuccess(t *testing.T) {
 	original := fetchServerLatest
 	t.Cleanup(func() { fetchServerLatest = original })
 
-	fetchServerLatest = func(serverName string) (*registry.ServerResponse, error) {
+	fetchServerLatest = func(_ string, serverName string) (*registry.ServerResponse, error) {
 		return &registry.ServerResponse{
 			Server: registry.ServerJSON{
 				Name:        serverName,
@@ -1708,7 +1779,7 @@ func TestRefreshRegistryEntryFallsBackOnError(t *testing.T) {
 	original := fetchServerLatest
 	t.Cleanup(func() { fetchServerLatest = original })
 
-	fetchServerLatest = func(_ string) (*registry.ServerResponse, error) {
+	fetchServerLatest = func(_ string, _ string) (*registry.ServerResponse, error) {
 		return nil, errors.New("network error")
 	}
 
@@ -1735,7 +1806,7 @@ func TestRefreshRegistryEntrySkipsCurated(t *testing.T) {
 	t.Cleanup(func() { fetchServerLatest = original })
 
 	called := false
-	fetchServerLatest = func(_ string) (*registry.ServerResponse, error) {
+	fetchServerLatest = func(_ string, _ string) (*registry.ServerResponse, error) {
 		called = true
 		return nil, nil
 	}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ciEnvironmentVariables are set by CI providers. CI is the de facto
+// convention; the others cover providers that do not always set it.
+var ciEnvironmentVariables = []string{
+	"CI",
+	"GITHUB_ACTIONS",
+	"GITLAB_CI",
+	"BUILDKITE",
+	"CIRCLECI",
+	"JENKINS_URL",
+	"TF_BUILD",
+}
+
+var lookupEnv = os.LookupEnv
+
+// runningInCI reports whether mcp-wire runs unattended in a CI environment:
+// a CI variable is set and stdin is not a terminal, so nobody can answer a
+// prompt.
+func runningInCI(input io.Reader) bool {
+	if isTerminalReader(input) {
+		return false
+	}
+
+	for _, name := range ciEnvironmentVariables {
+		value, ok := lookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "", "0", "false", "no":
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// ciNoPrompt returns the effective --no-prompt value for flows that are safe
+// to run unattended: under CI they never wait on stdin and accept the
+// non-interactive default for every confirmation.
+func ciNoPrompt(cmd *cobra.Command, noPrompt bool) bool {
+	return noPrompt || runningInCI(cmd.InOrStdin())
+}
+
+// requireInteractiveInput fails fast for flows that need a person at the
+// keyboard, pointing at the explicit command to use instead.
+func requireInteractiveInput(cmd *cobra.Command, usage string) error {
+	if !runningInCI(cmd.InOrStdin()) {
+		return nil
+	}
+
+	return fmt.Errorf("interactive mode is not available in CI; run %q instead", usage)
+}
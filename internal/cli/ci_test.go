@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func overrideCIEnvironment(t *testing.T, values map[string]string) {
+	t.Helper()
+
+	original := lookupEnv
+	lookupEnv = func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+	t.Cleanup(func() { lookupEnv = original })
+}
+
+func TestRunningInCI(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   bool
+	}{
+		{name: "no variables", values: map[string]string{}, want: false},
+		{name: "CI true", values: map[string]string{"CI": "true"}, want: true},
+		{name: "CI false", values: map[string]string{"CI": "false"}, want: false},
+		{name: "provider variable", values: map[string]string{"GITHUB_ACTIONS": "true"}, want: true},
+		{name: "empty provider variable", values: map[string]string{"GITLAB_CI": ""}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overrideCIEnvironment(t, tt.values)
+
+			if got := runningInCI(strings.NewReader("")); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRunningInCIIgnoresTerminal(t *testing.T) {
+	overrideCIEnvironment(t, map[string]string{"CI": "true"})
+
+	originalIsTerminalReader := isTerminalReader
+	isTerminalReader = func(_ io.Reader) bool { return true }
+	t.Cleanup(func() { isTerminalReader = originalIsTerminalReader })
+
+	if runningInCI(strings.NewReader("")) {
+		t.Fatal("expected an attached terminal to disable CI mode")
+	}
+}
+
+func TestInstallCommandInCIFailsFastOnMissingCredential(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+	overrideCIEnvironment(t, map[string]string{"CI": "true"})
+
+	installTarget := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha", installed: true}
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo-service": {
+				Name:      "demo-service",
+				Transport: "sse",
+				URL:       "https://example.com/mcp",
+				Env:       []service.EnvVar{{Name: "DEMO_TOKEN", Required: true}},
+			},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{installTarget} }
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{values: map[string]string{}} }
+	newCredentialFileSource = func(string) credential.Source { return &testCredentialSource{values: map[string]string{}} }
+
+	// Input is available on stdin, but CI mode must not read it.
+	_, err := executeInstallCommandWithInput(t, "typed-token\n", "demo-service")
+	if err == nil {
+		t.Fatal("expected install to fail without prompting in CI")
+	}
+
+	if !strings.Contains(err.Error(), "set DEMO_TOKEN in the environment") {
+		t.Fatalf("expected guidance in error, got %v", err)
+	}
+
+	if installTarget.installCalls != 0 {
+		t.Fatalf("expected no install, got %d calls", installTarget.installCalls)
+	}
+}
+
+func TestInstallWizardInCIFailsFast(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+	overrideCIEnvironment(t, map[string]string{"CI": "true"})
+
+	_, err := executeInstallCommandWithInput(t, "1\n")
+	if err == nil || !strings.Contains(err.Error(), "mcp-wire install <service> --target <slug>") {
+		t.Fatalf("expected CI guidance error, got %v", err)
+	}
+}
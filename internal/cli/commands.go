@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+// Deps are what commands are built on: where service definitions come from,
+// which targets there are, and how credentials are looked up and saved. The
+// mcp-wire binary runs with DefaultDeps; other front ends, such as a daemon
+// or an MCP server, build the same commands with their own.
+type Deps struct {
+	// LoadServices loads the curated service definitions, from the given
+	// directories or, with none, from the bundled and user directories.
+	LoadServices func(paths ...string) (map[string]service.Service, error)
+	// AllTargets returns every target commands may write to, and
+	// InstalledTargets the ones present on this machine.
+	AllTargets       func() []target.Target
+	InstalledTargets func() []target.Target
+	// FindTarget returns the target with slug.
+	FindTarget func(slug string) (target.Target, bool)
+	// Credentials returns the resolver credential lookups go through and
+	// the source prompted values are saved to.
+	Credentials func() (*credential.Resolver, credential.Source)
+}
+
+// DefaultDeps returns the dependencies the mcp-wire binary runs with.
+func DefaultDeps() Deps {
+	return Deps{
+		LoadServices:     service.LoadServices,
+		AllTargets:       target.AllTargets,
+		InstalledTargets: target.InstalledTargets,
+		FindTarget:       target.FindTarget,
+		Credentials:      defaultCredentialSources,
+	}
+}
+
+// commandFactories build the subcommands of the root command, in the order
+// their files registered them.
+var commandFactories []func() *cobra.Command
+
+// registerCommand adds the command factory builds to every root command
+// built from now on. Each command file registers its commands in init.
+func registerCommand(factory func() *cobra.Command) {
+	commandFactories = append(commandFactories, factory)
+}
+
+// NewRootCommand builds the mcp-wire command tree on deps. Fields left nil
+// keep the default. Commands reach their dependencies through package
+// state, so a process runs the commands of one Deps at a time: building a
+// tree replaces the dependencies of trees built before.
+func NewRootCommand(deps Deps) *cobra.Command {
+	useDeps(deps)
+	return newRootCommand()
+}
+
+func useDeps(deps Deps) {
+	defaults := DefaultDeps()
+	if deps.LoadServices == nil {
+		deps.LoadServices = defaults.LoadServices
+	}
+	if deps.AllTargets == nil {
+		deps.AllTargets = defaults.AllTargets
+	}
+	if deps.InstalledTargets == nil {
+		deps.InstalledTargets = defaults.InstalledTargets
+	}
+	if deps.FindTarget == nil {
+		deps.FindTarget = defaults.FindTarget
+	}
+	if deps.Credentials == nil {
+		deps.Credentials = defaults.Credentials
+	}
+
+	loadServices = deps.LoadServices
+	allTargets = deps.AllTargets
+	listInstalledTargets = deps.InstalledTargets
+	lookupTarget = deps.FindTarget
+	newCredentialSources = deps.Credentials
+}
+
+// newRootCommand builds the root command with every registered subcommand,
+// on the dependencies in use.
+func newRootCommand() *cobra.Command {
+	root := newRootCmd()
+	for _, factory := range commandFactories {
+		root.AddCommand(factory())
+	}
+
+	return root
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func TestNewRootCommandRunsCommandsOnDeps(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	originalNewCredentialSources := newCredentialSources
+	t.Cleanup(func() { newCredentialSources = originalNewCredentialSources })
+
+	selected := &fakeInstallTarget{name: "Selected CLI", slug: "selected", installed: true}
+	deps := Deps{
+		LoadServices: func(...string) (map[string]service.Service, error) {
+			return map[string]service.Service{
+				"demo-service": {
+					Name:      "demo-service",
+					Transport: "sse",
+					URL:       "https://example.com/mcp",
+					Env:       []service.EnvVar{{Name: "DEMO_TOKEN", Required: true}},
+				},
+			}, nil
+		},
+		FindTarget: func(slug string) (targetpkg.Target, bool) {
+			return selected, slug == "selected"
+		},
+		Credentials: func() (*credential.Resolver, credential.Source) {
+			source := &testCredentialSource{name: "daemon", values: map[string]string{"DEMO_TOKEN": "injected"}}
+			return credential.NewResolver(source), source
+		},
+	}
+
+	var stdout bytes.Buffer
+	root := NewRootCommand(deps)
+	root.SetOut(&stdout)
+	root.SetErr(&stdout)
+	root.SetArgs([]string{"install", "demo-service", "--target", "selected", "--no-prompt"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if selected.installCalls != 1 || selected.lastEnv["DEMO_TOKEN"] != "injected" {
+		t.Fatalf("expected the injected target and credentials to be used, got %d %v", selected.installCalls, selected.lastEnv)
+	}
+
+	if !strings.Contains(stdout.String(), "Selected CLI: configured") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestNewRootCommandBuildsIndependentTrees(t *testing.T) {
+	first := newRootCommand()
+	second := newRootCommand()
+
+	install, _, err := first.Find([]string{"install"})
+	if err != nil {
+		t.Fatalf("expected install to be registered: %v", err)
+	}
+
+	if err := install.Flags().Set("target", "alpha"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+
+	other, _, err := second.Find([]string{"install"})
+	if err != nil {
+		t.Fatalf("expected install to be registered: %v", err)
+	}
+
+	if other.Flags().Changed("target") || len(first.Commands()) != len(commandFactories) {
+		t.Fatalf("expected each tree to hold its own commands, got %d of %d", len(first.Commands()), len(commandFactories))
+	}
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+// checkServiceConflicts warns when a service known to clash with svc is
+// already configured on one of the selected targets. On a terminal the user
+// may choose to proceed; otherwise the warning is printed and the install
+// continues. It reports whether the install should go ahead.
+func checkServiceConflicts(cmd *cobra.Command, svc service.Service, targetDefinitions []target.Target, noPrompt bool, scope target.ConfigScope) (bool, error) {
+	services, err := loadServices()
+	if err != nil {
+		return true, nil
+	}
+
+	output := cmd.OutOrStdout()
+	found := false
+	for _, conflict := range service.Conflicts(services, svc) {
+		for _, targetDefinition := range targetDefinitions {
+			if !serviceConfiguredOn(targetDefinition, conflict, scope) {
+				continue
+			}
+
+			fmt.Fprintf(output, "Warning: %q conflicts with %q, which is configured on %s.\n", svc.Name, conflict, targetDefinition.Name())
+			found = true
+		}
+	}
+
+	if !found {
+		return true, nil
+	}
+
+	input := cmd.InOrStdin()
+	if noPrompt || !isTerminalReader(input) {
+		return true, nil
+	}
+
+	proceed, err := askYesNo(bufio.NewReader(input), output, "Install anyway? [y/N]: ", false)
+	if err != nil {
+		return false, fmt.Errorf("read conflict confirmation: %w", err)
+	}
+
+	if !proceed {
+		fmt.Fprintln(output, "Install cancelled.")
+	}
+
+	return proceed, nil
+}
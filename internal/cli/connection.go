@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/probe"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// connectionCheckTimeout bounds install --verify. It is generous because
+// stdio servers run through npx or uvx may download packages first.
+const connectionCheckTimeout = 60 * time.Second
+
+var probeServer = probe.Server
+
+// connectionReport is the result of install --verify in JSON output.
+type connectionReport struct {
+	Status  string `json:"status"` // "ok", "unauthorized", or "failed"
+	Server  string `json:"server,omitempty"`
+	Version string `json:"version,omitempty"`
+	Tools   int    `json:"tools"`
+	Error   string `json:"error,omitempty"`
+}
+
+// connectionCheckRequested reports whether cmd was run with --verify.
+// Commands without the flag, such as the guided wizard, never connect.
+func connectionCheckRequested(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup("verify")
+	return flag != nil && flag.Value.String() == "true"
+}
+
+// checkServerConnection starts or connects to svc the way a target would
+// and prints whether it completed the MCP initialize exchange. A server
+// that asks an OAuth service to sign in first is not a failure, since the
+// target completes OAuth on its own.
+func checkServerConnection(cmd *cobra.Command, svc service.Service, resolvedEnv map[string]string) error {
+	output := cmd.OutOrStdout()
+	fmt.Fprintln(output, "  Connecting to the server...")
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, connectionCheckTimeout)
+	defer cancel()
+
+	result, err := probeServer(ctx, svc, resolvedEnv)
+	report := operationReportFrom(cmd)
+	switch {
+	case err == nil:
+		fmt.Fprintf(output, "  Connection: ok (%s, %d tools)\n", describeProbedServer(svc, result), result.Tools)
+		if report != nil {
+			report.Connection = &connectionReport{Status: "ok", Server: result.ServerName, Version: result.ServerVersion, Tools: result.Tools}
+		}
+
+		return nil
+	case errors.Is(err, probe.ErrUnauthorized) && serviceUsesOAuth(svc):
+		fmt.Fprintln(output, "  Connection: the server asks to sign in first; complete OAuth in the target to use it.")
+		if report != nil {
+			report.Connection = &connectionReport{Status: "unauthorized"}
+		}
+
+		return nil
+	}
+
+	// A server that failed may echo the credentials it was given.
+	err = credentialRedactor.Wrap(err)
+	fmt.Fprintf(output, "  Connection: failed (%v)\n", err)
+	if report != nil {
+		report.Connection = &connectionReport{Status: "failed", Error: err.Error()}
+	}
+
+	return fmt.Errorf("configured service %q, but it did not respond: %w", svc.Name, err)
+}
+
+func describeProbedServer(svc service.Service, result probe.Result) string {
+	name := result.ServerName
+	if name == "" {
+		name = svc.Name
+	}
+
+	if result.ServerVersion == "" {
+		return name
+	}
+
+	return name + " " + result.ServerVersion
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/probe"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+func overrideConnectionCheckDependencies(t *testing.T, svc service.Service, probeFn func(context.Context, service.Service, map[string]string) (probe.Result, error)) *fakeInstallTarget {
+	t.Helper()
+
+	restore := overrideInstallCommandDependencies(t)
+	originalProbeServer := probeServer
+	t.Cleanup(func() {
+		restore()
+		probeServer = originalProbeServer
+	})
+
+	alpha := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{svc.Name: svc}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{alpha} }
+	newCredentialEnvSource = func() credential.Source {
+		return &testCredentialSource{values: map[string]string{"DEMO_TOKEN": "very-secret-token"}}
+	}
+	newCredentialFileSource = func(_ string) credential.Source { return &testCredentialSource{} }
+	shouldAutoAuthenticate = func(_ *cobra.Command) bool { return false }
+	probeServer = probeFn
+
+	return alpha
+}
+
+func TestInstallVerifyReportsToolCount(t *testing.T) {
+	demo := service.Service{Name: "demo", Transport: "stdio", Command: "npx", Env: []service.EnvVar{{Name: "DEMO_TOKEN", Required: true}}}
+	var probedEnv map[string]string
+	overrideConnectionCheckDependencies(t, demo, func(_ context.Context, svc service.Service, env map[string]string) (probe.Result, error) {
+		probedEnv = env
+		return probe.Result{ServerName: "demo-server", ServerVersion: "1.4.0", Tools: 7}, nil
+	})
+
+	output, err := executeInstallCommand(t, "demo", "--no-prompt", "--verify")
+	if err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "Connection: ok (demo-server 1.4.0, 7 tools)") {
+		t.Fatalf("expected tool count in output, got %q", output)
+	}
+
+	if probedEnv["DEMO_TOKEN"] != "very-secret-token" {
+		t.Fatalf("expected the resolved credentials to reach the server, got %v", probedEnv)
+	}
+}
+
+func TestInstallVerifyFailsWhenServerDoesNotRespond(t *testing.T) {
+	demo := service.Service{Name: "demo", Transport: "stdio", Command: "npx", Env: []service.EnvVar{{Name: "DEMO_TOKEN", Required: true}}}
+	alpha := overrideConnectionCheckDependencies(t, demo, func(context.Context, service.Service, map[string]string) (probe.Result, error) {
+		return probe.Result{}, errors.New("server exited before answering: token very-secret-token rejected")
+	})
+
+	output, err := executeInstallCommand(t, "demo", "--no-prompt", "--verify")
+	if err == nil || !strings.Contains(err.Error(), `configured service "demo", but it did not respond`) {
+		t.Fatalf("expected connection error, got %v", err)
+	}
+
+	if alpha.installCalls != 1 {
+		t.Fatalf("expected the config to be written before connecting, got %d installs", alpha.installCalls)
+	}
+
+	if strings.Contains(output+err.Error(), "very-secret-token") {
+		t.Fatalf("expected credentials to be masked, got %q and %v", output, err)
+	}
+
+	if !strings.Contains(output, "Connection: failed (server exited before answering") {
+		t.Fatalf("expected failure in output, got %q", output)
+	}
+}
+
+func TestInstallVerifyAcceptsOAuthSignInPrompt(t *testing.T) {
+	remote := service.Service{Name: "remote", Transport: "http", URL: "https://example.com/mcp", Auth: "oauth"}
+	overrideConnectionCheckDependencies(t, remote, func(context.Context, service.Service, map[string]string) (probe.Result, error) {
+		return probe.Result{}, fmt.Errorf("initialize: %w (HTTP 401)", probe.ErrUnauthorized)
+	})
+
+	stdout, _, err := executeCommandWithOutputFlags(t, newInstallCmd(), "remote", "--no-prompt", "--verify", "--json")
+	if err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	var report operationReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("expected JSON on stdout, got %q: %v", stdout, err)
+	}
+
+	if report.Connection == nil || report.Connection.Status != "unauthorized" || report.Status != "success" {
+		t.Fatalf("expected an unauthorized connection on a successful install, got %+v", report)
+	}
+}
+
+func TestInstallWithoutVerifyDoesNotConnect(t *testing.T) {
+	demo := service.Service{Name: "demo", Transport: "stdio", Command: "npx"}
+	overrideConnectionCheckDependencies(t, demo, func(context.Context, service.Service, map[string]string) (probe.Result, error) {
+		t.Fatal("expected no connection without --verify")
+		return probe.Result{}, nil
+	})
+
+	output, err := executeInstallCommand(t, "demo", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if strings.Contains(output, "Connection:") {
+		t.Fatalf("expected no connection check, got %q", output)
+	}
+}
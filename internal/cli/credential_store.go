@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+)
+
+// storedCredentialSource is a credential source mcp-wire writes to and can
+// remove credentials from again.
+type storedCredentialSource interface {
+	credential.Source
+	DeleteMany(envNames ...string) error
+}
+
+// newCredentialKeychainSource returns the OS keychain, or nil when this
+// system has none mcp-wire can use.
+var newCredentialKeychainSource = func() storedCredentialSource {
+	keychain := credential.NewKeychainSource()
+	if !keychain.Available() {
+		return nil
+	}
+
+	return keychain
+}
+
+// newOnePasswordSource returns the 1Password source for the references in
+// the "onepassword" config setting.
+var newOnePasswordSource = func(settings config.OnePasswordSettings) credential.Source {
+	return credential.NewOnePasswordSource(settings.Account, settings.References)
+}
+
+// newVaultSource returns the HashiCorp Vault source for the "vault" config
+// setting.
+var newVaultSource = func(settings config.VaultSettings) credential.Source {
+	return credential.NewVaultSource(credential.VaultOptions{
+		Address:   settings.Address,
+		Namespace: settings.Namespace,
+		TokenEnv:  settings.TokenEnv,
+		Path:      settings.Path,
+		Field:     settings.Field,
+	})
+}
+
+// credentialRedactor masks every credential value resolved in this process,
+// for error text that may echo one back.
+var credentialRedactor credential.Redactor
+
+// newCredentialSources returns the resolver and store commands use for
+// credentials; Deps.Credentials replaces it.
+var newCredentialSources = defaultCredentialSources
+
+// defaultCredentialSources returns the resolver credential lookups go
+// through and the source prompted values are saved to. Lookups check the
+// environment, then the OS keychain, then 1Password and HashiCorp Vault when
+// set up in config, then sources registered with the credential package,
+// then the credentials file. Values are saved to the keychain when there is one,
+// falling back to the credentials file, and every save is checked against
+// the registered store policies and "deny_plaintext_credentials".
+func defaultCredentialSources() (*credential.Resolver, credential.Source) {
+	fileSource := newCredentialFileSource("")
+	keychain := newCredentialKeychainSource()
+	cfg, cfgErr := loadConfig()
+
+	sources := []credential.Source{newCredentialEnvSource()}
+	if keychain != nil {
+		sources = append(sources, keychain)
+	}
+	if cfgErr == nil {
+		if settings := cfg.OnePassword(); len(settings.References) > 0 {
+			sources = append(sources, newOnePasswordSource(settings))
+		}
+		if settings := cfg.Vault(); settings.Path != "" {
+			sources = append(sources, newVaultSource(settings))
+		}
+	}
+	sources = append(sources, credential.RegisteredSources()...)
+	sources = append(sources, fileSource)
+
+	resolver := newCredentialResolver(sources...)
+	resolver.Use(credential.Cache(), credentialRedactor.Middleware())
+	resolver.Use(credential.RegisteredMiddleware()...)
+
+	policies := credential.RegisteredStorePolicies()
+	if cfgErr == nil && cfg.DenyPlaintextCredentials() {
+		policies = append(policies, credential.DenyPlaintext)
+	}
+
+	store := credential.GuardStore(fileSource, policies...)
+	if keychain != nil {
+		store = &fallbackCredentialStore{preferred: credential.GuardStore(keychain, policies...), fallback: store}
+	}
+
+	return resolver, store
+}
+
+// credentialOverrides returns middleware that answers the credentials set in
+// values from them, ahead of every source, for the env of a service listed
+// in a manifest or install file or given to batch. Values are reported as
+// coming from source.
+func credentialOverrides(values map[string]string, source string) credential.Middleware {
+	return func(next credential.Lookup) credential.Lookup {
+		return func(envName string) (string, string, bool) {
+			if value, ok := values[envName]; ok {
+				return value, source, true
+			}
+
+			return next(envName)
+		}
+	}
+}
+
+// fallbackCredentialStore saves to the preferred source and to the fallback
+// only when that fails, for example when the keychain is locked or its
+// provider stopped responding.
+type fallbackCredentialStore struct {
+	preferred credential.Source
+	fallback  credential.Source
+}
+
+func (s *fallbackCredentialStore) Name() string {
+	return s.preferred.Name()
+}
+
+func (s *fallbackCredentialStore) Get(envName string) (string, bool) {
+	if value, found := s.preferred.Get(envName); found {
+		return value, true
+	}
+
+	if s.fallback == nil {
+		return "", false
+	}
+
+	return s.fallback.Get(envName)
+}
+
+func (s *fallbackCredentialStore) Store(envName string, value string) error {
+	err := s.preferred.Store(envName, value)
+	if err == nil || s.fallback == nil {
+		return err
+	}
+
+	if fallbackErr := s.fallback.Store(envName, value); fallbackErr != nil {
+		return errors.Join(err, fallbackErr)
+	}
+
+	return nil
+}
+
+// storedCredentialSources returns every source uninstall cleans credentials
+// out of: the OS keychain when there is one, and the credentials file.
+func storedCredentialSources() []storedCredentialSource {
+	sources := make([]storedCredentialSource, 0, 2)
+	if keychain := newCredentialKeychainSource(); keychain != nil {
+		sources = append(sources, keychain)
+	}
+
+	return append(sources, newCredentialFileSourceForCleanup(""))
+}
@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+type fakeKeychainSource struct {
+	fakeCredentialSource
+	deleted []string
+}
+
+func (s *fakeKeychainSource) DeleteMany(envNames ...string) error {
+	for _, envName := range envNames {
+		delete(s.values, envName)
+		s.deleted = append(s.deleted, envName)
+	}
+
+	return nil
+}
+
+func overrideCredentialSources(t *testing.T, keychain storedCredentialSource, fileSource credential.Source) {
+	t.Helper()
+
+	originalKeychain := newCredentialKeychainSource
+	originalEnv := newCredentialEnvSource
+	originalFile := newCredentialFileSource
+	t.Cleanup(func() {
+		newCredentialKeychainSource = originalKeychain
+		newCredentialEnvSource = originalEnv
+		newCredentialFileSource = originalFile
+	})
+
+	newCredentialKeychainSource = func() storedCredentialSource { return keychain }
+	newCredentialEnvSource = func() credential.Source { return &fakeCredentialSource{name: "environment"} }
+	newCredentialFileSource = func(string) credential.Source { return fileSource }
+}
+
+func TestNewCredentialSourcesPrefersKeychain(t *testing.T) {
+	keychain := &fakeKeychainSource{fakeCredentialSource: fakeCredentialSource{
+		name:   "keychain",
+		values: map[string]string{"DEMO_TOKEN": "from-keychain"},
+	}}
+	fileSource := &fakeCredentialSource{name: "file", values: map[string]string{"DEMO_TOKEN": "from-file"}}
+	overrideCredentialSources(t, keychain, fileSource)
+
+	resolver, store := newCredentialSources()
+
+	value, source, found := resolver.Resolve("DEMO_TOKEN")
+	if !found || value != "from-keychain" || source != "keychain" {
+		t.Fatalf("expected keychain value first, got %q from %q (found=%v)", value, source, found)
+	}
+
+	if err := store.Store("NEW_TOKEN", "secret"); err != nil {
+		t.Fatalf("expected store to succeed: %v", err)
+	}
+
+	if keychain.stored["NEW_TOKEN"] != "secret" {
+		t.Fatalf("expected value saved to the keychain, got %v", keychain.stored)
+	}
+
+	if len(fileSource.stored) != 0 {
+		t.Fatalf("expected nothing saved to the file, got %v", fileSource.stored)
+	}
+}
+
+func TestNewCredentialSourcesUsesFileWithoutKeychain(t *testing.T) {
+	fileSource := &fakeCredentialSource{name: "file"}
+	overrideCredentialSources(t, nil, fileSource)
+
+	_, store := newCredentialSources()
+	if err := store.Store("DEMO_TOKEN", "secret"); err != nil {
+		t.Fatalf("expected store to succeed: %v", err)
+	}
+
+	if fileSource.stored["DEMO_TOKEN"] != "secret" {
+		t.Fatalf("expected value saved to the file, got %v", fileSource.stored)
+	}
+}
+
+func TestNewCredentialSourcesFallsBackToFileWhenKeychainFails(t *testing.T) {
+	keychain := &fakeKeychainSource{fakeCredentialSource: fakeCredentialSource{
+		name:     "keychain",
+		storeErr: errors.New("keychain is locked"),
+	}}
+	fileSource := &fakeCredentialSource{name: "file"}
+	overrideCredentialSources(t, keychain, fileSource)
+
+	_, store := newCredentialSources()
+	if err := store.Store("DEMO_TOKEN", "secret"); err != nil {
+		t.Fatalf("expected fallback store to succeed: %v", err)
+	}
+
+	if fileSource.stored["DEMO_TOKEN"] != "secret" {
+		t.Fatalf("expected value saved to the file, got %v", fileSource.stored)
+	}
+}
+
+func TestRemoveStoredCredentialsRemovesFromKeychainAndFile(t *testing.T) {
+	keychain := &fakeKeychainSource{fakeCredentialSource: fakeCredentialSource{
+		name:   "keychain",
+		values: map[string]string{"TOKEN_A": "a", "TOKEN_B": "b"},
+	}}
+	fileSource := credential.NewFileSource(filepath.Join(t.TempDir(), "credentials"))
+	if err := fileSource.Store("TOKEN_A", "old-a"); err != nil {
+		t.Fatalf("failed storing TOKEN_A: %v", err)
+	}
+
+	removedCount, err := removeStoredCredentials([]storedCredentialSource{keychain, fileSource}, []string{"TOKEN_A", "TOKEN_B", "TOKEN_C"})
+	if err != nil {
+		t.Fatalf("expected credential removal to succeed: %v", err)
+	}
+
+	if removedCount != 2 {
+		t.Fatalf("expected two credentials removed, got %d", removedCount)
+	}
+
+	if len(keychain.deleted) != 2 {
+		t.Fatalf("expected both keychain items deleted, got %v", keychain.deleted)
+	}
+
+	if _, found := fileSource.Get("TOKEN_A"); found {
+		t.Fatal("expected TOKEN_A to be removed from the file")
+	}
+}
+
+func TestNewCredentialSourcesDenyPlaintextSkipsSavingToFile(t *testing.T) {
+	fileSource := credential.NewFileSource(filepath.Join(t.TempDir(), "credentials"))
+	overrideCredentialSources(t, nil, fileSource)
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"deny_plaintext_credentials":true}`), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	originalLoadConfig := loadConfig
+	t.Cleanup(func() { loadConfig = originalLoadConfig })
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+
+	resolver, store := newCredentialSources()
+	svc := service.Service{Name: "demo-service", Env: []service.EnvVar{{Name: "DEMO_TOKEN", Required: true}}}
+
+	var output bytes.Buffer
+	resolved, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{
+		input:  strings.NewReader("token-value\n\n"),
+		output: &output,
+		store:  store,
+	})
+	if err != nil {
+		t.Fatalf("expected install to go on without saving: %v", err)
+	}
+
+	if resolved["DEMO_TOKEN"] != "token-value" {
+		t.Fatalf("expected prompted value to be used, got %q", resolved["DEMO_TOKEN"])
+	}
+
+	if !strings.Contains(output.String(), "Not saved: saving credentials in plaintext is not allowed") {
+		t.Fatalf("expected the refusal to be reported, got %q", output.String())
+	}
+
+	if _, found := fileSource.Get("DEMO_TOKEN"); found {
+		t.Fatal("expected nothing written to the credentials file")
+	}
+}
+
+func TestNewCredentialSourcesReadsOnePasswordReferences(t *testing.T) {
+	keychain := &fakeKeychainSource{fakeCredentialSource: fakeCredentialSource{name: "keychain"}}
+	fileSource := &fakeCredentialSource{name: "file", values: map[string]string{"JIRA_TOKEN": "from-file"}}
+	overrideCredentialSources(t, keychain, fileSource)
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	content := `{"onepassword":{"account":"my.1password.com","references":{"JIRA_TOKEN":"op://Work/Jira/credential"}}}`
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	originalLoadConfig := loadConfig
+	originalOnePassword := newOnePasswordSource
+	t.Cleanup(func() {
+		loadConfig = originalLoadConfig
+		newOnePasswordSource = originalOnePassword
+	})
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+
+	var gotSettings config.OnePasswordSettings
+	newOnePasswordSource = func(settings config.OnePasswordSettings) credential.Source {
+		gotSettings = settings
+		return &fakeCredentialSource{name: "1password", values: map[string]string{"JIRA_TOKEN": "from-1password"}}
+	}
+
+	resolver, _ := newCredentialSources()
+
+	value, source, found := resolver.Resolve("JIRA_TOKEN")
+	if !found || value != "from-1password" || source != "1password" {
+		t.Fatalf("expected 1Password value before the file, got %q from %q (found=%v)", value, source, found)
+	}
+
+	if gotSettings.Account != "my.1password.com" || gotSettings.References["JIRA_TOKEN"] != "op://Work/Jira/credential" {
+		t.Fatalf("expected configured settings to be passed, got %+v", gotSettings)
+	}
+}
+
+func TestNewCredentialSourcesReadsVaultWhenConfigured(t *testing.T) {
+	fileSource := &fakeCredentialSource{name: "file", values: map[string]string{"JIRA_TOKEN": "from-file"}}
+	overrideCredentialSources(t, nil, fileSource)
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	content := `{"vault":{"address":"https://vault.example.com","path":"secret/data/mcp-wire"}}`
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	originalLoadConfig := loadConfig
+	originalVault := newVaultSource
+	t.Cleanup(func() {
+		loadConfig = originalLoadConfig
+		newVaultSource = originalVault
+	})
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+
+	var gotSettings config.VaultSettings
+	newVaultSource = func(settings config.VaultSettings) credential.Source {
+		gotSettings = settings
+		return &fakeCredentialSource{name: "vault", values: map[string]string{"JIRA_TOKEN": "from-vault"}}
+	}
+
+	resolver, _ := newCredentialSources()
+
+	value, source, found := resolver.Resolve("JIRA_TOKEN")
+	if !found || value != "from-vault" || source != "vault" {
+		t.Fatalf("expected Vault value before the file, got %q from %q (found=%v)", value, source, found)
+	}
+
+	if gotSettings.Address != "https://vault.example.com" || gotSettings.Path != "secret/data/mcp-wire" || gotSettings.Field != "{name}" {
+		t.Fatalf("expected configured settings to be passed, got %+v", gotSettings)
+	}
+}
@@ -0,0 +1,328 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	registerCommand(newCredsCmd)
+}
+
+// storedCredential is one credential kept by mcp-wire, as creds list shows
+// it.
+type storedCredential struct {
+	Name  string `json:"name"`
+	Store string `json:"store"` // "keychain" or "file"
+	// Value is masked unless --reveal was given.
+	Value string `json:"value"`
+	// Services are the known services that use the credential.
+	Services []string `json:"services,omitempty"`
+	// ExpiresAt is when a built-in OAuth token expires.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func newCredsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "creds",
+		Short: "Inspect and edit stored credentials",
+		Long: `creds shows and edits the credentials mcp-wire keeps in the OS keychain and
+the credentials file, so a token can be rotated without reinstalling the
+services that use it. Target configs keep the value written at install time;
+run "mcp-wire sync" or reinstall the service to write the new one.
+
+Values are masked unless --reveal is given. The keychain cannot be searched,
+so list shows the keychain credentials of the services mcp-wire knows and
+every credential in the credentials file.`,
+	}
+
+	cmd.AddCommand(newCredsListCmd(), newCredsGetCmd(), newCredsSetCmd(), newCredsRmCmd())
+
+	return cmd
+}
+
+func newCredsListCmd() *cobra.Command {
+	var reveal bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stored credentials",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonOutput, err := jsonOutputRequested(cmd)
+			if err != nil {
+				return err
+			}
+
+			credentials := listStoredCredentials(reveal)
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), credentials)
+			}
+
+			output := cmd.OutOrStdout()
+			if len(credentials) == 0 {
+				fmt.Fprintln(output, "No stored credentials.")
+				return nil
+			}
+
+			nameWidth, storeWidth := 0, 0
+			for _, stored := range credentials {
+				nameWidth = max(nameWidth, len(stored.Name))
+				storeWidth = max(storeWidth, len(stored.Store))
+			}
+
+			for _, stored := range credentials {
+				line := fmt.Sprintf("%-*s  %-*s  %s", nameWidth, stored.Name, storeWidth, stored.Store, stored.Value)
+				if stored.ExpiresAt != nil {
+					line += "  " + describeTokenExpiry(*stored.ExpiresAt)
+				}
+				if len(stored.Services) > 0 {
+					line += "  (" + strings.Join(stored.Services, ", ") + ")"
+				}
+				fmt.Fprintln(output, line)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&reveal, "reveal", false, "Show the values instead of masking them")
+
+	return cmd
+}
+
+func newCredsGetCmd() *cobra.Command {
+	var reveal bool
+
+	cmd := &cobra.Command{
+		Use:   "get <name>",
+		Short: "Print a stored credential",
+		Long: `get prints the value of a stored credential, masked unless --reveal is
+given. With --reveal the value is printed alone, so it can be captured by a
+script.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(args[0])
+			for _, source := range storedCredentialSources() {
+				if value, found := source.Get(name); found {
+					if !reveal {
+						value = credential.MaskSecret(value)
+					}
+
+					fmt.Fprintln(cmd.OutOrStdout(), value)
+					return nil
+				}
+			}
+
+			cmd.SilenceUsage = true
+			return fmt.Errorf("credential %q is not stored", name)
+		},
+	}
+
+	cmd.Flags().BoolVar(&reveal, "reveal", false, "Print the value instead of masking it")
+
+	return cmd
+}
+
+func newCredsSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <name> [value]",
+		Short: "Store or replace a credential",
+		Long: `set saves a credential where prompted values go: the OS keychain when there is
+one, else the credentials file. Without a value it is read from the
+terminal with input hidden, or from the first line of stdin, which keeps it
+out of the shell history.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(args[0])
+			if name == "" {
+				return errors.New("credential name is required")
+			}
+
+			var value string
+			if len(args) == 2 {
+				value = args[1]
+			} else {
+				read, err := readCredentialValue(cmd, name)
+				if err != nil {
+					return err
+				}
+				value = read
+			}
+
+			if strings.TrimSpace(value) == "" {
+				return errors.New("credential value is empty")
+			}
+
+			cmd.SilenceUsage = true
+			_, store := newCredentialSources()
+			if err := store.Store(name, value); err != nil {
+				return fmt.Errorf("store %s: %w", name, err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Saved %s to %s.\n", name, store.Name())
+			return nil
+		},
+	}
+}
+
+// readCredentialValue reads the value of name from the terminal with input
+// hidden, or from the first line of a non-terminal stdin.
+func readCredentialValue(cmd *cobra.Command, name string) (string, error) {
+	input := cmd.InOrStdin()
+	if !isTerminalReader(input) {
+		line, err := bufio.NewReader(input).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("read %s: %w", name, err)
+		}
+
+		return strings.TrimSpace(line), nil
+	}
+
+	opts := normalizeInteractiveCredentialOptions(interactiveCredentialOptions{
+		input:  input,
+		output: cmd.ErrOrStderr(),
+	})
+
+	value, err := promptSecretValue(bufio.NewReader(input), opts, "  "+name+": ")
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", name, err)
+	}
+
+	return value, nil
+}
+
+func newCredsRmCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm <name>...",
+		Aliases: []string{"remove"},
+		Short:   "Remove stored credentials",
+		Long: `rm removes credentials from the OS keychain and the credentials file. Target
+configs that were written with a value keep it until the service is
+reinstalled or uninstalled.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			output := cmd.OutOrStdout()
+			sources := storedCredentialSources()
+
+			var failures []error
+			for _, arg := range args {
+				name := strings.TrimSpace(arg)
+
+				stored := false
+				var removedFrom []string
+				for _, source := range sources {
+					if _, found := source.Get(name); !found {
+						continue
+					}
+
+					stored = true
+					if err := source.DeleteMany(name); err != nil {
+						failures = append(failures, fmt.Errorf("remove %s from %s: %w", name, source.Name(), err))
+						continue
+					}
+
+					removedFrom = append(removedFrom, source.Name())
+				}
+
+				if !stored {
+					failures = append(failures, fmt.Errorf("credential %q is not stored", name))
+				} else if len(removedFrom) > 0 {
+					fmt.Fprintf(output, "Removed %s from %s.\n", name, strings.Join(removedFrom, " and "))
+				}
+			}
+
+			return errors.Join(failures...)
+		},
+	}
+}
+
+// describeTokenExpiry tells when a token expires, or how long ago it did.
+func describeTokenExpiry(expiresAt time.Time) string {
+	if _, expired := clock.Age(wallClock, expiresAt); expired {
+		return "expired " + format.Ago(wallClock, expiresAt)
+	}
+
+	return "expires " + format.Timestamp(expiresAt)
+}
+
+// listStoredCredentials returns the credentials in the keychain and the
+// credentials file, sorted by name with the keychain first. Keychain entries
+// are looked up by the names the known services use and the names in the
+// file.
+func listStoredCredentials(reveal bool) []storedCredential {
+	usedBy := map[string][]string{}
+	if services, err := loadServices(); err == nil {
+		for name, svc := range services {
+			for _, envName := range serviceEnvNames(svc) {
+				usedBy[envName] = append(usedBy[envName], name)
+			}
+			if serviceUsesOAuth(svc) {
+				tokenName := oauthTokenCredentialName(name)
+				usedBy[tokenName] = append(usedBy[tokenName], name)
+			}
+		}
+	}
+
+	candidates := map[string]bool{}
+	for envName := range usedBy {
+		candidates[envName] = true
+		sort.Strings(usedBy[envName])
+	}
+
+	sources := storedCredentialSources()
+	for _, source := range sources {
+		if lister, ok := source.(interface{ Names() ([]string, error) }); ok {
+			names, _ := lister.Names()
+			for _, name := range names {
+				candidates[name] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(candidates))
+	for name := range candidates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var credentials []storedCredential
+	for _, name := range names {
+		for _, source := range sources {
+			value, found := source.Get(name)
+			if !found {
+				continue
+			}
+
+			var expiresAt *time.Time
+			if token, ok := parseOAuthToken(value); ok && strings.HasPrefix(name, oauthTokenCredentialPrefix) && !token.ExpiresAt.IsZero() {
+				expiresAt = &token.ExpiresAt
+			}
+
+			if !reveal {
+				value = credential.MaskSecret(value)
+			}
+
+			credentials = append(credentials, storedCredential{
+				Name:      name,
+				Store:     source.Name(),
+				Value:     value,
+				Services:  usedBy[name],
+				ExpiresAt: expiresAt,
+			})
+		}
+	}
+
+	return credentials
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+// overrideCredsStores points creds at keychain and a credentials file in a
+// temporary directory, which it returns.
+func overrideCredsStores(t *testing.T, keychain *fakeKeychainSource) *credential.FileSource {
+	t.Helper()
+
+	fileSource := credential.NewFileSource(filepath.Join(t.TempDir(), "credentials"))
+	if keychain != nil {
+		overrideCredentialSources(t, keychain, fileSource)
+	} else {
+		overrideCredentialSources(t, nil, fileSource)
+	}
+
+	originalCleanup := newCredentialFileSourceForCleanup
+	originalLoadServices := loadServices
+	t.Cleanup(func() {
+		newCredentialFileSourceForCleanup = originalCleanup
+		loadServices = originalLoadServices
+	})
+
+	newCredentialFileSourceForCleanup = func(string) *credential.FileSource { return fileSource }
+	loadServices = func(...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"jira": {Name: "jira", Env: []service.EnvVar{{Name: "JIRA_TOKEN", Required: true}}},
+		}, nil
+	}
+
+	return fileSource
+}
+
+func TestCredsListShowsKeychainAndFileCredentials(t *testing.T) {
+	keychain := &fakeKeychainSource{fakeCredentialSource: fakeCredentialSource{
+		name:   "keychain",
+		values: map[string]string{"JIRA_TOKEN": "jira-secret-1234"},
+	}}
+	fileSource := overrideCredsStores(t, keychain)
+	if err := fileSource.Store("CUSTOM_KEY", "custom-secret-5678"); err != nil {
+		t.Fatalf("store credential: %v", err)
+	}
+
+	output, err := executeRootCommand(t, "creds", "list")
+	if err != nil {
+		t.Fatalf("expected list to succeed: %v", err)
+	}
+
+	want := "CUSTOM_KEY  file      ********5678\nJIRA_TOKEN  keychain  ********1234  (jira)\n"
+	if output != want {
+		t.Fatalf("expected masked credentials\n%s\ngot\n%s", want, output)
+	}
+
+	output, err = executeRootCommand(t, "creds", "list", "--reveal", "--json")
+	if err != nil {
+		t.Fatalf("expected list --json to succeed: %v", err)
+	}
+
+	var credentials []storedCredential
+	if err := json.Unmarshal([]byte(output), &credentials); err != nil {
+		t.Fatalf("expected JSON output: %v\n%s", err, output)
+	}
+
+	if len(credentials) != 2 || credentials[1].Value != "jira-secret-1234" || credentials[1].Store != "keychain" {
+		t.Fatalf("expected revealed keychain credential, got %+v", credentials)
+	}
+}
+
+func TestCredsListShowsOAuthTokenExpiry(t *testing.T) {
+	fileSource := overrideCredsStores(t, nil)
+	loadServices = func(...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"jira": {Name: "jira", Transport: "sse", Auth: "oauth", URL: "https://mcp.atlassian.com/v1/sse"},
+		}, nil
+	}
+
+	originalClock := wallClock
+	wallClock = clock.Fixed(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+	t.Cleanup(func() { wallClock = originalClock })
+
+	if err := fileSource.Store("MCP_WIRE_OAUTH_JIRA", `{"access_token":"access-1234","expires_at":"2026-10-01T09:00:00Z"}`); err != nil {
+		t.Fatalf("store credential: %v", err)
+	}
+
+	output, err := executeRootCommand(t, "creds", "list")
+	if err != nil {
+		t.Fatalf("expected list to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "MCP_WIRE_OAUTH_JIRA  file  ") || !strings.Contains(output, "expired 3 hours ago  (jira)") {
+		t.Fatalf("expected token expiry and service, got %q", output)
+	}
+
+	if strings.Contains(output, "access-1234") {
+		t.Fatalf("expected the token to stay masked, got %q", output)
+	}
+
+	output, err = executeRootCommand(t, "creds", "list", "--json")
+	if err != nil {
+		t.Fatalf("expected list --json to succeed: %v", err)
+	}
+
+	var credentials []storedCredential
+	if err := json.Unmarshal([]byte(output), &credentials); err != nil {
+		t.Fatalf("expected JSON output: %v\n%s", err, output)
+	}
+
+	if len(credentials) != 1 || credentials[0].ExpiresAt == nil || !credentials[0].ExpiresAt.Equal(time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected expires_at in JSON, got %+v", credentials)
+	}
+}
+
+func TestCredsGetMasksUnlessRevealed(t *testing.T) {
+	fileSource := overrideCredsStores(t, nil)
+	if err := fileSource.Store("JIRA_TOKEN", "jira-secret-1234"); err != nil {
+		t.Fatalf("store credential: %v", err)
+	}
+
+	if output, err := executeRootCommand(t, "creds", "get", "JIRA_TOKEN"); err != nil || output != "********1234\n" {
+		t.Fatalf("expected masked value, got %q (%v)", output, err)
+	}
+
+	if output, err := executeRootCommand(t, "creds", "get", "JIRA_TOKEN", "--reveal"); err != nil || output != "jira-secret-1234\n" {
+		t.Fatalf("expected revealed value, got %q (%v)", output, err)
+	}
+
+	if _, err := executeRootCommand(t, "creds", "get", "MISSING"); err == nil || !strings.Contains(err.Error(), `credential "MISSING" is not stored`) {
+		t.Fatalf("expected missing credential error, got %v", err)
+	}
+}
+
+func TestCredsSetReadsValueFromStdin(t *testing.T) {
+	keychain := &fakeKeychainSource{fakeCredentialSource: fakeCredentialSource{name: "keychain"}}
+	overrideCredsStores(t, keychain)
+
+	output, err := executeRootCommandWithInput(t, strings.NewReader("rotated-token\n"), "creds", "set", "JIRA_TOKEN")
+	if err != nil {
+		t.Fatalf("expected set to succeed: %v", err)
+	}
+
+	if keychain.stored["JIRA_TOKEN"] != "rotated-token" {
+		t.Fatalf("expected value saved to the keychain, got %v", keychain.stored)
+	}
+
+	if output != "Saved JIRA_TOKEN to keychain.\n" {
+		t.Fatalf("unexpected output %q", output)
+	}
+
+	if _, err := executeRootCommandWithInput(t, strings.NewReader("\n"), "creds", "set", "JIRA_TOKEN"); err == nil {
+		t.Fatal("expected an empty value to be rejected")
+	}
+}
+
+func TestCredsRmRemovesFromEveryStore(t *testing.T) {
+	keychain := &fakeKeychainSource{fakeCredentialSource: fakeCredentialSource{
+		name:   "keychain",
+		values: map[string]string{"JIRA_TOKEN": "from-keychain"},
+	}}
+	fileSource := overrideCredsStores(t, keychain)
+	if err := fileSource.Store("JIRA_TOKEN", "from-file"); err != nil {
+		t.Fatalf("store credential: %v", err)
+	}
+
+	output, err := executeRootCommand(t, "creds", "rm", "JIRA_TOKEN", "MISSING")
+	if err == nil || !strings.Contains(err.Error(), `credential "MISSING" is not stored`) {
+		t.Fatalf("expected error for the missing credential, got %v", err)
+	}
+
+	if !strings.Contains(output, "Removed JIRA_TOKEN from keychain and file.") {
+		t.Fatalf("expected removal from both stores, got %q", output)
+	}
+
+	if _, found := fileSource.Get("JIRA_TOKEN"); found || len(keychain.deleted) != 1 {
+		t.Fatalf("expected the credential gone from both stores, deleted %v", keychain.deleted)
+	}
+}
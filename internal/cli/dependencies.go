@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+// installServiceDependencies installs the services svc depends on, in
+// dependency order, before svc itself is installed. Dependencies already
+// configured on every selected target are skipped. On a terminal the user is
+// asked to confirm; otherwise dependencies are installed automatically.
+func installServiceDependencies(cmd *cobra.Command, svc service.Service, targetDefinitions []target.Target, noPrompt bool, scope target.ConfigScope) error {
+	if len(svc.DependsOn) == 0 {
+		return nil
+	}
+
+	services, err := loadServices()
+	if err != nil {
+		return fmt.Errorf("load services: %w", err)
+	}
+
+	dependencies, err := service.ResolveDependencies(services, svc)
+	if err != nil {
+		return err
+	}
+
+	missing := make([]service.Service, 0, len(dependencies))
+	for _, dep := range dependencies {
+		if !serviceConfiguredOnAll(targetDefinitions, dep.Name, scope) {
+			missing = append(missing, dep)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(missing))
+	for _, dep := range missing {
+		names = append(names, dep.Name)
+	}
+
+	output := cmd.OutOrStdout()
+	fmt.Fprintf(output, "Service %q depends on: %s\n", svc.Name, strings.Join(names, ", "))
+
+	input := cmd.InOrStdin()
+	if !noPrompt && isTerminalReader(input) {
+		install, err := askYesNo(bufio.NewReader(input), output, "Install dependencies first? [Y/n]: ", true)
+		if err != nil {
+			return fmt.Errorf("read dependency confirmation: %w", err)
+		}
+
+		if !install {
+			fmt.Fprintln(output, "Skipping dependencies.")
+			return nil
+		}
+	}
+
+	for _, dep := range missing {
+		if err := executeInstall(cmd, dep, targetDefinitions, noPrompt, scope); err != nil {
+			return fmt.Errorf("install dependency %q: %w", dep.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// warnServiceDependents prints a warning when services that depend on
+// serviceName are still configured on any of the selected targets.
+func warnServiceDependents(cmd *cobra.Command, serviceName string, targetDefinitions []target.Target, scope target.ConfigScope) {
+	services, err := loadServices()
+	if err != nil {
+		return
+	}
+
+	configured := make([]string, 0)
+	for _, dependent := range service.Dependents(services, serviceName) {
+		for _, targetDefinition := range targetDefinitions {
+			if serviceConfiguredOn(targetDefinition, dependent, scope) {
+				configured = append(configured, dependent)
+				break
+			}
+		}
+	}
+
+	if len(configured) == 0 {
+		return
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Warning: %s depend(s) on %q and may stop working.\n", strings.Join(configured, ", "), serviceName)
+}
+
+func serviceConfiguredOnAll(targetDefinitions []target.Target, serviceName string, scope target.ConfigScope) bool {
+	for _, targetDefinition := range targetDefinitions {
+		if !serviceConfiguredOn(targetDefinition, serviceName, scope) {
+			return false
+		}
+	}
+
+	return len(targetDefinitions) > 0
+}
+
+func serviceConfiguredOn(targetDefinition target.Target, serviceName string, scope target.ConfigScope) bool {
+	names, err := configuredServiceNames(targetDefinition, scope)
+	if err != nil {
+		return false
+	}
+
+	for _, name := range names {
+		if strings.EqualFold(name, serviceName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// configuredServiceNames lists the services targetDefinition configures in
+// scope, or in its user config when it does not support scope.
+func configuredServiceNames(targetDefinition target.Target, scope target.ConfigScope) ([]string, error) {
+	scopedTarget, supportsScopes := targetDefinition.(target.ScopedTarget)
+	if supportsScopes && targetSupportsScope(targetDefinition, scope) {
+		return scopedTarget.ListWithScope(scope)
+	}
+
+	return targetDefinition.List()
+}
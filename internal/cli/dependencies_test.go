@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+type fakeDependencyTarget struct {
+	*fakeInstallTarget
+	configured []string
+}
+
+func (t *fakeDependencyTarget) Install(svc service.Service, resolvedEnv map[string]string) error {
+	t.configured = append(t.configured, svc.Name)
+	return t.fakeInstallTarget.Install(svc, resolvedEnv)
+}
+
+func (t *fakeDependencyTarget) List() ([]string, error) {
+	return t.configured, nil
+}
+
+func dependencyCommandServices() map[string]service.Service {
+	return map[string]service.Service{
+		"proxy": {Name: "proxy", Transport: "stdio", Command: "proxy"},
+		"app":   {Name: "app", Transport: "stdio", Command: "app", DependsOn: []string{"proxy"}},
+	}
+}
+
+func TestInstallCommandInstallsDependenciesFirst(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	installTarget := &fakeDependencyTarget{fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha", installed: true}}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return dependencyCommandServices(), nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{installTarget} }
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{values: map[string]string{}} }
+	newCredentialFileSource = func(string) credential.Source { return &testCredentialSource{values: map[string]string{}} }
+
+	output, err := executeInstallCommand(t, "app", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected install command to succeed: %v", err)
+	}
+
+	want := []string{"proxy", "app"}
+	if !reflect.DeepEqual(installTarget.configured, want) {
+		t.Fatalf("expected install order %v, got %v", want, installTarget.configured)
+	}
+
+	if !strings.Contains(output, `Service "app" depends on: proxy`) {
+		t.Fatalf("expected dependency notice, got %q", output)
+	}
+}
+
+func TestInstallCommandSkipsDependenciesAlreadyConfigured(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	installTarget := &fakeDependencyTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha", installed: true},
+		configured:        []string{"proxy"},
+	}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return dependencyCommandServices(), nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{installTarget} }
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{values: map[string]string{}} }
+	newCredentialFileSource = func(string) credential.Source { return &testCredentialSource{values: map[string]string{}} }
+
+	output, err := executeInstallCommand(t, "app", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected install command to succeed: %v", err)
+	}
+
+	if installTarget.installCalls != 1 {
+		t.Fatalf("expected only the requested service to be installed, got %d installs", installTarget.installCalls)
+	}
+
+	if strings.Contains(output, "depends on") {
+		t.Fatalf("did not expect dependency notice, got %q", output)
+	}
+}
+
+func TestInstallCommandFailsOnUnknownDependency(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	installTarget := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha", installed: true}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"app": {Name: "app", Transport: "stdio", Command: "app", DependsOn: []string{"missing"}},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{installTarget} }
+
+	_, err := executeInstallCommand(t, "app", "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), `unknown service "missing"`) {
+		t.Fatalf("expected unknown dependency error, got %v", err)
+	}
+
+	if installTarget.installCalls != 0 {
+		t.Fatalf("expected no installs, got %d", installTarget.installCalls)
+	}
+}
+
+func TestUninstallCommandWarnsAboutConfiguredDependents(t *testing.T) {
+	restore := overrideUninstallCommandDependencies(t)
+	defer restore()
+
+	uninstallTarget := &fakeUninstallTarget{
+		name:              "Alpha CLI",
+		slug:              "alpha",
+		installed:         true,
+		installedServices: []string{"proxy", "app"},
+	}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return dependencyCommandServices(), nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{uninstallTarget} }
+
+	output, err := executeUninstallCommand(t, "proxy")
+	if err != nil {
+		t.Fatalf("expected uninstall command to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, `Warning: app depend(s) on "proxy"`) {
+		t.Fatalf("expected dependents warning, got %q", output)
+	}
+
+	if uninstallTarget.uninstallCalls != 1 {
+		t.Fatalf("expected uninstall to proceed, got %d calls", uninstallTarget.uninstallCalls)
+	}
+}
+
+func TestInstallCommandWarnsAboutConfiguredConflicts(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	installTarget := &fakeDependencyTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha", installed: true},
+		configured:        []string{"browser-a"},
+	}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"browser-a": {Name: "browser-a", Transport: "stdio", Command: "a"},
+			"browser-b": {Name: "browser-b", Transport: "stdio", Command: "b", ConflictsWith: []string{"browser-a"}},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{installTarget} }
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{values: map[string]string{}} }
+	newCredentialFileSource = func(string) credential.Source { return &testCredentialSource{values: map[string]string{}} }
+
+	output, err := executeInstallCommand(t, "browser-b", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected install command to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, `Warning: "browser-b" conflicts with "browser-a", which is configured on Alpha CLI.`) {
+		t.Fatalf("expected conflict warning, got %q", output)
+	}
+
+	if installTarget.installCalls != 1 {
+		t.Fatalf("expected install to proceed, got %d installs", installTarget.installCalls)
+	}
+}
+
+func TestInstallCommandCancelsOnDeclinedConflict(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	originalIsTerminalReader := isTerminalReader
+	isTerminalReader = func(_ io.Reader) bool { return true }
+	defer func() { isTerminalReader = originalIsTerminalReader }()
+
+	installTarget := &fakeDependencyTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha", installed: true},
+		configured:        []string{"browser-a"},
+	}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"browser-a": {Name: "browser-a", Transport: "stdio", Command: "a", ConflictsWith: []string{"browser-b"}},
+			"browser-b": {Name: "browser-b", Transport: "stdio", Command: "b"},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{installTarget} }
+
+	output, err := executeInstallCommandWithInput(t, "n\n", "browser-b")
+	if err != nil {
+		t.Fatalf("expected declined install to return cleanly: %v", err)
+	}
+
+	if !strings.Contains(output, "Install cancelled.") {
+		t.Fatalf("expected cancellation message, got %q", output)
+	}
+
+	if installTarget.installCalls != 0 {
+		t.Fatalf("expected no installs, got %d", installTarget.installCalls)
+	}
+}
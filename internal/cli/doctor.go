@@ -5,13 +5,22 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/andreagrandi/mcp-wire/internal/app"
+	"github.com/andreagrandi/mcp-wire/internal/clock"
 	"github.com/andreagrandi/mcp-wire/internal/config"
 	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/diagnostics"
+	"github.com/andreagrandi/mcp-wire/internal/format"
 	"github.com/andreagrandi/mcp-wire/internal/registry"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/state"
 	"github.com/andreagrandi/mcp-wire/internal/target"
 	"github.com/spf13/cobra"
 )
@@ -22,31 +31,102 @@ type doctorPathProbe struct {
 	path  string
 }
 
+// registryCacheMaxAge is how old the registry cache may get before doctor
+// reports it as stale, unless registry.cache_ttl allows more.
+const registryCacheMaxAge = 7 * 24 * time.Hour
+
+// doctorRuntimes lists the runtimes curated services launch, with the fix
+// doctor suggests when one is missing.
+var doctorRuntimes = []struct {
+	binary string
+	fix    string
+}{
+	{binary: "npx", fix: "Install Node.js (https://nodejs.org), which provides npx."},
+	{binary: "uvx", fix: "Install uv (https://docs.astral.sh/uv/), which provides uvx."},
+	{binary: "docker", fix: "Install Docker (https://docs.docker.com/get-docker/) and make sure it is on PATH."},
+	{binary: "dotnet", fix: "Install the .NET SDK (https://dotnet.microsoft.com/download)."},
+}
+
+// doctorCheckLevel grades a single doctor check.
+type doctorCheckLevel int
+
+const (
+	doctorCheckOK doctorCheckLevel = iota
+	doctorCheckWarn
+	doctorCheckFail
+)
+
+func (l doctorCheckLevel) label() string {
+	switch l {
+	case doctorCheckWarn:
+		return "[warn]"
+	case doctorCheckFail:
+		return "[fail]"
+	default:
+		return "[ok]"
+	}
+}
+
+// doctorCheck is one diagnostic result. Failed checks are critical and make
+// doctor exit non-zero; fix, when set, tells the user what to do about it.
+// Warnings carry the code they can be suppressed with.
+type doctorCheck struct {
+	level   doctorCheckLevel
+	code    diagnostics.Code
+	subject string
+	detail  string
+	fix     string
+}
+
 // doctorDeps wires the data sources doctor reads, so tests can substitute them.
 type doctorDeps struct {
-	loadConfig        func() (*config.Config, error)
-	allTargets        func() []target.Target
-	registryCachePath func() string
-	credentialsPath   func() string
-	userServicesPath  func() string
-	version           string
-	stat              func(name string) (os.FileInfo, error)
+	loadConfig         func() (*config.Config, error)
+	allTargets         func() []target.Target
+	registryCachePath  func() string
+	credentialsPath    func() string
+	userServicesPath   func() string
+	portAllocations    func() ([]state.PortAllocation, error)
+	loadServices       func() (map[string]service.Service, error)
+	installRecords     func() ([]state.Provenance, error)
+	keychain           func() credential.Source
+	credentialResolver func() *credential.Resolver
+	lookPath           func(file string) (string, error)
+	clock              clock.Clock
+	version            string
+	stat               func(name string) (os.FileInfo, error)
+	suppressions       diagnostics.Suppressions
 }
 
 func defaultDoctorDeps() doctorDeps {
 	return doctorDeps{
 		loadConfig:        loadConfig,
-		allTargets:        allTargets,
+		allTargets:        enabledTargets,
 		registryCachePath: registry.DefaultCachePath,
 		credentialsPath:   defaultCredentialsFilePath,
 		userServicesPath:  defaultUserServicesPath,
-		version:           app.Version,
-		stat:              os.Stat,
+		portAllocations:   func() ([]state.PortAllocation, error) { return newPortStore().List() },
+		loadServices:      func() (map[string]service.Service, error) { return loadServices() },
+		installRecords:    func() ([]state.Provenance, error) { return newProvenanceStore().All() },
+		keychain: func() credential.Source {
+			if keychain := newCredentialKeychainSource(); keychain != nil {
+				return keychain
+			}
+
+			return nil
+		},
+		credentialResolver: func() *credential.Resolver {
+			resolver, _ := newCredentialSources()
+			return resolver
+		},
+		lookPath: exec.LookPath,
+		clock:    wallClock,
+		version:  app.Version,
+		stat:     os.Stat,
 	}
 }
 
 func init() {
-	rootCmd.AddCommand(newDoctorCmd())
+	registerCommand(newDoctorCmd)
 }
 
 func newDoctorCmd() *cobra.Command {
@@ -56,9 +136,31 @@ func newDoctorCmd() *cobra.Command {
 		Long: `doctor prints detected targets, config paths, feature flag state,
 and likely setup problems.
 
+It checks that each target's binary is on PATH and its config parses, that
+the credentials file is readable and private, that the registry cache is
+fresh, and that the runtimes curated services launch (npx, uvx, docker,
+dotnet) are available. It warns when a target config grows past the size
+or project entry limits under "config_limits" in the mcp-wire config, and
+suggests which entries to remove. It warns about installed services whose
+required credentials no source holds, and about credentials still stored
+for services installed nowhere. Every problem comes with a suggested fix. doctor
+exits non-zero when a critical check fails, so scripts can gate on it.
+
 It is read-only: it never writes to target config files or credentials.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runDoctor(cmd.OutOrStdout(), defaultDoctorDeps())
+			suppressions, err := warningSuppressions(cmd)
+			if err != nil {
+				return err
+			}
+
+			deps := defaultDoctorDeps()
+			deps.suppressions = suppressions
+			err = runDoctor(cmd.OutOrStdout(), deps)
+			if err != nil {
+				cmd.SilenceUsage = true
+			}
+
+			return err
 		},
 	}
 }
@@ -69,8 +171,14 @@ func runDoctor(output io.Writer, deps doctorDeps) error {
 	writeDoctorTargets(output, deps)
 	writeDoctorFeatures(output, deps)
 	writeDoctorPaths(output, deps)
+	writeDoctorPorts(output, deps)
+	failed := writeDoctorChecks(output, buildDoctorChecks(deps), deps.suppressions)
 	writeDoctorHints(output, deps)
 
+	if failed > 0 {
+		return fmt.Errorf("%d critical doctor checks failed", failed)
+	}
+
 	return nil
 }
 
@@ -148,6 +256,7 @@ func writeDoctorPaths(output io.Writer, deps doctorDeps) {
 		{label: "mcp-wire config", path: defaultMCPWireConfigPath()},
 		{label: "Credentials file", path: deps.credentialsPath()},
 		{label: "User services dir", path: deps.userServicesPath()},
+		{label: "User services.d dir", path: userServicesDropInPath(deps.userServicesPath())},
 		{label: "Registry cache", path: deps.registryCachePath()},
 	}
 
@@ -166,6 +275,29 @@ func writeDoctorPaths(output io.Writer, deps doctorDeps) {
 	fmt.Fprintln(output)
 }
 
+func writeDoctorPorts(output io.Writer, deps doctorDeps) {
+	if deps.portAllocations == nil {
+		return
+	}
+
+	allocations, err := deps.portAllocations()
+	if err != nil {
+		fmt.Fprintf(output, "Port allocations:\n  (failed to load: %v)\n\n", err)
+		return
+	}
+
+	if len(allocations) == 0 {
+		return
+	}
+
+	fmt.Fprintln(output, "Port allocations:")
+	for _, allocation := range allocations {
+		fmt.Fprintf(output, "  %-5d  %s (%s)\n", allocation.Port, allocation.Service, allocation.Name)
+	}
+
+	fmt.Fprintln(output)
+}
+
 func writeDoctorHints(output io.Writer, deps doctorDeps) {
 	hints := buildDoctorHints(deps)
 	if len(hints) == 0 {
@@ -201,6 +333,301 @@ func buildDoctorHints(deps doctorDeps) []string {
 	return hints
 }
 
+// writeDoctorChecks prints every check with its fix and returns how many
+// critical checks failed. Warnings whose code is suppressed are counted but
+// not printed.
+func writeDoctorChecks(output io.Writer, checks []doctorCheck, suppressions diagnostics.Suppressions) int {
+	if len(checks) == 0 {
+		return 0
+	}
+
+	fmt.Fprintln(output, "Checks:")
+
+	failed := 0
+	suppressed := 0
+	for _, check := range checks {
+		if check.level == doctorCheckFail {
+			failed++
+		}
+
+		if check.level == doctorCheckWarn && suppressions.Suppressed(check.code) {
+			suppressed++
+			continue
+		}
+
+		detail := check.detail
+		if check.level == doctorCheckWarn && check.code != "" {
+			detail = fmt.Sprintf("%s [%s]", detail, check.code)
+		}
+
+		fmt.Fprintf(output, "  %-6s  %s: %s\n", check.level.label(), check.subject, detail)
+		if check.fix != "" {
+			fmt.Fprintf(output, "          Fix: %s\n", check.fix)
+		}
+	}
+
+	if suppressed > 0 {
+		fmt.Fprintf(output, "  (%d suppressed warnings hidden; run mcp-wire warnings to list the codes)\n", suppressed)
+	}
+
+	fmt.Fprintln(output)
+
+	return failed
+}
+
+func buildDoctorChecks(deps doctorDeps) []doctorCheck {
+	var checks []doctorCheck
+
+	checks = append(checks, doctorTargetChecks(deps)...)
+	checks = append(checks, doctorConfigSizeChecks(deps)...)
+	checks = append(checks, doctorCredentialCheck(deps))
+	checks = append(checks, doctorCredentialUsageChecks(deps)...)
+	if check, ok := doctorRegistryCacheCheck(deps); ok {
+		checks = append(checks, check)
+	}
+	checks = append(checks, doctorRuntimeChecks(deps)...)
+
+	return checks
+}
+
+func doctorTargetChecks(deps doctorDeps) []doctorCheck {
+	var checks []doctorCheck
+
+	for _, t := range deps.allTargets() {
+		subject := fmt.Sprintf("%s (%s)", t.Name(), t.Slug())
+
+		if t.IsInstalled() {
+			checks = append(checks, doctorCheck{level: doctorCheckOK, subject: subject, detail: "binary found"})
+		} else {
+			// The install suggestion is already printed under Hints.
+			checks = append(checks, doctorCheck{level: doctorCheckWarn, code: diagnostics.TargetNotFound, subject: subject, detail: "binary not found on PATH"})
+		}
+
+		checker, ok := t.(target.ConfigChecker)
+		if !ok {
+			continue
+		}
+
+		if err := checker.CheckConfig(); err != nil {
+			fix := "Repair the config file or move it aside; mcp-wire will not edit a config it cannot parse."
+			if configPath, ok := targetConfigPath(t); ok && configPath != "" {
+				fix = fmt.Sprintf("Repair %s or move it aside; mcp-wire will not edit a config it cannot parse.", configPath)
+			}
+
+			checks = append(checks, doctorCheck{level: doctorCheckFail, subject: subject, detail: err.Error(), fix: fix})
+			continue
+		}
+
+		checks = append(checks, doctorCheck{level: doctorCheckOK, subject: subject, detail: "config readable"})
+	}
+
+	return checks
+}
+
+// doctorConfigSizeChecks warns about target configs over the limits set
+// under "config_limits". Claude Code adds a project entry for every directory
+// it runs in and never drops one, so the fix suggests which entries can go.
+func doctorConfigSizeChecks(deps doctorDeps) []doctorCheck {
+	cfg, _ := deps.loadConfig()
+	limits := cfg.ConfigLimits()
+
+	var checks []doctorCheck
+	for _, t := range deps.allTargets() {
+		configPath, ok := targetConfigPath(t)
+		if !ok || configPath == "" {
+			continue
+		}
+
+		var problems []string
+		if info, err := deps.stat(configPath); err == nil && !info.IsDir() && info.Size() > int64(limits.MaxSizeMB)<<20 {
+			problems = append(problems, fmt.Sprintf("config is %s, over the %d MB limit", format.Bytes(info.Size()), limits.MaxSizeMB))
+		}
+
+		var entries []string
+		if lister, ok := t.(target.ProjectEntryLister); ok {
+			// A config that does not parse is already reported as failed.
+			entries, _ = lister.ProjectEntries()
+			if len(entries) > limits.MaxProjects {
+				problems = append(problems, fmt.Sprintf("config holds %d project entries, over the limit of %d", len(entries), limits.MaxProjects))
+			}
+		}
+
+		if len(problems) == 0 {
+			continue
+		}
+
+		checks = append(checks, doctorCheck{
+			level:   doctorCheckWarn,
+			code:    diagnostics.ConfigTooLarge,
+			subject: fmt.Sprintf("%s (%s)", t.Name(), t.Slug()),
+			detail:  strings.Join(problems, "; "),
+			fix:     doctorConfigCleanupFix(t.Name(), configPath, entries, deps.stat),
+		})
+	}
+
+	return checks
+}
+
+// doctorConfigCleanupFix suggests what to remove from an oversized config:
+// first the project entries whose directory no longer exists.
+func doctorConfigCleanupFix(targetName string, configPath string, entries []string, stat func(name string) (os.FileInfo, error)) string {
+	const raiseLimit = "To raise the limit, set \"config_limits\" in ~/.config/mcp-wire/config.json."
+
+	var stale []string
+	for _, entry := range entries {
+		if _, err := stat(entry); errors.Is(err, os.ErrNotExist) {
+			stale = append(stale, entry)
+		}
+	}
+
+	switch {
+	case len(stale) > 0:
+		shown := stale
+		if len(shown) > 3 {
+			shown = shown[:3]
+		}
+
+		list := strings.Join(shown, ", ")
+		if more := len(stale) - len(shown); more > 0 {
+			list = fmt.Sprintf("%s and %d more", list, more)
+		}
+
+		return fmt.Sprintf("%d project entries are for directories that no longer exist (%s); remove them from %s while %s is closed. %s",
+			len(stale), list, configPath, targetName, raiseLimit)
+	case len(entries) > 0:
+		return fmt.Sprintf("Remove the project entries you no longer use from %s while %s is closed. %s", configPath, targetName, raiseLimit)
+	default:
+		return fmt.Sprintf("Move settings you no longer need out of %s. %s", configPath, raiseLimit)
+	}
+}
+
+func doctorCredentialCheck(deps doctorDeps) doctorCheck {
+	const subject = "Credentials"
+
+	path := deps.credentialsPath()
+	count, err := credential.NewFileSource(path).Count()
+	if err != nil {
+		return doctorCheck{
+			level:   doctorCheckFail,
+			subject: subject,
+			detail:  err.Error(),
+			fix:     fmt.Sprintf("Make %s a readable file owned by you, or remove it and re-enter credentials on the next install.", path),
+		}
+	}
+
+	if count == 0 {
+		return doctorCheck{level: doctorCheckOK, subject: subject, detail: "no stored credentials; environment variables are used"}
+	}
+
+	if runtime.GOOS != "windows" {
+		if info, err := deps.stat(path); err == nil && info.Mode().Perm()&0o077 != 0 {
+			return doctorCheck{
+				level:   doctorCheckWarn,
+				code:    diagnostics.CredentialsFileMode,
+				subject: subject,
+				detail:  fmt.Sprintf("credentials file is readable by other users (mode %04o)", info.Mode().Perm()),
+				fix:     fmt.Sprintf("Run `chmod 600 %s`.", path),
+			}
+		}
+	}
+
+	return doctorCheck{
+		level:   doctorCheckOK,
+		subject: subject,
+		detail:  fmt.Sprintf("%d stored in the credentials file; environment variables take precedence", count),
+	}
+}
+
+// doctorRegistryCacheCheck reports registry cache freshness. It is skipped
+// when the registry feature is disabled, since the cache is then unused.
+func doctorRegistryCacheCheck(deps doctorDeps) (doctorCheck, bool) {
+	const subject = "Registry cache"
+
+	cfg, err := deps.loadConfig()
+	if err != nil || !cfg.IsFeatureEnabled("registry") {
+		return doctorCheck{}, false
+	}
+
+	refreshFix := "Run `mcp-wire registry refresh`, or `mcp-wire registry refresh --full` to fetch every server again."
+
+	path := deps.registryCachePath()
+	if _, err := deps.stat(path); err != nil {
+		return doctorCheck{level: doctorCheckWarn, code: diagnostics.RegistryCacheStale, subject: subject, detail: "not synced yet", fix: refreshFix}, true
+	}
+
+	cache := registry.NewCacheWithPath(nil, path)
+	if err := cache.Load(); err != nil {
+		return doctorCheck{level: doctorCheckWarn, code: diagnostics.RegistryCacheStale, subject: subject, detail: err.Error(), fix: refreshFix}, true
+	}
+
+	lastSynced := cache.LastSynced()
+	if lastSynced.IsZero() {
+		return doctorCheck{level: doctorCheckWarn, code: diagnostics.RegistryCacheStale, subject: subject, detail: "unreadable or never synced", fix: refreshFix}, true
+	}
+
+	age, ok := clock.Age(deps.clock, lastSynced)
+	if !ok {
+		return doctorCheck{
+			level:   doctorCheckWarn,
+			code:    diagnostics.RegistryCacheStale,
+			subject: subject,
+			detail:  fmt.Sprintf("last synced %s, which is in the future; check the system clock", format.Timestamp(lastSynced)),
+			fix:     refreshFix,
+		}, true
+	}
+
+	if age > max(registryCacheMaxAge, cfg.RegistryCacheTTL()) {
+		return doctorCheck{
+			level:   doctorCheckWarn,
+			code:    diagnostics.RegistryCacheStale,
+			subject: subject,
+			detail:  fmt.Sprintf("last synced %s (%d servers)", format.Ago(deps.clock, lastSynced), cache.Count()),
+			fix:     refreshFix,
+		}, true
+	}
+
+	return doctorCheck{
+		level:   doctorCheckOK,
+		subject: subject,
+		detail:  fmt.Sprintf("synced %s (%d servers)", format.Timestamp(lastSynced), cache.Count()),
+	}, true
+}
+
+// doctorRuntimeChecks reports whether each runtime curated services launch
+// is on PATH. A missing runtime is a warning: only the services that use it
+// are affected.
+func doctorRuntimeChecks(deps doctorDeps) []doctorCheck {
+	usedBy := map[string][]string{}
+	if deps.loadServices != nil {
+		if services, err := deps.loadServices(); err == nil {
+			for name, svc := range services {
+				command := filepath.Base(strings.TrimSpace(svc.Command))
+				usedBy[command] = append(usedBy[command], name)
+			}
+		}
+	}
+
+	checks := make([]doctorCheck, 0, len(doctorRuntimes))
+	for _, rt := range doctorRuntimes {
+		subject := "Runtime " + rt.binary
+
+		if path, err := deps.lookPath(rt.binary); err == nil {
+			checks = append(checks, doctorCheck{level: doctorCheckOK, subject: subject, detail: path})
+			continue
+		}
+
+		detail := "not found on PATH"
+		if services := usedBy[rt.binary]; len(services) > 0 {
+			sort.Strings(services)
+			detail = fmt.Sprintf("not found on PATH; needed by %s", strings.Join(services, ", "))
+		}
+
+		checks = append(checks, doctorCheck{level: doctorCheckWarn, code: diagnostics.RuntimeNotFound, subject: subject, detail: detail, fix: rt.fix})
+	}
+
+	return checks
+}
+
 func targetConfigPath(t target.Target) (string, bool) {
 	provider, ok := t.(target.ConfigPathProvider)
 	if !ok {
@@ -257,3 +684,9 @@ func defaultUserServicesPath() string {
 
 	return filepath.Join(homeDir, ".config", "mcp-wire", "services")
 }
+
+// userServicesDropInPath returns the services.d directory that sits next to
+// the user services directory.
+func userServicesDropInPath(userServicesPath string) string {
+	return filepath.Join(filepath.Dir(userServicesPath), "services.d")
+}
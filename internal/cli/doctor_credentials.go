@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/diagnostics"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// doctorCredentialUsageChecks compares stored credentials with the services
+// installed anywhere: installed services whose required credentials no
+// source holds, and credentials stored for services installed nowhere. Only
+// services mcp-wire knows are checked, since for any other it cannot tell
+// which credentials belong to it.
+func doctorCredentialUsageChecks(deps doctorDeps) []doctorCheck {
+	if deps.loadServices == nil {
+		return nil
+	}
+
+	services, err := deps.loadServices()
+	if err != nil {
+		return nil
+	}
+
+	installed := doctorInstalledServices(deps, services)
+
+	checks := doctorMissingCredentialChecks(deps, services, installed)
+	checks = append(checks, doctorStaleCredentialChecks(deps, services, installed)...)
+
+	return checks
+}
+
+// doctorInstalledServices returns the target slugs each known service is
+// installed on, by its definition name. A service counts as installed when a
+// target config in reach holds it, or when an install record says so, which
+// covers project scopes of other directories.
+func doctorInstalledServices(deps doctorDeps, services map[string]service.Service) map[string][]string {
+	installed := map[string][]string{}
+	add := func(name string, slug string) {
+		svc, err := findServiceDefinitionByName(services, name)
+		if err != nil {
+			return
+		}
+
+		if !containsFold(installed[svc.Name], slug) {
+			installed[svc.Name] = append(installed[svc.Name], slug)
+		}
+	}
+
+	for _, t := range deps.allTargets() {
+		if !t.IsInstalled() {
+			continue
+		}
+
+		// A config that does not parse is already reported as failed.
+		names, _ := tuiListInstalledServices(t, target.ConfigScopeEffective)
+		for _, name := range names {
+			add(name, t.Slug())
+		}
+	}
+
+	if deps.installRecords != nil {
+		records, _ := deps.installRecords()
+		for _, record := range records {
+			add(record.Service, record.Target)
+		}
+	}
+
+	for name := range installed {
+		sort.Strings(installed[name])
+	}
+
+	return installed
+}
+
+// doctorMissingCredentialChecks warns about installed services with a
+// required credential that no source holds. The target configs still carry
+// the value written at install time, but a reinstall or sync has to prompt
+// for it again.
+func doctorMissingCredentialChecks(deps doctorDeps, services map[string]service.Service, installed map[string][]string) []doctorCheck {
+	if deps.credentialResolver == nil {
+		return nil
+	}
+
+	resolver := deps.credentialResolver()
+
+	names := make([]string, 0, len(installed))
+	for name := range installed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var checks []doctorCheck
+	for _, name := range names {
+		var missing []string
+		for _, envVar := range services[name].Env {
+			envName := strings.TrimSpace(envVar.Name)
+			if envName == "" || !envVar.Required {
+				continue
+			}
+
+			if _, _, found := resolver.Resolve(envName); !found {
+				missing = append(missing, envName)
+			}
+		}
+
+		if len(missing) == 0 {
+			continue
+		}
+
+		slugs := installed[name]
+		targetFlags := make([]string, 0, len(slugs))
+		for _, slug := range slugs {
+			targetFlags = append(targetFlags, "--target "+slug)
+		}
+
+		checks = append(checks, doctorCheck{
+			level:   doctorCheckWarn,
+			code:    diagnostics.MissingCredential,
+			subject: "Service " + name,
+			detail:  fmt.Sprintf("installed on %s, but %s is not set in any credential source", strings.Join(slugs, ", "), strings.Join(missing, ", ")),
+			fix:     fmt.Sprintf("Run `mcp-wire install %s %s` to enter it again and save it.", name, strings.Join(targetFlags, " ")),
+		})
+	}
+
+	return checks
+}
+
+// doctorStaleCredentialChecks warns about credentials kept in the keychain or
+// the credentials file for services installed nowhere. A credential another
+// installed service also uses is never reported.
+func doctorStaleCredentialChecks(deps doctorDeps, services map[string]service.Service, installed map[string][]string) []doctorCheck {
+	inUse := map[string]bool{}
+	for name := range installed {
+		for _, envName := range serviceEnvNames(services[name]) {
+			inUse[envName] = true
+		}
+	}
+
+	stores := []credential.Source{credential.NewFileSource(deps.credentialsPath())}
+	if deps.keychain != nil {
+		if keychain := deps.keychain(); keychain != nil {
+			stores = append([]credential.Source{keychain}, stores...)
+		}
+	}
+
+	var names []string
+	for name := range services {
+		if _, ok := installed[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var checks []doctorCheck
+	for _, name := range names {
+		envNames := serviceEnvNames(services[name])
+
+		var stale, shared []string
+		for _, envName := range envNames {
+			if inUse[envName] {
+				shared = append(shared, envName)
+				continue
+			}
+
+			var where []string
+			for _, store := range stores {
+				if _, found := store.Get(envName); found {
+					where = append(where, doctorCredentialStoreLabel(store))
+				}
+			}
+
+			if len(where) > 0 {
+				stale = append(stale, fmt.Sprintf("%s (%s)", envName, strings.Join(where, ", ")))
+			}
+		}
+
+		if len(stale) == 0 {
+			continue
+		}
+
+		fix := fmt.Sprintf("Run `mcp-wire uninstall %s` in a terminal and answer y to remove its stored credentials.", name)
+		if len(shared) > 0 {
+			// Uninstalling would also remove the credentials installed
+			// services still use.
+			fix = fmt.Sprintf("Remove them by hand; `mcp-wire uninstall %s` would also remove %s, which installed services still use.", name, strings.Join(shared, ", "))
+		}
+
+		checks = append(checks, doctorCheck{
+			level:   doctorCheckWarn,
+			code:    diagnostics.StaleCredential,
+			subject: "Service " + name,
+			detail:  fmt.Sprintf("not installed anywhere, but %s still stored", strings.Join(stale, ", ")),
+			fix:     fix,
+		})
+	}
+
+	return checks
+}
+
+func doctorCredentialStoreLabel(store credential.Source) string {
+	if file, ok := store.(*credential.FileSource); ok {
+		return "credentials file " + file.Path()
+	}
+
+	return store.Name()
+}
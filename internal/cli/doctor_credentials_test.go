@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+type listingDoctorTarget struct {
+	fakeDoctorTarget
+	services []string
+}
+
+func (t listingDoctorTarget) List() ([]string, error) { return t.services, nil }
+
+func newCredentialUsageDoctorDeps(t *testing.T, installed ...string) doctorDeps {
+	t.Helper()
+
+	deps := newTestDoctorDeps(t, []target.Target{
+		listingDoctorTarget{fakeDoctorTarget: fakeDoctorTarget{name: "Claude Code", slug: "claude", installed: true}, services: installed},
+	})
+	deps.loadServices = func() (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"jira":   {Name: "jira", Env: []service.EnvVar{{Name: "JIRA_TOKEN", Required: true}, {Name: "ATLASSIAN_SITE"}}},
+			"sentry": {Name: "sentry", Env: []service.EnvVar{{Name: "SENTRY_TOKEN", Required: true}}},
+			"wiki":   {Name: "wiki", Env: []service.EnvVar{{Name: "ATLASSIAN_SITE", Required: true}}},
+		}, nil
+	}
+	deps.credentialResolver = func() *credential.Resolver {
+		return credential.NewResolver(credential.NewFileSource(deps.credentialsPath()))
+	}
+
+	return deps
+}
+
+func TestDoctorWarnsAboutInstalledServiceMissingCredentials(t *testing.T) {
+	deps := newCredentialUsageDoctorDeps(t, "jira")
+	deps.installRecords = func() ([]state.Provenance, error) {
+		return []state.Provenance{{Service: "jira", Target: "codex", Scope: "project"}}, nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected missing credentials to be non-critical: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{
+		"[warn]  Service jira: installed on claude, codex, but JIRA_TOKEN is not set in any credential source [MW206]",
+		"Fix: Run `mcp-wire install jira --target claude --target codex` to enter it again and save it.",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+}
+
+func TestDoctorWarnsAboutCredentialsForServicesInstalledNowhere(t *testing.T) {
+	deps := newCredentialUsageDoctorDeps(t, "jira")
+	content := "JIRA_TOKEN=jira-secret\nATLASSIAN_SITE=example\nSENTRY_TOKEN=sentry-secret\n"
+	if err := os.WriteFile(deps.credentialsPath(), []byte(content), 0o600); err != nil {
+		t.Fatalf("write credentials: %v", err)
+	}
+	deps.keychain = func() credential.Source {
+		return &fakeCredentialSource{name: "keychain", values: map[string]string{"SENTRY_TOKEN": "sentry-secret"}}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected stale credentials to be non-critical: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{
+		"[warn]  Service sentry: not installed anywhere, but SENTRY_TOKEN (keychain, credentials file " + deps.credentialsPath() + ") still stored [MW207]",
+		"Fix: Run `mcp-wire uninstall sentry` in a terminal and answer y to remove its stored credentials.",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+
+	// ATLASSIAN_SITE belongs to wiki too, but installed jira still uses it.
+	if strings.Contains(output, "Service wiki") || strings.Contains(output, "Service jira") {
+		t.Fatalf("expected credentials in use not to be reported, got %q", output)
+	}
+}
@@ -2,14 +2,21 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/andreagrandi/mcp-wire/internal/clock"
 	"github.com/andreagrandi/mcp-wire/internal/config"
+	"github.com/andreagrandi/mcp-wire/internal/diagnostics"
+	"github.com/andreagrandi/mcp-wire/internal/registry"
 	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/state"
 	"github.com/andreagrandi/mcp-wire/internal/target"
 )
 
@@ -28,6 +35,13 @@ func (t fakeDoctorTarget) Uninstall(_ string) error
 func (t fakeDoctorTarget) List() ([]string, error)                              { return nil, nil }
 func (t fakeDoctorTarget) ConfigPath() string                                   { return t.configPath }
 
+type fakeCheckingDoctorTarget struct {
+	fakeDoctorTarget
+	checkErr error
+}
+
+func (t fakeCheckingDoctorTarget) CheckConfig() error { return t.checkErr }
+
 func newTestDoctorDeps(t *testing.T, targets []target.Target) doctorDeps {
 	t.Helper()
 
@@ -42,6 +56,8 @@ func newTestDoctorDeps(t *testing.T, targets []target.Target) doctorDeps {
 		registryCachePath: func() string { return registryCachePath },
 		credentialsPath:   func() string { return credsPath },
 		userServicesPath:  func() string { return servicesDir },
+		lookPath:          func(file string) (string, error) { return filepath.Join("/usr/bin", file), nil },
+		clock:             clock.System,
 		version:           "test-version",
 		stat:              os.Stat,
 	}
@@ -202,6 +218,10 @@ func TestDoctorReportsPathsSection(t *testing.T) {
 		t.Fatalf("expected 'User services dir' label in output, got %q", output)
 	}
 
+	if !strings.Contains(output, "User services.d dir") {
+		t.Fatalf("expected 'User services.d dir' label in output, got %q", output)
+	}
+
 	if !strings.Contains(output, "Registry cache") {
 		t.Fatalf("expected 'Registry cache' label in output, got %q", output)
 	}
@@ -296,3 +316,309 @@ func TestDoctorCommandRegistered(t *testing.T) {
 		t.Fatalf("expected doctor help to mention read-only, got %q", output)
 	}
 }
+
+func TestDoctorReportsPortAllocations(t *testing.T) {
+	deps := newTestDoctorDeps(t, nil)
+	deps.portAllocations = func() ([]state.PortAllocation, error) {
+		return []state.PortAllocation{{Service: "proxy", Name: "HTTP_PORT", Port: 41000}}, nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected doctor to succeed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Port allocations:") || !strings.Contains(output, "41000  proxy (HTTP_PORT)") {
+		t.Fatalf("expected port allocation section, got %q", output)
+	}
+}
+
+func TestDoctorFailsWhenTargetConfigCannotBeParsed(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "claude.json")
+	targets := []target.Target{
+		fakeCheckingDoctorTarget{
+			fakeDoctorTarget: fakeDoctorTarget{name: "Claude Code", slug: "claude", installed: true, configPath: configPath},
+			checkErr:         errors.New("parse config file: unexpected end of JSON input"),
+		},
+		fakeCheckingDoctorTarget{
+			fakeDoctorTarget: fakeDoctorTarget{name: "Codex CLI", slug: "codex", installed: true},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	err := runDoctor(buf, newTestDoctorDeps(t, targets))
+	if err == nil || !strings.Contains(err.Error(), "1 critical doctor checks failed") {
+		t.Fatalf("expected critical failure error, got %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "[fail]  Claude Code (claude): parse config file") {
+		t.Fatalf("expected failed config check, got %q", output)
+	}
+
+	if !strings.Contains(output, "Fix: Repair "+configPath) {
+		t.Fatalf("expected fix naming the config path, got %q", output)
+	}
+
+	if !strings.Contains(output, "[ok]    Codex CLI (codex): config readable") {
+		t.Fatalf("expected readable config check for codex, got %q", output)
+	}
+}
+
+func TestDoctorWarnsAboutMissingRuntimesWithoutFailing(t *testing.T) {
+	deps := newTestDoctorDeps(t, nil)
+	deps.lookPath = func(file string) (string, error) {
+		if file == "uvx" {
+			return "", errors.New("not found")
+		}
+
+		return filepath.Join("/usr/bin", file), nil
+	}
+	deps.loadServices = func() (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"fetch": {Name: "fetch", Transport: "stdio", Command: "uvx"},
+			"jira":  {Name: "jira", Transport: "http", URL: "https://example.com/mcp"},
+		}, nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected missing runtime to be non-critical: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "[warn]  Runtime uvx: not found on PATH; needed by fetch") {
+		t.Fatalf("expected uvx warning, got %q", output)
+	}
+
+	if !strings.Contains(output, "Fix: Install uv") {
+		t.Fatalf("expected uv install fix, got %q", output)
+	}
+
+	if !strings.Contains(output, "[ok]    Runtime npx:") {
+		t.Fatalf("expected npx found, got %q", output)
+	}
+}
+
+func TestDoctorFailsWhenCredentialsFileUnreadable(t *testing.T) {
+	deps := newTestDoctorDeps(t, nil)
+	if err := os.Mkdir(deps.credentialsPath(), 0o700); err != nil {
+		t.Fatalf("failed to create directory in place of credentials file: %v", err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := runDoctor(buf, deps); err == nil {
+		t.Fatal("expected unreadable credentials file to fail doctor")
+	}
+
+	if !strings.Contains(buf.String(), "[fail]  Credentials:") {
+		t.Fatalf("expected credentials failure, got %q", buf.String())
+	}
+}
+
+func TestDoctorWarnsAboutWorldReadableCredentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not enforced on Windows")
+	}
+
+	deps := newTestDoctorDeps(t, nil)
+	if err := os.WriteFile(deps.credentialsPath(), []byte("DEMO_TOKEN=secret\n"), 0o644); err != nil {
+		t.Fatalf("failed to seed credentials file: %v", err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected permissions warning to be non-critical: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "readable by other users") || !strings.Contains(output, "chmod 600") {
+		t.Fatalf("expected permissions warning with chmod fix, got %q", output)
+	}
+
+	if strings.Contains(output, "secret") {
+		t.Fatalf("expected credential values to stay hidden, got %q", output)
+	}
+}
+
+func TestDoctorHidesSuppressedWarnings(t *testing.T) {
+	deps := newTestDoctorDeps(t, nil)
+	deps.lookPath = func(file string) (string, error) {
+		if file == "uvx" || file == "docker" {
+			return "", errors.New("not found")
+		}
+
+		return filepath.Join("/usr/bin", file), nil
+	}
+	deps.suppressions = diagnostics.Suppressions{diagnostics.RuntimeNotFound: true}
+
+	buf := new(bytes.Buffer)
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected doctor to succeed: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "Runtime uvx") || strings.Contains(output, "Runtime docker") {
+		t.Fatalf("expected suppressed runtime warnings to be hidden, got %q", output)
+	}
+
+	if !strings.Contains(output, "(2 suppressed warnings hidden") {
+		t.Fatalf("expected suppressed warning count, got %q", output)
+	}
+
+	if !strings.Contains(output, "[ok]    Runtime npx:") {
+		t.Fatalf("expected other checks to be kept, got %q", output)
+	}
+}
+
+func TestDoctorWarningsShowTheirCode(t *testing.T) {
+	deps := newTestDoctorDeps(t, []target.Target{fakeDoctorTarget{name: "Codex CLI", slug: "codex"}})
+
+	buf := new(bytes.Buffer)
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected doctor to succeed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "binary not found on PATH [MW201]") {
+		t.Fatalf("expected warning code in output, got %q", buf.String())
+	}
+}
+
+func TestDoctorReportsRegistryCacheFreshness(t *testing.T) {
+	deps := newTestDoctorDeps(t, nil)
+
+	cfg, err := deps.loadConfig()
+	if err != nil {
+		t.Fatalf("failed to load test config: %v", err)
+	}
+	if err := cfg.SetFeature("registry", true); err != nil {
+		t.Fatalf("failed to enable registry: %v", err)
+	}
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	deps.clock = clock.Fixed(now)
+
+	buf := new(bytes.Buffer)
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected doctor to succeed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "[warn]  Registry cache: not synced yet") {
+		t.Fatalf("expected unsynced cache warning, got %q", buf.String())
+	}
+
+	store := registry.CacheStore{LastSynced: now.Add(-10 * 24 * time.Hour)}
+	data, err := json.Marshal(store)
+	if err != nil {
+		t.Fatalf("failed to encode cache: %v", err)
+	}
+	if err := os.WriteFile(deps.registryCachePath(), data, 0o600); err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+
+	buf.Reset()
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected stale cache to be non-critical: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "Registry cache: last synced 10 days ago") {
+		t.Fatalf("expected stale cache warning, got %q", buf.String())
+	}
+
+	deps.clock = clock.Fixed(now.Add(-9 * 24 * time.Hour))
+
+	buf.Reset()
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected doctor to succeed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "[ok]    Registry cache: synced") {
+		t.Fatalf("expected fresh cache, got %q", buf.String())
+	}
+
+	deps.clock = clock.Fixed(now.Add(-11 * 24 * time.Hour))
+
+	buf.Reset()
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected doctor to succeed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "which is in the future; check the system clock") {
+		t.Fatalf("expected a future sync time warning, got %q", buf.String())
+	}
+}
+
+type fakeProjectDoctorTarget struct {
+	fakeDoctorTarget
+	projects []string
+}
+
+func (t fakeProjectDoctorTarget) ProjectEntries() ([]string, error) { return t.projects, nil }
+
+func writeDoctorLimits(t *testing.T, deps *doctorDeps, limits string) {
+	t.Helper()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"config_limits":`+limits+`}`), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	deps.loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+}
+
+func TestDoctorWarnsAboutTooManyProjectEntries(t *testing.T) {
+	existing := t.TempDir()
+	gone := filepath.Join(t.TempDir(), "gone")
+	targets := []target.Target{
+		fakeProjectDoctorTarget{
+			fakeDoctorTarget: fakeDoctorTarget{name: "Claude Code", slug: "claude", installed: true, configPath: filepath.Join(t.TempDir(), ".claude.json")},
+			projects:         []string{existing, gone + "-a", gone + "-b"},
+		},
+	}
+	deps := newTestDoctorDeps(t, targets)
+	writeDoctorLimits(t, &deps, `{"max_projects": 2}`)
+
+	buf := new(bytes.Buffer)
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected doctor to succeed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "[warn]  Claude Code (claude): config holds 3 project entries, over the limit of 2 [MW205]") {
+		t.Fatalf("expected project entry warning, got %q", output)
+	}
+
+	if !strings.Contains(output, "2 project entries are for directories that no longer exist ("+gone+"-a, "+gone+"-b)") {
+		t.Fatalf("expected stale entries to be suggested for removal, got %q", output)
+	}
+}
+
+func TestDoctorWarnsAboutOversizedConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(configPath, bytes.Repeat([]byte("#"), 2<<20), 0o600); err != nil {
+		t.Fatalf("write target config: %v", err)
+	}
+
+	deps := newTestDoctorDeps(t, []target.Target{fakeDoctorTarget{name: "Codex CLI", slug: "codex", installed: true, configPath: configPath}})
+	writeDoctorLimits(t, &deps, `{"max_size_mb": 1}`)
+
+	buf := new(bytes.Buffer)
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected doctor to succeed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "config is 2.0 MB, over the 1 MB limit [MW205]") {
+		t.Fatalf("expected size warning, got %q", buf.String())
+	}
+
+	writeDoctorLimits(t, &deps, `{"max_size_mb": 3}`)
+	buf.Reset()
+	if err := runDoctor(buf, deps); err != nil {
+		t.Fatalf("expected doctor to succeed: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "MW205") {
+		t.Fatalf("expected no warning under the limit, got %q", buf.String())
+	}
+}
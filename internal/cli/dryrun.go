@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+var errDryRunUnsupported = errors.New("dry run is not supported by this target")
+
+// configPreview is the content one config file has now and would have after
+// an operation.
+type configPreview struct {
+	path    string
+	existed bool
+	before  string
+	after   string
+}
+
+// diff returns the change as a unified diff, or an empty string when the
+// file would keep its content.
+func (p configPreview) diff() string {
+	if p.existed && p.before == p.after {
+		return ""
+	}
+
+	fromFile := p.path
+	if !p.existed {
+		fromFile = "/dev/null"
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(p.before),
+		B:        diffLines(p.after),
+		FromFile: fromFile,
+		ToFile:   p.path,
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+
+	return diff
+}
+
+// diffLines splits text into lines that keep their newline. Unlike
+// difflib.SplitLines, a final newline does not add an empty line.
+func diffLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// previewTargetChange runs change with the config writes of t captured
+// instead of made, and returns every config file it would have written.
+func previewTargetChange(t target.Target, change func() error) ([]configPreview, error) {
+	previewer, ok := t.(target.ConfigPreviewer)
+	if !ok {
+		return nil, errDryRunUnsupported
+	}
+
+	written := map[string][]byte{}
+	var paths []string
+	previewer.SetConfigPreview(func(configPath string, data []byte) {
+		if _, seen := written[configPath]; !seen {
+			paths = append(paths, configPath)
+		}
+		written[configPath] = data
+	})
+	defer previewer.SetConfigPreview(nil)
+
+	if err := change(); err != nil {
+		return nil, err
+	}
+
+	previews := make([]configPreview, 0, len(paths))
+	for _, configPath := range paths {
+		preview := configPreview{path: configPath, existed: true, after: string(written[configPath])}
+
+		before, err := os.ReadFile(configPath)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			preview.existed = false
+		case err != nil:
+			return nil, fmt.Errorf("read config file %q: %w", configPath, err)
+		default:
+			preview.before = string(before)
+		}
+
+		previews = append(previews, preview)
+	}
+
+	return previews, nil
+}
+
+// dryRunCredentials resolves the credentials of svc without prompting or
+// storing anything. A required credential that is not set yet is written as
+// a <NAME> placeholder, so the diff still shows where it would go.
+func dryRunCredentials(svc service.Service, resolver *credential.Resolver) map[string]string {
+	// Defaults expand the way templates do; see dryRunTemplateLookup.
+	svc, _ = svc.ExpandEnvDefaults(dryRunTemplateLookup(svc, nil, resolver))
+
+	resolvedEnv := map[string]string{}
+	for _, envVar := range svc.Env {
+		envName := strings.TrimSpace(envVar.Name)
+		if envName == "" {
+			continue
+		}
+
+		if value, _, found := resolver.Resolve(envName); found {
+			resolvedEnv[envName] = value
+			continue
+		}
+
+		if defaultValue := strings.TrimSpace(envVar.Default); defaultValue != "" {
+			resolvedEnv[envName] = defaultValue
+			continue
+		}
+
+		if envVar.Required {
+			resolvedEnv[envName] = "<" + envName + ">"
+		}
+	}
+
+	return resolvedEnv
+}
+
+// dryRunInstall returns the change install makes to one target, with svc
+// prepared the way executeInstall prepares it. Ports are not allocated, since
+// that would record them.
+func dryRunInstall(svc service.Service, scope target.ConfigScope) func(target.Target) error {
+	resolver, _ := newCredentialSources()
+	resolvedEnv := dryRunCredentials(svc, resolver)
+
+	// Expanding copies the headers map, which may be shared with the catalog
+	// entry. The dry-run lookup always resolves, so there is no error.
+	svc, _ = svc.ExpandTemplates(dryRunTemplateLookup(svc, resolvedEnv, resolver))
+
+	return func(targetDefinition target.Target) error {
+		scopedTarget, supportsScopes := targetDefinition.(target.ScopedTarget)
+		if supportsScopes && targetSupportsScope(targetDefinition, scope) {
+			return scopedTarget.InstallWithScope(svc, resolvedEnv, scope)
+		}
+
+		return targetDefinition.Install(svc, resolvedEnv)
+	}
+}
+
+// dryRunTemplateLookup resolves placeholders like an install does, and
+// writes a <NAME> placeholder for a name that is not set instead of failing.
+// Port slots are left as written, since ports are not allocated.
+func dryRunTemplateLookup(svc service.Service, resolvedEnv map[string]string, resolver *credential.Resolver) service.TemplateLookup {
+	lookup := serviceTemplateLookup(svc, resolvedEnv, resolver)
+
+	return func(name string) (string, bool) {
+		if value, ok := lookup(name); ok {
+			return value, true
+		}
+
+		if slices.Contains(svc.Ports, name) {
+			return "", false
+		}
+
+		return "<" + name + ">", true
+	}
+}
+
+// dryRunUninstall returns the change uninstall makes to one target.
+func dryRunUninstall(serviceName string, scope target.ConfigScope) func(target.Target) error {
+	return func(targetDefinition target.Target) error {
+		scopedTarget, supportsScopes := targetDefinition.(target.ScopedTarget)
+		if supportsScopes && targetSupportsScope(targetDefinition, scope) {
+			return scopedTarget.UninstallWithScope(serviceName, scope)
+		}
+
+		return targetDefinition.Uninstall(serviceName)
+	}
+}
+
+// previewOperation prints the diff change would apply to the config files of
+// each target, writing nothing. Credential values resolved in this run are
+// masked in the diff.
+func previewOperation(output io.Writer, report *operationReport, action string, serviceName string, targetDefinitions []target.Target, change func(target.Target) error) error {
+	if report != nil {
+		report.DryRun = true
+	}
+
+	fmt.Fprintf(output, "Dry run: showing what %s would change; nothing is written.\n", action)
+
+	previewErrors := make([]error, 0)
+	for _, targetDefinition := range targetDefinitions {
+		outcome := targetOutcome{Service: serviceName, Target: targetDefinition.Slug(), Name: targetDefinition.Name()}
+		fmt.Fprintln(output)
+
+		previews, err := previewTargetChange(targetDefinition, func() error { return change(targetDefinition) })
+		if err != nil {
+			err = credentialRedactor.Wrap(err)
+			fmt.Fprintf(output, "%s: failed (%v)\n", targetDefinition.Name(), err)
+			previewErrors = append(previewErrors, fmt.Errorf("target %q: %w", targetDefinition.Slug(), err))
+			outcome.Status = "failed"
+			outcome.Error = err.Error()
+			report.add(outcome)
+			continue
+		}
+
+		var diff strings.Builder
+		for _, preview := range previews {
+			diff.WriteString(preview.diff())
+		}
+
+		if diff.Len() == 0 {
+			fmt.Fprintf(output, "%s: no changes\n", targetDefinition.Name())
+			outcome.Status = "unchanged"
+			report.add(outcome)
+			continue
+		}
+
+		outcome.Status = "would change"
+		outcome.Diff = credentialRedactor.Redact(diff.String())
+		fmt.Fprintf(output, "%s:\n%s", targetDefinition.Name(), outcome.Diff)
+		report.add(outcome)
+	}
+
+	if len(previewErrors) > 0 {
+		return fmt.Errorf("failed to preview %s of service %q on one or more targets: %w", action, serviceName, errors.Join(previewErrors...))
+	}
+
+	return nil
+}
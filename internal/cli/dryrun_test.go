@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+// overrideDryRunDependencies makes opencode a sandboxed OpenCode target
+// writing to dir, and demo a service whose token resolves from the
+// environment.
+func overrideDryRunDependencies(t *testing.T, dir string, extra ...targetpkg.Target) targetpkg.Target {
+	t.Helper()
+
+	restore := overrideInstallCommandDependencies(t)
+	t.Cleanup(restore)
+
+	opencode := targetpkg.NewOpenCodeTarget().Sandbox(dir).(*targetpkg.OpenCodeTarget)
+	opencode.MarkInstalled()
+	targets := append([]targetpkg.Target{opencode}, extra...)
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo": {
+				Name:      "demo",
+				Transport: "stdio",
+				Command:   "npx",
+				Args:      []string{"-y", "demo-mcp"},
+				Env:       []service.EnvVar{{Name: "DEMO_TOKEN", Required: true}},
+			},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return targets }
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		for _, candidate := range targets {
+			if candidate.Slug() == slug {
+				return candidate, true
+			}
+		}
+
+		return nil, false
+	}
+	newCredentialEnvSource = func() credential.Source {
+		return &testCredentialSource{name: "environment", values: map[string]string{"DEMO_TOKEN": "dry-run-secret"}}
+	}
+	newCredentialFileSource = func(_ string) credential.Source { return &testCredentialSource{name: "file"} }
+	shouldAutoAuthenticate = func(_ *cobra.Command) bool { return false }
+
+	return opencode
+}
+
+func TestInstallDryRunPrintsDiffWithoutWriting(t *testing.T) {
+	dir := t.TempDir()
+	overrideDryRunDependencies(t, dir)
+
+	configPath := filepath.Join(dir, "opencode.json")
+	original := "{\n  \"theme\": \"dark\"\n}\n"
+	if err := os.WriteFile(configPath, []byte(original), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output, err := executeInstallCommand(t, "demo", "--target", "opencode", "--dry-run")
+	if err != nil {
+		t.Fatalf("expected dry run to succeed: %v\n%s", err, output)
+	}
+
+	for _, want := range []string{
+		"nothing is written",
+		"--- " + configPath,
+		"+++ " + configPath,
+		`+      "command": [`,
+		`   "theme": "dark"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+
+	if strings.Contains(output, "dry-run-secret") {
+		t.Fatalf("expected the credential value to be masked, got %q", output)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+
+	if string(data) != original {
+		t.Fatalf("expected dry run to leave the config alone, got %q", data)
+	}
+}
+
+func TestInstallDryRunShowsNewFile(t *testing.T) {
+	dir := t.TempDir()
+	overrideDryRunDependencies(t, dir)
+
+	output, err := executeInstallCommand(t, "demo", "--target", "opencode", "--dry-run")
+	if err != nil {
+		t.Fatalf("expected dry run to succeed: %v\n%s", err, output)
+	}
+
+	if !strings.Contains(output, "--- /dev/null") {
+		t.Fatalf("expected a new file to be diffed against /dev/null, got %q", output)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "opencode.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected dry run not to create the config")
+	}
+}
+
+func TestInstallDryRunFailsForTargetWithoutPreview(t *testing.T) {
+	alpha := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}
+	overrideDryRunDependencies(t, t.TempDir(), alpha)
+
+	output, err := executeInstallCommand(t, "demo", "--target", "alpha-cli", "--dry-run")
+	if err == nil {
+		t.Fatalf("expected dry run to fail, got %q", output)
+	}
+
+	if !strings.Contains(output, "dry run is not supported by this target") {
+		t.Fatalf("expected unsupported target to be reported, got %q", output)
+	}
+
+	if alpha.installCalls != 0 {
+		t.Fatalf("expected no install, got %d", alpha.installCalls)
+	}
+}
+
+func TestInstallDryRunRequiresServiceName(t *testing.T) {
+	overrideDryRunDependencies(t, t.TempDir())
+
+	if _, err := executeInstallCommand(t, "--dry-run"); err == nil || !strings.Contains(err.Error(), "--dry-run requires a service name") {
+		t.Fatalf("expected service name error, got %v", err)
+	}
+}
+
+func TestUninstallDryRunPrintsRemovalWithoutWriting(t *testing.T) {
+	dir := t.TempDir()
+	opencode := overrideDryRunDependencies(t, dir)
+
+	if err := opencode.Install(service.Service{Name: "demo", Transport: "stdio", Command: "npx"}, nil); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+
+	configPath := filepath.Join(dir, "opencode.json")
+	before, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+
+	output, err := executeUninstallCommand(t, "demo", "--target", "opencode", "--dry-run")
+	if err != nil {
+		t.Fatalf("expected dry run to succeed: %v\n%s", err, output)
+	}
+
+	if !strings.Contains(output, `-    "demo": {`) {
+		t.Fatalf("expected the removed entry in the diff, got %q", output)
+	}
+
+	after, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+
+	if string(after) != string(before) {
+		t.Fatalf("expected dry run to leave the config alone, got %q", after)
+	}
+}
+
+func TestUninstallDryRunReportsNoChanges(t *testing.T) {
+	dir := t.TempDir()
+	overrideDryRunDependencies(t, dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "opencode.json"), []byte("{\n  \"mcp\": {}\n}\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output, err := executeUninstallCommand(t, "demo", "--target", "opencode", "--dry-run", "--keep-empty")
+	if err != nil {
+		t.Fatalf("expected dry run to succeed: %v\n%s", err, output)
+	}
+
+	if !strings.Contains(output, "no changes") {
+		t.Fatalf("expected no changes, got %q", output)
+	}
+}
+
+func TestInstallDryRunJSONOutput(t *testing.T) {
+	overrideDryRunDependencies(t, t.TempDir())
+
+	stdout, _, err := executeCommandWithOutputFlags(t, newInstallCmd(), "demo", "--target", "opencode", "--dry-run", "--json")
+	if err != nil {
+		t.Fatalf("expected dry run to succeed: %v", err)
+	}
+
+	var report operationReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", stdout, err)
+	}
+
+	if !report.DryRun || report.Status != "success" || len(report.Targets) != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	outcome := report.Targets[0]
+	if outcome.Status != "would change" || !strings.Contains(outcome.Diff, "+++ ") {
+		t.Fatalf("unexpected outcome %+v", outcome)
+	}
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	registerCommand(newExplainCmd)
+}
+
+func newExplainCmd() *cobra.Command {
+	var targetSlugs []string
+
+	cmd := &cobra.Command{
+		Use:   "explain <service>",
+		Short: "Show every scope layer for a service and which one a target uses",
+		Long: `explain shows the entry each config scope holds for a service, ordered
+from highest to lowest precedence the way the target resolves it, and marks
+the effective one. Use it when a tool picks up a different URL or command
+than the one you just installed.
+
+Credential values are never printed; env vars and headers are listed by
+name only. This command is read-only.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serviceName := strings.TrimSpace(args[0])
+			if serviceName == "" {
+				return errors.New("service name is required")
+			}
+
+			targetDefinitions, err := resolveInstallTargets(targetSlugs)
+			if err != nil {
+				return err
+			}
+
+			if _, err := applyProjectDir(cmd, targetDefinitions); err != nil {
+				return err
+			}
+
+			for i, targetDefinition := range targetDefinitions {
+				if i > 0 {
+					fmt.Fprintln(cmd.OutOrStdout())
+				}
+
+				if err := writeServiceExplanation(cmd.OutOrStdout(), serviceName, targetDefinition); err != nil {
+					return fmt.Errorf("target %q: %w", targetDefinition.Slug(), err)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Explain specific target slug(s) or @group(s); can be repeated")
+	addProjectDirFlags(cmd)
+
+	return cmd
+}
+
+func writeServiceExplanation(output io.Writer, serviceName string, t targetpkg.Target) error {
+	fmt.Fprintf(output, "%s on %s:\n", serviceName, t.Name())
+
+	explainer, ok := t.(targetpkg.Explainer)
+	if !ok {
+		fmt.Fprintln(output, "  scope details are not available for this target")
+		return nil
+	}
+
+	layers, err := explainer.ExplainService(serviceName)
+	if err != nil {
+		return err
+	}
+
+	effective, found := targetpkg.EffectiveLayer(layers)
+
+	scopeWidth := 0
+	for _, layer := range layers {
+		if len(layer.Scope) > scopeWidth {
+			scopeWidth = len(layer.Scope)
+		}
+	}
+
+	for _, layer := range layers {
+		marker := " "
+		value := "not set"
+		if layer.Entry != nil {
+			value = describeConfigEntry(layer.Entry)
+			if found && layer.Scope == effective.Scope {
+				marker = "*"
+			} else {
+				value += " (overridden)"
+			}
+		}
+
+		fmt.Fprintf(output, "  %s %-*s  %s\n", marker, scopeWidth, layer.Scope, value)
+		fmt.Fprintf(output, "    %-*s  from %s\n", scopeWidth, "", layer.Path)
+	}
+
+	if !found {
+		fmt.Fprintln(output, "  Effective: not configured")
+		return nil
+	}
+
+	fmt.Fprintf(output, "  Effective: %s\n", scopeDescription(effective.Scope))
+	return nil
+}
+
+// describeConfigEntry renders an entry as sorted key=value pairs. Nested
+// objects such as env vars and headers are shown by key only, since their
+// values are usually credentials.
+func describeConfigEntry(entry map[string]any) string {
+	keys := make([]string, 0, len(entry))
+	for key := range entry {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+describeConfigValue(entry[key]))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func describeConfigValue(value any) string {
+	switch typed := value.(type) {
+	case map[string]any:
+		names := make([]string, 0, len(typed))
+		for name := range typed {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return "{" + strings.Join(names, ",") + "}"
+	case []any:
+		items := make([]string, 0, len(typed))
+		for _, item := range typed {
+			items = append(items, describeConfigValue(item))
+		}
+
+		return "[" + strings.Join(items, " ") + "]"
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+type fakeExplainTarget struct {
+	fakeListTarget
+	layers []targetpkg.ScopeLayer
+}
+
+func (t fakeExplainTarget) ExplainService(_ string) ([]targetpkg.ScopeLayer, error) {
+	return t.layers, nil
+}
+
+func TestExplainCommandMarksEffectiveLayerAndHidesSecrets(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	explainTarget := fakeExplainTarget{
+		fakeListTarget: fakeListTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true},
+		layers: []targetpkg.ScopeLayer{
+			{Scope: targetpkg.ConfigScopeSystem, Path: "/etc/alpha.json"},
+			{
+				Scope: targetpkg.ConfigScopeProject,
+				Path:  "/home/user/.alpha.json",
+				Entry: map[string]any{"type": "http", "url": "https://project.example.com"},
+			},
+			{
+				Scope: targetpkg.ConfigScopeUser,
+				Path:  "/home/user/.alpha.json",
+				Entry: map[string]any{
+					"command": "npx",
+					"args":    []any{"-y", "jira"},
+					"env":     map[string]any{"JIRA_TOKEN": "super-secret"},
+				},
+			},
+		},
+	}
+
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		return explainTarget, slug == "alpha-cli"
+	}
+
+	output, err := executeExplainCommand(t, "jira", "--target", "alpha-cli")
+	if err != nil {
+		t.Fatalf("expected explain to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "jira on Alpha CLI:") {
+		t.Fatalf("expected heading, got %q", output)
+	}
+
+	if !strings.Contains(output, "system   not set") {
+		t.Fatalf("expected empty system layer, got %q", output)
+	}
+
+	if !strings.Contains(output, "* project  type=http url=https://project.example.com\n") {
+		t.Fatalf("expected project layer to be marked effective, got %q", output)
+	}
+
+	if !strings.Contains(output, "user     args=[-y jira] command=npx env={JIRA_TOKEN} (overridden)") {
+		t.Fatalf("expected overridden user layer with env names only, got %q", output)
+	}
+
+	if strings.Contains(output, "super-secret") {
+		t.Fatalf("expected credential values to be hidden, got %q", output)
+	}
+
+	if !strings.Contains(output, "Effective: project") {
+		t.Fatalf("expected effective scope, got %q", output)
+	}
+}
+
+func TestExplainCommandReportsNotConfigured(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	explainTarget := fakeExplainTarget{
+		fakeListTarget: fakeListTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true},
+		layers:         []targetpkg.ScopeLayer{{Scope: targetpkg.ConfigScopeUser, Path: "/home/user/.alpha.json"}},
+	}
+
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{explainTarget} }
+
+	output, err := executeExplainCommand(t, "jira")
+	if err != nil {
+		t.Fatalf("expected explain to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "Effective: not configured") {
+		t.Fatalf("expected not configured summary, got %q", output)
+	}
+}
+
+func executeExplainCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	explainCmd := newExplainCmd()
+	var stdout, stderr bytes.Buffer
+
+	explainCmd.SetOut(&stdout)
+	explainCmd.SetErr(&stderr)
+	explainCmd.SetArgs(args)
+
+	err := explainCmd.Execute()
+	output := stdout.String() + stderr.String()
+
+	return output, err
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+const (
+	exportFormatMCPJSON = "mcp-json"
+	exportFormatGeneric = "generic"
+)
+
+func init() {
+	registerCommand(newExportCmd)
+}
+
+func newExportCmd() *cobra.Command {
+	var targetSlug string
+	var scopeValue string
+	var format string
+	var includeSecrets bool
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Print a target's configured services as a standard mcp.json",
+		Long: `export converts the services a target has configured into the canonical
+"mcpServers" JSON shape used by Claude Code, Cursor and other MCP clients,
+and prints it to stdout so it can be shared or committed to a repository.
+
+Credential values are replaced with ${NAME} placeholders: env values use the
+env var name, and header values use the header name in upper case. Pass
+--include-secrets to print the values as they are stored.
+
+Formats:
+  mcp-json  {"mcpServers": {"<name>": {...}}} (default)
+  generic   {"servers": [{"name": ..., "transport": ..., ...}]}`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if strings.TrimSpace(targetSlug) == "" {
+				return errors.New("--target is required")
+			}
+
+			targetDefinitions, err := resolveInstallTargets([]string{targetSlug})
+			if err != nil {
+				return err
+			}
+
+			if len(targetDefinitions) != 1 {
+				return errors.New("export reads a single target; pass one slug to --target")
+			}
+
+			targetDefinition := targetDefinitions[0]
+			scope, err := parseExportScope(scopeValue, targetDefinition)
+			if err != nil {
+				return err
+			}
+
+			if _, err := applyProjectDir(cmd, targetDefinitions); err != nil {
+				return err
+			}
+
+			servers, err := targetpkg.ExportServers(targetDefinition, scope)
+			if err != nil {
+				return fmt.Errorf("target %q: %w", targetDefinition.Slug(), err)
+			}
+
+			if !includeSecrets {
+				redactExportedServers(servers)
+			}
+
+			return writeExport(cmd.OutOrStdout(), servers, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&targetSlug, "target", "", "Target slug to export")
+	cmd.Flags().StringVar(&scopeValue, "scope", "", "Scope to export: user, project, system, remote, or effective (default: user)")
+	cmd.Flags().StringVar(&format, "format", exportFormatMCPJSON, "Output format: mcp-json or generic")
+	cmd.Flags().BoolVar(&includeSecrets, "include-secrets", false, "Print credential values instead of ${NAME} placeholders")
+	addProjectDirFlags(cmd)
+
+	return cmd
+}
+
+// parseExportScope accepts the install scopes and "effective", and requires
+// the target to support the scope instead of falling back to the user one.
+func parseExportScope(value string, targetDefinition targetpkg.Target) (targetpkg.ConfigScope, error) {
+	if strings.EqualFold(strings.TrimSpace(value), string(targetpkg.ConfigScopeEffective)) {
+		return targetpkg.ConfigScopeEffective, nil
+	}
+
+	scope, err := parseInstallUninstallScope(value)
+	if err != nil {
+		return "", err
+	}
+
+	if scope != targetpkg.ConfigScopeUser && !targetSupportsScope(targetDefinition, scope) {
+		return "", fmt.Errorf("target %q does not support %s scope", targetDefinition.Slug(), scope)
+	}
+
+	return scope, nil
+}
+
+// redactExportedServers replaces env and header values with placeholders.
+// Header values that already reference a variable are kept.
+func redactExportedServers(servers map[string]map[string]any) {
+	for _, server := range servers {
+		if env, ok := server["env"].(map[string]string); ok {
+			for name := range env {
+				env[name] = "${" + name + "}"
+			}
+		}
+
+		if headers, ok := server["headers"].(map[string]string); ok {
+			for name, value := range headers {
+				if strings.Contains(value, "${") {
+					continue
+				}
+
+				headers[name] = "${" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "}"
+			}
+		}
+	}
+}
+
+// exportedServer is one service in the generic export format.
+type exportedServer struct {
+	Name      string            `json:"name"`
+	Transport string            `json:"transport"`
+	URL       string            `json:"url,omitempty"`
+	Command   string            `json:"command,omitempty"`
+	Args      []string          `json:"args,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+func writeExport(output io.Writer, servers map[string]map[string]any, format string) error {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", exportFormatMCPJSON:
+		return writeJSON(output, map[string]any{"mcpServers": servers})
+	case exportFormatGeneric:
+		names := make([]string, 0, len(servers))
+		for name := range servers {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		generic := make([]exportedServer, 0, len(names))
+		for _, name := range names {
+			generic = append(generic, genericExportedServer(name, servers[name]))
+		}
+
+		return writeJSON(output, map[string]any{"servers": generic})
+	default:
+		return fmt.Errorf("invalid export format %q (supported: %s, %s)", format, exportFormatMCPJSON, exportFormatGeneric)
+	}
+}
+
+func genericExportedServer(name string, server map[string]any) exportedServer {
+	exported := exportedServer{Name: name}
+	exported.Command, _ = server["command"].(string)
+	exported.Args, _ = server["args"].([]string)
+	exported.Env, _ = server["env"].(map[string]string)
+	exported.URL, _ = server["url"].(string)
+	exported.Headers, _ = server["headers"].(map[string]string)
+
+	exported.Transport = "stdio"
+	if exported.Command == "" {
+		exported.Transport, _ = server["type"].(string)
+	}
+
+	return exported
+}
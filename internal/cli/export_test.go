@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+type fakeExportTarget struct {
+	fakeExplainTarget
+	names []string
+}
+
+func (t fakeExportTarget) List() ([]string, error) {
+	return t.names, nil
+}
+
+func newFakeExportTarget() fakeExportTarget {
+	return fakeExportTarget{
+		fakeExplainTarget: fakeExplainTarget{
+			fakeListTarget: fakeListTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true},
+			layers: []targetpkg.ScopeLayer{{
+				Scope: targetpkg.ConfigScopeUser,
+				Path:  "/home/user/.alpha.json",
+				Entry: map[string]any{
+					"command": "npx",
+					"args":    []any{"-y", "jira"},
+					"env":     map[string]any{"JIRA_TOKEN": "super-secret"},
+					"timeout": 30,
+				},
+			}},
+		},
+		names: []string{"jira"},
+	}
+}
+
+func TestExportCommandPrintsMCPJSONWithPlaceholders(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	exportTarget := newFakeExportTarget()
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		return exportTarget, slug == "alpha-cli"
+	}
+
+	output, err := executeExportCommand(t, "--target", "alpha-cli")
+	if err != nil {
+		t.Fatalf("expected export to succeed: %v", err)
+	}
+
+	var decoded struct {
+		MCPServers map[string]map[string]any `json:"mcpServers"`
+	}
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", output, err)
+	}
+
+	jira := decoded.MCPServers["jira"]
+	if jira["command"] != "npx" || jira["env"].(map[string]any)["JIRA_TOKEN"] != "${JIRA_TOKEN}" {
+		t.Fatalf("unexpected jira entry: %#v", jira)
+	}
+
+	if _, ok := jira["timeout"]; ok {
+		t.Fatalf("expected target-specific keys to be dropped, got %#v", jira)
+	}
+
+	if strings.Contains(output, "super-secret") {
+		t.Fatalf("expected credential values to be hidden, got %q", output)
+	}
+}
+
+func TestExportCommandGenericFormatIncludesSecretsOnRequest(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	exportTarget := newFakeExportTarget()
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		return exportTarget, slug == "alpha-cli"
+	}
+
+	output, err := executeExportCommand(t, "--target", "alpha-cli", "--format", "generic", "--include-secrets")
+	if err != nil {
+		t.Fatalf("expected export to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, `"transport": "stdio"`) || !strings.Contains(output, "super-secret") {
+		t.Fatalf("expected generic stdio entry with its secret, got %q", output)
+	}
+}
+
+func TestExportCommandRequiresTarget(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	if _, err := executeExportCommand(t); err == nil || !strings.Contains(err.Error(), "--target is required") {
+		t.Fatalf("expected missing target error, got %v", err)
+	}
+}
+
+func executeExportCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	exportCmd := newExportCmd()
+	var stdout, stderr bytes.Buffer
+
+	exportCmd.SetOut(&stdout)
+	exportCmd.SetErr(&stderr)
+	exportCmd.SetArgs(args)
+
+	err := exportCmd.Execute()
+
+	return stdout.String(), err
+}
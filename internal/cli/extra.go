@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+// parseRawSettings parses --set-raw values of the form key=json into a map
+// of extra keys for the target config block.
+func parseRawSettings(values []string) (map[string]any, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	settings := make(map[string]any, len(values))
+	for _, raw := range values {
+		key, value, found := strings.Cut(raw, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid --set-raw %q: expected key=json", raw)
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+		decoder.UseNumber()
+
+		var decoded any
+		if err := decoder.Decode(&decoded); err != nil {
+			return nil, fmt.Errorf("invalid --set-raw %q: value is not valid JSON: %w", raw, err)
+		}
+
+		if decoder.More() {
+			return nil, fmt.Errorf("invalid --set-raw %q: value must be a single JSON value", raw)
+		}
+
+		settings[key] = normalizeJSONNumbers(decoded)
+	}
+
+	return settings, nil
+}
+
+// normalizeJSONNumbers turns json.Number values into int64 when they are
+// whole numbers and float64 otherwise, so TOML configs get integers for
+// settings such as timeouts.
+func normalizeJSONNumbers(value any) any {
+	switch typed := value.(type) {
+	case json.Number:
+		if i, err := typed.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := typed.Float64()
+		return f
+	case map[string]any:
+		for key, nested := range typed {
+			typed[key] = normalizeJSONNumbers(nested)
+		}
+		return typed
+	case []any:
+		for i, nested := range typed {
+			typed[i] = normalizeJSONNumbers(nested)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// withExtra returns svc with settings merged over its extra keys.
+func withExtra(svc service.Service, settings map[string]any) service.Service {
+	if len(settings) == 0 {
+		return svc
+	}
+
+	extra := make(map[string]any, len(svc.Extra)+len(settings))
+	for key, value := range svc.Extra {
+		extra[key] = value
+	}
+	for key, value := range settings {
+		extra[key] = value
+	}
+
+	svc.Extra = extra
+	return svc
+}
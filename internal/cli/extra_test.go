@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func TestParseRawSettings(t *testing.T) {
+	settings, err := parseRawSettings([]string{
+		`timeout=30000`,
+		`ratio=0.5`,
+		`disabled=true`,
+		`icon="https://example.com/icon.png"`,
+		`tools=["search", {"limit": 5}]`,
+	})
+	if err != nil {
+		t.Fatalf("expected settings to parse: %v", err)
+	}
+
+	want := map[string]any{
+		"timeout":  int64(30000),
+		"ratio":    0.5,
+		"disabled": true,
+		"icon":     "https://example.com/icon.png",
+		"tools":    []any{"search", map[string]any{"limit": int64(5)}},
+	}
+	if !reflect.DeepEqual(settings, want) {
+		t.Fatalf("expected %#v, got %#v", want, settings)
+	}
+}
+
+func TestParseRawSettingsRejectsInvalidValues(t *testing.T) {
+	for _, raw := range []string{"timeout", "=1", "icon=bare-word", "a=1 2"} {
+		if _, err := parseRawSettings([]string{raw}); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
+
+func TestInstallCommandPassesSetRawToTarget(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	installTarget := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha", installed: true}
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo-service": {
+				Name:      "demo-service",
+				Transport: "sse",
+				URL:       "https://example.com/mcp",
+				Extra:     map[string]any{"icon": "demo.png", "timeout": 10},
+			},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{installTarget} }
+
+	_, err := executeInstallCommand(t, "demo-service", "--no-prompt", "--set-raw", "timeout=30000")
+	if err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	want := map[string]any{"icon": "demo.png", "timeout": int64(30000)}
+	if !reflect.DeepEqual(installTarget.lastService.Extra, want) {
+		t.Fatalf("expected extra %#v, got %#v", want, installTarget.lastService.Extra)
+	}
+}
+
+func TestInstallCommandRejectsSetRawWithoutService(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	_, err := executeInstallCommand(t, "--set-raw", "timeout=1")
+	if err == nil || !strings.Contains(err.Error(), "--set-raw requires a service name") {
+		t.Fatalf("expected set-raw error, got %v", err)
+	}
+}
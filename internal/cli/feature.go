@@ -11,6 +11,10 @@ import (
 var loadConfig = config.Load
 
 func init() {
+	registerCommand(newFeatureCmd)
+}
+
+func newFeatureCmd() *cobra.Command {
 	featureCmd := &cobra.Command{
 		Use:   "feature",
 		Short: "Manage feature flags",
@@ -19,7 +23,7 @@ func init() {
 	featureCmd.AddCommand(newFeatureEnableCmd())
 	featureCmd.AddCommand(newFeatureDisableCmd())
 	featureCmd.AddCommand(newFeatureListCmd())
-	rootCmd.AddCommand(featureCmd)
+	return featureCmd
 }
 
 func newFeatureEnableCmd() *cobra.Command {
@@ -27,7 +27,7 @@ func TestFeatureEnableCommand(t *testing.T) {
 	cleanup := withTestConfig(t)
 	defer cleanup()
 
-	cmd := rootCmd
+	cmd := newRootCommand()
 	buf := new(bytes.Buffer)
 	cmd.SetOut(buf)
 	cmd.SetArgs([]string{"feature", "enable", "registry"})
@@ -50,7 +50,7 @@ func TestFeatureDisableCommand(t *testing.T) {
 	cleanup := withTestConfig(t)
 	defer cleanup()
 
-	cmd := rootCmd
+	cmd := newRootCommand()
 	buf := new(bytes.Buffer)
 	cmd.SetOut(buf)
 	cmd.SetArgs([]string{"feature", "disable", "registry"})
@@ -69,7 +69,7 @@ func TestFeatureListCommand(t *testing.T) {
 	cleanup := withTestConfig(t)
 	defer cleanup()
 
-	cmd := rootCmd
+	cmd := newRootCommand()
 	buf := new(bytes.Buffer)
 	cmd.SetOut(buf)
 	cmd.SetArgs([]string{"feature", "list"})
@@ -92,7 +92,7 @@ func TestFeatureEnableUnknownFeature(t *testing.T) {
 	cleanup := withTestConfig(t)
 	defer cleanup()
 
-	cmd := rootCmd
+	cmd := newRootCommand()
 	buf := new(bytes.Buffer)
 	cmd.SetOut(buf)
 	cmd.SetErr(buf)
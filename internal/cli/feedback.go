@@ -0,0 +1,263 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/app"
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+	"github.com/spf13/cobra"
+)
+
+// feedbackIssueURL opens a new issue on the mcp-wire repository; feedback
+// prefills it when no endpoint is configured.
+const feedbackIssueURL = "https://github.com/andreagrandi/mcp-wire/issues/new"
+
+// feedbackTitleLen caps the issue title taken from the first line of the
+// message.
+const feedbackTitleLen = 60
+
+var feedbackHTTPClient = &http.Client{Timeout: 10 * time.Second}
+var openFeedbackURL = openSetupURL
+
+func init() {
+	registerCommand(newFeedbackCmd)
+}
+
+// feedbackReport is the JSON document posted to a feedback endpoint.
+type feedbackReport struct {
+	Message     string               `json:"message"`
+	MCPWire     string               `json:"mcp_wire_version"`
+	SubmittedAt time.Time            `json:"submitted_at"`
+	Environment *feedbackEnvironment `json:"environment,omitempty"`
+}
+
+// feedbackEnvironment describes the machine without identifying it: no
+// paths, user or host names, config contents, or credentials.
+type feedbackEnvironment struct {
+	OS       string   `json:"os"`
+	Arch     string   `json:"arch"`
+	Terminal string   `json:"terminal,omitempty"`
+	CI       bool     `json:"ci"`
+	Targets  []string `json:"targets"`
+	Features []string `json:"features"`
+}
+
+func newFeedbackCmd() *cobra.Command {
+	var includeEnv bool
+
+	cmd := &cobra.Command{
+		Use:   "feedback [message]",
+		Short: "Send feedback to the mcp-wire maintainers",
+		Long: `feedback sends a short message to the maintainers, such as what was
+confusing in the TUI or what you expected a command to do. Without a message
+argument it asks for one; finish it with an empty line.
+
+With "feedback.url" set in ~/.config/mcp-wire/config.json the message is
+posted there as JSON. Otherwise feedback prints a link to a prefilled GitHub
+issue, and opens it in the browser when run in a terminal; nothing is sent
+until you submit the issue.
+
+--include-env attaches the operating system, architecture, terminal type,
+whether it runs in CI, the detected targets, and the enabled feature flags.
+Paths, user and host names, and credentials are never included, and your
+home directory is replaced with ~ in the message.`,
+		Example: `  mcp-wire feedback "The scope picker was hard to find"
+  mcp-wire feedback --include-env`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			message := strings.TrimSpace(strings.Join(args, " "))
+			if message == "" {
+				if !isTerminalReader(cmd.InOrStdin()) {
+					return errors.New("feedback message is required; pass it as an argument")
+				}
+
+				var err error
+				message, err = promptFeedbackMessage(cmd.OutOrStdout(), bufio.NewReader(cmd.InOrStdin()))
+				if err != nil {
+					return err
+				}
+			}
+
+			report := feedbackReport{
+				Message:     redactHomeDir(message),
+				MCPWire:     app.Version,
+				SubmittedAt: clock.Stamp(wallClock),
+			}
+			if includeEnv {
+				report.Environment = collectFeedbackEnvironment(cmd)
+			}
+
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+
+			if endpoint := cfg.Feedback().URL; endpoint != "" {
+				if err := postFeedback(cmd.Context(), endpoint, report); err != nil {
+					return err
+				}
+
+				fmt.Fprintln(cmd.OutOrStdout(), "Feedback sent. Thank you!")
+				return nil
+			}
+
+			issueURL := feedbackIssueLink(report)
+			fmt.Fprintf(cmd.OutOrStdout(), "Open this link to submit your feedback as a GitHub issue:\n\n  %s\n", issueURL)
+			if canUseInteractiveUI(cmd.InOrStdin(), cmd.OutOrStdout()) {
+				_ = openFeedbackURL(issueURL)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&includeEnv, "include-env", false, "Attach redacted environment info: OS, architecture, terminal, detected targets, and feature flags")
+
+	return cmd
+}
+
+// promptFeedbackMessage reads a message of one or more lines, ending at the
+// first empty line or end of input.
+func promptFeedbackMessage(output io.Writer, reader *bufio.Reader) (string, error) {
+	fmt.Fprintln(output, "Your feedback (finish with an empty line):")
+
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(line) == "" && (len(lines) > 0 || err != nil) {
+			break
+		}
+
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	message := strings.TrimSpace(strings.Join(lines, "\n"))
+	if message == "" {
+		return "", errors.New("feedback message is required")
+	}
+
+	return message, nil
+}
+
+func collectFeedbackEnvironment(cmd *cobra.Command) *feedbackEnvironment {
+	environment := &feedbackEnvironment{
+		OS:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+		Terminal: os.Getenv("TERM"),
+		CI:       runningInCI(cmd.InOrStdin()),
+		Targets:  []string{},
+		Features: []string{},
+	}
+
+	for _, targetDefinition := range listInstalledTargets() {
+		environment.Targets = append(environment.Targets, targetDefinition.Slug())
+	}
+
+	if cfg, err := loadConfig(); err == nil {
+		for _, feature := range cfg.Features() {
+			if feature.Enabled {
+				environment.Features = append(environment.Features, feature.Name)
+			}
+		}
+	}
+
+	return environment
+}
+
+// redactHomeDir replaces the home directory in text with ~, so pasted
+// paths and error messages do not reveal the user name.
+func redactHomeDir(text string) string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil || len(homeDir) < 2 {
+		return text
+	}
+
+	return strings.ReplaceAll(text, homeDir, "~")
+}
+
+func postFeedback(ctx context.Context, endpoint string, report feedbackReport) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("encode feedback: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create feedback request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("mcp-wire/%s", app.Version))
+
+	resp, err := feedbackHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send feedback: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("send feedback: endpoint returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// feedbackIssueLink returns a link to a new GitHub issue prefilled with the
+// report.
+func feedbackIssueLink(report feedbackReport) string {
+	title, _, _ := strings.Cut(report.Message, "\n")
+	if runes := []rune(title); len(runes) > feedbackTitleLen {
+		title = string(runes[:feedbackTitleLen-1]) + "…"
+	}
+
+	var body strings.Builder
+	body.WriteString(report.Message)
+	body.WriteString("\n\n---\n")
+	fmt.Fprintf(&body, "mcp-wire %s\n", report.MCPWire)
+	if environment := report.Environment; environment != nil {
+		fmt.Fprintf(&body, "OS: %s/%s\n", environment.OS, environment.Arch)
+		if environment.Terminal != "" {
+			fmt.Fprintf(&body, "Terminal: %s\n", environment.Terminal)
+		}
+		fmt.Fprintf(&body, "CI: %t\n", environment.CI)
+		fmt.Fprintf(&body, "Targets: %s\n", feedbackList(environment.Targets))
+		fmt.Fprintf(&body, "Features: %s\n", feedbackList(environment.Features))
+	}
+
+	query := url.Values{}
+	query.Set("title", "Feedback: "+title)
+	query.Set("body", body.String())
+	query.Set("labels", "feedback")
+
+	return feedbackIssueURL + "?" + query.Encode()
+}
+
+func feedbackList(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+
+	return strings.Join(values, ", ")
+}
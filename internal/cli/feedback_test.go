@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func overrideFeedbackDependencies(t *testing.T, configContent string) {
+	t.Helper()
+
+	originalLoadConfig := loadConfig
+	originalListInstalledTargets := listInstalledTargets
+	originalWallClock := wallClock
+	t.Cleanup(func() {
+		loadConfig = originalLoadConfig
+		listInstalledTargets = originalListInstalledTargets
+		wallClock = originalWallClock
+	})
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := writeTempFile(configPath, configContent); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{newFakeManifestTarget("alpha-cli")} }
+	wallClock = clock.Fixed(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func TestFeedbackPostsToConfiguredEndpoint(t *testing.T) {
+	var received feedbackReport
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+
+		data, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &received); err != nil {
+			t.Errorf("decode feedback: %v", err)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	overrideFeedbackDependencies(t, `{"feedback": {"url": "`+server.URL+`"}, "features": {"registry": true}}`)
+
+	stdout, _, err := executeCommandWithOutputFlags(t, newFeedbackCmd(), "The", "scope", "picker", "--include-env")
+	if err != nil {
+		t.Fatalf("expected feedback to succeed: %v", err)
+	}
+
+	if stdout != "Feedback sent. Thank you!\n" {
+		t.Fatalf("unexpected output %q", stdout)
+	}
+
+	if received.Message != "The scope picker" || !received.SubmittedAt.Equal(wallClock.Now()) {
+		t.Fatalf("unexpected report %+v", received)
+	}
+
+	environment := received.Environment
+	if environment == nil || strings.Join(environment.Targets, ",") != "alpha-cli" || strings.Join(environment.Features, ",") != "registry" {
+		t.Fatalf("unexpected environment %+v", environment)
+	}
+}
+
+func TestFeedbackReportsEndpointFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	overrideFeedbackDependencies(t, `{"feedback": {"url": "`+server.URL+`"}}`)
+
+	_, _, err := executeCommandWithOutputFlags(t, newFeedbackCmd(), "broken")
+	if err == nil || err.Error() != "send feedback: endpoint returned 500 Internal Server Error" {
+		t.Fatalf("expected the endpoint error, got %v", err)
+	}
+}
+
+func TestFeedbackPrintsPrefilledIssueLink(t *testing.T) {
+	overrideFeedbackDependencies(t, `{}`)
+
+	stdout, _, err := executeCommandWithOutputFlags(t, newFeedbackCmd(), "Hard to find the scope picker")
+	if err != nil {
+		t.Fatalf("expected feedback to succeed: %v", err)
+	}
+
+	link := strings.TrimSpace(stdout[strings.Index(stdout, feedbackIssueURL):])
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("parse issue link %q: %v", link, err)
+	}
+
+	query := parsed.Query()
+	if query.Get("title") != "Feedback: Hard to find the scope picker" || query.Get("labels") != "feedback" {
+		t.Fatalf("unexpected issue link %q", link)
+	}
+
+	if body := query.Get("body"); !strings.HasPrefix(body, "Hard to find the scope picker\n\n---\nmcp-wire ") || strings.Contains(body, "Targets:") {
+		t.Fatalf("expected the message without environment info, got %q", body)
+	}
+}
+
+func TestFeedbackRequiresMessageWithoutTerminal(t *testing.T) {
+	overrideFeedbackDependencies(t, `{}`)
+
+	if _, _, err := executeCommandWithOutputFlags(t, newFeedbackCmd()); err == nil || !strings.Contains(err.Error(), "feedback message is required") {
+		t.Fatalf("expected a missing message error, got %v", err)
+	}
+}
+
+func TestPromptFeedbackMessageReadsUntilEmptyLine(t *testing.T) {
+	output := new(strings.Builder)
+	message, err := promptFeedbackMessage(output, bufio.NewReader(strings.NewReader("\nFirst line\nsecond line\n\nignored\n")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if message != "First line\nsecond line" {
+		t.Fatalf("unexpected message %q", message)
+	}
+
+	if _, err := promptFeedbackMessage(output, bufio.NewReader(strings.NewReader(""))); err == nil {
+		t.Fatal("expected an empty message to be rejected")
+	}
+}
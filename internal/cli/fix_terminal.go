@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+)
+
+// terminalResetSequence leaves the alternate screen, shows the cursor,
+// resets text attributes, and turns off mouse reporting and bracketed
+// paste: everything a full-screen program that was killed may leave on.
+const terminalResetSequence = "\x1b[?1049l" + showCursor + "\x1b[0m" +
+	"\x1b[?1000l\x1b[?1002l\x1b[?1003l\x1b[?1006l\x1b[?2004l"
+
+// resetTerminalModes turns echo and line editing back on for the terminal
+// input is connected to.
+var resetTerminalModes = func(input io.Reader) error {
+	stty, err := exec.LookPath("stty")
+	if err != nil {
+		return err
+	}
+
+	command := exec.Command(stty, "sane")
+	command.Stdin = input
+	return command.Run()
+}
+
+func init() {
+	registerCommand(newFixTerminalCmd)
+}
+
+func newFixTerminalCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "fix-terminal",
+		Short: "Restore a terminal left unusable by an interrupted session",
+		Long: `fix-terminal restores a terminal that an interrupted program, such as an
+OAuth login killed halfway, left without echo, without a cursor, or on the
+alternate screen. It resets the terminal modes with "stty sane" where stty
+exists, then leaves the alternate screen and shows the cursor.
+
+If typed text is not shown, type the command blind and press Enter.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			output := cmd.OutOrStdout()
+			if isTerminalReader(cmd.InOrStdin()) {
+				if err := resetTerminalModes(cmd.InOrStdin()); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Could not reset the terminal modes (%v); try running \"reset\".\n", err)
+				}
+			}
+
+			fmt.Fprint(output, terminalResetSequence)
+			fmt.Fprintln(output, "Terminal restored.")
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/diagnostics"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+// runGit runs git in dir and returns its standard output. Tests replace it.
+var runGit = func(dir string, args ...string) (string, error) {
+	output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	return string(output), err
+}
+
+// gitFile is a file inside a git work tree and how git sees it.
+type gitFile struct {
+	root string
+	// rel is the path of the file from root, with forward slashes.
+	rel string
+	// status is the two letter code of git status --porcelain: "??" for
+	// untracked, "!!" for ignored, empty for a tracked file with no changes.
+	status string
+}
+
+// tracked reports whether git already tracks the file or has it staged.
+func (f gitFile) tracked() bool {
+	return f.status != "??" && f.status != "!!"
+}
+
+func (f gitFile) describe() string {
+	switch {
+	case f.status == "??":
+		return "untracked, git would commit it"
+	case f.status == "!!":
+		return "ignored by git"
+	case f.status == "":
+		return "tracked by git, unchanged"
+	case f.status[1] == ' ':
+		return "tracked by git, staged"
+	default:
+		return "tracked by git, modified"
+	}
+}
+
+// gitFileStatus returns how git sees path. ok is false when path is not
+// inside a git work tree or git is not available.
+func gitFileStatus(path string) (gitFile, bool) {
+	dir, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return gitFile{}, false
+	}
+
+	output, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return gitFile{}, false
+	}
+
+	root := strings.TrimSpace(output)
+	rel, err := filepath.Rel(root, filepath.Join(dir, filepath.Base(path)))
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return gitFile{}, false
+	}
+
+	rel = filepath.ToSlash(rel)
+	output, err = runGit(root, "status", "--porcelain", "--ignored", "--", rel)
+	if err != nil {
+		return gitFile{}, false
+	}
+
+	file := gitFile{root: root, rel: rel}
+	if line := strings.TrimRight(output, "\n"); len(line) >= 2 {
+		file.status = line[:2]
+	}
+
+	return file, true
+}
+
+// addGitignoreEntry appends an entry for rel to the .gitignore at the root of
+// the work tree, unless one is already there.
+func addGitignoreEntry(root string, rel string) error {
+	path := filepath.Join(root, ".gitignore")
+	entry := "/" + rel
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line == entry || line == rel {
+			return nil
+		}
+	}
+
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		entry = "\n" + entry
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+
+	if _, err := fmt.Fprintln(file, entry); err != nil {
+		file.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+
+	return file.Close()
+}
+
+// reportProjectFiles prints the git status of every project file a
+// project-scope install wrote. When credentials went into a file git would
+// commit, it warns and, on a terminal, offers to add the file to .gitignore.
+func reportProjectFiles(cmd *cobra.Command, targetDefinitions []target.Target, outcomes []targetOutcome, resolvedEnv map[string]string, suppressions diagnostics.Suppressions) error {
+	output := cmd.OutOrStdout()
+	noPrompt, _ := cmd.Flags().GetBool("no-prompt")
+	interactive := !ciNoPrompt(cmd, noPrompt) && isTerminalReader(cmd.InOrStdin())
+
+	var reader *bufio.Reader
+	seen := map[string]bool{}
+	for i, targetDefinition := range targetDefinitions {
+		provider, ok := targetDefinition.(target.ScopedConfigPathProvider)
+		if !ok || outcomes[i].Status != "configured" {
+			continue
+		}
+
+		path, err := provider.ScopedConfigPath(target.ConfigScopeProject)
+		if err != nil || seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		file, ok := gitFileStatus(path)
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(output, "  %s: %s is %s\n", targetDefinition.Name(), file.rel, file.describe())
+		if len(resolvedEnv) == 0 || file.status == "!!" {
+			continue
+		}
+
+		writeWarning(output, suppressions, diagnostics.New(diagnostics.ProjectFileCredentials,
+			"%s holds the credentials written to it and is not ignored by git.", file.rel))
+		if file.tracked() {
+			fmt.Fprintf(output, "  Run \"git rm --cached %s\" to stop tracking it.\n", file.rel)
+		}
+
+		if !interactive {
+			continue
+		}
+
+		if reader == nil {
+			reader = bufio.NewReader(cmd.InOrStdin())
+		}
+
+		add, err := askYesNo(reader, output, fmt.Sprintf("  Add %s to .gitignore? [y/N]: ", file.rel), false)
+		if err != nil {
+			return err
+		}
+
+		if !add {
+			continue
+		}
+
+		if err := addGitignoreEntry(file.root, file.rel); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(output, "  Added /%s to .gitignore\n", file.rel)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// fakeProjectFileTarget writes its project scope to path.
+type fakeProjectFileTarget struct {
+	*fakeScopedInstallTarget
+	path string
+}
+
+func (t *fakeProjectFileTarget) ScopedConfigPath(scope targetpkg.ConfigScope) (string, error) {
+	return t.path, nil
+}
+
+func (t *fakeProjectFileTarget) InstallWithScope(svc service.Service, resolvedEnv map[string]string, scope targetpkg.ConfigScope) error {
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(t.path, []byte(`{"servers": {}}`), 0o644); err != nil {
+		return err
+	}
+
+	return t.fakeScopedInstallTarget.InstallWithScope(svc, resolvedEnv, scope)
+}
+
+// newGitRepo creates an empty git repository, skipping the test when git is
+// not installed.
+func newGitRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	if output, err := exec.Command("git", "-C", root, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v (%s)", err, output)
+	}
+
+	return root
+}
+
+func TestGitFileStatus(t *testing.T) {
+	root := newGitRepo(t)
+	path := filepath.Join(root, ".vscode", "settings.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatalf("write settings: %v", err)
+	}
+
+	file, ok := gitFileStatus(path)
+	if !ok || file.rel != ".vscode/settings.json" || file.describe() != "untracked, git would commit it" {
+		t.Fatalf("expected an untracked file, got %+v (ok %v)", file, ok)
+	}
+
+	if output, err := exec.Command("git", "-C", root, "add", ".vscode/settings.json").CombinedOutput(); err != nil {
+		t.Fatalf("git add: %v (%s)", err, output)
+	}
+
+	if file, _ := gitFileStatus(path); !file.tracked() || file.describe() != "tracked by git, staged" {
+		t.Fatalf("expected a staged file, got %+v", file)
+	}
+
+	if _, ok := gitFileStatus(filepath.Join(t.TempDir(), "opencode.json")); ok {
+		t.Fatal("expected no status outside a git work tree")
+	}
+}
+
+func TestAddGitignoreEntry(t *testing.T) {
+	root := newGitRepo(t)
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("node_modules"), 0o644); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+
+	for range 2 {
+		if err := addGitignoreEntry(root, "opencode.json"); err != nil {
+			t.Fatalf("expected the entry to be added: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, ".gitignore"))
+	if err != nil || string(data) != "node_modules\n/opencode.json\n" {
+		t.Fatalf("expected one entry appended on its own line, got %q (err %v)", data, err)
+	}
+
+	path := filepath.Join(root, "opencode.json")
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	if file, _ := gitFileStatus(path); file.describe() != "ignored by git" {
+		t.Fatalf("expected the file to be ignored, got %+v", file)
+	}
+}
+
+func setUpProjectFileInstall(t *testing.T) (*fakeProjectFileTarget, string) {
+	t.Helper()
+
+	restore := overrideInstallCommandDependencies(t)
+	t.Cleanup(restore)
+	originalIsTerminalReader := isTerminalReader
+	t.Cleanup(func() { isTerminalReader = originalIsTerminalReader })
+
+	root := newGitRepo(t)
+	projectTarget := &fakeProjectFileTarget{
+		fakeScopedInstallTarget: &fakeScopedInstallTarget{
+			fakeInstallTarget: &fakeInstallTarget{name: "OpenCode", slug: "opencode", installed: true},
+		},
+		path: filepath.Join(root, "opencode.json"),
+	}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo-service": {
+				Name:      "demo-service",
+				Transport: "sse",
+				URL:       "https://example.com/mcp",
+				Env:       []service.EnvVar{{Name: "DEMO_TOKEN", Required: true}},
+			},
+		}, nil
+	}
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		return projectTarget, slug == "opencode"
+	}
+	newCredentialEnvSource = func() credential.Source {
+		return &testCredentialSource{name: "environment", values: map[string]string{"DEMO_TOKEN": "env-token"}}
+	}
+	newCredentialFileSource = func(string) credential.Source { return &testCredentialSource{values: map[string]string{}} }
+
+	return projectTarget, root
+}
+
+func TestInstallCommandOffersGitignoreForProjectFileWithCredentials(t *testing.T) {
+	_, root := setUpProjectFileInstall(t)
+	isTerminalReader = func(_ io.Reader) bool { return true }
+
+	output, err := executeInstallCommandWithInput(t, "y\n", "demo-service", "--target", "opencode", "--scope", "project")
+	if err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "OpenCode: opencode.json is untracked, git would commit it") {
+		t.Fatalf("expected the git status of the project file, got %q", output)
+	}
+
+	if !strings.Contains(output, "[MW104] opencode.json holds the credentials written to it") {
+		t.Fatalf("expected the credentials warning, got %q", output)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, ".gitignore"))
+	if err != nil || string(data) != "/opencode.json\n" {
+		t.Fatalf("expected the file in .gitignore, got %q (err %v)", data, err)
+	}
+}
+
+func TestInstallCommandOnlyWarnsAboutProjectFileWithoutTerminal(t *testing.T) {
+	_, root := setUpProjectFileInstall(t)
+	isTerminalReader = func(_ io.Reader) bool { return false }
+
+	output, err := executeInstallCommand(t, "demo-service", "--target", "opencode", "--scope", "project", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "[MW104]") || strings.Contains(output, "Add opencode.json to .gitignore") {
+		t.Fatalf("expected only the credentials warning, got %q", output)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, ".gitignore")); !os.IsNotExist(err) {
+		t.Fatalf("expected no .gitignore to be written, got %v", err)
+	}
+}
@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/flow"
 	"github.com/andreagrandi/mcp-wire/internal/service"
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/webhook"
 	"github.com/spf13/cobra"
 )
 
@@ -30,8 +32,6 @@ func runInstallWizardWithScope(
 	return runInstallWizardPlain(cmd, reader, targetSlugs, noPrompt, scope, scopeSet)
 }
 
-var errRegistryOnly = errors.New("registry only source")
-
 func pickSourceInteractive(output ioWriter, reader *bufio.Reader) (string, error) {
 	for {
 		fmt.Fprintln(output, "  1) Curated services (recommended)")
@@ -73,75 +73,28 @@ func runInstallWizardPlain(
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	registryEnabled := cfg.IsFeatureEnabled("registry")
-
 	services, err := loadServices()
 	if err != nil {
 		return fmt.Errorf("load services: %w", err)
 	}
 
-	var svc service.Service
-	for {
-		source := "curated"
-		if registryEnabled {
-			fmt.Fprintln(output, "Source:")
-
-			var sourceErr error
-			source, sourceErr = pickSourceInteractive(output, reader)
-			if sourceErr != nil {
-				return sourceErr
-			}
-
-			fmt.Fprintln(output)
-		}
-
-		fmt.Fprintln(output, "Step 1/4: Service")
-
-		var serviceErr error
-		svc, serviceErr = pickServiceInteractive(output, reader, services, registryEnabled, source)
-		if errors.Is(serviceErr, errRegistryOnly) {
-			fmt.Fprintln(output)
-			continue
-		}
-
-		if serviceErr != nil {
-			return serviceErr
-		}
-
-		break
-	}
-
-	fmt.Fprintln(output)
-	fmt.Fprintln(output, "Step 2/4: Targets")
-
-	targetDefinitions, err := resolveTargetsForWizard(output, reader, targetSlugs)
-	if err != nil {
-		return err
-	}
-
-	selectedScope, err := resolveScopeForPlainWizard(output, reader, targetDefinitions, requestedScope, scopeSet, "Install")
-	if err != nil {
-		return err
+	w := &plainWizard{
+		cmd:            cmd,
+		output:         output,
+		reader:         reader,
+		targetSlugs:    targetSlugs,
+		noPrompt:       noPrompt,
+		requestedScope: requestedScope,
+		services:       services,
+		source:         "curated",
+		state: flow.State{
+			Action:          flow.ActionInstall,
+			RegistryEnabled: cfg.IsFeatureEnabled("registry"),
+			ScopeSet:        scopeSet,
+		},
 	}
 
-	confirmed, err := confirmInstallSelection(output, reader, svc, targetDefinitions, noPrompt, selectedScope, nil)
-	if err != nil {
-		return err
-	}
-	if !confirmed {
-		fmt.Fprintln(output, "Install cancelled.")
-		return nil
-	}
-
-	fmt.Fprintln(output)
-	fmt.Fprintln(output, "Step 4/4: Apply")
-
-	if err := executeInstall(cmd, svc, targetDefinitions, noPrompt, selectedScope); err != nil {
-		return err
-	}
-
-	printEquivalentCommand(output, buildEquivalentInstallCommand(svc.Name, targetDefinitions, selectedScope))
-	return nil
+	return w.run()
 }
 
 func runUninstallWizard(cmd *cobra.Command, reader *bufio.Reader, targetSlugs []string) error {
@@ -169,40 +122,305 @@ func runUninstallWizardPlain(
 	fmt.Fprintln(output, "Uninstall Wizard")
 	fmt.Fprintln(output)
 
-	// Step 1: Pick targets first.
-	fmt.Fprintln(output, "Step 1/4: Targets")
+	w := &plainWizard{
+		cmd:            cmd,
+		output:         output,
+		reader:         reader,
+		targetSlugs:    targetSlugs,
+		requestedScope: requestedScope,
+		state: flow.State{
+			Action:   flow.ActionUninstall,
+			ScopeSet: scopeSet,
+		},
+	}
+
+	return w.run()
+}
 
-	targetDefinitions, err := resolveTargetsForWizard(output, reader, targetSlugs)
+// plainWizard is the install and uninstall wizard of plain terminals. It
+// walks the steps in the order the flow controller gives, like the TUI, and
+// keeps the choices made so far.
+type plainWizard struct {
+	cmd            *cobra.Command
+	output         ioWriter
+	reader         *bufio.Reader
+	targetSlugs    []string
+	noPrompt       bool
+	requestedScope targetpkg.ConfigScope
+	services       map[string]service.Service
+
+	state  flow.State
+	source string
+	svc    service.Service
+	scope  targetpkg.ConfigScope
+}
+
+func (w *plainWizard) run() error {
+	_, err := w.walk(flow.Steps(w.state)[0], flow.StepNone)
+	return err
+}
+
+// walk runs the steps from step on until the wizard reaches stop, finishes,
+// or is cancelled, and returns the step it stopped at.
+func (w *plainWizard) walk(step flow.Step, stop flow.Step) (flow.Step, error) {
+	for step != stop && step != flow.StepNone {
+		next, err := w.runStep(step)
+		if err != nil {
+			return flow.StepNone, err
+		}
+
+		step = next
+	}
+
+	return step, nil
+}
+
+// runStep runs one step and returns the step to go to: the next one of the
+// flow, an earlier one to choose again, or StepNone when the wizard is done.
+// The plain wizard asks for missing credentials while applying, so it has no
+// credentials step of its own.
+func (w *plainWizard) runStep(step flow.Step) (flow.Step, error) {
+	switch step {
+	case flow.StepSource:
+		return w.pickSource()
+	case flow.StepService:
+		if w.state.Action == flow.ActionUninstall {
+			return w.pickInstalledService()
+		}
+		return w.pickService()
+	case flow.StepTrust:
+		return w.confirmTrust()
+	case flow.StepTargets:
+		return w.pickTargets()
+	case flow.StepScope:
+		return w.pickScope()
+	case flow.StepReview:
+		return w.review()
+	case flow.StepApply:
+		return w.apply()
+	}
+
+	return flow.Next(w.state, step), nil
+}
+
+func (w *plainWizard) pickSource() (flow.Step, error) {
+	fmt.Fprintln(w.output, "Source:")
+
+	source, err := pickSourceInteractive(w.output, w.reader)
 	if err != nil {
-		return err
+		return flow.StepNone, err
 	}
 
-	// Step 2: Pick from installed services.
-	fmt.Fprintln(output)
-	fmt.Fprintln(output, "Step 2/4: Service")
+	w.source = source
+	fmt.Fprintln(w.output)
+	return flow.Next(w.state, flow.StepSource), nil
+}
 
-	svc, err := pickInstalledServiceInteractive(output, reader, targetDefinitions)
+func (w *plainWizard) pickService() (flow.Step, error) {
+	fmt.Fprintln(w.output, "Step 1/4: Service")
+
+	if !w.state.RegistryEnabled || w.source == "curated" {
+		svc, err := pickServiceInteractive(w.output, w.reader, w.services)
+		if err != nil {
+			return flow.StepNone, err
+		}
+
+		w.svc = svc
+		w.state.Entry = catalog.Entry{Source: catalog.SourceCurated, Name: svc.Name, Curated: &svc}
+		return flow.Next(w.state, flow.StepService), nil
+	}
+
+	entry, err := pickCatalogEntryInteractive(w.output, w.reader, w.source)
 	if err != nil {
-		return err
+		return flow.StepNone, err
+	}
+
+	w.state.Entry = entry
+	if flow.NeedsTrust(entry) {
+		return flow.Next(w.state, flow.StepService), nil
 	}
 
-	selectedScope, err := resolveScopeForPlainWizard(output, reader, targetDefinitions, requestedScope, scopeSet, "Uninstall")
+	return w.resolveService(flow.StepService)
+}
+
+func (w *plainWizard) confirmTrust() (flow.Step, error) {
+	printRegistryTrustSummary(w.output, w.state.Entry)
+
+	confirmed, err := askYesNo(w.reader, w.output, "Proceed with this registry service? [y/N]: ", false)
 	if err != nil {
-		return err
+		return flow.StepNone, fmt.Errorf("read registry confirmation: %w", err)
+	}
+
+	if !confirmed {
+		return flow.Previous(w.state, flow.StepTrust), nil
+	}
+
+	return w.resolveService(flow.StepTrust)
+}
+
+// resolveService turns the chosen catalog entry into the service to install,
+// with the latest registry details. An entry mcp-wire cannot install sends
+// the wizard back to choose another service, or another source when only the
+// registry was searched.
+func (w *plainWizard) resolveService(current flow.Step) (flow.Step, error) {
+	if w.state.Entry.Source == catalog.SourceRegistry {
+		fmt.Fprintln(w.output, "Fetching latest details...")
+		w.state.Entry = refreshRegistryEntry(w.state.Entry)
+	}
+
+	svc, ok := catalogEntryToService(w.state.Entry)
+	if !ok {
+		if w.source == "registry" {
+			fmt.Fprintln(w.output, "This registry service has no supported install method (unsupported transport or package type).")
+			fmt.Fprintln(w.output)
+			return flow.StepSource, nil
+		}
+
+		fmt.Fprintln(w.output, "This registry service has no supported install method. Choose a curated service.")
+		return flow.StepService, nil
 	}
 
-	confirmed, err := confirmUninstallSelection(output, reader, svc, targetDefinitions, selectedScope)
+	w.svc = svc
+	return flow.Next(w.state, current), nil
+}
+
+func (w *plainWizard) pickInstalledService() (flow.Step, error) {
+	fmt.Fprintln(w.output)
+	fmt.Fprintln(w.output, "Step 2/4: Service")
+
+	svc, err := pickInstalledServiceInteractive(w.output, w.reader, w.state.Targets)
 	if err != nil {
-		return err
+		return flow.StepNone, err
+	}
+
+	w.svc = svc
+	return flow.Next(w.state, flow.StepService), nil
+}
+
+func (w *plainWizard) pickTargets() (flow.Step, error) {
+	if w.state.Action == flow.ActionUninstall {
+		fmt.Fprintln(w.output, "Step 1/4: Targets")
+	} else {
+		fmt.Fprintln(w.output)
+		fmt.Fprintln(w.output, "Step 2/4: Targets")
+	}
+
+	targetDefinitions, err := resolveTargetsForWizard(w.output, w.reader, w.targetSlugs)
+	if err != nil {
+		return flow.StepNone, err
+	}
+
+	w.state.Targets = targetDefinitions
+	// Without a scope step, a scope from the command line applies when the
+	// targets have a choice of scopes, and the user scope otherwise.
+	w.scope = targetpkg.ConfigScopeUser
+	if w.state.ScopeSet && flow.OffersScopeChoice(targetDefinitions) {
+		w.scope = w.requestedScope
+	}
+
+	if w.state.Action == flow.ActionUninstall {
+		if _, err := applyProjectDir(w.cmd, targetDefinitions); err != nil {
+			return flow.StepNone, err
+		}
+
+		applyPruneEmpty(targetDefinitions, flagString(w.cmd, "keep-empty") == "true")
+	}
+
+	return flow.Next(w.state, flow.StepTargets), nil
+}
+
+func (w *plainWizard) pickScope() (flow.Step, error) {
+	action := "Install"
+	if w.state.Action == flow.ActionUninstall {
+		action = "Uninstall"
+	}
+
+	scope, err := resolveScopeForPlainWizard(w.output, w.reader, w.state.Targets, w.requestedScope, w.state.ScopeSet, action)
+	if err != nil {
+		return flow.StepNone, err
+	}
+
+	w.scope = scope
+	return flow.Next(w.state, flow.StepScope), nil
+}
+
+// review asks to confirm the operation, and ends the wizard when it is
+// cancelled.
+func (w *plainWizard) review() (flow.Step, error) {
+	targetDefinitions := w.state.Targets
+	if w.state.Action == flow.ActionUninstall {
+		confirmed, err := reviewUntilConfigsSettled(w.output, targetDefinitions, func() (bool, error) {
+			return confirmUninstallSelection(w.output, w.reader, w.svc, targetDefinitions, w.scope)
+		})
+		if err != nil {
+			return flow.StepNone, err
+		}
+		if !confirmed {
+			fmt.Fprintln(w.output, "Uninstall cancelled.")
+			return flow.StepNone, nil
+		}
+
+		return flow.Next(w.state, flow.StepReview), nil
+	}
+
+	location, err := applyProjectDir(w.cmd, targetDefinitions)
+	if err != nil {
+		return flow.StepNone, err
+	}
+
+	proceed, err := confirmProjectScope(w.output, w.reader, !w.noPrompt, targetDefinitions, w.scope, location, false)
+	if err != nil || !proceed {
+		return flow.StepNone, err
+	}
+
+	confirmed, err := reviewUntilConfigsSettled(w.output, targetDefinitions, func() (bool, error) {
+		return confirmInstallSelection(w.output, w.reader, w.svc, targetDefinitions, w.noPrompt, w.scope, nil)
+	})
+	if err != nil {
+		return flow.StepNone, err
 	}
 	if !confirmed {
-		fmt.Fprintln(output, "Uninstall cancelled.")
-		return nil
+		fmt.Fprintln(w.output, "Install cancelled.")
+		return flow.StepNone, nil
+	}
+
+	return flow.Next(w.state, flow.StepReview), nil
+}
+
+func (w *plainWizard) apply() (flow.Step, error) {
+	if w.state.Action == flow.ActionUninstall {
+		return flow.Next(w.state, flow.StepApply), applyUninstallSelection(w.cmd, w.svc, w.state.Targets, w.scope)
+	}
+
+	fmt.Fprintln(w.output)
+	fmt.Fprintln(w.output, "Step 4/4: Apply")
+
+	if err := executeInstall(w.cmd, w.svc, w.state.Targets, w.noPrompt, w.scope); err != nil {
+		return flow.StepNone, err
 	}
 
+	printEquivalentCommand(w.output, buildEquivalentInstallCommand(w.svc.Name, w.state.Targets, w.scope))
+	return flow.Next(w.state, flow.StepApply), nil
+}
+
+// applyUninstallSelection is the apply step of the uninstall wizard: it
+// removes svc from each target in selectedScope once the selection was
+// confirmed.
+func applyUninstallSelection(
+	cmd *cobra.Command,
+	svc service.Service,
+	targetDefinitions []targetpkg.Target,
+	selectedScope targetpkg.ConfigScope,
+) error {
+	output := cmd.OutOrStdout()
+
 	fmt.Fprintln(output)
 	fmt.Fprintln(output, "Step 4/4: Apply")
 
+	if err := checkUninstallPolicy(svc.Name, targetDefinitions, selectedScope); err != nil {
+		return err
+	}
+
 	printUninstallPlan(output, targetDefinitions)
 
 	uninstallErrors := make([]error, 0)
@@ -215,12 +433,15 @@ func runUninstallWizardPlain(
 			err = targetDefinition.Uninstall(svc.Name)
 		}
 
+		emitWebhookEvent(webhook.EventUninstall, svc.Name, targetDefinition, selectedScope, err)
+
 		if err != nil {
 			fmt.Fprintf(output, "  %s: failed (%v)\n", targetDefinition.Name(), err)
 			uninstallErrors = append(uninstallErrors, fmt.Errorf("target %q: %w", targetDefinition.Slug(), err))
 			continue
 		}
 
+		forgetServiceProvenance(svc.Name, targetDefinition, selectedScope)
 		fmt.Fprintf(output, "  %s: removed\n", targetDefinition.Name())
 	}
 
@@ -280,19 +501,22 @@ func pickInstalledServiceInteractive(output ioWriter, reader *bufio.Reader, targ
 			continue
 		}
 
-		selectedName := names[index-1]
+		return installedServiceDefinition(names[index-1]), nil
+	}
+}
 
-		// Try to resolve full service definition for credential cleanup.
-		services, loadErr := loadServices()
-		if loadErr == nil {
-			if svc, findErr := findServiceDefinitionByName(services, selectedName); findErr == nil {
-				return svc, nil
-			}
+// installedServiceDefinition returns the definition of a configured service,
+// so uninstall can offer credential cleanup, or just its name when mcp-wire
+// does not know it.
+func installedServiceDefinition(name string) service.Service {
+	services, err := loadServices()
+	if err == nil {
+		if svc, findErr := findServiceDefinitionByName(services, name); findErr == nil {
+			return svc
 		}
-
-		// Fall back to name-only service.
-		return service.Service{Name: selectedName}, nil
 	}
+
+	return service.Service{Name: name}
 }
 
 func resolveTargetsForWizard(output ioWriter, reader *bufio.Reader, targetSlugs []string) ([]targetpkg.Target, error) {
@@ -314,11 +538,7 @@ func printEquivalentCommand(output ioWriter, command string) {
 	fmt.Fprintln(output)
 }
 
-func pickServiceInteractive(output ioWriter, reader *bufio.Reader, services map[string]service.Service, registryEnabled bool, source string) (service.Service, error) {
-	if registryEnabled && source != "curated" {
-		return pickServiceInteractiveCatalog(output, reader, source)
-	}
-
+func pickServiceInteractive(output ioWriter, reader *bufio.Reader, services map[string]service.Service) (service.Service, error) {
 	if len(services) == 0 {
 		return service.Service{}, errors.New("no service definitions available")
 	}
@@ -368,19 +588,21 @@ func pickServiceInteractive(output ioWriter, reader *bufio.Reader, services map[
 	}
 }
 
-func pickServiceInteractiveCatalog(output ioWriter, reader *bufio.Reader, source string) (service.Service, error) {
+// pickCatalogEntryInteractive lets the user search the catalog of source and
+// pick an entry. Trusting and resolving it are steps of their own.
+func pickCatalogEntryInteractive(output ioWriter, reader *bufio.Reader, source string) (catalog.Entry, error) {
 	showMarkers := source == "all"
 
 	for {
 		cat, err := loadCatalog(source, true)
 		if err != nil {
-			return service.Service{}, err
+			return catalog.Entry{}, err
 		}
 
 		if cat.Count() == 0 {
 			statusLine := registrySyncStatusLine(true)
 			if statusLine == "" {
-				return service.Service{}, errors.New("no service definitions available")
+				return catalog.Entry{}, errors.New("no service definitions available")
 			}
 
 			fmt.Fprintln(output, statusLine)
@@ -394,7 +616,7 @@ func pickServiceInteractiveCatalog(output ioWriter, reader *bufio.Reader, source
 
 		search, err := readTrimmedLine(reader, output, "Search (name/description, Enter=all): ")
 		if err != nil {
-			return service.Service{}, fmt.Errorf("read service search: %w", err)
+			return catalog.Entry{}, fmt.Errorf("read service search: %w", err)
 		}
 
 		matches := cat.Search(search)
@@ -426,7 +648,7 @@ func pickServiceInteractiveCatalog(output ioWriter, reader *bufio.Reader, source
 
 		selection, err := readTrimmedLine(reader, output, "Service number: ")
 		if err != nil {
-			return service.Service{}, fmt.Errorf("read service selection: %w", err)
+			return catalog.Entry{}, fmt.Errorf("read service selection: %w", err)
 		}
 
 		index, err := strconv.Atoi(selection)
@@ -435,38 +657,7 @@ func pickServiceInteractiveCatalog(output ioWriter, reader *bufio.Reader, source
 			continue
 		}
 
-		selected := matches[index-1]
-
-		if selected.Source == catalog.SourceRegistry {
-			printRegistryTrustSummary(output, selected)
-
-			confirmed, confirmErr := askYesNo(reader, output, "Proceed with this registry service? [y/N]: ", false)
-			if confirmErr != nil {
-				return service.Service{}, fmt.Errorf("read registry confirmation: %w", confirmErr)
-			}
-
-			if !confirmed {
-				continue
-			}
-		}
-
-		if selected.Source == catalog.SourceRegistry {
-			fmt.Fprintln(output, "Fetching latest details...")
-			selected = refreshRegistryEntry(selected)
-		}
-
-		svc, ok := catalogEntryToService(selected)
-		if !ok {
-			if source == "registry" {
-				fmt.Fprintln(output, "This registry service has no supported install method (unsupported transport or package type).")
-				return service.Service{}, errRegistryOnly
-			}
-
-			fmt.Fprintln(output, "This registry service has no supported install method. Choose a curated service.")
-			continue
-		}
-
-		return svc, nil
+		return matches[index-1], nil
 	}
 }
 
@@ -487,7 +678,7 @@ func filterServices(services []service.Service, query string) []service.Service
 }
 
 func pickTargetsInteractive(output ioWriter, reader *bufio.Reader) ([]targetpkg.Target, error) {
-	targets := allTargets()
+	targets := enabledTargets()
 	if len(targets) == 0 {
 		return nil, errors.New("no known targets found")
 	}
@@ -610,7 +801,7 @@ func confirmInstallSelection(
 		credentialMode = "existing values only"
 	}
 	fmt.Fprintf(output, "Credentials: %s\n", credentialMode)
-	if anyTargetSupportsProjectScope(targetDefinitions) {
+	if flow.SupportsProjectScope(targetDefinitions) {
 		fmt.Fprintf(output, "Scope (supported targets): %s\n", scopeDescription(scope))
 	}
 
@@ -637,7 +828,7 @@ func confirmUninstallSelection(
 	fmt.Fprintln(output, "Step 3/4: Review")
 	fmt.Fprintf(output, "Service: %s\n", svc.Name)
 	fmt.Fprintf(output, "Targets: %s\n", targetDisplayNames(targetDefinitions))
-	if anyTargetSupportsProjectScope(targetDefinitions) {
+	if flow.SupportsProjectScope(targetDefinitions) {
 		fmt.Fprintf(output, "Scope (supported targets): %s\n", scopeDescription(scope))
 	}
 
@@ -649,6 +840,33 @@ func confirmUninstallSelection(
 	return confirmed, nil
 }
 
+// reviewUntilConfigsSettled runs review until the user confirms with no
+// target config changed on disk while it waited for an answer. A change made
+// by another program is listed and the review shown again, so the user
+// confirms against the files as they are now.
+func reviewUntilConfigsSettled(output ioWriter, targetDefinitions []targetpkg.Target, review func() (bool, error)) (bool, error) {
+	for {
+		changed := watchTargetConfigs(targetDefinitions)
+
+		confirmed, err := review()
+		if err != nil || !confirmed {
+			return confirmed, err
+		}
+
+		paths := changed()
+		if len(paths) == 0 {
+			return true, nil
+		}
+
+		fmt.Fprintln(output)
+		fmt.Fprintln(output, "Another program changed these configs during the review:")
+		for _, path := range paths {
+			fmt.Fprintf(output, "  %s\n", path)
+		}
+		fmt.Fprintln(output, "Review the change again against their current content.")
+	}
+}
+
 func targetDisplayNames(targetDefinitions []targetpkg.Target) string {
 	names := make([]string, 0, len(targetDefinitions))
 	for _, targetDefinition := range targetDefinitions {
@@ -663,8 +881,11 @@ func buildEquivalentInstallCommand(serviceName string, targetDefinitions []targe
 	for _, targetDefinition := range targetDefinitions {
 		command += " --target " + targetDefinition.Slug()
 	}
-	if scope == targetpkg.ConfigScopeProject {
+	switch scope {
+	case targetpkg.ConfigScopeProject:
 		command += " --scope project"
+	case targetpkg.ConfigScopeRemote:
+		command += " --scope remote"
 	}
 
 	return command
@@ -675,8 +896,11 @@ func buildEquivalentUninstallCommand(serviceName string, targetDefinitions []tar
 	for _, targetDefinition := range targetDefinitions {
 		command += " --target " + targetDefinition.Slug()
 	}
-	if scope == targetpkg.ConfigScopeProject {
+	switch scope {
+	case targetpkg.ConfigScopeProject:
 		command += " --scope project"
+	case targetpkg.ConfigScopeRemote:
+		command += " --scope remote"
 	}
 
 	return command
@@ -690,7 +914,7 @@ func resolveScopeForPlainWizard(
 	scopeSet bool,
 	action string,
 ) (targetpkg.ConfigScope, error) {
-	if !anyTargetSupportsProjectScope(targetDefinitions) {
+	if !flow.OffersScopeChoice(targetDefinitions) {
 		return targetpkg.ConfigScopeUser, nil
 	}
 
@@ -698,8 +922,16 @@ func resolveScopeForPlainWizard(
 		return requestedScope, nil
 	}
 
+	remote := flow.SupportsRemoteScope(targetDefinitions)
+	choices := "1=user, 2=project"
+	invalid := "Invalid selection. Choose 1 (user) or 2 (project)."
+	if remote {
+		choices += ", 3=remote"
+		invalid = "Invalid selection. Choose 1 (user), 2 (project) or 3 (remote)."
+	}
+
 	for {
-		prompt := fmt.Sprintf("%s scope for supported targets [1=user, 2=project, Enter=user]: ", action)
+		prompt := fmt.Sprintf("%s scope for supported targets [%s, Enter=user]: ", action, choices)
 		selection, err := readTrimmedLine(reader, output, prompt)
 		if err != nil {
 			return "", fmt.Errorf("read scope selection: %w", err)
@@ -710,8 +942,13 @@ func resolveScopeForPlainWizard(
 			return targetpkg.ConfigScopeUser, nil
 		case "2", "project":
 			return targetpkg.ConfigScopeProject, nil
+		case "3", "remote":
+			if remote {
+				return targetpkg.ConfigScopeRemote, nil
+			}
+			fmt.Fprintln(output, invalid)
 		default:
-			fmt.Fprintln(output, "Invalid selection. Choose 1 (user) or 2 (project).")
+			fmt.Fprintln(output, invalid)
 		}
 	}
 }
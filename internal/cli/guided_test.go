@@ -3,11 +3,14 @@ package cli
 import (
 	"bufio"
 	"bytes"
-	"errors"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/flow"
 	"github.com/andreagrandi/mcp-wire/internal/registry"
 	"github.com/andreagrandi/mcp-wire/internal/service"
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
@@ -133,16 +136,31 @@ func TestPickSourceInteractiveRejectsInvalid(t *testing.T) {
 	}
 }
 
-func TestPickServiceInteractiveCatalogRegistryOnlyReturnsError(t *testing.T) {
+// newServiceStepWizard returns an install wizard that picks its service from
+// source with input, with the registry enabled.
+func newServiceStepWizard(source string, input string) (*plainWizard, *bytes.Buffer) {
+	var output bytes.Buffer
+
+	return &plainWizard{
+		output: &output,
+		reader: bufio.NewReader(strings.NewReader(input)),
+		source: source,
+		state:  flow.State{Action: flow.ActionInstall, RegistryEnabled: true},
+	}, &output
+}
+
+func TestPlainWizardGoesBackToSourceForRegistryOnlyService(t *testing.T) {
 	stubLoadServicesForCatalog(t)
 	stubLoadRegistryCache(t, fakeRegistryServers())
 
-	reader := bufio.NewReader(strings.NewReader("\n1\ny\n"))
-	var output bytes.Buffer
+	w, output := newServiceStepWizard("registry", "\n1\ny\n")
+	step, err := w.walk(flow.StepService, flow.StepSource)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
-	_, err := pickServiceInteractiveCatalog(&output, reader, "registry")
-	if !errors.Is(err, errRegistryOnly) {
-		t.Fatalf("expected errRegistryOnly, got %v", err)
+	if step != flow.StepSource {
+		t.Fatalf("expected the wizard to go back to the source step, got %q", step)
 	}
 
 	if !strings.Contains(output.String(), "Registry Service Information:") {
@@ -154,7 +172,7 @@ func TestPickServiceInteractiveCatalogRegistryOnlyReturnsError(t *testing.T) {
 	}
 }
 
-func TestPickServiceInteractiveCatalogDeclineTrustGoesBack(t *testing.T) {
+func TestPlainWizardDeclineTrustGoesBack(t *testing.T) {
 	stubLoadServicesForCatalog(t)
 	stubLoadRegistryCache(t, []registry.ServerResponse{
 		{
@@ -169,16 +187,14 @@ func TestPickServiceInteractiveCatalogDeclineTrustGoesBack(t *testing.T) {
 	})
 
 	// Search all, select 1 (gamma), decline trust, search all again, select 1, accept trust
-	reader := bufio.NewReader(strings.NewReader("\n1\nn\n\n1\ny\n"))
-	var output bytes.Buffer
-
-	svc, err := pickServiceInteractiveCatalog(&output, reader, "registry")
+	w, output := newServiceStepWizard("registry", "\n1\nn\n\n1\ny\n")
+	step, err := w.walk(flow.StepService, flow.StepTargets)
 	if err != nil {
-		t.Fatalf("expected service to be returned after re-accepting trust, got %v", err)
+		t.Fatalf("expected service to be chosen after re-accepting trust, got %v", err)
 	}
 
-	if svc.Name != "gamma" {
-		t.Fatalf("expected service name %q, got %q", "gamma", svc.Name)
+	if step != flow.StepTargets || w.svc.Name != "gamma" {
+		t.Fatalf("expected gamma before the targets step, got %q at %q", w.svc.Name, step)
 	}
 
 	outputStr := output.String()
@@ -195,7 +211,7 @@ func TestPickServiceInteractiveCatalogDeclineTrustGoesBack(t *testing.T) {
 	}
 }
 
-func TestPickServiceInteractiveCatalogTrustSummaryShowsTransport(t *testing.T) {
+func TestPlainWizardTrustSummaryShowsTransport(t *testing.T) {
 	stubLoadServicesForCatalog(t)
 	stubLoadRegistryCache(t, []registry.ServerResponse{
 		{
@@ -213,10 +229,8 @@ func TestPickServiceInteractiveCatalogTrustSummaryShowsTransport(t *testing.T) {
 		},
 	})
 
-	reader := bufio.NewReader(strings.NewReader("\n1\ny\n"))
-	var output bytes.Buffer
-
-	_, _ = pickServiceInteractiveCatalog(&output, reader, "registry")
+	w, output := newServiceStepWizard("registry", "\n1\ny\n")
+	_, _ = w.walk(flow.StepService, flow.StepTargets)
 	outputStr := output.String()
 
 	if !strings.Contains(outputStr, "Transport: streamable-http") {
@@ -228,22 +242,20 @@ func TestPickServiceInteractiveCatalogTrustSummaryShowsTransport(t *testing.T) {
 	}
 }
 
-func TestPickServiceInteractiveCatalogNoTrustForCurated(t *testing.T) {
+func TestPlainWizardSkipsTrustForCurated(t *testing.T) {
 	stubLoadServicesForCatalog(t)
 	stubLoadRegistryCache(t, fakeRegistryServers())
 
 	// source="all": curated alpha, beta + registry gamma, delta
 	// Select alpha (curated) -> should NOT show trust summary
-	reader := bufio.NewReader(strings.NewReader("alpha\n1\n"))
-	var output bytes.Buffer
-
-	svc, err := pickServiceInteractiveCatalog(&output, reader, "all")
+	w, output := newServiceStepWizard("all", "alpha\n1\n")
+	step, err := w.walk(flow.StepService, flow.StepTargets)
 	if err != nil {
 		t.Fatalf("expected curated service to succeed: %v", err)
 	}
 
-	if svc.Name != "alpha" {
-		t.Fatalf("expected alpha, got %q", svc.Name)
+	if step != flow.StepTargets || w.svc.Name != "alpha" {
+		t.Fatalf("expected alpha before the targets step, got %q at %q", w.svc.Name, step)
 	}
 
 	if strings.Contains(output.String(), "Registry Service Information:") {
@@ -326,7 +338,7 @@ func TestPickServiceInteractiveSupportsSearch(t *testing.T) {
 	reader := bufio.NewReader(strings.NewReader("doc\n1\n"))
 	var output bytes.Buffer
 
-	svc, err := pickServiceInteractive(&output, reader, services, false, "curated")
+	svc, err := pickServiceInteractive(&output, reader, services)
 	if err != nil {
 		t.Fatalf("expected service picker to succeed: %v", err)
 	}
@@ -335,3 +347,107 @@ func TestPickServiceInteractiveSupportsSearch(t *testing.T) {
 		t.Fatalf("expected context7 from search flow, got %q", svc.Name)
 	}
 }
+
+func TestReviewUntilConfigsSettledReviewsAgainAfterExternalChange(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(configPath, []byte(`{}`), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	targets := []targetpkg.Target{
+		&fakeLocatorTarget{fakeListTarget: fakeListTarget{name: "TestTarget", slug: "test"}, configPath: configPath},
+	}
+
+	reviews := 0
+	var output bytes.Buffer
+	confirmed, err := reviewUntilConfigsSettled(&output, targets, func() (bool, error) {
+		reviews++
+		if reviews == 1 {
+			// Another program edits the config while the review waits.
+			if err := os.WriteFile(configPath, []byte(`{"theme":"dark"}`), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+		}
+
+		return true, nil
+	})
+	if err != nil || !confirmed {
+		t.Fatalf("expected confirmation, got %v (%v)", confirmed, err)
+	}
+
+	if reviews != 2 {
+		t.Fatalf("expected the review to be shown again, got %d reviews", reviews)
+	}
+
+	if !strings.Contains(output.String(), "changed these configs during the review:\n  "+configPath) {
+		t.Fatalf("expected the changed config to be listed, got %q", output.String())
+	}
+}
+
+func TestReviewUntilConfigsSettledWatchesProjectConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	projectDir := t.TempDir()
+	projectConfig := filepath.Join(projectDir, "opencode.json")
+	if err := os.WriteFile(projectConfig, []byte(`{}`), 0o600); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	opencode := targetpkg.NewOpenCodeTarget()
+	opencode.SetProjectDir(projectDir)
+
+	reviews := 0
+	var output bytes.Buffer
+	confirmed, err := reviewUntilConfigsSettled(&output, []targetpkg.Target{opencode}, func() (bool, error) {
+		reviews++
+		if reviews == 1 {
+			// A teammate's pull rewrites the project config while the review waits.
+			if err := os.WriteFile(projectConfig, []byte(`{"mcp":{}}`), 0o600); err != nil {
+				t.Fatalf("write project config: %v", err)
+			}
+		}
+
+		return true, nil
+	})
+	if err != nil || !confirmed {
+		t.Fatalf("expected confirmation, got %v (%v)", confirmed, err)
+	}
+
+	if reviews != 2 || !strings.Contains(output.String(), "changed these configs during the review:\n  "+projectConfig) {
+		t.Fatalf("expected the project config change to be caught, got %d reviews and %q", reviews, output.String())
+	}
+}
+
+func TestWatchTargetConfigsCoversVSCodeWorkspaceAndRemoteSettings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	if err := os.MkdirAll(filepath.Join(home, ".vscode-server"), 0o755); err != nil {
+		t.Fatalf("create VS Code Server directory: %v", err)
+	}
+
+	workspaceDir := t.TempDir()
+	vscode := targetpkg.NewVSCodeTarget()
+	vscode.SetProjectDir(workspaceDir)
+
+	changed := watchTargetConfigs([]targetpkg.Target{vscode})
+
+	workspaceSettings := filepath.Join(workspaceDir, ".vscode", "settings.json")
+	remoteSettings := filepath.Join(home, ".vscode-server", "data", "Machine", "settings.json")
+	for _, path := range []string{workspaceSettings, remoteSettings} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create settings directory: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(`{}`), 0o600); err != nil {
+			t.Fatalf("write settings: %v", err)
+		}
+	}
+
+	paths := changed()
+	if len(paths) != 2 || !slices.Contains(paths, workspaceSettings) || !slices.Contains(paths, remoteSettings) {
+		t.Fatalf("expected the workspace and remote settings to be watched, got %v", paths)
+	}
+}
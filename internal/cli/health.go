@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/tui"
+	"github.com/andreagrandi/mcp-wire/internal/webhook"
+)
+
+// buildHealthChecks reports, for every installed target, whether its config
+// can be read and, for each configured service, whether mcp-wire knows the
+// service and can resolve its required credentials.
+func buildHealthChecks() []tui.HealthCheck {
+	services, _ := loadServices()
+	resolver, _ := newCredentialSources()
+
+	var checks []tui.HealthCheck
+	for _, t := range enabledTargets() {
+		if !t.IsInstalled() {
+			continue
+		}
+
+		names, err := tuiListInstalledServices(t, targetpkg.ConfigScopeEffective)
+		if err != nil {
+			checks = append(checks, tui.HealthCheck{
+				Target: t,
+				Level:  tui.HealthFail,
+				Detail: fmt.Sprintf("cannot read config: %v", err),
+			})
+			continue
+		}
+
+		if len(names) == 0 {
+			checks = append(checks, tui.HealthCheck{
+				Target: t,
+				Level:  tui.HealthOK,
+				Detail: "no services configured",
+			})
+			continue
+		}
+
+		sorted := append([]string(nil), names...)
+		sort.Strings(sorted)
+		for _, name := range sorted {
+			checks = append(checks, serviceHealthCheck(services, resolver.Resolve, name, t))
+		}
+	}
+
+	return checks
+}
+
+func serviceHealthCheck(
+	services map[string]service.Service,
+	resolve func(envName string) (string, string, bool),
+	name string,
+	t targetpkg.Target,
+) tui.HealthCheck {
+	check := tui.HealthCheck{Service: name, Target: t, Level: tui.HealthOK, Detail: "configured"}
+
+	svc, err := findServiceDefinitionByName(services, name)
+	if err != nil {
+		check.Level = tui.HealthWarn
+		check.Detail = "not a curated service; mcp-wire cannot verify it"
+		return check
+	}
+
+	check.OAuth = serviceUsesOAuth(svc)
+
+	var missing []string
+	for _, envVar := range svc.Env {
+		envName := strings.TrimSpace(envVar.Name)
+		if envName == "" {
+			continue
+		}
+
+		check.Credentials = append(check.Credentials, envName)
+		if _, _, found := resolve(envName); !found && envVar.Required {
+			missing = append(missing, envName)
+		}
+	}
+
+	if len(missing) > 0 {
+		check.Level = tui.HealthFail
+		check.Detail = "missing credential: " + strings.Join(missing, ", ")
+		// Offer the missing credential first when updating from the dashboard.
+		check.Credentials = append(missing, withoutNames(check.Credentials, missing)...)
+		return check
+	}
+
+	if check.OAuth {
+		check.Detail = "configured (OAuth)"
+	}
+
+	return check
+}
+
+// tuiReinstallService rewrites a service on a target using credentials that
+// can be resolved without prompting.
+func tuiReinstallService(name string, t targetpkg.Target) error {
+	services, err := loadServices()
+	if err != nil {
+		return fmt.Errorf("load services: %w", err)
+	}
+
+	svc, err := findServiceDefinitionByName(services, name)
+	if err != nil {
+		return err
+	}
+
+	resolver, _ := newCredentialSources()
+	env := map[string]string{}
+	var missing []string
+	for _, envVar := range svc.Env {
+		envName := strings.TrimSpace(envVar.Name)
+		if envName == "" {
+			continue
+		}
+
+		if value, _, found := resolver.Resolve(envName); found {
+			env[envName] = value
+			continue
+		}
+
+		if envVar.Required {
+			missing = append(missing, envName)
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.New("missing credential: " + strings.Join(missing, ", "))
+	}
+
+	return tuiInstallTarget(svc, env, t, configuredScope(t, name))
+}
+
+// configuredScope returns the scope a service is configured in on t,
+// preferring user scope when it is present in both.
+func configuredScope(t targetpkg.Target, name string) targetpkg.ConfigScope {
+	if targetSupportsScope(t, targetpkg.ConfigScopeProject) &&
+		!serviceConfiguredOn(t, name, targetpkg.ConfigScopeUser) &&
+		serviceConfiguredOn(t, name, targetpkg.ConfigScopeProject) {
+		return targetpkg.ConfigScopeProject
+	}
+
+	return targetpkg.ConfigScopeUser
+}
+
+func withoutNames(names []string, exclude []string) []string {
+	var kept []string
+	for _, name := range names {
+		excluded := false
+		for _, other := range exclude {
+			if name == other {
+				excluded = true
+				break
+			}
+		}
+
+		if !excluded {
+			kept = append(kept, name)
+		}
+	}
+
+	return kept
+}
+
+func tuiAuthenticateService(name string, t targetpkg.Target, stdin io.Reader, stdout, stderr io.Writer) error {
+	authTarget, ok := t.(targetpkg.AuthTarget)
+	if !ok {
+		return fmt.Errorf("target %q does not support automatic authentication", t.Slug())
+	}
+
+	stdout, stderr = oauthOutputs(stdout, stderr)
+	sig, err := runInForeground(func() error { return authTarget.Authenticate(name, stdin, stdout, stderr) })
+	if sig != nil && err == nil {
+		err = interruptError{signal: sig}
+	}
+	emitWebhookEvent(webhook.EventAuth, name, t, "", err)
+
+	return err
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/tui"
+)
+
+func TestServiceHealthCheckReportsMissingCredential(t *testing.T) {
+	services := map[string]service.Service{
+		"jira": {
+			Name: "jira",
+			Env: []service.EnvVar{
+				{Name: "JIRA_URL", Required: true},
+				{Name: "JIRA_TOKEN", Required: true},
+			},
+		},
+	}
+	resolve := func(envName string) (string, string, bool) {
+		return "value", "env", envName == "JIRA_URL"
+	}
+
+	check := serviceHealthCheck(services, resolve, "jira", &fakeInstallTarget{name: "Claude Code", slug: "claude"})
+
+	if check.Level != tui.HealthFail {
+		t.Fatalf("expected fail level, got %v", check.Level)
+	}
+
+	if check.Detail != "missing credential: JIRA_TOKEN" {
+		t.Fatalf("unexpected detail %q", check.Detail)
+	}
+
+	if strings.Join(check.Credentials, ",") != "JIRA_TOKEN,JIRA_URL" {
+		t.Fatalf("expected missing credential first, got %v", check.Credentials)
+	}
+}
+
+func TestServiceHealthCheckWarnsForUnknownService(t *testing.T) {
+	resolve := func(string) (string, string, bool) { return "", "", false }
+
+	check := serviceHealthCheck(map[string]service.Service{}, resolve, "custom", &fakeInstallTarget{name: "Codex", slug: "codex"})
+
+	if check.Level != tui.HealthWarn {
+		t.Fatalf("expected warn level, got %v", check.Level)
+	}
+}
+
+func TestServiceHealthCheckOKWhenCredentialsResolve(t *testing.T) {
+	services := map[string]service.Service{
+		"sentry": {Name: "sentry", Auth: "oauth"},
+	}
+	resolve := func(string) (string, string, bool) { return "", "", false }
+
+	check := serviceHealthCheck(services, resolve, "sentry", &fakeInstallTarget{name: "Codex", slug: "codex"})
+
+	if check.Level != tui.HealthOK || check.Detail != "configured (OAuth)" {
+		t.Fatalf("expected healthy OAuth check, got %+v", check)
+	}
+}
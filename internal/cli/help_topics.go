@@ -0,0 +1,165 @@
+package cli
+
+import "github.com/spf13/cobra"
+
+// helpTopic is an extended guide that does not belong to one command. Each
+// is shown by "mcp-wire help topics <name>" and written as the
+// mcp-wire-<name>(7) man page.
+type helpTopic struct {
+	name    string
+	summary string
+	body    string
+}
+
+// helpTopics lists the guides in the order "help topics" prints them.
+var helpTopics = []helpTopic{
+	{
+		name:    "credentials",
+		summary: "Where credentials come from and where they are saved",
+		body: `Services that need a token or key declare it as an env var. At install time
+each one is resolved from these sources, and the first match wins:
+
+  1. The process environment.
+  2. The OS keychain (macOS Keychain, Windows Credential Manager, or a
+     Secret Service provider such as GNOME Keyring through secret-tool).
+  3. 1Password and HashiCorp Vault, when set up under "onepassword" and
+     "vault" in ~/.config/mcp-wire/config.json.
+  4. The credentials file, ~/.config/mcp-wire/credentials.
+  5. An interactive prompt, unless --no-prompt is set or mcp-wire runs
+     in CI.
+
+A value typed at the prompt can be saved for next time. It goes to the
+keychain when there is one, otherwise to the credentials file, a plain
+KEY=value list kept with mode 0600. Set "deny_plaintext_credentials": true
+to never write that file. mcp-wire never saves to 1Password or Vault.
+
+Resolved values are written into each target's config, which is therefore
+created with mode 0600 as well. Values are masked in error messages and
+never printed by why, status, explain, or dry runs.
+
+Related commands:
+
+  mcp-wire creds list                lists stored credentials, masked
+  mcp-wire creds set <name>          stores or rotates a credential
+  mcp-wire doctor                    flags missing and orphaned credentials
+  mcp-wire uninstall <service>       offers to remove its stored credentials`,
+	},
+	{
+		name:    "scopes",
+		summary: "User, project, system, and remote config scopes",
+		body: `Targets that support scopes (Claude Code, OpenCode, and VS Code) can keep a
+service in more than one config. Choose one with --scope:
+
+  user      the default; the service is available in every project
+  project   only for the current project; for OpenCode its opencode.json
+  system    machine-wide for every user, through the managed config;
+            needs --allow-system and usually sudo
+  remote    VS Code only: the machine settings of a VS Code Server on a
+            remote machine, dev container, or Codespace
+
+Project scope applies to the current directory by default. --project-root
+picks how the project root is found (cwd, git, or manifest), and
+--project-dir names it directly. Outside anything that looks like a project
+mcp-wire asks before writing, and refuses in non-interactive runs unless
+--force is passed.
+
+When a tool reads several scopes, a higher one hides the entry in a lower
+one. For Claude Code the managed system config wins over project entries,
+which win over user entries. "mcp-wire explain <service>" prints the entry
+in every scope and marks the one the tool uses.
+
+Examples:
+
+  mcp-wire install jira --target claude --scope project
+  mcp-wire explain jira --target claude`,
+	},
+	{
+		name:    "registry-trust",
+		summary: "Installing community servers from the MCP Registry",
+		body: `Curated services ship with mcp-wire and are reviewed before each release.
+Servers from the MCP Registry are published by anyone, so mcp-wire treats
+them as untrusted until you confirm them.
+
+The registry source is off by default; turn it on with
+"mcp-wire feature enable registry". In the TUI a registry server shows a
+review screen with its source, transport, and URL or package, and nothing
+is installed until you confirm it. Press r or w there to open its
+repository or website first.
+
+Prefer servers that publish a remote, or whose packages pin a version:
+mcp-wire pins npm, PyPI, and Docker packages to the version installed, and
+"mcp-wire upgrade" lists newer versions before rewriting anything.
+Organization policy rules can refuse registry servers entirely, for
+example stdio servers that would run code on the machine.
+
+Private registries are listed under "registries" in
+~/.config/mcp-wire/config.json and read in ascending priority. When two
+registries publish the same name, the one read first wins.
+
+Related commands:
+
+  mcp-wire search <query>     search the registry without the feature flag
+  mcp-wire upgrade --dry-run  list pinned packages with newer versions`,
+	},
+	{
+		name:    "targets",
+		summary: "The AI tools mcp-wire configures and their config files",
+		body: `A target is an AI coding tool whose MCP config mcp-wire writes:
+
+  claude     Claude Code (~/.claude.json)
+  codex      Codex CLI ($CODEX_HOME/config.toml or ~/.codex/config.toml)
+  opencode   OpenCode (opencode.json or opencode.jsonc)
+  vscode     VS Code (the mcp.servers block of settings.json)
+
+Inside WSL, vscode-windows configures the VS Code installed on Windows.
+
+Without --target, commands use every target that is detected on this
+machine and not disabled. --target takes a slug or an @group defined under
+"targets.groups" in ~/.config/mcp-wire/config.json, and can be repeated.
+
+Only the MCP entries mcp-wire manages are changed; every other setting in
+a config file is kept, and comments in JSONC files survive. Before writing,
+mcp-wire backs the file up, so "mcp-wire rollback" can restore it.
+
+Related commands:
+
+  mcp-wire targets --verbose           config file of each target
+  mcp-wire targets configure <target>  set up a tool that was not detected
+  mcp-wire targets disable <target>    hide a tool you do not use`,
+	},
+}
+
+func init() {
+	registerCommand(newHelpTopicsCmd)
+}
+
+// newHelpTopicsCmd returns the "topics" help topic, with one help topic per
+// guide under it. None of them runs anything; cobra lists them as
+// additional help topics, and their help is the guide alone, without
+// usage or flags.
+func newHelpTopicsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "topics",
+		Short: "Guides on credentials, scopes, registry trust, and targets",
+		Long: `Guides on subjects that span several commands. Read one with
+"mcp-wire help topics <topic>". The same guides are written as
+mcp-wire-<topic>(7) man pages by "mcp-wire man".`,
+	}
+	cmd.SetHelpTemplate(`{{.Long}}
+
+Topics:
+{{range .Commands}}  {{rpad .Name 16}}{{.Short}}
+{{end}}`)
+
+	for _, topic := range helpTopics {
+		topicCmd := &cobra.Command{
+			Use:   topic.name,
+			Short: topic.summary,
+			Long:  topic.body,
+		}
+		topicCmd.SetHelpTemplate("{{.Long}}\n")
+		cmd.AddCommand(topicCmd)
+	}
+
+	return cmd
+}
@@ -1,9 +1,13 @@
 package cli
 
 import (
+	"github.com/andreagrandi/mcp-wire/internal/clock"
 	"github.com/andreagrandi/mcp-wire/internal/service"
 	"github.com/andreagrandi/mcp-wire/internal/target"
 )
 
 var loadServices = service.LoadServices
 var allTargets = target.AllTargets
+
+// wallClock stamps the reports, recordings, and backups commands write.
+var wallClock = clock.System
@@ -2,11 +2,31 @@ package cli
 
 import (
 	"bytes"
+	"io"
+	"os"
 	"testing"
 
 	"github.com/andreagrandi/mcp-wire/internal/service"
 )
 
+// TestMain points mcp-wire's state directory at a throwaway location so tests
+// that exercise install flows never touch the real user state.
+func TestMain(m *testing.M) {
+	stateHome, err := os.MkdirTemp("", "mcp-wire-cli-state-")
+	if err != nil {
+		panic(err)
+	}
+
+	os.Setenv("XDG_STATE_HOME", stateHome)
+	// Keep tests deterministic when the suite itself runs in CI.
+	lookupEnv = func(string) (string, bool) { return "", false }
+	// Never read or write the keychain of the machine running the tests.
+	newCredentialKeychainSource = func() storedCredentialSource { return nil }
+	code := m.Run()
+	os.RemoveAll(stateHome)
+	os.Exit(code)
+}
+
 type fakeListTarget struct {
 	name      string
 	slug      string
@@ -40,16 +60,21 @@ func (t fakeListTarget) List() ([]string, error) {
 func executeRootCommand(t *testing.T, args ...string) (string, error) {
 	t.Helper()
 
+	return executeRootCommandWithInput(t, nil, args...)
+}
+
+func executeRootCommandWithInput(t *testing.T, input io.Reader, args ...string) (string, error) {
+	t.Helper()
+
 	var stdout, stderr bytes.Buffer
 
-	rootCmd.SetOut(&stdout)
-	rootCmd.SetErr(&stderr)
-	rootCmd.SetArgs(args)
-	t.Cleanup(func() {
-		rootCmd.SetArgs([]string{})
-	})
+	root := newRootCommand()
+	root.SetIn(input)
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	root.SetArgs(args)
 
-	err := rootCmd.Execute()
+	err := root.Execute()
 	output := stdout.String() + stderr.String()
 
 	return output, err
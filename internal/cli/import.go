@@ -0,0 +1,324 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+	"github.com/tidwall/jsonc"
+)
+
+// envPlaceholderPattern matches a credential reference in an imported config:
+// ${NAME}, as Claude Code and Cursor expand it, or ${env:NAME}, as VS Code
+// does.
+var envPlaceholderPattern = regexp.MustCompile(`\$\{(?:env:)?([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+func init() {
+	registerCommand(newImportCmd)
+}
+
+func newImportCmd() *cobra.Command {
+	var targetSlugs []string
+	var serviceNames []string
+	var noPrompt bool
+	var scopeValue string
+	var allowSystem bool
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Install the servers defined in an existing mcp.json or tool config",
+		Long: `import reads the MCP servers defined in an existing config file and installs
+them into the selected targets. It understands the "mcpServers" object of an
+mcp.json, .mcp.json, ~/.claude.json or ~/.cursor/mcp.json, and the "servers"
+object of a VS Code .vscode/mcp.json.
+
+Env and header values written as ${NAME} or ${env:NAME} placeholders are
+looked up with the credential resolver (environment, keychain, credentials
+file) and prompted for when missing, like any other install. Literal values
+are copied as they are.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scope, err := parseInstallUninstallScope(scopeValue)
+			if err != nil {
+				return err
+			}
+
+			servers, err := readImportFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			servers, err = selectImportedServers(servers, serviceNames)
+			if err != nil {
+				return err
+			}
+
+			targetDefinitions, err := resolveInstallTargets(targetSlugs)
+			if err != nil {
+				return err
+			}
+
+			if err := checkSystemScope(scope, allowSystem, targetDefinitions); err != nil {
+				return err
+			}
+
+			if err := checkRemoteScope(scope, targetDefinitions); err != nil {
+				return err
+			}
+
+			if _, err := applyProjectDir(cmd, targetDefinitions); err != nil {
+				return err
+			}
+
+			// The provenance record names the file, wherever why is run from.
+			importPath, err := filepath.Abs(args[0])
+			if err != nil {
+				importPath = args[0]
+			}
+
+			promptDisabled := ciNoPrompt(cmd, noPrompt)
+			// One reader for every prompt, so answers typed ahead for a
+			// later server are not lost in the buffer of an earlier one.
+			input := bufio.NewReader(cmd.InOrStdin())
+			importErrors := make([]error, 0)
+			for i, server := range servers {
+				if i > 0 {
+					fmt.Fprintln(cmd.OutOrStdout())
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Importing %s\n", server.svc.Name)
+				if err := installImportedServer(cmd, input, server, importPath, targetDefinitions, promptDisabled, scope); err != nil {
+					fmt.Fprintf(cmd.OutOrStdout(), "  failed: %v\n", err)
+					importErrors = append(importErrors, fmt.Errorf("service %q: %w", server.svc.Name, err))
+				}
+			}
+
+			if len(importErrors) > 0 {
+				return fmt.Errorf("failed to import %d of %d services: %w", len(importErrors), len(servers), errors.Join(importErrors...))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Install to specific target slug(s) or @group(s); can be repeated")
+	cmd.Flags().StringArrayVar(&serviceNames, "service", nil, "Import only the named server(s); can be repeated")
+	cmd.Flags().BoolVar(&noPrompt, "no-prompt", false, "Fail when required credentials are missing instead of prompting")
+	cmd.Flags().StringVar(&scopeValue, "scope", string(target.ConfigScopeUser), "Config scope for supported targets: user, project, system, or remote")
+	cmd.Flags().BoolVar(&allowSystem, "allow-system", false, "Allow --scope system to change the machine-wide config of every user")
+	addProjectDirFlags(cmd)
+
+	return cmd
+}
+
+// importedServer is a server read from an imported config, as a service
+// definition plus where each env var of the entry gets its value.
+type importedServer struct {
+	svc     service.Service
+	env     map[string]string // env vars set to a literal value
+	envRefs map[string]string // env vars set from a placeholder, to the name it references
+}
+
+// readImportFile parses the servers defined in path. JSON with comments and
+// trailing commas is accepted, as the tools that write these files do.
+func readImportFile(path string) ([]importedServer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var document map[string]any
+	if err := json.Unmarshal(jsonc.ToJSON(data), &document); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	entries, ok := document["mcpServers"].(map[string]any)
+	if !ok {
+		entries, ok = document["servers"].(map[string]any)
+	}
+	if !ok || len(entries) == 0 {
+		return nil, fmt.Errorf("%s defines no MCP servers (expected an \"mcpServers\" or \"servers\" object)", path)
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	servers := make([]importedServer, 0, len(names))
+	for _, name := range names {
+		entry, ok := entries[name].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("server %q in %s is not an object", name, path)
+		}
+
+		server, err := importedServerFromEntry(name, entry)
+		if err != nil {
+			return nil, fmt.Errorf("server %q in %s: %w", name, path, err)
+		}
+
+		servers = append(servers, server)
+	}
+
+	return servers, nil
+}
+
+// importedServerFromEntry converts one "mcpServers" entry. Remote entries
+// without a type are treated as streamable HTTP, as Cursor does.
+func importedServerFromEntry(name string, entry map[string]any) (importedServer, error) {
+	server := importedServer{
+		svc:     service.Service{Name: name, Description: "Imported " + name},
+		env:     map[string]string{},
+		envRefs: map[string]string{},
+	}
+
+	canonical := target.CanonicalEntry(entry)
+	if canonical == nil {
+		return importedServer{}, errors.New("entry has neither a command nor a url")
+	}
+
+	envNames := map[string]bool{}
+	addEnvVar := func(name string, description string) {
+		if envNames[name] {
+			return
+		}
+
+		envNames[name] = true
+		server.svc.Env = append(server.svc.Env, service.EnvVar{Name: name, Description: description, Required: true})
+	}
+
+	if command, ok := canonical["command"].(string); ok {
+		server.svc.Transport = "stdio"
+		server.svc.Command = command
+		server.svc.Args, _ = canonical["args"].([]string)
+
+		env, _ := canonical["env"].(map[string]string)
+		for _, envName := range sortedKeys(env) {
+			value := env[envName]
+			match := envPlaceholderPattern.FindStringSubmatch(value)
+			if match == nil || match[0] != value {
+				server.env[envName] = value
+				continue
+			}
+
+			server.envRefs[envName] = match[1]
+			addEnvVar(match[1], fmt.Sprintf("Value for %s", envName))
+		}
+
+		return server, nil
+	}
+
+	server.svc.Transport, _ = canonical["type"].(string)
+	server.svc.URL, _ = canonical["url"].(string)
+
+	headers, _ := canonical["headers"].(map[string]string)
+	if len(headers) > 0 {
+		server.svc.Headers = make(map[string]string, len(headers))
+	}
+	for _, header := range sortedKeys(headers) {
+		value := headers[header]
+		for _, match := range envPlaceholderPattern.FindAllStringSubmatch(value, -1) {
+			addEnvVar(match[1], fmt.Sprintf("Used in the %s header", header))
+		}
+
+		server.svc.Headers[header] = envPlaceholderPattern.ReplaceAllString(value, "{$1}")
+	}
+
+	return server, nil
+}
+
+func selectImportedServers(servers []importedServer, names []string) ([]importedServer, error) {
+	if len(names) == 0 {
+		return servers, nil
+	}
+
+	selected := make([]importedServer, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, server := range servers {
+			if strings.EqualFold(server.svc.Name, strings.TrimSpace(name)) {
+				selected = append(selected, server)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("server %q is not defined in the imported file", name)
+		}
+	}
+
+	return selected, nil
+}
+
+// installImportedServer resolves the credentials the entry references and
+// installs it. The env written to targets keeps the entry's own var names,
+// so "API_KEY": "${ACME_TOKEN}" is written as API_KEY with the value found
+// for ACME_TOKEN.
+func installImportedServer(cmd *cobra.Command, input *bufio.Reader, server importedServer, importPath string, targetDefinitions []target.Target, noPrompt bool, scope target.ConfigScope) error {
+	svc := server.svc
+	if err := checkPolicy("install", svc, targetDefinitions, scope); err != nil {
+		return err
+	}
+
+	resolver, store := newCredentialSources()
+	credentialSources := map[string]string{}
+	credentials, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{
+		noPrompt: noPrompt,
+		input:    input,
+		output:   cmd.OutOrStdout(),
+		store:    store,
+		sources:  credentialSources,
+	})
+	if err != nil {
+		return err
+	}
+
+	origin := serviceOrigin{command: "import", source: importPath, credentialSources: map[string]string{}}
+	resolvedEnv := make(map[string]string, len(server.env)+len(server.envRefs))
+	for name, value := range server.env {
+		resolvedEnv[name] = value
+		origin.credentialSources[name] = "literal"
+	}
+	for name, ref := range server.envRefs {
+		resolvedEnv[name] = credentials[ref]
+		if source, ok := credentialSources[ref]; ok {
+			origin.credentialSources[name] = source
+		}
+	}
+
+	for header, template := range svc.Headers {
+		svc.Headers[header] = substituteVars(template, credentials)
+	}
+
+	// Targets write svc.Env names from resolvedEnv, so describe the entry's
+	// own env vars rather than the credentials they were looked up by.
+	svc.Env = make([]service.EnvVar, 0, len(resolvedEnv))
+	for _, name := range sortedKeys(resolvedEnv) {
+		svc.Env = append(svc.Env, service.EnvVar{Name: name})
+	}
+
+	return installResolvedService(cmd, svc, targetDefinitions, scope, resolvedEnv, origin)
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+const importFixture = `{
+  // Cursor and Claude Code accept comments and trailing commas.
+  "mcpServers": {
+    "files": {
+      "command": "npx",
+      "args": ["-y", "files-mcp"],
+      "env": {"API_KEY": "${ACME_TOKEN}", "MODE": "readonly"},
+    },
+    "docs": {
+      "url": "https://docs.example.com/mcp",
+      "headers": {"Authorization": "Bearer ${env:DOCS_TOKEN}"}
+    }
+  }
+}`
+
+func TestReadImportFileMapsPlaceholders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mcp.json")
+	if err := writeTempFile(path, importFixture); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	servers, err := readImportFile(path)
+	if err != nil {
+		t.Fatalf("expected import file to parse: %v", err)
+	}
+
+	if len(servers) != 2 || servers[0].svc.Name != "docs" || servers[1].svc.Name != "files" {
+		t.Fatalf("expected docs and files, got %+v", servers)
+	}
+
+	docs := servers[0]
+	if docs.svc.Transport != "http" || docs.svc.Headers["Authorization"] != "Bearer {DOCS_TOKEN}" {
+		t.Fatalf("unexpected docs service: %+v", docs.svc)
+	}
+
+	if len(docs.svc.Env) != 1 || docs.svc.Env[0].Name != "DOCS_TOKEN" || !docs.svc.Env[0].Required {
+		t.Fatalf("expected DOCS_TOKEN credential, got %+v", docs.svc.Env)
+	}
+
+	files := servers[1]
+	if files.svc.Transport != "stdio" || !reflect.DeepEqual(files.svc.Args, []string{"-y", "files-mcp"}) {
+		t.Fatalf("unexpected files service: %+v", files.svc)
+	}
+
+	if files.envRefs["API_KEY"] != "ACME_TOKEN" || files.env["MODE"] != "readonly" {
+		t.Fatalf("unexpected env mapping: refs=%v literals=%v", files.envRefs, files.env)
+	}
+}
+
+func TestReadImportFileRejectsFileWithoutServers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := writeTempFile(path, `{"theme": "dark"}`); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	if _, err := readImportFile(path); err == nil || !strings.Contains(err.Error(), "defines no MCP servers") {
+		t.Fatalf("expected no servers error, got %v", err)
+	}
+}
+
+func TestImportCommandInstallsWithResolvedCredentials(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	alpha := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{alpha} }
+	newCredentialEnvSource = func() credential.Source {
+		return &testCredentialSource{values: map[string]string{"ACME_TOKEN": "secret"}}
+	}
+	newCredentialFileSource = func(_ string) credential.Source { return &testCredentialSource{} }
+
+	path := filepath.Join(t.TempDir(), "mcp.json")
+	if err := writeTempFile(path, importFixture); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	output, err := executeImportCommand(t, path, "--service", "files", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected import to succeed: %v\n%s", err, output)
+	}
+
+	if alpha.installCalls != 1 || alpha.lastService.Name != "files" {
+		t.Fatalf("expected files to be installed once, got %d calls for %q", alpha.installCalls, alpha.lastService.Name)
+	}
+
+	want := map[string]string{"API_KEY": "secret", "MODE": "readonly"}
+	if !reflect.DeepEqual(alpha.lastEnv, want) {
+		t.Fatalf("expected env %v, got %v", want, alpha.lastEnv)
+	}
+}
+
+func TestImportCommandFailsOnMissingCredentialWithoutPrompt(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	alpha := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{alpha} }
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{} }
+	newCredentialFileSource = func(_ string) credential.Source { return &testCredentialSource{} }
+
+	path := filepath.Join(t.TempDir(), "mcp.json")
+	if err := writeTempFile(path, importFixture); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	_, err := executeImportCommand(t, path, "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), "failed to import 2 of 2 services") {
+		t.Fatalf("expected both imports to fail, got %v", err)
+	}
+
+	if alpha.installCalls != 0 {
+		t.Fatalf("expected nothing to be installed, got %d calls", alpha.installCalls)
+	}
+}
+
+func executeImportCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	importCmd := newImportCmd()
+	var stdout bytes.Buffer
+
+	importCmd.SetOut(&stdout)
+	importCmd.SetErr(&stdout)
+	importCmd.SetIn(strings.NewReader(""))
+	importCmd.SetArgs(args)
+
+	err := importCmd.Execute()
+
+	return stdout.String(), err
+}
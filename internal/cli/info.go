@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/format"
+	"github.com/andreagrandi/mcp-wire/internal/registry"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// openServiceDocs opens a service's docs link for `info --open`.
+var openServiceDocs = openSetupURL
+
+func init() {
+	registerCommand(newInfoCmd)
+}
+
+func newInfoCmd() *cobra.Command {
+	var openDocs bool
+
+	cmd := &cobra.Command{
+		Use:   "info <service>",
+		Short: "Show a service definition and its setup notes",
+		Long: `info prints what installing a service writes (transport, URL or command),
+the credentials it asks for with where to create each one, and the setup
+notes the service ships: which token to create or which account tier it
+needs. Pass --open to open the service's docs page in the browser.
+
+With the registry feature enabled, a service that is not curated is looked
+up in the registry instead. info then prints its trust summary: publisher,
+repository, website, transports, packages, env vars, and every version the
+registry has published. --open opens its website or repository.
+
+This command is read-only.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serviceName := strings.TrimSpace(args[0])
+			if serviceName == "" {
+				return errors.New("service name is required")
+			}
+
+			services, err := loadServices()
+			if err != nil {
+				return fmt.Errorf("load services: %w", err)
+			}
+
+			svc, err := findServiceDefinitionByName(services, serviceName)
+			if err != nil {
+				entry, found := findRegistryEntry(serviceName)
+				if !found {
+					return err
+				}
+
+				return showRegistryServiceInfo(cmd.OutOrStdout(), refreshRegistryEntry(entry), openDocs)
+			}
+
+			writeServiceInfo(cmd.OutOrStdout(), svc)
+
+			if !openDocs {
+				return nil
+			}
+
+			docsURL := strings.TrimSpace(svc.DocsURL)
+			if docsURL == "" {
+				return fmt.Errorf("service %q has no docs link", svc.Name)
+			}
+
+			if err := openServiceDocs(docsURL); err != nil {
+				return fmt.Errorf("open %s: %w", docsURL, err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&openDocs, "open", false, "Open the service's docs page in the browser")
+
+	return cmd
+}
+
+func writeServiceInfo(output io.Writer, svc service.Service) {
+	fmt.Fprintln(output, svc.Name)
+	if description := strings.TrimSpace(svc.Description); description != "" {
+		fmt.Fprintf(output, "  %s\n", description)
+	}
+
+	fmt.Fprintln(output)
+	fmt.Fprintf(output, "  Transport:  %s\n", svc.Transport)
+	if svc.URL != "" {
+		fmt.Fprintf(output, "  URL:        %s\n", svc.URL)
+	}
+	if svc.Command != "" {
+		fmt.Fprintf(output, "  Command:    %s\n", strings.Join(append([]string{svc.Command}, svc.Args...), " "))
+	}
+	if serviceUsesOAuth(svc) {
+		fmt.Fprintln(output, "  Auth:       OAuth, completed by the target after install")
+	}
+
+	if len(svc.Env) > 0 {
+		fmt.Fprintln(output, "  Credentials:")
+		for _, envVar := range svc.Env {
+			label := envVar.Name
+			if envVar.Required {
+				label += " (required)"
+			}
+			if description := strings.TrimSpace(envVar.Description); description != "" {
+				label += ": " + description
+			}
+
+			fmt.Fprintf(output, "    %s\n", label)
+			if hint := strings.TrimSpace(envVar.SetupHint); hint != "" {
+				fmt.Fprintf(output, "      %s\n", hint)
+			}
+			if setupURL := strings.TrimSpace(envVar.SetupURL); setupURL != "" {
+				fmt.Fprintf(output, "      %s\n", setupURL)
+			}
+		}
+	}
+
+	if setup := strings.TrimSpace(svc.Setup); setup != "" {
+		fmt.Fprintln(output)
+		fmt.Fprintln(output, "Setup:")
+		for _, line := range strings.Split(setup, "\n") {
+			fmt.Fprintf(output, "  %s\n", strings.TrimSpace(line))
+		}
+	}
+
+	if docsURL := strings.TrimSpace(svc.DocsURL); docsURL != "" {
+		fmt.Fprintln(output)
+		fmt.Fprintf(output, "Docs: %s (open it with --open)\n", docsURL)
+	}
+}
+
+// showRegistryServiceInfo prints a registry server's information for `info`
+// and opens its website or repository when openDocs is set.
+func showRegistryServiceInfo(output io.Writer, entry catalog.Entry, openDocs bool) error {
+	serverName := entry.Registry.Server.Name
+	versions, versionsErr := fetchServerVersions(registryBaseURL(entry.RegistryName()), serverName)
+	writeRegistryServiceInfo(output, entry, versions, versionsErr)
+
+	if !openDocs {
+		return nil
+	}
+
+	docsURL := strings.TrimSpace(entry.WebsiteURL())
+	if docsURL == "" {
+		docsURL = strings.TrimSpace(entry.RepositoryURL())
+	}
+	if docsURL == "" {
+		return fmt.Errorf("registry service %q has no website or repository link", serverName)
+	}
+
+	if err := openServiceDocs(docsURL); err != nil {
+		return fmt.Errorf("open %s: %w", docsURL, err)
+	}
+
+	return nil
+}
+
+// writeRegistryServiceInfo prints the trust summary the install flow shows,
+// followed by the details behind it and the versions the registry lists.
+// versionsErr is reported in place of the versions when they could not be
+// fetched.
+func writeRegistryServiceInfo(output io.Writer, entry catalog.Entry, versions []registry.ServerResponse, versionsErr error) {
+	server := entry.Registry.Server
+	fmt.Fprintln(output, server.Name)
+	if description := strings.TrimSpace(entry.Description()); description != "" {
+		fmt.Fprintf(output, "  %s\n", description)
+	}
+
+	printRegistryTrustSummary(output, entry)
+
+	fmt.Fprintln(output, "Details:")
+	if publisher, _, found := strings.Cut(server.Name, "/"); found {
+		fmt.Fprintf(output, "  Publisher:  %s\n", publisher)
+	}
+	if websiteURL := strings.TrimSpace(server.WebsiteURL); websiteURL != "" {
+		fmt.Fprintf(output, "  Website:    %s\n", websiteURL)
+	}
+	if server.Version != "" {
+		fmt.Fprintf(output, "  Version:    %s\n", server.Version)
+	}
+
+	if len(server.Remotes) > 0 {
+		fmt.Fprintln(output, "  Remotes:")
+		for _, remote := range server.Remotes {
+			fmt.Fprintf(output, "    %s %s\n", remote.Type, remote.URL)
+		}
+	}
+
+	if len(server.Packages) > 0 {
+		fmt.Fprintln(output, "  Packages:")
+		for _, pkg := range server.Packages {
+			identifier := pkg.Identifier
+			if pkg.Version != "" {
+				identifier += "@" + pkg.Version
+			}
+
+			line := fmt.Sprintf("    %s %s", pkg.RegistryType, identifier)
+			if pkg.Transport.Type != "" {
+				line += fmt.Sprintf(" (%s)", pkg.Transport.Type)
+			}
+			fmt.Fprintln(output, line)
+		}
+	}
+
+	if envVars := entry.EnvVars(); len(envVars) > 0 {
+		fmt.Fprintln(output, "  Env vars:")
+		for _, envVar := range envVars {
+			label := envVar.Name
+			if envVar.Required {
+				label += " (required)"
+			}
+			if description := strings.TrimSpace(envVar.Description); description != "" {
+				label += ": " + description
+			}
+
+			fmt.Fprintf(output, "    %s\n", label)
+		}
+	}
+
+	fmt.Fprintln(output)
+	if versionsErr != nil {
+		fmt.Fprintf(output, "Versions: unavailable (%v)\n", versionsErr)
+		return
+	}
+
+	if len(versions) == 0 {
+		fmt.Fprintln(output, "Versions: none listed")
+		return
+	}
+
+	sorted := append([]registry.ServerResponse(nil), versions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return versionNewer(sorted[i].Server.Version, sorted[j].Server.Version)
+	})
+
+	fmt.Fprintln(output, "Versions:")
+	for _, version := range sorted {
+		line := "  " + version.Server.Version
+		if official := version.Meta.Official; official != nil {
+			if !official.PublishedAt.IsZero() {
+				line += "  published " + format.Date(official.PublishedAt)
+			}
+			if official.Status != "" && official.Status != "active" {
+				line += "  " + official.Status
+			}
+			if official.IsLatest {
+				line += "  (latest)"
+			}
+		}
+
+		fmt.Fprintln(output, line)
+	}
+	fmt.Fprintf(output, "Install a specific version with: mcp-wire install %s@<version>\n", server.Name)
+}
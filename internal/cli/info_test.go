@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	"github.com/andreagrandi/mcp-wire/internal/registry"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func overrideInfoServices(t *testing.T) {
+	t.Helper()
+
+	originalLoadServices := loadServices
+	originalOpen := openServiceDocs
+	t.Cleanup(func() {
+		loadServices = originalLoadServices
+		openServiceDocs = originalOpen
+	})
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"acme": {
+				Name:        "acme",
+				Description: "Acme MCP server",
+				Transport:   "stdio",
+				Command:     "npx",
+				Args:        []string{"-y", "@acme/mcp"},
+				Setup:       "Create a read-only API token.\nNeeds a Team plan or above.",
+				DocsURL:     "https://docs.example.com/acme",
+				Env: []service.EnvVar{{
+					Name:        "ACME_TOKEN",
+					Description: "API token",
+					Required:    true,
+					SetupURL:    "https://acme.example.com/tokens",
+				}},
+			},
+			"plain": {Name: "plain", Transport: "http", URL: "https://plain.example.com/mcp"},
+		}, nil
+	}
+}
+
+func TestInfoCommandPrintsSetupNotes(t *testing.T) {
+	overrideInfoServices(t)
+	openServiceDocs = func(string) error {
+		t.Fatal("expected the docs not to be opened without --open")
+		return nil
+	}
+
+	output, _, err := executeCommandWithOutputFlags(t, newInfoCmd(), "ACME")
+	if err != nil {
+		t.Fatalf("expected info to succeed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Command:    npx -y @acme/mcp",
+		"ACME_TOKEN (required): API token",
+		"https://acme.example.com/tokens",
+		"Setup:\n  Create a read-only API token.\n  Needs a Team plan or above.",
+		"Docs: https://docs.example.com/acme",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+}
+
+func TestInfoCommandOpensDocs(t *testing.T) {
+	overrideInfoServices(t)
+
+	var opened string
+	openServiceDocs = func(url string) error {
+		opened = url
+		return nil
+	}
+
+	if _, _, err := executeCommandWithOutputFlags(t, newInfoCmd(), "acme", "--open"); err != nil {
+		t.Fatalf("expected info to succeed: %v", err)
+	}
+
+	if opened != "https://docs.example.com/acme" {
+		t.Fatalf("expected the docs link to be opened, got %q", opened)
+	}
+
+	_, _, err := executeCommandWithOutputFlags(t, newInfoCmd(), "plain", "--open")
+	if err == nil || !strings.Contains(err.Error(), `service "plain" has no docs link`) {
+		t.Fatalf("expected an error for a service without docs, got %v", err)
+	}
+}
+
+func TestInfoCommandPrintsRegistryServerWithVersions(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+	overrideInfoServices(t)
+
+	originalFetchServerVersions := fetchServerVersions
+	t.Cleanup(func() { fetchServerVersions = originalFetchServerVersions })
+
+	cfgPath := t.TempDir() + "/config.json"
+	if err := writeTempFile(cfgPath, `{"features":{"registry":true}}`); err != nil {
+		t.Fatalf("failed to write temp config: %v", err)
+	}
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(cfgPath) }
+
+	server := func(version string, latest bool) registry.ServerResponse {
+		return registry.ServerResponse{
+			Server: registry.ServerJSON{
+				Name:        "io.github.acme/mcp",
+				Description: "Acme registry server",
+				Version:     version,
+				WebsiteURL:  "https://acme.example.com",
+				Repository:  &registry.Repository{URL: "https://github.com/acme/mcp"},
+				Packages: []registry.Package{{
+					RegistryType:         "npm",
+					Identifier:           "@acme/mcp",
+					Version:              version,
+					Transport:            registry.Transport{Type: "stdio"},
+					EnvironmentVariables: []registry.KeyValueInput{{Name: "ACME_KEY", Description: "API key", IsRequired: true}},
+				}},
+			},
+			Meta: registry.ResponseMeta{Official: &registry.RegistryExtensions{Status: "active", IsLatest: latest}},
+		}
+	}
+	loadRegistryCache = func() []registry.ServerResponse { return []registry.ServerResponse{server("1.10.0", true)} }
+	fetchServerLatest = func(string, string) (*registry.ServerResponse, error) {
+		resp := server("1.10.0", true)
+		return &resp, nil
+	}
+	fetchServerVersions = func(_ string, name string) ([]registry.ServerResponse, error) {
+		return []registry.ServerResponse{server("1.2.0", false), server("1.10.0", true), server("1.9.1", false)}, nil
+	}
+
+	var opened string
+	openServiceDocs = func(url string) error {
+		opened = url
+		return nil
+	}
+
+	output, _, err := executeCommandWithOutputFlags(t, newInfoCmd(), "io.github.acme/mcp", "--open")
+	if err != nil {
+		t.Fatalf("expected info to succeed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Registry Service Information:",
+		"Repo:      https://github.com/acme/mcp",
+		"Publisher:  io.github.acme",
+		"Website:    https://acme.example.com",
+		"npm @acme/mcp@1.10.0 (stdio)",
+		"ACME_KEY (required): API key",
+		"Versions:\n  1.10.0  (latest)\n  1.9.1\n  1.2.0\n",
+		"mcp-wire install io.github.acme/mcp@<version>",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+
+	if opened != "https://acme.example.com" {
+		t.Fatalf("expected the website to be opened, got %q", opened)
+	}
+
+	fetchServerVersions = func(string, string) ([]registry.ServerResponse, error) { return nil, errors.New("offline") }
+	output, _, err = executeCommandWithOutputFlags(t, newInfoCmd(), "io.github.acme/mcp")
+	if err != nil || !strings.Contains(output, "Versions: unavailable (offline)") {
+		t.Fatalf("expected the versions to be reported unavailable, got %q (%v)", output, err)
+	}
+}
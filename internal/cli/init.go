@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/andreagrandi/mcp-wire/internal/recommend"
+	"github.com/spf13/cobra"
+)
+
+var getWorkingDirectory = os.Getwd
+
+func init() {
+	registerCommand(newInitCmd)
+}
+
+func newInitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "init",
+		Short: "Suggest services for the current project",
+		Long: `init inspects the current directory (package manifests, .github, tool
+config files) and suggests curated services that fit the project, with the
+commands to install them.
+
+It is read-only: nothing is installed until you run the suggested commands.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			dir, err := getWorkingDirectory()
+			if err != nil {
+				return fmt.Errorf("resolve current working directory: %w", err)
+			}
+
+			return runInit(cmd.OutOrStdout(), dir)
+		},
+	}
+}
+
+func runInit(output io.Writer, dir string) error {
+	recommendations, err := projectRecommendations(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(recommendations) == 0 {
+		fmt.Fprintf(output, "No service recommendations for %s.\n", dir)
+		return nil
+	}
+
+	fmt.Fprintf(output, "Recommended services for %s:\n\n", dir)
+
+	maxNameWidth := 0
+	for _, r := range recommendations {
+		if len(r.Service) > maxNameWidth {
+			maxNameWidth = len(r.Service)
+		}
+	}
+
+	for _, r := range recommendations {
+		fmt.Fprintf(output, "  %-*s  %s\n", maxNameWidth, r.Service, r.Reason)
+	}
+
+	fmt.Fprintln(output)
+	fmt.Fprintln(output, "Install with:")
+	for _, r := range recommendations {
+		fmt.Fprintf(output, "  mcp-wire install %s\n", r.Service)
+	}
+
+	return nil
+}
+
+func projectRecommendations(dir string) ([]recommend.Recommendation, error) {
+	services, err := loadServices()
+	if err != nil {
+		return nil, fmt.Errorf("load services: %w", err)
+	}
+
+	available := make(map[string]bool, len(services))
+	for name := range services {
+		available[name] = true
+	}
+
+	return recommend.Detect(dir, available), nil
+}
+
+func recommendedServiceNames() []string {
+	dir, err := getWorkingDirectory()
+	if err != nil {
+		return nil
+	}
+
+	recommendations, err := projectRecommendations(dir)
+	if err != nil {
+		return nil
+	}
+
+	return recommend.Names(recommendations)
+}
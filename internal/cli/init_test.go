@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func overrideInitServices(t *testing.T, names ...string) {
+	t.Helper()
+
+	original := loadServices
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		services := make(map[string]service.Service, len(names))
+		for _, name := range names {
+			services[name] = service.Service{Name: name, Transport: "stdio", Command: "npx"}
+		}
+		return services, nil
+	}
+	t.Cleanup(func() { loadServices = original })
+}
+
+func TestRunInitPrintsRecommendations(t *testing.T) {
+	overrideInitServices(t, "github", "sentry")
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".github"), 0o755); err != nil {
+		t.Fatalf("failed to create .github: %v", err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := runInit(buf, dir); err != nil {
+		t.Fatalf("expected init to succeed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "github") || !strings.Contains(output, "mcp-wire install github") {
+		t.Fatalf("expected github recommendation, got %q", output)
+	}
+
+	if strings.Contains(output, "sentry") {
+		t.Fatalf("did not expect sentry recommendation, got %q", output)
+	}
+}
+
+func TestRunInitNoRecommendations(t *testing.T) {
+	overrideInitServices(t, "github")
+
+	buf := new(bytes.Buffer)
+	if err := runInit(buf, t.TempDir()); err != nil {
+		t.Fatalf("expected init to succeed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "No service recommendations") {
+		t.Fatalf("unexpected output %q", buf.String())
+	}
+}
@@ -5,12 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/diagnostics"
 	"github.com/andreagrandi/mcp-wire/internal/service"
 	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/webhook"
 	"github.com/spf13/cobra"
 )
 
@@ -26,56 +31,199 @@ var shouldAutoAuthenticate = func(cmd *cobra.Command) bool {
 }
 
 func init() {
-	rootCmd.AddCommand(newInstallCmd())
+	registerCommand(newInstallCmd)
 }
 
 func newInstallCmd() *cobra.Command {
 	var targetSlugs []string
 	var noPrompt bool
 	var scopeValue string
+	var supervised bool
+	var rawSettings []string
+	var allowSystem bool
+	var force bool
+	var dryRun bool
+	var fromPath string
+	var codexProfile string
+	var builtinOAuth bool
 
 	cmd := &cobra.Command{
 		Use:   "install <service>",
 		Short: "Install a service into one or more targets",
-		Args:  cobra.MaximumNArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			scope, err := parseInstallUninstallScope(scopeValue)
-			if err != nil {
-				return err
-			}
+		Long: `install writes a service into the config of one or more targets. Without
+a service it starts a guided wizard.
 
-			scopeSet := cmd.Flags().Changed("scope")
-
-			if len(args) == 0 {
-				return runInstallWizardWithScope(cmd, bufio.NewReader(cmd.InOrStdin()), targetSlugs, noPrompt, scope, scopeSet)
-			}
-
-			requestedServiceName := strings.TrimSpace(args[0])
-			if requestedServiceName == "" {
-				return errors.New("service name is required")
+A registry service can be pinned to a published version as name@version,
+such as io.github.acme/mcp@1.2.3; upgrade leaves pinned services alone.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if builtinOAuth {
+				requestBuiltinOAuth(cmd)
 			}
 
-			svc, err := resolveServiceByName(requestedServiceName)
-			if err != nil {
-				return err
+			if fromPath != "" {
+				if len(args) > 0 {
+					return errors.New("--from cannot be used with a service name; list the services in the file instead")
+				}
+
+				for _, name := range installFromFlagConflicts {
+					if cmd.Flags().Changed(name) {
+						return fmt.Errorf("--%s cannot be used with --from; set targets and scope per service in the file instead", name)
+					}
+				}
+
+				jsonOutput, err := jsonOutputRequested(cmd)
+				if err != nil {
+					return err
+				}
+				if jsonOutput {
+					return errors.New("JSON output requires a service name")
+				}
+
+				return runInstallFromFile(cmd, fromPath, ciNoPrompt(cmd, noPrompt), force)
 			}
 
-			targetDefinitions, err := resolveInstallTargets(targetSlugs)
-			if err != nil {
-				return err
+			serviceName := ""
+			if len(args) > 0 {
+				serviceName = strings.TrimSpace(args[0])
 			}
 
-			return executeInstall(cmd, svc, targetDefinitions, noPrompt, scope)
+			return runReportingOperation(cmd, "install", serviceName, strings.ToLower(strings.TrimSpace(scopeValue)), func() error {
+				scope, err := parseInstallUninstallScope(scopeValue)
+				if err != nil {
+					return err
+				}
+
+				scopeSet := cmd.Flags().Changed("scope")
+				// JSON output is for scripts, so it never waits on stdin.
+				jsonOutput := operationReportFrom(cmd) != nil
+				promptDisabled := ciNoPrompt(cmd, noPrompt) || jsonOutput
+
+				extra, err := parseRawSettings(rawSettings)
+				if err != nil {
+					return err
+				}
+
+				if len(args) == 0 {
+					if jsonOutput {
+						return errors.New("JSON output requires a service name")
+					}
+
+					if len(extra) > 0 {
+						return errors.New("--set-raw requires a service name")
+					}
+
+					if dryRun {
+						return errors.New("--dry-run requires a service name")
+					}
+
+					if codexProfile != "" {
+						return errors.New("--codex-profile requires a service name")
+					}
+
+					if scope == target.ConfigScopeSystem {
+						return errors.New("--scope system requires a service name")
+					}
+
+					if err := requireInteractiveInput(cmd, "mcp-wire install <service> --target <slug>"); err != nil {
+						return err
+					}
+
+					return runInstallWizardWithScope(cmd, bufio.NewReader(cmd.InOrStdin()), targetSlugs, promptDisabled, scope, scopeSet)
+				}
+
+				requestedServiceName := strings.TrimSpace(args[0])
+				if requestedServiceName == "" {
+					return errors.New("service name is required")
+				}
+
+				svc, err := resolveServiceByName(requestedServiceName)
+				if err != nil {
+					return err
+				}
+
+				if supervised {
+					supervisedSvc, ok := svc.Supervised()
+					if !ok {
+						return fmt.Errorf("service %q has no serve configuration and cannot be installed as supervised", svc.Name)
+					}
+
+					svc = supervisedSvc
+				}
+
+				svc = withExtra(svc, extra)
+
+				targetDefinitions, err := resolveInstallTargets(targetSlugs)
+				if err != nil {
+					return err
+				}
+
+				if err := checkSystemScope(scope, allowSystem, targetDefinitions); err != nil {
+					return err
+				}
+
+				if err := checkRemoteScope(scope, targetDefinitions); err != nil {
+					return err
+				}
+
+				location, err := applyProjectDir(cmd, targetDefinitions)
+				if err != nil {
+					return err
+				}
+
+				if err := applyCodexProfile(targetDefinitions, codexProfile); err != nil {
+					return err
+				}
+
+				if dryRun {
+					if err := checkPolicy("install", svc, targetDefinitions, scope); err != nil {
+						return err
+					}
+
+					return previewOperation(cmd.OutOrStdout(), operationReportFrom(cmd), "install", svc.Name, targetDefinitions, dryRunInstall(svc, scope))
+				}
+
+				input := cmd.InOrStdin()
+				interactive := !promptDisabled && isTerminalReader(input)
+				proceed, err := confirmProjectScope(cmd.OutOrStdout(), bufio.NewReader(input), interactive, targetDefinitions, scope, location, force)
+				if err != nil || !proceed {
+					return err
+				}
+
+				proceed, err = checkServiceConflicts(cmd, svc, targetDefinitions, promptDisabled, scope)
+				if err != nil || !proceed {
+					return err
+				}
+
+				if err := installServiceDependencies(cmd, svc, targetDefinitions, promptDisabled, scope); err != nil {
+					return err
+				}
+
+				return executeInstall(cmd, svc, targetDefinitions, promptDisabled, scope)
+			})
 		},
 	}
 
-	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Install to specific target slug(s); can be repeated")
+	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Install to specific target slug(s) or @group(s); can be repeated")
 	cmd.Flags().BoolVar(&noPrompt, "no-prompt", false, "Fail when required credentials are missing instead of prompting")
-	cmd.Flags().StringVar(&scopeValue, "scope", string(target.ConfigScopeUser), "Config scope for supported targets: user or project")
+	cmd.Flags().StringVar(&scopeValue, "scope", string(target.ConfigScopeUser), "Config scope for supported targets: user, project, system, or remote")
+	cmd.Flags().BoolVar(&allowSystem, "allow-system", false, "Allow --scope system to change the machine-wide config of every user")
+	cmd.Flags().BoolVar(&force, "force", false, "Write project scope even when the current directory does not look like a project")
+	addProjectDirFlags(cmd)
+	cmd.Flags().StringArrayVar(&rawSettings, "set-raw", nil, "Set an extra key in the target config entry as key=json; can be repeated")
+	cmd.Flags().BoolVar(&supervised, "supervised", false, "Connect targets to the instance started by \"mcp-wire run\" instead of spawning their own")
+	cmd.Flags().Bool("verify", false, "Connect to the server after installing and report how many tools it offers")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the diff each target config would get without writing anything")
+	cmd.Flags().StringVar(&fromPath, "from", "", "Install every service listed in a YAML file, in the mcp-wire.yaml format")
+	cmd.Flags().StringVar(&codexProfile, "codex-profile", "", "Install into this Codex CLI profile instead of the global mcp_servers table")
+	cmd.Flags().BoolVar(&builtinOAuth, "builtin-oauth", false, "Sign in with mcp-wire's own OAuth client and write the token to targets that cannot run OAuth themselves")
 
 	return cmd
 }
 
+// resolveServiceByName finds a curated service, or a registry service when
+// the registry feature is on. A registry service can be pinned to a
+// published version as name@version.
 func resolveServiceByName(name string) (service.Service, error) {
 	services, err := loadServices()
 	if err != nil {
@@ -87,28 +235,34 @@ func resolveServiceByName(name string) (service.Service, error) {
 		return svc, nil
 	}
 
-	cfg, cfgErr := loadConfig()
-	if cfgErr != nil || !cfg.IsFeatureEnabled("registry") {
-		return service.Service{}, err
-	}
-
-	cat, catErr := loadCatalog("registry", true)
-	if catErr != nil {
-		return service.Service{}, err
+	name, version := splitServiceVersion(name)
+	if version != "" {
+		if _, curatedErr := findServiceDefinitionByName(services, name); curatedErr == nil {
+			return service.Service{}, fmt.Errorf("%q is a curated service; only registry services can be pinned to a version", name)
+		}
 	}
 
-	entry, found := cat.Find(name)
+	entry, found := findRegistryEntry(name)
 	if !found {
 		return service.Service{}, err
 	}
 
-	entry = refreshRegistryEntry(entry)
+	if version != "" {
+		entry, err = pinRegistryEntry(entry, version)
+		if err != nil {
+			return service.Service{}, err
+		}
+	} else {
+		entry = refreshRegistryEntry(entry)
+	}
 
 	resolved, ok := catalogEntryToService(entry)
 	if !ok {
 		return service.Service{}, fmt.Errorf("registry service %q has no supported install method", name)
 	}
 
+	resolved.Pin = version
+
 	return resolved, nil
 }
 
@@ -152,12 +306,22 @@ func resolveInstallTargets(targetSlugs []string) ([]target.Target, error) {
 		normalizedTargetSlugs = append(normalizedTargetSlugs, slug)
 	}
 
+	normalizedTargetSlugs, err := expandTargetGroups(normalizedTargetSlugs)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(normalizedTargetSlugs) == 0 {
-		targetDefinitions := listInstalledTargets()
-		if len(targetDefinitions) == 0 {
+		installedTargets := listInstalledTargets()
+		if len(installedTargets) == 0 {
 			return nil, errors.New("no installed targets found")
 		}
 
+		targetDefinitions := withoutDisabledTargets(installedTargets)
+		if len(targetDefinitions) == 0 {
+			return nil, errors.New("every installed target is disabled; pick one with --target or run: mcp-wire targets enable <target>")
+		}
+
 		return targetDefinitions, nil
 	}
 
@@ -175,7 +339,7 @@ func resolveInstallTargets(targetSlugs []string) ([]target.Target, error) {
 		}
 
 		if !targetDefinition.IsInstalled() {
-			return nil, fmt.Errorf("target %q is not installed", slug)
+			return nil, fmt.Errorf("target %q is not installed; if it is, run: mcp-wire targets configure %s", slug, slug)
 		}
 
 		targetDefinitions = append(targetDefinitions, targetDefinition)
@@ -199,53 +363,144 @@ func printInstallPlan(output io.Writer, targetDefinitions []target.Target) {
 }
 
 func executeInstall(cmd *cobra.Command, svc service.Service, targetDefinitions []target.Target, noPrompt bool, scope target.ConfigScope) error {
-	envSource := newCredentialEnvSource()
-	fileSource := newCredentialFileSource("")
-	resolver := newCredentialResolver(envSource, fileSource)
+	return executeInstallWithEnv(cmd, svc, targetDefinitions, noPrompt, scope, "", nil)
+}
+
+// executeInstallWithEnv is executeInstall for a service listed in the file
+// at source, whose env sets credentials ahead of every credential source.
+func executeInstallWithEnv(cmd *cobra.Command, svc service.Service, targetDefinitions []target.Target, noPrompt bool, scope target.ConfigScope, source string, env map[string]string) error {
+	if err := checkPolicy("install", svc, targetDefinitions, scope); err != nil {
+		return err
+	}
+
+	resolver, store := newCredentialSources()
+	if len(env) > 0 {
+		resolver.Use(credentialOverrides(env, "manifest"))
+	}
+	origin := serviceOrigin{command: "install", source: source, credentialSources: map[string]string{}}
 
 	resolvedEnv, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{
-		noPrompt:   noPrompt,
-		input:      cmd.InOrStdin(),
-		output:     cmd.OutOrStdout(),
-		fileSource: fileSource,
+		noPrompt: noPrompt,
+		input:    cmd.InOrStdin(),
+		output:   cmd.OutOrStdout(),
+		store:    store,
+		sources:  origin.credentialSources,
 	})
 	if err != nil {
 		return err
 	}
 
-	applyRegistrySubstitutions(&svc, resolvedEnv)
+	if err := allocateServicePorts(cmd.OutOrStdout(), svc, resolvedEnv); err != nil {
+		return err
+	}
+
+	if err := applyRegistrySubstitutions(&svc, resolvedEnv, resolver); err != nil {
+		return err
+	}
+
+	return installResolvedService(cmd, svc, targetDefinitions, scope, resolvedEnv, origin)
+}
+
+// installResolvedService writes svc, whose credentials are already resolved,
+// to every target, reports each outcome, records where each configured entry
+// came from, and runs OAuth and the --verify connection check where they
+// apply.
+func installResolvedService(cmd *cobra.Command, svc service.Service, targetDefinitions []target.Target, scope target.ConfigScope, resolvedEnv map[string]string, origin serviceOrigin) error {
+	suppressions, err := warningSuppressions(cmd)
+	if err != nil {
+		return err
+	}
 
 	printInstallPlan(cmd.OutOrStdout(), targetDefinitions)
-	autoAuthenticate := shouldAutoAuthenticate(cmd) && serviceUsesOAuth(svc)
+	if scope == target.ConfigScopeSystem && len(resolvedEnv) > 0 {
+		writeWarning(cmd.OutOrStdout(), suppressions, diagnostics.New(diagnostics.SystemScopeCredentials,
+			"The system config is readable by every user on this machine, including the credentials written to it."))
+	}
+	// JSON runs leave OAuth to the caller, since the browser flow needs a person.
+	report := operationReportFrom(cmd)
+	autoAuthenticate := report == nil && shouldAutoAuthenticate(cmd) && serviceUsesOAuth(svc)
 
 	installErrors := make([]error, 0)
-	authenticationErrors := make([]error, 0)
-	for _, targetDefinition := range targetDefinitions {
-		var err error
-		scopedTarget, supportsScopes := targetDefinition.(target.ScopedTarget)
-		if supportsScopes && targetSupportsScope(targetDefinition, scope) {
-			err = scopedTarget.InstallWithScope(svc, resolvedEnv, scope)
-		} else {
-			err = targetDefinition.Install(svc, resolvedEnv)
-		}
+	configuredCount := 0
+	outcomes := make([]targetOutcome, len(targetDefinitions))
+	recordResult := func(result targetInstallResult) {
+		targetDefinition := targetDefinitions[result.index]
+		outcome := targetOutcome{Service: svc.Name, Target: targetDefinition.Slug(), Name: targetDefinition.Name()}
 
+		err := withElevationHint(result.err, scope)
 		if err != nil {
+			// A tool that failed may echo the credentials it was given.
+			err = credentialRedactor.Wrap(err)
 			fmt.Fprintf(cmd.OutOrStdout(), "  %s: failed (%v)\n", targetDefinition.Name(), err)
 			installErrors = append(installErrors, fmt.Errorf("target %q: %w", targetDefinition.Slug(), err))
-			continue
+			outcome.Status = "failed"
+			outcome.Error = err.Error()
+			outcomes[result.index] = outcome
+			return
+		}
+
+		switch {
+		case result.verifyErr != nil && !suppressions.Suppressed(diagnostics.VerificationFailed):
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s: configured, but verification failed [%s] (%v)\n", targetDefinition.Name(), diagnostics.VerificationFailed, result.verifyErr)
+			outcome.Warning = result.verifyErr.Error()
+			outcome.WarningCode = string(diagnostics.VerificationFailed)
+		case result.verified && result.verifyErr == nil:
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s: configured (verified)\n", targetDefinition.Name())
+		default:
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s: configured\n", targetDefinition.Name())
 		}
+		configuredCount++
+		recordServiceProvenance(svc, targetDefinition, scope, origin)
+
+		outcome.Status = "configured"
+		outcome.Verified = result.verified && result.verifyErr == nil
+		outcomes[result.index] = outcome
+	}
+
+	// A signal lets the config writes in progress finish, so no target is
+	// left half written, and then skips OAuth.
+	var results []targetInstallResult
+	interrupted, _ := runInForeground(func() error {
+		results = installOnTargets(targetDefinitions, svc, resolvedEnv, scope)
+		return nil
+	})
+
+	// Outcomes are printed in target order, whichever install finished
+	// first.
+	for _, result := range results {
+		recordResult(result)
+	}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "  %s: configured\n", targetDefinition.Name())
+	for _, outcome := range outcomes {
+		report.add(outcome)
+	}
+
+	if report == nil && scope == target.ConfigScopeProject && interrupted == nil {
+		if err := reportProjectFiles(cmd, targetDefinitions, outcomes, resolvedEnv, suppressions); err != nil {
+			return err
+		}
+	}
 
-		if !autoAuthenticate {
+	// OAuth runs one target at a time, after every install finished, since
+	// it may open a browser or prompt on the terminal.
+	authenticationErrors := make([]error, 0)
+	builtinOAuth := builtinOAuthRequested(cmd)
+	var builtinTargets []target.Target
+	for i, targetDefinition := range targetDefinitions {
+		if !autoAuthenticate || outcomes[i].Status != "configured" || interrupted != nil {
 			continue
 		}
 
 		authTarget, supportsAuth := targetDefinition.(target.AuthTarget)
+		if !supportsAuth && builtinOAuth {
+			builtinTargets = append(builtinTargets, targetDefinition)
+			continue
+		}
+
 		if !supportsAuth {
 			manualAuthHint := oauthManualAuthHint(targetDefinition)
 			if manualAuthHint != "" {
-				fmt.Fprintf(cmd.OutOrStdout(), "  [!] Next step: %s\n", manualAuthHint)
+				writeWarning(cmd.OutOrStdout(), suppressions, diagnostics.New(diagnostics.ManualAuthentication, "Next step: %s", manualAuthHint))
 			} else {
 				fmt.Fprintf(cmd.OutOrStdout(), "  %s: authentication skipped (automatic OAuth is not supported by this target)\n", targetDefinition.Name())
 			}
@@ -254,7 +509,15 @@ func executeInstall(cmd *cobra.Command, svc service.Service, targetDefinitions [
 		}
 
 		fmt.Fprintf(cmd.OutOrStdout(), "  %s: starting OAuth authentication...\n", targetDefinition.Name())
-		err = authTarget.Authenticate(svc.Name, cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+		stdout, stderr := oauthOutputs(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		sig, err := runInForeground(func() error { return authTarget.Authenticate(svc.Name, cmd.InOrStdin(), stdout, stderr) })
+		if sig != nil {
+			interrupted = sig
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s: authentication interrupted\n", targetDefinition.Name())
+			continue
+		}
+
+		emitWebhookEvent(webhook.EventAuth, svc.Name, targetDefinition, scope, err)
 		if err != nil {
 			fmt.Fprintf(cmd.OutOrStdout(), "  %s: authentication failed (%v)\n", targetDefinition.Name(), err)
 			authenticationErrors = append(authenticationErrors, fmt.Errorf("target %q: %w", targetDefinition.Slug(), err))
@@ -264,6 +527,18 @@ func executeInstall(cmd *cobra.Command, svc service.Service, targetDefinitions [
 		fmt.Fprintf(cmd.OutOrStdout(), "  %s: authenticated\n", targetDefinition.Name())
 	}
 
+	if len(builtinTargets) > 0 {
+		authenticationErrors = append(authenticationErrors, authenticateWithBuiltinOAuth(cmd, svc, builtinTargets, scope, resolvedEnv)...)
+	}
+
+	if configuredCount > 0 {
+		recordRecentService(svc.Name)
+	}
+
+	if interrupted != nil {
+		return fmt.Errorf("install %q stopped after configuring %d of %d target(s): %w", svc.Name, configuredCount, len(targetDefinitions), interruptError{signal: interrupted})
+	}
+
 	if len(installErrors) > 0 {
 		return fmt.Errorf("failed to install service %q on one or more targets: %w", svc.Name, errors.Join(installErrors...))
 	}
@@ -272,9 +547,77 @@ func executeInstall(cmd *cobra.Command, svc service.Service, targetDefinitions [
 		return fmt.Errorf("configured service %q but failed OAuth authentication on one or more targets: %w", svc.Name, errors.Join(authenticationErrors...))
 	}
 
+	if configuredCount > 0 && connectionCheckRequested(cmd) {
+		return checkServerConnection(cmd, svc, resolvedEnv)
+	}
+
 	return nil
 }
 
+// maxParallelInstalls bounds how many targets are written at once, since
+// some targets shell out to their own CLI or probe docker and npx.
+const maxParallelInstalls = 4
+
+// targetInstallResult is the outcome of installing a service on the target
+// at index.
+type targetInstallResult struct {
+	index     int
+	err       error
+	verified  bool
+	verifyErr error
+}
+
+// installOnTargets installs svc on every target, at most maxParallelInstalls
+// at a time, and returns the results in the order of targetDefinitions once
+// every install finished.
+func installOnTargets(targetDefinitions []target.Target, svc service.Service, resolvedEnv map[string]string, scope target.ConfigScope) []targetInstallResult {
+	jobs := make(chan int)
+	results := make([]targetInstallResult, len(targetDefinitions))
+
+	var workers sync.WaitGroup
+	for range min(maxParallelInstalls, len(targetDefinitions)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for index := range jobs {
+				results[index] = installOnTarget(index, targetDefinitions[index], svc, resolvedEnv, scope)
+			}
+		}()
+	}
+
+	for index := range targetDefinitions {
+		jobs <- index
+	}
+	close(jobs)
+	workers.Wait()
+
+	return results
+}
+
+func installOnTarget(index int, targetDefinition target.Target, svc service.Service, resolvedEnv map[string]string, scope target.ConfigScope) targetInstallResult {
+	result := targetInstallResult{index: index}
+	result.err = writeServiceEntry(targetDefinition, svc, resolvedEnv, scope)
+
+	emitWebhookEvent(webhook.EventInstall, svc.Name, targetDefinition, scope, result.err)
+
+	if result.err == nil {
+		result.verified, result.verifyErr = verifyInstalledService(targetDefinition, svc, resolvedEnv, scope)
+	}
+
+	return result
+}
+
+// writeServiceEntry writes svc to the config of targetDefinition, in scope
+// when the target supports it.
+func writeServiceEntry(targetDefinition target.Target, svc service.Service, resolvedEnv map[string]string, scope target.ConfigScope) error {
+	scopedTarget, supportsScopes := targetDefinition.(target.ScopedTarget)
+	if supportsScopes && targetSupportsScope(targetDefinition, scope) {
+		return scopedTarget.InstallWithScope(svc, resolvedEnv, scope)
+	}
+
+	return targetDefinition.Install(svc, resolvedEnv)
+}
+
 func serviceUsesOAuth(svc service.Service) bool {
 	authType := strings.ToLower(strings.TrimSpace(svc.Auth))
 	if authType != "" {
@@ -304,15 +647,40 @@ func oauthManualAuthHint(targetDefinition target.Target) string {
 	}
 }
 
-func applyRegistrySubstitutions(svc *service.Service, resolvedEnv map[string]string) {
-	svc.URL = substituteVars(svc.URL, resolvedEnv)
-
-	for name, tmpl := range svc.Headers {
-		svc.Headers[name] = substituteVars(tmpl, resolvedEnv)
+// applyRegistrySubstitutions expands the placeholders in the URL, headers
+// and args of svc. An unresolved ${NAME} is an error naming it, so nothing is
+// written with a placeholder the target would pass through literally.
+func applyRegistrySubstitutions(svc *service.Service, resolvedEnv map[string]string, resolver *credential.Resolver) error {
+	expanded, err := svc.ExpandTemplates(serviceTemplateLookup(*svc, resolvedEnv, resolver))
+	if err != nil {
+		return err
 	}
 
-	for i, arg := range svc.Args {
-		svc.Args[i] = substituteVars(arg, resolvedEnv)
+	*svc = expanded
+	return nil
+}
+
+// serviceTemplateLookup resolves a placeholder of svc from its resolved
+// credentials and ports, then from the credential sources and the OS
+// environment. Port slots only resolve once allocated, so an unrelated PORT
+// in the environment never fills one.
+func serviceTemplateLookup(svc service.Service, resolvedEnv map[string]string, resolver *credential.Resolver) service.TemplateLookup {
+	return func(name string) (string, bool) {
+		if value, ok := resolvedEnv[name]; ok {
+			return value, true
+		}
+
+		if slices.Contains(svc.Ports, name) {
+			return "", false
+		}
+
+		if resolver != nil {
+			if value, _, found := resolver.Resolve(name); found {
+				return value, true
+			}
+		}
+
+		return os.LookupEnv(name)
 	}
 }
 
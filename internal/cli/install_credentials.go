@@ -21,7 +21,11 @@ type interactiveCredentialOptions struct {
 	output       io.Writer
 	openURL      func(string) error
 	secretReader func(fd int) ([]byte, error)
-	fileSource   credential.Source
+	store        credential.Source
+
+	// sources, when set, is filled with where each resolved value came
+	// from: the credential source name, "default", or "prompt".
+	sources map[string]string
 }
 
 func resolveServiceCredentials(
@@ -29,6 +33,11 @@ func resolveServiceCredentials(
 	resolver *credential.Resolver,
 	opts interactiveCredentialOptions,
 ) (map[string]string, error) {
+	svc, err := svc.ExpandEnvDefaults(serviceTemplateLookup(svc, nil, resolver))
+	if err != nil {
+		return nil, err
+	}
+
 	opts = normalizeInteractiveCredentialOptions(opts)
 	reader := bufio.NewReader(opts.input)
 	resolvedEnv := map[string]string{}
@@ -42,9 +51,10 @@ func resolveServiceCredentials(
 			continue
 		}
 
-		value, _, found := resolver.Resolve(envName)
+		value, source, found := resolver.Resolve(envName)
 		if found {
 			resolvedEnv[envName] = value
+			opts.recordSource(envName, source)
 			continue
 		}
 
@@ -52,6 +62,7 @@ func resolveServiceCredentials(
 			defaultValue := strings.TrimSpace(envVar.Default)
 			if defaultValue != "" {
 				resolvedEnv[envName] = defaultValue
+				opts.recordSource(envName, "default")
 			}
 			continue
 		}
@@ -61,10 +72,11 @@ func resolveServiceCredentials(
 		if opts.noPrompt {
 			if defaultValue != "" {
 				resolvedEnv[envName] = defaultValue
+				opts.recordSource(envName, "default")
 				continue
 			}
 
-			return nil, fmt.Errorf("required credential %q not found and prompting is disabled", envName)
+			return nil, fmt.Errorf("required credential %q not found and prompting is disabled; set %s in the environment", envName, envName)
 		}
 
 		if !headerPrinted {
@@ -81,11 +93,18 @@ func resolveServiceCredentials(
 		}
 
 		resolvedEnv[envName] = credentialValue
+		opts.recordSource(envName, "prompt")
 	}
 
 	return resolvedEnv, nil
 }
 
+func (opts interactiveCredentialOptions) recordSource(envName string, source string) {
+	if opts.sources != nil {
+		opts.sources[envName] = source
+	}
+}
+
 func normalizeInteractiveCredentialOptions(opts interactiveCredentialOptions) interactiveCredentialOptions {
 	if opts.input == nil {
 		opts.input = os.Stdin
@@ -167,18 +186,22 @@ func promptForCredentialValue(
 			continue
 		}
 
-		if opts.fileSource != nil {
+		if opts.store != nil {
 			shouldStore, err := askYesNo(reader, opts.output, "\n  Save to credential store? [Y/n]: ", true)
 			if err != nil {
 				return "", fmt.Errorf("read storage confirmation: %w", err)
 			}
 
 			if shouldStore {
-				if err := opts.fileSource.Store(envName, value); err != nil {
+				err := opts.store.Store(envName, value)
+				switch {
+				case errors.Is(err, credential.ErrPlaintextDenied):
+					fmt.Fprintf(opts.output, "  Not saved: %v.\n", err)
+				case err != nil:
 					return "", fmt.Errorf("store credential %q: %w", envName, err)
+				default:
+					fmt.Fprintln(opts.output, "  Saved.")
 				}
-
-				fmt.Fprintln(opts.output, "  Saved.")
 			}
 		}
 
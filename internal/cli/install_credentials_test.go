@@ -93,9 +93,9 @@ func TestResolveServiceCredentialsPromptsAndStoresValue(t *testing.T) {
 	var output bytes.Buffer
 
 	resolved, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{
-		input:      input,
-		output:     &output,
-		fileSource: store,
+		input:  input,
+		output: &output,
+		store:  store,
 	})
 	if err != nil {
 		t.Fatalf("expected prompt flow to succeed: %v", err)
@@ -165,10 +165,10 @@ func TestResolveServiceCredentialsOpensSetupURLWhenChosen(t *testing.T) {
 
 	var output bytes.Buffer
 	resolved, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{
-		input:      strings.NewReader("y\ntoken-value\nn\n"),
-		output:     &output,
-		openURL:    openURL,
-		fileSource: &fakeCredentialSource{name: "file"},
+		input:   strings.NewReader("y\ntoken-value\nn\n"),
+		output:  &output,
+		openURL: openURL,
+		store:   &fakeCredentialSource{name: "file"},
 	})
 	if err != nil {
 		t.Fatalf("expected setup URL flow to succeed: %v", err)
@@ -215,9 +215,9 @@ func TestResolveServiceCredentialsReturnsStoreError(t *testing.T) {
 	}
 
 	_, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{
-		input:      strings.NewReader("token-value\ny\n"),
-		output:     &bytes.Buffer{},
-		fileSource: store,
+		input:  strings.NewReader("token-value\ny\n"),
+		output: &bytes.Buffer{},
+		store:  store,
 	})
 	if err == nil {
 		t.Fatal("expected store error to be returned")
@@ -240,9 +240,9 @@ func TestResolveServiceCredentialsReturnsOpenURLError(t *testing.T) {
 	}
 
 	_, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{
-		input:      strings.NewReader("y\n"),
-		output:     &bytes.Buffer{},
-		fileSource: &fakeCredentialSource{name: "file"},
+		input:  strings.NewReader("y\n"),
+		output: &bytes.Buffer{},
+		store:  &fakeCredentialSource{name: "file"},
 		openURL: func(string) error {
 			return openErr
 		},
@@ -268,9 +268,9 @@ func TestPromptCredentialDefaultAccepted(t *testing.T) {
 
 	var output bytes.Buffer
 	resolved, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{
-		input:      strings.NewReader("\nn\n"),
-		output:     &output,
-		fileSource: &fakeCredentialSource{name: "file"},
+		input:  strings.NewReader("\nn\n"),
+		output: &output,
+		store:  &fakeCredentialSource{name: "file"},
 	})
 	if err != nil {
 		t.Fatalf("expected default flow to succeed: %v", err)
@@ -298,9 +298,9 @@ func TestPromptCredentialDefaultOverridden(t *testing.T) {
 
 	var output bytes.Buffer
 	resolved, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{
-		input:      strings.NewReader("custom-tenant\nn\n"),
-		output:     &output,
-		fileSource: &fakeCredentialSource{name: "file"},
+		input:  strings.NewReader("custom-tenant\nn\n"),
+		output: &output,
+		store:  &fakeCredentialSource{name: "file"},
 	})
 	if err != nil {
 		t.Fatalf("expected override flow to succeed: %v", err)
@@ -447,3 +447,29 @@ func TestBrowserOpenCommandUnsupportedOS(t *testing.T) {
 		t.Fatal("expected unsupported operating system error")
 	}
 }
+
+func TestResolveServiceCredentialsExpandsDefaultTemplates(t *testing.T) {
+	t.Setenv("MCP_WIRE_TEST_HOME", "/home/demo")
+	resolver := credential.NewResolver(credential.NewEnvSource())
+
+	svc := service.Service{
+		Name: "demo-service",
+		Env: []service.EnvVar{
+			{Name: "NOTES_DIR", Default: "${MCP_WIRE_TEST_HOME}/notes"},
+		},
+	}
+
+	resolved, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{noPrompt: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resolved["NOTES_DIR"] != "/home/demo/notes" {
+		t.Fatalf("expected expanded default, got %q", resolved["NOTES_DIR"])
+	}
+
+	svc.Env[0].Default = "${MCP_WIRE_TEST_UNSET}/notes"
+	if _, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{noPrompt: true}); err == nil || !strings.Contains(err.Error(), "default of NOTES_DIR: ${MCP_WIRE_TEST_UNSET} is not set") {
+		t.Fatalf("expected an unresolved default error, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/manifest"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+// installFromFlagConflicts are the install flags a file sets per service
+// instead.
+var installFromFlagConflicts = []string{"target", "scope", "allow-system", "set-raw", "supervised", "dry-run", "codex-profile"}
+
+// bulkInstall is one service of an install file, resolved to its
+// definition and targets.
+type bulkInstall struct {
+	service service.Service
+	targets []target.Target
+	scope   target.ConfigScope
+	env     map[string]string
+}
+
+// bulkInstallResult is the outcome of installing one service of an install
+// file.
+type bulkInstallResult struct {
+	service string
+	targets []string
+	scope   target.ConfigScope
+	status  string
+	err     error
+}
+
+// runInstallFromFile installs every service listed in the file at path,
+// which uses the manifest format, one after the other, and prints a summary
+// row per service. Every service and target is resolved, and every install
+// checked against the policy, before anything is written; after that a
+// failed service does not stop the ones listed after it.
+func runInstallFromFile(cmd *cobra.Command, path string, noPrompt bool, force bool) error {
+	m, err := manifest.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if m.Prune {
+		return fmt.Errorf("install file %q sets prune, which only applies to sync", path)
+	}
+
+	location, err := resolveProjectLocation(flagString(cmd, "project-dir"), flagString(cmd, "project-root"))
+	if err != nil {
+		return err
+	}
+
+	installs := make([]bulkInstall, 0, len(m.Services))
+	for _, entry := range m.Services {
+		svc, err := resolveServiceByName(entry.Name)
+		if err != nil {
+			return err
+		}
+
+		targetDefinitions, err := resolveInstallTargets(entry.Targets)
+		if err != nil {
+			return fmt.Errorf("service %q: %w", entry.Name, err)
+		}
+
+		pinProjectDir(targetDefinitions, location)
+		installs = append(installs, bulkInstall{
+			service: svc,
+			targets: targetDefinitions,
+			scope:   target.ConfigScope(entry.Scope),
+			env:     entry.Env,
+		})
+	}
+
+	var denials []error
+	for _, install := range installs {
+		if err := checkPolicy("install", install.service, install.targets, install.scope); err != nil {
+			denials = append(denials, err)
+		}
+	}
+
+	if len(denials) > 0 {
+		return errors.Join(denials...)
+	}
+
+	output := cmd.OutOrStdout()
+	reader := bufio.NewReader(cmd.InOrStdin())
+	interactive := !noPrompt && isTerminalReader(cmd.InOrStdin())
+
+	results := make([]bulkInstallResult, 0, len(installs))
+	for i, install := range installs {
+		fmt.Fprintf(output, "\n[%d/%d] %s\n", i+1, len(installs), install.service.Name)
+
+		result := bulkInstallResult{service: install.service.Name, scope: install.scope, status: "installed"}
+		for _, targetDefinition := range install.targets {
+			result.targets = append(result.targets, targetDefinition.Slug())
+		}
+
+		proceed, err := confirmProjectScope(output, reader, interactive, install.targets, install.scope, location, force)
+		if err == nil && proceed {
+			proceed, err = checkServiceConflicts(cmd, install.service, install.targets, noPrompt, install.scope)
+		}
+		if err == nil && proceed {
+			err = installServiceDependencies(cmd, install.service, install.targets, noPrompt, install.scope)
+		}
+		if err == nil && proceed {
+			err = executeInstallWithEnv(cmd, install.service, install.targets, noPrompt, install.scope, path, install.env)
+		}
+
+		switch {
+		case err != nil:
+			result.status = "failed"
+			result.err = err
+		case !proceed:
+			result.status = "skipped"
+		}
+
+		results = append(results, result)
+	}
+
+	failed := writeBulkInstallResults(output, results)
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d services failed to install", failed, len(results))
+	}
+
+	return nil
+}
+
+// writeBulkInstallResults prints one row per service and a summary, and
+// returns how many services failed.
+func writeBulkInstallResults(output io.Writer, results []bulkInstallResult) int {
+	serviceWidth := len("SERVICE")
+	scopeWidth := len("SCOPE")
+	targetsWidth := len("TARGETS")
+	for _, result := range results {
+		serviceWidth = max(serviceWidth, len(result.service))
+		scopeWidth = max(scopeWidth, len(result.scope))
+		targetsWidth = max(targetsWidth, len(strings.Join(result.targets, ",")))
+	}
+
+	fmt.Fprintln(output)
+	fmt.Fprintf(output, "%-*s  %-*s  %-*s  %s\n", serviceWidth, "SERVICE", scopeWidth, "SCOPE", targetsWidth, "TARGETS", "STATUS")
+
+	counts := make(map[string]int)
+	for _, result := range results {
+		counts[result.status]++
+
+		status := result.status
+		if result.err != nil {
+			status = "failed: " + result.err.Error()
+		}
+
+		fmt.Fprintf(output, "%-*s  %-*s  %-*s  %s\n", serviceWidth, result.service, scopeWidth, result.scope, targetsWidth, strings.Join(result.targets, ","), status)
+	}
+
+	fmt.Fprintf(output, "\n%d installed, %d skipped, %d failed\n", counts["installed"], counts["skipped"], counts["failed"])
+
+	return counts["failed"]
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func overrideInstallFromDependencies(t *testing.T, targets ...targetpkg.Target) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	overrideProjectScopeDependencies(t, dir, targets...)
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		for _, candidate := range targets {
+			if candidate.Slug() == slug {
+				return candidate, true
+			}
+		}
+
+		return nil, false
+	}
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo-service": {Name: "demo-service", Transport: "sse", URL: "https://example.com/mcp"},
+			"team-service": {
+				Name:      "team-service",
+				Transport: "sse",
+				URL:       "https://example.com/mcp?team={TEAM}",
+				Env:       []service.EnvVar{{Name: "TEAM", Required: true}},
+			},
+		}, nil
+	}
+
+	return dir
+}
+
+func TestInstallFromFileInstallsEveryService(t *testing.T) {
+	alpha := newFakeManifestTarget("alpha-cli")
+	beta := newFakeManifestTarget("beta-cli")
+	dir := overrideInstallFromDependencies(t, alpha, beta)
+
+	path := filepath.Join(dir, "setup.yaml")
+	if err := writeTempFile(path, "services:\n  - demo-service\n  - name: team-service\n    targets: [beta-cli]\n    env:\n      TEAM: platform\n"); err != nil {
+		t.Fatalf("write install file: %v", err)
+	}
+
+	output, err := executeInstallCommand(t, "--from", path, "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected install to succeed: %v\n%s", err, output)
+	}
+
+	if alpha.configured[targetpkg.ConfigScopeUser]["demo-service"] == "" || beta.configured[targetpkg.ConfigScopeUser]["demo-service"] == "" {
+		t.Fatalf("expected demo-service on every target, got alpha %v and beta %v", alpha.configured, beta.configured)
+	}
+
+	if got := beta.configured[targetpkg.ConfigScopeUser]["team-service"]; got != "https://example.com/mcp?team=platform" {
+		t.Fatalf("expected the env override in the URL, got %q", got)
+	}
+
+	if _, ok := alpha.configured[targetpkg.ConfigScopeUser]["team-service"]; ok {
+		t.Fatal("expected team-service only on the targets the file lists")
+	}
+
+	for _, line := range []string{
+		"[1/2] demo-service\n",
+		"SERVICE       SCOPE  TARGETS             STATUS\n",
+		"demo-service  user   alpha-cli,beta-cli  installed\n",
+		"team-service  user   beta-cli            installed\n",
+		"2 installed, 0 skipped, 0 failed\n",
+	} {
+		if !strings.Contains(output, line) {
+			t.Fatalf("expected %q in output, got %q", line, output)
+		}
+	}
+}
+
+func TestInstallFromFileContinuesAfterAFailedService(t *testing.T) {
+	alpha := newFakeManifestTarget("alpha-cli")
+	dir := overrideInstallFromDependencies(t, alpha)
+
+	path := filepath.Join(dir, "setup.yaml")
+	if err := writeTempFile(path, "services: [team-service, demo-service]\n"); err != nil {
+		t.Fatalf("write install file: %v", err)
+	}
+
+	output, err := executeInstallCommand(t, "--from", path, "--no-prompt")
+	if err == nil || err.Error() != "1 of 2 services failed to install" {
+		t.Fatalf("expected one failed service, got %v", err)
+	}
+
+	if alpha.configured[targetpkg.ConfigScopeUser]["demo-service"] == "" {
+		t.Fatal("expected the service after the failed one to be installed")
+	}
+
+	if !strings.Contains(output, `team-service  user   alpha-cli  failed: required credential "TEAM" not found`) {
+		t.Fatalf("expected the failure in the summary, got %q", output)
+	}
+}
+
+func TestInstallFromFileResolvesEveryServiceFirst(t *testing.T) {
+	alpha := newFakeManifestTarget("alpha-cli")
+	dir := overrideInstallFromDependencies(t, alpha)
+
+	path := filepath.Join(dir, "setup.yaml")
+	if err := writeTempFile(path, "services:\n  - demo-service\n  - name: team-service\n    targets: [missing-cli]\n"); err != nil {
+		t.Fatalf("write install file: %v", err)
+	}
+
+	if _, err := executeInstallCommand(t, "--from", path, "--no-prompt"); err == nil || !strings.Contains(err.Error(), "missing-cli") {
+		t.Fatalf("expected the unknown target to be reported, got %v", err)
+	}
+
+	if alpha.writes != 0 {
+		t.Fatalf("expected nothing installed, got %d writes", alpha.writes)
+	}
+}
+
+func TestInstallFromFileRejectsConflictingArguments(t *testing.T) {
+	overrideInstallFromDependencies(t)
+
+	for _, args := range [][]string{
+		{"demo-service", "--from", "setup.yaml"},
+		{"--from", "setup.yaml", "--target", "claude"},
+		{"--from", "setup.yaml", "--scope", "project"},
+	} {
+		if _, err := executeInstallCommand(t, args...); err == nil || !strings.Contains(err.Error(), "--from") {
+			t.Fatalf("expected %v to be rejected, got %v", args, err)
+		}
+	}
+}
@@ -5,13 +5,17 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/andreagrandi/mcp-wire/internal/config"
 	"github.com/andreagrandi/mcp-wire/internal/credential"
 	"github.com/andreagrandi/mcp-wire/internal/registry"
 	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/state"
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
 	"github.com/spf13/cobra"
 )
@@ -162,6 +166,59 @@ func TestInstallCommandInstallsToAllInstalledTargetsByDefault(t *testing.T) {
 	}
 }
 
+// slowInstallTarget records how many installs run at the same time.
+type slowInstallTarget struct {
+	*fakeInstallTarget
+	running *atomic.Int32
+	peak    *atomic.Int32
+}
+
+func (t *slowInstallTarget) Install(svc service.Service, resolvedEnv map[string]string) error {
+	running := t.running.Add(1)
+	defer t.running.Add(-1)
+
+	for {
+		peak := t.peak.Load()
+		if running <= peak || t.peak.CompareAndSwap(peak, running) {
+			break
+		}
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	return t.fakeInstallTarget.Install(svc, resolvedEnv)
+}
+
+func TestInstallOnTargetsRunsBoundedInParallel(t *testing.T) {
+	var running, peak atomic.Int32
+	var targets []targetpkg.Target
+	for _, slug := range []string{"a", "b", "c", "d", "e", "f"} {
+		targets = append(targets, &slowInstallTarget{
+			fakeInstallTarget: &fakeInstallTarget{name: slug, slug: slug, installed: true},
+			running:           &running,
+			peak:              &peak,
+		})
+	}
+
+	results := installOnTargets(targets, service.Service{Name: "demo-service"}, nil, targetpkg.ConfigScopeEffective)
+	if len(results) != len(targets) {
+		t.Fatalf("expected a result for every target, got %d", len(results))
+	}
+
+	for index, result := range results {
+		if result.index != index {
+			t.Fatalf("expected results in target order, got target %d at %d", result.index, index)
+		}
+
+		if result.err != nil {
+			t.Errorf("unexpected error for %s: %v", targets[index].Slug(), result.err)
+		}
+	}
+
+	if got := peak.Load(); got < 2 || got > maxParallelInstalls {
+		t.Fatalf("expected between 2 and %d installs at once, got %d", maxParallelInstalls, got)
+	}
+}
+
 func TestInstallCommandUsesSelectedTargets(t *testing.T) {
 	restore := overrideInstallCommandDependencies(t)
 	defer restore()
@@ -356,7 +413,7 @@ func TestInstallCommandFallsBackToRegistryForUnknownCuratedService(t *testing.T)
 		}
 	}
 
-	fetchServerLatest = func(name string) (*registry.ServerResponse, error) {
+	fetchServerLatest = func(_ string, name string) (*registry.ServerResponse, error) {
 		if name != "my-npm-server" {
 			return nil, errors.New("not found")
 		}
@@ -415,6 +472,61 @@ func TestInstallCommandFallsBackToRegistryForUnknownCuratedService(t *testing.T)
 	}
 }
 
+func TestInstallCommandPinsRegistryVersion(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	installTarget := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha", installed: true}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{installTarget} }
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{"curated": {Name: "curated", Transport: "stdio", Command: "curated"}}, nil
+	}
+
+	cfgPath := t.TempDir() + "/config.json"
+	if err := writeTempFile(cfgPath, `{"features":{"registry":true}}`); err != nil {
+		t.Fatalf("failed to write temp config: %v", err)
+	}
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(cfgPath) }
+
+	npmServer := func(version string) registry.ServerResponse {
+		return registry.ServerResponse{Server: registry.ServerJSON{
+			Name:     "io.github.acme/mcp",
+			Version:  version,
+			Packages: []registry.Package{{RegistryType: "npm", Identifier: "@acme/mcp", Version: version}},
+		}}
+	}
+	loadRegistryCache = func() []registry.ServerResponse { return []registry.ServerResponse{npmServer("2.0.0")} }
+	fetchServerLatest = func(string, string) (*registry.ServerResponse, error) {
+		t.Fatal("expected the pinned version, not the latest, to be fetched")
+		return nil, nil
+	}
+	fetchServerVersion = func(_ string, name string, version string) (*registry.ServerResponse, error) {
+		if name != "io.github.acme/mcp" || version != "1.2.3" {
+			return nil, errors.New("unexpected " + name + "@" + version)
+		}
+
+		resp := npmServer(version)
+		return &resp, nil
+	}
+
+	if _, err := executeInstallCommand(t, "io.github.acme/mcp@1.2.3", "--no-prompt"); err != nil {
+		t.Fatalf("expected pinned install to succeed: %v", err)
+	}
+
+	if got := strings.Join(installTarget.lastService.Args, " "); !strings.Contains(got, "@acme/mcp@1.2.3") {
+		t.Fatalf("expected the pinned package version, got args %q", got)
+	}
+
+	records, err := newProvenanceStore().ForService("io.github.acme/mcp")
+	if err != nil || len(records) != 1 || records[0].Pinned != "1.2.3" {
+		t.Fatalf("expected a record pinned to 1.2.3, got %+v (%v)", records, err)
+	}
+
+	if _, err := executeInstallCommand(t, "curated@1.0.0", "--no-prompt"); err == nil || !strings.Contains(err.Error(), "only registry services can be pinned") {
+		t.Fatalf("expected pinning a curated service to fail, got %v", err)
+	}
+}
+
 func TestInstallCommandReturnsErrorWhenRequiredCredentialIsMissingWithNoPrompt(t *testing.T) {
 	restore := overrideInstallCommandDependencies(t)
 	defer restore()
@@ -525,6 +637,15 @@ func TestInstallCommandPromptsForServiceWhenArgMissing(t *testing.T) {
 	if !strings.Contains(output, "Equivalent command:") || !strings.Contains(output, "mcp-wire install demo-service --target alpha") {
 		t.Fatalf("expected equivalent command output, got %q", output)
 	}
+
+	previous := -1
+	for _, heading := range []string{"Step 1/4: Service", "Step 2/4: Targets", "Step 3/4: Review", "Step 4/4: Apply"} {
+		index := strings.Index(output, heading)
+		if index <= previous {
+			t.Fatalf("expected %q after the steps before it, got %q", heading, output)
+		}
+		previous = index
+	}
 }
 
 func TestInstallCommandDoesNotPrintNativeOAuthHintForSentry(t *testing.T) {
@@ -629,7 +750,7 @@ func TestInstallCommandPrintsClaudeOAuthHintWhenAutoAuthIsUnsupported(t *testing
 		t.Fatalf("expected jira install to succeed: %v", err)
 	}
 
-	if !strings.Contains(output, "[!] Next step: In Claude Code, run /mcp to complete OAuth authentication.") {
+	if !strings.Contains(output, "[!] [MW103] Next step: In Claude Code, run /mcp to complete OAuth authentication.") {
 		t.Fatalf("expected Claude OAuth guidance in output, got %q", output)
 	}
 
@@ -638,6 +759,48 @@ func TestInstallCommandPrintsClaudeOAuthHintWhenAutoAuthIsUnsupported(t *testing
 	}
 }
 
+func TestInstallCommandHidesSuppressedWarnings(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"suppress_warnings":["mw103"]}`), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+
+	claudeTarget := &fakeInstallTarget{name: "Claude Code", slug: "claude", installed: true}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"jira": {Name: "jira", Transport: "sse", Auth: "oauth", URL: "https://mcp.atlassian.com/v1/mcp"},
+		}, nil
+	}
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		if slug == "claude" {
+			return claudeTarget, true
+		}
+
+		return nil, false
+	}
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{values: map[string]string{}} }
+	newCredentialFileSource = func(string) credential.Source { return &testCredentialSource{values: map[string]string{}} }
+	shouldAutoAuthenticate = func(*cobra.Command) bool { return true }
+
+	output, err := executeInstallCommand(t, "jira", "--target", "claude", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected jira install to succeed: %v", err)
+	}
+
+	if strings.Contains(output, "MW103") || strings.Contains(output, "Next step") {
+		t.Fatalf("expected suppressed OAuth hint to be hidden, got %q", output)
+	}
+
+	if !strings.Contains(output, "Claude Code: configured") {
+		t.Fatalf("expected install outcome to still be printed, got %q", output)
+	}
+}
+
 func TestInstallCommandKeepsGenericOAuthHintForOtherUnsupportedTargets(t *testing.T) {
 	restore := overrideInstallCommandDependencies(t)
 	defer restore()
@@ -922,7 +1085,9 @@ func TestApplyRegistrySubstitutionsURL(t *testing.T) {
 		"API_TOKEN": "secret",
 	}
 
-	applyRegistrySubstitutions(&svc, resolvedEnv)
+	if err := applyRegistrySubstitutions(&svc, resolvedEnv, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	if svc.URL != "https://acme.example.com/mcp" {
 		t.Fatalf("expected URL substitution, got %q", svc.URL)
@@ -942,7 +1107,9 @@ func TestApplyRegistrySubstitutionsHeaders(t *testing.T) {
 		"api_key": "my-secret-key",
 	}
 
-	applyRegistrySubstitutions(&svc, resolvedEnv)
+	if err := applyRegistrySubstitutions(&svc, resolvedEnv, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	if svc.Headers["Authorization"] != "Bearer my-secret-key" {
 		t.Fatalf("expected header substitution, got %q", svc.Headers["Authorization"])
@@ -967,7 +1134,9 @@ func TestApplyRegistrySubstitutionsPreservesResolvedEnv(t *testing.T) {
 		"EXTRA":  "keep-me",
 	}
 
-	applyRegistrySubstitutions(&svc, resolvedEnv)
+	if err := applyRegistrySubstitutions(&svc, resolvedEnv, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	if len(resolvedEnv) != 3 {
 		t.Fatalf("expected all 3 env vars preserved, got %d: %v", len(resolvedEnv), resolvedEnv)
@@ -996,12 +1165,25 @@ func overrideInstallCommandDependencies(t *testing.T) func() {
 	originalLoadConfig := loadConfig
 	originalLoadRegistryCache := loadRegistryCache
 	originalFetchServerLatest := fetchServerLatest
+	originalFetchServerVersion := fetchServerVersion
+	originalNewRecentStore := newRecentStore
+	originalNewProvenanceStore := newProvenanceStore
 
 	configPath := t.TempDir() + "/config.json"
 	loadConfig = func() (*config.Config, error) {
 		return config.LoadFrom(configPath)
 	}
 
+	recentPath := t.TempDir() + "/recent.json"
+	newRecentStore = func() *state.RecentStore {
+		return state.NewRecentStore(recentPath)
+	}
+
+	provenancePath := t.TempDir() + "/provenance.json"
+	newProvenanceStore = func() *state.ProvenanceStore {
+		return state.NewProvenanceStore(provenancePath)
+	}
+
 	return func() {
 		loadServices = originalLoadServices
 		listInstalledTargets = originalListInstalledTargets
@@ -1014,6 +1196,9 @@ func overrideInstallCommandDependencies(t *testing.T) func() {
 		loadConfig = originalLoadConfig
 		loadRegistryCache = originalLoadRegistryCache
 		fetchServerLatest = originalFetchServerLatest
+		fetchServerVersion = originalFetchServerVersion
+		newRecentStore = originalNewRecentStore
+		newProvenanceStore = originalNewProvenanceStore
 	}
 }
 
@@ -1050,3 +1235,143 @@ func executeInstallCommandWithInput(t *testing.T, input string, args ...string)
 func writeTempFile(path, content string) error {
 	return os.WriteFile(path, []byte(content), 0o644)
 }
+
+func TestInstallCommandRecordsRecentService(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	alpha := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo": {Name: "demo", Transport: "stdio", Command: "npx"},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{alpha} }
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{} }
+	newCredentialFileSource = func(_ string) credential.Source { return &testCredentialSource{} }
+	shouldAutoAuthenticate = func(_ *cobra.Command) bool { return false }
+
+	if _, err := executeRootCommand(t, "install", "demo", "--no-prompt"); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	names := recentServiceNames()
+	if len(names) != 1 || names[0] != "demo" {
+		t.Fatalf("expected demo in recent services, got %v", names)
+	}
+}
+
+func TestInstallCommandDoesNotRecordRecentServiceWhenAllTargetsFail(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	alpha := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true, installErr: errors.New("boom")}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo": {Name: "demo", Transport: "stdio", Command: "npx"},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{alpha} }
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{} }
+	newCredentialFileSource = func(_ string) credential.Source { return &testCredentialSource{} }
+	shouldAutoAuthenticate = func(_ *cobra.Command) bool { return false }
+
+	if _, err := executeRootCommand(t, "install", "demo", "--no-prompt"); err == nil {
+		t.Fatal("expected install to fail")
+	}
+
+	if names := recentServiceNames(); len(names) != 0 {
+		t.Fatalf("expected no recent services, got %v", names)
+	}
+}
+
+func TestApplyRegistrySubstitutionsExpandsEnvironmentPlaceholders(t *testing.T) {
+	t.Setenv("MCP_WIRE_TEST_HOST", "api.example.com")
+	resolver := credential.NewResolver(&fakeCredentialSource{name: "keychain", values: map[string]string{"WORKSPACE_ID": "ws-1"}})
+
+	svc := service.Service{
+		URL:     "https://${MCP_WIRE_TEST_HOST}/mcp/${WORKSPACE_ID}",
+		Args:    []string{"--port", "{PORT}", "--log", "${LOG_LEVEL:-info}"},
+		Ports:   []string{"PORT"},
+		Headers: map[string]string{"Authorization": "Bearer ${API_TOKEN}"},
+	}
+
+	if err := applyRegistrySubstitutions(&svc, map[string]string{"API_TOKEN": "secret", "PORT": "8080"}, resolver); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if svc.URL != "https://api.example.com/mcp/ws-1" {
+		t.Fatalf("expected URL from the environment and credential sources, got %q", svc.URL)
+	}
+
+	if strings.Join(svc.Args, " ") != "--port 8080 --log info" {
+		t.Fatalf("expected expanded args, got %q", svc.Args)
+	}
+
+	if svc.Headers["Authorization"] != "Bearer secret" {
+		t.Fatalf("expected header from the resolved credentials, got %q", svc.Headers["Authorization"])
+	}
+}
+
+func TestApplyRegistrySubstitutionsReportsUnresolvedPlaceholder(t *testing.T) {
+	t.Setenv("PORT", "9999")
+
+	svc := service.Service{
+		Name:  "demo",
+		URL:   "http://localhost:{PORT}/${MCP_WIRE_TEST_UNSET}",
+		Ports: []string{"PORT"},
+	}
+
+	err := applyRegistrySubstitutions(&svc, map[string]string{}, nil)
+	if err == nil {
+		t.Fatal("expected an error for an unresolved placeholder")
+	}
+
+	want := `service "demo" url: ${MCP_WIRE_TEST_UNSET} is not set; set MCP_WIRE_TEST_UNSET in the environment or a credential source`
+	if err.Error() != want {
+		t.Fatalf("expected %q, got %q", want, err.Error())
+	}
+
+	svc.URL = "http://localhost:{PORT}/mcp"
+	if err := applyRegistrySubstitutions(&svc, map[string]string{}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if svc.URL != "http://localhost:{PORT}/mcp" {
+		t.Fatalf("expected an unallocated port slot to be kept, got %q", svc.URL)
+	}
+}
+
+func TestInstallCommandWritesIntoCodexProfile(t *testing.T) {
+	codexDir := t.TempDir()
+	configPath := filepath.Join(codexDir, "config.toml")
+	codexTarget := targetpkg.NewCodexTarget()
+	codexTarget.MarkInstalled()
+	codex := codexTarget.Sandbox(codexDir)
+	alpha := newFakeManifestTarget("alpha-cli")
+	overrideInstallFromDependencies(t, codex, alpha)
+
+	if err := writeTempFile(configPath, "[profiles.work]\nmodel = \"o3\"\n"); err != nil {
+		t.Fatalf("write codex config: %v", err)
+	}
+
+	if _, err := executeInstallCommand(t, "demo-service", "--target", "alpha-cli", "--codex-profile", "work", "--no-prompt"); err == nil || err.Error() != "--codex-profile requires the codex target" {
+		t.Fatalf("expected the profile to require codex, got %v", err)
+	}
+
+	output, err := executeInstallCommand(t, "demo-service", "--target", "codex", "--codex-profile", "work", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected install to succeed: %v\n%s", err, output)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read codex config: %v", err)
+	}
+
+	if !strings.Contains(string(data), "[profiles.work.mcp_servers.demo-service]") || strings.Contains(string(data), "\n[mcp_servers") {
+		t.Fatalf("expected demo-service only in the work profile, got:\n%s", data)
+	}
+}
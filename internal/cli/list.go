@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	registerCommand(newListCmd)
+}
+
+func newListCmd() *cobra.Command {
+	var targetSlugs []string
+	var installed bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List available services, or what is installed where",
+		Long: `list prints the services mcp-wire can install, with their descriptions.
+
+With --installed it reads every target's config instead and prints a matrix
+of the services configured on each, with the scopes they are configured in.
+An entry mcp-wire installed is marked with *; the others were added by hand
+or by another tool. Project scope is read for the current project (see
+--project-dir), and a project entry counts as installed by mcp-wire when a
+project install of it on that target was recorded anywhere, since the
+records do not say which project it went to.
+
+This command is read-only.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonOutput, err := jsonOutputRequested(cmd)
+			if err != nil {
+				return err
+			}
+
+			if !installed {
+				if len(targetSlugs) > 0 {
+					return errors.New("--target requires --installed")
+				}
+
+				return writeServiceList(cmd.OutOrStdout())
+			}
+
+			targetDefinitions, err := resolveInstallTargets(targetSlugs)
+			if err != nil {
+				return err
+			}
+
+			if _, err := applyProjectDir(cmd, targetDefinitions); err != nil {
+				return err
+			}
+
+			listing, err := buildInstalledListing(targetDefinitions)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), listing)
+			}
+
+			writeInstalledListing(cmd.OutOrStdout(), listing)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&installed, "installed", false, "List the services configured on each target instead of the available ones")
+	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Only look at specific target slug(s) or @group(s); can be repeated")
+	addProjectDirFlags(cmd)
+
+	return cmd
+}
+
+func writeServiceList(output io.Writer) error {
+	services, err := loadServices()
+	if err != nil {
+		return fmt.Errorf("load services: %w", err)
+	}
+
+	names := make([]string, 0, len(services))
+	nameWidth := 0
+	for name := range services {
+		names = append(names, name)
+		nameWidth = max(nameWidth, len(name))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		description := strings.TrimSpace(services[name].Description)
+		if description == "" {
+			fmt.Fprintln(output, name)
+			continue
+		}
+
+		fmt.Fprintf(output, "%-*s  %s\n", nameWidth, name, description)
+	}
+
+	return nil
+}
+
+// installedListing is the result of `list --installed`, also its JSON
+// output.
+type installedListing struct {
+	Targets []installedListTarget `json:"targets"`
+	Entries []installedEntry      `json:"entries"`
+}
+
+// installedListTarget is one target the listing read, or why its config
+// could not be read.
+type installedListTarget struct {
+	Target string `json:"target"`
+	Name   string `json:"name"`
+	Error  string `json:"error,omitempty"`
+}
+
+// installedEntry is one service configured on one target in one scope.
+type installedEntry struct {
+	Service string `json:"service"`
+	Target  string `json:"target"`
+	Scope   string `json:"scope"`
+	Managed bool   `json:"managed"`
+}
+
+// buildInstalledListing lists the services of every scope of each target,
+// marking the ones the install records say mcp-wire wrote.
+func buildInstalledListing(targetDefinitions []targetpkg.Target) (installedListing, error) {
+	records, err := newProvenanceStore().All()
+	if err != nil {
+		return installedListing{}, err
+	}
+
+	listing := installedListing{
+		Targets: make([]installedListTarget, 0, len(targetDefinitions)),
+		Entries: make([]installedEntry, 0),
+	}
+
+	for _, t := range targetDefinitions {
+		listTarget := installedListTarget{Target: t.Slug(), Name: t.Name()}
+
+		var entries []installedEntry
+		for _, scope := range listScopes(t) {
+			names, err := tuiListInstalledServices(t, scope)
+			if err != nil {
+				listTarget.Error = err.Error()
+				entries = nil
+				break
+			}
+
+			for _, name := range names {
+				entries = append(entries, installedEntry{
+					Service: name,
+					Target:  t.Slug(),
+					Scope:   string(scope),
+					Managed: recordedInstallOf(records, name, t.Slug(), scope),
+				})
+			}
+		}
+
+		listing.Targets = append(listing.Targets, listTarget)
+		listing.Entries = append(listing.Entries, entries...)
+	}
+
+	sort.SliceStable(listing.Entries, func(i, j int) bool {
+		return listing.Entries[i].Service < listing.Entries[j].Service
+	})
+
+	return listing, nil
+}
+
+// listScopes returns the scopes of t that list reads: every concrete scope,
+// project included.
+func listScopes(t targetpkg.Target) []targetpkg.ConfigScope {
+	scopedTarget, ok := t.(targetpkg.ScopedTarget)
+	if !ok {
+		return []targetpkg.ConfigScope{targetpkg.ConfigScopeUser}
+	}
+
+	var scopes []targetpkg.ConfigScope
+	for _, scope := range scopedTarget.SupportedScopes() {
+		if scope != targetpkg.ConfigScopeEffective {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+func recordedInstallOf(records []state.Provenance, serviceName string, targetSlug string, scope targetpkg.ConfigScope) bool {
+	for _, record := range records {
+		if strings.EqualFold(record.Service, serviceName) && strings.EqualFold(record.Target, targetSlug) && record.Scope == string(scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeInstalledListing prints one row per service and one column per
+// target. A cell lists the scopes the service is configured in, each marked
+// with * when mcp-wire installed it.
+func writeInstalledListing(output io.Writer, listing installedListing) {
+	var services []string
+	cells := map[string]map[string][]string{}
+	external := false
+	for _, entry := range listing.Entries {
+		if cells[entry.Service] == nil {
+			cells[entry.Service] = map[string][]string{}
+			services = append(services, entry.Service)
+		}
+
+		label := entry.Scope
+		if entry.Managed {
+			label += "*"
+		} else {
+			external = true
+		}
+
+		cells[entry.Service][entry.Target] = append(cells[entry.Service][entry.Target], label)
+	}
+
+	if len(services) == 0 {
+		fmt.Fprintln(output, "No services configured on the selected targets.")
+		writeInstalledListingErrors(output, listing)
+		return
+	}
+
+	header := []string{"SERVICE"}
+	for _, t := range listing.Targets {
+		header = append(header, t.Target)
+	}
+
+	rows := [][]string{header}
+	for _, service := range services {
+		row := []string{service}
+		for _, t := range listing.Targets {
+			cell := strings.Join(cells[service][t.Target], ",")
+			switch {
+			case t.Error != "":
+				cell = "?"
+			case cell == "":
+				cell = "-"
+			}
+			row = append(row, cell)
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+
+	for _, row := range rows {
+		var line strings.Builder
+		for i, cell := range row {
+			if i == len(row)-1 {
+				line.WriteString(cell)
+				break
+			}
+			fmt.Fprintf(&line, "%-*s  ", widths[i], cell)
+		}
+		fmt.Fprintln(output, line.String())
+	}
+
+	fmt.Fprintln(output)
+	fmt.Fprint(output, "* installed by mcp-wire")
+	if external {
+		fmt.Fprint(output, "; entries without * were added outside mcp-wire")
+	}
+	fmt.Fprintln(output, ".")
+
+	writeInstalledListingErrors(output, listing)
+}
+
+func writeInstalledListingErrors(output io.Writer, listing installedListing) {
+	for _, t := range listing.Targets {
+		if t.Error != "" {
+			fmt.Fprintf(output, "%s (%s): cannot read config: %s\n", t.Name, t.Target, t.Error)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestListCommandPrintsAvailableServices(t *testing.T) {
+	overrideWhyDependencies(t)
+
+	output, _, err := executeCommandWithOutputFlags(t, newListCmd())
+	if err != nil {
+		t.Fatalf("expected list to succeed: %v", err)
+	}
+
+	if output != "demo\n" {
+		t.Fatalf("unexpected output %q", output)
+	}
+}
+
+func TestListInstalledPrintsServiceTargetMatrix(t *testing.T) {
+	alpha := newExplainingInstallTarget()
+	beta := &listingInstallTarget{fakeInstallTarget: &fakeInstallTarget{name: "Beta CLI", slug: "beta-cli", installed: true}}
+	overrideWhyDependencies(t, alpha, beta)
+
+	if _, err := executeRootCommand(t, "install", "demo", "--target", "alpha-cli", "--no-prompt"); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+	beta.services = []string{"demo", "manual"}
+
+	output, _, err := executeCommandWithOutputFlags(t, newListCmd(), "--installed")
+	if err != nil {
+		t.Fatalf("expected list to succeed: %v", err)
+	}
+
+	want := "SERVICE  alpha-cli  beta-cli\n" +
+		"demo     user*      user\n" +
+		"manual   -          user\n" +
+		"\n" +
+		"* installed by mcp-wire; entries without * were added outside mcp-wire.\n"
+	if output != want {
+		t.Fatalf("expected:\n%s\ngot:\n%s", want, output)
+	}
+
+	output, _, err = executeCommandWithOutputFlags(t, newListCmd(), "--installed", "--target", "alpha-cli", "--json")
+	if err != nil {
+		t.Fatalf("expected list --json to succeed: %v", err)
+	}
+
+	var listing installedListing
+	if err := json.Unmarshal([]byte(output), &listing); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, output)
+	}
+
+	if len(listing.Targets) != 1 || len(listing.Entries) != 1 || listing.Entries[0] != (installedEntry{Service: "demo", Target: "alpha-cli", Scope: "user", Managed: true}) {
+		t.Fatalf("unexpected listing %+v", listing)
+	}
+}
+
+func TestListTargetRequiresInstalled(t *testing.T) {
+	overrideWhyDependencies(t)
+
+	if _, _, err := executeCommandWithOutputFlags(t, newListCmd(), "--target", "claude"); err == nil || !strings.Contains(err.Error(), "--target requires --installed") {
+		t.Fatalf("expected a flag error, got %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+const logFollowInterval = 250 * time.Millisecond
+
+func init() {
+	registerCommand(newLogsCmd)
+}
+
+func newLogsCmd() *cobra.Command {
+	var follow bool
+	var lines int
+	var targetSlug string
+
+	cmd := &cobra.Command{
+		Use:   "logs [service]",
+		Short: "Show output captured from a supervised or running service",
+		Long: `logs prints the output mcp-wire captured from a service started with
+"mcp-wire run". Logs are rotated when they grow large; only the current file
+is shown.
+
+With --target, logs prints the MCP logs written by that target instead (for
+example Claude Code's per-server logs), narrowed to the given service.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serviceName := ""
+			if len(args) == 1 {
+				serviceName = strings.TrimSpace(args[0])
+			}
+
+			var path, filter string
+			if strings.TrimSpace(targetSlug) != "" {
+				var err error
+				path, filter, err = resolveTargetLog(cmd.OutOrStdout(), targetSlug, serviceName)
+				if err != nil {
+					return err
+				}
+			} else {
+				if serviceName == "" {
+					return errors.New("service name is required (or use --target)")
+				}
+
+				path = newSupervisor().LogPath(serviceName)
+			}
+
+			offset, err := printLogTail(cmd.OutOrStdout(), path, lines, filter)
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					return fmt.Errorf("no logs found for service %q", serviceName)
+				}
+
+				return err
+			}
+
+			if !follow {
+				return nil
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), interruptSignals...)
+			defer stop()
+
+			var output io.Writer = cmd.OutOrStdout()
+			if filter != "" {
+				output = &lineFilterWriter{output: output, term: filter}
+			}
+
+			_, err = runInForeground(func() error { return followLog(ctx, output, path, offset, logFollowInterval) })
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep printing new output as it is written")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 50, "Number of trailing lines to show (0 for all)")
+	cmd.Flags().StringVar(&targetSlug, "target", "", "Show MCP logs written by a target (e.g. claude) instead")
+
+	return cmd
+}
+
+// resolveTargetLog picks the newest log file a target wrote for serviceName
+// and returns it with the term lines must contain, if any.
+func resolveTargetLog(output io.Writer, targetSlug string, serviceName string) (string, string, error) {
+	slug := strings.ToLower(strings.TrimSpace(targetSlug))
+	targetDefinition, found := lookupTarget(slug)
+	if !found {
+		return "", "", fmt.Errorf("target %q is not known", slug)
+	}
+
+	source, ok := targetDefinition.(target.LogSource)
+	if !ok {
+		return "", "", fmt.Errorf("target %q does not report where it writes logs", slug)
+	}
+
+	files, shared, err := source.LogFiles(serviceName)
+	if err != nil {
+		return "", "", fmt.Errorf("find %s logs: %w", targetDefinition.Name(), err)
+	}
+
+	if len(files) == 0 {
+		if serviceName != "" {
+			return "", "", fmt.Errorf("no %s logs found for service %q", targetDefinition.Name(), serviceName)
+		}
+
+		return "", "", fmt.Errorf("no %s logs found", targetDefinition.Name())
+	}
+
+	path := files[len(files)-1]
+	fmt.Fprintf(output, "==> %s <==\n", path)
+
+	if shared {
+		return path, serviceName, nil
+	}
+
+	return path, "", nil
+}
+
+// printLogTail writes the last n lines of the log at path and returns the
+// file size, so following can resume from there. When filter is set, only
+// lines containing it (case-insensitively) are considered.
+func printLogTail(output io.Writer, path string, n int, filter string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	size := int64(len(data))
+	if filter != "" {
+		data = filterLines(data, filter)
+	}
+
+	tail := data
+	if n > 0 {
+		trimmed := bytes.TrimSuffix(data, []byte("\n"))
+		count := 0
+		for i := len(trimmed) - 1; i >= 0; i-- {
+			if trimmed[i] != '\n' {
+				continue
+			}
+
+			count++
+			if count == n {
+				tail = data[i+1:]
+				break
+			}
+		}
+	}
+
+	if _, err := output.Write(tail); err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+func filterLines(data []byte, term string) []byte {
+	needle := strings.ToLower(term)
+	var filtered bytes.Buffer
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if strings.Contains(strings.ToLower(string(line)), needle) {
+			filtered.Write(line)
+		}
+	}
+
+	return filtered.Bytes()
+}
+
+// lineFilterWriter forwards only complete lines that contain term.
+type lineFilterWriter struct {
+	output  io.Writer
+	term    string
+	pending []byte
+}
+
+func (w *lineFilterWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+
+	end := bytes.LastIndexByte(w.pending, '\n')
+	if end < 0 {
+		return len(p), nil
+	}
+
+	complete := w.pending[:end+1]
+	if _, err := w.output.Write(filterLines(complete, w.term)); err != nil {
+		return 0, err
+	}
+
+	w.pending = append([]byte(nil), w.pending[end+1:]...)
+	return len(p), nil
+}
+
+// followLog polls the log at path and copies anything written past offset
+// until ctx is cancelled. When the file shrinks (it was rotated or
+// truncated), following restarts from the beginning.
+func followLog(ctx context.Context, output io.Writer, path string, offset int64, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				offset = 0
+				continue
+			}
+
+			return fmt.Errorf("stat log file %q: %w", path, err)
+		}
+
+		if info.Size() < offset {
+			offset = 0
+		}
+
+		if info.Size() == offset {
+			continue
+		}
+
+		copied, err := copyLogRange(output, path, offset)
+		if err != nil {
+			return err
+		}
+
+		offset += copied
+	}
+}
+
+func copyLogRange(output io.Writer, path string, offset int64) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("open log file %q: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("seek log file %q: %w", path, err)
+	}
+
+	return io.Copy(output, file)
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/supervisor"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func TestLogsCommandPrintsTrailingLines(t *testing.T) {
+	stateDir := t.TempDir()
+	originalNewSupervisor := newSupervisor
+	newSupervisor = func() *supervisor.Supervisor { return supervisor.New(stateDir) }
+	defer func() { newSupervisor = originalNewSupervisor }()
+
+	logPath := supervisor.New(stateDir).LogPath("local-db")
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o700); err != nil {
+		t.Fatalf("failed to create log dir: %v", err)
+	}
+
+	if err := os.WriteFile(logPath, []byte("one\ntwo\nthree\n"), 0o600); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	logsCmd := newLogsCmd()
+	var stdout bytes.Buffer
+	logsCmd.SetOut(&stdout)
+	logsCmd.SetArgs([]string{"local-db", "--lines", "2"})
+
+	if err := logsCmd.Execute(); err != nil {
+		t.Fatalf("expected logs to succeed: %v", err)
+	}
+
+	if stdout.String() != "two\nthree\n" {
+		t.Fatalf("expected last two lines, got %q", stdout.String())
+	}
+}
+
+func TestLogsCommandReportsMissingLog(t *testing.T) {
+	stateDir := t.TempDir()
+	originalNewSupervisor := newSupervisor
+	newSupervisor = func() *supervisor.Supervisor { return supervisor.New(stateDir) }
+	defer func() { newSupervisor = originalNewSupervisor }()
+
+	logsCmd := newLogsCmd()
+	logsCmd.SetOut(new(bytes.Buffer))
+	logsCmd.SetErr(new(bytes.Buffer))
+	logsCmd.SetArgs([]string{"missing"})
+
+	err := logsCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `no logs found for service "missing"`) {
+		t.Fatalf("expected missing log error, got %v", err)
+	}
+}
+
+func TestFollowLogPrintsAppendedOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "demo.log")
+	if err := os.WriteFile(path, []byte("old\n"), 0o600); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var output bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		done <- followLog(ctx, &output, path, int64(len("old\n")), 10*time.Millisecond)
+	}()
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	_, _ = file.WriteString("new\n")
+	file.Close()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("expected follow to stop cleanly: %v", err)
+	}
+
+	if output.String() != "new\n" {
+		t.Fatalf("expected only appended output, got %q", output.String())
+	}
+}
+
+type fakeLogTarget struct {
+	fakeListTarget
+	files  []string
+	shared bool
+}
+
+func (t fakeLogTarget) LogFiles(_ string) ([]string, bool, error) {
+	return t.files, t.shared, nil
+}
+
+func TestLogsCommandFiltersSharedTargetLogs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "codex-tui.log")
+	if err := os.WriteFile(path, []byte("sentry: started\njira: started\nsentry: failed\n"), 0o600); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	originalLookupTarget := lookupTarget
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		return fakeLogTarget{fakeListTarget: fakeListTarget{name: "Codex CLI", slug: "codex"}, files: []string{path}, shared: true}, slug == "codex"
+	}
+	defer func() { lookupTarget = originalLookupTarget }()
+
+	logsCmd := newLogsCmd()
+	var stdout bytes.Buffer
+	logsCmd.SetOut(&stdout)
+	logsCmd.SetArgs([]string{"sentry", "--target", "codex"})
+
+	if err := logsCmd.Execute(); err != nil {
+		t.Fatalf("expected logs to succeed: %v", err)
+	}
+
+	want := "==> " + path + " <==\nsentry: started\nsentry: failed\n"
+	if stdout.String() != want {
+		t.Fatalf("expected filtered output %q, got %q", want, stdout.String())
+	}
+}
+
+func TestLogsCommandRejectsTargetWithoutLogSource(t *testing.T) {
+	originalLookupTarget := lookupTarget
+	lookupTarget = func(string) (targetpkg.Target, bool) {
+		return fakeListTarget{name: "Other CLI", slug: "other"}, true
+	}
+	defer func() { lookupTarget = originalLookupTarget }()
+
+	logsCmd := newLogsCmd()
+	logsCmd.SetOut(new(bytes.Buffer))
+	logsCmd.SetErr(new(bytes.Buffer))
+	logsCmd.SetArgs([]string{"--target", "other"})
+
+	err := logsCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "does not report where it writes logs") {
+		t.Fatalf("expected unsupported target error, got %v", err)
+	}
+}
+
+func TestLineFilterWriterForwardsMatchingCompleteLines(t *testing.T) {
+	var output bytes.Buffer
+	writer := &lineFilterWriter{output: &output, term: "Sentry"}
+
+	_, _ = writer.Write([]byte("sentry: a\njira: b\nsen"))
+	_, _ = writer.Write([]byte("try: c\n"))
+
+	if output.String() != "sentry: a\nsentry: c\n" {
+		t.Fatalf("unexpected filtered output %q", output.String())
+	}
+}
@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/app"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func init() {
+	registerCommand(newManCmd)
+}
+
+func newManCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "man <dir>",
+		Short: "Write man pages for every command and help topic",
+		Long: `man writes a man page for every command to <dir>/man1, named after the
+command path (mcp-wire-install.1), and one for every help topic to
+<dir>/man7 (mcp-wire-credentials.7). The pages are built from the same text
+as --help and "mcp-wire help topics", so they match the binary that wrote
+them.
+
+To read them without installing, add <dir> to MANPATH.`,
+		Example: `  mcp-wire man ./manpages
+  MANPATH=./manpages man mcp-wire-install`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := strings.TrimSpace(args[0])
+			if dir == "" {
+				return errors.New("man page directory is required")
+			}
+
+			count, err := writeManPages(cmd.Root(), dir)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d man pages to %s\n", count, dir)
+			return nil
+		},
+	}
+}
+
+// writeManPages writes the section 1 page of root and every command under
+// it, and the section 7 page of every help topic, below dir. It returns how
+// many pages it wrote.
+func writeManPages(root *cobra.Command, dir string) (int, error) {
+	commandDir := filepath.Join(dir, "man1")
+	topicDir := filepath.Join(dir, "man7")
+	for _, path := range []string{commandDir, topicDir} {
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			return 0, fmt.Errorf("create man page directory: %w", err)
+		}
+	}
+
+	count := 0
+	var writeCommand func(cmd *cobra.Command) error
+	writeCommand = func(cmd *cobra.Command) error {
+		name := strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+		if err := writeManPage(filepath.Join(commandDir, name+".1"), commandManPage(cmd)); err != nil {
+			return err
+		}
+		count++
+
+		for _, child := range cmd.Commands() {
+			if !manPageCommand(child) {
+				continue
+			}
+
+			if err := writeCommand(child); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := writeCommand(root); err != nil {
+		return count, err
+	}
+
+	for _, topic := range helpTopics {
+		path := filepath.Join(topicDir, root.Name()+"-"+topic.name+".7")
+		if err := writeManPage(path, topicManPage(root, topic)); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+func writeManPage(path string, content []byte) error {
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("write man page: %w", err)
+	}
+
+	return nil
+}
+
+// manPageCommand reports whether cmd gets a section 1 page. Help topics get
+// a section 7 page instead, and hidden and deprecated commands none.
+func manPageCommand(cmd *cobra.Command) bool {
+	return cmd.IsAvailableCommand() && !cmd.IsAdditionalHelpTopicCommand()
+}
+
+// commandManPage renders the section 1 page of cmd.
+func commandManPage(cmd *cobra.Command) []byte {
+	buf := new(bytes.Buffer)
+	name := strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+	writeManHeader(buf, name, "1")
+
+	buf.WriteString(".SH NAME\n")
+	fmt.Fprintf(buf, "%s \\- %s\n", roffEscape(name), roffEscape(cmd.Short))
+
+	buf.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(buf, ".B %s\n", roffEscape(cmd.UseLine()))
+
+	buf.WriteString(".SH DESCRIPTION\n")
+	description := cmd.Long
+	if strings.TrimSpace(description) == "" {
+		description = cmd.Short
+	}
+	writeRoffText(buf, description)
+
+	writeManFlags(buf, "OPTIONS", cmd.NonInheritedFlags())
+	writeManFlags(buf, "OPTIONS INHERITED FROM PARENT COMMANDS", cmd.InheritedFlags())
+
+	if cmd.HasExample() {
+		buf.WriteString(".SH EXAMPLES\n.nf\n")
+		for _, line := range strings.Split(strings.TrimRight(cmd.Example, "\n"), "\n") {
+			buf.WriteString(roffLine(line) + "\n")
+		}
+		buf.WriteString(".fi\n")
+	}
+
+	var related []string
+	if cmd.HasParent() {
+		related = append(related, manReference(cmd.Parent().CommandPath(), "1"))
+	}
+	for _, child := range cmd.Commands() {
+		if manPageCommand(child) {
+			related = append(related, manReference(child.CommandPath(), "1"))
+		}
+	}
+	if !cmd.HasParent() {
+		for _, topic := range helpTopics {
+			related = append(related, manReference(cmd.Name()+" "+topic.name, "7"))
+		}
+	}
+	writeManSeeAlso(buf, related)
+
+	return buf.Bytes()
+}
+
+// topicManPage renders the section 7 page of topic.
+func topicManPage(root *cobra.Command, topic helpTopic) []byte {
+	buf := new(bytes.Buffer)
+	name := root.Name() + "-" + topic.name
+	writeManHeader(buf, name, "7")
+
+	buf.WriteString(".SH NAME\n")
+	fmt.Fprintf(buf, "%s \\- %s\n", roffEscape(name), roffEscape(topic.summary))
+
+	buf.WriteString(".SH DESCRIPTION\n")
+	writeRoffText(buf, topic.body)
+
+	writeManSeeAlso(buf, []string{manReference(root.Name(), "1")})
+
+	return buf.Bytes()
+}
+
+func writeManHeader(buf *bytes.Buffer, name string, section string) {
+	fmt.Fprintf(buf, ".TH \"%s\" \"%s\" \"\" \"mcp-wire %s\" \"mcp-wire Manual\"\n", strings.ToUpper(name), section, roffEscape(app.Version))
+	buf.WriteString(".nh\n.ad l\n")
+}
+
+func writeManFlags(buf *bytes.Buffer, heading string, flags *pflag.FlagSet) {
+	if !flags.HasAvailableFlags() {
+		return
+	}
+
+	fmt.Fprintf(buf, ".SH %s\n", heading)
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden || flag.Deprecated != "" {
+			return
+		}
+
+		varName, usage := pflag.UnquoteUsage(flag)
+		term := "--" + flag.Name
+		if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
+			term = "-" + flag.Shorthand + ", " + term
+		}
+		if varName != "" {
+			term += " " + varName
+		}
+
+		buf.WriteString(".TP\n")
+		fmt.Fprintf(buf, "\\fB%s\\fP\n", roffEscape(term))
+		buf.WriteString(roffLine(usage) + "\n")
+	})
+}
+
+func writeManSeeAlso(buf *bytes.Buffer, related []string) {
+	if len(related) == 0 {
+		return
+	}
+
+	buf.WriteString(".SH SEE ALSO\n")
+	buf.WriteString(strings.Join(related, ",\n") + "\n")
+}
+
+// manReference formats the page of a command path, such as
+// "mcp-wire install", as a bold name and section.
+func manReference(commandPath string, section string) string {
+	return fmt.Sprintf("\\fB%s\\fP(%s)", roffEscape(strings.ReplaceAll(commandPath, " ", "-")), section)
+}
+
+// writeRoffText renders plain help text: blank lines separate paragraphs,
+// and indented lines, such as lists and example commands, keep their line
+// breaks and spacing.
+func writeRoffText(buf *bytes.Buffer, text string) {
+	preformatted := false
+	paragraph := false
+
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		switch {
+		case strings.TrimSpace(line) == "":
+			if preformatted {
+				buf.WriteString(".fi\n")
+				preformatted = false
+			}
+			paragraph = false
+		case strings.HasPrefix(line, " "):
+			if !preformatted {
+				buf.WriteString(".PP\n.nf\n")
+				preformatted = true
+			}
+			buf.WriteString(roffLine(line) + "\n")
+		default:
+			if preformatted {
+				buf.WriteString(".fi\n")
+				preformatted = false
+				paragraph = false
+			}
+			if !paragraph {
+				buf.WriteString(".PP\n")
+				paragraph = true
+			}
+			buf.WriteString(roffLine(line) + "\n")
+		}
+	}
+
+	if preformatted {
+		buf.WriteString(".fi\n")
+	}
+}
+
+// roffLine escapes line for use as a text line, guarding a leading period
+// or quote that roff would read as a request.
+func roffLine(line string) string {
+	escaped := roffEscape(line)
+	if strings.HasPrefix(escaped, ".") || strings.HasPrefix(escaped, "'") {
+		return "\\&" + escaped
+	}
+
+	return escaped
+}
+
+// roffEscape escapes backslashes, and hyphens so options such as --target
+// are not turned into typographic dashes.
+func roffEscape(text string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(text)
+}
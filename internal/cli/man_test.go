@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHelpTopicsListsAndPrintsGuides(t *testing.T) {
+	output, err := executeRootCommand(t, "help", "topics")
+	if err != nil {
+		t.Fatalf("expected help topics to succeed: %v", err)
+	}
+
+	for _, topic := range helpTopics {
+		if !strings.Contains(output, topic.name) {
+			t.Fatalf("expected topic %q in %q", topic.name, output)
+		}
+	}
+
+	output, err = executeRootCommand(t, "help", "topics", "scopes")
+	if err != nil {
+		t.Fatalf("expected help topics scopes to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "Choose one with --scope") {
+		t.Fatalf("expected the scopes guide, got %q", output)
+	}
+
+	output, err = executeRootCommand(t, "--help")
+	if err != nil {
+		t.Fatalf("expected help to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "Additional help topics:") || !strings.Contains(output, "mcp-wire topics") {
+		t.Fatalf("expected topics under additional help topics, got %q", output)
+	}
+}
+
+func TestWriteManPagesWritesCommandsAndTopics(t *testing.T) {
+	dir := t.TempDir()
+
+	count, err := writeManPages(newRootCommand(), dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	install, err := os.ReadFile(filepath.Join(dir, "man1", "mcp-wire-install.1"))
+	if err != nil {
+		t.Fatalf("read install page: %v", err)
+	}
+
+	for _, want := range []string{
+		`.TH "MCP-WIRE-INSTALL" "1"`,
+		`mcp\-wire\-install \- `,
+		`\fB\-\-target stringArray\fP`,
+		".SH OPTIONS INHERITED FROM PARENT COMMANDS\n",
+		`\fBmcp\-wire\fP(1)`,
+	} {
+		if !strings.Contains(string(install), want) {
+			t.Fatalf("expected %q in install page:\n%s", want, install)
+		}
+	}
+
+	topic, err := os.ReadFile(filepath.Join(dir, "man7", "mcp-wire-credentials.7"))
+	if err != nil {
+		t.Fatalf("read credentials page: %v", err)
+	}
+
+	if !strings.Contains(string(topic), `.TH "MCP-WIRE-CREDENTIALS" "7"`) || !strings.Contains(string(topic), ".nf\n  1. The process environment.\n") {
+		t.Fatalf("unexpected credentials page:\n%s", topic)
+	}
+
+	for _, hidden := range []string{"mcp-wire-topics.1", "mcp-wire-help.1"} {
+		if _, err := os.Stat(filepath.Join(dir, "man1", hidden)); !os.IsNotExist(err) {
+			t.Fatalf("expected no page %s, got %v", hidden, err)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "man1"))
+	if err != nil {
+		t.Fatalf("read man1: %v", err)
+	}
+
+	if count != len(entries)+len(helpTopics) {
+		t.Fatalf("expected %d pages, got %d", len(entries)+len(helpTopics), count)
+	}
+}
+
+func TestRoffTextEscapesAndKeepsIndentedBlocks(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writeRoffText(buf, "Use --force.\n.hidden request\n\n  a\\b\n  c\nAfter.")
+
+	want := ".PP\nUse \\-\\-force.\n\\&.hidden request\n.PP\n.nf\n  a\\eb\n  c\n.fi\n.PP\nAfter.\n"
+	if buf.String() != want {
+		t.Fatalf("expected %q, got %q", want, buf.String())
+	}
+}
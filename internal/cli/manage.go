@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"sort"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/tui"
+)
+
+// tuiInstalledServices lists the services configured on every installed,
+// enabled target for the TUI manage screen: one entry per scope that
+// defines the service, with the transport of that entry. System scope
+// entries are left out, since the TUI never removes them.
+func tuiInstalledServices() []tui.InstalledService {
+	var entries []tui.InstalledService
+	for _, t := range withoutDisabledTargets(tuiAllTargets()) {
+		if !t.IsInstalled() {
+			continue
+		}
+
+		names, err := tuiListInstalledServices(t, targetpkg.ConfigScopeEffective)
+		if err != nil {
+			continue
+		}
+
+		sorted := append([]string(nil), names...)
+		sort.Strings(sorted)
+		for _, name := range sorted {
+			entries = append(entries, installedServiceEntries(name, t)...)
+		}
+	}
+
+	return entries
+}
+
+// installedServiceEntries returns an entry for each scope of t that
+// defines name. Targets that cannot show their scope layers get a single
+// user scope entry with no transport.
+func installedServiceEntries(name string, t targetpkg.Target) []tui.InstalledService {
+	explainer, ok := t.(targetpkg.Explainer)
+	if !ok {
+		return []tui.InstalledService{{Name: name, Target: t, Scope: targetpkg.ConfigScopeUser}}
+	}
+
+	layers, err := explainer.ExplainService(name)
+	if err != nil {
+		return []tui.InstalledService{{Name: name, Target: t, Scope: targetpkg.ConfigScopeUser}}
+	}
+
+	var entries []tui.InstalledService
+	for _, layer := range layers {
+		if layer.Entry == nil || layer.Scope == targetpkg.ConfigScopeSystem {
+			continue
+		}
+
+		entry := tui.InstalledService{Name: name, Target: t, Scope: layer.Scope}
+		if canonical := targetpkg.CanonicalEntry(layer.Entry); canonical != nil {
+			entry.Transport = genericExportedServer(name, canonical).Transport
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"testing"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/tui"
+)
+
+func TestInstalledServiceEntriesListEachScopeWithTransport(t *testing.T) {
+	explainTarget := fakeExplainTarget{
+		fakeListTarget: fakeListTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true},
+		layers: []targetpkg.ScopeLayer{
+			{Scope: targetpkg.ConfigScopeSystem, Entry: map[string]any{"command": "npx"}},
+			{Scope: targetpkg.ConfigScopeProject, Entry: map[string]any{"type": "sse", "url": "https://example.com/sse"}},
+			{Scope: targetpkg.ConfigScopeUser, Entry: map[string]any{"command": "npx", "args": []any{"-y", "jira"}}},
+		},
+	}
+
+	entries := installedServiceEntries("jira", explainTarget)
+	if len(entries) != 2 {
+		t.Fatalf("expected the project and user entries, got %+v", entries)
+	}
+
+	if entries[0].Scope != targetpkg.ConfigScopeProject || entries[0].Transport != "sse" {
+		t.Fatalf("expected an sse project entry, got %+v", entries[0])
+	}
+
+	if entries[1].Scope != targetpkg.ConfigScopeUser || entries[1].Transport != "stdio" {
+		t.Fatalf("expected a stdio user entry, got %+v", entries[1])
+	}
+}
+
+func TestInstalledServiceEntriesWithoutLayers(t *testing.T) {
+	target := &fakeInstallTarget{name: "Beta", slug: "beta", installed: true}
+
+	entries := installedServiceEntries("jira", target)
+	want := tui.InstalledService{Name: "jira", Target: target, Scope: targetpkg.ConfigScopeUser}
+	if len(entries) != 1 || entries[0] != want {
+		t.Fatalf("expected a single user entry, got %+v", entries)
+	}
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// applyMergeStrategies sets the merge strategy chosen under targets.merge on
+// each target it names. A setting that does not parse makes installs on its
+// target fail with the reason, rather than fall back to replacing entries
+// the user asked to keep.
+func applyMergeStrategies(targetDefinitions []target.Target) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return
+	}
+
+	settings := cfg.TargetMergeSettings()
+	for _, targetDefinition := range targetDefinitions {
+		setting, ok := settings[targetDefinition.Slug()]
+		if !ok {
+			continue
+		}
+
+		setter, ok := targetDefinition.(target.MergeStrategySetter)
+		if !ok {
+			continue
+		}
+
+		strategy, err := target.NewMergeStrategy(setting.Strategy, setting.Patch)
+		if err != nil {
+			strategy = invalidMergeStrategy{err: fmt.Errorf("targets.merge.%s: %w", targetDefinition.Slug(), err)}
+		}
+
+		setter.SetMergeStrategy(strategy)
+	}
+}
+
+// invalidMergeStrategy fails every merge with the error found in its
+// setting.
+type invalidMergeStrategy struct {
+	err error
+}
+
+func (s invalidMergeStrategy) Merge(any, map[string]any) (any, error) {
+	return nil, s.err
+}
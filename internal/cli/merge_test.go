@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+type fakeMergeTarget struct {
+	fakeListTarget
+	strategy targetpkg.MergeStrategy
+}
+
+func (f *fakeMergeTarget) SetMergeStrategy(strategy targetpkg.MergeStrategy) {
+	f.strategy = strategy
+}
+
+func TestApplyMergeStrategiesSetsConfiguredStrategy(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	data := `{"targets":{"merge":{"alpha":"deep-merge","beta":"overlay"}}}`
+	if err := os.WriteFile(configPath, []byte(data), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	originalLoadConfig := loadConfig
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+	t.Cleanup(func() { loadConfig = originalLoadConfig })
+
+	alpha := &fakeMergeTarget{fakeListTarget: fakeListTarget{name: "Alpha CLI", slug: "alpha"}}
+	beta := &fakeMergeTarget{fakeListTarget: fakeListTarget{name: "Beta CLI", slug: "beta"}}
+	gamma := &fakeMergeTarget{fakeListTarget: fakeListTarget{name: "Gamma CLI", slug: "gamma"}}
+
+	applyMergeStrategies([]targetpkg.Target{alpha, beta, gamma})
+
+	if _, ok := alpha.strategy.(targetpkg.DeepMerge); !ok {
+		t.Fatalf("expected alpha to deep-merge, got %#v", alpha.strategy)
+	}
+
+	if gamma.strategy != nil {
+		t.Fatalf("expected target without a setting to keep replacing, got %#v", gamma.strategy)
+	}
+
+	if beta.strategy == nil {
+		t.Fatal("expected invalid setting to still be applied")
+	}
+
+	_, err := beta.strategy.Merge(nil, map[string]any{})
+	if err == nil || !strings.Contains(err.Error(), `targets.merge.beta: unknown merge strategy "overlay"`) {
+		t.Fatalf("expected installs on beta to fail with the setting error, got %v", err)
+	}
+}
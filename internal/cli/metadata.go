@@ -15,18 +15,19 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// metadataSchemaVersion identifies the structure of the metadata document.
-// Bump it only on breaking changes to the JSON shape so automation can detect
-// incompatibilities.
-const metadataSchemaVersion = 1
+// metadataSchemaVersion identifies the structure of the metadata document. It
+// follows the version of every other JSON output; see outputSchemaVersion.
+const metadataSchemaVersion = outputSchemaVersion
 
 // supportedTransports lists the transport types mcp-wire can install.
-var supportedTransports = []string{"http", "sse", "stdio"}
+var supportedTransports = []string{"http", "sse", "websocket", "stdio"}
 
 // installableScopes lists the config scopes accepted by install and uninstall.
 var installableScopes = []string{
 	string(target.ConfigScopeUser),
 	string(target.ConfigScopeProject),
+	string(target.ConfigScopeSystem),
+	string(target.ConfigScopeRemote),
 }
 
 // metadataDocument is the top-level machine-readable capability report.
@@ -96,7 +97,7 @@ func defaultMetadataDeps() metadataDeps {
 }
 
 func init() {
-	rootCmd.AddCommand(newMetadataCmd())
+	registerCommand(newMetadataCmd)
 }
 
 func newMetadataCmd() *cobra.Command {
@@ -123,6 +124,10 @@ By default only curated services are included. Use --source registry or
 				return err
 			}
 
+			if _, err := requestedSchemaVersion(cmd); err != nil {
+				return err
+			}
+
 			return runMetadata(cmd.OutOrStdout(), defaultMetadataDeps(), source)
 		},
 	}
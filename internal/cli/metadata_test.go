@@ -158,12 +158,12 @@ func TestMetadataTopLevelCapabilities(t *testing.T) {
 		t.Fatalf("expected version %q, got %q", "test-version", doc.MCPWireVersion)
 	}
 
-	if strings.Join(doc.Transports, ",") != "http,sse,stdio" {
-		t.Fatalf("expected transports [http sse stdio], got %v", doc.Transports)
+	if strings.Join(doc.Transports, ",") != "http,sse,websocket,stdio" {
+		t.Fatalf("expected transports [http sse websocket stdio], got %v", doc.Transports)
 	}
 
-	if strings.Join(doc.Scopes, ",") != "user,project" {
-		t.Fatalf("expected scopes [user project], got %v", doc.Scopes)
+	if strings.Join(doc.Scopes, ",") != "user,project,system,remote" {
+		t.Fatalf("expected scopes [user project system remote], got %v", doc.Scopes)
 	}
 }
 
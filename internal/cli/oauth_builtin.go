@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/oauth"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/webhook"
+	"github.com/spf13/cobra"
+)
+
+// builtinOAuthTimeout bounds how long the built-in OAuth sign-in waits for
+// the user.
+const builtinOAuthTimeout = 5 * time.Minute
+
+type builtinOAuthKey struct{}
+
+// requestBuiltinOAuth marks the install running on cmd as one that signs in
+// with mcp-wire's own OAuth client where a target cannot run OAuth itself.
+func requestBuiltinOAuth(cmd *cobra.Command) {
+	cmd.SetContext(context.WithValue(cmd.Context(), builtinOAuthKey{}, true))
+}
+
+// builtinOAuthRequested reports whether install --builtin-oauth runs on cmd.
+func builtinOAuthRequested(cmd *cobra.Command) bool {
+	ctx := cmd.Context()
+	if ctx == nil {
+		return false
+	}
+
+	requested, _ := ctx.Value(builtinOAuthKey{}).(bool)
+	return requested
+}
+
+// runBuiltinOAuth signs in to the server of svc with mcp-wire's own OAuth
+// client. Over SSH it uses the device flow when the server offers it, since
+// a browser opened on the remote machine is out of the user's reach;
+// otherwise it opens the browser and waits for the redirect on a loopback
+// port.
+var runBuiltinOAuth = func(ctx context.Context, svc service.Service, output io.Writer) (oauth.Token, error) {
+	flow := &oauth.Flow{ServerURL: svc.URL, Output: output, Clock: wallClock}
+	if !isRemoteSession() {
+		flow.OpenBrowser = openSetupURL
+		return flow.Authorize(ctx)
+	}
+
+	token, err := flow.AuthorizeDevice(ctx)
+	if !errors.Is(err, oauth.ErrDeviceFlowUnsupported) {
+		return token, err
+	}
+
+	return flow.Authorize(ctx)
+}
+
+// refreshBuiltinOAuth exchanges the refresh token of a stored built-in OAuth
+// token of svc for a new access token.
+var refreshBuiltinOAuth = func(ctx context.Context, svc service.Service, token oauth.Token) (oauth.Token, error) {
+	flow := &oauth.Flow{ServerURL: svc.URL, Clock: wallClock}
+	return flow.Refresh(ctx, token)
+}
+
+// authenticateWithBuiltinOAuth gets one token for svc and rewrites its entry
+// on each of targetDefinitions with the access token in an Authorization
+// header. It is for targets that have no OAuth login mcp-wire can start. It
+// returns an error per target that is left without a token.
+func authenticateWithBuiltinOAuth(cmd *cobra.Command, svc service.Service, targetDefinitions []target.Target, scope target.ConfigScope, resolvedEnv map[string]string) []error {
+	output := cmd.OutOrStdout()
+	fail := func(err error) []error {
+		errs := make([]error, 0, len(targetDefinitions))
+		for _, targetDefinition := range targetDefinitions {
+			emitWebhookEvent(webhook.EventAuth, svc.Name, targetDefinition, scope, err)
+			fmt.Fprintf(output, "  %s: authentication failed (%v)\n", targetDefinition.Name(), err)
+			errs = append(errs, fmt.Errorf("target %q: %w", targetDefinition.Slug(), err))
+		}
+
+		return errs
+	}
+
+	transport := strings.ToLower(strings.TrimSpace(svc.Transport))
+	if (transport != "http" && transport != "sse") || strings.TrimSpace(svc.URL) == "" {
+		return fail(errors.New("built-in OAuth needs a remote service with a URL"))
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), builtinOAuthTimeout)
+	defer cancel()
+
+	stdout, _ := oauthOutputs(output, cmd.ErrOrStderr())
+	token, err := obtainBuiltinOAuthToken(ctx, svc, stdout)
+	if err != nil {
+		return fail(err)
+	}
+
+	var errs []error
+	for _, targetDefinition := range targetDefinitions {
+		err := writeServiceEntry(targetDefinition, withOAuthToken(svc, token), resolvedEnv, scope)
+		emitWebhookEvent(webhook.EventAuth, svc.Name, targetDefinition, scope, err)
+		if err != nil {
+			fmt.Fprintf(output, "  %s: authentication failed (%v)\n", targetDefinition.Name(), err)
+			errs = append(errs, fmt.Errorf("target %q: %w", targetDefinition.Slug(), err))
+			continue
+		}
+
+		fmt.Fprintf(output, "  %s: authenticated (token written to its config)\n", targetDefinition.Name())
+	}
+
+	return errs
+}
+
+// obtainBuiltinOAuthToken returns the stored token of svc while it is valid,
+// refreshes it once it expired, and signs in again when there is none or
+// the refresh fails. A new or refreshed token is stored for the next run.
+func obtainBuiltinOAuthToken(ctx context.Context, svc service.Service, output io.Writer) (oauth.Token, error) {
+	_, store := newCredentialSources()
+	if stored, found := loadOAuthToken(store, svc.Name); found {
+		if !stored.Expired(wallClock) {
+			fmt.Fprintf(output, "  Using the stored OAuth token for %s.\n", svc.Name)
+			return stored, nil
+		}
+
+		token, err := refreshBuiltinOAuth(ctx, svc, stored)
+		if err == nil {
+			fmt.Fprintf(output, "  Refreshed the stored OAuth token for %s.\n", svc.Name)
+			saveOAuthToken(output, store, svc.Name, token)
+			return token, nil
+		}
+
+		fmt.Fprintf(output, "  Could not refresh the stored OAuth token for %s (%v).\n", svc.Name, err)
+	}
+
+	fmt.Fprintf(output, "  Starting built-in OAuth sign-in for %s...\n", svc.Name)
+	token, err := runBuiltinOAuth(ctx, svc, output)
+	if err != nil {
+		return oauth.Token{}, err
+	}
+
+	saveOAuthToken(output, store, svc.Name, token)
+	return token, nil
+}
+
+// withOAuthToken returns svc with token in its Authorization header.
+func withOAuthToken(svc service.Service, token oauth.Token) service.Service {
+	authenticated := svc
+	authenticated.Headers = maps.Clone(svc.Headers)
+	if authenticated.Headers == nil {
+		authenticated.Headers = map[string]string{}
+	}
+	authenticated.Headers["Authorization"] = token.AuthorizationHeader()
+
+	return authenticated
+}
+
+// oauthTokenCredentialPrefix starts the name of every credential that holds
+// a built-in OAuth token.
+const oauthTokenCredentialPrefix = "MCP_WIRE_OAUTH_"
+
+// oauthTokenCredentialName returns the credential the built-in OAuth token
+// of serviceName is stored as, such as MCP_WIRE_OAUTH_JIRA.
+func oauthTokenCredentialName(serviceName string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+
+		return '_'
+	}, strings.ToUpper(strings.TrimSpace(serviceName)))
+
+	return oauthTokenCredentialPrefix + name
+}
+
+// loadOAuthToken returns the built-in OAuth token stored for serviceName.
+func loadOAuthToken(source credential.Source, serviceName string) (oauth.Token, bool) {
+	value, found := source.Get(oauthTokenCredentialName(serviceName))
+	if !found {
+		return oauth.Token{}, false
+	}
+
+	return parseOAuthToken(value)
+}
+
+// parseOAuthToken decodes a stored built-in OAuth token. It reports false for
+// a value that is not one.
+func parseOAuthToken(value string) (oauth.Token, bool) {
+	var token oauth.Token
+	if err := json.Unmarshal([]byte(value), &token); err != nil || token.AccessToken == "" {
+		return oauth.Token{}, false
+	}
+
+	return token, true
+}
+
+// saveOAuthToken stores token for serviceName. A token that cannot be stored
+// still works until it expires, so failing to store it is only reported.
+func saveOAuthToken(output io.Writer, store credential.Source, serviceName string, token oauth.Token) {
+	name := oauthTokenCredentialName(serviceName)
+	value, err := json.Marshal(token)
+	if err == nil {
+		err = store.Store(name, string(value))
+	}
+
+	if err != nil {
+		fmt.Fprintf(output, "Warning: could not store the OAuth token of %s as %s: %v\n", serviceName, name, err)
+	}
+}
@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/oauth"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+func overrideBuiltinOAuth(t *testing.T, fn func(context.Context, service.Service, io.Writer) (oauth.Token, error)) {
+	t.Helper()
+
+	original := runBuiltinOAuth
+	runBuiltinOAuth = fn
+	t.Cleanup(func() { runBuiltinOAuth = original })
+}
+
+func overrideRefreshBuiltinOAuth(t *testing.T, fn func(context.Context, service.Service, oauth.Token) (oauth.Token, error)) {
+	t.Helper()
+
+	original := refreshBuiltinOAuth
+	refreshBuiltinOAuth = fn
+	t.Cleanup(func() { refreshBuiltinOAuth = original })
+}
+
+// storeBuiltinOAuthToken makes the credentials file hold token for the jira
+// service, stops the clock at builtinOAuthNow, and returns the file.
+func storeBuiltinOAuthToken(t *testing.T, token oauth.Token) *fakeCredentialSource {
+	t.Helper()
+
+	value, err := json.Marshal(token)
+	if err != nil {
+		t.Fatalf("marshal token: %v", err)
+	}
+
+	fileSource := &fakeCredentialSource{name: "file", values: map[string]string{"MCP_WIRE_OAUTH_JIRA": string(value)}}
+	newCredentialFileSource = func(string) credential.Source { return fileSource }
+
+	originalClock := wallClock
+	wallClock = clock.Fixed(builtinOAuthNow)
+	t.Cleanup(func() { wallClock = originalClock })
+
+	return fileSource
+}
+
+var builtinOAuthNow = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func setUpBuiltinOAuthInstall(t *testing.T, targets ...targetpkg.Target) {
+	t.Helper()
+
+	restore := overrideInstallCommandDependencies(t)
+	t.Cleanup(restore)
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"jira": {Name: "jira", Transport: "sse", Auth: "oauth", URL: "https://mcp.atlassian.com/v1/sse"},
+		}, nil
+	}
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		for _, candidate := range targets {
+			if candidate.Slug() == slug {
+				return candidate, true
+			}
+		}
+
+		return nil, false
+	}
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{values: map[string]string{}} }
+	newCredentialFileSource = func(string) credential.Source { return &testCredentialSource{values: map[string]string{}} }
+	shouldAutoAuthenticate = func(*cobra.Command) bool { return true }
+}
+
+func TestInstallCommandWritesBuiltinOAuthTokenToTargetsWithoutOAuth(t *testing.T) {
+	claudeTarget := &fakeInstallTarget{name: "Claude Code", slug: "claude", installed: true}
+	authTarget := &fakeAuthInstallTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Codex CLI", slug: "codex", installed: true},
+	}
+	setUpBuiltinOAuthInstall(t, claudeTarget, authTarget)
+
+	signIns := 0
+	overrideBuiltinOAuth(t, func(_ context.Context, svc service.Service, _ io.Writer) (oauth.Token, error) {
+		signIns++
+		if svc.URL != "https://mcp.atlassian.com/v1/sse" {
+			t.Errorf("expected sign-in against the service URL, got %q", svc.URL)
+		}
+
+		return oauth.Token{AccessToken: "access-1", TokenType: "bearer"}, nil
+	})
+
+	output, err := executeInstallCommand(t, "jira", "--target", "claude", "--target", "codex", "--no-prompt", "--builtin-oauth")
+	if err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if signIns != 1 {
+		t.Fatalf("expected one built-in sign-in, got %d", signIns)
+	}
+
+	if claudeTarget.installCalls != 2 {
+		t.Fatalf("expected Claude entry to be rewritten with the token, got %d installs", claudeTarget.installCalls)
+	}
+
+	if got := claudeTarget.lastService.Headers["Authorization"]; got != "Bearer access-1" {
+		t.Fatalf("expected bearer token header, got %q", got)
+	}
+
+	if authTarget.authCalls != 1 || authTarget.installCalls != 1 {
+		t.Fatalf("expected Codex to keep its own OAuth login, got %d auth and %d install calls", authTarget.authCalls, authTarget.installCalls)
+	}
+
+	if !strings.Contains(output, "Claude Code: authenticated (token written to its config)") {
+		t.Fatalf("expected built-in authentication outcome, got %q", output)
+	}
+
+	if strings.Contains(output, "run /mcp") {
+		t.Fatalf("expected no manual OAuth hint, got %q", output)
+	}
+}
+
+func TestInstallCommandFailsWhenBuiltinOAuthFails(t *testing.T) {
+	claudeTarget := &fakeInstallTarget{name: "Claude Code", slug: "claude", installed: true}
+	setUpBuiltinOAuthInstall(t, claudeTarget)
+
+	overrideBuiltinOAuth(t, func(context.Context, service.Service, io.Writer) (oauth.Token, error) {
+		return oauth.Token{}, &oauth.Error{Code: "access_denied"}
+	})
+
+	output, err := executeInstallCommand(t, "jira", "--target", "claude", "--no-prompt", "--builtin-oauth")
+	if err == nil || !strings.Contains(err.Error(), "failed OAuth authentication") {
+		t.Fatalf("expected OAuth authentication error, got %v", err)
+	}
+
+	var oauthErr *oauth.Error
+	if !errors.As(err, &oauthErr) {
+		t.Fatalf("expected the authorization server error to be wrapped, got %v", err)
+	}
+
+	if claudeTarget.installCalls != 1 {
+		t.Fatalf("expected the entry to be left as installed, got %d installs", claudeTarget.installCalls)
+	}
+
+	if !strings.Contains(output, "Claude Code: authentication failed (authorization server returned access_denied)") {
+		t.Fatalf("expected failure outcome, got %q", output)
+	}
+}
+
+func TestInstallCommandReusesValidStoredOAuthToken(t *testing.T) {
+	claudeTarget := &fakeInstallTarget{name: "Claude Code", slug: "claude", installed: true}
+	setUpBuiltinOAuthInstall(t, claudeTarget)
+	storeBuiltinOAuthToken(t, oauth.Token{AccessToken: "stored-1", ExpiresAt: builtinOAuthNow.Add(time.Hour)})
+
+	overrideBuiltinOAuth(t, func(context.Context, service.Service, io.Writer) (oauth.Token, error) {
+		t.Fatal("expected no sign-in while the stored token is valid")
+		return oauth.Token{}, nil
+	})
+
+	output, err := executeInstallCommand(t, "jira", "--target", "claude", "--no-prompt", "--builtin-oauth")
+	if err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if got := claudeTarget.lastService.Headers["Authorization"]; got != "Bearer stored-1" {
+		t.Fatalf("expected the stored token to be written, got %q", got)
+	}
+
+	if !strings.Contains(output, "Using the stored OAuth token for jira.") {
+		t.Fatalf("expected stored token notice, got %q", output)
+	}
+}
+
+func TestInstallCommandRefreshesExpiredStoredOAuthToken(t *testing.T) {
+	claudeTarget := &fakeInstallTarget{name: "Claude Code", slug: "claude", installed: true}
+	setUpBuiltinOAuthInstall(t, claudeTarget)
+	fileSource := storeBuiltinOAuthToken(t, oauth.Token{
+		AccessToken:  "stored-1",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    builtinOAuthNow.Add(-time.Hour),
+	})
+
+	overrideBuiltinOAuth(t, func(context.Context, service.Service, io.Writer) (oauth.Token, error) {
+		t.Fatal("expected the expired token to be refreshed instead of signing in")
+		return oauth.Token{}, nil
+	})
+	overrideRefreshBuiltinOAuth(t, func(_ context.Context, _ service.Service, token oauth.Token) (oauth.Token, error) {
+		if token.RefreshToken != "refresh-1" {
+			t.Errorf("expected the stored refresh token, got %q", token.RefreshToken)
+		}
+
+		return oauth.Token{AccessToken: "access-2", RefreshToken: "refresh-1", ExpiresAt: builtinOAuthNow.Add(time.Hour)}, nil
+	})
+
+	if _, err := executeInstallCommand(t, "jira", "--target", "claude", "--no-prompt", "--builtin-oauth"); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if got := claudeTarget.lastService.Headers["Authorization"]; got != "Bearer access-2" {
+		t.Fatalf("expected the refreshed token to be written, got %q", got)
+	}
+
+	stored, found := parseOAuthToken(fileSource.stored["MCP_WIRE_OAUTH_JIRA"])
+	if !found || stored.AccessToken != "access-2" {
+		t.Fatalf("expected the refreshed token to be stored, got %q", fileSource.stored["MCP_WIRE_OAUTH_JIRA"])
+	}
+}
+
+func TestInstallCommandSignsInWhenRefreshFails(t *testing.T) {
+	claudeTarget := &fakeInstallTarget{name: "Claude Code", slug: "claude", installed: true}
+	setUpBuiltinOAuthInstall(t, claudeTarget)
+	storeBuiltinOAuthToken(t, oauth.Token{AccessToken: "stored-1", RefreshToken: "revoked", ExpiresAt: builtinOAuthNow.Add(-time.Hour)})
+
+	overrideRefreshBuiltinOAuth(t, func(context.Context, service.Service, oauth.Token) (oauth.Token, error) {
+		return oauth.Token{}, &oauth.Error{Code: "invalid_grant"}
+	})
+	overrideBuiltinOAuth(t, func(context.Context, service.Service, io.Writer) (oauth.Token, error) {
+		return oauth.Token{AccessToken: "access-3"}, nil
+	})
+
+	output, err := executeInstallCommand(t, "jira", "--target", "claude", "--no-prompt", "--builtin-oauth")
+	if err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if got := claudeTarget.lastService.Headers["Authorization"]; got != "Bearer access-3" {
+		t.Fatalf("expected the token of the new sign-in, got %q", got)
+	}
+
+	if !strings.Contains(output, "Could not refresh the stored OAuth token for jira") {
+		t.Fatalf("expected refresh failure to be reported, got %q", output)
+	}
+}
+
+func TestOAuthTokenCredentialName(t *testing.T) {
+	if got := oauthTokenCredentialName("github-copilot"); got != "MCP_WIRE_OAUTH_GITHUB_COPILOT" {
+		t.Fatalf("unexpected credential name %q", got)
+	}
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/andreagrandi/mcp-wire/internal/qrcode"
+)
+
+// oauthURLPattern matches a URL in the output of a target's OAuth login.
+var oauthURLPattern = regexp.MustCompile(`https?://[^\s"'<>]+`)
+
+// isRemoteSession reports whether mcp-wire runs over SSH, where a browser
+// opened by an OAuth login lands on the remote machine rather than in front
+// of the user.
+var isRemoteSession = func() bool {
+	for _, name := range []string{"SSH_CONNECTION", "SSH_CLIENT", "SSH_TTY"} {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// oauthOutputs returns the streams to hand a target's OAuth login. In a
+// remote session each verification URL the login prints is followed by a QR
+// code and the plain URL, so it can be opened from a phone; otherwise the
+// streams are returned as they are.
+func oauthOutputs(stdout, stderr io.Writer) (io.Writer, io.Writer) {
+	if !isRemoteSession() {
+		return stdout, stderr
+	}
+
+	shown := &shownURLs{urls: map[string]bool{}}
+	return &oauthURLWriter{out: stdout, shown: shown}, &oauthURLWriter{out: stderr, shown: shown}
+}
+
+// shownURLs remembers the URLs already drawn, shared by the stdout and
+// stderr writers of one login.
+type shownURLs struct {
+	mu   sync.Mutex
+	urls map[string]bool
+}
+
+func (s *shownURLs) first(url string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.urls[url] {
+		return false
+	}
+
+	s.urls[url] = true
+	return true
+}
+
+// oauthURLWriter passes output through unchanged and, after each line with
+// a URL, writes a QR code for it.
+type oauthURLWriter struct {
+	out   io.Writer
+	shown *shownURLs
+	line  bytes.Buffer
+}
+
+func (w *oauthURLWriter) Write(p []byte) (int, error) {
+	n, err := w.out.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	for _, b := range p {
+		if b != '\n' {
+			w.line.WriteByte(b)
+			continue
+		}
+
+		line := w.line.String()
+		w.line.Reset()
+
+		url := strings.TrimRight(oauthURLPattern.FindString(line), ".,;)")
+		if url != "" && w.shown.first(url) {
+			writeVerificationQR(w.out, url)
+		}
+	}
+
+	return n, nil
+}
+
+// writeVerificationQR writes url as a QR code followed by the plain URL. A
+// URL too long for a QR code is only written as text.
+func writeVerificationQR(output io.Writer, url string) {
+	code, err := qrcode.Encode(url)
+	if err != nil {
+		fmt.Fprintf(output, "\n  Open this URL to continue: %s\n\n", url)
+		return
+	}
+
+	fmt.Fprintln(output)
+	fmt.Fprint(output, code.Terminal(2))
+	fmt.Fprintf(output, "\n  Scan the code or open this URL to continue: %s\n\n", url)
+}
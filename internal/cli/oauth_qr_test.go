@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func overrideRemoteSession(t *testing.T, remote bool) {
+	t.Helper()
+
+	original := isRemoteSession
+	isRemoteSession = func() bool { return remote }
+	t.Cleanup(func() { isRemoteSession = original })
+}
+
+func TestOAuthOutputsDrawQRCodeForVerificationURLInRemoteSession(t *testing.T) {
+	overrideRemoteSession(t, true)
+
+	stdoutBuf, stderrBuf := new(bytes.Buffer), new(bytes.Buffer)
+	stdout, stderr := oauthOutputs(stdoutBuf, stderrBuf)
+
+	// Output arrives in arbitrary chunks; the URL is drawn once the line
+	// ends.
+	_, _ = io.WriteString(stdout, "Authorize by visiting https://auth.example.com/dev")
+	if strings.Contains(stdoutBuf.String(), "Scan the code") {
+		t.Fatal("expected no QR code before the line ends")
+	}
+	_, _ = io.WriteString(stdout, "ice?code=ABCD.\nWaiting...\n")
+	_, _ = io.WriteString(stderr, "Still waiting for https://auth.example.com/device?code=ABCD\n")
+
+	output := stdoutBuf.String()
+	for _, want := range []string{
+		"Authorize by visiting https://auth.example.com/device?code=ABCD.\n",
+		"█",
+		"Scan the code or open this URL to continue: https://auth.example.com/device?code=ABCD\n",
+		"Waiting...\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+
+	if stderrBuf.String() != "Still waiting for https://auth.example.com/device?code=ABCD\n" {
+		t.Fatalf("expected a URL already drawn not to be drawn again, got %q", stderrBuf.String())
+	}
+}
+
+func TestOAuthOutputsUnchangedInLocalSession(t *testing.T) {
+	overrideRemoteSession(t, false)
+
+	stdoutBuf, stderrBuf := new(bytes.Buffer), new(bytes.Buffer)
+	stdout, stderr := oauthOutputs(stdoutBuf, stderrBuf)
+	if stdout != io.Writer(stdoutBuf) || stderr != io.Writer(stderrBuf) {
+		t.Fatal("expected the streams to be returned unchanged")
+	}
+}
+
+func TestWriteVerificationQRFallsBackToURLWhenTooLong(t *testing.T) {
+	url := "https://auth.example.com/?state=" + strings.Repeat("x", 3000)
+
+	buf := new(bytes.Buffer)
+	writeVerificationQR(buf, url)
+
+	if buf.String() != "\n  Open this URL to continue: "+url+"\n\n" {
+		t.Fatalf("expected only the URL, got %q", buf.String())
+	}
+}
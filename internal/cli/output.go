@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+// addOutputFlags registers the output format flags as persistent flags of
+// cmd. On the root command every subcommand accepts them.
+func addOutputFlags(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	flags.String("output", outputFormatText, "Output format: text or json")
+	flags.Bool("json", false, "Shorthand for --output json")
+	flags.Int("schema-version", outputSchemaVersion, "JSON output schema version to print; fails if unsupported")
+}
+
+// jsonOutputRequested reports whether cmd should print JSON to stdout instead
+// of human-readable text. Commands without the flags print text. It fails
+// when --schema-version names a version this build cannot print.
+func jsonOutputRequested(cmd *cobra.Command) (bool, error) {
+	jsonOutput, err := jsonOutputFlagSet(cmd)
+	if err != nil {
+		return false, err
+	}
+
+	if schemaFlag := cmd.Flags().Lookup("schema-version"); schemaFlag != nil && schemaFlag.Changed && !jsonOutput {
+		return false, errSchemaVersionWithoutJSON
+	}
+
+	if _, err := requestedSchemaVersion(cmd); err != nil {
+		return false, err
+	}
+
+	return jsonOutput, nil
+}
+
+func jsonOutputFlagSet(cmd *cobra.Command) (bool, error) {
+	if jsonFlag := cmd.Flags().Lookup("json"); jsonFlag != nil && jsonFlag.Value.String() == "true" {
+		return true, nil
+	}
+
+	outputFlag := cmd.Flags().Lookup("output")
+	if outputFlag == nil {
+		return false, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(outputFlag.Value.String())) {
+	case "", outputFormatText:
+		return false, nil
+	case outputFormatJSON:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid output format %q (supported: text, json)", outputFlag.Value.String())
+	}
+}
+
+// writeJSON writes v to output as indented JSON.
+func writeJSON(output io.Writer, v any) error {
+	encoded, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode output: %w", err)
+	}
+
+	encoded = append(encoded, '\n')
+
+	if _, err := output.Write(encoded); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+
+	return nil
+}
+
+// operationReport is the JSON result of an install or uninstall run.
+type operationReport struct {
+	Action  string `json:"action"`
+	Service string `json:"service"`
+	Scope   string `json:"scope"`
+	Status  string `json:"status"`
+	// DryRun is set when --dry-run previewed the changes without making
+	// them.
+	DryRun  bool            `json:"dry_run,omitempty"`
+	Targets []targetOutcome `json:"targets"`
+	// Connection is set when install --verify connected to the server.
+	Connection *connectionReport `json:"connection,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// targetOutcome is what happened to one service on one target. Service is
+// set because an install can also install the dependencies of a service.
+type targetOutcome struct {
+	Service  string `json:"service"`
+	Target   string `json:"target"`
+	Name     string `json:"name"`
+	Status   string `json:"status"` // "configured", "removed", "would change", "unchanged", or "failed"
+	Verified bool   `json:"verified"`
+	Warning  string `json:"warning,omitempty"`
+	// WarningCode is the code Warning can be suppressed with.
+	WarningCode string `json:"warning_code,omitempty"`
+	// Diff is the unified diff a dry run would apply to the target config.
+	Diff  string `json:"diff,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type operationReportKey struct{}
+
+// operationReportFrom returns the report the running command collects
+// outcomes in, or nil when it prints text.
+func operationReportFrom(cmd *cobra.Command) *operationReport {
+	ctx := cmd.Context()
+	if ctx == nil {
+		return nil
+	}
+
+	report, _ := ctx.Value(operationReportKey{}).(*operationReport)
+	return report
+}
+
+func (r *operationReport) add(outcome targetOutcome) {
+	if r == nil {
+		return
+	}
+
+	r.Targets = append(r.Targets, outcome)
+}
+
+// finish sets the overall status from the outcomes and the error the run
+// ended with.
+func (r *operationReport) finish(err error) {
+	failed := 0
+	for _, outcome := range r.Targets {
+		if outcome.Status == "failed" {
+			failed++
+		}
+	}
+
+	switch {
+	case err == nil && failed == 0:
+		r.Status = "success"
+	case failed < len(r.Targets):
+		r.Status = "partial_failure"
+	default:
+		r.Status = "failure"
+	}
+
+	if err != nil {
+		r.Error = err.Error()
+	}
+}
+
+// runReportingOperation runs an install or uninstall. With JSON output the
+// progress text moves to stderr and a report is printed to stdout once the
+// run ends, also when it failed, so scripts always get a document to parse.
+func runReportingOperation(cmd *cobra.Command, action string, serviceName string, scope string, run func() error) error {
+	jsonOutput, err := jsonOutputRequested(cmd)
+	if err != nil {
+		return err
+	}
+
+	if !jsonOutput {
+		return run()
+	}
+
+	stdout := cmd.OutOrStdout()
+	cmd.SetOut(cmd.ErrOrStderr())
+	defer cmd.SetOut(stdout)
+
+	originalCtx := cmd.Context()
+	defer cmd.SetContext(originalCtx)
+
+	ctx := originalCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	report := &operationReport{Action: action, Service: serviceName, Scope: scope, Targets: []targetOutcome{}}
+	cmd.SetContext(context.WithValue(ctx, operationReportKey{}, report))
+
+	runErr := run()
+	report.finish(runErr)
+
+	if err := writeJSON(stdout, report); err != nil {
+		return err
+	}
+
+	return runErr
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+func TestJSONOutputRequested(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    bool
+		wantErr bool
+	}{
+		{name: "default text", args: nil, want: false},
+		{name: "output json", args: []string{"--output", "JSON"}, want: true},
+		{name: "json shorthand", args: []string{"--json"}, want: true},
+		{name: "invalid format", args: []string{"--output", "yaml"}, wantErr: true},
+		{name: "pinned schema version", args: []string{"--json", "--schema-version", "1"}, want: true},
+		{name: "unsupported schema version", args: []string{"--json", "--schema-version", "2"}, wantErr: true},
+		{name: "schema version without json", args: []string{"--schema-version", "1"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "probe", RunE: func(*cobra.Command, []string) error { return nil }}
+			addOutputFlags(cmd)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+
+			got, err := jsonOutputRequested(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInstallCommandJSONOutputReportsTargets(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	alpha := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}
+	beta := &fakeInstallTarget{name: "Beta CLI", slug: "beta-cli", installed: true, installErr: errors.New("boom")}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo": {Name: "demo", Transport: "stdio", Command: "npx"},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{alpha, beta} }
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{} }
+	newCredentialFileSource = func(_ string) credential.Source { return &testCredentialSource{} }
+	shouldAutoAuthenticate = func(_ *cobra.Command) bool { return false }
+
+	stdout, stderr, err := executeCommandWithOutputFlags(t, newInstallCmd(), "demo", "--json")
+	if err == nil {
+		t.Fatal("expected install to fail on one target")
+	}
+
+	var report operationReport
+	if decodeErr := json.Unmarshal([]byte(stdout), &report); decodeErr != nil {
+		t.Fatalf("expected JSON on stdout, got %q: %v", stdout, decodeErr)
+	}
+
+	if report.Action != "install" || report.Service != "demo" || report.Scope != "user" || report.Status != "partial_failure" {
+		t.Fatalf("unexpected report %#v", report)
+	}
+
+	if len(report.Targets) != 2 || report.Targets[0].Status != "configured" || report.Targets[1].Status != "failed" || report.Targets[1].Error != "boom" {
+		t.Fatalf("unexpected target outcomes %#v", report.Targets)
+	}
+
+	if !strings.Contains(stderr, "Installing to: Alpha CLI, Beta CLI") {
+		t.Fatalf("expected progress text on stderr, got %q", stderr)
+	}
+}
+
+func TestInstallCommandJSONOutputReportsEarlyErrors(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{}, nil
+	}
+
+	stdout, _, err := executeCommandWithOutputFlags(t, newInstallCmd(), "missing", "--output", "json")
+	if err == nil {
+		t.Fatal("expected install of an unknown service to fail")
+	}
+
+	var report operationReport
+	if decodeErr := json.Unmarshal([]byte(stdout), &report); decodeErr != nil {
+		t.Fatalf("expected JSON on stdout, got %q: %v", stdout, decodeErr)
+	}
+
+	if report.Status != "failure" || report.Error == "" || len(report.Targets) != 0 {
+		t.Fatalf("unexpected report %#v", report)
+	}
+}
+
+func TestUninstallCommandJSONOutputReportsRemovedTargets(t *testing.T) {
+	restore := overrideUninstallCommandDependencies(t)
+	defer restore()
+
+	alpha := &fakeUninstallTarget{name: "Alpha CLI", slug: "alpha", installed: true}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{alpha} }
+
+	stdout, _, err := executeCommandWithOutputFlags(t, newUninstallCmd(), "demo-service", "--json")
+	if err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	var report operationReport
+	if decodeErr := json.Unmarshal([]byte(stdout), &report); decodeErr != nil {
+		t.Fatalf("expected JSON on stdout, got %q: %v", stdout, decodeErr)
+	}
+
+	if report.Status != "success" || len(report.Targets) != 1 || report.Targets[0].Status != "removed" || report.Targets[0].Target != "alpha" {
+		t.Fatalf("unexpected report %#v", report)
+	}
+}
+
+func TestBuildTargetListJSONIncludesConfigPaths(t *testing.T) {
+	dir := t.TempDir()
+	originalLoadConfig := loadConfig
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(filepath.Join(dir, "config.json")) }
+	t.Cleanup(func() { loadConfig = originalLoadConfig })
+
+	configPath := filepath.Join(dir, "alpha.json")
+	locator := &fakeLocatorTarget{
+		fakeListTarget: fakeListTarget{name: "Alpha CLI", slug: "alpha", installed: true},
+		configPath:     configPath,
+		candidates:     []targetpkg.ConfigCandidate{{Path: configPath, Label: "current releases"}},
+	}
+
+	var output bytes.Buffer
+	if err := writeJSON(&output, buildTargetListJSON([]targetpkg.Target{locator})); err != nil {
+		t.Fatalf("expected JSON to be written: %v", err)
+	}
+
+	var entries []targetListEntry
+	if err := json.Unmarshal(output.Bytes(), &entries); err != nil {
+		t.Fatalf("failed to decode %q: %v", output.String(), err)
+	}
+
+	if len(entries) != 1 || entries[0].Slug != "alpha" || !entries[0].Installed || entries[0].ConfigPath != configPath || entries[0].ConfigSource != "not_created" {
+		t.Fatalf("unexpected entries %#v", entries)
+	}
+
+	if len(entries[0].Candidates) != 1 || entries[0].Candidates[0].Exists {
+		t.Fatalf("unexpected candidates %#v", entries[0].Candidates)
+	}
+}
+
+func executeCommandWithOutputFlags(t *testing.T, cmd *cobra.Command, args ...string) (string, string, error) {
+	t.Helper()
+
+	addOutputFlags(cmd)
+
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetIn(strings.NewReader(""))
+	cmd.SetArgs(args)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+
+	err := cmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
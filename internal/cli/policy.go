@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/policy"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// loadPolicy returns the policy file named by policy_file or, when that is
+// not set, policy.yaml next to the config if it exists. It returns nil when
+// there is no policy.
+var loadPolicy = func() (*policy.Policy, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	path, explicit := cfg.PolicyFile()
+	if path == "" {
+		return nil, nil
+	}
+
+	if !explicit {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+	}
+
+	return policy.Load(path)
+}
+
+// checkPolicy evaluates action of svc on every target against the policy
+// and returns the denials of all of them, so nothing is applied unless the
+// whole operation is allowed.
+func checkPolicy(action string, svc service.Service, targetDefinitions []targetpkg.Target, scope targetpkg.ConfigScope) error {
+	rules, err := loadPolicy()
+	if err != nil || rules == nil {
+		return err
+	}
+
+	source := serviceSource(svc.Name)
+	secrets := make([]string, 0, len(svc.Env))
+	for _, envVar := range svc.Env {
+		secrets = append(secrets, envVar.Name)
+	}
+
+	var denials []error
+	for _, targetDefinition := range targetDefinitions {
+		err := rules.Evaluate(policy.Operation{
+			Action:    action,
+			Service:   svc.Name,
+			Source:    source,
+			Transport: svc.Transport,
+			Target:    targetDefinition.Slug(),
+			Scope:     effectiveScope(targetDefinition, scope),
+			Secrets:   secrets,
+		})
+		if err != nil {
+			denials = append(denials, err)
+		}
+	}
+
+	return errors.Join(denials...)
+}
+
+// checkUninstallPolicy is checkPolicy for uninstalls, which only know the
+// service name; the rest comes from the service definition when there is
+// one.
+func checkUninstallPolicy(serviceName string, targetDefinitions []targetpkg.Target, scope targetpkg.ConfigScope) error {
+	svc := service.Service{Name: serviceName}
+	if services, err := loadServices(); err == nil {
+		if definition, err := findServiceDefinitionByName(services, serviceName); err == nil {
+			svc = definition
+		}
+	}
+
+	return checkPolicy("uninstall", svc, targetDefinitions, scope)
+}
+
+// serviceSource reports where a service definition comes from: the bundled
+// and custom definitions, or the MCP Registry.
+func serviceSource(serviceName string) string {
+	services, err := loadServices()
+	if err == nil {
+		if _, err := findServiceDefinitionByName(services, serviceName); err == nil {
+			return string(catalog.SourceCurated)
+		}
+	}
+
+	return string(catalog.SourceRegistry)
+}
+
+// effectiveScope returns scope when the target supports it, or an empty
+// string for targets with a single config.
+func effectiveScope(targetDefinition targetpkg.Target, scope targetpkg.ConfigScope) string {
+	if !targetSupportsScope(targetDefinition, scope) {
+		return ""
+	}
+
+	return string(scope)
+}
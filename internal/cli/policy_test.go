@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func writePolicyConfig(t *testing.T, policy string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "org-policy.yaml")
+	if err := os.WriteFile(policyPath, []byte(policy), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+
+	configPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"policy_file":"`+filepath.ToSlash(policyPath)+`"}`), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+}
+
+func TestInstallCommandStopsWhenPolicyDeniesAnyTarget(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	allowed := &fakeInstallTarget{name: "Allowed CLI", slug: "allowed", installed: true}
+	blocked := &fakeInstallTarget{name: "Blocked CLI", slug: "blocked", installed: true}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo-service": {Name: "demo-service", Transport: "sse", URL: "https://example.com/mcp", Env: []service.EnvVar{{Name: "DEMO_TOKEN"}}},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{allowed, blocked} }
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{values: map[string]string{}} }
+	newCredentialFileSource = func(string) credential.Source { return &testCredentialSource{values: map[string]string{}} }
+
+	writePolicyConfig(t, `rules:
+  - name: curated-only
+    expression: 'source == "curated"'
+  - name: no-demo-on-blocked
+    expression: '!(target == "blocked" && "DEMO_TOKEN" in secrets)'
+    message: The demo token may not be given to the blocked CLI.
+`)
+
+	_, err := executeInstallCommand(t, "demo-service", "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), `The demo token may not be given to the blocked CLI. (rule "no-demo-on-blocked")`) {
+		t.Fatalf("expected policy denial, got %v", err)
+	}
+
+	if allowed.installCalls != 0 || blocked.installCalls != 0 {
+		t.Fatalf("expected nothing installed, got allowed=%d blocked=%d", allowed.installCalls, blocked.installCalls)
+	}
+}
+
+func TestCheckPolicyFailsOnMissingPolicyFile(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"policy_file":"/does/not/exist.yaml"}`), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+
+	if err := checkPolicy("install", service.Service{Name: "demo-service"}, nil, targetpkg.ConfigScopeUser); err == nil || !strings.Contains(err.Error(), "read policy file") {
+		t.Fatalf("expected a missing policy file to fail closed, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+)
+
+var newPortStore = func() *state.PortStore {
+	return state.NewPortStore("")
+}
+
+// allocateServicePorts assigns a local port to each port slot the service
+// declares and adds it to resolvedEnv, so it is both exported as an
+// environment variable and substituted into {NAME} placeholders.
+func allocateServicePorts(output io.Writer, svc service.Service, resolvedEnv map[string]string) error {
+	if len(svc.Ports) == 0 {
+		return nil
+	}
+
+	store := newPortStore()
+	for _, name := range svc.Ports {
+		port, err := store.Allocate(svc.Name, name)
+		if err != nil {
+			return fmt.Errorf("allocate port %s for service %q: %w", name, svc.Name, err)
+		}
+
+		resolvedEnv[name] = fmt.Sprintf("%d", port)
+		fmt.Fprintf(output, "Port %s: %d\n", name, port)
+	}
+
+	return nil
+}
+
+// releaseServicePorts frees the ports held by a service once it is no longer
+// configured on any installed target. Errors are ignored: a stale allocation
+// only reserves a port and never breaks an install.
+func releaseServicePorts(serviceName string) {
+	for _, targetDefinition := range listInstalledTargets() {
+		if serviceConfiguredOn(targetDefinition, serviceName, "") {
+			return
+		}
+	}
+
+	_, _ = newPortStore().Release(serviceName)
+}
+
+func copyEnv(env map[string]string) map[string]string {
+	copied := make(map[string]string, len(env))
+	for name, value := range env {
+		copied[name] = value
+	}
+
+	return copied
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func TestInstallCommandAllocatesServicePorts(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	portsPath := filepath.Join(t.TempDir(), "ports.json")
+	originalNewPortStore := newPortStore
+	newPortStore = func() *state.PortStore { return state.NewPortStore(portsPath) }
+	defer func() { newPortStore = originalNewPortStore }()
+
+	installTarget := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha", installed: true}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"bridge": {
+				Name:      "bridge",
+				Transport: "stdio",
+				Command:   "bridge",
+				Args:      []string{"--port", "{BRIDGE_PORT}"},
+				Ports:     []string{"BRIDGE_PORT"},
+			},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{installTarget} }
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{values: map[string]string{}} }
+	newCredentialFileSource = func(string) credential.Source { return &testCredentialSource{values: map[string]string{}} }
+
+	output, err := executeInstallCommand(t, "bridge", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected install command to succeed: %v", err)
+	}
+
+	allocations, err := state.NewPortStore(portsPath).List()
+	if err != nil || len(allocations) != 1 {
+		t.Fatalf("expected one port allocation, got %v (err=%v)", allocations, err)
+	}
+
+	port := strconv.Itoa(allocations[0].Port)
+	if installTarget.lastEnv["BRIDGE_PORT"] != port {
+		t.Fatalf("expected port in env, got %#v", installTarget.lastEnv)
+	}
+
+	if got := strings.Join(installTarget.lastService.Args, " "); got != "--port "+port {
+		t.Fatalf("expected port substituted into args, got %q", got)
+	}
+
+	if !strings.Contains(output, "Port BRIDGE_PORT: "+port) {
+		t.Fatalf("expected port allocation output, got %q", output)
+	}
+}
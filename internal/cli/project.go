@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/project"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+// projectLocation is the directory project scope applies to. An empty dir
+// leaves targets on their default of the working directory. recognized is set
+// when the directory was given explicitly or a project marker was found.
+type projectLocation struct {
+	dir        string
+	recognized bool
+}
+
+// addProjectDirFlags registers the flags that choose where project scope
+// applies.
+func addProjectDirFlags(cmd *cobra.Command) {
+	cmd.Flags().String("project-dir", "", "Directory project scope applies to; overrides --project-root")
+	cmd.Flags().String("project-root", "", "Project root detection for project scope: cwd, git, or manifest (default: project_root setting, else cwd)")
+}
+
+// applyProjectDir resolves the project directory from --project-dir,
+// --project-root, or the project_root setting, and pins every target keyed by
+// a project directory to it, so all targets agree on where project config
+// lands.
+func applyProjectDir(cmd *cobra.Command, targetDefinitions []target.Target) (projectLocation, error) {
+	location, err := resolveProjectLocation(flagString(cmd, "project-dir"), flagString(cmd, "project-root"))
+	if err != nil {
+		return projectLocation{}, err
+	}
+
+	pinProjectDir(targetDefinitions, location)
+
+	return location, nil
+}
+
+func resolveProjectLocation(projectDir, strategyValue string) (projectLocation, error) {
+	projectDir = strings.TrimSpace(projectDir)
+	if projectDir != "" {
+		absoluteDir, err := filepath.Abs(projectDir)
+		if err != nil {
+			return projectLocation{}, fmt.Errorf("resolve --project-dir %q: %w", projectDir, err)
+		}
+
+		info, err := os.Stat(absoluteDir)
+		if err != nil || !info.IsDir() {
+			return projectLocation{}, fmt.Errorf("--project-dir %q is not a directory", projectDir)
+		}
+
+		return projectLocation{dir: absoluteDir, recognized: true}, nil
+	}
+
+	if strings.TrimSpace(strategyValue) == "" {
+		if cfg, err := loadConfig(); err == nil {
+			strategyValue = cfg.ProjectRoot()
+		}
+	}
+
+	strategy, err := project.ParseStrategy(strategyValue)
+	if err != nil {
+		return projectLocation{}, err
+	}
+
+	if strategy == project.StrategyCwd {
+		return projectLocation{}, nil
+	}
+
+	cwd, err := getWorkingDirectory()
+	if err != nil {
+		return projectLocation{}, fmt.Errorf("resolve current working directory: %w", err)
+	}
+
+	root, found := project.Root(cwd, strategy)
+
+	return projectLocation{dir: root, recognized: found}, nil
+}
+
+func pinProjectDir(targetDefinitions []target.Target, location projectLocation) {
+	if location.dir == "" {
+		return
+	}
+
+	for _, targetDefinition := range targetDefinitions {
+		if setter, ok := targetDefinition.(target.ProjectDirSetter); ok {
+			setter.SetProjectDir(location.dir)
+		}
+	}
+}
+
+func flagString(cmd *cobra.Command, name string) string {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil {
+		return ""
+	}
+
+	return flag.Value.String()
+}
+
+// confirmProjectScope guards project-scope writes made outside any
+// recognizable project, since project config written into $HOME or / is
+// almost always a mistake. A directory counts as a project when it was
+// resolved from --project-dir or a detection strategy marker, when it or a
+// parent holds a .git entry, or when a selected target already keeps a
+// project entry for it. Otherwise a warning is printed and the write needs
+// force or an interactive confirmation. It reports whether to proceed.
+func confirmProjectScope(output io.Writer, reader *bufio.Reader, interactive bool, targetDefinitions []target.Target, scope target.ConfigScope, location projectLocation, force bool) (bool, error) {
+	if scope != target.ConfigScopeProject {
+		return true, nil
+	}
+
+	projectTargets := make([]target.Target, 0, len(targetDefinitions))
+	for _, targetDefinition := range targetDefinitions {
+		if targetSupportsScope(targetDefinition, target.ConfigScopeProject) {
+			projectTargets = append(projectTargets, targetDefinition)
+		}
+	}
+
+	if len(projectTargets) == 0 {
+		return true, nil
+	}
+
+	if location.recognized {
+		return true, nil
+	}
+
+	dir := location.dir
+	if dir == "" {
+		cwd, err := getWorkingDirectory()
+		if err != nil {
+			return false, fmt.Errorf("resolve current working directory: %w", err)
+		}
+
+		dir = cwd
+	}
+
+	if _, inGit := project.FindUp(dir, ".git"); inGit || hasProjectEntry(projectTargets) {
+		return true, nil
+	}
+
+	fmt.Fprintf(output, "Warning: %s does not look like a project (no .git found and no existing project entry); project config written here is almost always a mistake.\n", dir)
+
+	if force {
+		return true, nil
+	}
+
+	if !interactive {
+		return false, errors.New("refusing to write project scope outside a project; run from the project root or pass --force")
+	}
+
+	proceed, err := askYesNo(reader, output, "Write project config here anyway? [y/N]: ", false)
+	if err != nil {
+		return false, fmt.Errorf("read project scope confirmation: %w", err)
+	}
+
+	if !proceed {
+		fmt.Fprintln(output, "Install cancelled.")
+	}
+
+	return proceed, nil
+}
+
+func hasProjectEntry(targetDefinitions []target.Target) bool {
+	for _, targetDefinition := range targetDefinitions {
+		reporter, ok := targetDefinition.(target.ProjectEntryReporter)
+		if !ok {
+			continue
+		}
+
+		found, err := reporter.HasProjectEntry()
+		if err == nil && found {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,257 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+type fakeProjectEntryInstallTarget struct {
+	*fakeScopedInstallTarget
+	hasProjectEntry bool
+}
+
+func (t *fakeProjectEntryInstallTarget) HasProjectEntry() (bool, error) {
+	return t.hasProjectEntry, nil
+}
+
+func overrideProjectScopeDependencies(t *testing.T, dir string, targets ...targetpkg.Target) {
+	t.Helper()
+
+	restore := overrideInstallCommandDependencies(t)
+	originalGetWorkingDirectory := getWorkingDirectory
+	originalIsTerminalReader := isTerminalReader
+	t.Cleanup(func() {
+		getWorkingDirectory = originalGetWorkingDirectory
+		isTerminalReader = originalIsTerminalReader
+		restore()
+	})
+
+	getWorkingDirectory = func() (string, error) { return dir, nil }
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo-service": {Name: "demo-service", Transport: "sse", URL: "https://example.com/mcp"},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return targets }
+	newCredentialEnvSource = func() credential.Source {
+		return &testCredentialSource{name: "environment", values: map[string]string{}}
+	}
+	newCredentialFileSource = func(string) credential.Source {
+		return &testCredentialSource{name: "file", values: map[string]string{}}
+	}
+}
+
+func newFakeProjectScopedTarget() *fakeScopedInstallTarget {
+	return &fakeScopedInstallTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true},
+	}
+}
+
+func TestInstallCommandRefusesProjectScopeOutsideProject(t *testing.T) {
+	scopedTarget := newFakeProjectScopedTarget()
+	overrideProjectScopeDependencies(t, t.TempDir(), scopedTarget)
+
+	output, err := executeInstallCommand(t, "demo-service", "--scope", "project", "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), "pass --force") {
+		t.Fatalf("expected refusal outside a project, got %v", err)
+	}
+
+	if !strings.Contains(output, "does not look like a project") {
+		t.Fatalf("expected warning, got %q", output)
+	}
+
+	if scopedTarget.installCalls != 0 {
+		t.Fatalf("expected no install, got %d", scopedTarget.installCalls)
+	}
+}
+
+func TestInstallCommandProjectScopeOutsideProjectWithForce(t *testing.T) {
+	scopedTarget := newFakeProjectScopedTarget()
+	overrideProjectScopeDependencies(t, t.TempDir(), scopedTarget)
+
+	output, err := executeInstallCommand(t, "demo-service", "--scope", "project", "--force", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected --force to proceed: %v", err)
+	}
+
+	if !strings.Contains(output, "does not look like a project") {
+		t.Fatalf("expected warning even with --force, got %q", output)
+	}
+
+	if scopedTarget.lastScope != targetpkg.ConfigScopeProject {
+		t.Fatalf("expected project scope install, got %q", scopedTarget.lastScope)
+	}
+}
+
+func TestInstallCommandProjectScopeOutsideProjectAsksForConfirmation(t *testing.T) {
+	scopedTarget := newFakeProjectScopedTarget()
+	overrideProjectScopeDependencies(t, t.TempDir(), scopedTarget)
+	isTerminalReader = func(_ io.Reader) bool { return true }
+
+	output, err := executeInstallCommandWithInput(t, "n\n", "demo-service", "--scope", "project")
+	if err != nil {
+		t.Fatalf("expected declined confirmation to exit cleanly: %v", err)
+	}
+
+	if !strings.Contains(output, "Write project config here anyway? [y/N]") || !strings.Contains(output, "Install cancelled.") {
+		t.Fatalf("expected confirmation prompt and cancellation, got %q", output)
+	}
+
+	if scopedTarget.installCalls != 0 {
+		t.Fatalf("expected no install after declining, got %d", scopedTarget.installCalls)
+	}
+}
+
+func TestInstallCommandProjectScopeInsideGitRepository(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatalf("create .git: %v", err)
+	}
+
+	nested := filepath.Join(root, "packages", "api")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("create nested dir: %v", err)
+	}
+
+	scopedTarget := newFakeProjectScopedTarget()
+	overrideProjectScopeDependencies(t, nested, scopedTarget)
+
+	output, err := executeInstallCommand(t, "demo-service", "--scope", "project", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected install inside a git repository to succeed: %v", err)
+	}
+
+	if strings.Contains(output, "does not look like a project") {
+		t.Fatalf("did not expect a warning inside a git repository, got %q", output)
+	}
+}
+
+func TestInstallCommandProjectScopeWithExistingProjectEntry(t *testing.T) {
+	scopedTarget := &fakeProjectEntryInstallTarget{
+		fakeScopedInstallTarget: newFakeProjectScopedTarget(),
+		hasProjectEntry:         true,
+	}
+	overrideProjectScopeDependencies(t, t.TempDir(), scopedTarget)
+
+	output, err := executeInstallCommand(t, "demo-service", "--scope", "project", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected install with an existing project entry to succeed: %v", err)
+	}
+
+	if strings.Contains(output, "does not look like a project") {
+		t.Fatalf("did not expect a warning with an existing project entry, got %q", output)
+	}
+}
+
+type fakeProjectDirInstallTarget struct {
+	*fakeScopedInstallTarget
+	projectDir string
+}
+
+func (t *fakeProjectDirInstallTarget) SetProjectDir(dir string) {
+	t.projectDir = dir
+}
+
+func newFakeProjectDirTarget() *fakeProjectDirInstallTarget {
+	return &fakeProjectDirInstallTarget{fakeScopedInstallTarget: newFakeProjectScopedTarget()}
+}
+
+func TestInstallCommandProjectDirPinsTargets(t *testing.T) {
+	projectDir := t.TempDir()
+	scopedTarget := newFakeProjectDirTarget()
+	overrideProjectScopeDependencies(t, t.TempDir(), scopedTarget)
+
+	output, err := executeInstallCommand(t, "demo-service", "--scope", "project", "--project-dir", projectDir, "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected install with --project-dir to succeed: %v", err)
+	}
+
+	if scopedTarget.projectDir != projectDir {
+		t.Fatalf("expected target to be pinned to %q, got %q", projectDir, scopedTarget.projectDir)
+	}
+
+	if strings.Contains(output, "does not look like a project") {
+		t.Fatalf("did not expect a warning for an explicit --project-dir, got %q", output)
+	}
+}
+
+func TestInstallCommandProjectDirMustExist(t *testing.T) {
+	scopedTarget := newFakeProjectDirTarget()
+	overrideProjectScopeDependencies(t, t.TempDir(), scopedTarget)
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	_, err := executeInstallCommand(t, "demo-service", "--scope", "project", "--project-dir", missing, "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), "is not a directory") {
+		t.Fatalf("expected missing directory error, got %v", err)
+	}
+}
+
+func TestInstallCommandProjectRootGitUsesRepositoryRoot(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("create .git: %v", err)
+	}
+
+	nested := filepath.Join(repo, "services", "api")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("create nested dir: %v", err)
+	}
+
+	scopedTarget := newFakeProjectDirTarget()
+	overrideProjectScopeDependencies(t, nested, scopedTarget)
+
+	if _, err := executeInstallCommand(t, "demo-service", "--scope", "project", "--project-root", "git", "--no-prompt"); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if scopedTarget.projectDir != repo {
+		t.Fatalf("expected target to be pinned to git root %q, got %q", repo, scopedTarget.projectDir)
+	}
+}
+
+func TestInstallCommandProjectRootFromConfig(t *testing.T) {
+	pkg := filepath.Join(t.TempDir(), "packages", "web")
+	nested := filepath.Join(pkg, "src")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("create nested dir: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(pkg, "package.json"), []byte("{}\n"), 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"project_root":"manifest"}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	scopedTarget := newFakeProjectDirTarget()
+	overrideProjectScopeDependencies(t, nested, scopedTarget)
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+
+	if _, err := executeInstallCommand(t, "demo-service", "--scope", "project", "--no-prompt"); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if scopedTarget.projectDir != pkg {
+		t.Fatalf("expected target to be pinned to manifest root %q, got %q", pkg, scopedTarget.projectDir)
+	}
+}
+
+func TestInstallCommandRejectsUnknownProjectRootStrategy(t *testing.T) {
+	scopedTarget := newFakeProjectDirTarget()
+	overrideProjectScopeDependencies(t, t.TempDir(), scopedTarget)
+
+	_, err := executeInstallCommand(t, "demo-service", "--scope", "project", "--project-root", "nearest", "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), "invalid project root strategy") {
+		t.Fatalf("expected strategy error, got %v", err)
+	}
+}
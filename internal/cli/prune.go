@@ -0,0 +1,606 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+// pruneProbeTimeout bounds each command, lookup, and connection prune runs
+// to check an entry.
+const pruneProbeTimeout = 5 * time.Second
+
+// pruneProbes are how prune checks the commands, packages, and servers of
+// entries, so tests can substitute them.
+type pruneProbes struct {
+	lookPath   func(file string) (string, error)
+	stat       func(name string) (os.FileInfo, error)
+	run        func(ctx context.Context, name string, args ...string) ([]byte, error)
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	dial       func(ctx context.Context, network string, address string) (net.Conn, error)
+}
+
+var newPruneProbes = func() pruneProbes {
+	var dialer net.Dialer
+
+	return pruneProbes{
+		lookPath: exec.LookPath,
+		stat:     os.Stat,
+		run: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return exec.CommandContext(ctx, name, args...).CombinedOutput()
+		},
+		lookupHost: net.DefaultResolver.LookupHost,
+		dial:       dialer.DialContext,
+	}
+}
+
+// orphanEntry is a service entry whose server can no longer start or be
+// reached.
+type orphanEntry struct {
+	service string
+	target  targetpkg.Target
+	scope   targetpkg.ConfigScope
+	reason  string
+}
+
+func init() {
+	registerCommand(newPruneCmd)
+}
+
+func newPruneCmd() *cobra.Command {
+	var targetSlugs []string
+	var offline bool
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Find and remove entries whose server is gone",
+		Long: `prune checks every service entry in the user and project configs of the
+selected targets and lists the ones whose server can no longer run:
+
+  - the command of a local server is missing, or not on PATH
+  - the script a node or python server runs does not exist, as when its
+    npm or pip package was uninstalled
+  - the npm package npx runs is not found in its registry
+  - the docker image is neither pulled nor found in its registry
+  - the host of a remote server does not exist, or refuses connections
+
+Only a definite answer counts: an entry whose check fails for another
+reason, such as a registry that needs a login, no network, or docker
+arguments prune cannot read, is listed as unchecked and never removed.
+
+On a terminal it then offers to remove the entries you pick; --yes removes
+them all without asking, and is required when stdin is not a terminal.
+Pass --offline to skip the checks that need the network. Entries that
+still hold ${VAR} placeholders are not checked, and system scope is never
+changed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			targetDefinitions, err := resolveInstallTargets(targetSlugs)
+			if err != nil {
+				return err
+			}
+
+			if _, err := applyProjectDir(cmd, targetDefinitions); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			output := cmd.OutOrStdout()
+			orphans := findOrphanEntries(cmd.Context(), output, targetDefinitions, newPruneProbes(), offline)
+			if len(orphans) == 0 {
+				fmt.Fprintln(output, "No orphaned entries found.")
+				return nil
+			}
+
+			fmt.Fprintln(output, "Orphaned entries:")
+			for i, orphan := range orphans {
+				fmt.Fprintf(output, "  %d) %s from %s, %s scope (%s)\n", i+1, orphan.service, orphan.target.Name(), orphan.scope, orphan.reason)
+			}
+
+			selected := orphans
+			if !yes {
+				input := cmd.InOrStdin()
+				if !isTerminalReader(input) {
+					return errors.New("cannot pick the entries to remove without a terminal; run again with --yes to remove them all")
+				}
+
+				selected, err = selectOrphanEntries(bufio.NewReader(input), output, orphans)
+				if err != nil || len(selected) == 0 {
+					return err
+				}
+			}
+
+			return removeOrphanEntries(output, targetDefinitions, selected)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Only check specific target slug(s) or @group(s); can be repeated")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Skip the checks that need the network: npm packages, docker registries, and remote servers")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Remove every orphaned entry without asking")
+	addProjectDirFlags(cmd)
+
+	return cmd
+}
+
+// findOrphanEntries checks every entry of targetDefinitions in the scopes
+// prune looks at and returns those whose server is gone. A config that
+// cannot be read, and an entry whose check is inconclusive, are reported
+// and skipped.
+func findOrphanEntries(ctx context.Context, output io.Writer, targetDefinitions []targetpkg.Target, probes pruneProbes, offline bool) []orphanEntry {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	checker := &orphanChecker{probes: probes, offline: offline, results: map[string]probeResult{}}
+
+	var orphans []orphanEntry
+	for _, targetDefinition := range targetDefinitions {
+		if _, ok := targetDefinition.(targetpkg.Explainer); !ok {
+			continue
+		}
+
+		for _, scope := range pruneScopes(targetDefinition) {
+			servers, err := targetpkg.ExportServers(targetDefinition, scope)
+			if err != nil {
+				fmt.Fprintf(output, "Skipping %s, %s scope: %v\n", targetDefinition.Name(), scope, err)
+				continue
+			}
+
+			names := make([]string, 0, len(servers))
+			for name := range servers {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				reason, err := checker.check(ctx, servers[name])
+				if err != nil {
+					fmt.Fprintf(output, "Could not check %s from %s, %s scope: %v\n", name, targetDefinition.Name(), scope, err)
+					continue
+				}
+
+				if reason != "" {
+					orphans = append(orphans, orphanEntry{service: name, target: targetDefinition, scope: scope, reason: reason})
+				}
+			}
+		}
+	}
+
+	return orphans
+}
+
+// pruneScopes returns the scopes of t that prune checks: every concrete
+// scope but system, which is never changed without --allow-system.
+func pruneScopes(t targetpkg.Target) []targetpkg.ConfigScope {
+	var scopes []targetpkg.ConfigScope
+	for _, scope := range listScopes(t) {
+		if scope != targetpkg.ConfigScopeSystem {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+// orphanChecker checks canonical entries, running each probe once per
+// command, package, image, or host however many entries share it.
+type orphanChecker struct {
+	probes  pruneProbes
+	offline bool
+	results map[string]probeResult
+}
+
+// probeResult is why a server is gone, or the error that kept a check from
+// giving a definite answer.
+type probeResult struct {
+	reason string
+	err    error
+}
+
+// check returns why the server of entry is gone, or "" when it looks fine
+// or is not checked. It returns an error when a check cannot tell, as when
+// a registry needs a login or the network is down, so the entry is never
+// taken for orphaned on a guess.
+func (c *orphanChecker) check(ctx context.Context, entry map[string]any) (string, error) {
+	if command, _ := entry["command"].(string); command != "" {
+		args, _ := entry["args"].([]string)
+		if strings.Contains(command, "${") {
+			return "", nil
+		}
+
+		return c.checkCommand(ctx, command, args)
+	}
+
+	rawURL, _ := entry["url"].(string)
+	if c.offline || strings.Contains(rawURL, "${") {
+		return "", nil
+	}
+
+	return c.checkURL(ctx, rawURL)
+}
+
+func (c *orphanChecker) checkCommand(ctx context.Context, command string, args []string) (string, error) {
+	if reason, err := c.memo("command "+command, func() (string, error) { return c.commandMissing(command), nil }); reason != "" || err != nil {
+		return reason, err
+	}
+
+	switch strings.TrimSuffix(filepath.Base(command), ".exe") {
+	case "node", "python", "python3", "deno", "bun":
+		for _, arg := range args {
+			if !filepath.IsAbs(arg) {
+				continue
+			}
+
+			if _, err := c.probes.stat(arg); errors.Is(err, os.ErrNotExist) {
+				return fmt.Sprintf("script %s does not exist", arg), nil
+			}
+		}
+	case "npx":
+		pkg := npxPackage(args)
+		if c.offline || pkg == "" || c.commandMissing("npm") != "" {
+			return "", nil
+		}
+
+		return c.memo("npm "+pkg, func() (string, error) {
+			// npm view reads the same .npmrc as npx, so a private package
+			// the user can install is found too. E404 is the only answer
+			// that means the package is gone; E401, E403, and network
+			// errors leave it unknown.
+			output, err := c.run(ctx, "npm", "view", pkg, "name")
+			switch {
+			case err == nil:
+				return "", nil
+			case strings.Contains(string(output), "E404"):
+				return fmt.Sprintf("npm package %s is not found in its registry", pkg), nil
+			default:
+				return "", fmt.Errorf("npm view %s failed: %w", pkg, probeError(output, err))
+			}
+		})
+	case "docker":
+		image, ok := dockerImage(args)
+		if !ok {
+			return "", errors.New("cannot tell the image from the docker arguments")
+		}
+
+		if image == "" {
+			return "", nil
+		}
+
+		return c.memo("docker "+image, func() (string, error) {
+			if _, err := c.run(ctx, "docker", "image", "inspect", image); err == nil || c.offline {
+				return "", nil
+			}
+
+			// A registry that needs a login answers with an authentication
+			// error, which says nothing about the image.
+			output, err := c.run(ctx, "docker", "manifest", "inspect", image)
+			switch {
+			case err == nil:
+				return "", nil
+			case strings.Contains(string(output), "no such manifest") || strings.Contains(string(output), "manifest unknown"):
+				return fmt.Sprintf("docker image %s is neither pulled nor found in its registry", image), nil
+			default:
+				return "", fmt.Errorf("docker manifest inspect %s failed: %w", image, probeError(output, err))
+			}
+		})
+	}
+
+	return "", nil
+}
+
+// commandMissing returns why command cannot be started: an absolute path
+// that does not exist, or a name that is not on PATH. Relative paths depend
+// on the directory the tool starts servers in and are not checked.
+func (c *orphanChecker) commandMissing(command string) string {
+	if filepath.IsAbs(command) {
+		if _, err := c.probes.stat(command); errors.Is(err, os.ErrNotExist) {
+			return fmt.Sprintf("command %s does not exist", command)
+		}
+
+		return ""
+	}
+
+	if filepath.Base(command) != command {
+		return ""
+	}
+
+	if _, err := c.probes.lookPath(command); err != nil {
+		return fmt.Sprintf("command %s is not on PATH", command)
+	}
+
+	return ""
+}
+
+// checkURL resolves and connects to the host of rawURL. Only a name the
+// DNS says does not exist, or a connection the host refuses, counts as
+// gone; a timeout or an unreachable network may pass once back online.
+func (c *orphanChecker) checkURL(ctx context.Context, rawURL string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || parsed.Hostname() == "" {
+		return "", nil
+	}
+
+	host := parsed.Hostname()
+	port := parsed.Port()
+	if port == "" {
+		port = "443"
+		if strings.EqualFold(parsed.Scheme, "http") || strings.EqualFold(parsed.Scheme, "ws") {
+			port = "80"
+		}
+	}
+
+	address := net.JoinHostPort(host, port)
+	return c.memo("host "+address, func() (string, error) {
+		probeCtx, cancel := context.WithTimeout(ctx, pruneProbeTimeout)
+		defer cancel()
+
+		if net.ParseIP(host) == nil {
+			if _, err := c.probes.lookupHost(probeCtx, host); err != nil {
+				var dnsErr *net.DNSError
+				if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+					return fmt.Sprintf("host %s does not exist", host), nil
+				}
+
+				return "", fmt.Errorf("cannot resolve %s: %w", host, err)
+			}
+		}
+
+		conn, err := c.probes.dial(probeCtx, "tcp", address)
+		if err != nil {
+			if errors.Is(err, syscall.ECONNREFUSED) {
+				return fmt.Sprintf("%s refuses connections", address), nil
+			}
+
+			return "", fmt.Errorf("cannot connect to %s: %w", address, err)
+		}
+		conn.Close()
+
+		return "", nil
+	})
+}
+
+func (c *orphanChecker) run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	probeCtx, cancel := context.WithTimeout(ctx, pruneProbeTimeout)
+	defer cancel()
+
+	return c.probes.run(probeCtx, name, args...)
+}
+
+// memo returns the result of probe for key, running it only the first time.
+func (c *orphanChecker) memo(key string, probe func() (string, error)) (string, error) {
+	if result, ok := c.results[key]; ok {
+		return result.reason, result.err
+	}
+
+	reason, err := probe()
+	c.results[key] = probeResult{reason: reason, err: err}
+	return reason, err
+}
+
+// probeError returns the first line a failed probe printed, or err when it
+// printed nothing.
+func probeError(output []byte, err error) error {
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return errors.New(line)
+		}
+	}
+
+	return err
+}
+
+// npxPackage returns the package an npx command line runs, without its
+// version: the --package value, or the first argument that is not a flag.
+func npxPackage(args []string) string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "-p" || arg == "--package":
+			if i+1 < len(args) {
+				return packageName(args[i+1])
+			}
+			return ""
+		case strings.HasPrefix(arg, "--package="):
+			return packageName(strings.TrimPrefix(arg, "--package="))
+		case strings.HasPrefix(arg, "-"):
+			continue
+		default:
+			return packageName(arg)
+		}
+	}
+
+	return ""
+}
+
+// packageName strips the version from an npm package reference such as
+// @scope/name@1.2.0.
+func packageName(reference string) string {
+	if at := strings.LastIndex(reference, "@"); at > 0 {
+		return reference[:at]
+	}
+
+	return reference
+}
+
+// dockerValueFlags are the docker run flags that take the next argument as
+// their value.
+var dockerValueFlags = map[string]bool{
+	"-a": true, "--attach": true, "--add-host": true, "--annotation": true,
+	"--blkio-weight": true, "--blkio-weight-device": true, "--cap-add": true,
+	"--cap-drop": true, "--cgroup-parent": true, "--cgroupns": true,
+	"--cidfile": true, "-c": true, "--cpu-shares": true, "--cpu-period": true,
+	"--cpu-quota": true, "--cpu-rt-period": true, "--cpu-rt-runtime": true,
+	"--cpus": true, "--cpuset-cpus": true, "--cpuset-mems": true,
+	"--detach-keys": true, "--device": true, "--device-cgroup-rule": true,
+	"--device-read-bps": true, "--device-read-iops": true,
+	"--device-write-bps": true, "--device-write-iops": true, "--dns": true,
+	"--dns-option": true, "--dns-search": true, "--domainname": true,
+	"--entrypoint": true, "-e": true, "--env": true, "--env-file": true,
+	"--expose": true, "--gpus": true, "--group-add": true, "--health-cmd": true,
+	"--health-interval": true, "--health-retries": true,
+	"--health-start-interval": true, "--health-start-period": true,
+	"--health-timeout": true, "-h": true, "--hostname": true, "--ip": true,
+	"--ip6": true, "--ipc": true, "--isolation": true, "--kernel-memory": true,
+	"-l": true, "--label": true, "--label-file": true, "--link": true,
+	"--link-local-ip": true, "--log-driver": true, "--log-opt": true,
+	"--mac-address": true, "-m": true, "--memory": true,
+	"--memory-reservation": true, "--memory-swap": true,
+	"--memory-swappiness": true, "--mount": true, "--name": true,
+	"--network": true, "--net": true, "--network-alias": true,
+	"--net-alias": true, "--oom-score-adj": true, "--pid": true,
+	"--pids-limit": true, "--platform": true, "-p": true, "--publish": true,
+	"--pull": true, "--restart": true, "--runtime": true,
+	"--security-opt": true, "--shm-size": true, "--stop-signal": true,
+	"--stop-timeout": true, "--storage-opt": true, "--sysctl": true,
+	"--tmpfs": true, "--ulimit": true, "-u": true, "--user": true,
+	"--userns": true, "--uts": true, "-v": true, "--volume": true,
+	"--volume-driver": true, "--volumes-from": true, "-w": true,
+	"--workdir": true,
+}
+
+// dockerBoolFlags are the docker run flags that take no value.
+var dockerBoolFlags = map[string]bool{
+	"-d": true, "--detach": true, "--disable-content-trust": true,
+	"--init": true, "-i": true, "--interactive": true, "--no-healthcheck": true,
+	"--oom-kill-disable": true, "--privileged": true, "-P": true,
+	"--publish-all": true, "-q": true, "--quiet": true, "--read-only": true,
+	"--rm": true, "--sig-proxy": true, "-t": true, "--tty": true,
+}
+
+// dockerImage returns the image a docker run command line starts, or ""
+// for any other docker command. ok is false for a run whose image cannot
+// be told for sure, as when it has a flag dockerImage does not know and so
+// cannot say whether the next argument is its value or the image.
+func dockerImage(args []string) (image string, ok bool) {
+	switch {
+	case len(args) > 0 && args[0] == "run":
+		args = args[1:]
+	case len(args) > 1 && args[0] == "container" && args[1] == "run":
+		args = args[2:]
+	case len(args) > 0 && strings.HasPrefix(args[0], "-"):
+		// Global flags such as --context come before the command.
+		return "", false
+	default:
+		return "", true
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return "", false
+		case !strings.HasPrefix(arg, "-"):
+			return arg, true
+		case strings.HasPrefix(arg, "--") && strings.Contains(arg, "="):
+			continue
+		case dockerValueFlags[arg]:
+			i++
+		case dockerBoolFlags[arg]:
+			continue
+		case !strings.HasPrefix(arg, "--") && dockerShortFlags(arg):
+			continue
+		default:
+			return "", false
+		}
+	}
+
+	return "", false
+}
+
+// dockerShortFlags reports whether arg is a known group of short flags,
+// such as -it, or a short flag with its value attached, such as -eTOKEN.
+func dockerShortFlags(arg string) bool {
+	if len(arg) < 2 {
+		return false
+	}
+
+	if dockerValueFlags[arg[:2]] {
+		return true
+	}
+
+	for _, letter := range arg[1:] {
+		if !dockerBoolFlags["-"+string(letter)] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// selectOrphanEntries asks which of orphans to remove. Enter, or the end of
+// input, picks none.
+func selectOrphanEntries(reader *bufio.Reader, output io.Writer, orphans []orphanEntry) ([]orphanEntry, error) {
+	for {
+		selection, err := readTrimmedLine(reader, output, "Entry numbers to remove [e.g. 1,3], \"all\", or Enter to skip: ")
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("read entry selection: %w", err)
+		}
+
+		if selection == "" {
+			return nil, nil
+		}
+
+		selected, err := parseEntrySelection(selection, orphans)
+		if err != nil {
+			fmt.Fprintf(output, "Invalid selection: %v\n", err)
+			continue
+		}
+
+		return selected, nil
+	}
+}
+
+// removeOrphanEntries uninstalls each selected entry and prints how many
+// were removed. It goes on after a failure and returns the failures
+// together.
+func removeOrphanEntries(output io.Writer, targetDefinitions []targetpkg.Target, selected []orphanEntry) error {
+	applyPruneEmpty(targetDefinitions, false)
+
+	removed := map[string]bool{}
+	var failures []error
+	for _, orphan := range selected {
+		err := checkUninstallPolicy(orphan.service, []targetpkg.Target{orphan.target}, orphan.scope)
+		if err == nil {
+			err = removeServiceEntry(orphan.service, orphan.target, orphan.scope)
+		}
+
+		if err != nil {
+			fmt.Fprintf(output, "  %s from %s, %s scope: failed (%v)\n", orphan.service, orphan.target.Name(), orphan.scope, err)
+			failures = append(failures, fmt.Errorf("service %q on target %q: %w", orphan.service, orphan.target.Slug(), err))
+			continue
+		}
+
+		fmt.Fprintf(output, "  %s from %s, %s scope: removed\n", orphan.service, orphan.target.Name(), orphan.scope)
+		removed[orphan.service] = true
+	}
+
+	for serviceName := range removed {
+		releaseServicePorts(serviceName)
+	}
+
+	fmt.Fprintf(output, "Removed %d of %d orphaned entries.\n", len(selected)-len(failures), len(selected))
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to remove %d of %d orphaned entries: %w", len(failures), len(selected), errors.Join(failures...))
+	}
+
+	return nil
+}
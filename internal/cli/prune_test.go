@@ -0,0 +1,385 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+type fakePruneTarget struct {
+	fakeListTarget
+	entries map[string]map[string]any
+	removed []string
+}
+
+func (t *fakePruneTarget) List() ([]string, error) {
+	names := make([]string, 0, len(t.entries))
+	for name := range t.entries {
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+func (t *fakePruneTarget) ExplainService(name string) ([]targetpkg.ScopeLayer, error) {
+	return []targetpkg.ScopeLayer{{Scope: targetpkg.ConfigScopeUser, Path: "/home/user/.alpha.json", Entry: t.entries[name]}}, nil
+}
+
+func (t *fakePruneTarget) Uninstall(name string) error {
+	t.removed = append(t.removed, name)
+	delete(t.entries, name)
+	return nil
+}
+
+// fakePruneProbes finds only the commands, files, and hosts it is given,
+// runs only the command lines in available, and records every command line.
+// Other command lines fail the way npm and docker do for a package or image
+// that does not exist, and other hosts do not exist.
+func fakePruneProbes(commands []string, files []string, hosts []string, available []string, ran *[]string) pruneProbes {
+	contains := func(values []string, value string) bool {
+		for _, candidate := range values {
+			if candidate == value {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return pruneProbes{
+		lookPath: func(file string) (string, error) {
+			if contains(commands, file) {
+				return "/usr/bin/" + file, nil
+			}
+
+			return "", errors.New("not found")
+		},
+		stat: func(name string) (os.FileInfo, error) {
+			if contains(files, name) {
+				return nil, nil
+			}
+
+			return nil, os.ErrNotExist
+		},
+		run: func(_ context.Context, name string, args ...string) ([]byte, error) {
+			commandLine := strings.Join(append([]string{name}, args...), " ")
+			if ran != nil {
+				*ran = append(*ran, commandLine)
+			}
+
+			if contains(available, commandLine) {
+				return nil, nil
+			}
+
+			switch {
+			case strings.HasPrefix(commandLine, "npm view"):
+				return []byte("npm error code E404\nnpm error 404 Not Found"), errors.New("exit status 1")
+			case strings.HasPrefix(commandLine, "docker manifest inspect"):
+				return []byte("no such manifest: " + args[len(args)-1]), errors.New("exit status 1")
+			default:
+				return []byte("Error: No such image"), errors.New("exit status 1")
+			}
+		},
+		lookupHost: func(_ context.Context, host string) ([]string, error) {
+			if contains(hosts, host) {
+				return []string{"192.0.2.1"}, nil
+			}
+
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		},
+		dial: func(context.Context, string, string) (net.Conn, error) {
+			client, server := net.Pipe()
+			server.Close()
+			return client, nil
+		},
+	}
+}
+
+func TestOrphanCheckerReportsMissingServers(t *testing.T) {
+	probes := fakePruneProbes(
+		[]string{"node", "npx", "npm", "docker"},
+		[]string{"/opt/tools/server.js"},
+		[]string{"mcp.example.com"},
+		[]string{"npm view jira-mcp name", "docker image inspect ghcr.io/acme/pulled"},
+		nil,
+	)
+
+	tests := []struct {
+		name  string
+		entry map[string]any
+		want  string
+	}{
+		{name: "command on PATH", entry: map[string]any{"command": "node", "args": []string{"/opt/tools/server.js"}}},
+		{name: "command not on PATH", entry: map[string]any{"command": "uvx"}, want: "command uvx is not on PATH"},
+		{name: "absolute command", entry: map[string]any{"command": "/opt/old/bin/server"}, want: "command /opt/old/bin/server does not exist"},
+		{name: "relative command", entry: map[string]any{"command": "./bin/server"}},
+		{name: "missing script", entry: map[string]any{"command": "node", "args": []string{"/usr/lib/node_modules/gone/index.js"}}, want: "script /usr/lib/node_modules/gone/index.js does not exist"},
+		{name: "published npm package", entry: map[string]any{"command": "npx", "args": []string{"-y", "jira-mcp@1.2.0"}}},
+		{name: "unpublished npm package", entry: map[string]any{"command": "npx", "args": []string{"-y", "@acme/gone"}}, want: "npm package @acme/gone is not found in its registry"},
+		{name: "pulled docker image", entry: map[string]any{"command": "docker", "args": []string{"run", "-i", "--rm", "ghcr.io/acme/pulled"}}},
+		{name: "missing docker image", entry: map[string]any{"command": "docker", "args": []string{"run", "-e", "TOKEN", "ghcr.io/acme/gone"}}, want: "docker image ghcr.io/acme/gone is neither pulled nor found in its registry"},
+		{name: "resolving host", entry: map[string]any{"url": "https://mcp.example.com/sse"}},
+		{name: "unknown host", entry: map[string]any{"url": "https://gone.example.com/mcp"}, want: "host gone.example.com does not exist"},
+		{name: "placeholder", entry: map[string]any{"command": "${SERVER_BIN}"}},
+	}
+
+	checker := &orphanChecker{probes: probes, results: map[string]probeResult{}}
+	for _, tt := range tests {
+		if got, err := checker.check(context.Background(), tt.entry); got != tt.want || err != nil {
+			t.Errorf("%s: check = %q (err %v), want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestOrphanCheckerReportsRefusedConnections(t *testing.T) {
+	probes := fakePruneProbes(nil, nil, []string{"mcp.example.com"}, nil, nil)
+	probes.dial = func(context.Context, string, string) (net.Conn, error) {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+	}
+
+	checker := &orphanChecker{probes: probes, results: map[string]probeResult{}}
+	if got, err := checker.check(context.Background(), map[string]any{"url": "http://mcp.example.com/mcp"}); got != "mcp.example.com:80 refuses connections" || err != nil {
+		t.Fatalf("unexpected reason %q (err %v)", got, err)
+	}
+}
+
+func TestOrphanCheckerLeavesInconclusiveChecksUnknown(t *testing.T) {
+	probes := fakePruneProbes([]string{"npx", "npm", "docker"}, nil, []string{"slow.example.com"}, nil, nil)
+	probes.run = func(_ context.Context, name string, _ ...string) ([]byte, error) {
+		if name == "npm" {
+			return []byte("npm error code E401\nnpm error Unable to authenticate"), errors.New("exit status 1")
+		}
+
+		return []byte("unauthorized: authentication required"), errors.New("exit status 1")
+	}
+	probes.lookupHost = func(_ context.Context, host string) ([]string, error) {
+		if host == "slow.example.com" {
+			return []string{"192.0.2.1"}, nil
+		}
+
+		return nil, &net.DNSError{Err: "server misbehaving", Name: host, IsTemporary: true}
+	}
+	probes.dial = func(context.Context, string, string) (net.Conn, error) {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ENETUNREACH)}
+	}
+
+	entries := map[string]map[string]any{
+		"private npm package":    {"command": "npx", "args": []string{"-y", "@acme/private"}},
+		"private docker image":   {"command": "docker", "args": []string{"run", "ghcr.io/acme/private"}},
+		"unparseable docker run": {"command": "docker", "args": []string{"run", "--some-new-flag", "value", "ghcr.io/acme/server"}},
+		"offline DNS":            {"url": "https://mcp.example.com/mcp"},
+		"unreachable network":    {"url": "https://slow.example.com/mcp"},
+	}
+
+	checker := &orphanChecker{probes: probes, results: map[string]probeResult{}}
+	for name, entry := range entries {
+		if got, err := checker.check(context.Background(), entry); got != "" || err == nil {
+			t.Errorf("%s: expected an inconclusive check, got %q (err %v)", name, got, err)
+		}
+	}
+}
+
+func TestOrphanCheckerOfflineSkipsNetworkChecks(t *testing.T) {
+	var ran []string
+	probes := fakePruneProbes([]string{"npx", "npm", "docker"}, nil, nil, nil, &ran)
+
+	checker := &orphanChecker{probes: probes, offline: true, results: map[string]probeResult{}}
+	entries := []map[string]any{
+		{"command": "npx", "args": []string{"-y", "@acme/gone"}},
+		{"command": "docker", "args": []string{"run", "ghcr.io/acme/gone"}},
+		{"url": "https://gone.example.com/mcp"},
+	}
+	for _, entry := range entries {
+		if got, err := checker.check(context.Background(), entry); got != "" || err != nil {
+			t.Errorf("expected %v to be skipped offline, got %q (err %v)", entry, got, err)
+		}
+	}
+
+	if strings.Join(ran, "\n") != "docker image inspect ghcr.io/acme/gone" {
+		t.Fatalf("expected only the local docker check to run, got %q", ran)
+	}
+}
+
+func TestNpxPackageAndDockerImage(t *testing.T) {
+	packages := map[string][]string{
+		"jira-mcp":         {"-y", "jira-mcp@latest"},
+		"@acme/server":     {"--package=@acme/server@2.0.0", "acme-server"},
+		"@acme/other":      {"-p", "@acme/other", "other"},
+		"":                 {"-y"},
+		"plain-mcp-server": {"plain-mcp-server", "--port", "3000"},
+	}
+	for want, args := range packages {
+		if got := npxPackage(args); got != want {
+			t.Errorf("npxPackage(%q) = %q, want %q", args, got, want)
+		}
+	}
+
+	images := map[string][]string{
+		"ghcr.io/acme/server:1": {"run", "-i", "--rm", "-e", "TOKEN", "-v", "/data:/data", "ghcr.io/acme/server:1", "--stdio"},
+		"ghcr.io/acme/limited":  {"run", "-it", "--pull", "always", "-m", "512m", "--cpus", "2", "--add-host", "db:10.0.0.2", "--device", "/dev/fuse", "--cap-add", "SYS_ADMIN", "ghcr.io/acme/limited"},
+		"ghcr.io/acme/attached": {"container", "run", "-eTOKEN", "--network=host", "ghcr.io/acme/attached"},
+		"":                      {"compose", "up"},
+	}
+	for want, args := range images {
+		if got, ok := dockerImage(args); got != want || !ok {
+			t.Errorf("dockerImage(%q) = %q (ok %v), want %q", args, got, ok, want)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"run", "--some-new-flag", "value", "ghcr.io/acme/server"},
+		{"--context", "remote", "run", "ghcr.io/acme/server"},
+		{"run", "-i", "--rm"},
+	} {
+		if got, ok := dockerImage(args); ok {
+			t.Errorf("expected dockerImage(%q) to be unsure, got %q", args, got)
+		}
+	}
+}
+
+func setUpPruneCommand(t *testing.T) *fakePruneTarget {
+	t.Helper()
+
+	restore := overrideUninstallCommandDependencies(t)
+	t.Cleanup(restore)
+
+	pruneTarget := &fakePruneTarget{
+		fakeListTarget: fakeListTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true},
+		entries: map[string]map[string]any{
+			"jira":   {"command": "npx", "args": []any{"-y", "jira-mcp"}},
+			"legacy": {"command": "/opt/legacy/bin/server"},
+		},
+	}
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		return pruneTarget, slug == "alpha-cli"
+	}
+
+	original := newPruneProbes
+	newPruneProbes = func() pruneProbes {
+		return fakePruneProbes([]string{"npx", "npm"}, nil, nil, []string{"npm view jira-mcp name"}, nil)
+	}
+	t.Cleanup(func() { newPruneProbes = original })
+
+	return pruneTarget
+}
+
+func executePruneCommand(t *testing.T, input string, args ...string) (string, error) {
+	t.Helper()
+
+	pruneCmd := newPruneCmd()
+	var stdout, stderr bytes.Buffer
+
+	pruneCmd.SetOut(&stdout)
+	pruneCmd.SetErr(&stderr)
+	pruneCmd.SetIn(strings.NewReader(input))
+	pruneCmd.SetArgs(args)
+
+	err := pruneCmd.Execute()
+	output := stdout.String() + stderr.String()
+
+	return output, err
+}
+
+func TestPruneCommandRemovesOrphanedEntriesWithYes(t *testing.T) {
+	pruneTarget := setUpPruneCommand(t)
+
+	output, err := executePruneCommand(t, "", "--target", "alpha-cli", "--yes")
+	if err != nil {
+		t.Fatalf("expected prune to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "1) legacy from Alpha CLI, user scope (command /opt/legacy/bin/server does not exist)") {
+		t.Fatalf("expected the orphaned entry to be listed, got %q", output)
+	}
+
+	if strings.Join(pruneTarget.removed, ",") != "legacy" {
+		t.Fatalf("expected only legacy to be removed, got %v", pruneTarget.removed)
+	}
+
+	if !strings.Contains(output, "Removed 1 of 1 orphaned entries.") {
+		t.Fatalf("expected removal summary, got %q", output)
+	}
+}
+
+func TestPruneCommandAsksOnTerminal(t *testing.T) {
+	pruneTarget := setUpPruneCommand(t)
+	isTerminalReader = func(_ io.Reader) bool { return true }
+
+	output, err := executePruneCommand(t, "\n", "--target", "alpha-cli")
+	if err != nil {
+		t.Fatalf("expected prune to succeed: %v", err)
+	}
+
+	if len(pruneTarget.removed) != 0 {
+		t.Fatalf("expected Enter to skip removal, got %v", pruneTarget.removed)
+	}
+
+	if !strings.Contains(output, "Entry numbers to remove") {
+		t.Fatalf("expected selection prompt, got %q", output)
+	}
+
+	if _, err := executePruneCommand(t, "1\n", "--target", "alpha-cli"); err != nil {
+		t.Fatalf("expected prune to succeed: %v", err)
+	}
+
+	if strings.Join(pruneTarget.removed, ",") != "legacy" {
+		t.Fatalf("expected the picked entry to be removed, got %v", pruneTarget.removed)
+	}
+}
+
+func TestPruneCommandFailsWithoutTerminalOrYes(t *testing.T) {
+	pruneTarget := setUpPruneCommand(t)
+	isTerminalReader = func(_ io.Reader) bool { return false }
+
+	output, err := executePruneCommand(t, "", "--target", "alpha-cli")
+	if err == nil || !strings.Contains(err.Error(), "run again with --yes") {
+		t.Fatalf("expected prune without a terminal to fail with a --yes hint, got %v", err)
+	}
+
+	if len(pruneTarget.removed) != 0 {
+		t.Fatalf("expected nothing to be removed, got %v", pruneTarget.removed)
+	}
+
+	if !strings.Contains(output, "1) legacy from Alpha CLI") {
+		t.Fatalf("expected the orphaned entries to be listed, got %q", output)
+	}
+}
+
+func TestPruneCommandNeverRemovesUncheckedEntries(t *testing.T) {
+	pruneTarget := setUpPruneCommand(t)
+	pruneTarget.entries["private"] = map[string]any{"command": "npx", "args": []any{"-y", "@acme/private"}}
+	newPruneProbes = func() pruneProbes {
+		probes := fakePruneProbes([]string{"npx", "npm"}, nil, nil, []string{"npm view jira-mcp name"}, nil)
+		run := probes.run
+		probes.run = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			if strings.Contains(strings.Join(args, " "), "@acme/private") {
+				return []byte("npm error code E401"), errors.New("exit status 1")
+			}
+
+			return run(ctx, name, args...)
+		}
+
+		return probes
+	}
+
+	output, err := executePruneCommand(t, "", "--target", "alpha-cli", "--yes")
+	if err != nil {
+		t.Fatalf("expected prune to succeed: %v", err)
+	}
+
+	if strings.Join(pruneTarget.removed, ",") != "legacy" {
+		t.Fatalf("expected only the definite orphan to be removed, got %v", pruneTarget.removed)
+	}
+
+	if !strings.Contains(output, "Could not check private from Alpha CLI, user scope: npm view @acme/private failed: npm error code E401") {
+		t.Fatalf("expected the unchecked entry to be reported, got %q", output)
+	}
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/andreagrandi/mcp-wire/internal/format"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var newRecentStore = func() *state.RecentStore { return state.NewRecentStore("") }
+
+func init() {
+	registerCommand(newRecentCmd)
+}
+
+func newRecentCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "recent",
+		Short: "List recently installed services",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return listRecentServices(cmd.OutOrStdout())
+		},
+	}
+}
+
+func listRecentServices(output io.Writer) error {
+	services, err := newRecentStore().List()
+	if err != nil {
+		return err
+	}
+
+	if len(services) == 0 {
+		fmt.Fprintln(output, "No recently installed services.")
+		return nil
+	}
+
+	fmt.Fprintln(output, "Recently installed services:")
+	fmt.Fprintln(output)
+
+	maxNameWidth := 0
+	for _, svc := range services {
+		if len(svc.Name) > maxNameWidth {
+			maxNameWidth = len(svc.Name)
+		}
+	}
+
+	for _, svc := range services {
+		fmt.Fprintf(output, "  %-*s  %s\n", maxNameWidth, svc.Name, format.Timestamp(svc.UsedAt))
+	}
+
+	return nil
+}
+
+// recordRecentService remembers a successful install. Failures are ignored:
+// the recent list is a convenience and must never fail an install.
+func recordRecentService(name string) {
+	_ = newRecentStore().Record(name)
+}
+
+func recentServiceNames() []string {
+	names, err := newRecentStore().Names()
+	if err != nil {
+		return nil
+	}
+
+	return names
+}
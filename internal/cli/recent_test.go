@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/state"
+)
+
+func overrideRecentStore(t *testing.T) *state.RecentStore {
+	t.Helper()
+
+	original := newRecentStore
+	store := state.NewRecentStore(filepath.Join(t.TempDir(), "recent.json"))
+	newRecentStore = func() *state.RecentStore { return store }
+	t.Cleanup(func() { newRecentStore = original })
+
+	return store
+}
+
+func TestListRecentServicesEmpty(t *testing.T) {
+	overrideRecentStore(t)
+
+	buf := new(bytes.Buffer)
+	if err := listRecentServices(buf); err != nil {
+		t.Fatalf("expected recent to succeed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "No recently installed services.") {
+		t.Fatalf("unexpected output %q", buf.String())
+	}
+}
+
+func TestListRecentServicesMostRecentFirst(t *testing.T) {
+	store := overrideRecentStore(t)
+
+	for _, name := range []string{"jira", "sentry"} {
+		if err := store.Record(name); err != nil {
+			t.Fatalf("expected record to succeed: %v", err)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := listRecentServices(buf); err != nil {
+		t.Fatalf("expected recent to succeed: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Index(output, "sentry") > strings.Index(output, "jira") {
+		t.Fatalf("expected sentry listed before jira, got %q", output)
+	}
+}
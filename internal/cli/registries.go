@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"sort"
+
+	"github.com/andreagrandi/mcp-wire/internal/registry"
+)
+
+// registrySource is an MCP registry services are read from.
+type registrySource struct {
+	name    string
+	baseURL string
+}
+
+// registrySources returns the enabled registries in priority order: the
+// ones listed under "registries" in the config and the official registry,
+// which comes after them unless it is listed itself. Lower priorities come
+// first; equal ones keep that order.
+var registrySources = func() []registrySource {
+	official := registryWithPriority{registrySource: registrySource{name: registry.OfficialName, baseURL: registry.DefaultBaseURL}, enabled: true}
+
+	var listed []registryWithPriority
+	officialListed := false
+	if cfg, err := loadConfig(); err == nil {
+		for _, settings := range cfg.Registries() {
+			entry := registryWithPriority{
+				registrySource: registrySource{name: settings.Name, baseURL: settings.URL},
+				enabled:        settings.Enabled,
+				priority:       settings.Priority,
+			}
+
+			if settings.Name == registry.OfficialName {
+				officialListed = true
+				if entry.baseURL == "" {
+					entry.baseURL = registry.DefaultBaseURL
+				}
+			}
+
+			listed = append(listed, entry)
+		}
+	}
+
+	if !officialListed {
+		listed = append(listed, official)
+	}
+
+	sort.SliceStable(listed, func(i, j int) bool { return listed[i].priority < listed[j].priority })
+
+	sources := make([]registrySource, 0, len(listed))
+	for _, entry := range listed {
+		if entry.enabled {
+			sources = append(sources, entry.registrySource)
+		}
+	}
+
+	return sources
+}
+
+type registryWithPriority struct {
+	registrySource
+	enabled  bool
+	priority int
+}
+
+// registryBaseURL returns the base URL of the registry called name, or the
+// official one when name is empty or no longer configured.
+func registryBaseURL(name string) string {
+	for _, source := range registrySources() {
+		if source.name == name {
+			return source.baseURL
+		}
+	}
+
+	return registry.DefaultBaseURL
+}
+
+// registrySourceNames returns the names of the enabled registries, in
+// priority order.
+func registrySourceNames() []string {
+	sources := registrySources()
+	names := make([]string, 0, len(sources))
+	for _, source := range sources {
+		names = append(names, source.name)
+	}
+
+	return names
+}
+
+// loadRegistryCaches returns the cached servers of every source, in
+// priority order.
+func loadRegistryCaches(sources []registrySource) []registry.ServerResponse {
+	var servers []registry.ServerResponse
+	for _, cache := range openRegistryCaches(sources) {
+		servers = append(servers, cache.All()...)
+	}
+
+	return servers
+}
+
+// openRegistryCaches returns the cache of every source, in priority order,
+// loaded from disk and ready to sync. A cache that cannot be read is empty.
+func openRegistryCaches(sources []registrySource) []*registry.Cache {
+	caches := make([]*registry.Cache, len(sources))
+	for i, source := range sources {
+		caches[i] = registry.NewCacheFor(registry.NewClientWithBaseURL(source.baseURL), source.name)
+		_ = caches[i].Load()
+	}
+
+	return caches
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	"github.com/andreagrandi/mcp-wire/internal/registry"
+)
+
+func TestRegistrySourcesOrdersByPriority(t *testing.T) {
+	original := loadConfig
+	t.Cleanup(func() { loadConfig = original })
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	content := `{"registries":[
+		{"name":"staging","url":"https://staging.example.com","enabled":false},
+		{"name":"corp","url":"https://registry.example.com","priority":-1},
+		{"name":"mirror","url":"https://mirror.example.com"}
+	]}`
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+
+	want := []registrySource{
+		{name: "corp", baseURL: "https://registry.example.com"},
+		{name: "mirror", baseURL: "https://mirror.example.com"},
+		{name: registry.OfficialName, baseURL: registry.DefaultBaseURL},
+	}
+	if got := registrySources(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+
+	if got := registryBaseURL("mirror"); got != "https://mirror.example.com" {
+		t.Fatalf("expected mirror base URL, got %q", got)
+	}
+
+	if got := registryBaseURL("staging"); got != registry.DefaultBaseURL {
+		t.Fatalf("expected a disabled registry to fall back to the official one, got %q", got)
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/andreagrandi/mcp-wire/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+// refreshRegistryCache syncs the cache of source, fetching every server
+// again when full is set, and returns how many servers it holds.
+var refreshRegistryCache = func(source registrySource, full bool) (int, error) {
+	cache := registry.NewCacheFor(registry.NewClientWithBaseURL(source.baseURL), source.name)
+	if err := cache.Load(); err != nil {
+		return 0, err
+	}
+
+	sync := cache.Sync
+	if full {
+		sync = cache.SyncFull
+	}
+
+	err := sync()
+	return cache.Count(), err
+}
+
+func init() {
+	registerCommand(newRegistryCmd)
+}
+
+func newRegistryCmd() *cobra.Command {
+	registryCmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Manage the MCP Registry cache",
+	}
+
+	registryCmd.AddCommand(newRegistryRefreshCmd())
+	return registryCmd
+}
+
+func newRegistryRefreshCmd() *cobra.Command {
+	var full bool
+
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Refresh the registry cache now",
+		Long: `Refresh the cache of every enabled registry now, fetching the servers
+published or updated since the last sync. With --full, every server is fetched
+again, which also drops servers a registry no longer lists.
+
+The cache is otherwise refreshed in the background once it is older than
+registry.cache_ttl in the mcp-wire config (default 24h).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			sources := registrySources()
+			failed := 0
+			for _, source := range sources {
+				count, err := refreshRegistryCache(source, full)
+				if err != nil {
+					failed++
+					fmt.Fprintf(cmd.ErrOrStderr(), "Could not refresh registry %q: %v (%d servers cached)\n", source.name, err, count)
+					continue
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Registry %q refreshed: %d servers\n", source.name, count)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("could not refresh %d of %d registries", failed, len(sources))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&full, "full", false, "fetch every server again instead of only the updated ones")
+
+	return cmd
+}
@@ -1,9 +1,14 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	"github.com/andreagrandi/mcp-wire/internal/format"
 	"github.com/andreagrandi/mcp-wire/internal/registry"
 )
 
@@ -23,11 +28,56 @@ type registrySyncState struct {
 
 	err error
 
-	servers []registry.ServerResponse
+	// ttl is the registry.cache_ttl setting the sync ran with, and syncedAt
+	// the last sync of the least recently synced cache holding servers.
+	ttl      time.Duration
+	syncedAt time.Time
+
+	// servers is replaced, never modified, so readers can share it.
+	// serversVersion counts the replacements.
+	servers        []registry.ServerResponse
+	serversVersion uint64
 }
 
 var backgroundRegistrySync registrySyncState
 
+// registryCacheTTL returns the registry.cache_ttl setting.
+var registryCacheTTL = func() time.Duration {
+	cfg, err := loadConfig()
+	if err != nil {
+		return config.DefaultRegistryCacheTTL
+	}
+
+	return cfg.RegistryCacheTTL()
+}
+
+// registryCacheDue reports whether cache needs an automatic sync: it was
+// never synced, or it is older than ttl and automatic refresh is on.
+func registryCacheDue(cache *registry.Cache, ttl time.Duration) bool {
+	if cache.LastSynced().IsZero() {
+		return true
+	}
+
+	return ttl >= 0 && cache.Stale(ttl)
+}
+
+// oldestRegistrySync returns the last sync time of the least recently
+// synced cache holding servers, or the zero time when none holds any.
+func oldestRegistrySync(caches []*registry.Cache) time.Time {
+	var oldest time.Time
+	for _, cache := range caches {
+		if cache.Count() == 0 {
+			continue
+		}
+
+		if synced := cache.LastSynced(); oldest.IsZero() || synced.Before(oldest) {
+			oldest = synced
+		}
+	}
+
+	return oldest
+}
+
 func maybeStartRegistryBackgroundSync() {
 	cfg, err := loadConfig()
 	if err != nil || !cfg.IsFeatureEnabled("registry") {
@@ -43,76 +93,116 @@ func ensureRegistrySyncStarted(registryEnabled bool) {
 	}
 
 	backgroundRegistrySync.once.Do(func() {
-		seed := registry.NewCache(nil)
-		if err := seed.Load(); err == nil {
-			snapshot := seed.All()
-			backgroundRegistrySync.mu.Lock()
-			backgroundRegistrySync.servers = snapshot
-			backgroundRegistrySync.cached = len(snapshot)
-			backgroundRegistrySync.mu.Unlock()
+		sources := registrySources()
+		caches := openRegistryCaches(sources)
+		ttl := registryCacheTTL()
+
+		due := false
+		var snapshot []registry.ServerResponse
+		for _, cache := range caches {
+			snapshot = append(snapshot, cache.All()...)
+			due = due || registryCacheDue(cache, ttl)
 		}
 
 		backgroundRegistrySync.mu.Lock()
+		if len(snapshot) > 0 {
+			backgroundRegistrySync.servers = snapshot
+			backgroundRegistrySync.serversVersion++
+			backgroundRegistrySync.cached = len(snapshot)
+		}
 		backgroundRegistrySync.started = true
-		backgroundRegistrySync.syncing = true
+		backgroundRegistrySync.syncing = due
+		backgroundRegistrySync.ttl = ttl
+		backgroundRegistrySync.syncedAt = oldestRegistrySync(caches)
 		backgroundRegistrySync.mu.Unlock()
 
-		go runRegistryBackgroundSync()
+		if due {
+			go runRegistryBackgroundSync(sources, caches, ttl)
+		}
 	})
 }
 
-func runRegistryBackgroundSync() {
-	cache := registry.NewCache(registry.NewClient())
-	cache.SetSyncProgressCallback(func(progress registry.SyncProgress, snapshot []registry.ServerResponse) {
-		backgroundRegistrySync.mu.Lock()
-		defer backgroundRegistrySync.mu.Unlock()
-
-		backgroundRegistrySync.syncing = true
-		backgroundRegistrySync.mode = progress.Mode
-		backgroundRegistrySync.pages = progress.Pages
-		backgroundRegistrySync.fetched = progress.Fetched
-		backgroundRegistrySync.updated = progress.Updated
-		backgroundRegistrySync.cached = progress.Cached
-		backgroundRegistrySync.servers = snapshot
-		backgroundRegistrySync.err = nil
-	})
+// runRegistryBackgroundSync syncs the cache of each registry that is due in
+// turn and publishes the servers of all of them, in priority order, after
+// every page. A registry that fails keeps its stale cache; the others still
+// sync.
+func runRegistryBackgroundSync(sources []registrySource, caches []*registry.Cache, ttl time.Duration) {
+	snapshots := make([][]registry.ServerResponse, len(caches))
+	for i, cache := range caches {
+		snapshots[i] = cache.All()
+	}
+
+	publish := func() {
+		var servers []registry.ServerResponse
+		for _, snapshot := range snapshots {
+			servers = append(servers, snapshot...)
+		}
+
+		backgroundRegistrySync.servers = servers
+		backgroundRegistrySync.serversVersion++
+		backgroundRegistrySync.cached = len(servers)
+	}
+
+	var syncErrors []error
+	for i, cache := range caches {
+		if !registryCacheDue(cache, ttl) {
+			continue
+		}
+
+		cache.SetSyncProgressCallback(func(progress registry.SyncProgress, snapshot []registry.ServerResponse) {
+			backgroundRegistrySync.mu.Lock()
+			defer backgroundRegistrySync.mu.Unlock()
+
+			snapshots[i] = snapshot
+			backgroundRegistrySync.syncing = true
+			backgroundRegistrySync.mode = progress.Mode
+			backgroundRegistrySync.pages = progress.Pages
+			backgroundRegistrySync.fetched = progress.Fetched
+			backgroundRegistrySync.updated = progress.Updated
+			backgroundRegistrySync.err = nil
+			publish()
+		})
+
+		if err := cache.Sync(); err != nil {
+			syncErrors = append(syncErrors, fmt.Errorf("registry %q: %w", sources[i].name, err))
+		}
 
-	if err := cache.Load(); err == nil {
-		snapshot := cache.All()
 		backgroundRegistrySync.mu.Lock()
-		backgroundRegistrySync.servers = snapshot
-		backgroundRegistrySync.cached = len(snapshot)
+		snapshots[i] = cache.All()
+		publish()
 		backgroundRegistrySync.mu.Unlock()
 	}
 
-	syncErr := cache.Sync()
-	finalSnapshot := cache.All()
-
 	backgroundRegistrySync.mu.Lock()
-	backgroundRegistrySync.servers = finalSnapshot
-	backgroundRegistrySync.cached = len(finalSnapshot)
 	backgroundRegistrySync.syncing = false
-	backgroundRegistrySync.err = syncErr
+	backgroundRegistrySync.err = errors.Join(syncErrors...)
+	backgroundRegistrySync.syncedAt = oldestRegistrySync(caches)
 	backgroundRegistrySync.mu.Unlock()
 }
 
+// loadRegistryServersSnapshot returns the servers the background sync last
+// published. The slice is shared and must be treated as read-only.
 func loadRegistryServersSnapshot() []registry.ServerResponse {
 	backgroundRegistrySync.mu.RLock()
 	started := backgroundRegistrySync.started
-	servers := make([]registry.ServerResponse, len(backgroundRegistrySync.servers))
-	copy(servers, backgroundRegistrySync.servers)
+	servers := backgroundRegistrySync.servers
 	backgroundRegistrySync.mu.RUnlock()
 
 	if started {
 		return servers
 	}
 
-	cache := registry.NewCache(nil)
-	if err := cache.Load(); err != nil {
-		return nil
-	}
+	return loadRegistryCaches(registrySources())
+}
 
-	return cache.All()
+// registryServersVersion returns how many times the background sync has
+// published a new server list, so callers can tell when data they built from
+// it is out of date.
+func registryServersVersion() uint64 {
+	backgroundRegistrySync.mu.RLock()
+	defer backgroundRegistrySync.mu.RUnlock()
+
+	return backgroundRegistrySync.serversVersion
 }
 
 func registrySyncStatusLine(registryEnabled bool) string {
@@ -128,6 +218,8 @@ func registrySyncStatusLine(registryEnabled bool) string {
 	updated := backgroundRegistrySync.updated
 	cached := backgroundRegistrySync.cached
 	err := backgroundRegistrySync.err
+	ttl := backgroundRegistrySync.ttl
+	syncedAt := backgroundRegistrySync.syncedAt
 	backgroundRegistrySync.mu.RUnlock()
 
 	if !started {
@@ -150,9 +242,34 @@ func registrySyncStatusLine(registryEnabled bool) string {
 		return "Registry sync in background"
 	}
 
+	outdated := ""
+	if cached > 0 {
+		outdated = registryCacheOutdated(syncedAt, ttl)
+	}
+
 	if err != nil {
+		if outdated != "" {
+			return fmt.Sprintf("Registry sync failed; using cached results (%d servers, cache is %s old)", cached, outdated)
+		}
+
 		return fmt.Sprintf("Registry sync failed; using cached results (%d servers)", cached)
 	}
 
+	if outdated != "" {
+		return fmt.Sprintf("Registry cache is %s old; run \"mcp-wire registry refresh\" to update it", outdated)
+	}
+
 	return ""
 }
+
+// registryCacheOutdated returns how old a cache last synced at syncedAt is,
+// in days, when it is at least a day old and older than ttl allows. It
+// returns "" for a cache that is recent enough.
+func registryCacheOutdated(syncedAt time.Time, ttl time.Duration) string {
+	age, ok := clock.Age(wallClock, syncedAt)
+	if !ok || age < 24*time.Hour || (ttl >= 0 && age <= ttl) {
+		return ""
+	}
+
+	return format.Duration(age)
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+)
+
+func TestRegistryRefreshCommandRefreshesEverySource(t *testing.T) {
+	originalSources := registrySources
+	originalRefresh := refreshRegistryCache
+	t.Cleanup(func() {
+		registrySources = originalSources
+		refreshRegistryCache = originalRefresh
+	})
+
+	registrySources = func() []registrySource {
+		return []registrySource{{name: "corp", baseURL: "https://registry.example.com"}, {name: "official"}}
+	}
+
+	var refreshed []string
+	refreshRegistryCache = func(source registrySource, full bool) (int, error) {
+		refreshed = append(refreshed, source.name)
+		if !full {
+			t.Fatalf("expected --full to be passed on")
+		}
+
+		if source.name == "official" {
+			return 3, errors.New("network timeout")
+		}
+
+		return 12, nil
+	}
+
+	stdout, stderr, err := executeCommandWithOutputFlags(t, newRegistryRefreshCmd(), "--full")
+	if err == nil || err.Error() != "could not refresh 1 of 2 registries" {
+		t.Fatalf("expected one failed registry, got %v", err)
+	}
+
+	if len(refreshed) != 2 || !strings.Contains(stdout, `Registry "corp" refreshed: 12 servers`) {
+		t.Fatalf("expected every registry refreshed, got %v %q", refreshed, stdout)
+	}
+
+	if !strings.Contains(stderr, `Could not refresh registry "official": network timeout (3 servers cached)`) {
+		t.Fatalf("expected the failure reported, got %q", stderr)
+	}
+}
+
+func TestRegistrySyncStatusLineReportsOutdatedCache(t *testing.T) {
+	originalClock := wallClock
+	t.Cleanup(func() {
+		wallClock = originalClock
+		backgroundRegistrySync = registrySyncState{}
+	})
+
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	wallClock = clock.Fixed(now)
+
+	backgroundRegistrySync = registrySyncState{
+		started:  true,
+		cached:   40,
+		ttl:      time.Hour,
+		syncedAt: now.Add(-12 * 24 * time.Hour),
+	}
+
+	if got := registrySyncStatusLine(true); got != `Registry cache is 12 days old; run "mcp-wire registry refresh" to update it` {
+		t.Fatalf("unexpected status line %q", got)
+	}
+
+	backgroundRegistrySync.err = errors.New("offline")
+	if got := registrySyncStatusLine(true); got != "Registry sync failed; using cached results (40 servers, cache is 12 days old)" {
+		t.Fatalf("unexpected status line %q", got)
+	}
+
+	backgroundRegistrySync.err = nil
+	backgroundRegistrySync.ttl = 30 * 24 * time.Hour
+	if got := registrySyncStatusLine(true); got != "" {
+		t.Fatalf("expected no status line within the TTL, got %q", got)
+	}
+}
+
+func TestRegistryCommandsSkipBackgroundRegistrySync(t *testing.T) {
+	for _, args := range [][]string{
+		{"mcp-wire", "registry", "refresh"},
+		{"mcp-wire", "cache", "clear"},
+	} {
+		if !isCacheCommand(args) {
+			t.Fatalf("expected %q to skip the background registry sync", args)
+		}
+	}
+
+	if isCacheCommand([]string{"mcp-wire", "install", "sentry"}) {
+		t.Fatal("expected install to start the background registry sync")
+	}
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/format"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+var newBackupStore = func() *state.BackupStore { return state.NewBackupStore("") }
+
+// backupOperation identifies the backups of the operation in progress: each
+// config file it writes is saved once, as it was before the operation
+// started. A CLI command is one operation; the TUI starts one per apply.
+var backupOperation struct {
+	sync.Mutex
+	startedAt time.Time
+}
+
+func startBackupOperation() {
+	backupOperation.Lock()
+	defer backupOperation.Unlock()
+
+	backupOperation.startedAt = wallClock.Now()
+}
+
+func backupOperationStartedAt() time.Time {
+	backupOperation.Lock()
+	defer backupOperation.Unlock()
+
+	if backupOperation.startedAt.IsZero() {
+		backupOperation.startedAt = wallClock.Now()
+	}
+
+	return backupOperation.startedAt
+}
+
+// applyConfigBackups makes every target save its config files in the
+// backup store before writing them.
+func applyConfigBackups(targetDefinitions []target.Target) {
+	for _, targetDefinition := range targetDefinitions {
+		setter, ok := targetDefinition.(target.ConfigBackupSetter)
+		if !ok {
+			continue
+		}
+
+		slug := targetDefinition.Slug()
+		setter.SetConfigBackup(func(configPath string) error {
+			return newBackupStore().Save(slug, backupOperationStartedAt(), configPath)
+		})
+	}
+}
+
+func init() {
+	registerCommand(newRollbackCmd)
+}
+
+func newRollbackCmd() *cobra.Command {
+	var targetSlug string
+
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Restore target configs from before the last change",
+		Long: `rollback restores the config files mcp-wire changed in its most recent
+operation, on every target it touched, to the content they had before.
+
+Before writing a target config, mcp-wire copies it into
+~/.local/state/mcp-wire/backups/<target>/<timestamp>. Each rollback restores
+one backup and removes it, so running rollback again goes one operation
+further back. With --target only that target's latest backup is restored.`,
+		Example: `  mcp-wire rollback
+  mcp-wire rollback --target claude`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			backups, err := rollbackBackups(targetSlug)
+			if err != nil {
+				return err
+			}
+
+			return restoreBackups(cmd.OutOrStdout(), backups)
+		},
+	}
+
+	cmd.Flags().StringVar(&targetSlug, "target", "", "Roll back only this target (e.g. claude)")
+
+	return cmd
+}
+
+// rollbackBackups returns the latest backup of targetSlug or, without one,
+// every backup of the most recent operation.
+func rollbackBackups(targetSlug string) ([]state.Backup, error) {
+	store := newBackupStore()
+
+	if strings.TrimSpace(targetSlug) != "" {
+		targetDefinition, found := lookupTarget(targetSlug)
+		if !found {
+			return nil, fmt.Errorf("target %q is not known", strings.ToLower(strings.TrimSpace(targetSlug)))
+		}
+
+		backup, err := store.Latest(targetDefinition.Slug())
+		if errors.Is(err, state.ErrNoBackup) {
+			return nil, fmt.Errorf("no backup of %s to roll back to", targetDefinition.Name())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return []state.Backup{backup}, nil
+	}
+
+	var latest []state.Backup
+	for _, targetDefinition := range allTargets() {
+		backup, err := store.Latest(targetDefinition.Slug())
+		if errors.Is(err, state.ErrNoBackup) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case len(latest) == 0 || backup.CreatedAt.After(latest[0].CreatedAt):
+			latest = []state.Backup{backup}
+		case backup.CreatedAt.Equal(latest[0].CreatedAt):
+			latest = append(latest, backup)
+		}
+	}
+
+	if len(latest) == 0 {
+		return nil, fmt.Errorf("%w in %s", state.ErrNoBackup, store.Dir())
+	}
+
+	return latest, nil
+}
+
+func restoreBackups(output io.Writer, backups []state.Backup) error {
+	for _, backup := range backups {
+		name := backup.Target
+		if targetDefinition, found := lookupTarget(backup.Target); found {
+			name = fmt.Sprintf("%s (%s)", targetDefinition.Name(), targetDefinition.Slug())
+		}
+
+		if err := newBackupStore().Restore(backup); err != nil {
+			return fmt.Errorf("roll back %s: %w", name, err)
+		}
+
+		fmt.Fprintf(output, "Rolled back %s to before %s:\n", name, format.Timestamp(backup.CreatedAt))
+		for _, file := range backup.Files {
+			if file.Existed {
+				fmt.Fprintf(output, "  restored %s\n", file.Path)
+			} else {
+				fmt.Fprintf(output, "  removed %s (it did not exist before)\n", file.Path)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func overrideRollbackDependencies(t *testing.T, targets ...targetpkg.Target) *state.BackupStore {
+	t.Helper()
+
+	overrideTargetsConfigureDependencies(t, filepath.Join(t.TempDir(), "config.json"), targets...)
+
+	store := state.NewBackupStore(t.TempDir())
+	originalNewBackupStore := newBackupStore
+	originalAllTargets := allTargets
+	t.Cleanup(func() {
+		newBackupStore = originalNewBackupStore
+		allTargets = originalAllTargets
+	})
+
+	newBackupStore = func() *state.BackupStore { return store }
+	allTargets = func() []targetpkg.Target { return targets }
+
+	return store
+}
+
+func executeRollbackCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newRollbackCmd()
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+
+	return output.String(), err
+}
+
+func TestRollbackRestoresLatestOperationOnEveryTarget(t *testing.T) {
+	alpha := fakeListTarget{name: "Alpha CLI", slug: "alpha", installed: true}
+	beta := fakeListTarget{name: "Beta CLI", slug: "beta", installed: true}
+	store := overrideRollbackDependencies(t, alpha, beta)
+
+	dir := t.TempDir()
+	alphaPath := filepath.Join(dir, "alpha.json")
+	betaPath := filepath.Join(dir, "beta.json")
+	writeRollbackTestFile(t, alphaPath, "alpha before")
+
+	earlier := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := store.Save("beta", earlier, betaPath); err != nil {
+		t.Fatalf("expected earlier backup to be saved: %v", err)
+	}
+
+	latest := earlier.Add(time.Minute)
+	for slug, path := range map[string]string{"alpha": alphaPath, "beta": betaPath} {
+		if err := store.Save(slug, latest, path); err != nil {
+			t.Fatalf("expected backup of %s to be saved: %v", slug, err)
+		}
+	}
+
+	writeRollbackTestFile(t, alphaPath, "alpha after")
+	writeRollbackTestFile(t, betaPath, "beta after")
+
+	output, err := executeRollbackCommand(t)
+	if err != nil {
+		t.Fatalf("expected rollback to succeed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Rolled back Alpha CLI (alpha)",
+		"restored " + alphaPath,
+		"Rolled back Beta CLI (beta)",
+		"removed " + betaPath + " (it did not exist before)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+
+	if content, err := os.ReadFile(alphaPath); err != nil || string(content) != "alpha before" {
+		t.Fatalf("expected alpha config restored, got %q (%v)", content, err)
+	}
+
+	if _, err := os.Stat(betaPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected beta config removed, got %v", err)
+	}
+
+	backups, err := store.List("beta")
+	if err != nil || len(backups) != 1 || !backups[0].CreatedAt.Equal(earlier) {
+		t.Fatalf("expected only the earlier beta backup to be left, got %#v (%v)", backups, err)
+	}
+}
+
+func TestRollbackTargetRestoresOnlyThatTarget(t *testing.T) {
+	alpha := fakeListTarget{name: "Alpha CLI", slug: "alpha", installed: true}
+	beta := fakeListTarget{name: "Beta CLI", slug: "beta", installed: true}
+	store := overrideRollbackDependencies(t, alpha, beta)
+
+	dir := t.TempDir()
+	alphaPath := filepath.Join(dir, "alpha.json")
+	betaPath := filepath.Join(dir, "beta.json")
+	writeRollbackTestFile(t, alphaPath, "alpha before")
+	writeRollbackTestFile(t, betaPath, "beta before")
+
+	startedAt := time.Now()
+	for slug, path := range map[string]string{"alpha": alphaPath, "beta": betaPath} {
+		if err := store.Save(slug, startedAt, path); err != nil {
+			t.Fatalf("expected backup of %s to be saved: %v", slug, err)
+		}
+	}
+
+	writeRollbackTestFile(t, alphaPath, "alpha after")
+	writeRollbackTestFile(t, betaPath, "beta after")
+
+	if _, err := executeRollbackCommand(t, "--target", "beta"); err != nil {
+		t.Fatalf("expected rollback to succeed: %v", err)
+	}
+
+	if content, _ := os.ReadFile(betaPath); string(content) != "beta before" {
+		t.Fatalf("expected beta config restored, got %q", content)
+	}
+
+	if content, _ := os.ReadFile(alphaPath); string(content) != "alpha after" {
+		t.Fatalf("expected alpha config untouched, got %q", content)
+	}
+
+	if _, err := executeRollbackCommand(t, "--target", "beta"); err == nil || !strings.Contains(err.Error(), "no backup of Beta CLI") {
+		t.Fatalf("expected no backup error, got %v", err)
+	}
+}
+
+func TestRollbackWithoutBackupsFails(t *testing.T) {
+	overrideRollbackDependencies(t, fakeListTarget{name: "Alpha CLI", slug: "alpha", installed: true})
+
+	if _, err := executeRollbackCommand(t); !errors.Is(err, state.ErrNoBackup) {
+		t.Fatalf("expected ErrNoBackup, got %v", err)
+	}
+
+	if _, err := executeRollbackCommand(t, "--target", "nope"); err == nil || !strings.Contains(err.Error(), `target "nope" is not known`) {
+		t.Fatalf("expected unknown target error, got %v", err)
+	}
+}
+
+func writeRollbackTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
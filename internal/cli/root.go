@@ -2,43 +2,76 @@ package cli
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/andreagrandi/mcp-wire/internal/app"
 	"github.com/andreagrandi/mcp-wire/internal/catalog"
 	"github.com/andreagrandi/mcp-wire/internal/config"
-	"github.com/andreagrandi/mcp-wire/internal/credential"
 	"github.com/andreagrandi/mcp-wire/internal/service"
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
 	"github.com/andreagrandi/mcp-wire/internal/tui"
+	"github.com/andreagrandi/mcp-wire/internal/webhook"
+	"github.com/atotto/clipboard"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
 
-var rootCmd = &cobra.Command{
-	Use:   "mcp-wire",
-	Short: "Install and configure MCP servers across AI coding tools",
-	Long: `mcp-wire is a CLI tool that lets you install and configure MCP (Model Context Protocol)
+func newRootCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp-wire",
+		Short: "Install and configure MCP servers across AI coding tools",
+		Long: `mcp-wire is a CLI tool that lets you install and configure MCP (Model Context Protocol)
 servers across multiple AI coding CLI tools (Claude Code, Codex, OpenCode, etc.)
 from a single interface.
 
 Services are defined as YAML files -- no code needed to add one.
 Targets are the AI tools where services get installed.`,
-	Version: app.Version,
-	RunE: func(cmd *cobra.Command, _ []string) error {
-		return runGuidedMainMenu(cmd)
-	},
+		Version: app.Version,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, replayPath, err := sessionFileFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			if replayPath != "" {
+				return replaySession(cmd, replayPath)
+			}
+
+			return runGuidedMainMenu(cmd)
+		},
+	}
+
+	addSessionFileFlags(cmd)
+	addSuppressFlag(cmd)
+	addOutputFlags(cmd)
+
+	return cmd
 }
 
 func Execute() error {
+	stopWatchingInterrupts := watchInterrupts()
+	defer stopWatchingInterrupts()
+
+	root := NewRootCommand(DefaultDeps())
 	if !isCacheCommand(os.Args) {
 		maybeStartRegistryBackgroundSync()
 	}
 
-	return rootCmd.Execute()
+	applyTargetOverrides(allTargets())
+	applyConfigBackups(allTargets())
+	applyMergeStrategies(allTargets())
+
+	err := root.Execute()
+	flushWebhookEvents(os.Stderr)
+
+	return err
 }
 
 func isCacheCommand(args []string) bool {
@@ -46,7 +79,35 @@ func isCacheCommand(args []string) bool {
 		return false
 	}
 
-	return args[1] == "cache"
+	return args[1] == "cache" || args[1] == "registry"
+}
+
+// limitedTerminals are TERM values of terminals the TUI renders garbled on:
+// they lack cursor addressing, an alternate screen, or 256 colors.
+var limitedTerminals = map[string]bool{
+	"dumb":    true,
+	"unknown": true,
+	"emacs":   true,
+	"cons25":  true,
+	"linux":   true,
+	"ansi":    true,
+	"vt52":    true,
+	"vt100":   true,
+	"vt102":   true,
+	"vt220":   true,
+}
+
+// limitedTerminal reports whether the terminal cannot show the full-screen
+// TUI, going by TERM, and returns the TERM value when it cannot. An unset
+// TERM is limited too, except on Windows, whose consoles do not set it.
+func limitedTerminal() (string, bool) {
+	value, ok := lookupEnv("TERM")
+	value = strings.ToLower(strings.TrimSpace(value))
+	if !ok || value == "" {
+		return value, runtime.GOOS != "windows"
+	}
+
+	return value, limitedTerminals[value]
 }
 
 func canUseInteractiveUI(input io.Reader, output io.Writer) bool {
@@ -60,17 +121,104 @@ func canUseInteractiveUI(input io.Reader, output io.Writer) bool {
 }
 
 func runGuidedMainMenu(cmd *cobra.Command) error {
-	if canUseInteractiveUI(cmd.InOrStdin(), cmd.OutOrStdout()) {
+	interactive := canUseInteractiveUI(cmd.InOrStdin(), cmd.OutOrStdout())
+	limitedTerm, limited := limitedTerminal()
+	if interactive && limited {
+		if limitedTerm == "" {
+			limitedTerm = "unset"
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "This terminal (TERM=%s) cannot show the full-screen interface; using the step-by-step wizard.\n", limitedTerm)
+	}
+
+	if interactive && !limited {
 		cfg, _ := loadConfig()
 		if cfg == nil {
 			cfg = &config.Config{}
 		}
-		return tui.Run(tuiCallbacks(cfg), app.Version)
+		callbacks := tuiCallbacks(cfg)
+		suppressions, err := warningSuppressions(cmd)
+		if err != nil {
+			return err
+		}
+
+		callbacks.SuppressedWarnings = suppressions
+
+		// The TUI stops itself on SIGINT and SIGTERM; what it applied until
+		// then is still recorded and reported.
+		var session tui.Session
+		sig, err := runInForeground(func() error {
+			var err error
+			session, err = tui.Run(callbacks, app.Version)
+			return err
+		})
+		if err != nil && sig == nil {
+			return err
+		}
+
+		if recordPath, _, _ := sessionFileFlags(cmd); recordPath != "" {
+			if err := writeSessionRecording(recordPath, session); err != nil {
+				return err
+			}
+		}
+
+		reportErr := reportTUISession(cmd, session)
+		if sig != nil {
+			return interruptError{signal: sig}
+		}
+
+		return reportErr
+	}
+
+	if recordPath, _, _ := sessionFileFlags(cmd); recordPath != "" {
+		return errors.New("--record needs the TUI; run mcp-wire in a terminal with cursor movement, an alternate screen, and 256 colors")
+	}
+
+	if err := requireInteractiveInput(cmd, "mcp-wire install <service> --target <slug>"); err != nil {
+		return err
 	}
 
 	return runGuidedMainMenuPlain(cmd)
 }
 
+// tuiSessionSummary is the machine-readable summary printed to stderr when
+// operations performed in the TUI failed.
+type tuiSessionSummary struct {
+	Status     string        `json:"status"`
+	Total      int           `json:"total"`
+	Failed     int           `json:"failed"`
+	Operations []tui.Outcome `json:"operations"`
+}
+
+// reportTUISession mirrors failures from the TUI session to the shell: it
+// prints a JSON summary to stderr and returns an error so the process exits
+// non-zero.
+func reportTUISession(cmd *cobra.Command, session tui.Session) error {
+	failures := session.Failures()
+	if len(failures) == 0 {
+		return nil
+	}
+
+	status := "partial_failure"
+	if len(failures) == len(session.Outcomes) {
+		status = "failure"
+	}
+
+	data, err := json.Marshal(tuiSessionSummary{
+		Status:     status,
+		Total:      len(session.Outcomes),
+		Failed:     len(failures),
+		Operations: session.Outcomes,
+	})
+	if err != nil {
+		return fmt.Errorf("encode session summary: %w", err)
+	}
+
+	fmt.Fprintln(cmd.ErrOrStderr(), string(data))
+	cmd.SilenceUsage = true
+
+	return fmt.Errorf("%d of %d operations failed", len(failures), len(session.Outcomes))
+}
+
 func runGuidedMainMenuPlain(cmd *cobra.Command) error {
 	reader := bufio.NewReader(cmd.InOrStdin())
 	output := cmd.OutOrStdout()
@@ -112,25 +260,43 @@ func tuiCallbacks(cfg *config.Config) tui.Callbacks {
 	registryEnabled := cfg.IsFeatureEnabled("registry")
 	return tui.Callbacks{
 		LoadCatalog: func(source string) (*catalog.Catalog, error) {
-			return loadCatalog(source, registryEnabled)
+			return loadCatalogSnapshot(source, registryEnabled)
 		},
 		RegistrySyncStatus: func() string {
 			return registrySyncStatusLine(registryEnabled)
 		},
 		RefreshRegistryEntry:  refreshRegistryEntry,
+		BrowseRegistry:        tuiBrowseRegistry,
 		CatalogEntryToService: catalogEntryToService,
-		AllTargets:            allTargets,
+		AllTargets:            tuiAllTargets,
 		RegistryEnabled:       registryEnabled,
+		ConfigureTarget:       tuiConfigureTarget,
+		HiddenTargets:         disabledTargetSlugs,
+		SetTargetHidden:       tuiSetTargetHidden,
+		TargetGroups:          targetGroups,
+		RegistryNames:         registrySourceNames,
 
 		ResolveCredential:       tuiResolveCredential,
 		StoreCredential:         tuiStoreCredential,
 		InstallTarget:           tuiInstallTarget,
 		UninstallTarget:         tuiUninstallTarget,
+		PreviewChanges:          tuiPreviewChanges,
 		ServiceUsesOAuth:        serviceUsesOAuth,
 		OAuthManualHint:         oauthManualAuthHint,
 		RemoveStoredCredentials: tuiRemoveStoredCredentials,
 		ListInstalledServices:   tuiListInstalledServices,
+		InstalledServices:       tuiInstalledServices,
+		RecentServices:          recentServiceNames,
+		RecommendedServices:     recommendedServiceNames,
 		OpenURL:                 openSetupURL,
+		ReadClipboard:           clipboard.ReadAll,
+		HealthChecks:            buildHealthChecks,
+		ReinstallService:        tuiReinstallService,
+		AuthenticateService:     tuiAuthenticateService,
+		BeginUndo:               tuiBeginUndo,
+		LastUndo:                tuiLastUndo,
+		UndoLast:                tuiUndoLast,
+		WatchConfigs:            watchTargetConfigs,
 	}
 }
 
@@ -142,37 +308,174 @@ func tuiListInstalledServices(t targetpkg.Target, scope targetpkg.ConfigScope) (
 	return t.List()
 }
 
+func tuiConfigureTarget(t targetpkg.Target, configPath string) error {
+	return configureTarget(io.Discard, t, configPath)
+}
+
+func tuiSetTargetHidden(slug string, hidden bool) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	return cfg.SetTargetDisabled(slug, hidden)
+}
+
 func tuiResolveCredential(envName string) (string, string, bool) {
-	envSource := newCredentialEnvSource()
-	fileSource := newCredentialFileSource("")
-	resolver := newCredentialResolver(envSource, fileSource)
+	resolver, _ := newCredentialSources()
 	return resolver.Resolve(envName)
 }
 
+// tuiPreviewChanges renders what an install or uninstall from the TUI would
+// change, as --dry-run prints it. Failures are part of the text, since the
+// preview screen only shows it.
+func tuiPreviewChanges(action string, svc service.Service, targets []targetpkg.Target, scopes map[string]targetpkg.ConfigScope) string {
+	// Targets may have been given different scopes, so policy is checked
+	// and the change prepared once per scope.
+	var order []targetpkg.ConfigScope
+	targetsByScope := map[targetpkg.ConfigScope][]targetpkg.Target{}
+	for _, t := range targets {
+		scope := scopes[t.Slug()]
+		if _, seen := targetsByScope[scope]; !seen {
+			order = append(order, scope)
+		}
+		targetsByScope[scope] = append(targetsByScope[scope], t)
+	}
+
+	changes := make(map[targetpkg.ConfigScope]func(targetpkg.Target) error, len(order))
+	for _, scope := range order {
+		var err error
+		if action == "uninstall" {
+			err = checkUninstallPolicy(svc.Name, targetsByScope[scope], scope)
+			changes[scope] = dryRunUninstall(svc.Name, scope)
+		} else {
+			err = checkPolicy("install", svc, targetsByScope[scope], scope)
+			changes[scope] = dryRunInstall(svc, scope)
+		}
+
+		if err != nil {
+			return "Cannot preview: " + err.Error() + "\n"
+		}
+	}
+
+	change := func(t targetpkg.Target) error {
+		return changes[scopes[t.Slug()]](t)
+	}
+
+	var output strings.Builder
+	_ = previewOperation(&output, nil, action, svc.Name, targets, change)
+	return output.String()
+}
+
+// tuiCredentialSources tells where each value in env came from. The TUI
+// resolved them before install; a value no source holds was typed in.
+func tuiCredentialSources(env map[string]string) map[string]string {
+	resolver, _ := newCredentialSources()
+	sources := make(map[string]string, len(env))
+	for name, value := range env {
+		if resolved, source, found := resolver.Resolve(name); found && resolved == value {
+			sources[name] = source
+		} else {
+			sources[name] = "prompt"
+		}
+	}
+
+	return sources
+}
+
 func tuiRemoveStoredCredentials(envNames []string) (int, error) {
-	fileSource := credential.NewFileSource("")
-	return removeStoredCredentials(fileSource, envNames)
+	return removeStoredCredentials(storedCredentialSources(), envNames)
 }
 
 func tuiStoreCredential(envName, value string) error {
-	fileSource := credential.NewFileSource("")
-	return fileSource.Store(envName, value)
+	_, store := newCredentialSources()
+	return store.Store(envName, value)
 }
 
+// tuiAllTargets returns every target pinned to the project directory chosen
+// by the project_root setting and following the prune_empty setting, so the
+// TUI writes and uninstalls the way the CLI would.
+func tuiAllTargets() []targetpkg.Target {
+	targets := allTargets()
+	if location, err := resolveProjectLocation("", ""); err == nil {
+		pinProjectDir(targets, location)
+	}
+
+	applyPruneEmpty(targets, false)
+
+	return targets
+}
+
+// tuiStateMu serializes the state file updates of TUI installs and
+// uninstalls, which the apply screen runs on several targets at once.
+var tuiStateMu sync.Mutex
+
 func tuiInstallTarget(svc service.Service, env map[string]string, t targetpkg.Target, scope targetpkg.ConfigScope) error {
+	if len(svc.Ports) > 0 {
+		env = copyEnv(env)
+		tuiStateMu.Lock()
+		err := allocateServicePorts(io.Discard, svc, env)
+		tuiStateMu.Unlock()
+		if err != nil {
+			return err
+		}
+
+		if err := applyRegistrySubstitutions(&svc, env, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := checkPolicy("install", svc, []targetpkg.Target{t}, scope); err != nil {
+		return err
+	}
+
+	var err error
 	scopedTarget, supportsScopes := t.(targetpkg.ScopedTarget)
 	if supportsScopes && targetSupportsScope(t, scope) {
-		return scopedTarget.InstallWithScope(svc, env, scope)
+		err = scopedTarget.InstallWithScope(svc, env, scope)
+	} else {
+		err = t.Install(svc, env)
+	}
+
+	emitWebhookEvent(webhook.EventInstall, svc.Name, t, scope, err)
+	if err != nil {
+		return err
 	}
-	return t.Install(svc, env)
+
+	tuiStateMu.Lock()
+	recordRecentService(svc.Name)
+	recordServiceProvenance(svc, t, scope, serviceOrigin{command: "tui", credentialSources: tuiCredentialSources(env)})
+	tuiStateMu.Unlock()
+
+	_, err = verifyInstalledService(t, svc, env, scope)
+	return err
 }
 
 func tuiUninstallTarget(name string, t targetpkg.Target, scope targetpkg.ConfigScope) error {
+	if err := checkUninstallPolicy(name, []targetpkg.Target{t}, scope); err != nil {
+		return err
+	}
+
+	var err error
 	scopedTarget, supportsScopes := t.(targetpkg.ScopedTarget)
 	if supportsScopes && targetSupportsScope(t, scope) {
-		return scopedTarget.UninstallWithScope(name, scope)
+		err = scopedTarget.UninstallWithScope(name, scope)
+	} else {
+		err = t.Uninstall(name)
 	}
-	return t.Uninstall(name)
+
+	emitWebhookEvent(webhook.EventUninstall, name, t, scope, err)
+	if err == nil {
+		// Whichever uninstall takes the lock last sees every other target
+		// already updated, so the ports are released exactly when the
+		// service is gone from all of them.
+		tuiStateMu.Lock()
+		releaseServicePorts(name)
+		forgetServiceProvenance(name, t, scope)
+		tuiStateMu.Unlock()
+	}
+
+	return err
 }
 
 func readTrimmedLine(reader *bufio.Reader, output io.Writer, prompt string) (string, error) {
@@ -2,9 +2,12 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
+	"runtime"
 	"strings"
 	"testing"
 
+	"github.com/andreagrandi/mcp-wire/internal/tui"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -31,18 +34,16 @@ func TestRootCommand(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var stdout, stderr bytes.Buffer
 
-			rootCmd.SetOut(&stdout)
-			rootCmd.SetErr(&stderr)
-			rootCmd.SetArgs(tt.args)
-			rootCmd.ParseFlags([]string{})
+			root := newRootCommand()
+			root.SetOut(&stdout)
+			root.SetErr(&stderr)
+			root.SetArgs(tt.args)
 
-			err := rootCmd.Execute()
+			err := root.Execute()
 			assert.NoError(t, err)
 
 			output := stdout.String() + stderr.String()
 			assert.Contains(t, output, tt.contains)
-
-			rootCmd.SetArgs([]string{})
 		})
 	}
 }
@@ -60,3 +61,71 @@ func TestRootCommandGuidedMenuExit(t *testing.T) {
 	assert.Contains(t, output, "Main Menu")
 	assert.Contains(t, output, "Goodbye")
 }
+
+func TestReportTUISessionNoFailures(t *testing.T) {
+	cmd := &cobra.Command{}
+	errOutput := &bytes.Buffer{}
+	cmd.SetErr(errOutput)
+
+	session := tui.Session{Outcomes: []tui.Outcome{{Action: "install", Service: "sentry", Target: "claude"}}}
+	if err := reportTUISession(cmd, session); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if errOutput.Len() != 0 {
+		t.Fatalf("expected no summary, got %q", errOutput.String())
+	}
+}
+
+func TestReportTUISessionPartialFailure(t *testing.T) {
+	cmd := &cobra.Command{}
+	errOutput := &bytes.Buffer{}
+	cmd.SetErr(errOutput)
+
+	session := tui.Session{Outcomes: []tui.Outcome{
+		{Action: "install", Service: "sentry", Target: "claude"},
+		{Action: "install", Service: "sentry", Target: "codex", Error: "write failed"},
+	}}
+
+	err := reportTUISession(cmd, session)
+	if err == nil || err.Error() != "1 of 2 operations failed" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var summary tuiSessionSummary
+	if err := json.Unmarshal(errOutput.Bytes(), &summary); err != nil {
+		t.Fatalf("expected JSON summary, got %q: %v", errOutput.String(), err)
+	}
+
+	if summary.Status != "partial_failure" || summary.Total != 2 || summary.Failed != 1 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+
+	if summary.Operations[1].Error != "write failed" {
+		t.Fatalf("expected failure detail, got %+v", summary.Operations[1])
+	}
+}
+
+func TestLimitedTerminal(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		limited bool
+	}{
+		{name: "256 colors", values: map[string]string{"TERM": "xterm-256color"}, limited: false},
+		{name: "screen", values: map[string]string{"TERM": "screen"}, limited: false},
+		{name: "dumb", values: map[string]string{"TERM": "dumb"}, limited: true},
+		{name: "vt100", values: map[string]string{"TERM": "VT100"}, limited: true},
+		{name: "empty", values: map[string]string{"TERM": ""}, limited: runtime.GOOS != "windows"},
+		{name: "unset", values: map[string]string{}, limited: runtime.GOOS != "windows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overrideCIEnvironment(t, tt.values)
+
+			_, limited := limitedTerminal()
+			assert.Equal(t, tt.limited, limited)
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/supervisor"
+	"github.com/spf13/cobra"
+)
+
+var newSupervisor = func() *supervisor.Supervisor {
+	return supervisor.New("")
+}
+
+func init() {
+	registerCommand(newRunCmd)
+}
+
+func newRunCmd() *cobra.Command {
+	var detach bool
+	var noPrompt bool
+
+	cmd := &cobra.Command{
+		Use:   "run <service>",
+		Short: "Run a service as a long-lived local server",
+		Long: `run starts a service that declares a serve configuration as a standalone
+local server, so targets can connect to one shared instance instead of each
+spawning their own copy.
+
+By default the server runs in the foreground until interrupted. With
+--detach it is started in the background and supervised; use
+"mcp-wire run status" and "mcp-wire run stop <service>" to manage it, and
+"mcp-wire install <service> --supervised" to point targets at it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := resolveServiceByName(args[0])
+			if err != nil {
+				return err
+			}
+
+			spec, err := buildServeSpec(cmd, svc, ciNoPrompt(cmd, noPrompt))
+			if err != nil {
+				return err
+			}
+
+			if detach {
+				return startSupervisedService(cmd.OutOrStdout(), spec)
+			}
+
+			return runServiceForeground(cmd, spec)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&detach, "detach", "d", false, "Run in the background under supervision")
+	cmd.Flags().BoolVar(&noPrompt, "no-prompt", false, "Fail when required credentials are missing instead of prompting")
+
+	cmd.AddCommand(newRunStopCmd())
+	cmd.AddCommand(newRunStatusCmd())
+
+	return cmd
+}
+
+func newRunStopCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop <service>",
+		Short: "Stop a supervised service",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return stopSupervisedService(cmd.OutOrStdout(), strings.TrimSpace(args[0]))
+		},
+	}
+}
+
+func newRunStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show supervised services",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonOutput, err := jsonOutputRequested(cmd)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return writeSupervisedServicesJSON(cmd.OutOrStdout())
+			}
+
+			return listSupervisedServices(cmd.OutOrStdout())
+		},
+	}
+}
+
+// buildServeSpec resolves credentials and ports for a service and expands
+// them into the command line and URL of its serve configuration.
+func buildServeSpec(cmd *cobra.Command, svc service.Service, noPrompt bool) (supervisor.Spec, error) {
+	if svc.Serve == nil {
+		return supervisor.Spec{}, fmt.Errorf("service %q has no serve configuration and cannot run as a standalone server", svc.Name)
+	}
+
+	resolver, store := newCredentialSources()
+
+	resolvedEnv, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{
+		noPrompt: noPrompt,
+		input:    cmd.InOrStdin(),
+		output:   cmd.OutOrStdout(),
+		store:    store,
+	})
+	if err != nil {
+		return supervisor.Spec{}, err
+	}
+
+	if err := allocateServicePorts(cmd.OutOrStdout(), svc, resolvedEnv); err != nil {
+		return supervisor.Spec{}, err
+	}
+
+	command, rawArgs := svc.ServeCommand()
+	args := make([]string, 0, len(rawArgs))
+	for _, arg := range rawArgs {
+		args = append(args, substituteVars(arg, resolvedEnv))
+	}
+
+	env := make([]string, 0, len(resolvedEnv))
+	for name, value := range resolvedEnv {
+		env = append(env, name+"="+value)
+	}
+	sort.Strings(env)
+
+	return supervisor.Spec{
+		Service: svc.Name,
+		Command: command,
+		Args:    args,
+		Env:     env,
+		URL:     substituteVars(svc.Serve.URL, resolvedEnv),
+	}, nil
+}
+
+func runServiceForeground(cmd *cobra.Command, spec supervisor.Spec) error {
+	fmt.Fprintf(cmd.OutOrStdout(), "Serving %s at %s (press Ctrl-C to stop)\n", spec.Service, spec.URL)
+
+	logFile, err := newSupervisor().OpenLog(spec.Service)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	process := exec.Command(spec.Command, spec.Args...)
+	process.Env = append(process.Environ(), spec.Env...)
+	process.Stdin = cmd.InOrStdin()
+	process.Stdout = io.MultiWriter(cmd.OutOrStdout(), logFile)
+	process.Stderr = io.MultiWriter(cmd.ErrOrStderr(), logFile)
+
+	// Ctrl-C reaches the service too; stopping it that way is not an error.
+	sig, err := runInForeground(process.Run)
+	if err != nil && sig == nil {
+		return fmt.Errorf("run service %q: %w", spec.Service, err)
+	}
+
+	return nil
+}
+
+func startSupervisedService(output io.Writer, spec supervisor.Spec) error {
+	process, err := newSupervisor().Start(spec)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(output, "Started %s (pid %d)\n", process.Service, process.PID)
+	fmt.Fprintf(output, "  URL:  %s\n", process.URL)
+	fmt.Fprintf(output, "  Logs: %s\n", process.LogPath)
+	fmt.Fprintf(output, "Point targets at it with: mcp-wire install %s --supervised\n", process.Service)
+
+	return nil
+}
+
+func stopSupervisedService(output io.Writer, serviceName string) error {
+	if err := newSupervisor().Stop(serviceName); err != nil {
+		if errors.Is(err, supervisor.ErrNotRunning) {
+			return fmt.Errorf("service %q is not running", serviceName)
+		}
+
+		return err
+	}
+
+	fmt.Fprintf(output, "Stopped %s.\n", serviceName)
+	return nil
+}
+
+func listSupervisedServices(output io.Writer) error {
+	statuses, err := newSupervisor().List()
+	if err != nil {
+		return err
+	}
+
+	if len(statuses) == 0 {
+		fmt.Fprintln(output, "No supervised services.")
+		return nil
+	}
+
+	maxNameWidth := len("SERVICE")
+	for _, status := range statuses {
+		if len(status.Service) > maxNameWidth {
+			maxNameWidth = len(status.Service)
+		}
+	}
+
+	fmt.Fprintf(output, "%-*s  %-7s  %-7s  %s\n", maxNameWidth, "SERVICE", "STATE", "PID", "URL")
+	for _, status := range statuses {
+		state := "stopped"
+		if status.Running {
+			state = "running"
+		}
+
+		fmt.Fprintf(output, "%-*s  %-7s  %-7d  %s\n", maxNameWidth, status.Service, state, status.PID, status.URL)
+	}
+
+	return nil
+}
+
+// supervisedServiceEntry is one service in the JSON output of run status.
+// Arguments are left out because they can hold resolved credentials.
+type supervisedServiceEntry struct {
+	Service   string    `json:"service"`
+	State     string    `json:"state"`
+	PID       int       `json:"pid"`
+	URL       string    `json:"url,omitempty"`
+	LogPath   string    `json:"log_path"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+func writeSupervisedServicesJSON(output io.Writer) error {
+	statuses, err := newSupervisor().List()
+	if err != nil {
+		return err
+	}
+
+	entries := make([]supervisedServiceEntry, 0, len(statuses))
+	for _, status := range statuses {
+		state := "stopped"
+		if status.Running {
+			state = "running"
+		}
+
+		entries = append(entries, supervisedServiceEntry{
+			Service:   status.Service,
+			State:     state,
+			PID:       status.PID,
+			URL:       status.URL,
+			LogPath:   status.LogPath,
+			StartedAt: status.StartedAt,
+		})
+	}
+
+	return writeJSON(output, entries)
+}
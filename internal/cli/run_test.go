@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	"github.com/andreagrandi/mcp-wire/internal/supervisor"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func overrideRunCommandDependencies(t *testing.T) {
+	t.Helper()
+
+	restore := overrideInstallCommandDependencies(t)
+	t.Cleanup(restore)
+
+	stateDir := t.TempDir()
+	originalNewSupervisor := newSupervisor
+	originalNewPortStore := newPortStore
+	newSupervisor = func() *supervisor.Supervisor { return supervisor.New(stateDir) }
+	newPortStore = func() *state.PortStore { return state.NewPortStore(filepath.Join(stateDir, "ports.json")) }
+	t.Cleanup(func() {
+		newSupervisor = originalNewSupervisor
+		newPortStore = originalNewPortStore
+	})
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"local-db": {
+				Name:      "local-db",
+				Transport: "stdio",
+				Command:   "sleep",
+				Args:      []string{"1"},
+				Ports:     []string{"DB_PORT"},
+				Serve: &service.ServeConfig{
+					Args: []string{"30"},
+					URL:  "http://127.0.0.1:{DB_PORT}/mcp",
+				},
+			},
+			"plain": {Name: "plain", Transport: "stdio", Command: "plain"},
+		}, nil
+	}
+	newCredentialEnvSource = func() credential.Source { return &testCredentialSource{values: map[string]string{}} }
+	newCredentialFileSource = func(string) credential.Source { return &testCredentialSource{values: map[string]string{}} }
+}
+
+func executeRunCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	runCmd := newRunCmd()
+	var stdout bytes.Buffer
+
+	runCmd.SetOut(&stdout)
+	runCmd.SetErr(&stdout)
+	runCmd.SetIn(strings.NewReader(""))
+	runCmd.SetArgs(args)
+
+	err := runCmd.Execute()
+	return stdout.String(), err
+}
+
+func TestRunCommandDetachStatusAndStop(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("relies on a POSIX sleep command")
+	}
+
+	overrideRunCommandDependencies(t)
+
+	output, err := executeRunCommand(t, "local-db", "--detach", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected run --detach to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "Started local-db") || !strings.Contains(output, "URL:  http://127.0.0.1:41") {
+		t.Fatalf("expected start summary with allocated URL, got %q", output)
+	}
+
+	output, err = executeRunCommand(t, "status")
+	if err != nil {
+		t.Fatalf("expected run status to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "local-db") || !strings.Contains(output, "running") {
+		t.Fatalf("expected running service in status, got %q", output)
+	}
+
+	output, err = executeRunCommand(t, "stop", "local-db")
+	if err != nil {
+		t.Fatalf("expected run stop to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "Stopped local-db.") {
+		t.Fatalf("expected stop confirmation, got %q", output)
+	}
+
+	output, err = executeRunCommand(t, "status")
+	if err != nil {
+		t.Fatalf("expected run status to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "No supervised services.") {
+		t.Fatalf("expected empty status, got %q", output)
+	}
+}
+
+func TestRunCommandRejectsServiceWithoutServeConfig(t *testing.T) {
+	overrideRunCommandDependencies(t)
+
+	_, err := executeRunCommand(t, "plain", "--detach")
+	if err == nil || !strings.Contains(err.Error(), "no serve configuration") {
+		t.Fatalf("expected missing serve configuration error, got %v", err)
+	}
+}
+
+func TestRunStopReportsServiceNotRunning(t *testing.T) {
+	overrideRunCommandDependencies(t)
+
+	_, err := executeRunCommand(t, "stop", "local-db")
+	if err == nil || !strings.Contains(err.Error(), `service "local-db" is not running`) {
+		t.Fatalf("expected not running error, got %v", err)
+	}
+}
+
+func TestInstallCommandSupervisedUsesServeURL(t *testing.T) {
+	overrideRunCommandDependencies(t)
+
+	installTarget := &fakeInstallTarget{name: "Alpha CLI", slug: "alpha", installed: true}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{installTarget} }
+
+	_, err := executeInstallCommand(t, "local-db", "--supervised", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected supervised install to succeed: %v", err)
+	}
+
+	installed := installTarget.lastService
+	if installed.Transport != "http" || installed.Command != "" {
+		t.Fatalf("expected http client definition, got %+v", installed)
+	}
+
+	if !strings.HasPrefix(installed.URL, "http://127.0.0.1:41") || strings.Contains(installed.URL, "{DB_PORT}") {
+		t.Fatalf("expected port substituted into URL, got %q", installed.URL)
+	}
+}
@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/jsonschema"
+	"github.com/spf13/cobra"
+)
+
+// outputSchemaVersion is the version of the JSON documents mcp-wire prints
+// with --output json and from metadata. Within a version, fields are never
+// removed, renamed, or given another type, and new fields are optional;
+// anything else needs a new version. The golden schema files under
+// testdata/golden enforce this.
+const outputSchemaVersion = 1
+
+// supportedOutputSchemaVersions lists the versions --schema-version accepts.
+var supportedOutputSchemaVersions = []int{1}
+
+// outputSchema describes one machine-readable document a command prints.
+type outputSchema struct {
+	name        string
+	commands    string
+	description string
+	value       any
+}
+
+// outputSchemas lists every machine-readable document, in the order the
+// schema command prints them.
+var outputSchemas = []outputSchema{
+	{
+		name:        "metadata",
+		commands:    "metadata",
+		description: "Capability report: transports, scopes, targets, services, and feature flags.",
+		value:       metadataDocument{},
+	},
+	{
+		name:        "list-installed",
+		commands:    "list --installed --json",
+		description: "Services configured on each target and scope, and whether mcp-wire installed them.",
+		value:       installedListing{},
+	},
+	{
+		name:        "operation",
+		commands:    "install --json, uninstall --json",
+		description: "Result of an install or uninstall run, with one outcome per service and target.",
+		value:       operationReport{},
+	},
+	{
+		name:        "batch",
+		commands:    "batch",
+		description: "One line per operation applied by batch, with the install or uninstall report or the update output.",
+		value:       batchResult{},
+	},
+	{
+		name:        "creds",
+		commands:    "creds list --json",
+		description: "Credentials stored in the keychain and the credentials file, with values masked unless --reveal is given.",
+		value:       []storedCredential{},
+	},
+	{
+		name:        "run-status",
+		commands:    "run status --json",
+		description: "Services started by run, one entry per service.",
+		value:       []supervisedServiceEntry{},
+	},
+	{
+		name:        "search",
+		commands:    "search --json",
+		description: "MCP Registry servers matching a query.",
+		value:       []searchResult{},
+	},
+	{
+		name:        "status",
+		commands:    "status --json",
+		description: "Configured services per target and their drift from the install records.",
+		value:       statusReport{},
+	},
+	{
+		name:        "status-recorded",
+		commands:    "status --recorded --json",
+		description: "Installs recorded in the install state file, one entry per service, target, and scope.",
+		value:       []recordedInstall{},
+	},
+	{
+		name:        "targets",
+		commands:    "targets --json",
+		description: "Supported targets with install status and config paths.",
+		value:       []targetListEntry{},
+	},
+	{
+		name:        "targets-matrix",
+		commands:    "targets --matrix --json",
+		description: "Conformance suite results per target.",
+		value:       []targetMatrixEntry{},
+	},
+	{
+		name:        "verify-manifest",
+		commands:    "verify-manifest --json",
+		description: "Result of verifying the running binary against its release attestation.",
+		value:       manifestVerification{},
+	},
+	{
+		name:        "why",
+		commands:    "why --json",
+		description: "Where a service came from on each target and scope: command, source, version, and credential sources.",
+		value:       []whyEntry{},
+	},
+}
+
+func init() {
+	registerCommand(newSchemaCmd)
+}
+
+func newSchemaCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "schema [name]",
+		Short: "Print the JSON Schema of machine-readable output",
+		Long: `schema prints the JSON Schema (draft 2020-12) of a document mcp-wire prints
+with --output json or from metadata. Without a name it lists the documents
+and the commands that print them. With --dir it writes every schema to
+<dir>/<name>.schema.json.
+
+Schemas are versioned. Within a version, fields are never removed, renamed,
+or given another type, and new fields are optional, so a consumer written
+against a version keeps working across releases. Pass --schema-version to
+pin the version a script expects; mcp-wire fails instead of printing a
+document of a version it does not support.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := requestedSchemaVersion(cmd)
+			if err != nil {
+				return err
+			}
+
+			if strings.TrimSpace(dir) != "" {
+				return writeOutputSchemaFiles(cmd.OutOrStdout(), dir, version)
+			}
+
+			if len(args) == 0 {
+				writeOutputSchemaList(cmd.OutOrStdout(), version)
+				return nil
+			}
+
+			schema, ok := findOutputSchema(args[0])
+			if !ok {
+				return fmt.Errorf("unknown schema %q; run mcp-wire schema to list them", args[0])
+			}
+
+			return writeJSON(cmd.OutOrStdout(), schema.document(version))
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Write every schema to this directory")
+
+	return cmd
+}
+
+// requestedSchemaVersion returns the version selected with --schema-version,
+// or the current one when the flag is not set.
+func requestedSchemaVersion(cmd *cobra.Command) (int, error) {
+	flag := cmd.Flags().Lookup("schema-version")
+	if flag == nil || !flag.Changed {
+		return outputSchemaVersion, nil
+	}
+
+	version, err := cmd.Flags().GetInt("schema-version")
+	if err != nil {
+		return 0, err
+	}
+
+	for _, supported := range supportedOutputSchemaVersions {
+		if version == supported {
+			return version, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported schema version %d (supported: %s)", version, joinInts(supportedOutputSchemaVersions))
+}
+
+func findOutputSchema(name string) (outputSchema, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, schema := range outputSchemas {
+		if schema.name == name {
+			return schema, true
+		}
+	}
+
+	return outputSchema{}, false
+}
+
+// document returns the JSON Schema of s at version.
+func (s outputSchema) document(version int) map[string]any {
+	id := fmt.Sprintf("https://github.com/andreagrandi/mcp-wire/schemas/v%d/%s.schema.json", version, s.name)
+	description := s.description + " Printed by: " + s.commands + "."
+	document := jsonschema.Generate(s.value, id, "mcp-wire "+s.name, description)
+	document["x-mcp-wire-schema-version"] = version
+
+	return document
+}
+
+func writeOutputSchemaList(output io.Writer, version int) {
+	nameWidth := len("NAME")
+	for _, schema := range outputSchemas {
+		nameWidth = max(nameWidth, len(schema.name))
+	}
+
+	fmt.Fprintf(output, "Schema version %d\n\n", version)
+	fmt.Fprintf(output, "%-*s  %s\n", nameWidth, "NAME", "PRINTED BY")
+	for _, schema := range outputSchemas {
+		fmt.Fprintf(output, "%-*s  %s\n", nameWidth, schema.name, schema.commands)
+	}
+}
+
+func writeOutputSchemaFiles(output io.Writer, dir string, version int) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create schema directory: %w", err)
+	}
+
+	for _, schema := range outputSchemas {
+		encoded, err := json.MarshalIndent(schema.document(version), "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode schema %q: %w", schema.name, err)
+		}
+
+		path := filepath.Join(dir, schema.name+".schema.json")
+		if err := os.WriteFile(path, append(encoded, '\n'), 0o644); err != nil {
+			return fmt.Errorf("write schema %q: %w", schema.name, err)
+		}
+
+		fmt.Fprintln(output, path)
+	}
+
+	return nil
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, fmt.Sprint(value))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// errSchemaVersionWithoutJSON is returned when --schema-version is passed to
+// a command printing text, where it would otherwise be silently ignored.
+var errSchemaVersionWithoutJSON = errors.New("--schema-version only applies to JSON output; add --output json")
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGoldenOutputSchemas pins every published schema. A diff here means a
+// JSON output changed shape: adding an optional field is fine to regenerate,
+// anything else needs a new schema version.
+func TestGoldenOutputSchemas(t *testing.T) {
+	for _, schema := range outputSchemas {
+		t.Run(schema.name, func(t *testing.T) {
+			encoded, err := json.MarshalIndent(schema.document(outputSchemaVersion), "", "  ")
+			if err != nil {
+				t.Fatalf("encode schema: %v", err)
+			}
+
+			assertGolden(t, "schema-v1-"+schema.name, string(encoded)+"\n")
+		})
+	}
+}
+
+func TestSchemaCommandListsAndPrintsSchemas(t *testing.T) {
+	output, err := executeSchemaCommand(t)
+	if err != nil {
+		t.Fatalf("expected schema list to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "Schema version 1") || !strings.Contains(output, "operation        install --json, uninstall --json") {
+		t.Fatalf("unexpected schema list: %q", output)
+	}
+
+	output, err = executeSchemaCommand(t, "targets")
+	if err != nil {
+		t.Fatalf("expected schema targets to succeed: %v", err)
+	}
+
+	var document map[string]any
+	if err := json.Unmarshal([]byte(output), &document); err != nil {
+		t.Fatalf("expected JSON schema, got %q: %v", output, err)
+	}
+
+	if document["type"] != "array" || document["x-mcp-wire-schema-version"] != float64(1) {
+		t.Fatalf("unexpected targets schema: %#v", document)
+	}
+
+	if _, err := executeSchemaCommand(t, "nope"); err == nil {
+		t.Fatal("expected an unknown schema to fail")
+	}
+}
+
+func TestSchemaCommandWritesFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "schemas")
+
+	if _, err := executeSchemaCommand(t, "--dir", dir); err != nil {
+		t.Fatalf("expected schema --dir to succeed: %v", err)
+	}
+
+	for _, schema := range outputSchemas {
+		if _, err := os.Stat(filepath.Join(dir, schema.name+".schema.json")); err != nil {
+			t.Fatalf("expected %s schema file: %v", schema.name, err)
+		}
+	}
+}
+
+func TestSchemaCommandRejectsUnsupportedVersion(t *testing.T) {
+	if _, err := executeSchemaCommand(t, "--schema-version", "2"); err == nil || !strings.Contains(err.Error(), "unsupported schema version 2") {
+		t.Fatalf("expected unsupported version error, got %v", err)
+	}
+}
+
+func executeSchemaCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	schemaCmd := newSchemaCmd()
+	addOutputFlags(schemaCmd)
+
+	var stdout bytes.Buffer
+	schemaCmd.SetOut(&stdout)
+	schemaCmd.SetErr(&stdout)
+	schemaCmd.SetArgs(args)
+
+	err := schemaCmd.Execute()
+
+	return stdout.String(), err
+}
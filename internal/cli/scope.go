@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
@@ -14,31 +16,69 @@ func parseInstallUninstallScope(value string) (targetpkg.ConfigScope, error) {
 	}
 
 	switch scope {
-	case targetpkg.ConfigScopeUser, targetpkg.ConfigScopeProject:
+	case targetpkg.ConfigScopeUser, targetpkg.ConfigScopeProject, targetpkg.ConfigScopeSystem, targetpkg.ConfigScopeRemote:
 		return scope, nil
 	default:
-		return "", fmt.Errorf("invalid scope %q (supported: user, project)", value)
+		return "", fmt.Errorf("invalid scope %q (supported: user, project, system, remote)", value)
 	}
 }
 
-func targetSupportsScope(targetDefinition targetpkg.Target, scope targetpkg.ConfigScope) bool {
-	scopedTarget, ok := targetDefinition.(targetpkg.ScopedTarget)
-	if !ok {
-		return false
+// checkSystemScope guards machine-wide writes: system scope must be confirmed
+// with --allow-system, and every selected target must support it, because
+// falling back to the user config would silently install for one user only.
+func checkSystemScope(scope targetpkg.ConfigScope, allowSystem bool, targetDefinitions []targetpkg.Target) error {
+	if scope != targetpkg.ConfigScopeSystem {
+		return nil
 	}
 
-	for _, supportedScope := range scopedTarget.SupportedScopes() {
-		if supportedScope == scope {
-			return true
+	if !allowSystem {
+		return errors.New("--scope system changes the MCP config of every user on this machine; pass --allow-system to confirm")
+	}
+
+	for _, targetDefinition := range targetDefinitions {
+		if !targetSupportsScope(targetDefinition, targetpkg.ConfigScopeSystem) {
+			return fmt.Errorf("target %q does not support system scope", targetDefinition.Slug())
 		}
 	}
 
-	return false
+	return nil
 }
 
-func anyTargetSupportsProjectScope(targetDefinitions []targetpkg.Target) bool {
+// checkRemoteScope requires every selected target to have remote settings on
+// this machine. Falling back to the user settings would write a file that a
+// VS Code attached from elsewhere never reads.
+func checkRemoteScope(scope targetpkg.ConfigScope, targetDefinitions []targetpkg.Target) error {
+	if scope != targetpkg.ConfigScopeRemote {
+		return nil
+	}
+
 	for _, targetDefinition := range targetDefinitions {
-		if targetSupportsScope(targetDefinition, targetpkg.ConfigScopeProject) {
+		if !targetSupportsScope(targetDefinition, targetpkg.ConfigScopeRemote) {
+			return fmt.Errorf("target %q has no remote settings here; run mcp-wire inside the remote machine or dev container VS Code attaches to", targetDefinition.Slug())
+		}
+	}
+
+	return nil
+}
+
+// withElevationHint explains permission failures on system scope, which
+// usually means the command was not run with administrator rights.
+func withElevationHint(err error, scope targetpkg.ConfigScope) error {
+	if err == nil || scope != targetpkg.ConfigScopeSystem || !errors.Is(err, os.ErrPermission) {
+		return err
+	}
+
+	return fmt.Errorf("%w (system scope needs administrator rights; re-run with sudo, or from an elevated prompt on Windows)", err)
+}
+
+func targetSupportsScope(targetDefinition targetpkg.Target, scope targetpkg.ConfigScope) bool {
+	scopedTarget, ok := targetDefinition.(targetpkg.ScopedTarget)
+	if !ok {
+		return false
+	}
+
+	for _, supportedScope := range scopedTarget.SupportedScopes() {
+		if supportedScope == scope {
 			return true
 		}
 	}
@@ -52,6 +92,10 @@ func scopeDescription(scope targetpkg.ConfigScope) string {
 		return "project"
 	case targetpkg.ConfigScopeUser:
 		return "user"
+	case targetpkg.ConfigScopeSystem:
+		return "system"
+	case targetpkg.ConfigScopeRemote:
+		return "remote"
 	case targetpkg.ConfigScopeEffective:
 		return "effective"
 	default:
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+type fakeSystemScopeInstallTarget struct {
+	*fakeScopedInstallTarget
+}
+
+func (t *fakeSystemScopeInstallTarget) SupportedScopes() []targetpkg.ConfigScope {
+	return []targetpkg.ConfigScope{targetpkg.ConfigScopeUser, targetpkg.ConfigScopeSystem}
+}
+
+func newFakeSystemScopeInstallTarget(name, slug string) *fakeSystemScopeInstallTarget {
+	return &fakeSystemScopeInstallTarget{
+		fakeScopedInstallTarget: &fakeScopedInstallTarget{
+			fakeInstallTarget: &fakeInstallTarget{name: name, slug: slug, installed: true},
+		},
+	}
+}
+
+func overrideSystemScopeInstallDependencies(t *testing.T, targets ...targetpkg.Target) func() {
+	t.Helper()
+
+	restore := overrideInstallCommandDependencies(t)
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo-service": {Name: "demo-service", Transport: "sse", URL: "https://example.com/mcp"},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return targets }
+	newCredentialEnvSource = func() credential.Source {
+		return &testCredentialSource{name: "environment", values: map[string]string{}}
+	}
+	newCredentialFileSource = func(string) credential.Source {
+		return &testCredentialSource{name: "file", values: map[string]string{}}
+	}
+
+	return restore
+}
+
+func TestParseInstallUninstallScopeAcceptsSystem(t *testing.T) {
+	scope, err := parseInstallUninstallScope(" System ")
+	if err != nil {
+		t.Fatalf("expected system scope to parse: %v", err)
+	}
+
+	if scope != targetpkg.ConfigScopeSystem {
+		t.Fatalf("expected system scope, got %q", scope)
+	}
+}
+
+func TestInstallCommandSystemScopeRequiresAllowSystem(t *testing.T) {
+	systemTarget := newFakeSystemScopeInstallTarget("Alpha CLI", "alpha-cli")
+	restore := overrideSystemScopeInstallDependencies(t, systemTarget)
+	defer restore()
+
+	_, err := executeInstallCommand(t, "demo-service", "--scope", "system", "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), "pass --allow-system to confirm") {
+		t.Fatalf("expected --allow-system error, got %v", err)
+	}
+
+	if systemTarget.installCalls != 0 {
+		t.Fatalf("expected no install without --allow-system, got %d", systemTarget.installCalls)
+	}
+}
+
+func TestInstallCommandSystemScopeRejectsUnsupportedTargets(t *testing.T) {
+	systemTarget := newFakeSystemScopeInstallTarget("Alpha CLI", "alpha-cli")
+	userOnly := &fakeInstallTarget{name: "Beta CLI", slug: "beta-cli", installed: true}
+	restore := overrideSystemScopeInstallDependencies(t, systemTarget, userOnly)
+	defer restore()
+
+	_, err := executeInstallCommand(t, "demo-service", "--scope", "system", "--allow-system", "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), `target "beta-cli" does not support system scope`) {
+		t.Fatalf("expected unsupported target error, got %v", err)
+	}
+
+	if systemTarget.installCalls != 0 || userOnly.installCalls != 0 {
+		t.Fatal("expected no target to be written when one does not support system scope")
+	}
+}
+
+func TestInstallCommandSystemScopeWritesSystemConfig(t *testing.T) {
+	systemTarget := newFakeSystemScopeInstallTarget("Alpha CLI", "alpha-cli")
+	restore := overrideSystemScopeInstallDependencies(t, systemTarget)
+	defer restore()
+
+	output, err := executeInstallCommand(t, "demo-service", "--scope", "system", "--allow-system", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected system install to succeed: %v", err)
+	}
+
+	if systemTarget.lastScope != targetpkg.ConfigScopeSystem {
+		t.Fatalf("expected system scope install, got %q", systemTarget.lastScope)
+	}
+
+	if !strings.Contains(output, "Alpha CLI: configured") {
+		t.Fatalf("expected success line, got %q", output)
+	}
+}
+
+func TestInstallCommandSystemScopePermissionErrorSuggestsElevation(t *testing.T) {
+	systemTarget := newFakeSystemScopeInstallTarget("Alpha CLI", "alpha-cli")
+	systemTarget.installErr = os.ErrPermission
+	restore := overrideSystemScopeInstallDependencies(t, systemTarget)
+	defer restore()
+
+	output, err := executeInstallCommand(t, "demo-service", "--scope", "system", "--allow-system", "--no-prompt")
+	if err == nil {
+		t.Fatal("expected install to fail")
+	}
+
+	if !strings.Contains(output, "re-run with sudo") {
+		t.Fatalf("expected elevation hint, got %q", output)
+	}
+}
+
+func TestInstallCommandSystemScopeRequiresServiceName(t *testing.T) {
+	restore := overrideSystemScopeInstallDependencies(t)
+	defer restore()
+
+	_, err := executeInstallCommand(t, "--scope", "system", "--allow-system")
+	if err == nil || !strings.Contains(err.Error(), "--scope system requires a service name") {
+		t.Fatalf("expected service name error, got %v", err)
+	}
+}
+
+func TestUninstallCommandSystemScopeRequiresAllowSystem(t *testing.T) {
+	systemTarget := newFakeSystemScopeInstallTarget("Alpha CLI", "alpha-cli")
+	restore := overrideSystemScopeInstallDependencies(t, systemTarget)
+	defer restore()
+
+	_, err := executeUninstallCommand(t, "demo-service", "--scope", "system")
+	if err == nil || !strings.Contains(err.Error(), "pass --allow-system to confirm") {
+		t.Fatalf("expected --allow-system error, got %v", err)
+	}
+}
+
+func TestInstallCommandRemoteScopeRejectsTargetsWithoutRemoteSettings(t *testing.T) {
+	userOnly := &fakeInstallTarget{name: "Beta CLI", slug: "beta-cli", installed: true}
+	restore := overrideSystemScopeInstallDependencies(t, userOnly)
+	defer restore()
+
+	_, err := executeInstallCommand(t, "demo-service", "--scope", "remote", "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), `target "beta-cli" has no remote settings here`) {
+		t.Fatalf("expected missing remote settings error, got %v", err)
+	}
+
+	if userOnly.installCalls != 0 {
+		t.Fatal("expected no fallback write to the user config")
+	}
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultSearchLimit    = 50
+	searchDescriptionSize = 60
+)
+
+var listRegistryServers = func(opts registry.ListOptions) (*registry.ServerListResponse, error) {
+	return registry.NewClient().ListServers(opts)
+}
+
+func init() {
+	registerCommand(newSearchCmd)
+}
+
+func newSearchCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search the MCP Registry for servers",
+		Long: `search asks the Official MCP Registry for servers matching the query and
+prints their name, install type, transport, and description. Unlike the
+catalog in the TUI, it always queries the registry live, so newly published
+servers show up without waiting for a cache sync.
+
+Results are fetched page by page until --limit servers were found; use
+--limit 0 to fetch every match.`,
+		Example: `  mcp-wire search github
+  mcp-wire search "vector database" --limit 0
+  mcp-wire search postgres --json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, err := jsonOutputRequested(cmd)
+			if err != nil {
+				return err
+			}
+
+			query := strings.TrimSpace(strings.Join(args, " "))
+			if query == "" {
+				return errors.New("search query is required")
+			}
+
+			if limit < 0 {
+				return errors.New("--limit must be 0 or more")
+			}
+
+			servers, more, err := searchRegistryServers(query, limit)
+			if err != nil {
+				return fmt.Errorf("search registry: %w", err)
+			}
+
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), buildSearchResultsJSON(servers))
+			}
+
+			writeSearchResults(cmd.OutOrStdout(), query, servers, more)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", defaultSearchLimit, "Maximum number of servers to show; 0 shows every match")
+
+	return cmd
+}
+
+// searchRegistryServers follows the registry cursor until limit servers were
+// collected, or every match when limit is 0. It reports whether the registry
+// has more matches than were returned.
+func searchRegistryServers(query string, limit int) ([]registry.ServerResponse, bool, error) {
+	var servers []registry.ServerResponse
+	cursor := ""
+	for {
+		pageSize := 100
+		if limit > 0 {
+			pageSize = min(pageSize, limit-len(servers))
+		}
+
+		page, err := listRegistryServers(registry.ListOptions{Limit: pageSize, Cursor: cursor, Search: query})
+		if err != nil {
+			return nil, false, err
+		}
+
+		servers = append(servers, page.Servers...)
+		cursor = page.Metadata.NextCursor
+		if cursor == "" || len(page.Servers) == 0 {
+			return servers, false, nil
+		}
+
+		if limit > 0 && len(servers) >= limit {
+			return servers[:limit], true, nil
+		}
+	}
+}
+
+func writeSearchResults(output io.Writer, query string, servers []registry.ServerResponse, more bool) {
+	if len(servers) == 0 {
+		fmt.Fprintf(output, "No registry servers match %q.\n", query)
+		return
+	}
+
+	entries := catalog.Localize(catalog.FromRegistrySlice(servers), userLocale())
+	nameWidth := len("NAME")
+	installWidth := len("INSTALL")
+	transportWidth := len("TRANSPORT")
+	for _, entry := range entries {
+		nameWidth = max(nameWidth, len(entry.Name))
+		installWidth = max(installWidth, len(entry.InstallType()))
+		transportWidth = max(transportWidth, len(entry.Transport()))
+	}
+
+	fmt.Fprintf(output, "%-*s  %-*s  %-*s  %s\n", nameWidth, "NAME", installWidth, "INSTALL", transportWidth, "TRANSPORT", "DESCRIPTION")
+	for _, entry := range entries {
+		line := fmt.Sprintf("%-*s  %-*s  %-*s  %s", nameWidth, entry.Name, installWidth, entry.InstallType(), transportWidth, entry.Transport(), shortenDescription(entry.Description()))
+		fmt.Fprintln(output, strings.TrimRight(line, " "))
+	}
+
+	fmt.Fprintln(output)
+	if more {
+		fmt.Fprintf(output, "Showing the first %d matches; use --limit 0 to see them all.\n", len(servers))
+	} else {
+		fmt.Fprintf(output, "%d servers match %q.\n", len(servers), query)
+	}
+
+	if cfg, err := loadConfig(); err == nil && !cfg.IsFeatureEnabled("registry") {
+		fmt.Fprintln(output, "To install one, run: mcp-wire feature enable registry, then mcp-wire install <name>")
+	} else {
+		fmt.Fprintln(output, "To install one, run: mcp-wire install <name>")
+	}
+}
+
+// shortenDescription keeps descriptions on one line of the results table.
+func shortenDescription(description string) string {
+	description = strings.Join(strings.Fields(description), " ")
+	runes := []rune(description)
+	if len(runes) <= searchDescriptionSize {
+		return description
+	}
+
+	return strings.TrimRight(string(runes[:searchDescriptionSize-3]), " ") + "..."
+}
+
+// searchResult is one server in the JSON output of search.
+type searchResult struct {
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	Description string `json:"description"`
+	InstallType string `json:"install_type,omitempty"`
+	Transport   string `json:"transport,omitempty"`
+}
+
+func buildSearchResultsJSON(servers []registry.ServerResponse) []searchResult {
+	results := make([]searchResult, 0, len(servers))
+	locale := userLocale()
+	for _, server := range servers {
+		entry := catalog.FromRegistry(server)
+		entry.Locale = locale
+		results = append(results, searchResult{
+			Name:        entry.Name,
+			Version:     server.Server.Version,
+			Description: entry.Description(),
+			InstallType: entry.InstallType(),
+			Transport:   entry.Transport(),
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	"github.com/andreagrandi/mcp-wire/internal/registry"
+)
+
+// overrideRegistrySearch serves count matching servers, pageSize at a time,
+// and records the requests made.
+func overrideRegistrySearch(t *testing.T, count int, pageSize int) *[]registry.ListOptions {
+	t.Helper()
+
+	originalListRegistryServers := listRegistryServers
+	originalLoadConfig := loadConfig
+	t.Cleanup(func() {
+		listRegistryServers = originalListRegistryServers
+		loadConfig = originalLoadConfig
+	})
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+
+	var requests []registry.ListOptions
+	listRegistryServers = func(opts registry.ListOptions) (*registry.ServerListResponse, error) {
+		requests = append(requests, opts)
+
+		start := 0
+		if opts.Cursor != "" {
+			fmt.Sscanf(opts.Cursor, "offset-%d", &start)
+		}
+		end := min(start+min(pageSize, opts.Limit), count)
+
+		response := &registry.ServerListResponse{}
+		for i := start; i < end; i++ {
+			response.Servers = append(response.Servers, registry.ServerResponse{Server: registry.ServerJSON{
+				Name:        fmt.Sprintf("io.github.example/server-%d", i),
+				Version:     "1.0.0",
+				Description: "Query   the example\nservice " + strings.Repeat("and more ", 10),
+				Remotes:     []registry.Transport{{Type: "streamable-http", URL: "https://example.com/mcp"}},
+			}})
+		}
+		if end < count {
+			response.Metadata.NextCursor = fmt.Sprintf("offset-%d", end)
+		}
+
+		return response, nil
+	}
+
+	return &requests
+}
+
+func executeSearchCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newSearchCmd()
+	addOutputFlags(cmd)
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetErr(&output)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+func TestSearchCommandFollowsCursorUntilLimit(t *testing.T) {
+	requests := overrideRegistrySearch(t, 5, 2)
+
+	output, err := executeSearchCommand(t, "example", "service", "--limit", "3")
+	if err != nil {
+		t.Fatalf("expected search to succeed: %v", err)
+	}
+
+	if len(*requests) != 2 || (*requests)[0].Search != "example service" || (*requests)[1].Cursor != "offset-2" || (*requests)[1].Limit != 1 {
+		t.Fatalf("unexpected registry requests %+v", *requests)
+	}
+
+	for _, want := range []string{
+		"NAME                        INSTALL  TRANSPORT        DESCRIPTION\n",
+		"io.github.example/server-0  remote   streamable-http  Query the example service and more and more and more and...\n",
+		"Showing the first 3 matches; use --limit 0 to see them all.",
+		"mcp-wire feature enable registry",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output:\n%s", want, output)
+		}
+	}
+
+	if strings.Contains(output, "server-3") {
+		t.Fatalf("expected results to stop at the limit:\n%s", output)
+	}
+}
+
+func TestSearchCommandFetchesEveryPageWithoutLimit(t *testing.T) {
+	requests := overrideRegistrySearch(t, 250, 100)
+
+	output, err := executeSearchCommand(t, "example", "--limit", "0", "--json")
+	if err != nil {
+		t.Fatalf("expected search to succeed: %v", err)
+	}
+
+	var results []searchResult
+	if err := json.Unmarshal([]byte(output), &results); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", output, err)
+	}
+
+	if len(results) != 250 || len(*requests) != 3 {
+		t.Fatalf("expected 250 results over 3 pages, got %d over %d", len(results), len(*requests))
+	}
+
+	if results[0].InstallType != "remote" || results[0].Transport != "streamable-http" || results[0].Version != "1.0.0" {
+		t.Fatalf("unexpected first result %+v", results[0])
+	}
+}
+
+func TestSearchCommandReportsNoMatchesAndErrors(t *testing.T) {
+	overrideRegistrySearch(t, 0, 10)
+
+	output, err := executeSearchCommand(t, "nothing")
+	if err != nil || !strings.Contains(output, `No registry servers match "nothing".`) {
+		t.Fatalf("expected no matches message, got %q, %v", output, err)
+	}
+
+	listRegistryServers = func(registry.ListOptions) (*registry.ServerListResponse, error) {
+		return nil, errors.New("registry unavailable")
+	}
+
+	_, err = executeSearchCommand(t, "anything")
+	if err == nil || err.Error() != "search registry: registry unavailable" {
+		t.Fatalf("expected registry error, got %v", err)
+	}
+}
+
+func TestTUIBrowseRegistryFetchesOnePage(t *testing.T) {
+	requests := overrideRegistrySearch(t, 45, 100)
+
+	page, err := tuiBrowseRegistry("github", "")
+	if err != nil {
+		t.Fatalf("expected browse to succeed: %v", err)
+	}
+
+	if len(page.Entries) != registryBrowsePageSize || page.NextCursor != "offset-30" {
+		t.Fatalf("expected a first page of %d with a cursor, got %d entries and cursor %q", registryBrowsePageSize, len(page.Entries), page.NextCursor)
+	}
+
+	if got := (*requests)[0]; got.Search != "github" || got.Limit != registryBrowsePageSize || got.Cursor != "" {
+		t.Fatalf("unexpected request %+v", got)
+	}
+
+	page, err = tuiBrowseRegistry("github", page.NextCursor)
+	if err != nil {
+		t.Fatalf("expected second page to succeed: %v", err)
+	}
+
+	if len(page.Entries) != 15 || page.NextCursor != "" {
+		t.Fatalf("expected a last page of 15, got %d entries and cursor %q", len(page.Entries), page.NextCursor)
+	}
+}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/app"
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+	"github.com/andreagrandi/mcp-wire/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// sessionRecordingVersion is the format version of the files written by
+// --record.
+const sessionRecordingVersion = 1
+
+// addSessionFileFlags registers --record and --replay on the root command.
+func addSessionFileFlags(cmd *cobra.Command) {
+	cmd.Flags().String("record", "", "Write the operations applied in the TUI to a session file that --replay can apply again")
+	cmd.Flags().String("replay", "", "Apply the operations of a session file written by --record, without the TUI")
+}
+
+// sessionRecording is a file written by --record: the install, uninstall,
+// and reinstall operations a TUI session applied, in order. It holds the
+// choices made in the wizard, never credential values, so --replay resolves
+// credentials again on the machine it runs on.
+type sessionRecording struct {
+	Version    int                 `json:"version"`
+	MCPWire    string              `json:"mcp_wire_version"`
+	RecordedAt time.Time           `json:"recorded_at"`
+	Operations []recordedOperation `json:"operations"`
+}
+
+// recordedOperation is one service the wizard installed or uninstalled on a
+// set of targets in one scope.
+type recordedOperation struct {
+	Action  string   `json:"action"` // "install", "uninstall", or "reinstall"
+	Service string   `json:"service"`
+	Scope   string   `json:"scope,omitempty"`
+	Targets []string `json:"targets"`
+}
+
+// newSessionRecording groups the outcomes of session into operations.
+// Outcomes of the same action, service, and scope that follow each other,
+// as the targets of one apply do, become one operation.
+func newSessionRecording(session tui.Session) sessionRecording {
+	recording := sessionRecording{
+		Version:    sessionRecordingVersion,
+		MCPWire:    app.Version,
+		RecordedAt: clock.Stamp(wallClock),
+		Operations: []recordedOperation{},
+	}
+
+	for _, outcome := range session.Outcomes {
+		last := len(recording.Operations) - 1
+		if last >= 0 {
+			previous := &recording.Operations[last]
+			if previous.Action == outcome.Action && previous.Service == outcome.Service && previous.Scope == outcome.Scope {
+				previous.Targets = append(previous.Targets, outcome.Target)
+				continue
+			}
+		}
+
+		recording.Operations = append(recording.Operations, recordedOperation{
+			Action:  outcome.Action,
+			Service: outcome.Service,
+			Scope:   outcome.Scope,
+			Targets: []string{outcome.Target},
+		})
+	}
+
+	return recording
+}
+
+func writeSessionRecording(path string, session tui.Session) error {
+	data, err := json.MarshalIndent(newSessionRecording(session), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode session recording: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write session recording: %w", err)
+	}
+
+	return nil
+}
+
+func loadSessionRecording(path string) (sessionRecording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return sessionRecording{}, fmt.Errorf("read session recording: %w", err)
+	}
+
+	var recording sessionRecording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return sessionRecording{}, fmt.Errorf("parse session recording %q: %w", path, err)
+	}
+
+	if recording.Version != sessionRecordingVersion {
+		return sessionRecording{}, fmt.Errorf("session recording %q has version %d; this mcp-wire reads version %d", path, recording.Version, sessionRecordingVersion)
+	}
+
+	for i, operation := range recording.Operations {
+		switch {
+		case operation.Action != "install" && operation.Action != "uninstall" && operation.Action != "reinstall":
+			return sessionRecording{}, fmt.Errorf("session recording %q: operation %d has unknown action %q", path, i+1, operation.Action)
+		case strings.TrimSpace(operation.Service) == "":
+			return sessionRecording{}, fmt.Errorf("session recording %q: operation %d has no service", path, i+1)
+		case len(operation.Targets) == 0:
+			return sessionRecording{}, fmt.Errorf("session recording %q: operation %d has no targets", path, i+1)
+		}
+	}
+
+	return recording, nil
+}
+
+// replaySession applies every operation of the recording at path, in order,
+// the way the equivalent install or uninstall command would with
+// --no-prompt. A failed operation does not stop the ones after it.
+func replaySession(cmd *cobra.Command, path string) error {
+	recording, err := loadSessionRecording(path)
+	if err != nil {
+		return err
+	}
+
+	output := cmd.OutOrStdout()
+	if len(recording.Operations) == 0 {
+		fmt.Fprintln(output, "The session recording has no operations.")
+		return nil
+	}
+
+	failed := 0
+	for i, operation := range recording.Operations {
+		args := replayArguments(operation)
+		fmt.Fprintf(output, "\n[%d/%d] mcp-wire %s\n", i+1, len(recording.Operations), strings.Join(args, " "))
+
+		if err := runReplayedOperation(cmd, operation, args); err != nil {
+			fmt.Fprintf(output, "  failed: %v\n", err)
+			failed++
+		}
+	}
+
+	fmt.Fprintf(output, "\n%d of %d operations applied\n", len(recording.Operations)-failed, len(recording.Operations))
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d operations failed", failed, len(recording.Operations))
+	}
+
+	return nil
+}
+
+// replayArguments returns the install or uninstall command line that
+// applies operation. A reinstall is an install.
+func replayArguments(operation recordedOperation) []string {
+	action := operation.Action
+	if action == "reinstall" {
+		action = "install"
+	}
+
+	args := []string{action, operation.Service}
+	for _, slug := range operation.Targets {
+		args = append(args, "--target", slug)
+	}
+
+	if operation.Scope != "" {
+		args = append(args, "--scope", operation.Scope)
+	}
+
+	if action == "install" {
+		args = append(args, "--no-prompt")
+	}
+
+	return args
+}
+
+func runReplayedOperation(cmd *cobra.Command, operation recordedOperation, args []string) error {
+	operationCmd := newUninstallCmd()
+	if operation.Action != "uninstall" {
+		operationCmd = newInstallCmd()
+	}
+
+	operationCmd.SetArgs(args[1:])
+	operationCmd.SetIn(strings.NewReader(""))
+	operationCmd.SetOut(cmd.OutOrStdout())
+	operationCmd.SetErr(cmd.ErrOrStderr())
+	operationCmd.SetContext(cmd.Context())
+	operationCmd.SilenceUsage = true
+	operationCmd.SilenceErrors = true
+
+	return operationCmd.Execute()
+}
+
+// sessionFileFlags returns the --record and --replay paths, rejecting both
+// at once.
+func sessionFileFlags(cmd *cobra.Command) (record string, replay string, err error) {
+	record = strings.TrimSpace(flagString(cmd, "record"))
+	replay = strings.TrimSpace(flagString(cmd, "replay"))
+	if record != "" && replay != "" {
+		return "", "", errors.New("--record and --replay cannot be combined")
+	}
+
+	return record, replay, nil
+}
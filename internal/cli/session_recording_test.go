@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+func TestSessionRecordingGroupsTargetsOfOneApply(t *testing.T) {
+	original := wallClock
+	wallClock = clock.Fixed(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+	t.Cleanup(func() { wallClock = original })
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	err := writeSessionRecording(path, tui.Session{Outcomes: []tui.Outcome{
+		{Action: "install", Service: "sentry", Target: "claude", Scope: "user"},
+		{Action: "install", Service: "sentry", Target: "codex", Scope: "user", Error: "write failed"},
+		{Action: "uninstall", Service: "jira", Target: "claude", Scope: "project"},
+		{Action: "reinstall", Service: "github", Target: "codex"},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	recording, err := loadSessionRecording(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(recording.Operations) != 3 {
+		t.Fatalf("expected 3 operations, got %+v", recording.Operations)
+	}
+
+	if got := strings.Join(replayArguments(recording.Operations[0]), " "); got != "install sentry --target claude --target codex --scope user --no-prompt" {
+		t.Fatalf("unexpected install replay %q", got)
+	}
+
+	if got := strings.Join(replayArguments(recording.Operations[1]), " "); got != "uninstall jira --target claude --scope project" {
+		t.Fatalf("unexpected uninstall replay %q", got)
+	}
+
+	if got := strings.Join(replayArguments(recording.Operations[2]), " "); got != "install github --target codex --no-prompt" {
+		t.Fatalf("unexpected reinstall replay %q", got)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read recording: %v", err)
+	}
+
+	if strings.Contains(string(data), "write failed") || !strings.Contains(string(data), `"recorded_at": "2026-10-01T12:00:00Z"`) {
+		t.Fatalf("unexpected recording %s", data)
+	}
+}
+
+func TestLoadSessionRecordingRejectsInvalidFiles(t *testing.T) {
+	tests := map[string]string{
+		"bad version":    `{"version": 2, "operations": []}`,
+		"unknown action": `{"version": 1, "operations": [{"action": "upgrade", "service": "jira", "targets": ["claude"]}]}`,
+		"no targets":     `{"version": 1, "operations": [{"action": "install", "service": "jira", "targets": []}]}`,
+		"not json":       `operations`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "session.json")
+			if err := writeTempFile(path, content); err != nil {
+				t.Fatalf("write recording: %v", err)
+			}
+
+			if _, err := loadSessionRecording(path); err == nil {
+				t.Fatalf("expected error for %s", content)
+			}
+		})
+	}
+}
+
+func TestReplaySessionAppliesOperationsInOrder(t *testing.T) {
+	alpha := newFakeManifestTarget("alpha-cli")
+	beta := newFakeManifestTarget("beta-cli")
+	dir := overrideInstallFromDependencies(t, alpha, beta)
+
+	path := filepath.Join(dir, "session.json")
+	if err := writeTempFile(path, `{"version": 1, "operations": [
+  {"action": "install", "service": "demo-service", "scope": "user", "targets": ["alpha-cli", "beta-cli"]},
+  {"action": "install", "service": "team-service", "targets": ["alpha-cli"]},
+  {"action": "uninstall", "service": "demo-service", "scope": "user", "targets": ["alpha-cli"]}
+]}`); err != nil {
+		t.Fatalf("write recording: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	output := new(bytes.Buffer)
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+
+	err := replaySession(cmd, path)
+	if err == nil || err.Error() != "1 of 3 operations failed" {
+		t.Fatalf("expected the operation without credentials to fail, got %v", err)
+	}
+
+	if _, ok := alpha.configured[targetpkg.ConfigScopeUser]["demo-service"]; ok {
+		t.Fatal("expected demo-service to be uninstalled from alpha-cli")
+	}
+
+	if beta.configured[targetpkg.ConfigScopeUser]["demo-service"] == "" {
+		t.Fatal("expected demo-service to stay on beta-cli")
+	}
+
+	for _, want := range []string{
+		"[1/3] mcp-wire install demo-service --target alpha-cli --target beta-cli --scope user --no-prompt\n",
+		`failed: required credential "TEAM" not found`,
+		"[3/3] mcp-wire uninstall demo-service --target alpha-cli --scope user\n",
+		"2 of 3 operations applied\n",
+	} {
+		if !strings.Contains(output.String(), want) {
+			t.Fatalf("expected %q in output, got %q", want, output.String())
+		}
+	}
+}
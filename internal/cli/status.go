@@ -0,0 +1,571 @@
+package cli
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/format"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+// Drift states of a service entry, compared with the install records.
+const (
+	statusInSync   = "ok"
+	statusAdded    = "added"
+	statusRemoved  = "removed"
+	statusModified = "modified"
+)
+
+func init() {
+	registerCommand(newStatusCmd)
+}
+
+func newStatusCmd() *cobra.Command {
+	var targetSlugs []string
+	var recorded bool
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show configured services and drift from what mcp-wire installed",
+		Long: `status lists the services configured on each target and compares them with
+the install records mcp-wire keeps under its state directory. Entries that
+changed outside mcp-wire are reported as drift:
+
+  added     configured, but mcp-wire has no record of installing it
+  removed   installed by mcp-wire, but gone from the target config
+  modified  installed by mcp-wire, then edited by hand or by another tool
+
+Project scope is not compared, since the records do not say which project
+an entry was written to. Run "mcp-wire why <service>" to see where a
+service came from.
+
+With --recorded, status only lists what the install state file
+(state.json under the state directory) records, without reading any
+target config.
+
+On a terminal, status then offers to uninstall drifted entries: pick them
+by number and each goes through the review and apply steps of the
+uninstall wizard. Uninstalling a removed entry forgets its stale record.
+System scope entries are never offered. Nothing changes without a
+selection.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonOutput, err := jsonOutputRequested(cmd)
+			if err != nil {
+				return err
+			}
+
+			if recorded {
+				installs, err := listRecordedInstalls(targetSlugs)
+				if err != nil {
+					return err
+				}
+
+				if jsonOutput {
+					return writeJSON(cmd.OutOrStdout(), installs)
+				}
+
+				writeRecordedInstalls(cmd.OutOrStdout(), installs)
+				return nil
+			}
+
+			targetDefinitions, err := resolveInstallTargets(targetSlugs)
+			if err != nil {
+				return err
+			}
+
+			report, err := buildStatusReport(targetDefinitions)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), report)
+			}
+
+			writeStatusReport(cmd.OutOrStdout(), report)
+
+			if !report.Drift || !isTerminalReader(cmd.InOrStdin()) {
+				return nil
+			}
+
+			return offerDriftCleanup(cmd, bufio.NewReader(cmd.InOrStdin()), targetDefinitions, report)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Only look at specific target slug(s) or @group(s); can be repeated")
+	cmd.Flags().BoolVar(&recorded, "recorded", false, "List the installs mcp-wire recorded, without reading target configs")
+
+	return cmd
+}
+
+// recordedInstall is one install in the state file, as `status --recorded`
+// prints it.
+type recordedInstall struct {
+	Service     string    `json:"service"`
+	Target      string    `json:"target"`
+	Scope       string    `json:"scope"`
+	Command     string    `json:"command"`
+	Source      string    `json:"source,omitempty"`
+	Version     string    `json:"version"`
+	InstalledAt time.Time `json:"installed_at"`
+	Env         []string  `json:"env,omitempty"`
+}
+
+// listRecordedInstalls returns the recorded installs, limited to the given
+// target slugs or groups when there are any.
+func listRecordedInstalls(targetSlugs []string) ([]recordedInstall, error) {
+	slugs := make([]string, 0, len(targetSlugs))
+	for _, slug := range targetSlugs {
+		if trimmed := strings.ToLower(strings.TrimSpace(slug)); trimmed != "" {
+			slugs = append(slugs, trimmed)
+		}
+	}
+
+	slugs, err := expandTargetGroups(slugs)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := newProvenanceStore().All()
+	if err != nil {
+		return nil, err
+	}
+
+	installs := make([]recordedInstall, 0, len(records))
+	for _, record := range records {
+		if len(slugs) > 0 && !containsFold(slugs, record.Target) {
+			continue
+		}
+
+		install := recordedInstall{
+			Service:     record.Service,
+			Target:      record.Target,
+			Scope:       record.Scope,
+			Command:     record.Command,
+			Source:      record.Source,
+			Version:     record.Version,
+			InstalledAt: record.InstalledAt,
+		}
+
+		for _, use := range record.Credentials {
+			install.Env = append(install.Env, use.Name)
+		}
+
+		installs = append(installs, install)
+	}
+
+	return installs, nil
+}
+
+func writeRecordedInstalls(output io.Writer, installs []recordedInstall) {
+	if len(installs) == 0 {
+		fmt.Fprintln(output, "mcp-wire has no record of installing any service.")
+		return
+	}
+
+	nameWidth := 0
+	for _, install := range installs {
+		if len(install.Service) > nameWidth {
+			nameWidth = len(install.Service)
+		}
+	}
+
+	for _, install := range installs {
+		line := fmt.Sprintf("%-*s  %s, %s scope, installed %s with mcp-wire %s by %s",
+			nameWidth, install.Service, install.Target, install.Scope,
+			format.Timestamp(install.InstalledAt), install.Version, describeOrigin(install.Command, install.Source))
+		if len(install.Env) > 0 {
+			line += "; env " + strings.Join(install.Env, ", ")
+		}
+
+		fmt.Fprintln(output, line)
+	}
+}
+
+// statusReport is the result of `status`, also its JSON output.
+type statusReport struct {
+	Drift   bool           `json:"drift"`
+	Targets []statusTarget `json:"targets"`
+}
+
+// statusTarget lists the services of one target, or why its config could
+// not be read.
+type statusTarget struct {
+	Target   string          `json:"target"`
+	Name     string          `json:"name"`
+	Error    string          `json:"error,omitempty"`
+	Services []statusService `json:"services"`
+}
+
+// statusService is one service entry in one scope and its drift state.
+type statusService struct {
+	Service string `json:"service"`
+	Scope   string `json:"scope"`
+	State   string `json:"state"`
+}
+
+// buildStatusReport compares, for every scope of each target other than
+// project, the configured services with the install records.
+func buildStatusReport(targetDefinitions []targetpkg.Target) (statusReport, error) {
+	records, err := newProvenanceStore().All()
+	if err != nil {
+		return statusReport{}, err
+	}
+
+	report := statusReport{Targets: make([]statusTarget, 0, len(targetDefinitions))}
+	for _, t := range targetDefinitions {
+		entry := statusTarget{Target: t.Slug(), Name: t.Name(), Services: make([]statusService, 0)}
+
+		for _, scope := range statusScopes(t) {
+			services, err := statusScopeServices(t, scope, records)
+			if err != nil {
+				entry.Error = err.Error()
+				entry.Services = entry.Services[:0]
+				break
+			}
+
+			entry.Services = append(entry.Services, services...)
+		}
+
+		for _, service := range entry.Services {
+			if service.State != statusInSync {
+				report.Drift = true
+			}
+		}
+
+		report.Targets = append(report.Targets, entry)
+	}
+
+	return report, nil
+}
+
+// statusScopes returns the scopes of t that status compares.
+func statusScopes(t targetpkg.Target) []targetpkg.ConfigScope {
+	scopedTarget, ok := t.(targetpkg.ScopedTarget)
+	if !ok {
+		return []targetpkg.ConfigScope{targetpkg.ConfigScopeUser}
+	}
+
+	var scopes []targetpkg.ConfigScope
+	for _, scope := range scopedTarget.SupportedScopes() {
+		if scope == targetpkg.ConfigScopeProject || scope == targetpkg.ConfigScopeEffective {
+			continue
+		}
+
+		scopes = append(scopes, scope)
+	}
+
+	return scopes
+}
+
+func statusScopeServices(t targetpkg.Target, scope targetpkg.ConfigScope, records []state.Provenance) ([]statusService, error) {
+	var names []string
+	var err error
+	if scopedTarget, ok := t.(targetpkg.ScopedTarget); ok {
+		names, err = scopedTarget.ListWithScope(scope)
+	} else {
+		names, err = t.List()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var scopeRecords []state.Provenance
+	for _, record := range records {
+		if strings.EqualFold(record.Target, t.Slug()) && record.Scope == string(scope) {
+			scopeRecords = append(scopeRecords, record)
+		}
+	}
+
+	services := make([]statusService, 0, len(names)+len(scopeRecords))
+	for _, name := range names {
+		service := statusService{Service: name, Scope: string(scope), State: statusAdded}
+
+		for _, record := range scopeRecords {
+			if !strings.EqualFold(record.Service, name) {
+				continue
+			}
+
+			service.State = statusInSync
+			if record.Fingerprint != "" && record.Fingerprint != entryFingerprint(t, name, scope) {
+				service.State = statusModified
+			}
+		}
+
+		services = append(services, service)
+	}
+
+	for _, record := range scopeRecords {
+		if !containsFold(names, record.Service) {
+			services = append(services, statusService{Service: record.Service, Scope: string(scope), State: statusRemoved})
+		}
+	}
+
+	sort.SliceStable(services, func(i, j int) bool {
+		return services[i].Service < services[j].Service
+	})
+
+	return services, nil
+}
+
+// entryFingerprint hashes the entry t holds for serviceName in scope, so a
+// later edit to it can be detected. It is empty when the target cannot show
+// the entry.
+func entryFingerprint(t targetpkg.Target, serviceName string, scope targetpkg.ConfigScope) string {
+	explainer, ok := t.(targetpkg.Explainer)
+	if !ok {
+		return ""
+	}
+
+	layers, err := explainer.ExplainService(serviceName)
+	if err != nil {
+		return ""
+	}
+
+	for _, layer := range layers {
+		if layer.Scope != scope || layer.Entry == nil {
+			continue
+		}
+
+		data, err := json.Marshal(layer.Entry)
+		if err != nil {
+			return ""
+		}
+
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+
+	return ""
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeStatusReport(output io.Writer, report statusReport) {
+	drifted := 0
+	for _, target := range report.Targets {
+		fmt.Fprintf(output, "%s (%s):\n", target.Name, target.Target)
+
+		if target.Error != "" {
+			fmt.Fprintf(output, "  cannot read config: %s\n", target.Error)
+			continue
+		}
+
+		if len(target.Services) == 0 {
+			fmt.Fprintln(output, "  no services configured")
+			continue
+		}
+
+		nameWidth := 0
+		for _, service := range target.Services {
+			if len(service.Service) > nameWidth {
+				nameWidth = len(service.Service)
+			}
+		}
+
+		for _, service := range target.Services {
+			if service.State != statusInSync {
+				drifted++
+			}
+
+			marker := "\u2713"
+			if service.State != statusInSync {
+				marker = "\u2717"
+			}
+
+			fmt.Fprintf(output, "  %s %-*s  %-7s  %s\n", marker, nameWidth, service.Service, service.Scope, describeDrift(service.State))
+		}
+	}
+
+	fmt.Fprintln(output)
+	if !report.Drift {
+		fmt.Fprintln(output, "No drift: every entry matches what mcp-wire installed.")
+		return
+	}
+
+	noun := "entries"
+	if drifted == 1 {
+		noun = "entry"
+	}
+
+	fmt.Fprintf(output, "Drift: %d %s changed outside mcp-wire. Run `mcp-wire why <service>` for details.\n", drifted, noun)
+}
+
+// describeDrift renders a drift state for people.
+func describeDrift(state string) string {
+	switch state {
+	case statusAdded:
+		return "added outside mcp-wire"
+	case statusRemoved:
+		return "removed outside mcp-wire"
+	case statusModified:
+		return "modified outside mcp-wire"
+	default:
+		return "in sync"
+	}
+}
+
+// driftEntry is a drifted entry status offers to uninstall.
+type driftEntry struct {
+	target  targetpkg.Target
+	service statusService
+}
+
+// offerDriftCleanup lists the drifted entries of report and uninstalls the
+// ones picked, through the review and apply steps of the uninstall wizard.
+func offerDriftCleanup(cmd *cobra.Command, reader *bufio.Reader, targetDefinitions []targetpkg.Target, report statusReport) error {
+	entries := removableDriftEntries(targetDefinitions, report)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	output := cmd.OutOrStdout()
+	fmt.Fprintln(output)
+	fmt.Fprintln(output, "Remove \u2717 entries?")
+	for i, entry := range entries {
+		fmt.Fprintf(output, "  %d) %s from %s, %s scope (%s)\n",
+			i+1, entry.service.Service, entry.target.Name(), entry.service.Scope, describeDrift(entry.service.State))
+	}
+
+	for {
+		selection, err := readTrimmedLine(reader, output, "Entry numbers [e.g. 1,3], \"all\", or Enter to skip: ")
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("read entry selection: %w", err)
+		}
+
+		if selection == "" {
+			return nil
+		}
+
+		selected, err := parseEntrySelection(selection, entries)
+		if err != nil {
+			fmt.Fprintf(output, "Invalid selection: %v\n", err)
+			continue
+		}
+
+		return uninstallDriftEntries(cmd, reader, targetDefinitions, selected)
+	}
+}
+
+// removableDriftEntries returns the drifted entries of report, leaving out
+// system scope, which is never changed without --allow-system.
+func removableDriftEntries(targetDefinitions []targetpkg.Target, report statusReport) []driftEntry {
+	var entries []driftEntry
+	for i, target := range report.Targets {
+		for _, service := range target.Services {
+			if service.State == statusInSync || service.Scope == string(targetpkg.ConfigScopeSystem) {
+				continue
+			}
+
+			entries = append(entries, driftEntry{target: targetDefinitions[i], service: service})
+		}
+	}
+
+	return entries
+}
+
+// parseEntrySelection returns the entries picked by input: comma-separated
+// numbers counted from 1, or "all".
+func parseEntrySelection[E any](input string, entries []E) ([]E, error) {
+	if strings.EqualFold(strings.TrimSpace(input), "all") {
+		return entries, nil
+	}
+
+	selected := make([]E, 0, len(entries))
+	seen := make(map[int]bool)
+	for _, token := range strings.Split(input, ",") {
+		index, err := strconv.Atoi(strings.TrimSpace(token))
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry number %q", strings.TrimSpace(token))
+		}
+
+		if index < 1 || index > len(entries) {
+			return nil, fmt.Errorf("entry number %d is out of range", index)
+		}
+
+		if !seen[index] {
+			seen[index] = true
+			selected = append(selected, entries[index-1])
+		}
+	}
+
+	return selected, nil
+}
+
+// uninstallDriftEntries uninstalls the selected entries, one wizard review
+// per service and scope covering every target picked for it.
+func uninstallDriftEntries(cmd *cobra.Command, reader *bufio.Reader, targetDefinitions []targetpkg.Target, selected []driftEntry) error {
+	type driftGroup struct {
+		service string
+		scope   targetpkg.ConfigScope
+		targets []targetpkg.Target
+	}
+
+	var groups []*driftGroup
+	for _, entry := range selected {
+		scope := targetpkg.ConfigScope(entry.service.Scope)
+
+		var group *driftGroup
+		for _, existing := range groups {
+			if strings.EqualFold(existing.service, entry.service.Service) && existing.scope == scope {
+				group = existing
+			}
+		}
+
+		if group == nil {
+			group = &driftGroup{service: entry.service.Service, scope: scope}
+			groups = append(groups, group)
+		}
+
+		group.targets = append(group.targets, entry.target)
+	}
+
+	applyPruneEmpty(targetDefinitions, false)
+
+	output := cmd.OutOrStdout()
+	var uninstallErrors []error
+	for _, group := range groups {
+		svc := installedServiceDefinition(group.service)
+
+		confirmed, err := confirmUninstallSelection(output, reader, svc, group.targets, group.scope)
+		if err != nil {
+			return err
+		}
+
+		if !confirmed {
+			fmt.Fprintln(output, "Uninstall cancelled.")
+			continue
+		}
+
+		if err := applyUninstallSelection(cmd, svc, group.targets, group.scope); err != nil {
+			uninstallErrors = append(uninstallErrors, err)
+		}
+	}
+
+	return errors.Join(uninstallErrors...)
+}
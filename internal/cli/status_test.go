@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// explainingInstallTarget also shows the entries it holds, so status can
+// tell an edited entry from the one mcp-wire wrote.
+type explainingInstallTarget struct {
+	*listingInstallTarget
+	entries map[string]map[string]any
+}
+
+func newExplainingInstallTarget() *explainingInstallTarget {
+	return &explainingInstallTarget{
+		listingInstallTarget: &listingInstallTarget{fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}},
+		entries:              map[string]map[string]any{},
+	}
+}
+
+func (t *explainingInstallTarget) Install(svc service.Service, resolvedEnv map[string]string) error {
+	if err := t.listingInstallTarget.Install(svc, resolvedEnv); err != nil {
+		return err
+	}
+
+	t.entries[svc.Name] = map[string]any{"command": svc.Command}
+	return nil
+}
+
+func (t *explainingInstallTarget) ExplainService(serviceName string) ([]targetpkg.ScopeLayer, error) {
+	return []targetpkg.ScopeLayer{{Scope: targetpkg.ConfigScopeUser, Entry: t.entries[serviceName]}}, nil
+}
+
+func TestStatusCommandReportsNoDriftAfterInstall(t *testing.T) {
+	alpha := newExplainingInstallTarget()
+	overrideWhyDependencies(t, alpha)
+
+	if _, err := executeRootCommand(t, "install", "demo", "--no-prompt"); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	output, err := executeRootCommand(t, "status")
+	if err != nil {
+		t.Fatalf("expected status to succeed: %v", err)
+	}
+
+	for _, want := range []string{"Alpha CLI (alpha-cli):", "demo  user     in sync", "No drift"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+}
+
+func TestStatusCommandReportsDrift(t *testing.T) {
+	alpha := newExplainingInstallTarget()
+	overrideWhyDependencies(t, alpha)
+
+	if _, err := executeRootCommand(t, "install", "demo", "--no-prompt"); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	// Edited by hand, and another service added behind mcp-wire's back.
+	alpha.entries["demo"]["command"] = "uvx"
+	alpha.services = append(alpha.services, "manual")
+
+	output, err := executeRootCommand(t, "status")
+	if err != nil {
+		t.Fatalf("expected status to succeed: %v", err)
+	}
+
+	for _, want := range []string{
+		"demo    user     modified outside mcp-wire",
+		"manual  user     added outside mcp-wire",
+		"Drift: 2 entries changed outside mcp-wire.",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+}
+
+func TestStatusCommandJSONReportsRemovedEntry(t *testing.T) {
+	alpha := newExplainingInstallTarget()
+	overrideWhyDependencies(t, alpha)
+
+	if _, err := executeRootCommand(t, "install", "demo", "--no-prompt"); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	// Removed by hand.
+	alpha.services = nil
+
+	stdout, _, err := executeCommandWithOutputFlags(t, newStatusCmd(), "--json")
+	if err != nil {
+		t.Fatalf("expected status to succeed: %v", err)
+	}
+
+	var report statusReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", stdout, err)
+	}
+
+	if !report.Drift || len(report.Targets) != 1 {
+		t.Fatalf("expected drift on one target, got %+v", report)
+	}
+
+	services := report.Targets[0].Services
+	if len(services) != 1 || services[0].Service != "demo" || services[0].State != statusRemoved {
+		t.Fatalf("expected demo to be reported removed, got %+v", services)
+	}
+}
+
+func executeStatusCommandWithInput(t *testing.T, input string) (string, error) {
+	t.Helper()
+
+	originalIsTerminalReader := isTerminalReader
+	isTerminalReader = func(_ io.Reader) bool { return true }
+	t.Cleanup(func() { isTerminalReader = originalIsTerminalReader })
+
+	return executeRootCommandWithInput(t, strings.NewReader(input), "status")
+}
+
+func TestStatusCommandUninstallsSelectedDriftEntries(t *testing.T) {
+	alpha := newExplainingInstallTarget()
+	alpha.services = []string{"manual"}
+	overrideWhyDependencies(t, alpha)
+
+	output, err := executeStatusCommandWithInput(t, "1\ny\n")
+	if err != nil {
+		t.Fatalf("expected status to succeed: %v", err)
+	}
+
+	for _, want := range []string{
+		"\u2717 manual  user     added outside mcp-wire",
+		"1) manual from Alpha CLI, user scope (added outside mcp-wire)",
+		"Step 3/4: Review",
+		"Alpha CLI: removed",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+
+	if len(alpha.services) != 0 {
+		t.Fatalf("expected manual to be uninstalled, got %v", alpha.services)
+	}
+}
+
+func TestStatusCommandSkipsCleanupWithoutSelection(t *testing.T) {
+	alpha := newExplainingInstallTarget()
+	alpha.services = []string{"manual"}
+	overrideWhyDependencies(t, alpha)
+
+	output, err := executeStatusCommandWithInput(t, "\n")
+	if err != nil {
+		t.Fatalf("expected status to succeed: %v", err)
+	}
+
+	if strings.Contains(output, "Step 3/4: Review") || len(alpha.services) != 1 {
+		t.Fatalf("expected nothing to change, got %v and output %q", alpha.services, output)
+	}
+}
+
+func TestStatusCommandListsRecordedInstalls(t *testing.T) {
+	alpha := newExplainingInstallTarget()
+	overrideWhyDependencies(t, alpha)
+
+	if _, err := executeRootCommand(t, "install", "demo", "--no-prompt"); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	// The record is listed even though the target no longer holds it.
+	alpha.services = nil
+
+	output, _, err := executeCommandWithOutputFlags(t, newStatusCmd(), "--recorded", "--target", "alpha-cli")
+	if err != nil {
+		t.Fatalf("expected status to succeed: %v", err)
+	}
+
+	for _, want := range []string{"demo  alpha-cli, user scope, installed", "by `mcp-wire install`; env DEMO_TOKEN"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+
+	if strings.Contains(output, "removed outside mcp-wire") {
+		t.Fatalf("expected target configs to be left unread, got %q", output)
+	}
+}
@@ -0,0 +1,307 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/webhook"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	registerCommand(newSyncCmd)
+}
+
+func newSyncCmd() *cobra.Command {
+	var projectPatterns []string
+	var targetSlugs []string
+	var noPrompt bool
+	var manifestPath string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "sync [service...]",
+		Short: "Reconcile targets with mcp-wire.yaml, or install services into many projects",
+		Long: `Without arguments, sync reads mcp-wire.yaml from the current directory
+(or --manifest) and makes the targets match it: listed services that are
+missing are installed, entries that drifted are rewritten, and with
+"prune: true" services the manifest does not list are removed from the
+targets and scopes it manages. Project scope means the directory holding the
+manifest. Use --dry-run to see the changes without making them.
+
+With services, sync installs them at project scope into every directory
+matched by --projects, for monorepos where many packages need the same
+project-level MCP wiring. Credentials are resolved once per service.
+
+Only targets that support project scope are used. A table summarizes the
+result for every project, service, and target.`,
+		Example: `  mcp-wire sync
+  mcp-wire sync --manifest ops/mcp-wire.yaml --dry-run
+  mcp-wire sync jira sentry --projects 'packages/*'
+  mcp-wire sync github --projects 'apps/*' --projects 'libs/*' --target claude`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			promptDisabled := ciNoPrompt(cmd, noPrompt)
+
+			if len(args) == 0 {
+				if len(projectPatterns) > 0 {
+					return errors.New("--projects needs the services to sync; without services, sync reads mcp-wire.yaml")
+				}
+
+				if len(targetSlugs) > 0 {
+					return errors.New("--target cannot be used with a manifest; list targets in the manifest instead")
+				}
+
+				return runManifestSync(cmd, manifestPath, dryRun, promptDisabled)
+			}
+
+			if manifestPath != "" || dryRun {
+				return errors.New("--manifest and --dry-run only apply when syncing from a manifest; drop the service arguments")
+			}
+
+			baseDir, err := getWorkingDirectory()
+			if err != nil {
+				return fmt.Errorf("resolve current working directory: %w", err)
+			}
+
+			projectDirs, err := expandProjectPatterns(baseDir, projectPatterns)
+			if err != nil {
+				return err
+			}
+
+			services := make([]service.Service, 0, len(args))
+			for _, arg := range args {
+				svc, err := resolveServiceByName(strings.TrimSpace(arg))
+				if err != nil {
+					return err
+				}
+
+				services = append(services, svc)
+			}
+
+			targetDefinitions, err := resolveInstallTargets(targetSlugs)
+			if err != nil {
+				return err
+			}
+
+			projectTargets := make([]target.Target, 0, len(targetDefinitions))
+			for _, targetDefinition := range targetDefinitions {
+				_, pinnable := targetDefinition.(target.ProjectDirSetter)
+				if pinnable && targetSupportsScope(targetDefinition, target.ConfigScopeProject) {
+					projectTargets = append(projectTargets, targetDefinition)
+				}
+			}
+
+			if len(projectTargets) == 0 {
+				return errors.New("none of the selected targets support project scope")
+			}
+
+			return runSync(cmd, services, projectTargets, baseDir, projectDirs, promptDisabled)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&projectPatterns, "projects", nil, "Glob of project directories to sync, e.g. 'packages/*'; can be repeated")
+	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Sync specific target slug(s) or @group(s); can be repeated")
+	cmd.Flags().BoolVar(&noPrompt, "no-prompt", false, "Fail when required credentials are missing instead of prompting")
+	cmd.Flags().StringVar(&manifestPath, "manifest", "", "Manifest to reconcile targets with (default: mcp-wire.yaml in the current directory)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the changes a manifest sync would make without making them")
+
+	return cmd
+}
+
+// syncResult is the outcome of installing one service on one target in one
+// project.
+type syncResult struct {
+	project  string
+	service  string
+	target   string
+	verified bool
+	err      error
+}
+
+func runSync(cmd *cobra.Command, services []service.Service, targetDefinitions []target.Target, baseDir string, projectDirs []string, noPrompt bool) error {
+	for _, svc := range services {
+		if err := checkPolicy("install", svc, targetDefinitions, target.ConfigScopeProject); err != nil {
+			return err
+		}
+	}
+
+	output := cmd.OutOrStdout()
+	resolver, store := newCredentialSources()
+
+	results := make([]syncResult, 0, len(services)*len(targetDefinitions)*len(projectDirs))
+	for _, svc := range services {
+		credentialSources := map[string]string{}
+		resolvedEnv, err := resolveServiceCredentials(svc, resolver, interactiveCredentialOptions{
+			noPrompt: noPrompt,
+			input:    cmd.InOrStdin(),
+			output:   output,
+			store:    store,
+			sources:  credentialSources,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := allocateServicePorts(output, svc, resolvedEnv); err != nil {
+			return err
+		}
+
+		if err := applyRegistrySubstitutions(&svc, resolvedEnv, resolver); err != nil {
+			return err
+		}
+
+		for _, projectDir := range projectDirs {
+			pinProjectDir(targetDefinitions, projectLocation{dir: projectDir, recognized: true})
+
+			for _, targetDefinition := range targetDefinitions {
+				var verified bool
+				err := targetDefinition.(target.ScopedTarget).InstallWithScope(svc, resolvedEnv, target.ConfigScopeProject)
+				emitWebhookEvent(webhook.EventInstall, svc.Name, targetDefinition, target.ConfigScopeProject, err)
+				if err == nil {
+					recordServiceProvenance(svc, targetDefinition, target.ConfigScopeProject, serviceOrigin{command: "sync", source: projectDir, credentialSources: credentialSources})
+					verified, err = verifyInstalledService(targetDefinition, svc, resolvedEnv, target.ConfigScopeProject)
+				}
+
+				results = append(results, syncResult{
+					project:  displayProjectDir(baseDir, projectDir),
+					service:  svc.Name,
+					target:   targetDefinition.Slug(),
+					verified: verified,
+					err:      err,
+				})
+			}
+		}
+
+		recordRecentService(svc.Name)
+	}
+
+	failed := writeSyncResults(output, results)
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d project installs failed", failed, len(results))
+	}
+
+	return nil
+}
+
+// expandProjectPatterns expands each glob, relative to baseDir, into the
+// directories it matches, sorted and without duplicates. Files matched by a
+// pattern are skipped.
+func expandProjectPatterns(baseDir string, patterns []string) ([]string, error) {
+	seen := make(map[string]struct{})
+	projectDirs := make([]string, 0)
+
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		globPattern := pattern
+		if !filepath.IsAbs(globPattern) {
+			globPattern = filepath.Join(baseDir, globPattern)
+		}
+
+		matches, err := filepath.Glob(globPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --projects pattern %q: %w", pattern, err)
+		}
+
+		matchedDir := false
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil || !info.IsDir() {
+				continue
+			}
+
+			absoluteDir, err := filepath.Abs(match)
+			if err != nil {
+				return nil, fmt.Errorf("resolve project directory %q: %w", match, err)
+			}
+
+			matchedDir = true
+			if _, exists := seen[absoluteDir]; exists {
+				continue
+			}
+
+			seen[absoluteDir] = struct{}{}
+			projectDirs = append(projectDirs, absoluteDir)
+		}
+
+		if !matchedDir {
+			return nil, fmt.Errorf("--projects %q matched no directories", pattern)
+		}
+	}
+
+	if len(projectDirs) == 0 {
+		return nil, errors.New("--projects is required")
+	}
+
+	sort.Strings(projectDirs)
+
+	return projectDirs, nil
+}
+
+func displayProjectDir(baseDir, projectDir string) string {
+	relativeDir, err := filepath.Rel(baseDir, projectDir)
+	if err != nil || strings.HasPrefix(relativeDir, "..") {
+		return projectDir
+	}
+
+	return filepath.ToSlash(relativeDir)
+}
+
+// writeSyncResults prints the result table and returns how many installs
+// failed.
+func writeSyncResults(output io.Writer, results []syncResult) int {
+	projectWidth := len("PROJECT")
+	serviceWidth := len("SERVICE")
+	targetWidth := len("TARGET")
+	for _, result := range results {
+		projectWidth = max(projectWidth, len(result.project))
+		serviceWidth = max(serviceWidth, len(result.service))
+		targetWidth = max(targetWidth, len(result.target))
+	}
+
+	fmt.Fprintln(output)
+	fmt.Fprintf(output, "%-*s  %-*s  %-*s  %s\n", projectWidth, "PROJECT", serviceWidth, "SERVICE", targetWidth, "TARGET", "STATUS")
+
+	failed := 0
+	for _, result := range results {
+		var verification *target.VerificationError
+		status := "configured"
+		switch {
+		case errors.As(result.err, &verification) && verification.NotLoaded != "":
+			status = "configured (would not load)"
+		case errors.As(result.err, &verification):
+			status = "configured (not verified)"
+		case result.err != nil:
+			status = "failed: " + result.err.Error()
+			failed++
+		case result.verified:
+			status = "configured (verified)"
+		}
+
+		fmt.Fprintf(output, "%-*s  %-*s  %-*s  %s\n", projectWidth, result.project, serviceWidth, result.service, targetWidth, result.target, status)
+	}
+
+	fmt.Fprintf(output, "\n%d projects, %d installs, %d failed\n", countProjects(results), len(results), failed)
+
+	return failed
+}
+
+func countProjects(results []syncResult) int {
+	projects := make(map[string]struct{})
+	for _, result := range results {
+		projects[result.project] = struct{}{}
+	}
+
+	return len(projects)
+}
@@ -0,0 +1,338 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/manifest"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/webhook"
+	"github.com/spf13/cobra"
+)
+
+// manifestChange is what sync did, or would do in a dry run, for one service
+// on one target and scope.
+type manifestChange struct {
+	target  string
+	scope   target.ConfigScope
+	service string
+	status  string
+	warning string
+	err     error
+}
+
+// manifestBinding is one target and scope a manifest service should be
+// configured on.
+type manifestBinding struct {
+	target target.Target
+	scope  target.ConfigScope
+}
+
+func (b manifestBinding) key() string {
+	return b.target.Slug() + "\x00" + string(b.scope)
+}
+
+// runManifestSync makes every target match the manifest at manifestPath:
+// missing services are installed, services whose entry drifted are
+// rewritten, and with prune set, services the manifest does not list are
+// removed from the targets and scopes it manages. Project scope is the
+// directory holding the manifest.
+func runManifestSync(cmd *cobra.Command, manifestPath string, dryRun bool, noPrompt bool) error {
+	baseDir, err := getWorkingDirectory()
+	if err != nil {
+		return fmt.Errorf("resolve current working directory: %w", err)
+	}
+
+	explicitPath := manifestPath != ""
+	if !explicitPath {
+		manifestPath = manifest.FileName
+	}
+	if !filepath.IsAbs(manifestPath) {
+		manifestPath = filepath.Join(baseDir, manifestPath)
+	}
+
+	m, err := manifest.Load(manifestPath)
+	if err != nil {
+		if !explicitPath && errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no %s in %s; write one, pass --manifest, or give services and --projects", manifest.FileName, baseDir)
+		}
+
+		return err
+	}
+
+	output := cmd.OutOrStdout()
+	projectDir := filepath.Dir(manifestPath)
+
+	services := make([]service.Service, 0, len(m.Services))
+	bindings := make([][]manifestBinding, 0, len(m.Services))
+	var changes []manifestChange
+	for _, entry := range m.Services {
+		svc, err := resolveServiceByName(entry.Name)
+		if err != nil {
+			return err
+		}
+
+		targetDefinitions, err := resolveInstallTargets(entry.Targets)
+		if err != nil {
+			return fmt.Errorf("service %q: %w", entry.Name, err)
+		}
+
+		pinProjectDir(targetDefinitions, projectLocation{dir: projectDir, recognized: true})
+
+		scope := target.ConfigScope(entry.Scope)
+		serviceBindings := make([]manifestBinding, 0, len(targetDefinitions))
+		for _, targetDefinition := range targetDefinitions {
+			if scope == target.ConfigScopeProject && !targetSupportsScope(targetDefinition, scope) {
+				changes = append(changes, manifestChange{target: targetDefinition.Slug(), scope: scope, service: svc.Name, status: "skipped (no project scope)"})
+				continue
+			}
+
+			serviceBindings = append(serviceBindings, manifestBinding{target: targetDefinition, scope: scope})
+		}
+
+		services = append(services, svc)
+		bindings = append(bindings, serviceBindings)
+	}
+
+	var denials []error
+	for i, svc := range services {
+		for _, binding := range bindings[i] {
+			if err := checkPolicy("install", svc, []target.Target{binding.target}, binding.scope); err != nil {
+				denials = append(denials, err)
+			}
+		}
+	}
+
+	if len(denials) > 0 {
+		return errors.Join(denials...)
+	}
+
+	if dryRun {
+		fmt.Fprintf(output, "Dry run: comparing targets with %s; nothing is changed.\n", displayProjectDir(baseDir, manifestPath))
+	}
+
+	resolver, store := newCredentialSources()
+	for i, svc := range services {
+		if dryRun {
+			for _, binding := range bindings[i] {
+				status := "would install"
+				if serviceConfiguredOn(binding.target, svc.Name, binding.scope) {
+					status = "configured"
+				}
+
+				changes = append(changes, manifestChange{target: binding.target.Slug(), scope: binding.scope, service: svc.Name, status: status})
+			}
+			continue
+		}
+
+		serviceResolver := resolver
+		if env := m.Services[i].Env; len(env) > 0 {
+			serviceResolver, _ = newCredentialSources()
+			serviceResolver.Use(credentialOverrides(env, "manifest"))
+		}
+
+		origin := serviceOrigin{command: "sync", source: manifestPath, credentialSources: map[string]string{}}
+		resolvedEnv, err := resolveServiceCredentials(svc, serviceResolver, interactiveCredentialOptions{
+			noPrompt: noPrompt,
+			input:    cmd.InOrStdin(),
+			output:   output,
+			store:    store,
+			sources:  origin.credentialSources,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := allocateServicePorts(output, svc, resolvedEnv); err != nil {
+			return err
+		}
+
+		if err := applyRegistrySubstitutions(&svc, resolvedEnv, serviceResolver); err != nil {
+			return err
+		}
+
+		for _, binding := range bindings[i] {
+			changes = append(changes, reconcileManifestService(binding, svc, resolvedEnv, origin))
+		}
+
+		recordRecentService(svc.Name)
+	}
+
+	if m.Prune {
+		changes = append(changes, pruneManifestTargets(services, bindings, dryRun)...)
+	}
+
+	failed := writeManifestSyncResults(output, changes)
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d manifest changes failed", failed, len(changes))
+	}
+
+	return nil
+}
+
+// reconcileManifestService leaves an entry that already matches svc alone
+// and writes it otherwise, recording origin for what it wrote.
+func reconcileManifestService(binding manifestBinding, svc service.Service, resolvedEnv map[string]string, origin serviceOrigin) manifestChange {
+	change := manifestChange{target: binding.target.Slug(), scope: binding.scope, service: svc.Name}
+
+	status := "installed"
+	if serviceConfiguredOn(binding.target, svc.Name, binding.scope) {
+		verified, err := verifyInstalledService(binding.target, svc, resolvedEnv, binding.scope)
+		var verification *target.VerificationError
+		switch {
+		case verified && err == nil:
+			change.status = "unchanged"
+			return change
+		case errors.As(err, &verification) && verification.NotLoaded != "":
+			// Rewriting cannot fix an entry the tool would not load.
+			change.status = "unchanged"
+			change.warning = "would not load"
+			return change
+		}
+
+		status = "updated"
+	}
+
+	var err error
+	scopedTarget, supportsScopes := binding.target.(target.ScopedTarget)
+	if supportsScopes && targetSupportsScope(binding.target, binding.scope) {
+		err = scopedTarget.InstallWithScope(svc, resolvedEnv, binding.scope)
+	} else {
+		err = binding.target.Install(svc, resolvedEnv)
+	}
+
+	emitWebhookEvent(webhook.EventInstall, svc.Name, binding.target, binding.scope, err)
+
+	if err != nil {
+		change.status = "failed"
+		change.err = err
+		return change
+	}
+
+	change.status = status
+	recordServiceProvenance(svc, binding.target, binding.scope, origin)
+	if _, verifyErr := verifyInstalledService(binding.target, svc, resolvedEnv, binding.scope); verifyErr != nil {
+		var verification *target.VerificationError
+		if errors.As(verifyErr, &verification) && verification.NotLoaded != "" {
+			change.warning = "would not load"
+		} else {
+			change.warning = "not verified"
+		}
+	}
+
+	return change
+}
+
+// pruneManifestTargets removes services the manifest does not list from
+// every target and scope it configures something on. Targets the manifest
+// never mentions are left alone.
+func pruneManifestTargets(services []service.Service, bindings [][]manifestBinding, dryRun bool) []manifestChange {
+	wanted := make(map[string]map[string]struct{})
+	var managed []manifestBinding
+	for i, serviceBindings := range bindings {
+		for _, binding := range serviceBindings {
+			names, seen := wanted[binding.key()]
+			if !seen {
+				names = make(map[string]struct{})
+				wanted[binding.key()] = names
+				managed = append(managed, binding)
+			}
+
+			names[strings.ToLower(services[i].Name)] = struct{}{}
+		}
+	}
+
+	var changes []manifestChange
+	for _, binding := range managed {
+		var names []string
+		var err error
+		scopedTarget, supportsScopes := binding.target.(target.ScopedTarget)
+		if supportsScopes && targetSupportsScope(binding.target, binding.scope) {
+			names, err = scopedTarget.ListWithScope(binding.scope)
+		} else {
+			names, err = binding.target.List()
+		}
+
+		if err != nil {
+			changes = append(changes, manifestChange{target: binding.target.Slug(), scope: binding.scope, service: "*", status: "failed", err: err})
+			continue
+		}
+
+		sort.Strings(names)
+		for _, name := range names {
+			if _, keep := wanted[binding.key()][strings.ToLower(name)]; keep {
+				continue
+			}
+
+			change := manifestChange{target: binding.target.Slug(), scope: binding.scope, service: name, status: "would remove"}
+			if !dryRun {
+				change.status = "removed"
+				err = checkUninstallPolicy(name, []target.Target{binding.target}, binding.scope)
+				if err == nil {
+					if supportsScopes && targetSupportsScope(binding.target, binding.scope) {
+						err = scopedTarget.UninstallWithScope(name, binding.scope)
+					} else {
+						err = binding.target.Uninstall(name)
+					}
+
+					emitWebhookEvent(webhook.EventUninstall, name, binding.target, binding.scope, err)
+				}
+
+				if err != nil {
+					change.status = "failed"
+					change.err = err
+				} else {
+					releaseServicePorts(name)
+					forgetServiceProvenance(name, binding.target, binding.scope)
+				}
+			}
+
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// writeManifestSyncResults prints one row per change and a summary, and
+// returns how many changes failed.
+func writeManifestSyncResults(output io.Writer, changes []manifestChange) int {
+	targetWidth := len("TARGET")
+	scopeWidth := len("SCOPE")
+	serviceWidth := len("SERVICE")
+	for _, change := range changes {
+		targetWidth = max(targetWidth, len(change.target))
+		scopeWidth = max(scopeWidth, len(change.scope))
+		serviceWidth = max(serviceWidth, len(change.service))
+	}
+
+	fmt.Fprintln(output)
+	fmt.Fprintf(output, "%-*s  %-*s  %-*s  %s\n", targetWidth, "TARGET", scopeWidth, "SCOPE", serviceWidth, "SERVICE", "STATUS")
+
+	counts := make(map[string]int)
+	for _, change := range changes {
+		counts[change.status]++
+
+		status := change.status
+		switch {
+		case change.err != nil:
+			status = "failed: " + change.err.Error()
+		case change.warning != "":
+			status = fmt.Sprintf("%s (%s)", status, change.warning)
+		}
+
+		fmt.Fprintf(output, "%-*s  %-*s  %-*s  %s\n", targetWidth, change.target, scopeWidth, change.scope, serviceWidth, change.service, status)
+	}
+
+	fmt.Fprintf(output, "\n%d installed, %d updated, %d removed, %d unchanged, %d failed\n",
+		counts["installed"]+counts["would install"], counts["updated"], counts["removed"]+counts["would remove"], counts["unchanged"]+counts["configured"], counts["failed"])
+
+	return counts["failed"]
+}
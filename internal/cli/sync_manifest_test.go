@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// fakeManifestTarget keeps configured services per scope in memory and
+// verifies an entry by comparing its URL.
+type fakeManifestTarget struct {
+	slug       string
+	configured map[targetpkg.ConfigScope]map[string]string
+	projectDir string
+	writes     int
+	removals   []string
+}
+
+func newFakeManifestTarget(slug string) *fakeManifestTarget {
+	return &fakeManifestTarget{slug: slug, configured: map[targetpkg.ConfigScope]map[string]string{}}
+}
+
+func (t *fakeManifestTarget) Name() string      { return t.slug }
+func (t *fakeManifestTarget) Slug() string      { return t.slug }
+func (t *fakeManifestTarget) IsInstalled() bool { return true }
+
+func (t *fakeManifestTarget) Install(svc service.Service, env map[string]string) error {
+	return t.InstallWithScope(svc, env, targetpkg.ConfigScopeUser)
+}
+
+func (t *fakeManifestTarget) Uninstall(name string) error {
+	return t.UninstallWithScope(name, targetpkg.ConfigScopeUser)
+}
+
+func (t *fakeManifestTarget) List() ([]string, error) {
+	return t.ListWithScope(targetpkg.ConfigScopeUser)
+}
+
+func (t *fakeManifestTarget) SupportedScopes() []targetpkg.ConfigScope {
+	return []targetpkg.ConfigScope{targetpkg.ConfigScopeUser, targetpkg.ConfigScopeProject, targetpkg.ConfigScopeEffective}
+}
+
+func (t *fakeManifestTarget) InstallWithScope(svc service.Service, _ map[string]string, scope targetpkg.ConfigScope) error {
+	if t.configured[scope] == nil {
+		t.configured[scope] = map[string]string{}
+	}
+
+	t.configured[scope][svc.Name] = svc.URL
+	t.writes++
+	return nil
+}
+
+func (t *fakeManifestTarget) UninstallWithScope(name string, scope targetpkg.ConfigScope) error {
+	delete(t.configured[scope], name)
+	t.removals = append(t.removals, name)
+	return nil
+}
+
+func (t *fakeManifestTarget) ListWithScope(scope targetpkg.ConfigScope) ([]string, error) {
+	names := make([]string, 0, len(t.configured[scope]))
+	for name := range t.configured[scope] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (t *fakeManifestTarget) Verify(svc service.Service, _ map[string]string, scope targetpkg.ConfigScope) error {
+	if t.configured[scope][svc.Name] != svc.URL {
+		return &targetpkg.VerificationError{Service: svc.Name, Changed: []string{"url"}}
+	}
+
+	return nil
+}
+
+func (t *fakeManifestTarget) SetProjectDir(dir string) {
+	t.projectDir = dir
+}
+
+func writeManifest(t *testing.T, dir string, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, "mcp-wire.yaml"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+}
+
+func TestSyncManifestInstallsUpdatesAndLeavesMatchingEntries(t *testing.T) {
+	dir := t.TempDir()
+	manifestTarget := newFakeManifestTarget("alpha-cli")
+	manifestTarget.configured[targetpkg.ConfigScopeProject] = map[string]string{"demo-service": "https://old.example.com/mcp"}
+	overrideProjectScopeDependencies(t, dir, manifestTarget)
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo-service":  {Name: "demo-service", Transport: "sse", URL: "https://example.com/mcp"},
+			"other-service": {Name: "other-service", Transport: "sse", URL: "https://other.example.com/mcp"},
+		}, nil
+	}
+
+	writeManifest(t, dir, "scope: project\nservices:\n  - demo-service\n  - other-service\n")
+
+	output, err := executeSyncCommand(t, "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+
+	if manifestTarget.projectDir != dir {
+		t.Fatalf("expected project scope pinned to the manifest directory, got %q", manifestTarget.projectDir)
+	}
+
+	for _, line := range []string{
+		"TARGET     SCOPE    SERVICE        STATUS",
+		"alpha-cli  project  demo-service   updated",
+		"alpha-cli  project  other-service  installed",
+		"1 installed, 1 updated, 0 removed, 0 unchanged, 0 failed",
+	} {
+		if !strings.Contains(output, line) {
+			t.Fatalf("expected %q in output, got %q", line, output)
+		}
+	}
+
+	writes := manifestTarget.writes
+	output, err = executeSyncCommand(t, "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected second sync to succeed: %v", err)
+	}
+
+	if manifestTarget.writes != writes {
+		t.Fatalf("expected matching entries to be left alone, got %d new writes", manifestTarget.writes-writes)
+	}
+
+	if !strings.Contains(output, "0 installed, 0 updated, 0 removed, 2 unchanged, 0 failed") {
+		t.Fatalf("expected nothing to change, got %q", output)
+	}
+}
+
+func TestSyncManifestUsesServiceEnv(t *testing.T) {
+	dir := t.TempDir()
+	manifestTarget := newFakeManifestTarget("alpha-cli")
+	overrideProjectScopeDependencies(t, dir, manifestTarget)
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"team-service": {
+				Name:      "team-service",
+				Transport: "sse",
+				URL:       "https://example.com/mcp?team={TEAM}",
+				Env:       []service.EnvVar{{Name: "TEAM", Required: true}},
+			},
+		}, nil
+	}
+
+	writeManifest(t, dir, "services:\n  - name: team-service\n    env:\n      TEAM: platform\n")
+
+	if output, err := executeSyncCommand(t, "--no-prompt"); err != nil {
+		t.Fatalf("expected sync to succeed: %v\n%s", err, output)
+	}
+
+	if got := manifestTarget.configured[targetpkg.ConfigScopeUser]["team-service"]; got != "https://example.com/mcp?team=platform" {
+		t.Fatalf("expected the manifest env in the URL, got %q", got)
+	}
+}
+
+func TestSyncManifestPrunesUnlistedServices(t *testing.T) {
+	dir := t.TempDir()
+	manifestTarget := newFakeManifestTarget("alpha-cli")
+	manifestTarget.configured[targetpkg.ConfigScopeUser] = map[string]string{
+		"demo-service": "https://example.com/mcp",
+		"stale":        "https://stale.example.com/mcp",
+	}
+	manifestTarget.configured[targetpkg.ConfigScopeProject] = map[string]string{"project-only": "https://p.example.com/mcp"}
+	overrideProjectScopeDependencies(t, dir, manifestTarget)
+
+	writeManifest(t, dir, "prune: true\nservices: [demo-service]\n")
+
+	output, err := executeSyncCommand(t, "--no-prompt", "--dry-run")
+	if err != nil {
+		t.Fatalf("expected dry run to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "alpha-cli  user   stale         would remove") {
+		t.Fatalf("expected dry run to list the removal, got %q", output)
+	}
+
+	if len(manifestTarget.removals) != 0 || manifestTarget.writes != 0 {
+		t.Fatalf("expected dry run to change nothing, got removals %v and %d writes", manifestTarget.removals, manifestTarget.writes)
+	}
+
+	output, err = executeSyncCommand(t, "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+
+	if strings.Join(manifestTarget.removals, ",") != "stale" {
+		t.Fatalf("expected only the unlisted user service to be removed, got %v", manifestTarget.removals)
+	}
+
+	if _, kept := manifestTarget.configured[targetpkg.ConfigScopeProject]["project-only"]; !kept {
+		t.Fatal("expected scopes the manifest does not manage to be left alone")
+	}
+
+	if !strings.Contains(output, "0 installed, 0 updated, 1 removed, 1 unchanged, 0 failed") {
+		t.Fatalf("unexpected summary in %q", output)
+	}
+}
+
+func TestSyncManifestRequiresManifest(t *testing.T) {
+	dir := t.TempDir()
+	overrideProjectScopeDependencies(t, dir, newFakeManifestTarget("alpha-cli"))
+
+	_, err := executeSyncCommand(t, "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), "no mcp-wire.yaml in "+dir) {
+		t.Fatalf("expected missing manifest error, got %v", err)
+	}
+
+	_, err = executeSyncCommand(t, "--projects", "packages/*")
+	if err == nil || !strings.Contains(err.Error(), "--projects needs the services to sync") {
+		t.Fatalf("expected --projects without services to be rejected, got %v", err)
+	}
+
+	_, err = executeSyncCommand(t, "demo-service", "--dry-run")
+	if err == nil || !strings.Contains(err.Error(), "only apply when syncing from a manifest") {
+		t.Fatalf("expected --dry-run with services to be rejected, got %v", err)
+	}
+}
+
+func TestSyncManifestUsesExplicitPath(t *testing.T) {
+	dir := t.TempDir()
+	manifestTarget := newFakeManifestTarget("alpha-cli")
+	overrideProjectScopeDependencies(t, t.TempDir(), manifestTarget)
+
+	path := filepath.Join(dir, "team.yaml")
+	if err := os.WriteFile(path, []byte("targets: [alpha-cli]\nservices: [demo-service]\n"), 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		return manifestTarget, slug == "alpha-cli"
+	}
+
+	if _, err := executeSyncCommand(t, "--manifest", path, "--no-prompt"); err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+
+	if _, found := manifestTarget.configured[targetpkg.ConfigScopeUser]["demo-service"]; !found {
+		t.Fatal("expected demo-service at user scope")
+	}
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+type fakeSyncTarget struct {
+	*fakeProjectDirInstallTarget
+	installedDirs []string
+	failDir       string
+}
+
+func (t *fakeSyncTarget) InstallWithScope(svc service.Service, resolvedEnv map[string]string, scope targetpkg.ConfigScope) error {
+	if t.projectDir == t.failDir {
+		return errors.New("config is read-only")
+	}
+
+	t.installedDirs = append(t.installedDirs, t.projectDir)
+	return t.fakeProjectDirInstallTarget.InstallWithScope(svc, resolvedEnv, scope)
+}
+
+func newSyncWorkspace(t *testing.T, packages ...string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "packages"), 0o755); err != nil {
+		t.Fatalf("create packages dir: %v", err)
+	}
+
+	for _, pkg := range packages {
+		if err := os.MkdirAll(filepath.Join(root, "packages", pkg), 0o755); err != nil {
+			t.Fatalf("create package %q: %v", pkg, err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "packages", "README.md"), []byte("docs\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	return root
+}
+
+func TestSyncCommandInstallsIntoEveryMatchedProject(t *testing.T) {
+	root := newSyncWorkspace(t, "api", "web")
+	syncTarget := &fakeSyncTarget{fakeProjectDirInstallTarget: newFakeProjectDirTarget()}
+	userOnly := &fakeInstallTarget{name: "Beta CLI", slug: "beta-cli", installed: true}
+	overrideProjectScopeDependencies(t, root, syncTarget, userOnly)
+
+	output, err := executeSyncCommand(t, "demo-service", "--projects", "packages/*", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+
+	want := []string{filepath.Join(root, "packages", "api"), filepath.Join(root, "packages", "web")}
+	if strings.Join(syncTarget.installedDirs, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected installs into %v, got %v", want, syncTarget.installedDirs)
+	}
+
+	if syncTarget.lastScope != targetpkg.ConfigScopeProject {
+		t.Fatalf("expected project scope, got %q", syncTarget.lastScope)
+	}
+
+	if userOnly.installCalls != 0 {
+		t.Fatalf("expected targets without project scope to be skipped, got %d installs", userOnly.installCalls)
+	}
+
+	for _, line := range []string{
+		"PROJECT       SERVICE       TARGET     STATUS",
+		"packages/api  demo-service  alpha-cli  configured",
+		"packages/web  demo-service  alpha-cli  configured",
+		"2 projects, 2 installs, 0 failed",
+	} {
+		if !strings.Contains(output, line) {
+			t.Fatalf("expected %q in output, got %q", line, output)
+		}
+	}
+}
+
+func TestSyncCommandReportsFailuresInTable(t *testing.T) {
+	root := newSyncWorkspace(t, "api", "web")
+	syncTarget := &fakeSyncTarget{
+		fakeProjectDirInstallTarget: newFakeProjectDirTarget(),
+		failDir:                     filepath.Join(root, "packages", "web"),
+	}
+	overrideProjectScopeDependencies(t, root, syncTarget)
+
+	output, err := executeSyncCommand(t, "demo-service", "--projects", "packages/*", "--no-prompt")
+	if err == nil || err.Error() != "1 of 2 project installs failed" {
+		t.Fatalf("expected summary error, got %v", err)
+	}
+
+	if !strings.Contains(output, "packages/web  demo-service  alpha-cli  failed: config is read-only") {
+		t.Fatalf("expected failure row, got %q", output)
+	}
+
+	if len(syncTarget.installedDirs) != 1 {
+		t.Fatalf("expected the other project to still be installed, got %v", syncTarget.installedDirs)
+	}
+}
+
+func TestSyncCommandRequiresMatchingDirectories(t *testing.T) {
+	root := newSyncWorkspace(t)
+	syncTarget := &fakeSyncTarget{fakeProjectDirInstallTarget: newFakeProjectDirTarget()}
+	overrideProjectScopeDependencies(t, root, syncTarget)
+
+	_, err := executeSyncCommand(t, "demo-service", "--projects", "apps/*", "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), `--projects "apps/*" matched no directories`) {
+		t.Fatalf("expected no match error, got %v", err)
+	}
+}
+
+func TestSyncCommandRequiresProjectScopedTarget(t *testing.T) {
+	root := newSyncWorkspace(t, "api")
+	userOnly := &fakeInstallTarget{name: "Beta CLI", slug: "beta-cli", installed: true}
+	overrideProjectScopeDependencies(t, root, userOnly)
+
+	_, err := executeSyncCommand(t, "demo-service", "--projects", "packages/*", "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), "none of the selected targets support project scope") {
+		t.Fatalf("expected project scope error, got %v", err)
+	}
+}
+
+func executeSyncCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	syncCmd := newSyncCmd()
+	var stdout, stderr bytes.Buffer
+
+	syncCmd.SetOut(&stdout)
+	syncCmd.SetErr(&stderr)
+	syncCmd.SetIn(strings.NewReader(""))
+	syncCmd.SetArgs(args)
+
+	err := syncCmd.Execute()
+	output := stdout.String() + stderr.String()
+
+	return output, err
+}
+
+func TestWriteSyncResultsReportsEntriesThatWouldNotLoad(t *testing.T) {
+	buf := new(bytes.Buffer)
+	failed := writeSyncResults(buf, []syncResult{
+		{project: "packages/api", service: "jira", target: "claude", err: &targetpkg.VerificationError{Service: "jira", NotLoaded: "Claude Code reads MCP servers from ~/.claude.json"}},
+		{project: "packages/web", service: "jira", target: "claude", err: &targetpkg.VerificationError{Service: "jira", Dropped: []string{"cwd"}}},
+	})
+
+	if failed != 0 {
+		t.Fatalf("expected warnings not to count as failures, got %d", failed)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "configured (would not load)") || !strings.Contains(output, "configured (not verified)") {
+		t.Fatalf("unexpected sync table %q", output)
+	}
+}
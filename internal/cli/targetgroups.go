@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// targetGroups returns the targets.groups setting.
+func targetGroups() map[string][]string {
+	cfg, err := loadConfig()
+	if err != nil {
+		return map[string][]string{}
+	}
+
+	return cfg.TargetGroups()
+}
+
+// expandTargetGroups replaces every @group in slugs with the targets the
+// group lists under targets.groups. slugs must already be lowercased.
+func expandTargetGroups(slugs []string) ([]string, error) {
+	var groups map[string][]string
+	expanded := make([]string, 0, len(slugs))
+	for _, slug := range slugs {
+		name, isGroup := strings.CutPrefix(slug, "@")
+		if !isGroup {
+			expanded = append(expanded, slug)
+			continue
+		}
+
+		if groups == nil {
+			groups = targetGroups()
+		}
+
+		members, found := groups[name]
+		if !found {
+			return nil, unknownTargetGroupError(name, groups)
+		}
+
+		expanded = append(expanded, members...)
+	}
+
+	return expanded, nil
+}
+
+func unknownTargetGroupError(name string, groups map[string][]string) error {
+	if len(groups) == 0 {
+		return fmt.Errorf("target group %q is not defined; add it under targets.groups in the mcp-wire config", name)
+	}
+
+	names := make([]string, 0, len(groups))
+	for groupName := range groups {
+		names = append(names, "@"+groupName)
+	}
+	sort.Strings(names)
+
+	return fmt.Errorf("target group %q is not defined (available: %s)", name, strings.Join(names, ", "))
+}
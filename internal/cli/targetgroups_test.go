@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func writeTargetGroupsConfig(t *testing.T, data string) {
+	t.Helper()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(data), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+}
+
+func TestResolveInstallTargetsExpandsGroups(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	known := map[string]targetpkg.Target{}
+	for _, slug := range []string{"alpha", "beta", "gamma"} {
+		known[slug] = &fakeInstallTarget{name: strings.ToUpper(slug), slug: slug, installed: true}
+	}
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		target, found := known[slug]
+		return target, found
+	}
+	writeTargetGroupsConfig(t, `{"targets":{"groups":{"editors":["alpha","beta"]}}}`)
+
+	targets, err := resolveInstallTargets([]string{"@Editors", "beta", "gamma"})
+	if err != nil {
+		t.Fatalf("expected group to resolve: %v", err)
+	}
+
+	var slugs []string
+	for _, target := range targets {
+		slugs = append(slugs, target.Slug())
+	}
+
+	if strings.Join(slugs, ",") != "alpha,beta,gamma" {
+		t.Fatalf("expected group members once followed by gamma, got %v", slugs)
+	}
+}
+
+func TestResolveInstallTargetsRejectsUnknownGroup(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	writeTargetGroupsConfig(t, `{"targets":{"groups":{"editors":["alpha"],"clis":["beta"]}}}`)
+
+	_, err := resolveInstallTargets([]string{"@nope"})
+	if err == nil || err.Error() != `target group "nope" is not defined (available: @clis, @editors)` {
+		t.Fatalf("expected unknown group error listing groups, got %v", err)
+	}
+
+	writeTargetGroupsConfig(t, `{}`)
+	_, err = resolveInstallTargets([]string{"@nope"})
+	if err == nil || !strings.Contains(err.Error(), "add it under targets.groups") {
+		t.Fatalf("expected hint to define the group, got %v", err)
+	}
+}
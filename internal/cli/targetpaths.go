@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// applyTargetOverrides points every target named under the target_paths
+// setting at the configured file instead of the detected one, and marks the
+// targets listed under installed_targets as installed.
+func applyTargetOverrides(targetDefinitions []target.Target) {
+	overrides := targetPathOverrides()
+	installed := markedInstalledTargets()
+	for _, targetDefinition := range targetDefinitions {
+		if path, ok := overrides[targetDefinition.Slug()]; ok {
+			if locator, ok := targetDefinition.(target.ConfigLocator); ok {
+				locator.SetConfigPath(path)
+			}
+		}
+
+		if slices.Contains(installed, targetDefinition.Slug()) {
+			if marker, ok := targetDefinition.(target.InstallMarker); ok {
+				marker.MarkInstalled()
+			}
+		}
+	}
+}
+
+// markedInstalledTargets returns the slugs of targets the user marked as
+// installed with targets configure.
+func markedInstalledTargets() []string {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil
+	}
+
+	return cfg.InstalledTargets()
+}
+
+// targetPathOverrides returns the target_paths setting with a leading ~
+// expanded to the home directory.
+func targetPathOverrides() map[string]string {
+	cfg, err := loadConfig()
+	if err != nil {
+		return map[string]string{}
+	}
+
+	overrides := cfg.TargetPaths()
+	for slug, path := range overrides {
+		overrides[slug] = expandUserPath(path)
+	}
+
+	return overrides
+}
+
+func expandUserPath(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	if path == "~" {
+		return homeDir
+	}
+
+	return filepath.Join(homeDir, path[2:])
+}
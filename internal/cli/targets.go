@@ -0,0 +1,336 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+var runTargetConformance = targetpkg.RunConformance
+
+func init() {
+	registerCommand(newTargetsCmd)
+}
+
+func newTargetsCmd() *cobra.Command {
+	var matrix bool
+	var verbose bool
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "targets",
+		Short: "List supported targets",
+		Long: `targets lists every supported target and whether it is installed.
+
+With --matrix it runs the conformance suite (install sse and stdio services,
+env handling, scope behavior, uninstall idempotency, list correctness)
+against every target in a temporary sandbox and prints a support matrix.
+Real target configs are never touched.
+
+With --verbose it also shows the config file each target uses, why it was
+chosen, and every location the tool has kept its config in across versions.
+With --output json these details are always included.
+
+Targets hidden with targets disable are left out unless --all is given.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonOutput, err := jsonOutputRequested(cmd)
+			if err != nil {
+				return err
+			}
+
+			if matrix {
+				if jsonOutput {
+					return writeTargetsMatrixJSON(cmd.OutOrStdout(), allTargets())
+				}
+
+				return runTargetsMatrix(cmd.OutOrStdout(), allTargets())
+			}
+
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), buildTargetListJSON(allTargets()))
+			}
+
+			targets := enabledTargets()
+			if all {
+				targets = allTargets()
+			}
+
+			writeTargetList(cmd.OutOrStdout(), targets, verbose)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&matrix, "matrix", false, "Run the conformance suite in a sandbox and print a support matrix")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show the config path each target uses and the candidates it was chosen from")
+	cmd.Flags().BoolVar(&all, "all", false, "Include targets hidden with targets disable")
+	cmd.AddCommand(newTargetsConfigureCmd())
+	cmd.AddCommand(newTargetsDisableCmd())
+	cmd.AddCommand(newTargetsEnableCmd())
+
+	return cmd
+}
+
+func writeTargetList(output io.Writer, targets []targetpkg.Target, verbose bool) {
+	var overrides map[string]string
+	if verbose {
+		overrides = targetPathOverrides()
+	}
+
+	disabled := disabledTargetSlugs()
+
+	maxSlugWidth := 0
+	for _, t := range targets {
+		if len(t.Slug()) > maxSlugWidth {
+			maxSlugWidth = len(t.Slug())
+		}
+	}
+
+	for _, t := range targets {
+		status := "not installed"
+		if t.IsInstalled() {
+			status = "installed"
+		}
+
+		if slices.Contains(disabled, t.Slug()) {
+			status += ", disabled"
+		}
+
+		fmt.Fprintf(output, "%-*s  %s (%s)\n", maxSlugWidth, t.Slug(), t.Name(), status)
+
+		if verbose {
+			writeTargetConfigPaths(output, t, maxSlugWidth+2, overrides)
+		}
+	}
+}
+
+// writeTargetConfigPaths prints the config file t uses and why, followed by
+// every candidate location the tool has kept its config in.
+func writeTargetConfigPaths(output io.Writer, t targetpkg.Target, indent int, overrides map[string]string) {
+	provider, ok := t.(targetpkg.ConfigPathProvider)
+	if !ok {
+		return
+	}
+
+	configPath := provider.ConfigPath()
+	padding := strings.Repeat(" ", indent)
+
+	reason := "not created yet"
+	switch configPathSource(t, configPath, overrides) {
+	case "target_paths":
+		reason = "set in target_paths"
+	case "found":
+		reason = "found"
+	}
+
+	fmt.Fprintf(output, "%sconfig: %s (%s)\n", padding, configPath, reason)
+
+	locator, ok := t.(targetpkg.ConfigLocator)
+	if !ok {
+		return
+	}
+
+	for _, candidate := range locator.ConfigCandidates() {
+		marker := " "
+		if filepath.Clean(candidate.Path) == filepath.Clean(configPath) {
+			marker = "*"
+		}
+
+		state := "missing"
+		if fileExists(candidate.Path) {
+			state = "exists"
+		}
+
+		fmt.Fprintf(output, "%s  %s %s (%s, %s)\n", padding, marker, candidate.Path, candidate.Label, state)
+	}
+}
+
+// configPathSource reports why t uses configPath: "target_paths" when the
+// user set it, "found" when the file exists, or "not_created" otherwise.
+func configPathSource(t targetpkg.Target, configPath string, overrides map[string]string) string {
+	if _, overridden := overrides[t.Slug()]; overridden {
+		return "target_paths"
+	}
+
+	if fileExists(configPath) {
+		return "found"
+	}
+
+	return "not_created"
+}
+
+// targetListEntry is one target in the JSON output of targets.
+type targetListEntry struct {
+	Slug         string                `json:"slug"`
+	Name         string                `json:"name"`
+	Installed    bool                  `json:"installed"`
+	Disabled     bool                  `json:"disabled,omitempty"`
+	ConfigPath   string                `json:"config_path,omitempty"`
+	ConfigSource string                `json:"config_source,omitempty"`
+	Candidates   []targetListCandidate `json:"candidates,omitempty"`
+}
+
+type targetListCandidate struct {
+	Path   string `json:"path"`
+	Label  string `json:"label"`
+	Exists bool   `json:"exists"`
+}
+
+func buildTargetListJSON(targets []targetpkg.Target) []targetListEntry {
+	overrides := targetPathOverrides()
+	disabled := disabledTargetSlugs()
+
+	entries := make([]targetListEntry, 0, len(targets))
+	for _, t := range targets {
+		entry := targetListEntry{Slug: t.Slug(), Name: t.Name(), Installed: t.IsInstalled(), Disabled: slices.Contains(disabled, t.Slug())}
+
+		if provider, ok := t.(targetpkg.ConfigPathProvider); ok {
+			entry.ConfigPath = provider.ConfigPath()
+			entry.ConfigSource = configPathSource(t, entry.ConfigPath, overrides)
+		}
+
+		if locator, ok := t.(targetpkg.ConfigLocator); ok {
+			for _, candidate := range locator.ConfigCandidates() {
+				entry.Candidates = append(entry.Candidates, targetListCandidate{
+					Path:   candidate.Path,
+					Label:  candidate.Label,
+					Exists: fileExists(candidate.Path),
+				})
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func runTargetsMatrix(output io.Writer, targets []targetpkg.Target) error {
+	if len(targets) == 0 {
+		fmt.Fprintln(output, "No targets registered.")
+		return nil
+	}
+
+	sandboxDir, err := os.MkdirTemp("", "mcp-wire-conformance-")
+	if err != nil {
+		return fmt.Errorf("create conformance sandbox: %w", err)
+	}
+	defer os.RemoveAll(sandboxDir)
+
+	results := make([][]targetpkg.CheckResult, len(targets))
+	for i, t := range targets {
+		results[i] = runTargetConformance(t, sandboxDir)
+	}
+
+	checks := targetpkg.ConformanceChecks()
+	checkWidth := len("Check")
+	for _, check := range checks {
+		if len(check) > checkWidth {
+			checkWidth = len(check)
+		}
+	}
+
+	columnWidths := make([]int, len(targets))
+	header := []string{fmt.Sprintf("%-*s", checkWidth, "Check")}
+	for i, t := range targets {
+		columnWidths[i] = max(len(t.Slug()), len(targetpkg.CheckUnsupported))
+		header = append(header, fmt.Sprintf("%-*s", columnWidths[i], t.Slug()))
+	}
+	fmt.Fprintln(output, strings.TrimRight(strings.Join(header, "  "), " "))
+
+	var failures []string
+	for row, check := range checks {
+		cells := []string{fmt.Sprintf("%-*s", checkWidth, check)}
+		for i, t := range targets {
+			result := targetpkg.CheckResult{Check: check, Status: targetpkg.CheckFail, Err: errors.New("no result")}
+			if row < len(results[i]) {
+				result = results[i][row]
+			}
+
+			status := result.Status
+			if status == targetpkg.CheckFail {
+				failures = append(failures, fmt.Sprintf("%s / %s: %v", t.Slug(), check, result.Err))
+			}
+
+			cells = append(cells, fmt.Sprintf("%-*s", columnWidths[i], status))
+		}
+
+		fmt.Fprintln(output, strings.TrimRight(strings.Join(cells, "  "), " "))
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(output)
+	fmt.Fprintln(output, "Failures:")
+	for _, failure := range failures {
+		fmt.Fprintf(output, "  %s\n", failure)
+	}
+
+	return fmt.Errorf("%d conformance checks failed", len(failures))
+}
+
+// targetMatrixEntry holds the conformance results of one target in the JSON
+// output of targets --matrix.
+type targetMatrixEntry struct {
+	Slug    string              `json:"slug"`
+	Results []targetMatrixCheck `json:"results"`
+}
+
+type targetMatrixCheck struct {
+	Check  string `json:"check"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// writeTargetsMatrixJSON runs the conformance suite like runTargetsMatrix and
+// prints the results as JSON, failing when any check failed.
+func writeTargetsMatrixJSON(output io.Writer, targets []targetpkg.Target) error {
+	sandboxDir, err := os.MkdirTemp("", "mcp-wire-conformance-")
+	if err != nil {
+		return fmt.Errorf("create conformance sandbox: %w", err)
+	}
+	defer os.RemoveAll(sandboxDir)
+
+	entries := make([]targetMatrixEntry, 0, len(targets))
+	failures := 0
+	for _, t := range targets {
+		entry := targetMatrixEntry{Slug: t.Slug(), Results: []targetMatrixCheck{}}
+		for _, result := range runTargetConformance(t, sandboxDir) {
+			check := targetMatrixCheck{Check: result.Check, Status: string(result.Status)}
+			if result.Status == targetpkg.CheckFail {
+				failures++
+				if result.Err != nil {
+					check.Error = result.Err.Error()
+				}
+			}
+
+			entry.Results = append(entry.Results, check)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := writeJSON(output, entries); err != nil {
+		return err
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d conformance checks failed", failures)
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+func newTargetsConfigureCmd() *cobra.Command {
+	var configPath string
+	var reset bool
+	var noPrompt bool
+
+	cmd := &cobra.Command{
+		Use:   "configure <target>",
+		Short: "Mark an undetected target as installed",
+		Long: `configure marks a target as installed when mcp-wire does not detect it,
+for example because its binary lives outside PATH and the usual install
+locations, and sets the config file it writes to.
+
+Without --config-path it asks for the config file, offering the detected
+one. The override is saved in the mcp-wire config, under installed_targets
+and target_paths, and applies to every later command. --reset drops it so
+the target is detected again.`,
+		Example: `  mcp-wire targets configure codex
+  mcp-wire targets configure claude --config-path ~/work/.claude.json
+  mcp-wire targets configure codex --reset`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			targetDefinition, found := lookupTarget(args[0])
+			if !found {
+				return fmt.Errorf("target %q is not known", strings.ToLower(strings.TrimSpace(args[0])))
+			}
+
+			if reset {
+				if cmd.Flags().Changed("config-path") {
+					return errors.New("--reset cannot be combined with --config-path")
+				}
+
+				return resetTargetOverride(cmd.OutOrStdout(), targetDefinition)
+			}
+
+			if !cmd.Flags().Changed("config-path") && !noPrompt {
+				path, err := promptTargetConfigPath(bufio.NewReader(cmd.InOrStdin()), cmd.OutOrStdout(), targetDefinition)
+				if err != nil {
+					return err
+				}
+
+				configPath = path
+			}
+
+			return configureTarget(cmd.OutOrStdout(), targetDefinition, configPath)
+		},
+	}
+
+	cmd.Flags().StringVar(&configPath, "config-path", "", "Config file the target writes to (default: the detected one)")
+	cmd.Flags().BoolVar(&reset, "reset", false, "Drop the override so the target is detected again")
+	cmd.Flags().BoolVar(&noPrompt, "no-prompt", false, "Keep the detected config file instead of asking for one")
+
+	return cmd
+}
+
+// promptTargetConfigPath asks for the config file of targetDefinition. An
+// empty answer keeps the detected one.
+func promptTargetConfigPath(reader *bufio.Reader, output io.Writer, targetDefinition targetpkg.Target) (string, error) {
+	locator, ok := targetDefinition.(targetpkg.ConfigLocator)
+	if !ok {
+		return "", nil
+	}
+
+	answer, err := readTrimmedLine(reader, output, fmt.Sprintf("Config file for %s [%s]: ", targetDefinition.Name(), locator.ConfigPath()))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	return answer, nil
+}
+
+// configureTarget marks targetDefinition as installed, points it at
+// configPath when one is given, and saves both in the mcp-wire config.
+func configureTarget(output io.Writer, targetDefinition targetpkg.Target, configPath string) error {
+	marker, ok := targetDefinition.(targetpkg.InstallMarker)
+	if !ok {
+		return fmt.Errorf("target %q cannot be marked as installed", targetDefinition.Slug())
+	}
+
+	locator, hasLocator := targetDefinition.(targetpkg.ConfigLocator)
+	configPath = strings.TrimSpace(configPath)
+	if configPath != "" {
+		if !hasLocator {
+			return fmt.Errorf("target %q does not support a custom config path", targetDefinition.Slug())
+		}
+
+		absolutePath, err := absoluteConfigPath(configPath)
+		if err != nil {
+			return err
+		}
+
+		configPath = absolutePath
+		if filepath.Clean(expandUserPath(configPath)) == filepath.Clean(locator.ConfigPath()) {
+			// The detected file needs no override.
+			configPath = ""
+		}
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	if err := cfg.ConfigureTarget(targetDefinition.Slug(), configPath); err != nil {
+		return err
+	}
+
+	marker.MarkInstalled()
+	if configPath != "" {
+		locator.SetConfigPath(expandUserPath(configPath))
+	}
+
+	fmt.Fprintf(output, "Marked %s (%s) as installed.\n", targetDefinition.Name(), targetDefinition.Slug())
+	if hasLocator {
+		fmt.Fprintf(output, "Config file: %s\n", locator.ConfigPath())
+		if !fileExists(locator.ConfigPath()) {
+			fmt.Fprintln(output, "  The file does not exist yet; it is created on the first install.")
+		}
+	}
+
+	return nil
+}
+
+// absoluteConfigPath resolves a relative config path against the working
+// directory. Paths starting with ~ are kept so the config stays portable.
+func absoluteConfigPath(path string) (string, error) {
+	if filepath.IsAbs(path) || path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, `~\`) {
+		return path, nil
+	}
+
+	workingDir, err := getWorkingDirectory()
+	if err != nil {
+		return "", fmt.Errorf("resolve current working directory: %w", err)
+	}
+
+	return filepath.Join(workingDir, path), nil
+}
+
+// resetTargetOverride drops the installed mark and config path override of
+// targetDefinition. The change applies from the next command.
+func resetTargetOverride(output io.Writer, targetDefinition targetpkg.Target) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	if err := cfg.ResetTarget(targetDefinition.Slug()); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(output, "Removed the override for %s (%s); it is detected again from the next command.\n", targetDefinition.Name(), targetDefinition.Slug())
+
+	return nil
+}
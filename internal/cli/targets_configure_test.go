@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func executeTargetsConfigureCommand(t *testing.T, input string, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newTargetsCmd()
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetErr(&output)
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetArgs(append([]string{"configure"}, args...))
+
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+func overrideTargetsConfigureDependencies(t *testing.T, configPath string, targets ...targetpkg.Target) {
+	t.Helper()
+
+	originalLoadConfig := loadConfig
+	originalLookupTarget := lookupTarget
+	t.Cleanup(func() {
+		loadConfig = originalLoadConfig
+		lookupTarget = originalLookupTarget
+	})
+
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		for _, candidate := range targets {
+			if candidate.Slug() == slug {
+				return candidate, true
+			}
+		}
+
+		return nil, false
+	}
+}
+
+func TestTargetsConfigureMarksTargetInstalledWithPromptedPath(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	customPath := filepath.Join(dir, "custom", "alpha.json")
+	alpha := &fakeLocatorTarget{fakeListTarget: fakeListTarget{name: "Alpha CLI", slug: "alpha"}, configPath: "/detected/alpha.json"}
+	overrideTargetsConfigureDependencies(t, configPath, alpha)
+
+	output, err := executeTargetsConfigureCommand(t, customPath+"\n", "alpha")
+	if err != nil {
+		t.Fatalf("expected configure to succeed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Config file for Alpha CLI [/detected/alpha.json]: ",
+		"Marked Alpha CLI (alpha) as installed.",
+		"Config file: " + customPath,
+		"The file does not exist yet",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+
+	if !alpha.IsInstalled() || alpha.configPath != customPath {
+		t.Fatalf("expected override applied to the target, got installed=%v path=%q", alpha.IsInstalled(), alpha.configPath)
+	}
+
+	// A fresh target picks the override up from the saved config.
+	restarted := &fakeLocatorTarget{fakeListTarget: fakeListTarget{name: "Alpha CLI", slug: "alpha"}, configPath: "/detected/alpha.json"}
+	applyTargetOverrides([]targetpkg.Target{restarted})
+	if !restarted.IsInstalled() || restarted.configPath != customPath {
+		t.Fatalf("expected override to persist, got installed=%v path=%q", restarted.IsInstalled(), restarted.configPath)
+	}
+}
+
+func TestTargetsConfigureKeepsDetectedPathByDefault(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	detectedPath := filepath.Join(dir, "alpha.json")
+	if err := os.WriteFile(detectedPath, []byte(`{}`), 0o600); err != nil {
+		t.Fatalf("failed to write target config: %v", err)
+	}
+
+	alpha := &fakeLocatorTarget{fakeListTarget: fakeListTarget{name: "Alpha CLI", slug: "alpha"}, configPath: detectedPath}
+	overrideTargetsConfigureDependencies(t, configPath, alpha)
+
+	output, err := executeTargetsConfigureCommand(t, "", "alpha", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected configure to succeed: %v", err)
+	}
+
+	if strings.Contains(output, "Config file for") || strings.Contains(output, "does not exist") {
+		t.Fatalf("expected no prompt and no missing file note, got %q", output)
+	}
+
+	cfg, err := config.LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected config to load: %v", err)
+	}
+
+	if len(cfg.TargetPaths()) != 0 || strings.Join(cfg.InstalledTargets(), ",") != "alpha" {
+		t.Fatalf("expected only the installed mark, got paths %#v and installed %v", cfg.TargetPaths(), cfg.InstalledTargets())
+	}
+
+	if _, err := executeTargetsConfigureCommand(t, "", "alpha", "--reset"); err != nil {
+		t.Fatalf("expected reset to succeed: %v", err)
+	}
+
+	cfg, err = config.LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected config to load: %v", err)
+	}
+
+	if len(cfg.InstalledTargets()) != 0 {
+		t.Fatalf("expected reset to drop the installed mark, got %v", cfg.InstalledTargets())
+	}
+}
+
+func TestTargetsConfigureRejectsUnknownTarget(t *testing.T) {
+	overrideTargetsConfigureDependencies(t, filepath.Join(t.TempDir(), "config.json"))
+
+	_, err := executeTargetsConfigureCommand(t, "", "missing", "--no-prompt")
+	if err == nil || !strings.Contains(err.Error(), `target "missing" is not known`) {
+		t.Fatalf("expected unknown target error, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+func newTargetsDisableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable <target>...",
+		Short: "Hide targets you do not use",
+		Long: `disable hides targets you do not use: they no longer show up in the
+targets list, doctor, the TUI target screens, or installs without --target.
+A disabled target can still be picked explicitly with --target.
+
+The choice is saved in the mcp-wire config under targets.disabled.`,
+		Example: `  mcp-wire targets disable geminicli
+  mcp-wire targets disable opencode vscode`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setTargetsDisabled(cmd.OutOrStdout(), args, true)
+		},
+	}
+}
+
+func newTargetsEnableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable <target>...",
+		Short: "Show targets hidden with disable again",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setTargetsDisabled(cmd.OutOrStdout(), args, false)
+		},
+	}
+}
+
+func setTargetsDisabled(output io.Writer, slugs []string, disabled bool) error {
+	targetDefinitions := make([]targetpkg.Target, 0, len(slugs))
+	for _, slug := range slugs {
+		targetDefinition, found := lookupTarget(slug)
+		if !found {
+			return fmt.Errorf("target %q is not known", strings.ToLower(strings.TrimSpace(slug)))
+		}
+
+		targetDefinitions = append(targetDefinitions, targetDefinition)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	for _, targetDefinition := range targetDefinitions {
+		if err := cfg.SetTargetDisabled(targetDefinition.Slug(), disabled); err != nil {
+			return fmt.Errorf("save config: %w", err)
+		}
+
+		if disabled {
+			fmt.Fprintf(output, "Disabled %s (%s).\n", targetDefinition.Name(), targetDefinition.Slug())
+		} else {
+			fmt.Fprintf(output, "Enabled %s (%s).\n", targetDefinition.Name(), targetDefinition.Slug())
+		}
+	}
+
+	return nil
+}
+
+// disabledTargetSlugs returns the targets.disabled setting.
+func disabledTargetSlugs() []string {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil
+	}
+
+	return cfg.DisabledTargets()
+}
+
+// withoutDisabledTargets drops the targets listed under targets.disabled.
+func withoutDisabledTargets(targetDefinitions []targetpkg.Target) []targetpkg.Target {
+	disabled := disabledTargetSlugs()
+	if len(disabled) == 0 {
+		return targetDefinitions
+	}
+
+	enabled := make([]targetpkg.Target, 0, len(targetDefinitions))
+	for _, targetDefinition := range targetDefinitions {
+		if !slices.Contains(disabled, targetDefinition.Slug()) {
+			enabled = append(enabled, targetDefinition)
+		}
+	}
+
+	return enabled
+}
+
+// enabledTargets returns every known target except the disabled ones.
+func enabledTargets() []targetpkg.Target {
+	return withoutDisabledTargets(allTargets())
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func TestTargetsDisableHidesTargetUnlessAll(t *testing.T) {
+	claude := fakeListTarget{name: "Claude Code", slug: "claude", installed: true}
+	gemini := fakeListTarget{name: "Gemini CLI", slug: "geminicli", installed: true}
+	overrideTargetsConfigureDependencies(t, filepath.Join(t.TempDir(), "config.json"), claude, gemini)
+
+	originalAllTargets := allTargets
+	allTargets = func() []targetpkg.Target { return []targetpkg.Target{claude, gemini} }
+	t.Cleanup(func() { allTargets = originalAllTargets })
+
+	run := func(args ...string) string {
+		t.Helper()
+
+		cmd := newTargetsCmd()
+		var output bytes.Buffer
+		cmd.SetOut(&output)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("expected targets %v to succeed: %v", args, err)
+		}
+
+		return output.String()
+	}
+
+	if output := run("disable", "geminicli"); output != "Disabled Gemini CLI (geminicli).\n" {
+		t.Fatalf("unexpected disable output %q", output)
+	}
+
+	if output := run(); output != "claude  Claude Code (installed)\n" {
+		t.Fatalf("expected disabled target to be left out, got %q", output)
+	}
+
+	if output := run("--all"); !strings.Contains(output, "geminicli  Gemini CLI (installed, disabled)") {
+		t.Fatalf("expected --all to list the disabled target, got %q", output)
+	}
+
+	run("enable", "geminicli")
+	if output := run(); !strings.Contains(output, "Gemini CLI (installed)") {
+		t.Fatalf("expected enabled target to be listed again, got %q", output)
+	}
+}
+
+func TestTargetsDisableRejectsUnknownTarget(t *testing.T) {
+	overrideTargetsConfigureDependencies(t, filepath.Join(t.TempDir(), "config.json"))
+
+	cmd := newTargetsCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"disable", "nope"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), `target "nope" is not known`) {
+		t.Fatalf("expected unknown target error, got %v", err)
+	}
+}
+
+func TestResolveInstallTargetsSkipsDisabledTargetsByDefault(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	alpha := &fakeInstallTarget{name: "Alpha", slug: "alpha", installed: true}
+	beta := &fakeInstallTarget{name: "Beta", slug: "beta", installed: true}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{alpha, beta} }
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		if slug == "beta" {
+			return beta, true
+		}
+
+		return alpha, slug == "alpha"
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	if err := cfg.SetTargetDisabled("beta", true); err != nil {
+		t.Fatalf("disable beta: %v", err)
+	}
+
+	targets, err := resolveInstallTargets(nil)
+	if err != nil || len(targets) != 1 || targets[0].Slug() != "alpha" {
+		t.Fatalf("expected only alpha by default, got %v, %v", targets, err)
+	}
+
+	targets, err = resolveInstallTargets([]string{"beta"})
+	if err != nil || len(targets) != 1 || targets[0].Slug() != "beta" {
+		t.Fatalf("expected an explicit --target to pick a disabled target, got %v, %v", targets, err)
+	}
+
+	if err := cfg.SetTargetDisabled("alpha", true); err != nil {
+		t.Fatalf("disable alpha: %v", err)
+	}
+
+	if _, err := resolveInstallTargets(nil); err == nil || !strings.Contains(err.Error(), "every installed target is disabled") {
+		t.Fatalf("expected an error when every installed target is disabled, got %v", err)
+	}
+}
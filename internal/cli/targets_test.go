@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func TestTargetsCommandListsTargets(t *testing.T) {
+	originalAllTargets := allTargets
+	allTargets = func() []targetpkg.Target {
+		return []targetpkg.Target{
+			fakeListTarget{name: "Claude Code", slug: "claude", installed: true},
+			fakeListTarget{name: "Codex CLI", slug: "codex"},
+		}
+	}
+	t.Cleanup(func() { allTargets = originalAllTargets })
+
+	cmd := newTargetsCmd()
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetArgs(nil)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := "claude  Claude Code (installed)\ncodex   Codex CLI (not installed)\n"
+	if output.String() != want {
+		t.Fatalf("unexpected output:\n%s", output.String())
+	}
+}
+
+func TestTargetsMatrixRendersResultsAndFailures(t *testing.T) {
+	originalRunTargetConformance := runTargetConformance
+	runTargetConformance = func(target targetpkg.Target, _ string) []targetpkg.CheckResult {
+		var results []targetpkg.CheckResult
+		for _, check := range targetpkg.ConformanceChecks() {
+			result := targetpkg.CheckResult{Check: check, Status: targetpkg.CheckPass}
+			if target.Slug() == "codex" && check == "env handling" {
+				result.Status = targetpkg.CheckFail
+				result.Err = errors.New("env not written")
+			}
+			if target.Slug() == "codex" && check == "scope behavior" {
+				result.Status = targetpkg.CheckUnsupported
+			}
+			results = append(results, result)
+		}
+		return results
+	}
+	t.Cleanup(func() { runTargetConformance = originalRunTargetConformance })
+
+	var output bytes.Buffer
+	err := runTargetsMatrix(&output, []targetpkg.Target{
+		fakeListTarget{name: "Claude Code", slug: "claude"},
+		fakeListTarget{name: "Codex CLI", slug: "codex"},
+	})
+	if err == nil || err.Error() != "1 conformance checks failed" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	got := output.String()
+	for _, want := range []string{
+		"Check                  claude  codex\n",
+		"env handling           pass    fail\n",
+		"scope behavior         pass    n/a\n",
+		"  codex / env handling: env not written\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in output:\n%s", want, got)
+		}
+	}
+}
+
+type fakeLocatorTarget struct {
+	fakeListTarget
+	configPath string
+	candidates []targetpkg.ConfigCandidate
+}
+
+func (t *fakeLocatorTarget) ConfigPath() string { return t.configPath }
+func (t *fakeLocatorTarget) ConfigCandidates() []targetpkg.ConfigCandidate {
+	return t.candidates
+}
+func (t *fakeLocatorTarget) SetConfigPath(path string) { t.configPath = path }
+func (t *fakeLocatorTarget) MarkInstalled()            { t.installed = true }
+
+func TestTargetsVerboseShowsChosenConfigPath(t *testing.T) {
+	dir := t.TempDir()
+	newPath := filepath.Join(dir, "new.json")
+	oldPath := filepath.Join(dir, "old.json")
+	if err := os.WriteFile(oldPath, []byte(`{}`), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	originalLoadConfig := loadConfig
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(filepath.Join(dir, "config.json")) }
+	t.Cleanup(func() { loadConfig = originalLoadConfig })
+
+	locator := &fakeLocatorTarget{
+		fakeListTarget: fakeListTarget{name: "Alpha CLI", slug: "alpha", installed: true},
+		configPath:     oldPath,
+		candidates: []targetpkg.ConfigCandidate{
+			{Path: newPath, Label: "current releases"},
+			{Path: oldPath, Label: "early releases"},
+		},
+	}
+
+	var output bytes.Buffer
+	writeTargetList(&output, []targetpkg.Target{locator}, true)
+
+	want := "alpha  Alpha CLI (installed)\n" +
+		"       config: " + oldPath + " (found)\n" +
+		"           " + newPath + " (current releases, missing)\n" +
+		"         * " + oldPath + " (early releases, exists)\n"
+	if output.String() != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", output.String(), want)
+	}
+}
+
+func TestApplyTargetOverridesSetsConfiguredPath(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"target_paths":{"alpha":"~/custom/alpha.json"}}`), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	t.Setenv("HOME", dir)
+	originalLoadConfig := loadConfig
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+	t.Cleanup(func() { loadConfig = originalLoadConfig })
+
+	alpha := &fakeLocatorTarget{fakeListTarget: fakeListTarget{name: "Alpha CLI", slug: "alpha"}, configPath: "/detected/alpha.json"}
+	beta := &fakeLocatorTarget{fakeListTarget: fakeListTarget{name: "Beta CLI", slug: "beta"}, configPath: "/detected/beta.json"}
+
+	applyTargetOverrides([]targetpkg.Target{alpha, beta})
+
+	if alpha.configPath != filepath.Join(dir, "custom", "alpha.json") {
+		t.Fatalf("expected override with home expanded, got %q", alpha.configPath)
+	}
+
+	if beta.configPath != "/detected/beta.json" {
+		t.Fatalf("expected target without override to keep its path, got %q", beta.configPath)
+	}
+
+	var output bytes.Buffer
+	writeTargetList(&output, []targetpkg.Target{alpha}, true)
+	if !strings.Contains(output.String(), "(set in target_paths)") {
+		t.Fatalf("expected override to be reported, got %q", output.String())
+	}
+}
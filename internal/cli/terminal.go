@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// interruptSignals stop mcp-wire: Ctrl-C and kill.
+var interruptSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// showCursor makes the cursor visible again after a prompt or program that
+// hid it was stopped.
+const showCursor = "\x1b[?25h"
+
+var exitProcess = os.Exit
+
+// interrupts is the terminal state mcp-wire started with and the foreground
+// sections running now.
+var interrupts struct {
+	sync.Mutex
+	fd       int
+	terminal *term.State
+	// sections is a stack: a signal goes to the innermost section.
+	sections []*foregroundSection
+}
+
+// foregroundSection is code that handles interrupts itself, such as the TUI
+// or a target CLI, or that must not be cut short, such as writing configs.
+type foregroundSection struct {
+	signal os.Signal
+}
+
+// interruptError is returned by a command stopped by a signal once it
+// finished the step it was in.
+type interruptError struct {
+	signal os.Signal
+}
+
+func (e interruptError) Error() string {
+	if e.signal == syscall.SIGTERM {
+		return "terminated"
+	}
+
+	return "interrupted"
+}
+
+// ExitCode returns the exit status for the error Execute returned: 128 plus
+// the signal number when a signal stopped the command, else 1.
+func ExitCode(err error) int {
+	var interrupted interruptError
+	if errors.As(err, &interrupted) {
+		return interruptExitCode(interrupted.signal)
+	}
+
+	return 1
+}
+
+func interruptExitCode(sig os.Signal) int {
+	if number, ok := sig.(syscall.Signal); ok {
+		return 128 + int(number)
+	}
+
+	return 1
+}
+
+// watchInterrupts saves the terminal state and handles SIGINT and SIGTERM
+// for the rest of the run. Outside a foreground section a signal restores
+// the terminal, delivers pending webhook events, and exits; inside one it is
+// left to the section. The returned function stops watching.
+func watchInterrupts() func() {
+	saveTerminalState(os.Stdin)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, interruptSignals...)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				deliverInterrupt(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+func saveTerminalState(input *os.File) {
+	fd := int(input.Fd())
+	if !term.IsTerminal(fd) {
+		return
+	}
+
+	state, err := term.GetState(fd)
+	if err != nil {
+		return
+	}
+
+	interrupts.Lock()
+	defer interrupts.Unlock()
+
+	interrupts.fd = fd
+	interrupts.terminal = state
+}
+
+func deliverInterrupt(sig os.Signal) {
+	interrupts.Lock()
+	if n := len(interrupts.sections); n > 0 {
+		if section := interrupts.sections[n-1]; section.signal == nil {
+			section.signal = sig
+		}
+
+		interrupts.Unlock()
+		return
+	}
+	interrupts.Unlock()
+
+	restoreTerminal(os.Stdout)
+	fmt.Fprintf(os.Stderr, "\nmcp-wire: %s\n", interruptError{signal: sig})
+	flushWebhookEvents(os.Stderr)
+	exitProcess(interruptExitCode(sig))
+}
+
+// runInForeground runs fn as a foreground section and returns the first
+// signal received while it ran, or nil. Signals are not acted on: fn, or the
+// program it starts, sees them too and decides when to stop. After a signal
+// the terminal is restored, in case a program was killed before it could do
+// so itself.
+func runInForeground(fn func() error) (os.Signal, error) {
+	section := &foregroundSection{}
+
+	interrupts.Lock()
+	interrupts.sections = append(interrupts.sections, section)
+	interrupts.Unlock()
+
+	err := fn()
+
+	interrupts.Lock()
+	for i := len(interrupts.sections) - 1; i >= 0; i-- {
+		if interrupts.sections[i] == section {
+			interrupts.sections = append(interrupts.sections[:i], interrupts.sections[i+1:]...)
+			break
+		}
+	}
+	sig := section.signal
+	interrupts.Unlock()
+
+	if sig != nil {
+		restoreTerminal(os.Stdout)
+	}
+
+	return sig, err
+}
+
+// restoreTerminal puts the terminal back in the state mcp-wire started in,
+// turning echo and line editing back on after a hidden prompt or a program
+// that used raw mode, and shows the cursor.
+func restoreTerminal(output io.Writer) {
+	interrupts.Lock()
+	fd, state := interrupts.fd, interrupts.terminal
+	interrupts.Unlock()
+
+	if state != nil {
+		_ = term.Restore(fd, state)
+	}
+
+	if outputFile, ok := output.(*os.File); ok && term.IsTerminal(int(outputFile.Fd())) {
+		fmt.Fprint(output, showCursor)
+	}
+}
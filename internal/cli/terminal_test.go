@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestRunInForegroundLeavesSignalsToTheSection(t *testing.T) {
+	originalExitProcess := exitProcess
+	t.Cleanup(func() { exitProcess = originalExitProcess })
+
+	exited := -1
+	exitProcess = func(code int) { exited = code }
+
+	var inner os.Signal
+	outer, err := runInForeground(func() error {
+		deliverInterrupt(syscall.SIGTERM)
+
+		inner, _ = runInForeground(func() error {
+			deliverInterrupt(os.Interrupt)
+			return nil
+		})
+
+		return errors.New("stopped")
+	})
+
+	if exited != -1 {
+		t.Fatalf("expected no exit inside a foreground section, got %d", exited)
+	}
+
+	if outer != syscall.SIGTERM || inner != os.Interrupt || err == nil {
+		t.Fatalf("expected each section to see its own signal, got outer %v inner %v (%v)", outer, inner, err)
+	}
+
+	deliverInterrupt(os.Interrupt)
+	if exited != 130 {
+		t.Fatalf("expected exit 130 outside a foreground section, got %d", exited)
+	}
+}
+
+func TestExitCodeForInterruptedCommands(t *testing.T) {
+	wrapped := fmt.Errorf("install stopped: %w", interruptError{signal: syscall.SIGTERM})
+	if code := ExitCode(wrapped); code != 143 || wrapped.Error() != "install stopped: terminated" {
+		t.Fatalf("expected exit 143 for %q, got %d", wrapped, code)
+	}
+
+	if code := ExitCode(errors.New("boom")); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+}
+
+func TestFixTerminalResetsModesAndScreen(t *testing.T) {
+	originalIsTerminalReader := isTerminalReader
+	originalResetTerminalModes := resetTerminalModes
+	t.Cleanup(func() {
+		isTerminalReader = originalIsTerminalReader
+		resetTerminalModes = originalResetTerminalModes
+	})
+
+	reset := false
+	isTerminalReader = func(io.Reader) bool { return true }
+	resetTerminalModes = func(io.Reader) error {
+		reset = true
+		return nil
+	}
+
+	stdout, _, err := executeCommandWithOutputFlags(t, newFixTerminalCmd())
+	if err != nil {
+		t.Fatalf("expected fix-terminal to succeed: %v", err)
+	}
+
+	if !reset || !strings.HasPrefix(stdout, terminalResetSequence) || !strings.HasSuffix(stdout, "Terminal restored.\n") {
+		t.Fatalf("expected modes reset and the reset sequence, got %v %q", reset, stdout)
+	}
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+var newUndoStore = func() *state.UndoStore { return state.NewUndoStore("") }
+
+// targetConfigFiles lists every config file an operation on t may write.
+func targetConfigFiles(t target.Target) []string {
+	var paths []string
+	if provider, ok := t.(target.ConfigPathProvider); ok {
+		paths = append(paths, provider.ConfigPath())
+	}
+
+	if provider, ok := t.(target.SystemConfigPathProvider); ok {
+		paths = append(paths, provider.SystemConfigPath())
+	}
+
+	// Scopes such as the VS Code workspace or the OpenCode project live in
+	// files of their own. A scope whose file cannot be located cannot be
+	// written either.
+	scopedTarget, scoped := t.(target.ScopedTarget)
+	provider, ok := t.(target.ScopedConfigPathProvider)
+	if !scoped || !ok {
+		return paths
+	}
+
+	for _, scope := range scopedTarget.SupportedScopes() {
+		if scope == target.ConfigScopeEffective {
+			continue
+		}
+
+		if path, err := provider.ScopedConfigPath(scope); err == nil {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// tuiBeginUndo snapshots the config files of targets before a TUI apply and
+// returns a func that records the ones the apply changed as the most recent
+// change. Snapshot failures disable undo for the apply instead of blocking it.
+func tuiBeginUndo(targets []target.Target) func(description string) bool {
+	startBackupOperation()
+
+	var paths []string
+	for _, t := range targets {
+		paths = append(paths, targetConfigFiles(t)...)
+	}
+
+	before, err := state.SnapshotFiles(paths)
+	if err != nil {
+		return func(string) bool { return false }
+	}
+
+	return func(description string) bool {
+		recorded, err := newUndoStore().Record(description, before)
+		return err == nil && recorded
+	}
+}
+
+// watchTargetConfigs snapshots the config files of targets and returns a
+// func listing the ones changed on disk since. Files that cannot be read
+// are not watched: the apply step reports them if it cannot read them
+// either.
+func watchTargetConfigs(targets []target.Target) func() []string {
+	var paths []string
+	for _, t := range targets {
+		paths = append(paths, targetConfigFiles(t)...)
+	}
+
+	before, err := state.SnapshotFiles(paths)
+	if err != nil {
+		return func() []string { return nil }
+	}
+
+	return func() []string {
+		changed, err := state.ChangedFiles(before)
+		if err != nil {
+			return nil
+		}
+
+		return changed
+	}
+}
+
+func tuiLastUndo() (string, bool) {
+	record, err := newUndoStore().Last()
+	if err != nil || record == nil {
+		return "", false
+	}
+
+	return record.Description, true
+}
+
+func tuiUndoLast() (string, error) {
+	record, err := newUndoStore().Undo()
+	if err != nil {
+		return "", err
+	}
+
+	return record.Description, nil
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func overrideUndoStore(t *testing.T) {
+	t.Helper()
+
+	original := newUndoStore
+	store := state.NewUndoStore(filepath.Join(t.TempDir(), "undo.json"))
+	newUndoStore = func() *state.UndoStore { return store }
+	t.Cleanup(func() { newUndoStore = original })
+}
+
+func TestTUIUndoRestoresConfigChangedByApply(t *testing.T) {
+	overrideUndoStore(t)
+
+	configPath := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(configPath, []byte(`{}`), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	targets := []target.Target{fakeDoctorTarget{name: "Claude Code", slug: "claude", installed: true, configPath: configPath}}
+	commit := tuiBeginUndo(targets)
+
+	if _, ok := tuiLastUndo(); ok {
+		t.Fatal("expected no undo before the apply is committed")
+	}
+
+	if err := os.WriteFile(configPath, []byte(`{"mcpServers":{"sentry":{}}}`), 0o600); err != nil {
+		t.Fatalf("failed to update config: %v", err)
+	}
+
+	if !commit("install sentry") {
+		t.Fatal("expected changed config to be recorded")
+	}
+
+	description, ok := tuiLastUndo()
+	if !ok || description != "install sentry" {
+		t.Fatalf("expected last undo %q, got %q (ok=%v)", "install sentry", description, ok)
+	}
+
+	description, err := tuiUndoLast()
+	if err != nil {
+		t.Fatalf("expected undo to succeed: %v", err)
+	}
+
+	if description != "install sentry" {
+		t.Fatalf("unexpected undo description %q", description)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+
+	if string(data) != `{}` {
+		t.Fatalf("expected config to be restored, got %q", string(data))
+	}
+
+	if _, ok := tuiLastUndo(); ok {
+		t.Fatal("expected undo to be cleared after undoing")
+	}
+}
+
+func TestTUIBeginUndoSkipsApplyThatChangedNothing(t *testing.T) {
+	overrideUndoStore(t)
+
+	configPath := filepath.Join(t.TempDir(), "settings.json")
+	targets := []target.Target{fakeDoctorTarget{name: "Claude Code", slug: "claude", installed: true, configPath: configPath}}
+
+	if tuiBeginUndo(targets)("uninstall sentry") {
+		t.Fatal("expected an apply that changed nothing not to be recorded")
+	}
+}
+
+func TestTUIUndoRestoresWorkspaceScopeInstall(t *testing.T) {
+	overrideUndoStore(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	workspaceDir := t.TempDir()
+	vscode := target.NewVSCodeTarget()
+	vscode.SetProjectDir(workspaceDir)
+
+	settingsPath := filepath.Join(workspaceDir, ".vscode", "settings.json")
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0o755); err != nil {
+		t.Fatalf("failed to create workspace settings directory: %v", err)
+	}
+
+	if err := os.WriteFile(settingsPath, []byte(`{"editor.tabSize": 2}`), 0o600); err != nil {
+		t.Fatalf("failed to write workspace settings: %v", err)
+	}
+
+	commit := tuiBeginUndo([]target.Target{vscode})
+
+	svc := service.Service{Name: "sentry", Transport: "sse", URL: "https://mcp.sentry.dev/sse"}
+	if err := vscode.InstallWithScope(svc, nil, target.ConfigScopeProject); err != nil {
+		t.Fatalf("expected workspace install to succeed: %v", err)
+	}
+
+	if !commit("install sentry") {
+		t.Fatal("expected the changed workspace settings to be recorded")
+	}
+
+	if _, err := tuiUndoLast(); err != nil {
+		t.Fatalf("expected undo to succeed: %v", err)
+	}
+
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatalf("failed to read workspace settings: %v", err)
+	}
+
+	if string(data) != `{"editor.tabSize": 2}` {
+		t.Fatalf("expected workspace settings to be restored, got %q", string(data))
+	}
+}
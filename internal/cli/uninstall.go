@@ -9,8 +9,10 @@ import (
 	"strings"
 
 	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/flow"
 	"github.com/andreagrandi/mcp-wire/internal/service"
 	"github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/webhook"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
@@ -26,74 +28,399 @@ var isTerminalReader = func(reader io.Reader) bool {
 }
 
 func init() {
-	rootCmd.AddCommand(newUninstallCmd())
+	registerCommand(newUninstallCmd)
 }
 
 func newUninstallCmd() *cobra.Command {
 	var targetSlugs []string
 	var scopeValue string
+	var allowSystem bool
+	var keepEmpty bool
+	var dryRun bool
+	var codexProfile string
+	var allTargets bool
+	var allServices bool
+	var yes bool
 
 	cmd := &cobra.Command{
 		Use:   "uninstall <service>",
 		Short: "Remove a service from one or more targets",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `uninstall removes a service from the selected targets, or from every installed
+and enabled target when none is given. Without a service name it starts an
+interactive wizard.
+
+--all-targets removes the service from every installed target that has it
+configured in the scope, disabled targets included, and leaves the others
+alone.
+
+--all-services removes every service mcp-wire installed, as its install
+records show, from the targets given with --target. Services added by hand
+or by another tool are kept. The services are listed and removal is
+confirmed before anything is written; pass --yes to skip the question, or
+--dry-run to only list them.`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			scope, err := parseInstallUninstallScope(scopeValue)
-			if err != nil {
-				return err
+			serviceName := ""
+			if len(args) > 0 {
+				serviceName = strings.TrimSpace(args[0])
 			}
 
-			scopeSet := cmd.Flags().Changed("scope")
+			return runReportingOperation(cmd, "uninstall", serviceName, strings.ToLower(strings.TrimSpace(scopeValue)), func() error {
+				scope, err := parseInstallUninstallScope(scopeValue)
+				if err != nil {
+					return err
+				}
 
-			if len(args) == 0 {
-				return runUninstallWizardWithScope(cmd, bufio.NewReader(cmd.InOrStdin()), targetSlugs, scope, scopeSet)
-			}
+				scopeSet := cmd.Flags().Changed("scope")
 
-			serviceName := strings.TrimSpace(args[0])
-			if serviceName == "" {
-				return errors.New("service name is required")
-			}
+				if allTargets && len(targetSlugs) > 0 {
+					return errors.New("--all-targets cannot be combined with --target")
+				}
 
-			targetDefinitions, err := resolveInstallTargets(targetSlugs)
-			if err != nil {
-				return err
-			}
+				if allServices {
+					if len(args) > 0 {
+						return errors.New("--all-services removes every service mcp-wire installed; do not name a service")
+					}
+
+					if allTargets {
+						return errors.New("--all-services cannot be combined with --all-targets")
+					}
+
+					if len(targetSlugs) == 0 {
+						return errors.New("--all-services requires --target, naming the targets to clear")
+					}
+
+					if !yes && operationReportFrom(cmd) != nil && !dryRun {
+						return errors.New("--all-services with JSON output requires --yes")
+					}
+				}
+
+				if len(args) == 0 && !allServices {
+					if operationReportFrom(cmd) != nil {
+						return errors.New("JSON output requires a service name")
+					}
+
+					if scope == target.ConfigScopeSystem {
+						return errors.New("--scope system requires a service name")
+					}
+
+					if dryRun {
+						return errors.New("--dry-run requires a service name")
+					}
+
+					if codexProfile != "" {
+						return errors.New("--codex-profile requires a service name")
+					}
+
+					if allTargets {
+						return errors.New("--all-targets requires a service name")
+					}
+
+					if err := requireInteractiveInput(cmd, "mcp-wire uninstall <service> --target <slug>"); err != nil {
+						return err
+					}
+
+					return runUninstallWizardWithScope(cmd, bufio.NewReader(cmd.InOrStdin()), targetSlugs, scope, scopeSet)
+				}
 
-			printUninstallPlan(cmd.OutOrStdout(), targetDefinitions)
+				if !allServices && serviceName == "" {
+					return errors.New("service name is required")
+				}
 
-			uninstallErrors := make([]error, 0)
-			for _, targetDefinition := range targetDefinitions {
-				var err error
-				scopedTarget, supportsScopes := targetDefinition.(target.ScopedTarget)
-				if supportsScopes && targetSupportsScope(targetDefinition, scope) {
-					err = scopedTarget.UninstallWithScope(serviceName, scope)
+				var targetDefinitions []target.Target
+				if allTargets {
+					targetDefinitions = targetsWithScope(listInstalledTargets(), scope)
 				} else {
-					err = targetDefinition.Uninstall(serviceName)
+					targetDefinitions, err = resolveInstallTargets(targetSlugs)
+					if err != nil {
+						return err
+					}
 				}
 
-				if err != nil {
-					fmt.Fprintf(cmd.OutOrStdout(), "  %s: failed (%v)\n", targetDefinition.Name(), err)
-					uninstallErrors = append(uninstallErrors, fmt.Errorf("target %q: %w", targetDefinition.Slug(), err))
-					continue
+				if err := checkSystemScope(scope, allowSystem, targetDefinitions); err != nil {
+					return err
 				}
 
-				fmt.Fprintf(cmd.OutOrStdout(), "  %s: removed\n", targetDefinition.Name())
-			}
+				if err := checkRemoteScope(scope, targetDefinitions); err != nil {
+					return err
+				}
 
-			if len(uninstallErrors) > 0 {
-				return fmt.Errorf("failed to uninstall service %q from one or more targets: %w", serviceName, errors.Join(uninstallErrors...))
-			}
+				if _, err := applyProjectDir(cmd, targetDefinitions); err != nil {
+					return err
+				}
+
+				applyPruneEmpty(targetDefinitions, keepEmpty)
+
+				if err := applyCodexProfile(targetDefinitions, codexProfile); err != nil {
+					return err
+				}
 
-			return maybeRemoveStoredCredentials(cmd, serviceName)
+				if allServices {
+					return uninstallManagedServices(cmd, targetDefinitions, scope, dryRun, yes)
+				}
+
+				if allTargets {
+					targetDefinitions = targetsConfiguring(targetDefinitions, serviceName, scope)
+					if len(targetDefinitions) == 0 {
+						return fmt.Errorf("service %q is not configured on any installed target in %s scope", serviceName, scopeDescription(scope))
+					}
+				}
+
+				if err := checkUninstallPolicy(serviceName, targetDefinitions, scope); err != nil {
+					return err
+				}
+
+				if dryRun {
+					return previewOperation(cmd.OutOrStdout(), operationReportFrom(cmd), "uninstall", serviceName, targetDefinitions, dryRunUninstall(serviceName, scope))
+				}
+
+				warnServiceDependents(cmd, serviceName, targetDefinitions, scope)
+				printUninstallPlan(cmd.OutOrStdout(), targetDefinitions)
+
+				report := operationReportFrom(cmd)
+				uninstallErrors := make([]error, 0)
+				for _, targetDefinition := range targetDefinitions {
+					err := removeServiceEntry(serviceName, targetDefinition, scope)
+
+					outcome := targetOutcome{Service: serviceName, Target: targetDefinition.Slug(), Name: targetDefinition.Name()}
+					if err != nil {
+						fmt.Fprintf(cmd.OutOrStdout(), "  %s: failed (%v)\n", targetDefinition.Name(), err)
+						uninstallErrors = append(uninstallErrors, fmt.Errorf("target %q: %w", targetDefinition.Slug(), err))
+						outcome.Status = "failed"
+						outcome.Error = err.Error()
+						report.add(outcome)
+						continue
+					}
+
+					fmt.Fprintf(cmd.OutOrStdout(), "  %s: removed\n", targetDefinition.Name())
+					outcome.Status = "removed"
+					report.add(outcome)
+				}
+
+				if len(uninstallErrors) > 0 {
+					return fmt.Errorf("failed to uninstall service %q from one or more targets: %w", serviceName, errors.Join(uninstallErrors...))
+				}
+
+				releaseServicePorts(serviceName)
+
+				if report != nil {
+					return nil
+				}
+
+				return maybeRemoveStoredCredentials(cmd, serviceName)
+			})
 		},
 	}
 
-	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Uninstall from specific target slug(s); can be repeated")
-	cmd.Flags().StringVar(&scopeValue, "scope", string(target.ConfigScopeUser), "Config scope for supported targets: user or project")
+	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Uninstall from specific target slug(s) or @group(s); can be repeated")
+	cmd.Flags().StringVar(&scopeValue, "scope", string(target.ConfigScopeUser), "Config scope for supported targets: user, project, system, or remote")
+	cmd.Flags().BoolVar(&allowSystem, "allow-system", false, "Allow --scope system to change the machine-wide config of every user")
+	cmd.Flags().BoolVar(&keepEmpty, "keep-empty", false, "Keep config structures the uninstall leaves empty instead of pruning them")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the diff each target config would get without writing anything")
+	cmd.Flags().StringVar(&codexProfile, "codex-profile", "", "Uninstall from this Codex CLI profile instead of the global mcp_servers table")
+	cmd.Flags().BoolVar(&allTargets, "all-targets", false, "Uninstall from every installed target that has the service configured")
+	cmd.Flags().BoolVar(&allServices, "all-services", false, "Uninstall every service mcp-wire installed on the --target target(s)")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Remove the services of --all-services without asking")
+	addProjectDirFlags(cmd)
 
 	return cmd
 }
 
+// removeServiceEntry removes serviceName from targetDefinition in scope,
+// reports it to webhooks, and forgets its install record.
+func removeServiceEntry(serviceName string, targetDefinition target.Target, scope target.ConfigScope) error {
+	var err error
+	scopedTarget, supportsScopes := targetDefinition.(target.ScopedTarget)
+	if supportsScopes && targetSupportsScope(targetDefinition, scope) {
+		err = scopedTarget.UninstallWithScope(serviceName, scope)
+	} else {
+		err = targetDefinition.Uninstall(serviceName)
+	}
+
+	emitWebhookEvent(webhook.EventUninstall, serviceName, targetDefinition, scope, err)
+
+	if err != nil {
+		return withElevationHint(err, scope)
+	}
+
+	forgetServiceProvenance(serviceName, targetDefinition, scope)
+	return nil
+}
+
+// targetsWithScope returns the targets of targetDefinitions that an uninstall
+// in scope can reach: all of them for the user scope, and those that support
+// any other scope.
+func targetsWithScope(targetDefinitions []target.Target, scope target.ConfigScope) []target.Target {
+	if scope == target.ConfigScopeUser {
+		return targetDefinitions
+	}
+
+	var supported []target.Target
+	for _, targetDefinition := range targetDefinitions {
+		if targetSupportsScope(targetDefinition, scope) {
+			supported = append(supported, targetDefinition)
+		}
+	}
+
+	return supported
+}
+
+// targetsConfiguring returns the targets of targetDefinitions that have
+// serviceName configured in scope.
+func targetsConfiguring(targetDefinitions []target.Target, serviceName string, scope target.ConfigScope) []target.Target {
+	var configured []target.Target
+	for _, targetDefinition := range targetDefinitions {
+		if serviceConfiguredOn(targetDefinition, serviceName, scope) {
+			configured = append(configured, targetDefinition)
+		}
+	}
+
+	return configured
+}
+
+// managedServiceEntry is a service mcp-wire installed on a target.
+type managedServiceEntry struct {
+	service string
+	target  target.Target
+}
+
+// uninstallManagedServices removes every service the install records say
+// mcp-wire wrote to targetDefinitions in scope. The services are listed
+// first, and removed only once confirmed, or with yes; dryRun stops after
+// the list. It ends with a summary of what was removed.
+func uninstallManagedServices(cmd *cobra.Command, targetDefinitions []target.Target, scope target.ConfigScope, dryRun bool, yes bool) error {
+	records, err := newProvenanceStore().All()
+	if err != nil {
+		return fmt.Errorf("read install records: %w", err)
+	}
+
+	var entries []managedServiceEntry
+	for _, targetDefinition := range targetDefinitions {
+		names, err := configuredServiceNames(targetDefinition, scope)
+		if err != nil {
+			return fmt.Errorf("target %q: %w", targetDefinition.Slug(), err)
+		}
+
+		for _, name := range names {
+			if recordedInstallOf(records, name, targetDefinition.Slug(), writtenScope(targetDefinition, scope)) {
+				entries = append(entries, managedServiceEntry{service: name, target: targetDefinition})
+			}
+		}
+	}
+
+	output := cmd.OutOrStdout()
+	if len(entries) == 0 {
+		fmt.Fprintln(output, "No services installed by mcp-wire on the selected targets.")
+		return nil
+	}
+
+	for _, entry := range entries {
+		if err := checkUninstallPolicy(entry.service, []target.Target{entry.target}, scope); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(output, "Services installed by mcp-wire:")
+	for _, entry := range entries {
+		fmt.Fprintf(output, "  %s on %s\n", entry.service, entry.target.Name())
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	if !yes {
+		input := cmd.InOrStdin()
+		if !isTerminalReader(input) {
+			return errors.New("cannot confirm removing these services without a terminal; run again with --yes to remove them")
+		}
+
+		proceed, err := askYesNo(bufio.NewReader(input), output, fmt.Sprintf("Remove %d service(s)? [y/N]: ", len(entries)), false)
+		if err != nil {
+			return fmt.Errorf("read uninstall confirmation: %w", err)
+		}
+
+		if !proceed {
+			fmt.Fprintln(output, "Uninstall cancelled.")
+			return nil
+		}
+	}
+
+	report := operationReportFrom(cmd)
+	removed := map[string]bool{}
+	var failures []error
+	for _, entry := range entries {
+		err := removeServiceEntry(entry.service, entry.target, scope)
+
+		outcome := targetOutcome{Service: entry.service, Target: entry.target.Slug(), Name: entry.target.Name()}
+		if err != nil {
+			fmt.Fprintf(output, "  %s on %s: failed (%v)\n", entry.service, entry.target.Name(), err)
+			failures = append(failures, fmt.Errorf("service %q on target %q: %w", entry.service, entry.target.Slug(), err))
+			outcome.Status = "failed"
+			outcome.Error = err.Error()
+			report.add(outcome)
+			continue
+		}
+
+		fmt.Fprintf(output, "  %s on %s: removed\n", entry.service, entry.target.Name())
+		outcome.Status = "removed"
+		report.add(outcome)
+		removed[entry.service] = true
+	}
+
+	for serviceName := range removed {
+		releaseServicePorts(serviceName)
+	}
+
+	fmt.Fprintf(output, "Removed %d of %d service entries.\n", len(entries)-len(failures), len(entries))
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to uninstall %d of %d service entries: %w", len(failures), len(entries), errors.Join(failures...))
+	}
+
+	return nil
+}
+
+// applyPruneEmpty tells every target whether to prune structures an
+// uninstall leaves empty. keepEmpty overrides the prune_empty setting.
+func applyPruneEmpty(targetDefinitions []target.Target, keepEmpty bool) {
+	prune := !keepEmpty
+	if prune {
+		if cfg, err := loadConfig(); err == nil {
+			prune = cfg.PruneEmpty()
+		}
+	}
+
+	for _, targetDefinition := range targetDefinitions {
+		if setter, ok := targetDefinition.(target.EmptyPruneSetter); ok {
+			setter.SetPruneEmpty(prune)
+		}
+	}
+}
+
+// applyCodexProfile points the Codex CLI target at the profile name. It
+// fails when name is set but Codex is not among the targets, since the
+// flag would otherwise be silently ignored.
+func applyCodexProfile(targetDefinitions []target.Target, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+
+	applied := false
+	for _, targetDefinition := range targetDefinitions {
+		if profiled, ok := targetDefinition.(target.ProfileTarget); ok {
+			profiled.SetProfile(name)
+			applied = true
+		}
+	}
+
+	if !applied {
+		return errors.New("--codex-profile requires the codex target")
+	}
+
+	return nil
+}
+
 func printUninstallPlan(output io.Writer, targetDefinitions []target.Target) {
 	names := make([]string, 0, len(targetDefinitions))
 	for _, targetDefinition := range targetDefinitions {
@@ -119,11 +446,12 @@ func maybeRemoveStoredCredentials(cmd *cobra.Command, serviceName string) error
 		return nil
 	}
 
-	envNames := serviceEnvNames(serviceDefinition)
-	if len(envNames) == 0 {
+	if !flow.OfferCredentialCleanup(flow.ActionUninstall, serviceDefinition, false) {
 		return nil
 	}
 
+	envNames := serviceEnvNames(serviceDefinition)
+
 	reader := bufio.NewReader(input)
 	shouldRemove, err := askYesNo(reader, cmd.OutOrStdout(), "\nRemove stored credentials for this service? [y/N]: ", false)
 	if err != nil {
@@ -134,8 +462,7 @@ func maybeRemoveStoredCredentials(cmd *cobra.Command, serviceName string) error
 		return nil
 	}
 
-	fileSource := newCredentialFileSourceForCleanup("")
-	removedCount, err := removeStoredCredentials(fileSource, envNames)
+	removedCount, err := removeStoredCredentials(storedCredentialSources(), envNames)
 	if err != nil {
 		return fmt.Errorf("remove stored credentials: %w", err)
 	}
@@ -170,31 +497,40 @@ func serviceEnvNames(serviceDefinition service.Service) []string {
 	return envNames
 }
 
-func removeStoredCredentials(fileSource *credential.FileSource, envNames []string) (int, error) {
-	if fileSource == nil {
-		return 0, errors.New("credential file source is nil")
+// removeStoredCredentials deletes envNames from every source and returns how
+// many of them were stored in at least one.
+func removeStoredCredentials(sources []storedCredentialSource, envNames []string) (int, error) {
+	if len(sources) == 0 {
+		return 0, errors.New("no credential sources to remove from")
 	}
 
 	if len(envNames) == 0 {
 		return 0, nil
 	}
 
-	matchedEnvNames := make([]string, 0, len(envNames))
-	for _, envName := range envNames {
-		if _, found := fileSource.Get(envName); !found {
-			continue
+	removed := make(map[string]struct{}, len(envNames))
+	for _, source := range sources {
+		matchedEnvNames := make([]string, 0, len(envNames))
+		for _, envName := range envNames {
+			if _, found := source.Get(envName); !found {
+				continue
+			}
+
+			matchedEnvNames = append(matchedEnvNames, envName)
 		}
 
-		matchedEnvNames = append(matchedEnvNames, envName)
-	}
+		if len(matchedEnvNames) == 0 {
+			continue
+		}
 
-	if len(matchedEnvNames) == 0 {
-		return 0, nil
-	}
+		if err := source.DeleteMany(matchedEnvNames...); err != nil {
+			return 0, fmt.Errorf("%s: %w", source.Name(), err)
+		}
 
-	if err := fileSource.DeleteMany(matchedEnvNames...); err != nil {
-		return 0, err
+		for _, envName := range matchedEnvNames {
+			removed[envName] = struct{}{}
+		}
 	}
 
-	return len(matchedEnvNames), nil
+	return len(removed), nil
 }
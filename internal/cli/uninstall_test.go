@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/andreagrandi/mcp-wire/internal/config"
 	"github.com/andreagrandi/mcp-wire/internal/credential"
 	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/state"
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
 	"github.com/spf13/cobra"
 )
@@ -345,7 +347,7 @@ func TestRemoveStoredCredentialsRemovesMatchingKeys(t *testing.T) {
 		t.Fatalf("failed storing TOKEN_B: %v", err)
 	}
 
-	removedCount, err := removeStoredCredentials(fileSource, []string{"TOKEN_A", "TOKEN_C"})
+	removedCount, err := removeStoredCredentials([]storedCredentialSource{fileSource}, []string{"TOKEN_A", "TOKEN_C"})
 	if err != nil {
 		t.Fatalf("expected credential removal to succeed: %v", err)
 	}
@@ -379,6 +381,197 @@ func TestMaybeRemoveStoredCredentialsSkipsWhenNotInteractive(t *testing.T) {
 	}
 }
 
+type fakePruningUninstallTarget struct {
+	*fakeUninstallTarget
+	pruneEmpty *bool
+}
+
+func (t *fakePruningUninstallTarget) SetPruneEmpty(enabled bool) {
+	t.pruneEmpty = &enabled
+}
+
+func TestUninstallCommandAppliesPruneEmptySetting(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		args      []string
+		wantPrune bool
+	}{
+		{name: "default", args: []string{"demo-service"}, wantPrune: true},
+		{name: "keep-empty flag", args: []string{"demo-service", "--keep-empty"}, wantPrune: false},
+		{name: "config disabled", config: `{"prune_empty":false}`, args: []string{"demo-service"}, wantPrune: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restore := overrideUninstallCommandDependencies(t)
+			defer restore()
+
+			if tt.config != "" {
+				configPath := filepath.Join(t.TempDir(), "config.json")
+				if err := os.WriteFile(configPath, []byte(tt.config), 0o600); err != nil {
+					t.Fatalf("failed to write config: %v", err)
+				}
+
+				loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+			}
+
+			alpha := &fakePruningUninstallTarget{
+				fakeUninstallTarget: &fakeUninstallTarget{name: "Alpha CLI", slug: "alpha", installed: true},
+			}
+			listInstalledTargets = func() []targetpkg.Target {
+				return []targetpkg.Target{alpha}
+			}
+
+			if _, err := executeUninstallCommand(t, tt.args...); err != nil {
+				t.Fatalf("expected uninstall command to succeed: %v", err)
+			}
+
+			if alpha.pruneEmpty == nil || *alpha.pruneEmpty != tt.wantPrune {
+				t.Fatalf("expected prune empty %v, got %v", tt.wantPrune, alpha.pruneEmpty)
+			}
+		})
+	}
+}
+
+func TestUninstallCommandAllTargetsRemovesOnlyWhereConfigured(t *testing.T) {
+	restore := overrideUninstallCommandDependencies(t)
+	defer restore()
+
+	alpha := &fakeUninstallTarget{name: "Alpha CLI", slug: "alpha", installed: true, installedServices: []string{"demo-service"}}
+	beta := &fakeUninstallTarget{name: "Beta CLI", slug: "beta", installed: true}
+	listInstalledTargets = func() []targetpkg.Target {
+		return []targetpkg.Target{alpha, beta}
+	}
+
+	output, err := executeUninstallCommand(t, "demo-service", "--all-targets")
+	if err != nil {
+		t.Fatalf("expected uninstall command to succeed: %v", err)
+	}
+
+	if alpha.uninstallCalls != 1 || beta.uninstallCalls != 0 {
+		t.Fatalf("expected only the configured target to uninstall, got alpha=%d beta=%d", alpha.uninstallCalls, beta.uninstallCalls)
+	}
+
+	if !strings.Contains(output, "Uninstalling from: Alpha CLI\n") {
+		t.Fatalf("expected plan to name only the configured target, got %q", output)
+	}
+
+	_, err = executeUninstallCommand(t, "other-service", "--all-targets")
+	if err == nil || !strings.Contains(err.Error(), `service "other-service" is not configured on any installed target`) {
+		t.Fatalf("expected error for a service configured nowhere, got %v", err)
+	}
+}
+
+func TestUninstallCommandAllTargetsRejectsTarget(t *testing.T) {
+	restore := overrideUninstallCommandDependencies(t)
+	defer restore()
+
+	_, err := executeUninstallCommand(t, "demo-service", "--all-targets", "--target", "alpha")
+	if err == nil || !strings.Contains(err.Error(), "--all-targets cannot be combined with --target") {
+		t.Fatalf("expected flag conflict error, got %v", err)
+	}
+}
+
+// setUpAllServicesUninstall selects alpha, which configures a service
+// mcp-wire installed and one added by hand.
+func setUpAllServicesUninstall(t *testing.T) *fakeUninstallTarget {
+	t.Helper()
+
+	restore := overrideUninstallCommandDependencies(t)
+	t.Cleanup(restore)
+
+	alpha := &fakeUninstallTarget{name: "Alpha CLI", slug: "alpha", installed: true, installedServices: []string{"managed-service", "hand-made"}}
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		if slug == "alpha" {
+			return alpha, true
+		}
+
+		return nil, false
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{alpha} }
+
+	originalProvenanceStore := newProvenanceStore
+	provenancePath := filepath.Join(t.TempDir(), "provenance.json")
+	newProvenanceStore = func() *state.ProvenanceStore { return state.NewProvenanceStore(provenancePath) }
+	t.Cleanup(func() { newProvenanceStore = originalProvenanceStore })
+
+	if err := newProvenanceStore().Record(state.Provenance{Service: "managed-service", Target: "alpha", Scope: "user", Command: "install"}); err != nil {
+		t.Fatalf("record provenance: %v", err)
+	}
+
+	return alpha
+}
+
+func TestUninstallCommandAllServicesRemovesManagedServicesAfterConfirmation(t *testing.T) {
+	alpha := setUpAllServicesUninstall(t)
+	isTerminalReader = func(io.Reader) bool { return true }
+
+	output, err := executeUninstallCommandWithInput(t, "y\n", "--all-services", "--target", "alpha")
+	if err != nil {
+		t.Fatalf("expected uninstall command to succeed: %v", err)
+	}
+
+	if alpha.uninstallCalls != 1 || alpha.lastService != "managed-service" {
+		t.Fatalf("expected only the managed service to be removed, got %d calls, last %q", alpha.uninstallCalls, alpha.lastService)
+	}
+
+	for _, want := range []string{"  managed-service on Alpha CLI\n", "Remove 1 service(s)? [y/N]: ", "managed-service on Alpha CLI: removed", "Removed 1 of 1 service entries."} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+
+	if strings.Contains(output, "hand-made") {
+		t.Fatalf("expected the service added by hand to be left out, got %q", output)
+	}
+
+	records, err := newProvenanceStore().All()
+	if err != nil || len(records) != 0 {
+		t.Fatalf("expected the install record to be dropped, got %v (%v)", records, err)
+	}
+}
+
+func TestUninstallCommandAllServicesKeepsServicesWithoutConfirmation(t *testing.T) {
+	alpha := setUpAllServicesUninstall(t)
+
+	output, err := executeUninstallCommand(t, "--all-services", "--target", "alpha")
+	if err == nil || !strings.Contains(err.Error(), "run again with --yes") {
+		t.Fatalf("expected uninstall without a terminal to fail with a --yes hint, got %v", err)
+	}
+
+	if alpha.uninstallCalls != 0 {
+		t.Fatalf("expected nothing removed without confirmation, got %d calls", alpha.uninstallCalls)
+	}
+
+	if !strings.Contains(output, "Services installed by mcp-wire:") {
+		t.Fatalf("expected the services to be listed, got %q", output)
+	}
+
+	if _, err := executeUninstallCommand(t, "--all-services", "--target", "alpha", "--yes"); err != nil {
+		t.Fatalf("expected uninstall with --yes to succeed: %v", err)
+	}
+
+	if alpha.uninstallCalls != 1 {
+		t.Fatalf("expected --yes to remove the managed service, got %d calls", alpha.uninstallCalls)
+	}
+}
+
+func TestUninstallCommandAllServicesRequiresTarget(t *testing.T) {
+	restore := overrideUninstallCommandDependencies(t)
+	defer restore()
+
+	_, err := executeUninstallCommand(t, "--all-services")
+	if err == nil || !strings.Contains(err.Error(), "--all-services requires --target") {
+		t.Fatalf("expected --target to be required, got %v", err)
+	}
+
+	_, err = executeUninstallCommand(t, "demo-service", "--all-services", "--target", "alpha")
+	if err == nil || !strings.Contains(err.Error(), "do not name a service") {
+		t.Fatalf("expected a service name to be rejected, got %v", err)
+	}
+}
+
 func executeUninstallCommand(t *testing.T, args ...string) (string, error) {
 	return executeUninstallCommandWithInput(t, "", args...)
 }
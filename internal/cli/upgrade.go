@@ -0,0 +1,409 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/registry"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	registerCommand(newUpgradeCmd)
+}
+
+// serviceUpgrade is a pinned package reference in a target config that the
+// registry has a newer version of.
+type serviceUpgrade struct {
+	target       targetpkg.Target
+	scope        targetpkg.ConfigScope
+	service      string
+	registryType string
+	identifier   string
+	from         string
+	to           string
+	index        int    // position of the reference in the command line
+	oldReference string // reference as written, e.g. "@acme/mcp@1.2.0"
+	newReference string
+	pinned       string // registry version the service was installed at with name@version
+}
+
+func newUpgradeCmd() *cobra.Command {
+	var targetSlugs []string
+	var yes bool
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade [service]",
+		Short: "Upgrade pinned package versions of installed registry services",
+		Long: `upgrade compares the npm, PyPI and Docker versions pinned in target
+configs with the latest version published in the registry and, after
+confirmation, rewrites the entries to the new version. Without a service it
+checks every configured registry service.
+
+Services installed at a fixed version with "mcp-wire install name@version"
+are pinned and never upgraded; install them again without @version to
+unpin them.
+
+Only the package reference in the command line changes; env vars, headers
+and other settings in the entry are kept. References without a version
+already run the latest release and are left alone.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serviceName := ""
+			if len(args) == 1 {
+				serviceName = strings.TrimSpace(args[0])
+				if serviceName == "" {
+					return errors.New("service name is required")
+				}
+			}
+
+			targetDefinitions, err := resolveInstallTargets(targetSlugs)
+			if err != nil {
+				return err
+			}
+
+			if _, err := applyProjectDir(cmd, targetDefinitions); err != nil {
+				return err
+			}
+
+			upgrades, err := findServiceUpgrades(targetDefinitions, serviceName)
+			if err != nil {
+				return err
+			}
+
+			output := cmd.OutOrStdout()
+			upgrades, pinned := splitPinnedUpgrades(upgrades)
+			for _, upgrade := range pinned {
+				fmt.Fprintf(output, "Skipping %s on %s: pinned to version %s (%s %s is available)\n", upgrade.service, upgradeLocation(upgrade), upgrade.pinned, upgrade.identifier, upgrade.to)
+			}
+
+			if len(upgrades) == 0 {
+				if len(pinned) > 0 {
+					return nil
+				}
+
+				if serviceName != "" {
+					fmt.Fprintf(output, "%s is up to date.\n", serviceName)
+				} else {
+					fmt.Fprintln(output, "All configured registry services are up to date.")
+				}
+
+				return nil
+			}
+
+			fmt.Fprintln(output, "Upgrades available:")
+			for _, upgrade := range upgrades {
+				fmt.Fprintf(output, "  %s on %s: %s %s %s -> %s\n", upgrade.service, upgradeLocation(upgrade), upgrade.registryType, upgrade.identifier, upgrade.from, upgrade.to)
+			}
+
+			if dryRun {
+				return nil
+			}
+
+			if !yes {
+				input := cmd.InOrStdin()
+				if !isTerminalReader(input) {
+					return errors.New("cannot confirm these upgrades without a terminal; run again with --yes to apply them, or with --dry-run to only list them")
+				}
+
+				proceed, err := askYesNo(bufio.NewReader(input), output, fmt.Sprintf("Apply %d upgrade(s)? [y/N]: ", len(upgrades)), false)
+				if err != nil {
+					return fmt.Errorf("read upgrade confirmation: %w", err)
+				}
+
+				if !proceed {
+					fmt.Fprintln(output, "Upgrade cancelled.")
+					return nil
+				}
+			}
+
+			return applyServiceUpgrades(output, upgrades)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Upgrade on specific target slug(s) or @group(s); can be repeated")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Apply the upgrades without asking")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the available upgrades without applying them")
+	addProjectDirFlags(cmd)
+
+	return cmd
+}
+
+// findServiceUpgrades returns the upgrades available for serviceName, or for
+// every configured service when it is empty, on targetDefinitions.
+// Curated services are skipped; the others are looked up in each enabled
+// registry in priority order.
+func findServiceUpgrades(targetDefinitions []targetpkg.Target, serviceName string) ([]serviceUpgrade, error) {
+	curated, err := loadServices()
+	if err != nil {
+		curated = nil
+	}
+
+	isCurated := func(name string) bool {
+		for curatedName := range curated {
+			if strings.EqualFold(curatedName, name) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	latest := map[string]*registry.ServerResponse{}
+	lookupLatest := func(name string) *registry.ServerResponse {
+		if resp, ok := latest[name]; ok {
+			return resp
+		}
+
+		var found *registry.ServerResponse
+		for _, source := range registrySources() {
+			resp, err := fetchServerLatest(source.baseURL, name)
+			if err == nil && resp != nil {
+				found = resp
+				break
+			}
+		}
+
+		latest[name] = found
+		return found
+	}
+
+	// Install records are only a hint here: without them nothing is pinned.
+	records, _ := newProvenanceStore().All()
+
+	var upgrades []serviceUpgrade
+	configured := false
+	for _, targetDefinition := range targetDefinitions {
+		explainer, ok := targetDefinition.(targetpkg.Explainer)
+		if !ok {
+			continue
+		}
+
+		if _, ok := targetDefinition.(targetpkg.CommandUpdater); !ok {
+			continue
+		}
+
+		names, err := targetDefinition.List()
+		if err != nil {
+			return nil, fmt.Errorf("target %q: %w", targetDefinition.Slug(), err)
+		}
+
+		for _, name := range names {
+			if serviceName != "" && !strings.EqualFold(name, serviceName) {
+				continue
+			}
+
+			configured = true
+			if isCurated(name) {
+				continue
+			}
+
+			layers, err := explainer.ExplainService(name)
+			if err != nil {
+				return nil, fmt.Errorf("target %q: %w", targetDefinition.Slug(), err)
+			}
+
+			for _, layer := range layers {
+				command := targetpkg.EntryCommand(layer.Entry)
+				if len(command) == 0 {
+					continue
+				}
+
+				resp := lookupLatest(name)
+				if resp == nil {
+					continue
+				}
+
+				for _, pkg := range resp.Server.Packages {
+					upgrade, ok := packageUpgrade(command, pkg)
+					if !ok {
+						continue
+					}
+
+					upgrade.target = targetDefinition
+					upgrade.scope = layer.Scope
+					upgrade.service = name
+					upgrade.pinned = pinnedVersion(records, name, targetDefinition, layer.Scope)
+					upgrades = append(upgrades, upgrade)
+				}
+			}
+		}
+	}
+
+	if serviceName != "" && !configured {
+		return nil, fmt.Errorf("service %q is not configured on any selected target", serviceName)
+	}
+
+	return upgrades, nil
+}
+
+// pinnedVersion returns the version serviceName was pinned to on t in scope,
+// or "" when it is not pinned there.
+func pinnedVersion(records []state.Provenance, serviceName string, t targetpkg.Target, scope targetpkg.ConfigScope) string {
+	if scope == "" {
+		scope = targetpkg.ConfigScopeUser
+	}
+
+	for _, record := range records {
+		if strings.EqualFold(record.Service, serviceName) && strings.EqualFold(record.Target, t.Slug()) && record.Scope == string(scope) {
+			return record.Pinned
+		}
+	}
+
+	return ""
+}
+
+// splitPinnedUpgrades separates the upgrades of pinned services, which are
+// reported but never applied.
+func splitPinnedUpgrades(upgrades []serviceUpgrade) ([]serviceUpgrade, []serviceUpgrade) {
+	var unpinned, pinned []serviceUpgrade
+	for _, upgrade := range upgrades {
+		if upgrade.pinned != "" {
+			pinned = append(pinned, upgrade)
+		} else {
+			unpinned = append(unpinned, upgrade)
+		}
+	}
+
+	return unpinned, pinned
+}
+
+// packageUpgrade finds the reference to pkg in command and reports an
+// upgrade when it pins a version older than the one pkg has.
+func packageUpgrade(command []string, pkg registry.Package) (serviceUpgrade, bool) {
+	identifier := strings.TrimSpace(pkg.Identifier)
+	latest := strings.TrimSpace(pkg.Version)
+	if identifier == "" || latest == "" {
+		return serviceUpgrade{}, false
+	}
+
+	registryType := strings.ToLower(pkg.RegistryType)
+	var separators []string
+	switch registryType {
+	case "npm":
+		separators = []string{"@"}
+	case "pypi":
+		separators = []string{"==", "@"}
+	case "docker", "oci":
+		separators = []string{":"}
+	default:
+		return serviceUpgrade{}, false
+	}
+
+	for i, arg := range command {
+		for _, separator := range separators {
+			installed, ok := strings.CutPrefix(arg, identifier+separator)
+			if !ok || installed == "" || installed == "latest" || !versionNewer(latest, installed) {
+				continue
+			}
+
+			return serviceUpgrade{
+				registryType: registryType,
+				identifier:   identifier,
+				from:         installed,
+				to:           latest,
+				index:        i,
+				oldReference: arg,
+				newReference: identifier + separator + latest,
+			}, true
+		}
+	}
+
+	return serviceUpgrade{}, false
+}
+
+// applyServiceUpgrades rewrites each upgraded reference. It goes on after a
+// failure and returns the failures together.
+func applyServiceUpgrades(output io.Writer, upgrades []serviceUpgrade) error {
+	var errs []error
+	for _, upgrade := range upgrades {
+		updater := upgrade.target.(targetpkg.CommandUpdater)
+		err := updater.UpdateServiceCommand(upgrade.service, upgrade.scope, func(command []string) []string {
+			if upgrade.index < len(command) && command[upgrade.index] == upgrade.oldReference {
+				command[upgrade.index] = upgrade.newReference
+			}
+
+			return command
+		})
+		if err != nil {
+			fmt.Fprintf(output, "Failed to upgrade %s on %s: %v\n", upgrade.service, upgradeLocation(upgrade), err)
+			errs = append(errs, fmt.Errorf("upgrade %q on %s: %w", upgrade.service, upgrade.target.Slug(), err))
+			continue
+		}
+
+		fmt.Fprintf(output, "Upgraded %s on %s to %s %s\n", upgrade.service, upgradeLocation(upgrade), upgrade.identifier, upgrade.to)
+	}
+
+	return errors.Join(errs...)
+}
+
+func upgradeLocation(upgrade serviceUpgrade) string {
+	if upgrade.scope == "" || upgrade.scope == targetpkg.ConfigScopeUser {
+		return upgrade.target.Name()
+	}
+
+	return fmt.Sprintf("%s (%s)", upgrade.target.Name(), upgrade.scope)
+}
+
+// versionNewer reports whether version latest is newer than installed.
+// Dotted numeric versions, with an optional "v" prefix and pre-release
+// suffix, are compared part by part; anything else counts as newer when it
+// differs.
+func versionNewer(latest, installed string) bool {
+	latestParts, latestPre, ok := parseVersion(latest)
+	if !ok {
+		return latest != installed
+	}
+
+	installedParts, installedPre, ok := parseVersion(installed)
+	if !ok {
+		return latest != installed
+	}
+
+	for i := 0; i < len(latestParts) || i < len(installedParts); i++ {
+		var l, r int
+		if i < len(latestParts) {
+			l = latestParts[i]
+		}
+		if i < len(installedParts) {
+			r = installedParts[i]
+		}
+
+		if l != r {
+			return l > r
+		}
+	}
+
+	// A release is newer than its pre-releases.
+	if installedPre != "" && latestPre == "" {
+		return true
+	}
+
+	return latestPre != "" && installedPre != "" && latestPre > installedPre
+}
+
+func parseVersion(version string) ([]int, string, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "+")
+	version, pre, _ := strings.Cut(version, "-")
+
+	fields := strings.Split(version, ".")
+	parts := make([]int, 0, len(fields))
+	for _, field := range fields {
+		part, err := strconv.Atoi(field)
+		if err != nil || part < 0 {
+			return nil, "", false
+		}
+
+		parts = append(parts, part)
+	}
+
+	return parts, pre, true
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/registry"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+type fakeUpgradeTarget struct {
+	*fakeInstallTarget
+	entries map[string]map[string]any
+}
+
+func (t *fakeUpgradeTarget) List() ([]string, error) {
+	names := make([]string, 0, len(t.entries))
+	for name := range t.entries {
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+func (t *fakeUpgradeTarget) ExplainService(serviceName string) ([]targetpkg.ScopeLayer, error) {
+	return []targetpkg.ScopeLayer{{Scope: targetpkg.ConfigScopeUser, Path: "/tmp/config.json", Entry: t.entries[serviceName]}}, nil
+}
+
+func (t *fakeUpgradeTarget) UpdateServiceCommand(serviceName string, _ targetpkg.ConfigScope, update func([]string) []string) error {
+	command := update(targetpkg.EntryCommand(t.entries[serviceName]))
+	t.entries[serviceName]["command"] = command[0]
+	t.entries[serviceName]["args"] = command[1:]
+	return nil
+}
+
+func executeUpgradeCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	upgradeCmd := newUpgradeCmd()
+	var stdout bytes.Buffer
+
+	upgradeCmd.SetOut(&stdout)
+	upgradeCmd.SetErr(&stdout)
+	upgradeCmd.SetIn(strings.NewReader(""))
+	upgradeCmd.SetArgs(args)
+
+	err := upgradeCmd.Execute()
+	return stdout.String(), err
+}
+
+func TestUpgradeCommandRewritesPinnedPackages(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	fake := &fakeUpgradeTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Fake CLI", slug: "fake", installed: true},
+		entries: map[string]map[string]any{
+			"io.github.acme/mcp":    {"command": "npx", "args": []any{"-y", "@acme/mcp@1.2.0"}, "env": map[string]any{"ACME_TOKEN": "secret"}},
+			"io.github.acme/py":     {"command": "uvx", "args": []any{"acme-py==0.9.0"}},
+			"io.github.acme/latest": {"command": "npx", "args": []any{"-y", "@acme/latest"}},
+			"curated":               {"command": "npx", "args": []any{"-y", "curated@1.0.0"}},
+		},
+	}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{"curated": {Name: "curated"}}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{fake} }
+
+	fetchServerLatest = func(_ string, serverName string) (*registry.ServerResponse, error) {
+		packages := map[string]registry.Package{
+			"io.github.acme/mcp":    {RegistryType: "npm", Identifier: "@acme/mcp", Version: "1.10.0"},
+			"io.github.acme/py":     {RegistryType: "pypi", Identifier: "acme-py", Version: "0.9.0"},
+			"io.github.acme/latest": {RegistryType: "npm", Identifier: "@acme/latest", Version: "3.0.0"},
+			"curated":               {RegistryType: "npm", Identifier: "curated", Version: "2.0.0"},
+		}
+
+		return &registry.ServerResponse{Server: registry.ServerJSON{Name: serverName, Packages: []registry.Package{packages[serverName]}}}, nil
+	}
+
+	output, err := executeUpgradeCommand(t)
+	if err == nil || !strings.Contains(err.Error(), "run again with --yes") {
+		t.Fatalf("expected upgrade without a terminal to fail with a --yes hint, got %v", err)
+	}
+
+	if !strings.Contains(output, "io.github.acme/mcp on Fake CLI: npm @acme/mcp 1.2.0 -> 1.10.0") {
+		t.Fatalf("expected the npm upgrade to be offered, got %q", output)
+	}
+
+	for _, unexpected := range []string{"acme-py", "@acme/latest", "curated", "secret"} {
+		if strings.Contains(output, unexpected) {
+			t.Fatalf("expected %q to be left out, got %q", unexpected, output)
+		}
+	}
+
+	if got := targetpkg.EntryCommand(fake.entries["io.github.acme/mcp"]); got[2] != "@acme/mcp@1.2.0" {
+		t.Fatalf("expected nothing rewritten without confirmation, got %v", got)
+	}
+
+	if _, err := executeUpgradeCommand(t, "--yes"); err != nil {
+		t.Fatalf("expected upgrade to succeed: %v", err)
+	}
+
+	want := []string{"npx", "-y", "@acme/mcp@1.10.0"}
+	if got := targetpkg.EntryCommand(fake.entries["io.github.acme/mcp"]); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected command %v, got %v", want, got)
+	}
+
+	if _, ok := fake.entries["io.github.acme/mcp"]["env"]; !ok {
+		t.Fatal("expected env to be kept")
+	}
+}
+
+func TestUpgradeCommandSkipsPinnedServices(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	fake := &fakeUpgradeTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Fake CLI", slug: "fake", installed: true},
+		entries: map[string]map[string]any{
+			"io.github.acme/mcp": {"command": "npx", "args": []any{"-y", "@acme/mcp@1.2.0"}},
+		},
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{fake} }
+	fetchServerLatest = func(_ string, serverName string) (*registry.ServerResponse, error) {
+		return &registry.ServerResponse{Server: registry.ServerJSON{Name: serverName, Packages: []registry.Package{{RegistryType: "npm", Identifier: "@acme/mcp", Version: "1.10.0"}}}}, nil
+	}
+
+	record := state.Provenance{Service: "io.github.acme/mcp", Target: "fake", Scope: "user", Command: "install", Pinned: "1.2.0"}
+	if err := newProvenanceStore().Record(record); err != nil {
+		t.Fatalf("record provenance: %v", err)
+	}
+
+	output, err := executeUpgradeCommand(t, "--yes")
+	if err != nil {
+		t.Fatalf("expected upgrade to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "Skipping io.github.acme/mcp on Fake CLI: pinned to version 1.2.0") || strings.Contains(output, "Upgraded") {
+		t.Fatalf("expected the pinned service to be skipped, got %q", output)
+	}
+
+	if got := targetpkg.EntryCommand(fake.entries["io.github.acme/mcp"]); got[2] != "@acme/mcp@1.2.0" {
+		t.Fatalf("expected the pinned reference to be kept, got %v", got)
+	}
+}
+
+func TestUpgradeCommandFailsForUnconfiguredService(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	fake := &fakeUpgradeTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Fake CLI", slug: "fake", installed: true},
+		entries:           map[string]map[string]any{},
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{fake} }
+
+	_, err := executeUpgradeCommand(t, "io.github.acme/mcp")
+	if err == nil || !strings.Contains(err.Error(), "is not configured on any selected target") {
+		t.Fatalf("expected an unconfigured service error, got %v", err)
+	}
+}
+
+func TestVersionNewer(t *testing.T) {
+	tests := []struct {
+		latest    string
+		installed string
+		want      bool
+	}{
+		{"1.10.0", "1.9.0", true},
+		{"1.2.0", "1.2.0", false},
+		{"1.2.0", "1.3.0", false},
+		{"v2.0", "1.9.9", true},
+		{"1.0.0", "1.0.0-beta.1", true},
+		{"1.0.0-beta.1", "1.0.0", false},
+		{"sha-abc", "sha-def", true},
+	}
+
+	for _, test := range tests {
+		if got := versionNewer(test.latest, test.installed); got != test.want {
+			t.Fatalf("versionNewer(%q, %q) = %v, want %v", test.latest, test.installed, got, test.want)
+		}
+	}
+}
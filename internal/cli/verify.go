@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// verifyInstalledService reads the entry just written for svc back from
+// targets that support it. It reports whether the target could be checked;
+// a *target.VerificationError means fields did not survive the write or the
+// tool would not load the entry.
+func verifyInstalledService(t target.Target, svc service.Service, resolvedEnv map[string]string, scope target.ConfigScope) (bool, error) {
+	verifier, ok := t.(target.Verifier)
+	if !ok {
+		return false, nil
+	}
+
+	// Targets without scope support were written through Install, which
+	// always uses the user scope.
+	if _, supportsScopes := t.(target.ScopedTarget); !supportsScopes || !targetSupportsScope(t, scope) {
+		scope = target.ConfigScopeUser
+	}
+
+	if err := verifier.Verify(svc, resolvedEnv, scope); err != nil {
+		return true, err
+	}
+
+	return true, simulateServiceLoad(t, svc.Name)
+}
+
+// simulateServiceLoad checks that the tool would load the entry just written,
+// for targets that can resolve their config the way the tool does. An entry
+// the tool would skip is reported as a *target.VerificationError, so callers
+// warn about it like any other read-back mismatch.
+func simulateServiceLoad(t target.Target, serviceName string) error {
+	simulator, ok := t.(target.LoadSimulator)
+	if !ok {
+		return nil
+	}
+
+	result, err := simulator.SimulateLoad(serviceName)
+	if err != nil {
+		return err
+	}
+
+	if result.Loaded {
+		return nil
+	}
+
+	return &target.VerificationError{Service: serviceName, NotLoaded: result.Reason}
+}
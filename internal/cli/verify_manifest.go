@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/app"
+	"github.com/spf13/cobra"
+)
+
+const (
+	releaseRepository     = "andreagrandi/mcp-wire"
+	releaseSignerWorkflow = "andreagrandi/mcp-wire/.github/workflows/release.yml"
+)
+
+var (
+	executablePath    = os.Executable
+	runAttestationGH  = defaultRunAttestationGH
+	lookPathGitHubCLI = func() (string, error) { return exec.LookPath("gh") }
+)
+
+// manifestVerification is the summary printed by verify-manifest.
+type manifestVerification struct {
+	Binary           string   `json:"binary"`
+	Version          string   `json:"version"`
+	SHA256           string   `json:"sha256"`
+	Verified         bool     `json:"verified"`
+	PredicateType    string   `json:"predicate_type,omitempty"`
+	SignerWorkflow   string   `json:"signer_workflow,omitempty"`
+	SourceRepository string   `json:"source_repository,omitempty"`
+	SourceRef        string   `json:"source_ref,omitempty"`
+	SourceCommit     string   `json:"source_commit,omitempty"`
+	BuildRun         string   `json:"build_run,omitempty"`
+	Issuer           string   `json:"issuer,omitempty"`
+	Timestamps       []string `json:"timestamps,omitempty"`
+	Warnings         []string `json:"warnings,omitempty"`
+	Error            string   `json:"error,omitempty"`
+}
+
+// ghAttestationResult is the part of `gh attestation verify --format json`
+// output that verify-manifest reports.
+type ghAttestationResult struct {
+	VerificationResult struct {
+		Statement struct {
+			PredicateType string `json:"predicateType"`
+			Subject       []struct {
+				Name   string            `json:"name"`
+				Digest map[string]string `json:"digest"`
+			} `json:"subject"`
+		} `json:"statement"`
+		Signature struct {
+			Certificate struct {
+				Issuer                 string `json:"issuer"`
+				BuildSignerURI         string `json:"buildSignerURI"`
+				SourceRepositoryURI    string `json:"sourceRepositoryURI"`
+				SourceRepositoryRef    string `json:"sourceRepositoryRef"`
+				SourceRepositoryDigest string `json:"sourceRepositoryDigest"`
+				RunInvocationURI       string `json:"runInvocationURI"`
+			} `json:"certificate"`
+		} `json:"signature"`
+		VerifiedTimestamps []struct {
+			Type      string `json:"type"`
+			Timestamp string `json:"timestamp"`
+		} `json:"verifiedTimestamps"`
+	} `json:"verificationResult"`
+}
+
+func init() {
+	registerCommand(newVerifyManifestCmd)
+}
+
+func newVerifyManifestCmd() *cobra.Command {
+	var bundlePath string
+
+	cmd := &cobra.Command{
+		Use:   "verify-manifest",
+		Short: "Verify this mcp-wire binary against its release attestation",
+		Long: `verify-manifest checks that the running mcp-wire binary is one built by the
+release workflow of ` + releaseRepository + `. It hashes the binary and
+verifies it against the signed build provenance attestation published with
+each release, using the GitHub CLI (gh attestation verify). The attestation
+is signed through Sigstore, and its certificate must name the release
+workflow as the signer.
+
+Pass --bundle to verify against a downloaded attestation bundle instead of
+fetching it from GitHub. Binaries built from source have no attestation and
+fail verification. The command exits non-zero when verification fails.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonOutput, err := jsonOutputRequested(cmd)
+			if err != nil {
+				return err
+			}
+
+			summary, verifyErr := verifyRunningBinary(bundlePath)
+			if verifyErr != nil {
+				summary.Error = verifyErr.Error()
+				cmd.SilenceUsage = true
+			}
+
+			if jsonOutput {
+				if err := writeJSON(cmd.OutOrStdout(), summary); err != nil {
+					return err
+				}
+			} else {
+				writeManifestVerification(cmd.OutOrStdout(), summary)
+			}
+
+			return verifyErr
+		},
+	}
+
+	cmd.Flags().StringVar(&bundlePath, "bundle", "", "Verify against a downloaded attestation bundle file")
+
+	return cmd
+}
+
+// verifyRunningBinary hashes the running binary and verifies it against the
+// release attestation. The summary is filled in as far as it got, also when
+// an error is returned.
+func verifyRunningBinary(bundlePath string) (manifestVerification, error) {
+	summary := manifestVerification{Version: app.Version}
+
+	binary, err := executablePath()
+	if err != nil {
+		return summary, fmt.Errorf("locate running binary: %w", err)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(binary); err == nil {
+		binary = resolved
+	}
+	summary.Binary = binary
+
+	digest, err := fileSHA256(binary)
+	if err != nil {
+		return summary, err
+	}
+	summary.SHA256 = digest
+
+	if _, err := lookPathGitHubCLI(); err != nil {
+		return summary, errors.New("the GitHub CLI (gh) is required to verify attestations; install it from https://cli.github.com and run: gh auth login")
+	}
+
+	args := []string{"attestation", "verify", binary, "--repo", releaseRepository, "--signer-workflow", releaseSignerWorkflow, "--format", "json"}
+	if bundlePath != "" {
+		args = append(args, "--bundle", bundlePath)
+	}
+
+	out, err := runAttestationGH(args...)
+	if err != nil {
+		return summary, err
+	}
+
+	var results []ghAttestationResult
+	if err := json.Unmarshal(out, &results); err != nil {
+		return summary, fmt.Errorf("parse gh attestation output: %w", err)
+	}
+
+	for _, result := range results {
+		verification := result.VerificationResult
+		if !subjectHasDigest(result, digest) {
+			continue
+		}
+
+		certificate := verification.Signature.Certificate
+		summary.Verified = true
+		summary.PredicateType = verification.Statement.PredicateType
+		summary.SignerWorkflow = certificate.BuildSignerURI
+		summary.SourceRepository = certificate.SourceRepositoryURI
+		summary.SourceRef = certificate.SourceRepositoryRef
+		summary.SourceCommit = certificate.SourceRepositoryDigest
+		summary.BuildRun = certificate.RunInvocationURI
+		summary.Issuer = certificate.Issuer
+		for _, timestamp := range verification.VerifiedTimestamps {
+			summary.Timestamps = append(summary.Timestamps, strings.TrimSpace(timestamp.Type+" "+timestamp.Timestamp))
+		}
+
+		if wantRef := "refs/tags/v" + app.Version; summary.SourceRef != "" && summary.SourceRef != wantRef {
+			summary.Warnings = append(summary.Warnings, fmt.Sprintf("the attestation was built from %s, but this binary reports version %s", summary.SourceRef, app.Version))
+		}
+
+		return summary, nil
+	}
+
+	return summary, fmt.Errorf("no verified attestation covers sha256 %s", digest)
+}
+
+func subjectHasDigest(result ghAttestationResult, digest string) bool {
+	for _, subject := range result.VerificationResult.Statement.Subject {
+		if strings.EqualFold(subject.Digest["sha256"], digest) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("open binary %q: %w", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("hash binary %q: %w", path, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func defaultRunAttestationGH(args ...string) ([]byte, error) {
+	cmd := exec.Command("gh", args...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+
+		return nil, fmt.Errorf("gh attestation verify failed: %s", message)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func writeManifestVerification(output io.Writer, summary manifestVerification) {
+	fmt.Fprintf(output, "Binary:   %s\n", summary.Binary)
+	fmt.Fprintf(output, "Version:  %s\n", summary.Version)
+	if summary.SHA256 != "" {
+		fmt.Fprintf(output, "SHA-256:  %s\n", summary.SHA256)
+	}
+
+	if !summary.Verified {
+		fmt.Fprintln(output, "Status:   NOT verified")
+		return
+	}
+
+	fmt.Fprintln(output, "Status:   verified")
+	fmt.Fprintf(output, "  Predicate:   %s\n", summary.PredicateType)
+	fmt.Fprintf(output, "  Signed by:   %s\n", summary.SignerWorkflow)
+	fmt.Fprintf(output, "  Source:      %s@%s (%s)\n", summary.SourceRepository, summary.SourceRef, summary.SourceCommit)
+	fmt.Fprintf(output, "  Build run:   %s\n", summary.BuildRun)
+	fmt.Fprintf(output, "  Issuer:      %s\n", summary.Issuer)
+	for _, timestamp := range summary.Timestamps {
+		fmt.Fprintf(output, "  Timestamp:   %s\n", timestamp)
+	}
+
+	for _, warning := range summary.Warnings {
+		fmt.Fprintf(output, "Warning: %s\n", warning)
+	}
+}
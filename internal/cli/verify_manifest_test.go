@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/app"
+)
+
+const testBinaryDigest = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" // sha256("hello")
+
+func overrideVerifyManifestDependencies(t *testing.T, ghOutput string) *[]string {
+	t.Helper()
+
+	originalExecutablePath := executablePath
+	originalRunAttestationGH := runAttestationGH
+	originalLookPathGitHubCLI := lookPathGitHubCLI
+	t.Cleanup(func() {
+		executablePath = originalExecutablePath
+		runAttestationGH = originalRunAttestationGH
+		lookPathGitHubCLI = originalLookPathGitHubCLI
+	})
+
+	binary := filepath.Join(t.TempDir(), "mcp-wire")
+	if err := os.WriteFile(binary, []byte("hello"), 0o700); err != nil {
+		t.Fatalf("write binary: %v", err)
+	}
+
+	var calledArgs []string
+	executablePath = func() (string, error) { return binary, nil }
+	lookPathGitHubCLI = func() (string, error) { return "/usr/bin/gh", nil }
+	runAttestationGH = func(args ...string) ([]byte, error) {
+		calledArgs = args
+		return []byte(ghOutput), nil
+	}
+
+	return &calledArgs
+}
+
+func executeVerifyManifestCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	verifyCmd := newVerifyManifestCmd()
+	addOutputFlags(verifyCmd)
+
+	var stdout bytes.Buffer
+	verifyCmd.SetOut(&stdout)
+	verifyCmd.SetErr(&stdout)
+	verifyCmd.SetArgs(args)
+
+	err := verifyCmd.Execute()
+	return stdout.String(), err
+}
+
+func TestVerifyManifestCommandPrintsAttestationSummary(t *testing.T) {
+	calledArgs := overrideVerifyManifestDependencies(t, `[{"verificationResult":{
+		"statement":{"predicateType":"https://slsa.dev/provenance/v1","subject":[{"name":"mcp-wire","digest":{"sha256":"`+testBinaryDigest+`"}}]},
+		"signature":{"certificate":{
+			"issuer":"CN=sigstore-intermediate,O=sigstore.dev",
+			"buildSignerURI":"https://github.com/andreagrandi/mcp-wire/.github/workflows/release.yml@refs/tags/v`+app.Version+`",
+			"sourceRepositoryURI":"https://github.com/andreagrandi/mcp-wire",
+			"sourceRepositoryRef":"refs/tags/v`+app.Version+`",
+			"sourceRepositoryDigest":"abc123",
+			"runInvocationURI":"https://github.com/andreagrandi/mcp-wire/actions/runs/1/attempts/1"}},
+		"verifiedTimestamps":[{"type":"Tlog","timestamp":"2026-01-02T03:04:05Z"}]}}]`)
+
+	output, err := executeVerifyManifestCommand(t, "--bundle", "attestation.jsonl")
+	if err != nil {
+		t.Fatalf("expected verification to succeed: %v", err)
+	}
+
+	for _, want := range []string{
+		"SHA-256:  " + testBinaryDigest,
+		"Status:   verified",
+		"Signed by:   https://github.com/andreagrandi/mcp-wire/.github/workflows/release.yml",
+		"Source:      https://github.com/andreagrandi/mcp-wire@refs/tags/v" + app.Version + " (abc123)",
+		"Timestamp:   Tlog 2026-01-02T03:04:05Z",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+
+	if strings.Contains(output, "Warning") {
+		t.Fatalf("expected no warning for a matching version, got %q", output)
+	}
+
+	got := strings.Join(*calledArgs, " ")
+	if !strings.Contains(got, "--repo andreagrandi/mcp-wire --signer-workflow andreagrandi/mcp-wire/.github/workflows/release.yml") || !strings.HasSuffix(got, "--bundle attestation.jsonl") {
+		t.Fatalf("unexpected gh arguments: %v", *calledArgs)
+	}
+}
+
+func TestVerifyManifestCommandFailsWhenDigestIsNotAttested(t *testing.T) {
+	overrideVerifyManifestDependencies(t, `[{"verificationResult":{"statement":{"subject":[{"name":"mcp-wire","digest":{"sha256":"0000"}}]}}}]`)
+
+	output, err := executeVerifyManifestCommand(t, "--json")
+	if err == nil || !strings.Contains(err.Error(), "no verified attestation covers sha256 "+testBinaryDigest) {
+		t.Fatalf("expected an unattested digest error, got %v", err)
+	}
+
+	if !strings.Contains(output, `"verified": false`) || !strings.Contains(output, `"error": "no verified attestation`) {
+		t.Fatalf("expected JSON summary of the failure, got %q", output)
+	}
+}
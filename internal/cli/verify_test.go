@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+type fakeVerifyingInstallTarget struct {
+	*fakeInstallTarget
+	verifyErr   error
+	verifyCalls int
+	lastScope   targetpkg.ConfigScope
+}
+
+func (t *fakeVerifyingInstallTarget) Verify(_ service.Service, _ map[string]string, scope targetpkg.ConfigScope) error {
+	t.verifyCalls++
+	t.lastScope = scope
+	return t.verifyErr
+}
+
+func TestInstallCommandReportsReadBackVerification(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	verified := &fakeVerifyingInstallTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true},
+	}
+	mismatched := &fakeVerifyingInstallTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Beta CLI", slug: "beta-cli", installed: true},
+		verifyErr:         &targetpkg.VerificationError{Service: "demo-service", Dropped: []string{"cwd"}},
+	}
+	plain := &fakeInstallTarget{name: "Gamma CLI", slug: "gamma-cli", installed: true}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo-service": {Name: "demo-service", Transport: "sse", URL: "https://example.com/mcp"},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{verified, mismatched, plain} }
+	newCredentialEnvSource = func() credential.Source {
+		return &testCredentialSource{name: "environment", values: map[string]string{}}
+	}
+	newCredentialFileSource = func(string) credential.Source {
+		return &testCredentialSource{name: "file", values: map[string]string{}}
+	}
+
+	output, err := executeInstallCommand(t, "demo-service", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected a verification mismatch not to fail the install: %v", err)
+	}
+
+	if !strings.Contains(output, "Alpha CLI: configured (verified)") {
+		t.Fatalf("expected verified status, got %q", output)
+	}
+
+	if !strings.Contains(output, "Beta CLI: configured, but verification failed") || !strings.Contains(output, "dropped cwd") {
+		t.Fatalf("expected verification warning, got %q", output)
+	}
+
+	if !strings.Contains(output, "Gamma CLI: configured\n") {
+		t.Fatalf("expected plain status for target without verification, got %q", output)
+	}
+
+	if verified.lastScope != targetpkg.ConfigScopeUser {
+		t.Fatalf("expected unscoped target to be verified in user scope, got %q", verified.lastScope)
+	}
+}
+
+func TestTUIInstallTargetReturnsVerificationError(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	mismatched := &fakeVerifyingInstallTarget{
+		fakeInstallTarget: &fakeInstallTarget{name: "Beta CLI", slug: "beta-cli", installed: true},
+		verifyErr:         &targetpkg.VerificationError{Service: "demo-service", Missing: true},
+	}
+
+	svc := service.Service{Name: "demo-service", Transport: "sse", URL: "https://example.com/mcp"}
+	err := tuiInstallTarget(svc, nil, mismatched, targetpkg.ConfigScopeUser)
+	if err == nil || !strings.Contains(err.Error(), "was not found in the config after writing it") {
+		t.Fatalf("expected verification error, got %v", err)
+	}
+
+	if mismatched.installCalls != 1 || mismatched.verifyCalls != 1 {
+		t.Fatalf("expected one install and one verification, got %d and %d", mismatched.installCalls, mismatched.verifyCalls)
+	}
+}
+
+type fakeLoadSimulatingInstallTarget struct {
+	*fakeVerifyingInstallTarget
+	result targetpkg.LoadResult
+}
+
+func (t *fakeLoadSimulatingInstallTarget) SimulateLoad(_ string) (targetpkg.LoadResult, error) {
+	return t.result, nil
+}
+
+func TestInstallCommandWarnsWhenTargetWouldNotLoadService(t *testing.T) {
+	restore := overrideInstallCommandDependencies(t)
+	defer restore()
+
+	loaded := &fakeLoadSimulatingInstallTarget{
+		fakeVerifyingInstallTarget: &fakeVerifyingInstallTarget{
+			fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true},
+		},
+		result: targetpkg.LoadResult{Loaded: true, Path: "/home/user/.alpha.json"},
+	}
+	skipped := &fakeLoadSimulatingInstallTarget{
+		fakeVerifyingInstallTarget: &fakeVerifyingInstallTarget{
+			fakeInstallTarget: &fakeInstallTarget{name: "Beta CLI", slug: "beta-cli", installed: true},
+		},
+		result: targetpkg.LoadResult{Reason: "Beta CLI reads MCP servers from ~/.beta.json, not /home/user/.beta/settings.json"},
+	}
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo-service": {Name: "demo-service", Transport: "sse", URL: "https://example.com/mcp"},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return []targetpkg.Target{loaded, skipped} }
+	newCredentialEnvSource = func() credential.Source {
+		return &testCredentialSource{name: "environment", values: map[string]string{}}
+	}
+	newCredentialFileSource = func(string) credential.Source {
+		return &testCredentialSource{name: "file", values: map[string]string{}}
+	}
+
+	output, err := executeInstallCommand(t, "demo-service", "--no-prompt")
+	if err != nil {
+		t.Fatalf("expected a service that would not load not to fail the install: %v", err)
+	}
+
+	if !strings.Contains(output, "Alpha CLI: configured (verified)") {
+		t.Fatalf("expected verified status, got %q", output)
+	}
+
+	if !strings.Contains(output, "Beta CLI: configured, but verification failed") || !strings.Contains(output, "would not be loaded: Beta CLI reads MCP servers from ~/.beta.json") {
+		t.Fatalf("expected load warning, got %q", output)
+	}
+}
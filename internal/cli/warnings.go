@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/andreagrandi/mcp-wire/internal/diagnostics"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	registerCommand(newWarningsCmd)
+}
+
+// addSuppressFlag registers --suppress as a persistent flag of cmd.
+func addSuppressFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringSlice("suppress", nil, "Warning code(s) to hide for this run, e.g. MW201; can be repeated or comma-separated")
+}
+
+func newWarningsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "warnings",
+		Short: "List warning codes and which ones are suppressed",
+		Long: `warnings lists the codes of the warnings install, uninstall, doctor and the
+TUI report, and marks the ones that are suppressed.
+
+Suppress a code for one run with --suppress MW201, or everywhere by listing
+it under "suppress_warnings" in ~/.config/mcp-wire/config.json. Other
+warnings keep showing.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			suppressions, err := warningSuppressions(cmd)
+			if err != nil {
+				return err
+			}
+
+			writeWarningCodes(cmd.OutOrStdout(), suppressions)
+			return nil
+		},
+	}
+}
+
+// warningSuppressions returns the codes suppressed by the "suppress_warnings"
+// setting and the --suppress flag. An unknown code in either is an error.
+func warningSuppressions(cmd *cobra.Command) (diagnostics.Suppressions, error) {
+	var codes []string
+	if cfg, err := loadConfig(); err == nil {
+		codes = append(codes, cfg.SuppressedWarnings()...)
+	}
+
+	if cmd.Flags().Lookup("suppress") != nil {
+		flagCodes, err := cmd.Flags().GetStringSlice("suppress")
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, flagCodes...)
+	}
+
+	return diagnostics.NewSuppressions(codes)
+}
+
+// writeWarning prints warning as an indented "[!]" line unless its code is
+// suppressed.
+func writeWarning(output io.Writer, suppressions diagnostics.Suppressions, warning diagnostics.Warning) {
+	if suppressions.Suppressed(warning.Code) {
+		return
+	}
+
+	fmt.Fprintf(output, "  [!] %s\n", warning)
+}
+
+func writeWarningCodes(output io.Writer, suppressions diagnostics.Suppressions) {
+	fmt.Fprintf(output, "%-6s  %-10s  %s\n", "CODE", "STATUS", "DESCRIPTION")
+	for _, code := range diagnostics.Codes() {
+		status := "shown"
+		if suppressions.Suppressed(code) {
+			status = "suppressed"
+		}
+
+		fmt.Fprintf(output, "%-6s  %-10s  %s\n", code, status, code.Description())
+	}
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	"github.com/andreagrandi/mcp-wire/internal/diagnostics"
+	"github.com/spf13/cobra"
+)
+
+func newTestSuppressCmd(t *testing.T, configJSON string, args ...string) *cobra.Command {
+	t.Helper()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(configJSON), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	originalLoadConfig := loadConfig
+	t.Cleanup(func() { loadConfig = originalLoadConfig })
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringSlice("suppress", nil, "")
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+
+	return cmd
+}
+
+func TestWarningSuppressionsMergesConfigAndFlag(t *testing.T) {
+	cmd := newTestSuppressCmd(t, `{"suppress_warnings":["MW201"]}`, "--suppress", "mw204,MW101")
+
+	suppressions, err := warningSuppressions(cmd)
+	if err != nil {
+		t.Fatalf("expected suppressions to parse: %v", err)
+	}
+
+	for _, code := range []diagnostics.Code{diagnostics.TargetNotFound, diagnostics.RuntimeNotFound, diagnostics.SystemScopeCredentials} {
+		if !suppressions.Suppressed(code) {
+			t.Fatalf("expected %s to be suppressed, got %v", code, suppressions)
+		}
+	}
+
+	if suppressions.Suppressed(diagnostics.ManualAuthentication) {
+		t.Fatal("expected MW103 to stay shown")
+	}
+}
+
+func TestWarningSuppressionsRejectsUnknownCode(t *testing.T) {
+	cmd := newTestSuppressCmd(t, `{}`, "--suppress", "MW999")
+
+	if _, err := warningSuppressions(cmd); err == nil || !strings.Contains(err.Error(), "MW999") {
+		t.Fatalf("expected unknown code error, got %v", err)
+	}
+}
+
+func TestWriteWarningSkipsSuppressedCodes(t *testing.T) {
+	buf := new(bytes.Buffer)
+	suppressions := diagnostics.Suppressions{diagnostics.SystemScopeCredentials: true}
+
+	writeWarning(buf, suppressions, diagnostics.New(diagnostics.SystemScopeCredentials, "hidden"))
+	writeWarning(buf, suppressions, diagnostics.New(diagnostics.ManualAuthentication, "Next step: %s", "run /mcp"))
+
+	if got := buf.String(); got != "  [!] [MW103] Next step: run /mcp\n" {
+		t.Fatalf("unexpected warning output %q", got)
+	}
+}
+
+func TestWriteWarningCodesMarksSuppressed(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writeWarningCodes(buf, diagnostics.Suppressions{diagnostics.RegistryCacheStale: true})
+
+	output := buf.String()
+	if !strings.Contains(output, "MW203   suppressed") {
+		t.Fatalf("expected MW203 to be marked suppressed, got %q", output)
+	}
+
+	if !strings.Contains(output, "MW101   shown") {
+		t.Fatalf("expected MW101 to be shown, got %q", output)
+	}
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/webhook"
+)
+
+// webhookFlushTimeout bounds how long the process waits on exit for queued
+// webhook events, so a slow endpoint never holds the terminal for long.
+const webhookFlushTimeout = 5 * time.Second
+
+// webhookEmitter is created on the first event, from the webhook settings
+// in the mcp-wire config; it stays nil when no webhook is configured.
+var webhookEmitter struct {
+	sync.Mutex
+	loaded  bool
+	emitter *webhook.Emitter
+}
+
+func activeWebhookEmitter() *webhook.Emitter {
+	webhookEmitter.Lock()
+	defer webhookEmitter.Unlock()
+
+	if webhookEmitter.loaded {
+		return webhookEmitter.emitter
+	}
+
+	webhookEmitter.loaded = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil
+	}
+
+	settings := cfg.Webhook()
+	if settings.URL == "" {
+		return nil
+	}
+
+	resolver, _ := newCredentialSources()
+	secret, _, _ := resolver.Resolve(settings.SecretEnv)
+	webhookEmitter.emitter = webhook.NewEmitter(settings.URL, secret)
+
+	return webhookEmitter.emitter
+}
+
+// emitWebhookEvent queues an event for the outcome of eventType on one
+// target. It returns immediately; delivery happens in the background.
+func emitWebhookEvent(eventType string, serviceName string, targetDefinition targetpkg.Target, scope targetpkg.ConfigScope, err error) {
+	emitter := activeWebhookEmitter()
+	if emitter == nil {
+		return
+	}
+
+	event := webhook.Event{Type: eventType, Service: serviceName, Target: targetDefinition.Slug(), Scope: effectiveScope(targetDefinition, scope)}
+
+	switch {
+	case err != nil:
+		event.Status = "failed"
+	case eventType == webhook.EventInstall:
+		event.Status = "configured"
+	case eventType == webhook.EventUninstall:
+		event.Status = "removed"
+	case eventType == webhook.EventAuth:
+		event.Status = "authenticated"
+	}
+
+	emitter.Emit(event)
+}
+
+// flushWebhookEvents waits for queued webhook events before the process
+// exits and warns on errOutput about events that were not delivered.
+func flushWebhookEvents(errOutput io.Writer) {
+	webhookEmitter.Lock()
+	emitter := webhookEmitter.emitter
+	webhookEmitter.emitter = nil
+	webhookEmitter.loaded = false
+	webhookEmitter.Unlock()
+
+	if emitter == nil {
+		return
+	}
+
+	undelivered, timedOut := emitter.Close(webhookFlushTimeout)
+	if timedOut {
+		fmt.Fprintf(errOutput, "Warning: gave up waiting for webhook events after %s.\n", webhookFlushTimeout)
+	}
+
+	if undelivered > 0 {
+		fmt.Fprintf(errOutput, "Warning: %d webhook event(s) could not be delivered.\n", undelivered)
+	}
+}
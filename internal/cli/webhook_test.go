@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/config"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/andreagrandi/mcp-wire/internal/webhook"
+)
+
+func overrideWebhookDependencies(t *testing.T, configData string) {
+	t.Helper()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(configData), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	originalLoadConfig := loadConfig
+	t.Cleanup(func() {
+		loadConfig = originalLoadConfig
+		flushWebhookEvents(io.Discard)
+	})
+
+	loadConfig = func() (*config.Config, error) { return config.LoadFrom(configPath) }
+	flushWebhookEvents(io.Discard)
+}
+
+func TestEmitWebhookEventPostsSignedEvent(t *testing.T) {
+	var mu sync.Mutex
+	var bodies [][]byte
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		bodies = append(bodies, body)
+		signatures = append(signatures, r.Header.Get(webhook.SignatureHeader))
+		mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("TEAM_WEBHOOK_SECRET", "s3cret")
+	overrideWebhookDependencies(t, `{"webhook":{"url":"`+server.URL+`","secret_env":"TEAM_WEBHOOK_SECRET"}}`)
+
+	scoped := &fakeScopedUninstallTarget{fakeUninstallTarget: &fakeUninstallTarget{name: "Claude Code", slug: "claude"}}
+	emitWebhookEvent(webhook.EventInstall, "sentry", scoped, targetpkg.ConfigScopeProject, nil)
+	emitWebhookEvent(webhook.EventUninstall, "sentry", fakeListTarget{name: "Codex", slug: "codex"}, targetpkg.ConfigScopeProject, errors.New("boom"))
+
+	var errOutput bytes.Buffer
+	flushWebhookEvents(&errOutput)
+	if errOutput.Len() != 0 {
+		t.Fatalf("expected no delivery warnings, got %q", errOutput.String())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected two events, got %d", len(bodies))
+	}
+
+	want := []webhook.Event{
+		{Type: webhook.EventInstall, Service: "sentry", Target: "claude", Scope: "project", Status: "configured"},
+		{Type: webhook.EventUninstall, Service: "sentry", Target: "codex", Status: "failed"},
+	}
+	for i, body := range bodies {
+		var event webhook.Event
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Fatalf("failed to decode %q: %v", body, err)
+		}
+
+		if event.Type != want[i].Type || event.Service != want[i].Service || event.Target != want[i].Target || event.Scope != want[i].Scope || event.Status != want[i].Status {
+			t.Fatalf("event %d: expected %#v, got %#v", i, want[i], event)
+		}
+
+		if signatures[i] != webhook.Sign([]byte("s3cret"), body) {
+			t.Fatalf("event %d: unexpected signature %q", i, signatures[i])
+		}
+
+		if bytes.Contains(body, []byte("s3cret")) {
+			t.Fatalf("event %d leaks the secret: %s", i, body)
+		}
+	}
+}
+
+func TestEmitWebhookEventWithoutWebhookDoesNothing(t *testing.T) {
+	overrideWebhookDependencies(t, `{}`)
+
+	emitWebhookEvent(webhook.EventInstall, "sentry", fakeListTarget{name: "Codex", slug: "codex"}, targetpkg.ConfigScopeUser, nil)
+	if activeWebhookEmitter() != nil {
+		t.Fatal("expected no emitter without a configured webhook")
+	}
+}
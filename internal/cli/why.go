@@ -0,0 +1,285 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/app"
+	"github.com/andreagrandi/mcp-wire/internal/format"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+var newProvenanceStore = func() *state.ProvenanceStore { return state.NewProvenanceStore("") }
+
+// serviceOrigin describes what wrote a service to a target, for the
+// provenance record `why` reads back.
+type serviceOrigin struct {
+	command string // "install", "import", "sync", or "tui"
+	source  string // the imported file, manifest, or project directory
+	// credentialSources maps each env var of the service to where its value
+	// was found.
+	credentialSources map[string]string
+}
+
+// recordServiceProvenance remembers how svc came to be configured on t.
+// Failures are ignored: like the recent list, provenance must never fail
+// an install.
+func recordServiceProvenance(svc service.Service, t targetpkg.Target, scope targetpkg.ConfigScope, origin serviceOrigin) {
+	// Records are kept per target and scope, so an entry in a Codex profile
+	// would be mistaken for the global one.
+	if profiled, ok := t.(targetpkg.ProfileTarget); ok && profiled.Profile() != "" {
+		return
+	}
+
+	record := state.Provenance{
+		Service: svc.Name,
+		Target:  t.Slug(),
+		Scope:   string(writtenScope(t, scope)),
+		Command: origin.command,
+		Source:  origin.source,
+		Version: app.Version,
+		Pinned:  svc.Pin,
+	}
+	record.Fingerprint = entryFingerprint(t, svc.Name, targetpkg.ConfigScope(record.Scope))
+
+	for _, envVar := range svc.Env {
+		name := strings.TrimSpace(envVar.Name)
+		source, ok := origin.credentialSources[name]
+		if name == "" || !ok {
+			continue
+		}
+
+		record.Credentials = append(record.Credentials, state.CredentialUse{Name: name, Source: source})
+	}
+
+	_ = newProvenanceStore().Record(record)
+}
+
+// forgetServiceProvenance drops the record of a service removed from t.
+func forgetServiceProvenance(serviceName string, t targetpkg.Target, scope targetpkg.ConfigScope) {
+	if profiled, ok := t.(targetpkg.ProfileTarget); ok && profiled.Profile() != "" {
+		return
+	}
+
+	_ = newProvenanceStore().Remove(serviceName, t.Slug(), string(writtenScope(t, scope)))
+}
+
+// writtenScope is the scope an install in scope actually writes to: targets
+// without scope support always write their user config.
+func writtenScope(t targetpkg.Target, scope targetpkg.ConfigScope) targetpkg.ConfigScope {
+	if !targetSupportsScope(t, scope) {
+		return targetpkg.ConfigScopeUser
+	}
+
+	return scope
+}
+
+func init() {
+	registerCommand(newWhyCmd)
+}
+
+func newWhyCmd() *cobra.Command {
+	var targetSlugs []string
+
+	cmd := &cobra.Command{
+		Use:   "why <service>",
+		Short: "Explain why a service is installed",
+		Long: `why shows, for each target and scope a service is configured in, what put it
+there: the command and the file or manifest it came from, when, with which
+mcp-wire version, and where each credential was taken from. mcp-wire keeps
+this record under its state directory every time it writes a service.
+
+Entries mcp-wire has no record of were added by hand or by another tool.
+Credential values are never recorded or printed. This command is read-only.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, err := jsonOutputRequested(cmd)
+			if err != nil {
+				return err
+			}
+
+			serviceName := strings.TrimSpace(args[0])
+			if serviceName == "" {
+				return errors.New("service name is required")
+			}
+
+			targetDefinitions, err := resolveInstallTargets(targetSlugs)
+			if err != nil {
+				return err
+			}
+
+			if _, err := applyProjectDir(cmd, targetDefinitions); err != nil {
+				return err
+			}
+
+			entries, err := explainServiceProvenance(serviceName, targetDefinitions)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), entries)
+			}
+
+			writeServiceProvenance(cmd.OutOrStdout(), serviceName, entries)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&targetSlugs, "target", nil, "Only look at specific target slug(s) or @group(s); can be repeated")
+	addProjectDirFlags(cmd)
+
+	return cmd
+}
+
+// whyEntry is what `why` knows about a service on one target and scope.
+type whyEntry struct {
+	Target      string                `json:"target"`
+	Name        string                `json:"name"`
+	Scope       string                `json:"scope"`
+	Configured  bool                  `json:"configured"`
+	Recorded    bool                  `json:"recorded"`
+	Command     string                `json:"command,omitempty"`
+	Source      string                `json:"source,omitempty"`
+	Version     string                `json:"version,omitempty"`
+	InstalledAt *time.Time            `json:"installed_at,omitempty"`
+	Pinned      string                `json:"pinned,omitempty"`
+	Credentials []state.CredentialUse `json:"credentials,omitempty"`
+}
+
+// explainServiceProvenance pairs the scopes each target configures the
+// service in with the provenance records. A record whose entry is gone is
+// kept, so a service removed by hand still shows where it came from.
+func explainServiceProvenance(serviceName string, targetDefinitions []targetpkg.Target) ([]whyEntry, error) {
+	records, err := newProvenanceStore().ForService(serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]whyEntry, 0)
+	for _, t := range targetDefinitions {
+		scopes := configuredScopes(t, serviceName)
+		for _, record := range records {
+			if strings.EqualFold(record.Target, t.Slug()) && !containsScope(scopes, targetpkg.ConfigScope(record.Scope)) {
+				scopes = append(scopes, targetpkg.ConfigScope(record.Scope))
+			}
+		}
+
+		for _, scope := range scopes {
+			entry := whyEntry{
+				Target:     t.Slug(),
+				Name:       t.Name(),
+				Scope:      string(scope),
+				Configured: serviceConfiguredOn(t, serviceName, scope),
+			}
+
+			for _, record := range records {
+				if !strings.EqualFold(record.Target, t.Slug()) || record.Scope != string(scope) {
+					continue
+				}
+
+				installedAt := record.InstalledAt
+				entry.Recorded = true
+				entry.Command = record.Command
+				entry.Source = record.Source
+				entry.Version = record.Version
+				entry.InstalledAt = &installedAt
+				entry.Pinned = record.Pinned
+				entry.Credentials = record.Credentials
+			}
+
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// configuredScopes returns the scopes of t that hold serviceName, in the
+// order the target resolves them.
+func configuredScopes(t targetpkg.Target, serviceName string) []targetpkg.ConfigScope {
+	if explainer, ok := t.(targetpkg.Explainer); ok {
+		layers, err := explainer.ExplainService(serviceName)
+		if err == nil {
+			var scopes []targetpkg.ConfigScope
+			for _, layer := range layers {
+				if layer.Entry != nil && !containsScope(scopes, layer.Scope) {
+					scopes = append(scopes, layer.Scope)
+				}
+			}
+
+			return scopes
+		}
+	}
+
+	if serviceConfiguredOn(t, serviceName, targetpkg.ConfigScopeUser) {
+		return []targetpkg.ConfigScope{targetpkg.ConfigScopeUser}
+	}
+
+	return nil
+}
+
+func containsScope(scopes []targetpkg.ConfigScope, scope targetpkg.ConfigScope) bool {
+	for _, candidate := range scopes {
+		if candidate == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeServiceProvenance(output io.Writer, serviceName string, entries []whyEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintf(output, "%s is not configured on any selected target, and mcp-wire has no record of installing it.\n", serviceName)
+		return
+	}
+
+	fmt.Fprintf(output, "%s:\n", serviceName)
+	for _, entry := range entries {
+		fmt.Fprintf(output, "  %s (%s), %s scope\n", entry.Name, entry.Target, entry.Scope)
+
+		if !entry.Recorded {
+			fmt.Fprintln(output, "    configured outside mcp-wire: there is no install record")
+			continue
+		}
+
+		fmt.Fprintf(output, "    installed %s with mcp-wire %s by %s\n",
+			format.Timestamp(*entry.InstalledAt), entry.Version, describeOrigin(entry.Command, entry.Source))
+
+		if entry.Pinned != "" {
+			fmt.Fprintf(output, "    pinned to registry version %s\n", entry.Pinned)
+		}
+
+		for _, use := range entry.Credentials {
+			fmt.Fprintf(output, "    credential %s from %s\n", use.Name, use.Source)
+		}
+
+		if !entry.Configured {
+			fmt.Fprintln(output, "    no longer configured: the entry was removed outside mcp-wire")
+		}
+	}
+}
+
+// describeOrigin renders a recorded command for people.
+func describeOrigin(command string, source string) string {
+	switch command {
+	case "tui":
+		return "the interactive TUI"
+	case "":
+		return "an unknown command"
+	}
+
+	description := "`mcp-wire " + command + "`"
+	if source != "" {
+		description += " from " + source
+	}
+
+	return description
+}
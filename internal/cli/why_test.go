@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+	"github.com/spf13/cobra"
+)
+
+// listingInstallTarget lists the services it was given, so why sees them
+// as configured.
+type listingInstallTarget struct {
+	*fakeInstallTarget
+	services []string
+}
+
+func (t *listingInstallTarget) Install(svc service.Service, resolvedEnv map[string]string) error {
+	if err := t.fakeInstallTarget.Install(svc, resolvedEnv); err != nil {
+		return err
+	}
+
+	t.services = append(t.services, svc.Name)
+	return nil
+}
+
+func (t *listingInstallTarget) Uninstall(name string) error {
+	kept := t.services[:0]
+	for _, existing := range t.services {
+		if existing != name {
+			kept = append(kept, existing)
+		}
+	}
+
+	t.services = kept
+	return nil
+}
+
+func (t *listingInstallTarget) List() ([]string, error) {
+	return t.services, nil
+}
+
+func overrideWhyDependencies(t *testing.T, targets ...targetpkg.Target) {
+	t.Helper()
+
+	restore := overrideInstallCommandDependencies(t)
+	t.Cleanup(restore)
+
+	loadServices = func(_ ...string) (map[string]service.Service, error) {
+		return map[string]service.Service{
+			"demo": {
+				Name:      "demo",
+				Transport: "stdio",
+				Command:   "npx",
+				Env:       []service.EnvVar{{Name: "DEMO_TOKEN", Required: true}},
+			},
+		}, nil
+	}
+	listInstalledTargets = func() []targetpkg.Target { return targets }
+	lookupTarget = func(slug string) (targetpkg.Target, bool) {
+		for _, candidate := range targets {
+			if candidate.Slug() == slug {
+				return candidate, true
+			}
+		}
+
+		return nil, false
+	}
+	newCredentialEnvSource = func() credential.Source {
+		return &testCredentialSource{name: "environment", values: map[string]string{"DEMO_TOKEN": "secret"}}
+	}
+	newCredentialFileSource = func(_ string) credential.Source { return &testCredentialSource{name: "file"} }
+	shouldAutoAuthenticate = func(_ *cobra.Command) bool { return false }
+}
+
+func TestWhyCommandExplainsInstalledService(t *testing.T) {
+	alpha := &listingInstallTarget{fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}}
+	overrideWhyDependencies(t, alpha)
+
+	if _, err := executeRootCommand(t, "install", "demo", "--no-prompt"); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	output, err := executeRootCommand(t, "why", "demo")
+	if err != nil {
+		t.Fatalf("expected why to succeed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Alpha CLI (alpha-cli), user scope",
+		"by `mcp-wire install`",
+		"credential DEMO_TOKEN from environment",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+
+	if strings.Contains(output, "secret") {
+		t.Fatalf("expected credential value to stay out of the output, got %q", output)
+	}
+}
+
+func TestWhyCommandReportsEntryWithoutRecord(t *testing.T) {
+	alpha := &listingInstallTarget{fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}, services: []string{"demo"}}
+	overrideWhyDependencies(t, alpha)
+
+	output, err := executeRootCommand(t, "why", "demo")
+	if err != nil {
+		t.Fatalf("expected why to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "configured outside mcp-wire") {
+		t.Fatalf("expected missing record to be reported, got %q", output)
+	}
+}
+
+func TestWhyCommandReportsRecordOfRemovedEntry(t *testing.T) {
+	alpha := &listingInstallTarget{fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}}
+	overrideWhyDependencies(t, alpha)
+
+	if _, err := executeRootCommand(t, "install", "demo", "--no-prompt"); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	// Removed by hand, behind mcp-wire's back.
+	alpha.services = nil
+
+	output, err := executeRootCommand(t, "why", "demo")
+	if err != nil {
+		t.Fatalf("expected why to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "no longer configured") {
+		t.Fatalf("expected removed entry to be reported, got %q", output)
+	}
+}
+
+func TestWhyCommandReportsUnknownService(t *testing.T) {
+	alpha := &listingInstallTarget{fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}}
+	overrideWhyDependencies(t, alpha)
+
+	output, err := executeRootCommand(t, "why", "demo")
+	if err != nil {
+		t.Fatalf("expected why to succeed: %v", err)
+	}
+
+	if !strings.Contains(output, "no record of installing it") {
+		t.Fatalf("expected unknown service to be reported, got %q", output)
+	}
+}
+
+func TestUninstallCommandForgetsProvenance(t *testing.T) {
+	alpha := &listingInstallTarget{fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}}
+	overrideWhyDependencies(t, alpha)
+
+	if _, err := executeRootCommand(t, "install", "demo", "--no-prompt"); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if _, err := executeUninstallCommand(t, "demo", "--target", "alpha-cli"); err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	records, err := newProvenanceStore().ForService("demo")
+	if err != nil {
+		t.Fatalf("expected provenance to load: %v", err)
+	}
+
+	if len(records) != 0 {
+		t.Fatalf("expected uninstall to drop the record, got %+v", records)
+	}
+}
+
+func TestWhyCommandJSONOutput(t *testing.T) {
+	alpha := &listingInstallTarget{fakeInstallTarget: &fakeInstallTarget{name: "Alpha CLI", slug: "alpha-cli", installed: true}}
+	overrideWhyDependencies(t, alpha)
+
+	if err := newProvenanceStore().Record(state.Provenance{
+		Service: "demo",
+		Target:  "alpha-cli",
+		Scope:   "user",
+		Command: "import",
+		Source:  "/work/mcp.json",
+		Version: "1.2.3",
+	}); err != nil {
+		t.Fatalf("expected record to succeed: %v", err)
+	}
+
+	stdout, _, err := executeCommandWithOutputFlags(t, newWhyCmd(), "demo", "--json")
+	if err != nil {
+		t.Fatalf("expected why to succeed: %v", err)
+	}
+
+	var entries []whyEntry
+	if err := json.Unmarshal([]byte(stdout), &entries); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", stdout, err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("expected one entry, got %+v", entries)
+	}
+
+	entry := entries[0]
+	if !entry.Recorded || entry.Configured || entry.Command != "import" || entry.Source != "/work/mcp.json" || entry.Version != "1.2.3" {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+}
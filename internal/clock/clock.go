@@ -0,0 +1,72 @@
+// Package clock is the time source for caches, state files, webhooks, and
+// diagnostics. Code that needs the current time takes a Clock rather than
+// calling time.Now, so tests can fix the time.
+//
+// Timestamps are stored in UTC without a monotonic reading, so a value
+// compares the same before and after a round trip through a file. Ages are
+// computed against the wall clock, which can jump: a timestamp in the
+// future, left by a clock that was set back or by another machine, has no
+// age, and Expired treats it as expired rather than as fresh forever.
+package clock
+
+import "time"
+
+// Clock tells the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// Func adapts a function such as time.Now to a Clock.
+type Func func() time.Time
+
+// Now returns f().
+func (f Func) Now() time.Time {
+	return f()
+}
+
+// System is the clock of the machine.
+var System Clock = Func(time.Now)
+
+// Fixed returns a clock that always reports t.
+func Fixed(t time.Time) Clock {
+	return Func(func() time.Time { return t })
+}
+
+// OrSystem returns c, or System when c is nil, so a zero-value struct with
+// a Clock field still tells the time.
+func OrSystem(c Clock) Clock {
+	if c == nil {
+		return System
+	}
+
+	return c
+}
+
+// Stamp returns the current time of c for storing: in UTC and without the
+// monotonic reading, which would not survive being written to a file.
+func Stamp(c Clock) time.Time {
+	return OrSystem(c).Now().UTC().Round(0)
+}
+
+// Age returns how long before the current time of c t was. ok is false when
+// t is zero or in the future.
+func Age(c Clock, t time.Time) (age time.Duration, ok bool) {
+	if t.IsZero() {
+		return 0, false
+	}
+
+	age = OrSystem(c).Now().Sub(t)
+	if age < 0 {
+		return 0, false
+	}
+
+	return age, true
+}
+
+// Expired reports whether t is more than ttl before the current time of c.
+// A zero or future t is expired, so callers refresh instead of trusting a
+// timestamp they cannot place.
+func Expired(c Clock, t time.Time, ttl time.Duration) bool {
+	age, ok := Age(c, t)
+	return !ok || age > ttl
+}
@@ -0,0 +1,50 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStampIsUTCWithoutMonotonicReading(t *testing.T) {
+	local := time.FixedZone("CEST", 2*60*60)
+	stamp := Stamp(Func(func() time.Time { return time.Date(2026, 10, 1, 14, 0, 0, 0, local) }))
+
+	if stamp.Location() != time.UTC || stamp.Hour() != 12 {
+		t.Fatalf("expected 12:00 UTC, got %v", stamp)
+	}
+
+	if now := Stamp(System); now != now.Round(0) {
+		t.Fatalf("expected no monotonic reading in %v", now)
+	}
+}
+
+func TestAgeAndExpired(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	c := Fixed(now)
+
+	tests := []struct {
+		name    string
+		stamp   time.Time
+		age     time.Duration
+		ok      bool
+		expired bool
+	}{
+		{name: "recent", stamp: now.Add(-time.Hour), age: time.Hour, ok: true, expired: false},
+		{name: "old", stamp: now.Add(-48 * time.Hour), age: 48 * time.Hour, ok: true, expired: true},
+		{name: "future", stamp: now.Add(time.Hour), ok: false, expired: true},
+		{name: "zero", ok: false, expired: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			age, ok := Age(c, tt.stamp)
+			if age != tt.age || ok != tt.ok {
+				t.Fatalf("expected age %v (%v), got %v (%v)", tt.age, tt.ok, age, ok)
+			}
+
+			if got := Expired(c, tt.stamp, 24*time.Hour); got != tt.expired {
+				t.Fatalf("expected expired %v, got %v", tt.expired, got)
+			}
+		})
+	}
+}
@@ -6,13 +6,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
 	configFileName = "config.json"
 	configDirName  = "mcp-wire"
+	policyFileName = "policy.yaml"
 )
 
 // FeatureRegistry defines all known feature flags and their defaults.
@@ -148,6 +152,758 @@ func (c *Config) Features() []FeatureStatus {
 	return result
 }
 
+// ProjectRoot returns the project root detection strategy set under
+// "project_root", or an empty string when it is not set.
+func (c *Config) ProjectRoot() string {
+	if c == nil {
+		return ""
+	}
+
+	raw, ok := c.raw["project_root"]
+	if !ok {
+		return ""
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(value)
+}
+
+// Locale returns the locale set under "locale" for translated registry
+// descriptions, or an empty string when it is not set.
+func (c *Config) Locale() string {
+	if c == nil {
+		return ""
+	}
+
+	raw, ok := c.raw["locale"]
+	if !ok {
+		return ""
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(value)
+}
+
+// SuppressedWarnings returns the warning codes listed under
+// "suppress_warnings", for example ["MW201"]. Codes are returned as written;
+// callers validate them.
+func (c *Config) SuppressedWarnings() []string {
+	if c == nil {
+		return nil
+	}
+
+	raw, ok := c.raw["suppress_warnings"]
+	if !ok {
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil
+	}
+
+	return values
+}
+
+// PruneEmpty reports whether uninstall should remove structures it leaves
+// empty in target configs. It is set under "prune_empty" and defaults to true.
+func (c *Config) PruneEmpty() bool {
+	if c == nil {
+		return true
+	}
+
+	raw, ok := c.raw["prune_empty"]
+	if !ok {
+		return true
+	}
+
+	var value bool
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return true
+	}
+
+	return value
+}
+
+// DenyPlaintextCredentials reports whether credentials may only be saved to
+// encrypted stores such as the OS keychain, never to the plaintext
+// credentials file. It is set under "deny_plaintext_credentials" and
+// defaults to false.
+func (c *Config) DenyPlaintextCredentials() bool {
+	if c == nil {
+		return false
+	}
+
+	raw, ok := c.raw["deny_plaintext_credentials"]
+	if !ok {
+		return false
+	}
+
+	var value bool
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return false
+	}
+
+	return value
+}
+
+// TargetPaths returns the config file overrides set under "target_paths",
+// keyed by target slug. Entries with an empty path are skipped.
+func (c *Config) TargetPaths() map[string]string {
+	paths := make(map[string]string)
+	if c == nil {
+		return paths
+	}
+
+	raw, ok := c.raw["target_paths"]
+	if !ok {
+		return paths
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return paths
+	}
+
+	for slug, path := range values {
+		slug = strings.ToLower(strings.TrimSpace(slug))
+		path = strings.TrimSpace(path)
+		if slug == "" || path == "" {
+			continue
+		}
+
+		paths[slug] = path
+	}
+
+	return paths
+}
+
+// InstalledTargets returns the slugs listed under "installed_targets":
+// targets the user marked as installed because mcp-wire did not detect them.
+func (c *Config) InstalledTargets() []string {
+	if c == nil {
+		return nil
+	}
+
+	raw, ok := c.raw["installed_targets"]
+	if !ok {
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil
+	}
+
+	slugs := make([]string, 0, len(values))
+	for _, value := range values {
+		slug := strings.ToLower(strings.TrimSpace(value))
+		if slug == "" || slices.Contains(slugs, slug) {
+			continue
+		}
+
+		slugs = append(slugs, slug)
+	}
+
+	return slugs
+}
+
+// ConfigureTarget marks a target as installed and, when configPath is not
+// empty, sets its config file under "target_paths". The config is
+// persisted.
+func (c *Config) ConfigureTarget(slug string, configPath string) error {
+	if c == nil {
+		return errors.New("config is nil")
+	}
+
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" {
+		return errors.New("target slug is required")
+	}
+
+	installed := c.InstalledTargets()
+	if !slices.Contains(installed, slug) {
+		installed = append(installed, slug)
+	}
+
+	paths := c.TargetPaths()
+	if configPath = strings.TrimSpace(configPath); configPath != "" {
+		paths[slug] = configPath
+	}
+
+	if err := c.setTargetOverrides(installed, paths); err != nil {
+		return err
+	}
+
+	return c.save()
+}
+
+// ResetTarget drops the installed mark and config path override of a
+// target, so it is detected again. The config is persisted.
+func (c *Config) ResetTarget(slug string) error {
+	if c == nil {
+		return errors.New("config is nil")
+	}
+
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	installed := slices.DeleteFunc(c.InstalledTargets(), func(value string) bool { return value == slug })
+	paths := c.TargetPaths()
+	delete(paths, slug)
+
+	if err := c.setTargetOverrides(installed, paths); err != nil {
+		return err
+	}
+
+	return c.save()
+}
+
+func (c *Config) setTargetOverrides(installed []string, paths map[string]string) error {
+	delete(c.raw, "installed_targets")
+	if len(installed) > 0 {
+		installedJSON, err := json.Marshal(installed)
+		if err != nil {
+			return fmt.Errorf("marshal installed targets: %w", err)
+		}
+
+		c.raw["installed_targets"] = installedJSON
+	}
+
+	delete(c.raw, "target_paths")
+	if len(paths) > 0 {
+		pathsJSON, err := json.Marshal(paths)
+		if err != nil {
+			return fmt.Errorf("marshal target paths: %w", err)
+		}
+
+		c.raw["target_paths"] = pathsJSON
+	}
+
+	return nil
+}
+
+// DisabledTargets returns the slugs listed under "targets.disabled":
+// targets the user hid so they stop showing up in lists and default installs.
+func (c *Config) DisabledTargets() []string {
+	if c == nil {
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(c.targetsSettings()["disabled"], &values); err != nil {
+		return nil
+	}
+
+	slugs := make([]string, 0, len(values))
+	for _, value := range values {
+		slug := strings.ToLower(strings.TrimSpace(value))
+		if slug == "" || slices.Contains(slugs, slug) {
+			continue
+		}
+
+		slugs = append(slugs, slug)
+	}
+
+	return slugs
+}
+
+// SetTargetDisabled adds a target to "targets.disabled" or removes it from
+// there. The config is persisted.
+func (c *Config) SetTargetDisabled(slug string, disabled bool) error {
+	if c == nil {
+		return errors.New("config is nil")
+	}
+
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" {
+		return errors.New("target slug is required")
+	}
+
+	slugs := slices.DeleteFunc(c.DisabledTargets(), func(value string) bool { return value == slug })
+	if disabled {
+		slugs = append(slugs, slug)
+	}
+
+	settings := c.targetsSettings()
+	delete(settings, "disabled")
+	if len(slugs) > 0 {
+		slugsJSON, err := json.Marshal(slugs)
+		if err != nil {
+			return fmt.Errorf("marshal disabled targets: %w", err)
+		}
+
+		settings["disabled"] = slugsJSON
+	}
+
+	if err := c.setTargetsSettings(settings); err != nil {
+		return err
+	}
+
+	return c.save()
+}
+
+// TargetGroups returns the groups defined under "targets.groups", keyed by
+// group name, each listing the slugs of its targets. Names and slugs are
+// lowercased; groups without targets are left out.
+func (c *Config) TargetGroups() map[string][]string {
+	groups := make(map[string][]string)
+	if c == nil {
+		return groups
+	}
+
+	var values map[string][]string
+	if err := json.Unmarshal(c.targetsSettings()["groups"], &values); err != nil {
+		return groups
+	}
+
+	for name, members := range values {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		for _, member := range members {
+			slug := strings.ToLower(strings.TrimSpace(member))
+			if slug == "" || slices.Contains(groups[name], slug) {
+				continue
+			}
+
+			groups[name] = append(groups[name], slug)
+		}
+	}
+
+	return groups
+}
+
+// MergeSettings picks how installs on a target combine the generated service
+// entry with the one already in its config.
+type MergeSettings struct {
+	// Strategy is "replace", "deep-merge", or "json-patch".
+	Strategy string `json:"strategy"`
+	// Patch holds the RFC 6902 operations of the json-patch strategy.
+	Patch json.RawMessage `json:"patch,omitempty"`
+}
+
+// TargetMergeSettings returns the settings under "targets.merge", keyed by
+// lowercased target slug. A slug may map to a bare strategy name instead of
+// an object. Entries that are neither are skipped; the strategy names are
+// checked where they are used.
+func (c *Config) TargetMergeSettings() map[string]MergeSettings {
+	settings := make(map[string]MergeSettings)
+	if c == nil {
+		return settings
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(c.targetsSettings()["merge"], &values); err != nil {
+		return settings
+	}
+
+	for slug, raw := range values {
+		slug = strings.ToLower(strings.TrimSpace(slug))
+		if slug == "" {
+			continue
+		}
+
+		var value MergeSettings
+		if err := json.Unmarshal(raw, &value.Strategy); err != nil {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				continue
+			}
+		}
+
+		value.Strategy = strings.ToLower(strings.TrimSpace(value.Strategy))
+		settings[slug] = value
+	}
+
+	return settings
+}
+
+// RegistrySettings describes an MCP registry listed under "registries".
+type RegistrySettings struct {
+	Name string
+	URL  string
+	// Enabled is false for registries turned off with "enabled": false.
+	Enabled bool
+	// Priority orders registries: lower values come first, and their
+	// servers win when several registries publish the same name.
+	Priority int
+}
+
+// Registries returns the registries listed under "registries", in the
+// order they are listed. Names are lowercased and must be made of letters,
+// digits, '-' and '_'; entries with an invalid or repeated name are skipped,
+// as are entries without a URL other than "official", which only adjusts
+// the built-in registry.
+func (c *Config) Registries() []RegistrySettings {
+	if c == nil {
+		return nil
+	}
+
+	raw, ok := c.raw["registries"]
+	if !ok {
+		return nil
+	}
+
+	var values []struct {
+		Name     string `json:"name"`
+		URL      string `json:"url"`
+		Enabled  *bool  `json:"enabled"`
+		Priority int    `json:"priority"`
+	}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil
+	}
+
+	registries := make([]RegistrySettings, 0, len(values))
+	for _, value := range values {
+		name := strings.ToLower(strings.TrimSpace(value.Name))
+		url := strings.TrimSpace(value.URL)
+		if !validRegistryName(name) || (url == "" && name != "official") {
+			continue
+		}
+
+		if slices.ContainsFunc(registries, func(registry RegistrySettings) bool { return registry.Name == name }) {
+			continue
+		}
+
+		registries = append(registries, RegistrySettings{
+			Name:     name,
+			URL:      url,
+			Enabled:  value.Enabled == nil || *value.Enabled,
+			Priority: value.Priority,
+		})
+	}
+
+	return registries
+}
+
+func validRegistryName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DefaultRegistryCacheTTL is how old a registry cache may get before it is
+// refreshed automatically, when "registry.cache_ttl" does not set it.
+const DefaultRegistryCacheTTL = 24 * time.Hour
+
+// RegistryRefreshOff is the cache TTL returned when "registry.cache_ttl" is
+// "never": the cache is then only refreshed on request.
+const RegistryRefreshOff time.Duration = -1
+
+// RegistryCacheTTL returns how old a registry cache may get before it is
+// refreshed automatically, set under "registry.cache_ttl" as a Go duration
+// such as "12h", a number of days such as "7d", "0" to refresh on every run,
+// or "never". A missing or invalid value falls back to the default.
+func (c *Config) RegistryCacheTTL() time.Duration {
+	if c == nil {
+		return DefaultRegistryCacheTTL
+	}
+
+	raw, ok := c.raw["registry"]
+	if !ok {
+		return DefaultRegistryCacheTTL
+	}
+
+	var values struct {
+		CacheTTL string `json:"cache_ttl"`
+	}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return DefaultRegistryCacheTTL
+	}
+
+	ttl, ok := parseCacheTTL(values.CacheTTL)
+	if !ok {
+		return DefaultRegistryCacheTTL
+	}
+
+	return ttl
+}
+
+func parseCacheTTL(value string) (time.Duration, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case value == "":
+		return 0, false
+	case value == "never":
+		return RegistryRefreshOff, true
+	case strings.HasSuffix(value, "d"):
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil || days < 0 {
+			return 0, false
+		}
+
+		return time.Duration(days) * 24 * time.Hour, true
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, false
+	}
+
+	return ttl, true
+}
+
+// PolicyFile returns the policy file set under "policy_file" and true, or,
+// when it is not set, policy.yaml next to the config file and false.
+func (c *Config) PolicyFile() (string, bool) {
+	if c == nil || c.path == "" {
+		return "", false
+	}
+
+	if raw, ok := c.raw["policy_file"]; ok {
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value), true
+		}
+	}
+
+	return filepath.Join(filepath.Dir(c.path), policyFileName), false
+}
+
+// WebhookSettings configures the webhook events are posted to.
+type WebhookSettings struct {
+	URL string `json:"url"`
+	// SecretEnv names the credential holding the HMAC secret used to sign
+	// events; the secret itself is never kept in this file.
+	SecretEnv string `json:"secret_env"`
+}
+
+// DefaultWebhookSecretEnv is the credential read for the webhook secret
+// when "webhook.secret_env" is not set.
+const DefaultWebhookSecretEnv = "MCP_WIRE_WEBHOOK_SECRET"
+
+// Webhook returns the settings under "webhook". The URL is empty when no
+// webhook is configured.
+func (c *Config) Webhook() WebhookSettings {
+	settings := WebhookSettings{SecretEnv: DefaultWebhookSecretEnv}
+	if c == nil {
+		return settings
+	}
+
+	raw, ok := c.raw["webhook"]
+	if !ok {
+		return settings
+	}
+
+	var values WebhookSettings
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return settings
+	}
+
+	settings.URL = strings.TrimSpace(values.URL)
+	if secretEnv := strings.TrimSpace(values.SecretEnv); secretEnv != "" {
+		settings.SecretEnv = secretEnv
+	}
+
+	return settings
+}
+
+// FeedbackSettings configures where "mcp-wire feedback" submits messages.
+type FeedbackSettings struct {
+	URL string `json:"url"`
+}
+
+// Feedback returns the settings under "feedback". The URL is empty when no
+// endpoint is configured, and feedback opens a GitHub issue instead.
+func (c *Config) Feedback() FeedbackSettings {
+	if c == nil {
+		return FeedbackSettings{}
+	}
+
+	raw, ok := c.raw["feedback"]
+	if !ok {
+		return FeedbackSettings{}
+	}
+
+	var values FeedbackSettings
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return FeedbackSettings{}
+	}
+
+	return FeedbackSettings{URL: strings.TrimSpace(values.URL)}
+}
+
+// ConfigLimits are the thresholds above which doctor reports a target config
+// as oversized.
+type ConfigLimits struct {
+	// MaxSizeMB is the largest a target config file may get, in megabytes.
+	MaxSizeMB int `json:"max_size_mb"`
+	// MaxProjects is how many project entries a target config may hold.
+	MaxProjects int `json:"max_projects"`
+}
+
+// Default thresholds used when "config_limits" does not set them.
+const (
+	DefaultConfigMaxSizeMB   = 5
+	DefaultConfigMaxProjects = 200
+)
+
+// ConfigLimits returns the settings under "config_limits". A missing or
+// non-positive value falls back to its default.
+func (c *Config) ConfigLimits() ConfigLimits {
+	limits := ConfigLimits{MaxSizeMB: DefaultConfigMaxSizeMB, MaxProjects: DefaultConfigMaxProjects}
+	if c == nil {
+		return limits
+	}
+
+	raw, ok := c.raw["config_limits"]
+	if !ok {
+		return limits
+	}
+
+	var values ConfigLimits
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return limits
+	}
+
+	if values.MaxSizeMB > 0 {
+		limits.MaxSizeMB = values.MaxSizeMB
+	}
+
+	if values.MaxProjects > 0 {
+		limits.MaxProjects = values.MaxProjects
+	}
+
+	return limits
+}
+
+// OnePasswordSettings maps credentials to 1Password secret references.
+type OnePasswordSettings struct {
+	// Account is the 1Password account to read from, for op's --account
+	// flag. Empty uses the op CLI default.
+	Account string `json:"account"`
+	// References maps env var names to references such as
+	// "op://Private/Jira/credential".
+	References map[string]string `json:"references"`
+}
+
+// OnePassword returns the settings under "onepassword". References with an
+// empty name or reference are dropped.
+func (c *Config) OnePassword() OnePasswordSettings {
+	var settings OnePasswordSettings
+	if c == nil {
+		return settings
+	}
+
+	raw, ok := c.raw["onepassword"]
+	if !ok {
+		return settings
+	}
+
+	var values OnePasswordSettings
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return settings
+	}
+
+	settings.Account = strings.TrimSpace(values.Account)
+	for name, reference := range values.References {
+		name = strings.TrimSpace(name)
+		reference = strings.TrimSpace(reference)
+		if name == "" || reference == "" {
+			continue
+		}
+
+		if settings.References == nil {
+			settings.References = make(map[string]string)
+		}
+
+		settings.References[name] = reference
+	}
+
+	return settings
+}
+
+// VaultSettings locates credentials in a HashiCorp Vault KV secrets engine.
+// Path and Field are templates in which "{name}" stands for the env var
+// name, so one secret can hold every credential or each can have its own.
+type VaultSettings struct {
+	// Address is the Vault server URL. Empty uses VAULT_ADDR.
+	Address string `json:"address"`
+	// Namespace is the Vault Enterprise namespace, if any.
+	Namespace string `json:"namespace"`
+	// TokenEnv names the env var holding the Vault token. When it is unset,
+	// the token the vault CLI saved in ~/.vault-token is used.
+	TokenEnv string `json:"token_env"`
+	// Path is the secret path, for example "secret/data/mcp-wire" (KV v2)
+	// or "kv/mcp-wire/{name}" (KV v1). Empty disables the Vault source.
+	Path string `json:"path"`
+	// Field is the key read from the secret data.
+	Field string `json:"field"`
+}
+
+// Vault returns the settings under "vault", with the token read from
+// VAULT_TOKEN and each credential from the field named after it by default.
+func (c *Config) Vault() VaultSettings {
+	settings := VaultSettings{TokenEnv: "VAULT_TOKEN", Field: "{name}"}
+	if c == nil {
+		return settings
+	}
+
+	raw, ok := c.raw["vault"]
+	if !ok {
+		return settings
+	}
+
+	var values VaultSettings
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return settings
+	}
+
+	settings.Address = strings.TrimSpace(values.Address)
+	settings.Namespace = strings.TrimSpace(values.Namespace)
+	settings.Path = strings.Trim(strings.TrimSpace(values.Path), "/")
+	if tokenEnv := strings.TrimSpace(values.TokenEnv); tokenEnv != "" {
+		settings.TokenEnv = tokenEnv
+	}
+	if field := strings.TrimSpace(values.Field); field != "" {
+		settings.Field = field
+	}
+
+	return settings
+}
+
+// targetsSettings returns the "targets" object, keyed by setting name.
+func (c *Config) targetsSettings() map[string]json.RawMessage {
+	settings := make(map[string]json.RawMessage)
+	if raw, ok := c.raw["targets"]; ok {
+		_ = json.Unmarshal(raw, &settings)
+	}
+
+	return settings
+}
+
+func (c *Config) setTargetsSettings(settings map[string]json.RawMessage) error {
+	delete(c.raw, "targets")
+	if len(settings) == 0 {
+		return nil
+	}
+
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("marshal targets settings: %w", err)
+	}
+
+	c.raw["targets"] = settingsJSON
+	return nil
+}
+
 // FeatureStatus describes the current state of a feature flag.
 type FeatureStatus struct {
 	Name        string
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadFromReturnsDefaultsWhenFileMissing(t *testing.T) {
@@ -309,3 +311,463 @@ func TestSetFeaturePreservesUnknownTopLevelKeys(t *testing.T) {
 		t.Fatal("expected registry=true in JSON")
 	}
 }
+
+func TestProjectRootReadsSetting(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	content := `{"project_root":" manifest ","features":{"registry":true}}`
+
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	if cfg.ProjectRoot() != "manifest" {
+		t.Fatalf("expected manifest strategy, got %q", cfg.ProjectRoot())
+	}
+
+	if err := cfg.SetFeature("registry", false); err != nil {
+		t.Fatalf("expected set feature to succeed: %v", err)
+	}
+
+	reloaded, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected reload to succeed: %v", err)
+	}
+
+	if reloaded.ProjectRoot() != "manifest" {
+		t.Fatalf("expected project_root to survive a save, got %q", reloaded.ProjectRoot())
+	}
+}
+
+func TestLocaleReadsSetting(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"locale":" pt-BR "}`), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	if cfg.Locale() != "pt-BR" {
+		t.Fatalf("expected pt-BR locale, got %q", cfg.Locale())
+	}
+}
+
+func TestSuppressedWarningsReadsSetting(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"suppress_warnings":["MW201","mw204"]}`), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	codes := cfg.SuppressedWarnings()
+	if len(codes) != 2 || codes[0] != "MW201" || codes[1] != "mw204" {
+		t.Fatalf("unexpected suppressed warnings: %v", codes)
+	}
+}
+
+func TestPruneEmptyDefaultsToTrue(t *testing.T) {
+	cfg, err := LoadFrom(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	if !cfg.PruneEmpty() {
+		t.Fatal("expected prune_empty to default to true")
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"prune_empty":false}`), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err = LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	if cfg.PruneEmpty() {
+		t.Fatal("expected prune_empty false to disable pruning")
+	}
+}
+
+func TestDenyPlaintextCredentialsDefaultsToFalse(t *testing.T) {
+	cfg, err := LoadFrom(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	if cfg.DenyPlaintextCredentials() {
+		t.Fatal("expected deny_plaintext_credentials to default to false")
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"deny_plaintext_credentials":true}`), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err = LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	if !cfg.DenyPlaintextCredentials() {
+		t.Fatal("expected deny_plaintext_credentials true to be read")
+	}
+}
+
+func TestTargetPathsReadsOverrides(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	content := `{"target_paths":{" Claude ":"~/work/.claude.json","codex":"  "}}`
+
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	paths := cfg.TargetPaths()
+	if len(paths) != 1 || paths["claude"] != "~/work/.claude.json" {
+		t.Fatalf("expected one normalized override, got %#v", paths)
+	}
+}
+
+func TestConfigureTargetPersistsOverrides(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	if err := cfg.ConfigureTarget(" Codex ", "/opt/codex/config.toml"); err != nil {
+		t.Fatalf("expected configure to succeed: %v", err)
+	}
+
+	if err := cfg.ConfigureTarget("opencode", ""); err != nil {
+		t.Fatalf("expected configure to succeed: %v", err)
+	}
+
+	reloaded, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected reload to succeed: %v", err)
+	}
+
+	if installed := reloaded.InstalledTargets(); strings.Join(installed, ",") != "codex,opencode" {
+		t.Fatalf("expected both targets marked installed, got %v", installed)
+	}
+
+	if paths := reloaded.TargetPaths(); len(paths) != 1 || paths["codex"] != "/opt/codex/config.toml" {
+		t.Fatalf("expected only the codex path override, got %#v", paths)
+	}
+
+	if err := reloaded.ResetTarget("codex"); err != nil {
+		t.Fatalf("expected reset to succeed: %v", err)
+	}
+
+	reloaded, err = LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected reload to succeed: %v", err)
+	}
+
+	if installed := reloaded.InstalledTargets(); strings.Join(installed, ",") != "opencode" {
+		t.Fatalf("expected codex to be detected again, got %v", installed)
+	}
+
+	if paths := reloaded.TargetPaths(); len(paths) != 0 {
+		t.Fatalf("expected codex path override to be dropped, got %#v", paths)
+	}
+}
+
+func TestSetTargetDisabledKeepsOtherTargetsSettings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"targets":{"disabled":["GeminiCLI"],"other":true}}`), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	if disabled := cfg.DisabledTargets(); strings.Join(disabled, ",") != "geminicli" {
+		t.Fatalf("expected normalized disabled slugs, got %v", disabled)
+	}
+
+	if err := cfg.SetTargetDisabled("codex", true); err != nil {
+		t.Fatalf("expected disable to succeed: %v", err)
+	}
+
+	if err := cfg.SetTargetDisabled("geminicli", false); err != nil {
+		t.Fatalf("expected enable to succeed: %v", err)
+	}
+
+	reloaded, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected reload to succeed: %v", err)
+	}
+
+	if disabled := reloaded.DisabledTargets(); strings.Join(disabled, ",") != "codex" {
+		t.Fatalf("expected only codex disabled, got %v", disabled)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"other": true`) {
+		t.Fatalf("expected unrelated targets settings to be kept, got %s", data)
+	}
+}
+
+func TestTargetGroupsNormalizesNamesAndMembers(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	data := `{"targets":{"groups":{"Editors":["claude"," VSCode ","claude"],"empty":[],"clis":["codex"]}}}`
+	if err := os.WriteFile(configPath, []byte(data), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	groups := cfg.TargetGroups()
+	if len(groups) != 2 {
+		t.Fatalf("expected two non-empty groups, got %#v", groups)
+	}
+
+	if members := strings.Join(groups["editors"], ","); members != "claude,vscode" {
+		t.Fatalf("expected normalized editors members, got %q", members)
+	}
+
+	if members := strings.Join(groups["clis"], ","); members != "codex" {
+		t.Fatalf("expected clis members, got %q", members)
+	}
+}
+
+func TestTargetMergeSettingsAcceptsNamesAndObjects(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	data := `{"targets":{"merge":{"Claude":" Deep-Merge ","vscode":{"strategy":"json-patch","patch":[{"op":"remove","path":"/type"}]},"codex":42}}}`
+	if err := os.WriteFile(configPath, []byte(data), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	settings := cfg.TargetMergeSettings()
+	if len(settings) != 2 {
+		t.Fatalf("expected the malformed codex entry to be skipped, got %#v", settings)
+	}
+
+	if settings["claude"].Strategy != "deep-merge" {
+		t.Fatalf("expected normalized claude strategy, got %#v", settings["claude"])
+	}
+
+	vscode := settings["vscode"]
+	if vscode.Strategy != "json-patch" || !strings.Contains(string(vscode.Patch), `"remove"`) {
+		t.Fatalf("unexpected vscode settings %#v", vscode)
+	}
+}
+
+func TestWebhookDefaultsSecretEnv(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	data := `{"webhook":{"url":" https://hooks.example.com/mcp "}}`
+	if err := os.WriteFile(configPath, []byte(data), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	webhook := cfg.Webhook()
+	if webhook.URL != "https://hooks.example.com/mcp" || webhook.SecretEnv != DefaultWebhookSecretEnv {
+		t.Fatalf("unexpected webhook settings %#v", webhook)
+	}
+
+	if webhook := (&Config{}).Webhook(); webhook.URL != "" {
+		t.Fatalf("expected no webhook by default, got %#v", webhook)
+	}
+}
+
+func TestFeedbackReadsEndpoint(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	data := `{"feedback":{"url":" https://feedback.example.com/mcp-wire "}}`
+	if err := os.WriteFile(configPath, []byte(data), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	if feedback := cfg.Feedback(); feedback.URL != "https://feedback.example.com/mcp-wire" {
+		t.Fatalf("unexpected feedback settings %#v", feedback)
+	}
+
+	if feedback := (&Config{}).Feedback(); feedback.URL != "" {
+		t.Fatalf("expected no feedback endpoint by default, got %#v", feedback)
+	}
+}
+
+func TestRegistriesSkipsInvalidEntries(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	data := `{"registries":[
+		{"name":" Corp ","url":"https://mcp.corp.example","priority":-1},
+		{"name":"corp","url":"https://duplicate.example"},
+		{"name":"../evil","url":"https://evil.example"},
+		{"name":"nourl"},
+		{"name":"official","enabled":false}
+	]}`
+	if err := os.WriteFile(configPath, []byte(data), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	registries := cfg.Registries()
+	if len(registries) != 2 {
+		t.Fatalf("expected two registries, got %#v", registries)
+	}
+
+	if corp := registries[0]; corp.Name != "corp" || corp.URL != "https://mcp.corp.example" || !corp.Enabled || corp.Priority != -1 {
+		t.Fatalf("unexpected corp registry %#v", corp)
+	}
+
+	if official := registries[1]; official.Name != "official" || official.Enabled {
+		t.Fatalf("expected the official registry to be disabled, got %#v", official)
+	}
+}
+
+func TestRegistryCacheTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    time.Duration
+	}{
+		{name: "missing", content: `{}`, want: DefaultRegistryCacheTTL},
+		{name: "duration", content: `{"registry":{"cache_ttl":"12h"}}`, want: 12 * time.Hour},
+		{name: "days", content: `{"registry":{"cache_ttl":"7d"}}`, want: 7 * 24 * time.Hour},
+		{name: "every run", content: `{"registry":{"cache_ttl":"0"}}`, want: 0},
+		{name: "never", content: `{"registry":{"cache_ttl":" Never "}}`, want: RegistryRefreshOff},
+		{name: "negative", content: `{"registry":{"cache_ttl":"-1h"}}`, want: DefaultRegistryCacheTTL},
+		{name: "invalid", content: `{"registry":{"cache_ttl":"soon"}}`, want: DefaultRegistryCacheTTL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			cfg, err := LoadFrom(configPath)
+			if err != nil {
+				t.Fatalf("expected load to succeed: %v", err)
+			}
+
+			if got := cfg.RegistryCacheTTL(); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConfigLimitsFallBackToDefaults(t *testing.T) {
+	cfg, err := LoadFrom(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	if limits := cfg.ConfigLimits(); limits.MaxSizeMB != DefaultConfigMaxSizeMB || limits.MaxProjects != DefaultConfigMaxProjects {
+		t.Fatalf("unexpected default limits: %+v", limits)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"config_limits":{"max_projects":50,"max_size_mb":0}}`), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err = LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	if limits := cfg.ConfigLimits(); limits.MaxSizeMB != DefaultConfigMaxSizeMB || limits.MaxProjects != 50 {
+		t.Fatalf("unexpected limits: %+v", limits)
+	}
+}
+
+func TestOnePasswordSettings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	content := `{"onepassword":{"account":" team.1password.com ","references":{"JIRA_TOKEN":"op://Work/Jira/credential","EMPTY":" "}}}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	settings := cfg.OnePassword()
+	if settings.Account != "team.1password.com" {
+		t.Fatalf("unexpected account %q", settings.Account)
+	}
+
+	if len(settings.References) != 1 || settings.References["JIRA_TOKEN"] != "op://Work/Jira/credential" {
+		t.Fatalf("unexpected references %v", settings.References)
+	}
+}
+
+func TestVaultSettings(t *testing.T) {
+	cfg, err := LoadFrom(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	defaults := cfg.Vault()
+	if defaults.Path != "" || defaults.TokenEnv != "VAULT_TOKEN" || defaults.Field != "{name}" {
+		t.Fatalf("unexpected defaults %+v", defaults)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	content := `{"vault":{"address":" https://vault.example.com ","path":"/kv/mcp-wire/{name}/","field":"value","token_env":"CORP_VAULT_TOKEN"}}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err = LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("expected load to succeed: %v", err)
+	}
+
+	settings := cfg.Vault()
+	want := VaultSettings{Address: "https://vault.example.com", TokenEnv: "CORP_VAULT_TOKEN", Path: "kv/mcp-wire/{name}", Field: "value"}
+	if settings != want {
+		t.Fatalf("expected %+v, got %+v", want, settings)
+	}
+}
@@ -0,0 +1,85 @@
+package credential
+
+import "sync"
+
+// The extension registry lets new credential sources, such as a vault or a
+// password manager CLI, and new policies be added from an init function in
+// their own file. Every resolver and store mcp-wire builds picks them up, so
+// install code paths do not change.
+var extensions struct {
+	mu         sync.RWMutex
+	sources    []func() Source
+	middleware []Middleware
+	policies   []StorePolicy
+}
+
+// RegisterSource adds a source factory. Registered sources are checked after
+// the environment and the OS keychain and before the credentials file, in
+// registration order. A factory may return nil when its backend is not
+// available on this system.
+func RegisterSource(factory func() Source) {
+	if factory == nil {
+		return
+	}
+
+	extensions.mu.Lock()
+	defer extensions.mu.Unlock()
+	extensions.sources = append(extensions.sources, factory)
+}
+
+// RegisterMiddleware adds middleware to every resolver, after the built-in
+// middleware.
+func RegisterMiddleware(middleware Middleware) {
+	if middleware == nil {
+		return
+	}
+
+	extensions.mu.Lock()
+	defer extensions.mu.Unlock()
+	extensions.middleware = append(extensions.middleware, middleware)
+}
+
+// RegisterStorePolicy adds a policy every credential save is checked
+// against.
+func RegisterStorePolicy(policy StorePolicy) {
+	if policy == nil {
+		return
+	}
+
+	extensions.mu.Lock()
+	defer extensions.mu.Unlock()
+	extensions.policies = append(extensions.policies, policy)
+}
+
+// RegisteredSources returns a new instance of every registered source,
+// skipping factories that returned nil.
+func RegisteredSources() []Source {
+	extensions.mu.RLock()
+	factories := append([]func() Source(nil), extensions.sources...)
+	extensions.mu.RUnlock()
+
+	sources := make([]Source, 0, len(factories))
+	for _, factory := range factories {
+		if source := factory(); source != nil {
+			sources = append(sources, source)
+		}
+	}
+
+	return sources
+}
+
+// RegisteredMiddleware returns the registered middleware in order.
+func RegisteredMiddleware() []Middleware {
+	extensions.mu.RLock()
+	defer extensions.mu.RUnlock()
+
+	return append([]Middleware(nil), extensions.middleware...)
+}
+
+// RegisteredStorePolicies returns the registered store policies in order.
+func RegisteredStorePolicies() []StorePolicy {
+	extensions.mu.RLock()
+	defer extensions.mu.RUnlock()
+
+	return append([]StorePolicy(nil), extensions.policies...)
+}
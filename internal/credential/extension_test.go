@@ -0,0 +1,45 @@
+package credential
+
+import "testing"
+
+func resetExtensions(t *testing.T) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		extensions.mu.Lock()
+		defer extensions.mu.Unlock()
+		extensions.sources = nil
+		extensions.middleware = nil
+		extensions.policies = nil
+	})
+}
+
+func TestRegisterSourceSkipsUnavailableBackends(t *testing.T) {
+	resetExtensions(t)
+
+	RegisterSource(func() Source { return fakeSource{name: "vault"} })
+	RegisterSource(func() Source { return nil })
+	RegisterSource(nil)
+
+	sources := RegisteredSources()
+	if len(sources) != 1 || sources[0].Name() != "vault" {
+		t.Fatalf("expected only the vault source, got %v", sources)
+	}
+}
+
+func TestRegisterMiddlewareAndPolicies(t *testing.T) {
+	resetExtensions(t)
+
+	RegisterMiddleware(Cache())
+	RegisterMiddleware(nil)
+	RegisterStorePolicy(DenyPlaintext)
+	RegisterStorePolicy(nil)
+
+	if len(RegisteredMiddleware()) != 1 {
+		t.Fatalf("expected one middleware, got %d", len(RegisteredMiddleware()))
+	}
+
+	if len(RegisteredStorePolicies()) != 1 {
+		t.Fatalf("expected one policy, got %d", len(RegisteredStorePolicies()))
+	}
+}
@@ -37,6 +37,11 @@ func (s *FileSource) Name() string {
 	return fileSourceName
 }
 
+// Plaintext reports that the file keeps values unencrypted.
+func (s *FileSource) Plaintext() bool {
+	return true
+}
+
 // Path returns the on-disk path of the credentials file.
 func (s *FileSource) Path() string {
 	if s == nil {
@@ -66,6 +71,42 @@ func (s *FileSource) Get(envName string) (string, bool) {
 	return value, found
 }
 
+// Count returns how many credentials the file holds. A missing file holds
+// none; an unreadable file is reported as an error.
+func (s *FileSource) Count() (int, error) {
+	if s == nil {
+		return 0, errors.New("file source is nil")
+	}
+
+	entries, err := s.readAll()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(entries), nil
+}
+
+// Names returns the names of the credentials the file holds, sorted. A
+// missing file holds none; an unreadable file is reported as an error.
+func (s *FileSource) Names() ([]string, error) {
+	if s == nil {
+		return nil, errors.New("file source is nil")
+	}
+
+	entries, err := s.readAll()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
 // Store saves or updates a credential in the file.
 func (s *FileSource) Store(envName string, value string) error {
 	if s == nil {
@@ -407,3 +407,68 @@ func TestFileSourceDeleteManyRejectsNilReceiver(t *testing.T) {
 		t.Fatal("expected nil receiver to return error")
 	}
 }
+
+func TestFileSourceCountReportsStoredCredentials(t *testing.T) {
+	source := NewFileSource(filepath.Join(t.TempDir(), "credentials"))
+
+	count, err := source.Count()
+	if err != nil {
+		t.Fatalf("expected missing file to count as empty: %v", err)
+	}
+
+	if count != 0 {
+		t.Fatalf("expected 0 credentials, got %d", count)
+	}
+
+	if err := source.Store("DEMO_TOKEN", "secret"); err != nil {
+		t.Fatalf("store credential: %v", err)
+	}
+
+	if err := source.Store("OTHER_TOKEN", "secret"); err != nil {
+		t.Fatalf("store credential: %v", err)
+	}
+
+	count, err = source.Count()
+	if err != nil {
+		t.Fatalf("count credentials: %v", err)
+	}
+
+	if count != 2 {
+		t.Fatalf("expected 2 credentials, got %d", count)
+	}
+}
+
+func TestFileSourceNamesAreSorted(t *testing.T) {
+	source := NewFileSource(filepath.Join(t.TempDir(), "credentials"))
+
+	names, err := source.Names()
+	if err != nil || len(names) != 0 {
+		t.Fatalf("expected no names for a missing file, got %v (%v)", names, err)
+	}
+
+	for _, name := range []string{"ZETA_TOKEN", "ALPHA_TOKEN"} {
+		if err := source.Store(name, "secret"); err != nil {
+			t.Fatalf("store credential: %v", err)
+		}
+	}
+
+	names, err = source.Names()
+	if err != nil {
+		t.Fatalf("list names: %v", err)
+	}
+
+	if strings.Join(names, ",") != "ALPHA_TOKEN,ZETA_TOKEN" {
+		t.Fatalf("expected sorted names, got %v", names)
+	}
+}
+
+func TestFileSourceCountReturnsErrorWhenUnreadable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.Mkdir(path, 0o700); err != nil {
+		t.Fatalf("create directory in place of file: %v", err)
+	}
+
+	if _, err := NewFileSource(path).Count(); err == nil {
+		t.Fatal("expected error when credentials path is unreadable")
+	}
+}
@@ -0,0 +1,121 @@
+package credential
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	keychainSourceName  = "keychain"
+	keychainServiceName = "mcp-wire"
+)
+
+// errKeychainItemNotFound is returned by keychain backends when no item is
+// stored for an account.
+var errKeychainItemNotFound = errors.New("keychain item not found")
+
+// keychainBackend talks to the credential store of one operating system.
+// Items are addressed by a service name shared by every mcp-wire credential
+// and an account named after the environment variable.
+type keychainBackend interface {
+	available() bool
+	get(service string, account string) (string, error)
+	set(service string, account string, value string) error
+	remove(service string, account string) error
+}
+
+// KeychainSource resolves and stores credentials in the operating system
+// keychain: the macOS Keychain, Windows Credential Manager, or a Secret
+// Service provider such as GNOME Keyring or KWallet on Linux.
+type KeychainSource struct {
+	service string
+	backend keychainBackend
+}
+
+// NewKeychainSource creates a source backed by the keychain of the current
+// operating system. Use Available to check that the keychain can be used
+// before relying on Store.
+func NewKeychainSource() *KeychainSource {
+	return &KeychainSource{service: keychainServiceName, backend: defaultKeychainBackend()}
+}
+
+// Name returns a stable source name.
+func (s *KeychainSource) Name() string {
+	return keychainSourceName
+}
+
+// Available reports whether the keychain can be reached on this system, for
+// example false on a Linux host without a Secret Service provider.
+func (s *KeychainSource) Available() bool {
+	return s != nil && s.backend != nil && s.backend.available()
+}
+
+// Get returns the credential value when the keychain holds one.
+func (s *KeychainSource) Get(envName string) (string, bool) {
+	if !s.Available() {
+		return "", false
+	}
+
+	trimmedName := strings.TrimSpace(envName)
+	if trimmedName == "" {
+		return "", false
+	}
+
+	value, err := s.backend.get(s.service, trimmedName)
+	if err != nil {
+		return "", false
+	}
+
+	return value, true
+}
+
+// Store saves or updates a credential in the keychain.
+func (s *KeychainSource) Store(envName string, value string) error {
+	if !s.Available() {
+		return errors.New("keychain is not available")
+	}
+
+	trimmedName := strings.TrimSpace(envName)
+	if trimmedName == "" {
+		return errors.New("environment variable name is required")
+	}
+
+	return s.backend.set(s.service, trimmedName, value)
+}
+
+// Delete removes a credential from the keychain.
+func (s *KeychainSource) Delete(envName string) error {
+	return s.DeleteMany(envName)
+}
+
+// DeleteMany removes multiple credentials from the keychain. Names without a
+// stored item are ignored.
+func (s *KeychainSource) DeleteMany(envNames ...string) error {
+	if !s.Available() {
+		return errors.New("keychain is not available")
+	}
+
+	for _, rawName := range envNames {
+		name := strings.TrimSpace(rawName)
+		if name == "" {
+			continue
+		}
+
+		if err := s.backend.remove(s.service, name); err != nil && !errors.Is(err, errKeychainItemNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unavailableKeychain is the backend on systems without a supported keychain.
+type unavailableKeychain struct{}
+
+func (unavailableKeychain) available() bool { return false }
+
+func (unavailableKeychain) get(string, string) (string, error) { return "", errKeychainItemNotFound }
+
+func (unavailableKeychain) set(string, string, string) error { return ErrNotSupported }
+
+func (unavailableKeychain) remove(string, string) error { return ErrNotSupported }
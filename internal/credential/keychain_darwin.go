@@ -0,0 +1,80 @@
+package credential
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// securityItemNotFound is the exit status of the security tool when no
+// matching keychain item exists.
+const securityItemNotFound = 44
+
+func defaultKeychainBackend() keychainBackend {
+	return &macOSKeychain{run: runKeychainCommand, lookPath: exec.LookPath}
+}
+
+// macOSKeychain stores generic passwords in the login keychain through the
+// security tool that ships with macOS.
+type macOSKeychain struct {
+	run      keychainCommandRunner
+	lookPath func(file string) (string, error)
+}
+
+func (k *macOSKeychain) available() bool {
+	_, err := k.lookPath("security")
+	return err == nil
+}
+
+func (k *macOSKeychain) get(service string, account string) (string, error) {
+	output, status, err := k.run("", "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	if err != nil {
+		return "", fmt.Errorf("read keychain item %q: %w", account, err)
+	}
+
+	switch status {
+	case 0:
+		return strings.TrimSuffix(output, "\n"), nil
+	case securityItemNotFound:
+		return "", errKeychainItemNotFound
+	default:
+		return "", fmt.Errorf("read keychain item %q: security exited with status %d", account, status)
+	}
+}
+
+// set feeds the command to security on stdin with the value hex-encoded, so
+// the secret never shows up in the process list.
+func (k *macOSKeychain) set(service string, account string, value string) error {
+	if strings.ContainsAny(service+account, "'\n\r") {
+		return fmt.Errorf("store keychain item %q: name contains unsupported characters", account)
+	}
+
+	command := fmt.Sprintf("add-generic-password -U -s '%s' -a '%s' -X %s\n", service, account, hex.EncodeToString([]byte(value)))
+	_, status, err := k.run(command, "security", "-i")
+	if err != nil {
+		return fmt.Errorf("store keychain item %q: %w", account, err)
+	}
+
+	if status != 0 {
+		return fmt.Errorf("store keychain item %q: security exited with status %d", account, status)
+	}
+
+	return nil
+}
+
+func (k *macOSKeychain) remove(service string, account string) error {
+	_, status, err := k.run("", "security", "delete-generic-password", "-s", service, "-a", account)
+	if err != nil {
+		return fmt.Errorf("delete keychain item %q: %w", account, err)
+	}
+
+	switch status {
+	case 0:
+		return nil
+	case securityItemNotFound:
+		return errKeychainItemNotFound
+	default:
+		return fmt.Errorf("delete keychain item %q: security exited with status %d", account, status)
+	}
+}
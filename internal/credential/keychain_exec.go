@@ -0,0 +1,35 @@
+//go:build darwin || linux
+
+package credential
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// keychainCommandRunner runs a keychain helper program with stdin and returns
+// its standard output and exit status. A non-zero exit status is not an
+// error; failing to start the program is.
+type keychainCommandRunner func(stdin string, name string, args ...string) (string, int, error)
+
+func runKeychainCommand(stdin string, name string, args ...string) (string, int, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return stdout.String(), exitErr.ExitCode(), nil
+	}
+
+	if err != nil {
+		return "", -1, err
+	}
+
+	return stdout.String(), 0, nil
+}
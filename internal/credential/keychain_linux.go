@@ -0,0 +1,86 @@
+package credential
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+func defaultKeychainBackend() keychainBackend {
+	return &secretServiceKeychain{run: runKeychainCommand, lookPath: exec.LookPath, getenv: os.Getenv, statPath: os.Stat}
+}
+
+// secretServiceKeychain stores secrets through the Secret Service D-Bus API
+// (GNOME Keyring, KWallet, KeePassXC) using secret-tool from libsecret.
+type secretServiceKeychain struct {
+	run      keychainCommandRunner
+	lookPath func(file string) (string, error)
+	getenv   func(key string) string
+	statPath func(name string) (os.FileInfo, error)
+}
+
+// available requires secret-tool and a session bus to reach the provider
+// through. Headless servers and containers usually have neither.
+func (k *secretServiceKeychain) available() bool {
+	if _, err := k.lookPath("secret-tool"); err != nil {
+		return false
+	}
+
+	if k.getenv("DBUS_SESSION_BUS_ADDRESS") != "" {
+		return true
+	}
+
+	runtimeDir := k.getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		return false
+	}
+
+	_, err := k.statPath(filepath.Join(runtimeDir, "bus"))
+	return err == nil
+}
+
+func (k *secretServiceKeychain) get(service string, account string) (string, error) {
+	output, status, err := k.run("", "secret-tool", "lookup", "service", service, "account", account)
+	if err != nil {
+		return "", fmt.Errorf("read keychain item %q: %w", account, err)
+	}
+
+	// secret-tool exits with status 1 both when nothing matches and when the
+	// provider cannot be reached; either way there is no value to use.
+	if status != 0 {
+		return "", errKeychainItemNotFound
+	}
+
+	return strings.TrimSuffix(output, "\n"), nil
+}
+
+// set passes the value on stdin, so the secret never shows up in the process
+// list.
+func (k *secretServiceKeychain) set(service string, account string, value string) error {
+	label := fmt.Sprintf("%s: %s", service, account)
+	_, status, err := k.run(value, "secret-tool", "store", "--label="+label, "service", service, "account", account)
+	if err != nil {
+		return fmt.Errorf("store keychain item %q: %w", account, err)
+	}
+
+	if status != 0 {
+		return fmt.Errorf("store keychain item %q: secret-tool exited with status %d", account, status)
+	}
+
+	return nil
+}
+
+func (k *secretServiceKeychain) remove(service string, account string) error {
+	_, status, err := k.run("", "secret-tool", "clear", "service", service, "account", account)
+	if err != nil {
+		return fmt.Errorf("delete keychain item %q: %w", account, err)
+	}
+
+	if status != 0 {
+		return fmt.Errorf("delete keychain item %q: secret-tool exited with status %d", account, status)
+	}
+
+	return nil
+}
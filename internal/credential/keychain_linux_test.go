@@ -0,0 +1,88 @@
+package credential
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type recordedKeychainCommand struct {
+	stdin string
+	args  []string
+}
+
+func newTestSecretServiceKeychain(output string, status int) (*secretServiceKeychain, *[]recordedKeychainCommand) {
+	var commands []recordedKeychainCommand
+	keychain := &secretServiceKeychain{
+		run: func(stdin string, name string, args ...string) (string, int, error) {
+			commands = append(commands, recordedKeychainCommand{stdin: stdin, args: append([]string{name}, args...)})
+			return output, status, nil
+		},
+		lookPath: func(string) (string, error) { return "/usr/bin/secret-tool", nil },
+		getenv:   func(string) string { return "" },
+		statPath: func(string) (os.FileInfo, error) { return nil, os.ErrNotExist },
+	}
+
+	return keychain, &commands
+}
+
+func TestSecretServiceKeychainStorePassesValueOnStdin(t *testing.T) {
+	keychain, commands := newTestSecretServiceKeychain("", 0)
+
+	if err := keychain.set("mcp-wire", "DEMO_TOKEN", "super-secret"); err != nil {
+		t.Fatalf("expected store to succeed: %v", err)
+	}
+
+	command := (*commands)[0]
+	if command.stdin != "super-secret" {
+		t.Fatalf("expected value on stdin, got %q", command.stdin)
+	}
+
+	if strings.Contains(strings.Join(command.args, " "), "super-secret") {
+		t.Fatalf("expected value to stay out of the arguments: %v", command.args)
+	}
+
+	want := []string{"secret-tool", "store", "--label=mcp-wire: DEMO_TOKEN", "service", "mcp-wire", "account", "DEMO_TOKEN"}
+	if !slices.Equal(command.args, want) {
+		t.Fatalf("unexpected arguments: %v", command.args)
+	}
+}
+
+func TestSecretServiceKeychainLookup(t *testing.T) {
+	keychain, _ := newTestSecretServiceKeychain("super-secret\n", 0)
+
+	value, err := keychain.get("mcp-wire", "DEMO_TOKEN")
+	if err != nil || value != "super-secret" {
+		t.Fatalf("expected stored value, got %q (err=%v)", value, err)
+	}
+
+	missing, _ := newTestSecretServiceKeychain("", 1)
+	if _, err := missing.get("mcp-wire", "DEMO_TOKEN"); !errors.Is(err, errKeychainItemNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestSecretServiceKeychainNeedsSessionBus(t *testing.T) {
+	keychain, _ := newTestSecretServiceKeychain("", 0)
+
+	if keychain.available() {
+		t.Fatal("expected keychain without a session bus to be unavailable")
+	}
+
+	keychain.getenv = func(key string) string {
+		if key == "DBUS_SESSION_BUS_ADDRESS" {
+			return "unix:path=/run/user/1000/bus"
+		}
+		return ""
+	}
+	if !keychain.available() {
+		t.Fatal("expected keychain with a session bus to be available")
+	}
+
+	keychain.lookPath = func(string) (string, error) { return "", errors.New("not found") }
+	if keychain.available() {
+		t.Fatal("expected keychain without secret-tool to be unavailable")
+	}
+}
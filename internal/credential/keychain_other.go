@@ -0,0 +1,7 @@
+//go:build !darwin && !linux && !windows
+
+package credential
+
+func defaultKeychainBackend() keychainBackend {
+	return unavailableKeychain{}
+}
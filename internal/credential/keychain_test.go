@@ -0,0 +1,135 @@
+package credential
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeKeychain struct {
+	unavailable bool
+	items       map[string]string
+	removeErr   error
+}
+
+func (k *fakeKeychain) available() bool {
+	return !k.unavailable
+}
+
+func (k *fakeKeychain) get(service string, account string) (string, error) {
+	value, found := k.items[service+"/"+account]
+	if !found {
+		return "", errKeychainItemNotFound
+	}
+
+	return value, nil
+}
+
+func (k *fakeKeychain) set(service string, account string, value string) error {
+	k.items[service+"/"+account] = value
+	return nil
+}
+
+func (k *fakeKeychain) remove(service string, account string) error {
+	if k.removeErr != nil {
+		return k.removeErr
+	}
+
+	if _, found := k.items[service+"/"+account]; !found {
+		return errKeychainItemNotFound
+	}
+
+	delete(k.items, service+"/"+account)
+	return nil
+}
+
+func newTestKeychainSource() (*KeychainSource, *fakeKeychain) {
+	backend := &fakeKeychain{items: map[string]string{}}
+	return &KeychainSource{service: keychainServiceName, backend: backend}, backend
+}
+
+func TestKeychainSourceName(t *testing.T) {
+	source, _ := newTestKeychainSource()
+
+	if source.Name() != "keychain" {
+		t.Fatalf("expected source name keychain, got %q", source.Name())
+	}
+}
+
+func TestKeychainSourceStoreAndGet(t *testing.T) {
+	source, backend := newTestKeychainSource()
+
+	if err := source.Store(" DEMO_TOKEN ", "secret"); err != nil {
+		t.Fatalf("expected store to succeed: %v", err)
+	}
+
+	if _, found := backend.items["mcp-wire/DEMO_TOKEN"]; !found {
+		t.Fatalf("expected item under the mcp-wire service, got %v", backend.items)
+	}
+
+	value, found := source.Get("DEMO_TOKEN")
+	if !found || value != "secret" {
+		t.Fatalf("expected stored value, got %q (found=%v)", value, found)
+	}
+
+	if _, found := source.Get("OTHER_TOKEN"); found {
+		t.Fatal("expected missing item to return not found")
+	}
+}
+
+func TestKeychainSourceStoreRequiresName(t *testing.T) {
+	source, _ := newTestKeychainSource()
+
+	if err := source.Store("  ", "secret"); err == nil {
+		t.Fatal("expected error for empty name")
+	}
+}
+
+func TestKeychainSourceUnavailable(t *testing.T) {
+	source, backend := newTestKeychainSource()
+	backend.unavailable = true
+	backend.items["mcp-wire/DEMO_TOKEN"] = "secret"
+
+	if source.Available() {
+		t.Fatal("expected keychain to be unavailable")
+	}
+
+	if _, found := source.Get("DEMO_TOKEN"); found {
+		t.Fatal("expected unavailable keychain to return not found")
+	}
+
+	if err := source.Store("DEMO_TOKEN", "other"); err == nil {
+		t.Fatal("expected store to fail when keychain is unavailable")
+	}
+
+	var nilSource *KeychainSource
+	if nilSource.Available() {
+		t.Fatal("expected nil source to be unavailable")
+	}
+}
+
+func TestKeychainSourceDeleteManyIgnoresMissingItems(t *testing.T) {
+	source, backend := newTestKeychainSource()
+	backend.items["mcp-wire/TOKEN_A"] = "a"
+	backend.items["mcp-wire/TOKEN_B"] = "b"
+
+	if err := source.DeleteMany("TOKEN_A", "TOKEN_C", ""); err != nil {
+		t.Fatalf("expected delete to succeed: %v", err)
+	}
+
+	if _, found := backend.items["mcp-wire/TOKEN_A"]; found {
+		t.Fatal("expected TOKEN_A to be removed")
+	}
+
+	if _, found := backend.items["mcp-wire/TOKEN_B"]; !found {
+		t.Fatal("expected TOKEN_B to remain")
+	}
+}
+
+func TestKeychainSourceDeleteManyReturnsBackendError(t *testing.T) {
+	source, backend := newTestKeychainSource()
+	backend.removeErr = errors.New("locked")
+
+	if err := source.Delete("TOKEN_A"); err == nil {
+		t.Fatal("expected backend error to be returned")
+	}
+}
@@ -0,0 +1,123 @@
+package credential
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+var (
+	advapi32        = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW   = advapi32.NewProc("CredReadW")
+	procCredWriteW  = advapi32.NewProc("CredWriteW")
+	procCredDeleteW = advapi32.NewProc("CredDeleteW")
+	procCredFree    = advapi32.NewProc("CredFree")
+)
+
+// winCredential mirrors the CREDENTIALW structure.
+type winCredential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+func defaultKeychainBackend() keychainBackend {
+	return windowsCredentialManager{}
+}
+
+// windowsCredentialManager stores generic credentials in Windows Credential
+// Manager, named "<service>:<account>".
+type windowsCredentialManager struct{}
+
+func (windowsCredentialManager) available() bool {
+	return procCredReadW.Find() == nil
+}
+
+func (windowsCredentialManager) get(service string, account string) (string, error) {
+	targetName, err := syscall.UTF16PtrFromString(service + ":" + account)
+	if err != nil {
+		return "", err
+	}
+
+	var cred *winCredential
+	result, _, callErr := procCredReadW.Call(uintptr(unsafe.Pointer(targetName)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if result == 0 {
+		if errors.Is(callErr, errorNotFound) {
+			return "", errKeychainItemNotFound
+		}
+
+		return "", fmt.Errorf("read credential %q: %w", account, callErr)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	if cred.CredentialBlobSize == 0 {
+		return "", nil
+	}
+
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+func (windowsCredentialManager) set(service string, account string, value string) error {
+	targetName, err := syscall.UTF16PtrFromString(service + ":" + account)
+	if err != nil {
+		return err
+	}
+
+	userName, err := syscall.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+
+	blob := []byte(value)
+	cred := winCredential{
+		Type:               credTypeGeneric,
+		TargetName:         targetName,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           userName,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+
+	result, _, callErr := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if result == 0 {
+		return fmt.Errorf("store credential %q: %w", account, callErr)
+	}
+
+	return nil
+}
+
+func (windowsCredentialManager) remove(service string, account string) error {
+	targetName, err := syscall.UTF16PtrFromString(service + ":" + account)
+	if err != nil {
+		return err
+	}
+
+	result, _, callErr := procCredDeleteW.Call(uintptr(unsafe.Pointer(targetName)), credTypeGeneric, 0)
+	if result == 0 {
+		if errors.Is(callErr, errorNotFound) {
+			return errKeychainItemNotFound
+		}
+
+		return fmt.Errorf("delete credential %q: %w", account, callErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package credential
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Lookup resolves one credential name to its value and the name of the
+// source it came from.
+type Lookup func(envName string) (value string, source string, found bool)
+
+// Middleware wraps a Lookup. It can change or reject what the rest of the
+// chain resolves, answer without calling it, or just observe the result.
+type Middleware func(next Lookup) Lookup
+
+// Validator checks a resolved value. A non-nil error rejects it.
+type Validator func(envName string, value string) error
+
+// Validate returns middleware that treats values rejected by any validator
+// as not found, so callers fall back to prompting. onReject, when set, is
+// told which credential was rejected and why; it never receives the value.
+func Validate(onReject func(envName string, source string, err error), validators ...Validator) Middleware {
+	return func(next Lookup) Lookup {
+		return func(envName string) (string, string, bool) {
+			value, source, found := next(envName)
+			if !found {
+				return "", "", false
+			}
+
+			for _, validate := range validators {
+				if err := validate(envName, value); err != nil {
+					if onReject != nil {
+						onReject(envName, source, err)
+					}
+
+					return "", "", false
+				}
+			}
+
+			return value, source, true
+		}
+	}
+}
+
+// Cache returns middleware that remembers every credential it resolved, so
+// sources such as the keychain are asked once per name however many services
+// or projects need it. Misses are not cached.
+func Cache() Middleware {
+	type cached struct {
+		value  string
+		source string
+	}
+
+	var mu sync.Mutex
+	entries := make(map[string]cached)
+
+	return func(next Lookup) Lookup {
+		return func(envName string) (string, string, bool) {
+			mu.Lock()
+			entry, ok := entries[envName]
+			mu.Unlock()
+			if ok {
+				return entry.value, entry.source, true
+			}
+
+			value, source, found := next(envName)
+			if found {
+				mu.Lock()
+				entries[envName] = cached{value: value, source: source}
+				mu.Unlock()
+			}
+
+			return value, source, found
+		}
+	}
+}
+
+// Redactor remembers the values a resolver handed out and masks them in text
+// meant for the screen or logs, such as error messages from a tool that
+// echoed its arguments.
+type Redactor struct {
+	mu     sync.Mutex
+	values map[string]struct{}
+}
+
+// minRedactedLen keeps very short values from being masked inside unrelated
+// words.
+const minRedactedLen = 4
+
+// Middleware returns middleware that records every resolved value.
+func (r *Redactor) Middleware() Middleware {
+	return func(next Lookup) Lookup {
+		return func(envName string) (string, string, bool) {
+			value, source, found := next(envName)
+			if found && len(value) >= minRedactedLen {
+				r.mu.Lock()
+				if r.values == nil {
+					r.values = make(map[string]struct{})
+				}
+				r.values[value] = struct{}{}
+				r.mu.Unlock()
+			}
+
+			return value, source, found
+		}
+	}
+}
+
+// Redact replaces every recorded value in text with a fixed mask. Longer
+// values are replaced first so a value containing another is masked whole.
+func (r *Redactor) Redact(text string) string {
+	if r == nil {
+		return text
+	}
+
+	r.mu.Lock()
+	values := make([]string, 0, len(r.values))
+	for value := range r.values {
+		values = append(values, value)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, value := range values {
+		text = strings.ReplaceAll(text, value, maskedPlaceholder)
+	}
+
+	return text
+}
+
+// Wrap returns err with recorded values masked in its message. errors.Is and
+// errors.As still see the original error.
+func (r *Redactor) Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &redactedError{err: err, message: r.Redact(err.Error())}
+}
+
+type redactedError struct {
+	err     error
+	message string
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
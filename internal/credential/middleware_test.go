@@ -0,0 +1,122 @@
+package credential
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+type countingSource struct {
+	fakeSource
+	gets int
+}
+
+func (s *countingSource) Get(envName string) (string, bool) {
+	s.gets++
+	return s.fakeSource.Get(envName)
+}
+
+func TestResolverRunsMiddlewareInOrder(t *testing.T) {
+	resolver := NewResolver(fakeSource{name: "file", values: map[string]string{"DEMO_TOKEN": "value"}})
+
+	var calls []string
+	tag := func(name string) Middleware {
+		return func(next Lookup) Lookup {
+			return func(envName string) (string, string, bool) {
+				calls = append(calls, name+">")
+				value, source, found := next(envName)
+				calls = append(calls, "<"+name)
+				return value, source, found
+			}
+		}
+	}
+	resolver.Use(tag("outer"), nil, tag("inner"))
+
+	value, source, found := resolver.Resolve(" DEMO_TOKEN ")
+	if !found || value != "value" || source != "file" {
+		t.Fatalf("unexpected result %q from %q (found=%v)", value, source, found)
+	}
+
+	if got := strings.Join(calls, " "); got != "outer> inner> <inner <outer" {
+		t.Fatalf("unexpected middleware order: %s", got)
+	}
+}
+
+func TestValidateRejectsValues(t *testing.T) {
+	resolver := NewResolver(fakeSource{name: "environment", values: map[string]string{"BLANK": "  ", "GOOD": "token"}})
+
+	var rejected []string
+	nonBlank := func(_ string, value string) error {
+		if strings.TrimSpace(value) == "" {
+			return errors.New("value is blank")
+		}
+		return nil
+	}
+	resolver.Use(Validate(func(envName string, source string, err error) {
+		rejected = append(rejected, envName+"@"+source+": "+err.Error())
+	}, nonBlank))
+
+	if _, _, found := resolver.Resolve("BLANK"); found {
+		t.Fatal("expected blank value to be rejected")
+	}
+
+	if value, _, found := resolver.Resolve("GOOD"); !found || value != "token" {
+		t.Fatalf("expected valid value to pass, got %q (found=%v)", value, found)
+	}
+
+	if len(rejected) != 1 || rejected[0] != "BLANK@environment: value is blank" {
+		t.Fatalf("unexpected rejections: %v", rejected)
+	}
+}
+
+func TestCacheAsksSourcesOncePerName(t *testing.T) {
+	source := &countingSource{fakeSource: fakeSource{name: "keychain", values: map[string]string{"DEMO_TOKEN": "value"}}}
+	resolver := NewResolver(source)
+	resolver.Use(Cache())
+
+	for range 3 {
+		if value, origin, found := resolver.Resolve("DEMO_TOKEN"); !found || value != "value" || origin != "keychain" {
+			t.Fatalf("unexpected cached result %q from %q", value, origin)
+		}
+	}
+
+	resolver.Resolve("MISSING")
+	resolver.Resolve("MISSING")
+
+	if source.gets != 3 {
+		t.Fatalf("expected one lookup for the hit and one per miss, got %d", source.gets)
+	}
+}
+
+func TestRedactorMasksResolvedValues(t *testing.T) {
+	var redactor Redactor
+	resolver := NewResolver(fakeSource{name: "file", values: map[string]string{
+		"LONG":  "secret-token-123",
+		"SHORT": "ab",
+		"INNER": "token",
+	}})
+	resolver.Use(redactor.Middleware())
+
+	resolver.Resolve("LONG")
+	resolver.Resolve("SHORT")
+	resolver.Resolve("INNER")
+
+	got := redactor.Redact("npx failed: --key secret-token-123 and ab")
+	if got != "npx failed: --key ******** and ab" {
+		t.Fatalf("unexpected redaction %q", got)
+	}
+
+	err := redactor.Wrap(&os.PathError{Op: "open", Path: "/tmp/secret-token-123", Err: os.ErrNotExist})
+	if strings.Contains(err.Error(), "secret-token-123") {
+		t.Fatalf("expected wrapped error to be redacted, got %q", err)
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("expected wrapped error to keep its chain")
+	}
+
+	if redactor.Wrap(nil) != nil {
+		t.Fatal("expected nil error to stay nil")
+	}
+}
@@ -0,0 +1,116 @@
+package credential
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+)
+
+const onePasswordBinaryName = "op"
+
+// OnePasswordSource resolves credentials from 1Password through the op CLI.
+// Each env var is mapped to a secret reference: "op://vault/item/field" is
+// read with `op read`, and "item/field" or "vault/item/field" with
+// `op item get`. Values stay in 1Password; this source never stores them.
+type OnePasswordSource struct {
+	account    string
+	references map[string]string
+	lookPath   func(file string) (string, error)
+	run        func(name string, args ...string) (string, error)
+}
+
+// NewOnePasswordSource creates a source reading the given references, keyed
+// by env var name, from account. An empty account uses the op CLI default.
+func NewOnePasswordSource(account string, references map[string]string) *OnePasswordSource {
+	return &OnePasswordSource{
+		account:    strings.TrimSpace(account),
+		references: references,
+		lookPath:   exec.LookPath,
+		run:        runOnePasswordCommand,
+	}
+}
+
+// Name returns a stable source name.
+func (s *OnePasswordSource) Name() string {
+	return "1password"
+}
+
+// Get reads the secret the env var is mapped to. An unmapped name, a missing
+// op CLI, a locked or signed-out account, and an empty secret all count as
+// not found, so resolution moves on to the next source.
+func (s *OnePasswordSource) Get(envName string) (string, bool) {
+	reference, ok := s.references[strings.TrimSpace(envName)]
+	if !ok {
+		return "", false
+	}
+
+	args := onePasswordReadArgs(reference)
+	if args == nil {
+		return "", false
+	}
+
+	if _, err := s.lookPath(onePasswordBinaryName); err != nil {
+		return "", false
+	}
+
+	if s.account != "" {
+		args = append(args, "--account", s.account)
+	}
+
+	value, err := s.run(onePasswordBinaryName, args...)
+	if err != nil {
+		return "", false
+	}
+
+	value = strings.TrimSuffix(value, "\n")
+	if value == "" {
+		return "", false
+	}
+
+	return value, true
+}
+
+// Store is not supported: secrets are managed in 1Password.
+func (s *OnePasswordSource) Store(_ string, _ string) error {
+	return ErrNotSupported
+}
+
+// onePasswordReadArgs returns the op arguments that print the secret a
+// reference points at, or nil when the reference is malformed.
+func onePasswordReadArgs(reference string) []string {
+	reference = strings.TrimSpace(reference)
+	if strings.HasPrefix(reference, "op://") {
+		return []string{"read", "--no-newline", reference}
+	}
+
+	parts := strings.Split(reference, "/")
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return nil
+		}
+	}
+
+	switch len(parts) {
+	case 2:
+		return []string{"item", "get", parts[0], "--fields", "label=" + parts[1], "--reveal"}
+	case 3:
+		return []string{"item", "get", parts[1], "--vault", parts[0], "--fields", "label=" + parts[2], "--reveal"}
+	default:
+		return nil
+	}
+}
+
+// runOnePasswordCommand runs op and returns its standard output. op prints
+// errors, such as a signed-out account, on stderr, which is left alone.
+func runOnePasswordCommand(name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,105 @@
+package credential
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newTestOnePasswordSource(account string, references map[string]string, output string, runErr error) (*OnePasswordSource, *[]string) {
+	var calledArgs []string
+
+	source := NewOnePasswordSource(account, references)
+	source.lookPath = func(string) (string, error) { return "/usr/local/bin/op", nil }
+	source.run = func(_ string, args ...string) (string, error) {
+		calledArgs = args
+		return output, runErr
+	}
+
+	return source, &calledArgs
+}
+
+func TestOnePasswordSourceGetReadsSecretReference(t *testing.T) {
+	source, calledArgs := newTestOnePasswordSource("my.1password.com", map[string]string{
+		"JIRA_TOKEN": "op://Work/Jira/credential",
+	}, "secret\n", nil)
+
+	value, found := source.Get("JIRA_TOKEN")
+	if !found || value != "secret" {
+		t.Fatalf("expected secret to be found, got %q found=%v", value, found)
+	}
+
+	want := []string{"read", "--no-newline", "op://Work/Jira/credential", "--account", "my.1password.com"}
+	if !reflect.DeepEqual(*calledArgs, want) {
+		t.Fatalf("expected args %v, got %v", want, *calledArgs)
+	}
+}
+
+func TestOnePasswordSourceGetReadsItemField(t *testing.T) {
+	tests := []struct {
+		reference string
+		want      []string
+	}{
+		{reference: "Jira/credential", want: []string{"item", "get", "Jira", "--fields", "label=credential", "--reveal"}},
+		{reference: "Work/Jira/credential", want: []string{"item", "get", "Jira", "--vault", "Work", "--fields", "label=credential", "--reveal"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			source, calledArgs := newTestOnePasswordSource("", map[string]string{"JIRA_TOKEN": tt.reference}, "secret", nil)
+
+			if _, found := source.Get("JIRA_TOKEN"); !found {
+				t.Fatal("expected secret to be found")
+			}
+
+			if !reflect.DeepEqual(*calledArgs, tt.want) {
+				t.Fatalf("expected args %v, got %v", tt.want, *calledArgs)
+			}
+		})
+	}
+}
+
+func TestOnePasswordSourceGetNotFound(t *testing.T) {
+	tests := []struct {
+		name       string
+		references map[string]string
+		output     string
+		runErr     error
+	}{
+		{name: "unmapped", references: map[string]string{}, output: "secret"},
+		{name: "malformed reference", references: map[string]string{"JIRA_TOKEN": "credential"}, output: "secret"},
+		{name: "command fails", references: map[string]string{"JIRA_TOKEN": "op://Work/Jira/credential"}, runErr: errors.New("not signed in")},
+		{name: "empty secret", references: map[string]string{"JIRA_TOKEN": "op://Work/Jira/credential"}, output: "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, _ := newTestOnePasswordSource("", tt.references, tt.output, tt.runErr)
+
+			if value, found := source.Get("JIRA_TOKEN"); found {
+				t.Fatalf("expected no value, got %q", value)
+			}
+		})
+	}
+}
+
+func TestOnePasswordSourceGetWithoutCLI(t *testing.T) {
+	source, calledArgs := newTestOnePasswordSource("", map[string]string{"JIRA_TOKEN": "op://Work/Jira/credential"}, "secret", nil)
+	source.lookPath = func(string) (string, error) { return "", errors.New("not found") }
+
+	if _, found := source.Get("JIRA_TOKEN"); found {
+		t.Fatal("expected no value without the op CLI")
+	}
+
+	if *calledArgs != nil {
+		t.Fatalf("expected op not to run, got %v", *calledArgs)
+	}
+}
+
+func TestOnePasswordSourceStoreNotSupported(t *testing.T) {
+	source := NewOnePasswordSource("", nil)
+
+	if err := source.Store("JIRA_TOKEN", "secret"); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("expected ErrNotSupported, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package credential
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPlaintextDenied is returned when a store policy refuses to save a
+// credential to a source that keeps it in plaintext.
+var ErrPlaintextDenied = errors.New("saving credentials in plaintext is not allowed")
+
+// PlaintextSource is implemented by sources that can report whether they
+// keep values unencrypted on disk.
+type PlaintextSource interface {
+	Plaintext() bool
+}
+
+// StorePolicy decides whether a credential may be saved to source. A non-nil
+// error refuses the save.
+type StorePolicy func(source Source, envName string) error
+
+// DenyPlaintext is a StorePolicy that refuses sources keeping values in
+// plaintext, such as the credentials file.
+func DenyPlaintext(source Source, _ string) error {
+	if plaintext, ok := source.(PlaintextSource); ok && plaintext.Plaintext() {
+		return fmt.Errorf("%w (%s source)", ErrPlaintextDenied, source.Name())
+	}
+
+	return nil
+}
+
+// GuardStore returns source with every Store checked against policies first.
+// Lookups are passed through unchanged. Without policies, source is returned
+// as is.
+func GuardStore(source Source, policies ...StorePolicy) Source {
+	if source == nil || len(policies) == 0 {
+		return source
+	}
+
+	return &guardedSource{Source: source, policies: policies}
+}
+
+type guardedSource struct {
+	Source
+	policies []StorePolicy
+}
+
+func (s *guardedSource) Store(envName string, value string) error {
+	for _, policy := range s.policies {
+		if err := policy(s.Source, envName); err != nil {
+			return err
+		}
+	}
+
+	return s.Source.Store(envName, value)
+}
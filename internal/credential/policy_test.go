@@ -0,0 +1,46 @@
+package credential
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestDenyPlaintextRefusesFileSource(t *testing.T) {
+	fileSource := NewFileSource(filepath.Join(t.TempDir(), "credentials"))
+	guarded := GuardStore(fileSource, DenyPlaintext)
+
+	err := guarded.Store("DEMO_TOKEN", "secret")
+	if !errors.Is(err, ErrPlaintextDenied) {
+		t.Fatalf("expected plaintext denial, got %v", err)
+	}
+
+	if _, found := fileSource.Get("DEMO_TOKEN"); found {
+		t.Fatal("expected nothing to be written")
+	}
+
+	if guarded.Name() != "file" {
+		t.Fatalf("expected guarded source to keep its name, got %q", guarded.Name())
+	}
+}
+
+func TestGuardStoreAllowsEncryptedSources(t *testing.T) {
+	keychain, backend := newTestKeychainSource()
+	guarded := GuardStore(keychain, DenyPlaintext)
+
+	if err := guarded.Store("DEMO_TOKEN", "secret"); err != nil {
+		t.Fatalf("expected keychain store to be allowed: %v", err)
+	}
+
+	if backend.items["mcp-wire/DEMO_TOKEN"] != "secret" {
+		t.Fatalf("expected value in keychain, got %v", backend.items)
+	}
+}
+
+func TestGuardStoreWithoutPoliciesReturnsSource(t *testing.T) {
+	fileSource := NewFileSource(filepath.Join(t.TempDir(), "credentials"))
+
+	if GuardStore(fileSource) != Source(fileSource) {
+		t.Fatal("expected source to be returned unchanged")
+	}
+}
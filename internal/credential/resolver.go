@@ -15,9 +15,12 @@ type Source interface {
 	Store(envName string, value string) error
 }
 
-// Resolver resolves credentials by checking sources in order.
+// Resolver resolves credentials by checking sources in order. Middleware
+// added with Use wraps the lookup, for example to validate, cache, or record
+// resolved values.
 type Resolver struct {
-	sources []Source
+	sources    []Source
+	middleware []Middleware
 }
 
 // NewResolver creates a resolver with a fixed source order.
@@ -28,7 +31,21 @@ func NewResolver(sources ...Source) *Resolver {
 	return &Resolver{sources: resolverSources}
 }
 
-// Resolve tries each source in order.
+// Use appends middleware to the lookup chain. Middleware added first runs
+// first and sees the result of everything added after it.
+func (r *Resolver) Use(middleware ...Middleware) {
+	if r == nil {
+		return
+	}
+
+	for _, mw := range middleware {
+		if mw != nil {
+			r.middleware = append(r.middleware, mw)
+		}
+	}
+}
+
+// Resolve tries each source in order, through the middleware chain.
 //
 // It returns the value, source name, and whether a value was found.
 func (r *Resolver) Resolve(envName string) (value string, source string, found bool) {
@@ -41,12 +58,21 @@ func (r *Resolver) Resolve(envName string) (value string, source string, found b
 		return "", "", false
 	}
 
+	lookup := r.lookupSources
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		lookup = r.middleware[i](lookup)
+	}
+
+	return lookup(trimmedName)
+}
+
+func (r *Resolver) lookupSources(envName string) (string, string, bool) {
 	for _, src := range r.sources {
 		if src == nil {
 			continue
 		}
 
-		resolvedValue, ok := src.Get(trimmedName)
+		resolvedValue, ok := src.Get(envName)
 		if !ok {
 			continue
 		}
@@ -0,0 +1,182 @@
+package credential
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const vaultRequestTimeout = 10 * time.Second
+
+// VaultOptions configures a VaultSource. Path and Field are templates in
+// which "{name}" stands for the env var being resolved.
+type VaultOptions struct {
+	// Address is the Vault server URL. Empty uses VAULT_ADDR.
+	Address string
+	// Namespace is sent as X-Vault-Namespace when set.
+	Namespace string
+	// TokenEnv names the env var holding the token. When it is empty or
+	// unset, the token the vault CLI saved in ~/.vault-token is used.
+	TokenEnv string
+	// Path is the secret path under /v1/, for a KV v1 or v2 engine.
+	Path string
+	// Field is the key read from the secret data.
+	Field string
+}
+
+// VaultSource resolves credentials from a HashiCorp Vault KV secrets engine
+// over its HTTP API. Each secret is read once per process and shared by the
+// credentials it holds. Values stay in Vault; this source never stores them.
+type VaultSource struct {
+	options VaultOptions
+	client  *http.Client
+
+	mu      sync.Mutex
+	secrets map[string]map[string]any
+}
+
+// NewVaultSource creates a source reading from Vault with options.
+func NewVaultSource(options VaultOptions) *VaultSource {
+	return &VaultSource{
+		options: options,
+		client:  &http.Client{Timeout: vaultRequestTimeout},
+		secrets: make(map[string]map[string]any),
+	}
+}
+
+// Name returns a stable source name.
+func (s *VaultSource) Name() string {
+	return "vault"
+}
+
+// Get reads the credential from the secret and field its templates point
+// at. A missing address or token, an unreachable server, a denied or missing
+// secret, and a field that is absent or not a string all count as not found,
+// so resolution moves on to the next source.
+func (s *VaultSource) Get(envName string) (string, bool) {
+	envName = strings.TrimSpace(envName)
+	if envName == "" || strings.TrimSpace(s.options.Path) == "" {
+		return "", false
+	}
+
+	secretPath := strings.Trim(expandVaultTemplate(s.options.Path, envName), "/")
+	field := expandVaultTemplate(s.options.Field, envName)
+	if field == "" {
+		field = envName
+	}
+
+	data := s.secret(secretPath)
+
+	value, ok := data[field].(string)
+	if !ok || value == "" {
+		return "", false
+	}
+
+	return value, true
+}
+
+// Store is not supported: secrets are managed in Vault.
+func (s *VaultSource) Store(_ string, _ string) error {
+	return ErrNotSupported
+}
+
+// secret returns the data of the secret at secretPath, reading it on first
+// use. A secret that cannot be read is remembered as empty, so a down server
+// costs one timeout rather than one per credential.
+func (s *VaultSource) secret(secretPath string) map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if data, ok := s.secrets[secretPath]; ok {
+		return data
+	}
+
+	// The reason is dropped: a lookup only reports found or not found.
+	data, _ := s.readSecret(secretPath)
+	s.secrets[secretPath] = data
+	return data
+}
+
+func (s *VaultSource) readSecret(secretPath string) (map[string]any, error) {
+	address := strings.TrimSpace(s.options.Address)
+	if address == "" {
+		address = strings.TrimSpace(os.Getenv("VAULT_ADDR"))
+	}
+	if address == "" {
+		return nil, errors.New("no Vault address")
+	}
+
+	token := s.token()
+	if token == "" {
+		return nil, errors.New("no Vault token")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(address, "/")+"/v1/"+secretPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := strings.TrimSpace(s.options.Namespace); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("read secret %q: unexpected status %s", secretPath, resp.Status)
+	}
+
+	var body struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode secret %q: %w", secretPath, err)
+	}
+
+	// KV v2 nests the secret under data.data, next to its metadata.
+	if inner, ok := body.Data["data"].(map[string]any); ok {
+		if _, versioned := body.Data["metadata"]; versioned {
+			return inner, nil
+		}
+	}
+
+	return body.Data, nil
+}
+
+// token returns the Vault token from the configured env var, falling back to
+// the one `vault login` saved.
+func (s *VaultSource) token() string {
+	tokenEnv := strings.TrimSpace(s.options.TokenEnv)
+	if tokenEnv != "" {
+		if token := strings.TrimSpace(os.Getenv(tokenEnv)); token != "" {
+			return token
+		}
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	data, err := os.ReadFile(filepath.Join(homeDir, ".vault-token"))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+func expandVaultTemplate(template string, envName string) string {
+	return strings.ReplaceAll(strings.TrimSpace(template), "{name}", envName)
+}
@@ -0,0 +1,105 @@
+package credential
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestVaultServer(t *testing.T, secrets map[string]string) (*httptest.Server, *int) {
+	t.Helper()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		body, ok := secrets[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestVaultSourceGetReadsKVv2SecretOnce(t *testing.T) {
+	server, requests := newTestVaultServer(t, map[string]string{
+		"/v1/secret/data/mcp-wire": `{"data":{"data":{"JIRA_TOKEN":"jira-secret","SENTRY_TOKEN":"sentry-secret"},"metadata":{"version":3}}}`,
+	})
+	t.Setenv("VAULT_TOKEN", "test-token")
+
+	source := NewVaultSource(VaultOptions{Address: server.URL, TokenEnv: "VAULT_TOKEN", Path: "secret/data/mcp-wire", Field: "{name}"})
+
+	for envName, want := range map[string]string{"JIRA_TOKEN": "jira-secret", "SENTRY_TOKEN": "sentry-secret"} {
+		value, found := source.Get(envName)
+		if !found || value != want {
+			t.Fatalf("expected %s=%q, got %q found=%v", envName, want, value, found)
+		}
+	}
+
+	if _, found := source.Get("MISSING_TOKEN"); found {
+		t.Fatal("expected a missing field not to be found")
+	}
+
+	if *requests != 1 {
+		t.Fatalf("expected the secret to be read once, got %d requests", *requests)
+	}
+}
+
+func TestVaultSourceGetReadsKVv1SecretPerName(t *testing.T) {
+	server, _ := newTestVaultServer(t, map[string]string{
+		"/v1/kv/mcp-wire/JIRA_TOKEN": `{"data":{"value":"jira-secret"}}`,
+	})
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("HOME", t.TempDir())
+	if err := os.WriteFile(filepath.Join(os.Getenv("HOME"), ".vault-token"), []byte("test-token\n"), 0o600); err != nil {
+		t.Fatalf("write token: %v", err)
+	}
+
+	source := NewVaultSource(VaultOptions{TokenEnv: "UNSET_VAULT_TOKEN", Path: "kv/mcp-wire/{name}", Field: "value"})
+
+	value, found := source.Get("JIRA_TOKEN")
+	if !found || value != "jira-secret" {
+		t.Fatalf("expected secret from the saved token, got %q found=%v", value, found)
+	}
+
+	if _, found := source.Get("SENTRY_TOKEN"); found {
+		t.Fatal("expected a missing secret not to be found")
+	}
+}
+
+func TestVaultSourceGetNotFoundWithoutAccess(t *testing.T) {
+	server, _ := newTestVaultServer(t, map[string]string{
+		"/v1/secret/data/mcp-wire": `{"data":{"data":{"JIRA_TOKEN":"jira-secret"},"metadata":{}}}`,
+	})
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VAULT_TOKEN", "wrong-token")
+
+	source := NewVaultSource(VaultOptions{Address: server.URL, TokenEnv: "VAULT_TOKEN", Path: "secret/data/mcp-wire", Field: "{name}"})
+	if _, found := source.Get("JIRA_TOKEN"); found {
+		t.Fatal("expected a denied read not to be found")
+	}
+
+	unconfigured := NewVaultSource(VaultOptions{Address: server.URL, TokenEnv: "VAULT_TOKEN"})
+	if _, found := unconfigured.Get("JIRA_TOKEN"); found {
+		t.Fatal("expected a source without a path not to be found")
+	}
+}
+
+func TestVaultSourceStoreNotSupported(t *testing.T) {
+	if err := NewVaultSource(VaultOptions{}).Store("JIRA_TOKEN", "secret"); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("expected ErrNotSupported, got %v", err)
+	}
+}
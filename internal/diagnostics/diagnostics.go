@@ -0,0 +1,149 @@
+// Package diagnostics defines the warnings mcp-wire reports, each under a
+// stable code, and which of them the user chose to suppress. The CLI and the
+// TUI share it, so a code suppressed in config is quiet everywhere.
+package diagnostics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Code identifies a kind of warning. Codes are stable across releases, so
+// they can be listed in config.
+type Code string
+
+// Warnings reported by install and uninstall.
+const (
+	// SystemScopeCredentials: credentials are written to a system config
+	// every user on the machine can read.
+	SystemScopeCredentials Code = "MW101"
+	// VerificationFailed: the entry read back from a target config differs
+	// from the one written.
+	VerificationFailed Code = "MW102"
+	// ManualAuthentication: the target needs a manual OAuth step.
+	ManualAuthentication Code = "MW103"
+	// ProjectFileCredentials: credentials are written to a project file git
+	// would commit.
+	ProjectFileCredentials Code = "MW104"
+)
+
+// Warnings reported by doctor.
+const (
+	// TargetNotFound: a target's binary is not on PATH.
+	TargetNotFound Code = "MW201"
+	// CredentialsFileMode: the credentials file is readable by other users.
+	CredentialsFileMode Code = "MW202"
+	// RegistryCacheStale: the registry cache is missing, unreadable or old.
+	RegistryCacheStale Code = "MW203"
+	// RuntimeNotFound: a runtime curated services launch is not on PATH.
+	RuntimeNotFound Code = "MW204"
+	// ConfigTooLarge: a target config is over the size or project entry
+	// limit set in config.
+	ConfigTooLarge Code = "MW205"
+	// MissingCredential: an installed service needs a credential that no
+	// credential source holds.
+	MissingCredential Code = "MW206"
+	// StaleCredential: a credential is stored for a service installed nowhere.
+	StaleCredential Code = "MW207"
+)
+
+var descriptions = map[Code]string{
+	SystemScopeCredentials: "credentials written to a system config readable by every user",
+	VerificationFailed:     "installed entry differs from the one written when read back",
+	ManualAuthentication:   "target needs a manual OAuth step after install",
+	ProjectFileCredentials: "credentials written to a project file git would commit",
+	TargetNotFound:         "target binary not found on PATH",
+	CredentialsFileMode:    "credentials file readable by other users",
+	RegistryCacheStale:     "registry cache missing, unreadable or out of date",
+	RuntimeNotFound:        "runtime used by curated services not found on PATH",
+	ConfigTooLarge:         "target config over the configured size or project limit",
+	MissingCredential:      "installed service missing a required credential in every source",
+	StaleCredential:        "credential stored for a service not installed anywhere",
+}
+
+// Description returns what code warns about, or an empty string for an
+// unknown code.
+func (c Code) Description() string {
+	return descriptions[c]
+}
+
+// Codes returns every known code in order.
+func Codes() []Code {
+	codes := make([]Code, 0, len(descriptions))
+	for code := range descriptions {
+		codes = append(codes, code)
+	}
+
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	return codes
+}
+
+// ParseCode normalizes value ("mw101", " MW101 ") and fails for a code
+// mcp-wire does not define.
+func ParseCode(value string) (Code, error) {
+	code := Code(strings.ToUpper(strings.TrimSpace(value)))
+	if _, ok := descriptions[code]; !ok {
+		return "", fmt.Errorf("unknown warning code %q; run mcp-wire warnings to list them", value)
+	}
+
+	return code, nil
+}
+
+// Warning is one warning, as reported to the user.
+type Warning struct {
+	Code    Code
+	Message string
+}
+
+// New returns a warning with a formatted message.
+func New(code Code, format string, args ...any) Warning {
+	return Warning{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// String renders the warning with its code, as "[MW101] message".
+func (w Warning) String() string {
+	return fmt.Sprintf("[%s] %s", w.Code, w.Message)
+}
+
+// Suppressions is the set of codes the user chose not to see. The zero value
+// suppresses nothing.
+type Suppressions map[Code]bool
+
+// NewSuppressions parses codes. Unknown codes are an error, so a typo does
+// not silently leave a warning on.
+func NewSuppressions(codes []string) (Suppressions, error) {
+	suppressions := Suppressions{}
+	for _, value := range codes {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		code, err := ParseCode(value)
+		if err != nil {
+			return nil, err
+		}
+
+		suppressions[code] = true
+	}
+
+	return suppressions, nil
+}
+
+// Suppressed reports whether warnings with code are suppressed.
+func (s Suppressions) Suppressed(code Code) bool {
+	return s[code]
+}
+
+// Filter returns the warnings that are not suppressed.
+func (s Suppressions) Filter(warnings []Warning) []Warning {
+	kept := make([]Warning, 0, len(warnings))
+	for _, warning := range warnings {
+		if !s.Suppressed(warning.Code) {
+			kept = append(kept, warning)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,56 @@
+package diagnostics
+
+import "testing"
+
+func TestCodesAreSortedAndDescribed(t *testing.T) {
+	codes := Codes()
+	if len(codes) == 0 {
+		t.Fatal("expected warning codes")
+	}
+
+	for i, code := range codes {
+		if code.Description() == "" {
+			t.Fatalf("expected %s to have a description", code)
+		}
+
+		if i > 0 && codes[i-1] >= code {
+			t.Fatalf("expected codes in order, got %v", codes)
+		}
+	}
+}
+
+func TestParseCodeNormalizes(t *testing.T) {
+	code, err := ParseCode(" mw101 ")
+	if err != nil {
+		t.Fatalf("expected code to parse: %v", err)
+	}
+
+	if code != SystemScopeCredentials {
+		t.Fatalf("expected MW101, got %q", code)
+	}
+
+	if _, err := ParseCode("MW000"); err == nil {
+		t.Fatal("expected unknown code to fail")
+	}
+}
+
+func TestSuppressionsFilter(t *testing.T) {
+	suppressions, err := NewSuppressions([]string{"MW201", ""})
+	if err != nil {
+		t.Fatalf("expected suppressions to parse: %v", err)
+	}
+
+	warnings := suppressions.Filter([]Warning{
+		New(TargetNotFound, "codex not found"),
+		New(RuntimeNotFound, "uvx not found"),
+	})
+
+	if len(warnings) != 1 || warnings[0].String() != "[MW204] uvx not found" {
+		t.Fatalf("unexpected warnings after filter: %v", warnings)
+	}
+
+	var none Suppressions
+	if none.Suppressed(TargetNotFound) {
+		t.Fatal("expected the zero value to suppress nothing")
+	}
+}
@@ -0,0 +1,196 @@
+// Package flow decides the order of the install and uninstall wizard steps.
+//
+// The plain prompt wizard and the full-screen TUI present steps differently,
+// but both ask the controller which step comes next, so features such as the
+// registry trust check, scope selection, and credential cleanup behave the
+// same in every frontend.
+package flow
+
+import (
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// Wizard actions.
+const (
+	ActionInstall   = "install"
+	ActionUninstall = "uninstall"
+)
+
+// Step identifies a wizard step. The value doubles as its display label.
+type Step string
+
+const (
+	StepNone        Step = ""
+	StepSource      Step = "Source"
+	StepService     Step = "Service"
+	StepTrust       Step = "Trust"
+	StepTargets     Step = "Targets"
+	StepScope       Step = "Scope"
+	StepReview      Step = "Review"
+	StepCredentials Step = "Credentials"
+	StepApply       Step = "Apply"
+)
+
+var installOrder = []Step{
+	StepSource,
+	StepService,
+	StepTrust,
+	StepTargets,
+	StepScope,
+	StepReview,
+	StepCredentials,
+	StepApply,
+}
+
+// Uninstall picks targets first so the service list only shows what is
+// actually configured on them.
+var uninstallOrder = []Step{
+	StepTargets,
+	StepService,
+	StepScope,
+	StepReview,
+	StepApply,
+}
+
+// State is what the controller needs to know about the choices made so far.
+type State struct {
+	Action             string
+	RegistryEnabled    bool
+	Entry              catalog.Entry
+	Targets            []targetpkg.Target
+	ScopeSet           bool // scope was given on the command line
+	MissingCredentials bool // required credentials could not be resolved
+}
+
+// Steps returns the steps that apply to s, in order.
+func Steps(s State) []Step {
+	var steps []Step
+	for _, step := range order(s.Action) {
+		if applies(s, step) {
+			steps = append(steps, step)
+		}
+	}
+
+	return steps
+}
+
+// Next returns the first step after current that applies to s, or StepNone
+// when current is the last one.
+func Next(s State, current Step) Step {
+	steps := order(s.Action)
+	for i, step := range steps {
+		if step != current {
+			continue
+		}
+
+		for _, next := range steps[i+1:] {
+			if applies(s, next) {
+				return next
+			}
+		}
+	}
+
+	return StepNone
+}
+
+// Previous returns the closest step before current that applies to s, or
+// StepNone when current is the first one.
+func Previous(s State, current Step) Step {
+	steps := order(s.Action)
+	for i, step := range steps {
+		if step != current {
+			continue
+		}
+
+		for j := i - 1; j >= 0; j-- {
+			if applies(s, steps[j]) {
+				return steps[j]
+			}
+		}
+	}
+
+	return StepNone
+}
+
+// NeedsTrust reports whether entry must be confirmed before it is installed.
+func NeedsTrust(entry catalog.Entry) bool {
+	return entry.Source == catalog.SourceRegistry
+}
+
+// SupportsProjectScope reports whether any of targets can be configured at
+// project scope.
+func SupportsProjectScope(targets []targetpkg.Target) bool {
+	return supportsScope(targets, targetpkg.ConfigScopeProject)
+}
+
+// SupportsRemoteScope reports whether any of targets has remote settings on
+// this machine, such as VS Code Server inside a dev container.
+func SupportsRemoteScope(targets []targetpkg.Target) bool {
+	return supportsScope(targets, targetpkg.ConfigScopeRemote)
+}
+
+// OffersScopeChoice reports whether the user has more than the user scope to
+// choose from for targets.
+func OffersScopeChoice(targets []targetpkg.Target) bool {
+	return SupportsProjectScope(targets) || SupportsRemoteScope(targets)
+}
+
+func supportsScope(targets []targetpkg.Target, want targetpkg.ConfigScope) bool {
+	for _, t := range targets {
+		scopedTarget, ok := t.(targetpkg.ScopedTarget)
+		if !ok {
+			continue
+		}
+
+		for _, scope := range scopedTarget.SupportedScopes() {
+			if scope == want {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// OfferCredentialCleanup reports whether to offer removing the stored
+// credentials of svc once an operation finished.
+func OfferCredentialCleanup(action string, svc service.Service, failed bool) bool {
+	if action != ActionUninstall || failed {
+		return false
+	}
+
+	for _, envVar := range svc.Env {
+		if strings.TrimSpace(envVar.Name) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func order(action string) []Step {
+	if action == ActionUninstall {
+		return uninstallOrder
+	}
+
+	return installOrder
+}
+
+func applies(s State, step Step) bool {
+	switch step {
+	case StepSource:
+		return s.RegistryEnabled
+	case StepTrust:
+		return NeedsTrust(s.Entry)
+	case StepScope:
+		return !s.ScopeSet && OffersScopeChoice(s.Targets)
+	case StepCredentials:
+		return s.MissingCredentials
+	default:
+		return true
+	}
+}
@@ -0,0 +1,165 @@
+package flow
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+type fakeTarget struct {
+	slug   string
+	scopes []targetpkg.ConfigScope
+}
+
+func (t fakeTarget) Name() string                                     { return t.slug }
+func (t fakeTarget) Slug() string                                     { return t.slug }
+func (t fakeTarget) IsInstalled() bool                                { return true }
+func (t fakeTarget) Install(service.Service, map[string]string) error { return nil }
+func (t fakeTarget) Uninstall(string) error                           { return nil }
+func (t fakeTarget) List() ([]string, error)                          { return nil, nil }
+
+type fakeScopedTarget struct {
+	fakeTarget
+}
+
+func (t fakeScopedTarget) SupportedScopes() []targetpkg.ConfigScope { return t.scopes }
+func (t fakeScopedTarget) InstallWithScope(service.Service, map[string]string, targetpkg.ConfigScope) error {
+	return nil
+}
+func (t fakeScopedTarget) UninstallWithScope(string, targetpkg.ConfigScope) error { return nil }
+func (t fakeScopedTarget) ListWithScope(targetpkg.ConfigScope) ([]string, error) {
+	return nil, nil
+}
+
+func projectScopedTarget() targetpkg.Target {
+	return fakeScopedTarget{fakeTarget{
+		slug:   "claude",
+		scopes: []targetpkg.ConfigScope{targetpkg.ConfigScopeUser, targetpkg.ConfigScopeProject},
+	}}
+}
+
+func TestStepsInstallMinimal(t *testing.T) {
+	got := Steps(State{Action: ActionInstall, Targets: []targetpkg.Target{fakeTarget{slug: "codex"}}})
+	want := []Step{StepService, StepTargets, StepReview, StepApply}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestStepsInstallWithEveryOptionalStep(t *testing.T) {
+	got := Steps(State{
+		Action:             ActionInstall,
+		RegistryEnabled:    true,
+		Entry:              catalog.Entry{Source: catalog.SourceRegistry, Name: "community"},
+		Targets:            []targetpkg.Target{projectScopedTarget()},
+		MissingCredentials: true,
+	})
+	want := []Step{StepSource, StepService, StepTrust, StepTargets, StepScope, StepReview, StepCredentials, StepApply}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestStepsUninstallSelectsTargetsFirst(t *testing.T) {
+	got := Steps(State{
+		Action:          ActionUninstall,
+		RegistryEnabled: true,
+		Targets:         []targetpkg.Target{projectScopedTarget()},
+	})
+	want := []Step{StepTargets, StepService, StepScope, StepReview, StepApply}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestScopeSetSkipsScopeStep(t *testing.T) {
+	state := State{Action: ActionInstall, Targets: []targetpkg.Target{projectScopedTarget()}, ScopeSet: true}
+
+	if got := Next(state, StepTargets); got != StepReview {
+		t.Fatalf("expected review after targets, got %q", got)
+	}
+}
+
+func TestRemoteScopeShowsScopeStep(t *testing.T) {
+	remoteTarget := fakeScopedTarget{fakeTarget{
+		slug:   "vscode",
+		scopes: []targetpkg.ConfigScope{targetpkg.ConfigScopeUser, targetpkg.ConfigScopeRemote},
+	}}
+	state := State{Action: ActionInstall, Targets: []targetpkg.Target{remoteTarget}}
+
+	if got := Next(state, StepTargets); got != StepScope {
+		t.Fatalf("expected scope after targets, got %q", got)
+	}
+
+	if SupportsProjectScope(state.Targets) || !SupportsRemoteScope(state.Targets) {
+		t.Fatal("expected only remote scope to be supported")
+	}
+}
+
+func TestOpenCodeShowsScopeStep(t *testing.T) {
+	state := State{Action: ActionInstall, Targets: []targetpkg.Target{targetpkg.NewOpenCodeTarget().Sandbox(t.TempDir())}}
+
+	if got := Next(state, StepTargets); got != StepScope {
+		t.Fatalf("expected scope after targets, got %q", got)
+	}
+
+	if !SupportsProjectScope(state.Targets) {
+		t.Fatal("expected OpenCode to support project scope")
+	}
+}
+
+func TestNextAndPrevious(t *testing.T) {
+	state := State{
+		Action:  ActionInstall,
+		Entry:   catalog.Entry{Source: catalog.SourceCurated, Name: "sentry"},
+		Targets: []targetpkg.Target{projectScopedTarget()},
+	}
+
+	tests := []struct {
+		name string
+		got  Step
+		want Step
+	}{
+		{name: "service skips trust for curated", got: Next(state, StepService), want: StepTargets},
+		{name: "targets then scope", got: Next(state, StepTargets), want: StepScope},
+		{name: "review then apply", got: Next(state, StepReview), want: StepApply},
+		{name: "apply is last", got: Next(state, StepApply), want: StepNone},
+		{name: "back from review", got: Previous(state, StepReview), want: StepScope},
+		{name: "back from service", got: Previous(state, StepService), want: StepNone},
+		{name: "unknown step", got: Next(state, Step("Other")), want: StepNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, tt.got)
+			}
+		})
+	}
+}
+
+func TestOfferCredentialCleanup(t *testing.T) {
+	withEnv := service.Service{Name: "jira", Env: []service.EnvVar{{Name: "JIRA_TOKEN"}}}
+
+	if !OfferCredentialCleanup(ActionUninstall, withEnv, false) {
+		t.Fatal("expected cleanup offer after a successful uninstall")
+	}
+
+	if OfferCredentialCleanup(ActionUninstall, withEnv, true) {
+		t.Fatal("expected no cleanup offer after a failed uninstall")
+	}
+
+	if OfferCredentialCleanup(ActionInstall, withEnv, false) {
+		t.Fatal("expected no cleanup offer after install")
+	}
+
+	if OfferCredentialCleanup(ActionUninstall, service.Service{Name: "context7"}, false) {
+		t.Fatal("expected no cleanup offer for services without credentials")
+	}
+}
@@ -0,0 +1,115 @@
+// Package format renders times, durations, and sizes for people, so every
+// command and screen shows them the same way. Times are shown in the local
+// time zone; durations and ages are rounded down to their largest whole
+// unit, as in "3 days" or "2 hours ago"; sizes use binary units.
+package format
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+)
+
+// Layouts of the times shown to people.
+const (
+	TimestampLayout = "2006-01-02 15:04"
+	DateLayout      = time.DateOnly
+)
+
+// Timestamp formats t to the minute, in the local time zone. A zero t is
+// shown as "never".
+func Timestamp(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	return t.Local().Format(TimestampLayout)
+}
+
+// Date formats the day of t, in the local time zone. A zero t is shown as
+// "never".
+func Date(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	return t.Local().Format(DateLayout)
+}
+
+// durationUnits are the units Duration picks from, largest first.
+var durationUnits = []struct {
+	size time.Duration
+	name string
+}{
+	{24 * time.Hour, "day"},
+	{time.Hour, "hour"},
+	{time.Minute, "minute"},
+	{time.Second, "second"},
+}
+
+// Duration formats d in its largest whole unit, from seconds to days, such
+// as "1 hour" for 90 minutes. Negative durations are shown as their length.
+func Duration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+
+	for _, unit := range durationUnits {
+		if d >= unit.size || unit.size == time.Second {
+			return count(int64(d/unit.size), unit.name)
+		}
+	}
+
+	return ""
+}
+
+// Ago formats how long before the current time of c t was, such as
+// "2 hours ago", or "just now" within the last minute. A zero t is
+// "never"; a t in the future, which has no age, is shown as its timestamp.
+func Ago(c clock.Clock, t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	age, ok := clock.Age(c, t)
+	if !ok {
+		return Timestamp(t)
+	}
+
+	if age < time.Minute {
+		return "just now"
+	}
+
+	return Duration(age) + " ago"
+}
+
+// byteUnits are the units Bytes uses past plain bytes, each 1024 times the
+// one before.
+var byteUnits = []string{"KB", "MB", "GB", "TB"}
+
+// Bytes formats a size of n bytes, such as "512 B" or "2.0 MB", with one
+// decimal above a kilobyte.
+func Bytes(n int64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	value := float64(n) / 1024
+	unit := 0
+	for value >= 1024 && unit < len(byteUnits)-1 {
+		value /= 1024
+		unit++
+	}
+
+	return fmt.Sprintf("%.1f %s", value, byteUnits[unit])
+}
+
+// count formats n of unit, in the plural unless n is 1.
+func count(n int64, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+
+	return fmt.Sprintf("%d %ss", n, unit)
+}
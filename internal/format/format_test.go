@@ -0,0 +1,90 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+)
+
+func TestTimestampAndDateUseLocalTime(t *testing.T) {
+	original := time.Local
+	time.Local = time.FixedZone("CEST", 2*60*60)
+	t.Cleanup(func() { time.Local = original })
+
+	stamp := time.Date(2026, 10, 1, 23, 0, 0, 0, time.UTC)
+	if got := Timestamp(stamp); got != "2026-10-02 01:00" {
+		t.Fatalf("expected local time, got %q", got)
+	}
+
+	if got := Date(stamp); got != "2026-10-02" {
+		t.Fatalf("expected local date, got %q", got)
+	}
+
+	if got := Timestamp(time.Time{}); got != "never" {
+		t.Fatalf("expected never for a zero time, got %q", got)
+	}
+}
+
+func TestDurationUsesLargestWholeUnit(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0 seconds"},
+		{time.Second, "1 second"},
+		{59 * time.Second, "59 seconds"},
+		{90 * time.Minute, "1 hour"},
+		{47 * time.Hour, "1 day"},
+		{12*24*time.Hour + 5*time.Hour, "12 days"},
+		{-3 * time.Minute, "3 minutes"},
+	}
+
+	for _, tt := range tests {
+		if got := Duration(tt.d); got != tt.want {
+			t.Errorf("Duration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestAgo(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	c := clock.Fixed(now)
+
+	tests := []struct {
+		name  string
+		stamp time.Time
+		want  string
+	}{
+		{name: "zero", want: "never"},
+		{name: "recent", stamp: now.Add(-20 * time.Second), want: "just now"},
+		{name: "hours", stamp: now.Add(-2*time.Hour - 10*time.Minute), want: "2 hours ago"},
+		{name: "future", stamp: now.Add(time.Hour), want: Timestamp(now.Add(time.Hour))},
+	}
+
+	for _, tt := range tests {
+		if got := Ago(c, tt.stamp); got != tt.want {
+			t.Errorf("%s: Ago = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KB"},
+		{2 << 20, "2.0 MB"},
+		{5 << 40, "5.0 TB"},
+		{3 << 50, "3072.0 TB"},
+	}
+
+	for _, tt := range tests {
+		if got := Bytes(tt.n); got != tt.want {
+			t.Errorf("Bytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type sampleChild struct {
+	Name string `json:"name"`
+}
+
+type sample struct {
+	ID       string            `json:"id"`
+	Count    int               `json:"count,omitempty"`
+	Enabled  bool              `json:"enabled"`
+	When     time.Time         `json:"when"`
+	Children []sampleChild     `json:"children"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Parent   *sampleChild      `json:"parent,omitempty"`
+	Hidden   string            `json:"-"`
+	internal string
+}
+
+func TestGenerateFollowsJSONTags(t *testing.T) {
+	schema := Generate(sample{}, "https://example.com/sample.json", "sample", "")
+
+	if schema["$schema"] != Draft || schema["$id"] != "https://example.com/sample.json" || schema["type"] != "object" {
+		t.Fatalf("unexpected header: %#v", schema)
+	}
+
+	if _, ok := schema["description"]; ok {
+		t.Fatalf("expected no description, got %#v", schema["description"])
+	}
+
+	wantRequired := []string{"id", "enabled", "when", "children"}
+	if !reflect.DeepEqual(schema["required"], wantRequired) {
+		t.Fatalf("expected required %v, got %v", wantRequired, schema["required"])
+	}
+
+	properties := schema["properties"].(map[string]any)
+	if len(properties) != 7 {
+		t.Fatalf("expected 7 properties, got %#v", properties)
+	}
+
+	if !reflect.DeepEqual(properties["when"], map[string]any{"type": "string", "format": "date-time"}) {
+		t.Fatalf("unexpected time schema: %#v", properties["when"])
+	}
+
+	children := properties["children"].(map[string]any)
+	items := children["items"].(map[string]any)
+	if children["type"] != "array" || !reflect.DeepEqual(items["required"], []string{"name"}) {
+		t.Fatalf("unexpected children schema: %#v", children)
+	}
+
+	if !reflect.DeepEqual(properties["labels"], map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}}) {
+		t.Fatalf("unexpected labels schema: %#v", properties["labels"])
+	}
+
+	if properties["parent"].(map[string]any)["type"] != "object" {
+		t.Fatalf("expected pointer to resolve to its struct, got %#v", properties["parent"])
+	}
+}
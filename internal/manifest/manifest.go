@@ -0,0 +1,176 @@
+// Package manifest reads mcp-wire.yaml, the declarative list of services a
+// team wants configured and the targets and scope to configure them on.
+// "mcp-wire sync" reconciles targets against it, and "mcp-wire install
+// --from" installs the services of a file in the same format.
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the manifest file sync looks for in the working directory.
+const FileName = "mcp-wire.yaml"
+
+const (
+	ScopeUser    = "user"
+	ScopeProject = "project"
+)
+
+// Manifest is the desired state of MCP configuration. Targets and Scope are
+// defaults for services that do not set their own.
+type Manifest struct {
+	Targets  []string  `yaml:"targets"`
+	Scope    string    `yaml:"scope"`
+	Prune    bool      `yaml:"prune"`
+	Services []Service `yaml:"services"`
+}
+
+// Service is one service the manifest wants configured. In YAML it is
+// either a bare service name or a mapping with name, targets, scope, and
+// env. Env sets credentials of the service, taking precedence over every
+// credential source.
+type Service struct {
+	Name    string            `yaml:"name"`
+	Targets []string          `yaml:"targets"`
+	Scope   string            `yaml:"scope"`
+	Env     map[string]string `yaml:"env"`
+}
+
+// UnmarshalYAML accepts a bare service name as shorthand for {name: ...}.
+func (s *Service) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		s.Name = node.Value
+		return nil
+	}
+
+	type plain Service
+	var decoded plain
+	if err := node.Decode(&decoded); err != nil {
+		return err
+	}
+
+	*s = Service(decoded)
+	return nil
+}
+
+// Load reads and validates the manifest at path. Scopes and target lists
+// left empty are filled from the manifest defaults, and the default scope is
+// user.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest %q: %w", path, err)
+	}
+
+	m, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("manifest %q: %w", path, err)
+	}
+
+	return m, nil
+}
+
+// Parse decodes and validates manifest YAML. Unknown keys are rejected so a
+// typo cannot silently change what sync does.
+func Parse(data []byte) (*Manifest, error) {
+	var m Manifest
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&m); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+
+	m.Scope = strings.ToLower(strings.TrimSpace(m.Scope))
+	if m.Scope == "" {
+		m.Scope = ScopeUser
+	}
+
+	if err := validateScope(m.Scope); err != nil {
+		return nil, err
+	}
+
+	m.Targets = normalizeSlugs(m.Targets)
+
+	if len(m.Services) == 0 {
+		return nil, errors.New("no services listed")
+	}
+
+	seen := make(map[string]struct{}, len(m.Services))
+	for i := range m.Services {
+		svc := &m.Services[i]
+		svc.Name = strings.TrimSpace(svc.Name)
+		if svc.Name == "" {
+			return nil, fmt.Errorf("service %d has no name", i+1)
+		}
+
+		key := strings.ToLower(svc.Name)
+		if _, duplicate := seen[key]; duplicate {
+			return nil, fmt.Errorf("service %q is listed more than once", svc.Name)
+		}
+		seen[key] = struct{}{}
+
+		svc.Scope = strings.ToLower(strings.TrimSpace(svc.Scope))
+		if svc.Scope == "" {
+			svc.Scope = m.Scope
+		}
+
+		if err := validateScope(svc.Scope); err != nil {
+			return nil, fmt.Errorf("service %q: %w", svc.Name, err)
+		}
+
+		svc.Targets = normalizeSlugs(svc.Targets)
+		if len(svc.Targets) == 0 {
+			svc.Targets = m.Targets
+		}
+
+		env := make(map[string]string, len(svc.Env))
+		for name, value := range svc.Env {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				return nil, fmt.Errorf("service %q: env has an empty name", svc.Name)
+			}
+
+			env[name] = value
+		}
+		svc.Env = env
+	}
+
+	return &m, nil
+}
+
+func validateScope(scope string) error {
+	switch scope {
+	case ScopeUser, ScopeProject:
+		return nil
+	default:
+		return fmt.Errorf("invalid scope %q (supported: user, project)", scope)
+	}
+}
+
+func normalizeSlugs(slugs []string) []string {
+	normalized := make([]string, 0, len(slugs))
+	seen := make(map[string]struct{}, len(slugs))
+	for _, slug := range slugs {
+		slug = strings.ToLower(strings.TrimSpace(slug))
+		if slug == "" {
+			continue
+		}
+
+		if _, duplicate := seen[slug]; duplicate {
+			continue
+		}
+
+		seen[slug] = struct{}{}
+		normalized = append(normalized, slug)
+	}
+
+	return normalized
+}
@@ -0,0 +1,101 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseAppliesDefaults(t *testing.T) {
+	m, err := Parse([]byte(`
+targets: [Claude, codex, claude]
+scope: project
+services:
+  - jira
+  - name: sentry
+    targets: [vscode]
+    scope: user
+    env:
+      " SENTRY_HOST ": sentry.example.com
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(m.Services) != 2 {
+		t.Fatalf("expected 2 services, got %d", len(m.Services))
+	}
+
+	jira := m.Services[0]
+	if jira.Name != "jira" || jira.Scope != ScopeProject || !slices.Equal(jira.Targets, []string{"claude", "codex"}) {
+		t.Fatalf("expected jira to inherit defaults, got %+v", jira)
+	}
+
+	sentry := m.Services[1]
+	if sentry.Scope != ScopeUser || !slices.Equal(sentry.Targets, []string{"vscode"}) {
+		t.Fatalf("expected sentry to keep its own settings, got %+v", sentry)
+	}
+
+	if len(sentry.Env) != 1 || sentry.Env["SENTRY_HOST"] != "sentry.example.com" {
+		t.Fatalf("expected trimmed env overrides, got %v", sentry.Env)
+	}
+
+	if m.Prune {
+		t.Fatal("expected prune to default to false")
+	}
+}
+
+func TestParseDefaultsToUserScopeAndAllTargets(t *testing.T) {
+	m, err := Parse([]byte("services: [jira]\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if m.Services[0].Scope != ScopeUser {
+		t.Fatalf("expected user scope, got %q", m.Services[0].Scope)
+	}
+
+	if len(m.Services[0].Targets) != 0 {
+		t.Fatalf("expected no targets (meaning all installed), got %v", m.Services[0].Targets)
+	}
+}
+
+func TestParseRejectsInvalidManifests(t *testing.T) {
+	tests := map[string]string{
+		"empty":          "",
+		"no services":    "targets: [claude]\n",
+		"unknown key":    "services: [jira]\nprnue: true\n",
+		"bad scope":      "scope: system\nservices: [jira]\n",
+		"bad svc scope":  "services:\n  - name: jira\n    scope: remote\n",
+		"duplicate":      "services: [jira, JIRA]\n",
+		"missing name":   "services:\n  - targets: [claude]\n",
+		"not a manifest": "- jira\n",
+		"empty env name": "services:\n  - name: jira\n    env: {' ': x}\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(content)); err == nil {
+				t.Fatalf("expected error for %q", content)
+			}
+		})
+	}
+}
+
+func TestLoadWrapsErrorsWithPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("services: []\n"), 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	_, err := Load(path)
+	if err == nil || !strings.Contains(err.Error(), path) {
+		t.Fatalf("expected error naming %s, got %v", path, err)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("expected error for missing manifest")
+	}
+}
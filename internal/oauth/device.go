@@ -0,0 +1,136 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/app"
+)
+
+// deviceCodeGrant is the grant type of RFC 8628 token requests.
+const deviceCodeGrant = "urn:ietf:params:oauth:grant-type:device_code"
+
+// defaultPollInterval is how long to wait between token requests when the
+// server does not say.
+const defaultPollInterval = 5 * time.Second
+
+// AuthorizeDevice runs the device authorization flow: it prints a URL and a
+// code for the user to enter on any device, then polls the token endpoint
+// until the user approves, denies, or the code expires. It suits sessions
+// where a browser on this machine cannot reach the user, such as over SSH.
+func (f *Flow) AuthorizeDevice(ctx context.Context) (Token, error) {
+	metadata, err := Discover(ctx, f.httpClient(), f.ServerURL)
+	if err != nil {
+		return Token{}, err
+	}
+
+	if metadata.DeviceAuthorizationEndpoint == "" {
+		return Token{}, ErrDeviceFlowUnsupported
+	}
+
+	clientID, clientSecret, err := f.register(ctx, metadata, "", []string{deviceCodeGrant, "refresh_token"})
+	if err != nil {
+		return Token{}, err
+	}
+
+	form := url.Values{"client_id": {clientID}, "resource": {f.resource()}}
+	if len(f.Scopes) > 0 {
+		form.Set("scope", strings.Join(f.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.DeviceAuthorizationEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("mcp-wire/%s", app.Version))
+
+	var device struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationURI         string `json:"verification_uri"`
+		VerificationURIComplete string `json:"verification_uri_complete"`
+		ExpiresIn               int64  `json:"expires_in"`
+		Interval                int64  `json:"interval"`
+	}
+	if err := f.do(req, &device); err != nil {
+		return Token{}, fmt.Errorf("request device code: %w", err)
+	}
+
+	if device.DeviceCode == "" || device.VerificationURI == "" {
+		return Token{}, errors.New("device authorization response has no device_code or verification_uri")
+	}
+
+	fmt.Fprintf(f.output(), "  Open %s and enter the code %s\n", device.VerificationURI, device.UserCode)
+	if device.VerificationURIComplete != "" {
+		fmt.Fprintf(f.output(), "  or open %s\n", device.VerificationURIComplete)
+	}
+
+	if device.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(device.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	interval := defaultPollInterval
+	if device.Interval > 0 {
+		interval = time.Duration(device.Interval) * time.Second
+	}
+
+	tokenForm := url.Values{
+		"grant_type":  {deviceCodeGrant},
+		"device_code": {device.DeviceCode},
+		"client_id":   {clientID},
+		"resource":    {f.resource()},
+	}
+	if clientSecret != "" {
+		tokenForm.Set("client_secret", clientSecret)
+	}
+
+	for {
+		if err := f.pause(ctx, interval); err != nil {
+			return Token{}, fmt.Errorf("wait for device approval: %w", err)
+		}
+
+		token, err := f.requestToken(ctx, metadata, tokenForm)
+		var oauthErr *Error
+		if !errors.As(err, &oauthErr) {
+			if err != nil {
+				return Token{}, fmt.Errorf("poll for device token: %w", err)
+			}
+
+			return token, nil
+		}
+
+		switch oauthErr.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return Token{}, fmt.Errorf("poll for device token: %w", err)
+		}
+	}
+}
+
+func (f *Flow) pause(ctx context.Context, d time.Duration) error {
+	if f.wait != nil {
+		return f.wait(ctx, d)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
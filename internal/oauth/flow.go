@@ -0,0 +1,193 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// callbackPath is where the loopback server receives the authorization
+// response.
+const callbackPath = "/callback"
+
+// callbackPage is shown in the browser once the authorization response
+// arrived.
+const callbackPage = `<!doctype html>
+<html><head><meta charset="utf-8"><title>mcp-wire</title></head>
+<body><p>%s You can close this window and return to the terminal.</p></body></html>
+`
+
+// callbackResult is what the loopback server received.
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// Authorize runs the authorization code flow with PKCE. It listens on a
+// loopback port for the redirect, opens the authorization page in the
+// browser, prints its URL to Output either way, and exchanges the code it
+// receives for a token. It returns when ctx is done if the user never
+// completes the login.
+func (f *Flow) Authorize(ctx context.Context) (Token, error) {
+	metadata, err := Discover(ctx, f.httpClient(), f.ServerURL)
+	if err != nil {
+		return Token{}, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return Token{}, fmt.Errorf("listen for the OAuth redirect: %w", err)
+	}
+	defer listener.Close()
+
+	redirectURI := fmt.Sprintf("http://%s%s", listener.Addr().String(), callbackPath)
+	clientID, clientSecret, err := f.register(ctx, metadata, redirectURI, []string{"authorization_code", "refresh_token"})
+	if err != nil {
+		return Token{}, err
+	}
+
+	verifier, err := randomString(32)
+	if err != nil {
+		return Token{}, err
+	}
+
+	state, err := randomString(16)
+	if err != nil {
+		return Token{}, err
+	}
+
+	results := make(chan callbackResult, 1)
+	server := &http.Server{
+		Handler:           callbackHandler(state, results),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	authorizationURL, err := buildAuthorizationURL(metadata, url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"redirect_uri":          {redirectURI},
+		"state":                 {state},
+		"code_challenge":        {pkceChallenge(verifier)},
+		"code_challenge_method": {"S256"},
+		"resource":              {f.resource()},
+	}, f.Scopes)
+	if err != nil {
+		return Token{}, err
+	}
+
+	fmt.Fprintf(f.output(), "  Open this URL to sign in:\n  %s\n", authorizationURL)
+	if f.OpenBrowser != nil {
+		_ = f.OpenBrowser(authorizationURL)
+	}
+
+	var result callbackResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return Token{}, fmt.Errorf("wait for the OAuth redirect: %w", ctx.Err())
+	}
+
+	if result.err != nil {
+		return Token{}, result.err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {result.code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {clientID},
+		"code_verifier": {verifier},
+		"resource":      {f.resource()},
+	}
+	if clientSecret != "" {
+		form.Set("client_secret", clientSecret)
+	}
+
+	token, err := f.requestToken(ctx, metadata, form)
+	if err != nil {
+		return Token{}, fmt.Errorf("exchange authorization code: %w", err)
+	}
+
+	return token, nil
+}
+
+// callbackHandler answers the redirect to callbackPath and sends its code,
+// or the error it carries, on results. Requests with another state are
+// rejected without ending the wait, so a stray request cannot cut the login
+// short.
+func callbackHandler(state string, results chan<- callbackResult) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("state") != state {
+			http.Error(w, "state mismatch", http.StatusBadRequest)
+			return
+		}
+
+		var result callbackResult
+		message := "Signed in."
+		if code := query.Get("error"); code != "" {
+			result.err = &Error{Code: code, Description: query.Get("error_description")}
+			message = "Sign-in failed."
+		} else if result.code = query.Get("code"); result.code == "" {
+			result.err = errors.New("OAuth redirect has no code")
+			message = "Sign-in failed."
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, callbackPage, message)
+
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	return mux
+}
+
+// buildAuthorizationURL adds params and scopes to the authorization
+// endpoint, keeping any query it already has.
+func buildAuthorizationURL(metadata Metadata, params url.Values, scopes []string) (string, error) {
+	endpoint, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint %q: %w", metadata.AuthorizationEndpoint, err)
+	}
+
+	query := endpoint.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	if len(scopes) > 0 {
+		query.Set("scope", strings.Join(scopes, " "))
+	}
+
+	endpoint.RawQuery = query.Encode()
+	return endpoint.String(), nil
+}
+
+// pkceChallenge returns the S256 code challenge of verifier.
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// randomString returns n random bytes, base64url encoded.
+func randomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate random value: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
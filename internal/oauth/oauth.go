@@ -0,0 +1,372 @@
+// Package oauth obtains access tokens for remote MCP servers that use OAuth,
+// for targets that cannot run the login themselves. It discovers the
+// authorization server the way the MCP authorization spec describes,
+// registers mcp-wire as a public client when no client ID is given, and
+// runs either the authorization code flow with PKCE, with a loopback
+// redirect, or the device authorization flow.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/app"
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+)
+
+// ClientName is how mcp-wire names itself when it registers a client.
+const ClientName = "mcp-wire"
+
+// maxResponseBytes bounds the responses read from authorization servers.
+const maxResponseBytes = 1 << 20
+
+// Token is an access token and what came with it. ClientID, ClientSecret
+// and TokenEndpoint record the client that obtained it and where, so it can
+// be refreshed without discovering or registering again.
+type Token struct {
+	AccessToken   string    `json:"access_token"`
+	TokenType     string    `json:"token_type,omitempty"`
+	RefreshToken  string    `json:"refresh_token,omitempty"`
+	Scope         string    `json:"scope,omitempty"`
+	ExpiresAt     time.Time `json:"expires_at,omitzero"`
+	ClientID      string    `json:"client_id,omitempty"`
+	ClientSecret  string    `json:"client_secret,omitempty"`
+	TokenEndpoint string    `json:"token_endpoint,omitempty"`
+}
+
+// AuthorizationHeader returns the value of the Authorization header that
+// carries the token.
+func (t Token) AuthorizationHeader() string {
+	tokenType := t.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+
+	return tokenType + " " + t.AccessToken
+}
+
+// Metadata is the part of an authorization server's RFC 8414 metadata that
+// the flows use.
+type Metadata struct {
+	Issuer                      string   `json:"issuer,omitempty"`
+	AuthorizationEndpoint       string   `json:"authorization_endpoint"`
+	TokenEndpoint               string   `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string   `json:"device_authorization_endpoint,omitempty"`
+	RegistrationEndpoint        string   `json:"registration_endpoint,omitempty"`
+	ScopesSupported             []string `json:"scopes_supported,omitempty"`
+}
+
+// Error is an error response from an authorization server.
+type Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return "authorization server returned " + e.Code
+	}
+
+	return fmt.Sprintf("authorization server returned %s: %s", e.Code, e.Description)
+}
+
+// ErrDeviceFlowUnsupported is returned by AuthorizeDevice when the
+// authorization server has no device authorization endpoint.
+var ErrDeviceFlowUnsupported = errors.New("authorization server does not support the device flow")
+
+// Flow obtains an access token for one MCP server. The zero value of every
+// field but ServerURL is usable.
+type Flow struct {
+	// ServerURL is the URL of the MCP server the token is for.
+	ServerURL string
+	// ClientID is used when set; otherwise mcp-wire registers itself with
+	// the authorization server.
+	ClientID string
+	// Scopes are the scopes to request; empty leaves the choice to the
+	// server.
+	Scopes []string
+	// HTTPClient makes every request; nil uses a client with a timeout.
+	HTTPClient *http.Client
+	// OpenBrowser opens the authorization page. When it is nil or fails the
+	// URL is only printed.
+	OpenBrowser func(url string) error
+	// Output receives the instructions for the user.
+	Output io.Writer
+	// Clock dates the token expiry; nil uses the system clock.
+	Clock clock.Clock
+
+	// wait pauses between device flow polls; tests shorten it.
+	wait func(ctx context.Context, d time.Duration) error
+}
+
+const defaultTimeout = 30 * time.Second
+
+func (f *Flow) httpClient() *http.Client {
+	if f.HTTPClient != nil {
+		return f.HTTPClient
+	}
+
+	return &http.Client{Timeout: defaultTimeout}
+}
+
+func (f *Flow) output() io.Writer {
+	if f.Output != nil {
+		return f.Output
+	}
+
+	return io.Discard
+}
+
+// Discover finds the authorization server of the MCP server at serverURL.
+// It reads the RFC 9728 protected resource metadata to learn the server's
+// issuer, falling back to the MCP server's own origin, and then that
+// issuer's RFC 8414 or OpenID metadata. When the issuer publishes neither,
+// the default /authorize, /token and /register endpoints of the MCP spec
+// are assumed.
+func Discover(ctx context.Context, client *http.Client, serverURL string) (Metadata, error) {
+	resource, err := url.Parse(strings.TrimSpace(serverURL))
+	if err != nil || resource.Scheme == "" || resource.Host == "" {
+		return Metadata{}, fmt.Errorf("invalid server URL %q", serverURL)
+	}
+
+	issuer := resource.Scheme + "://" + resource.Host
+	var protected struct {
+		AuthorizationServers []string `json:"authorization_servers"`
+	}
+	for _, candidate := range wellKnownURLs(resource, "oauth-protected-resource") {
+		found, err := getJSON(ctx, client, candidate, &protected)
+		if err != nil {
+			return Metadata{}, err
+		}
+
+		if found && len(protected.AuthorizationServers) > 0 {
+			issuer = strings.TrimSpace(protected.AuthorizationServers[0])
+			break
+		}
+	}
+
+	issuerURL, err := url.Parse(issuer)
+	if err != nil || issuerURL.Scheme == "" || issuerURL.Host == "" {
+		return Metadata{}, fmt.Errorf("invalid authorization server %q", issuer)
+	}
+
+	for _, candidate := range append(wellKnownURLs(issuerURL, "oauth-authorization-server"), wellKnownURLs(issuerURL, "openid-configuration")...) {
+		var metadata Metadata
+		found, err := getJSON(ctx, client, candidate, &metadata)
+		if err != nil {
+			return Metadata{}, err
+		}
+
+		if !found {
+			continue
+		}
+
+		if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" {
+			return Metadata{}, fmt.Errorf("authorization server metadata at %s has no authorization or token endpoint", candidate)
+		}
+
+		return metadata, nil
+	}
+
+	origin := issuerURL.Scheme + "://" + issuerURL.Host
+	return Metadata{
+		Issuer:                origin,
+		AuthorizationEndpoint: origin + "/authorize",
+		TokenEndpoint:         origin + "/token",
+		RegistrationEndpoint:  origin + "/register",
+	}, nil
+}
+
+// wellKnownURLs returns where a metadata document called name may live for
+// base: after the path of base first, as RFC 8414 and RFC 9728 insert it,
+// and then at the root of its origin.
+func wellKnownURLs(base *url.URL, name string) []string {
+	origin := base.Scheme + "://" + base.Host
+	root := origin + "/.well-known/" + name
+
+	path := strings.TrimSuffix(base.EscapedPath(), "/")
+	if path == "" {
+		return []string{root}
+	}
+
+	return []string{root + path, root}
+}
+
+// getJSON decodes the document at rawURL into value. It reports false,
+// without an error, when the server has no such document.
+func getJSON(ctx context.Context, client *http.Client, rawURL string, value any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("mcp-wire/%s", app.Version))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return false, fmt.Errorf("read %s: %w", rawURL, err)
+	}
+
+	if err := json.Unmarshal(body, value); err != nil {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// register registers mcp-wire as a public client with redirectURI, or
+// without one for the device flow, and returns the client ID and, when the
+// server issued one, the secret.
+func (f *Flow) register(ctx context.Context, metadata Metadata, redirectURI string, grantTypes []string) (string, string, error) {
+	if f.ClientID != "" {
+		return f.ClientID, "", nil
+	}
+
+	if metadata.RegistrationEndpoint == "" {
+		return "", "", errors.New("authorization server does not support client registration; set a client ID")
+	}
+
+	request := map[string]any{
+		"client_name":                ClientName,
+		"grant_types":                grantTypes,
+		"token_endpoint_auth_method": "none",
+	}
+	if redirectURI != "" {
+		request["redirect_uris"] = []string{redirectURI}
+		request["response_types"] = []string{"code"}
+	}
+	if len(f.Scopes) > 0 {
+		request["scope"] = strings.Join(f.Scopes, " ")
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", "", fmt.Errorf("marshal client registration: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.RegistrationEndpoint, strings.NewReader(string(body)))
+	if err != nil {
+		return "", "", fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("mcp-wire/%s", app.Version))
+
+	var registered struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}
+	if err := f.do(req, &registered); err != nil {
+		return "", "", fmt.Errorf("register client: %w", err)
+	}
+
+	if registered.ClientID == "" {
+		return "", "", errors.New("register client: response has no client_id")
+	}
+
+	return registered.ClientID, registered.ClientSecret, nil
+}
+
+// requestToken posts form to the token endpoint and returns the token it
+// grants, recording the client of form and the endpoint on it.
+func (f *Flow) requestToken(ctx context.Context, metadata Metadata, form url.Values) (Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("mcp-wire/%s", app.Version))
+
+	var response struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		RefreshToken string `json:"refresh_token"`
+		Scope        string `json:"scope"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := f.do(req, &response); err != nil {
+		return Token{}, err
+	}
+
+	if response.AccessToken == "" {
+		return Token{}, errors.New("token response has no access_token")
+	}
+
+	token := Token{
+		AccessToken:   response.AccessToken,
+		TokenType:     response.TokenType,
+		RefreshToken:  response.RefreshToken,
+		Scope:         response.Scope,
+		ClientID:      form.Get("client_id"),
+		ClientSecret:  form.Get("client_secret"),
+		TokenEndpoint: metadata.TokenEndpoint,
+	}
+	if response.ExpiresIn > 0 {
+		token.ExpiresAt = clock.Stamp(f.Clock).Add(time.Duration(response.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// do sends req and decodes a successful JSON response into value. An OAuth
+// error response is returned as *Error.
+func (f *Flow) do(req *http.Request, value any) error {
+	resp, err := f.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var oauthErr Error
+		if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Code != "" {
+			return &oauthErr
+		}
+
+		return fmt.Errorf("%s returned HTTP %d", req.URL.Redacted(), resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(body, value); err != nil {
+		return fmt.Errorf("parse response from %s: %w", req.URL.Redacted(), err)
+	}
+
+	return nil
+}
+
+// resource returns the RFC 8707 resource indicator for the MCP server: its
+// URL without a fragment.
+func (f *Flow) resource() string {
+	resource, err := url.Parse(strings.TrimSpace(f.ServerURL))
+	if err != nil {
+		return strings.TrimSpace(f.ServerURL)
+	}
+
+	resource.Fragment = ""
+	return resource.String()
+}
@@ -0,0 +1,361 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+)
+
+// fakeAuthServer is an MCP server and its authorization server on one
+// origin.
+type fakeAuthServer struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	challenge     string
+	redirectURI   string
+	pendingPolls  int
+	tokenRequests []url.Values
+}
+
+func newFakeAuthServer(t *testing.T) *fakeAuthServer {
+	t.Helper()
+
+	s := &fakeAuthServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/oauth-protected-resource/mcp", func(w http.ResponseWriter, _ *http.Request) {
+		writeTestJSON(w, map[string]any{"authorization_servers": []string{s.URL + "/auth"}})
+	})
+	mux.HandleFunc("/.well-known/oauth-authorization-server/auth", func(w http.ResponseWriter, _ *http.Request) {
+		writeTestJSON(w, Metadata{
+			Issuer:                      s.URL + "/auth",
+			AuthorizationEndpoint:       s.URL + "/auth/authorize",
+			TokenEndpoint:               s.URL + "/auth/token",
+			DeviceAuthorizationEndpoint: s.URL + "/auth/device",
+			RegistrationEndpoint:        s.URL + "/auth/register",
+		})
+	})
+	mux.HandleFunc("/auth/register", func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ClientName   string   `json:"client_name"`
+			RedirectURIs []string `json:"redirect_uris"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.ClientName != ClientName {
+			http.Error(w, "bad registration", http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		if len(request.RedirectURIs) > 0 {
+			s.redirectURI = request.RedirectURIs[0]
+		}
+		s.mu.Unlock()
+
+		writeTestJSON(w, map[string]string{"client_id": "client-123"})
+	})
+	mux.HandleFunc("/auth/device", func(w http.ResponseWriter, _ *http.Request) {
+		writeTestJSON(w, map[string]any{
+			"device_code":      "device-abc",
+			"user_code":        "WDJB-MJHT",
+			"verification_uri": s.URL + "/auth/activate",
+			"interval":         1,
+			"expires_in":       600,
+		})
+	})
+	mux.HandleFunc("/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "bad form", http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.tokenRequests = append(s.tokenRequests, r.PostForm)
+
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			if r.PostForm.Get("code") != "code-xyz" || pkceChallenge(r.PostForm.Get("code_verifier")) != s.challenge {
+				w.WriteHeader(http.StatusBadRequest)
+				writeTestJSON(w, Error{Code: "invalid_grant"})
+				return
+			}
+		case deviceCodeGrant:
+			if s.pendingPolls > 0 {
+				s.pendingPolls--
+				w.WriteHeader(http.StatusBadRequest)
+				writeTestJSON(w, Error{Code: "authorization_pending"})
+				return
+			}
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refresh-1" {
+				w.WriteHeader(http.StatusBadRequest)
+				writeTestJSON(w, Error{Code: "invalid_grant"})
+				return
+			}
+
+			writeTestJSON(w, map[string]any{"access_token": "access-2", "token_type": "bearer", "expires_in": 7200})
+			return
+		}
+
+		writeTestJSON(w, map[string]any{
+			"access_token":  "access-1",
+			"token_type":    "bearer",
+			"refresh_token": "refresh-1",
+			"expires_in":    3600,
+		})
+	})
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func writeTestJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func TestDiscoverFollowsProtectedResourceMetadata(t *testing.T) {
+	server := newFakeAuthServer(t)
+
+	metadata, err := Discover(context.Background(), server.Client(), server.URL+"/mcp")
+	if err != nil {
+		t.Fatalf("expected discovery to succeed: %v", err)
+	}
+
+	if metadata.TokenEndpoint != server.URL+"/auth/token" {
+		t.Fatalf("expected token endpoint of the linked authorization server, got %#v", metadata)
+	}
+}
+
+func TestDiscoverFallsBackToDefaultEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	metadata, err := Discover(context.Background(), server.Client(), server.URL+"/v1/mcp")
+	if err != nil {
+		t.Fatalf("expected discovery to succeed: %v", err)
+	}
+
+	if metadata.AuthorizationEndpoint != server.URL+"/authorize" || metadata.RegistrationEndpoint != server.URL+"/register" {
+		t.Fatalf("expected default endpoints on the server origin, got %#v", metadata)
+	}
+}
+
+func TestAuthorizeExchangesCodeWithPKCE(t *testing.T) {
+	server := newFakeAuthServer(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var output strings.Builder
+	flow := &Flow{
+		ServerURL:  server.URL + "/mcp",
+		HTTPClient: server.Client(),
+		Output:     &output,
+		Clock:      clock.Fixed(now),
+		OpenBrowser: func(rawURL string) error {
+			authorizationURL, err := url.Parse(rawURL)
+			if err != nil {
+				return err
+			}
+
+			query := authorizationURL.Query()
+			if query.Get("resource") != server.URL+"/mcp" || query.Get("code_challenge_method") != "S256" {
+				t.Errorf("unexpected authorization request %q", rawURL)
+			}
+
+			server.mu.Lock()
+			server.challenge = query.Get("code_challenge")
+			redirectURI := server.redirectURI
+			server.mu.Unlock()
+
+			if query.Get("redirect_uri") != redirectURI {
+				t.Errorf("expected registered redirect URI %q, got %q", redirectURI, query.Get("redirect_uri"))
+			}
+
+			// Act as the browser following the authorization server's redirect.
+			go func() {
+				resp, err := http.Get(redirectURI + "?code=code-xyz&state=" + url.QueryEscape(query.Get("state")))
+				if err == nil {
+					resp.Body.Close()
+				}
+			}()
+
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	token, err := flow.Authorize(ctx)
+	if err != nil {
+		t.Fatalf("expected authorization to succeed: %v", err)
+	}
+
+	if token.AccessToken != "access-1" || token.RefreshToken != "refresh-1" {
+		t.Fatalf("unexpected token %#v", token)
+	}
+
+	if !token.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expected expiry an hour from now, got %v", token.ExpiresAt)
+	}
+
+	if token.AuthorizationHeader() != "Bearer access-1" {
+		t.Fatalf("unexpected authorization header %q", token.AuthorizationHeader())
+	}
+
+	if !strings.Contains(output.String(), server.URL+"/auth/authorize?") {
+		t.Fatalf("expected authorization URL to be printed, got %q", output.String())
+	}
+}
+
+func TestAuthorizeRejectsRedirectWithError(t *testing.T) {
+	server := newFakeAuthServer(t)
+
+	flow := &Flow{
+		ServerURL:  server.URL + "/mcp",
+		HTTPClient: server.Client(),
+		OpenBrowser: func(rawURL string) error {
+			authorizationURL, _ := url.Parse(rawURL)
+			query := authorizationURL.Query()
+			go func() {
+				resp, err := http.Get(query.Get("redirect_uri") + "?error=access_denied&state=" + url.QueryEscape(query.Get("state")))
+				if err == nil {
+					resp.Body.Close()
+				}
+			}()
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := flow.Authorize(ctx)
+	var oauthErr *Error
+	if !errors.As(err, &oauthErr) || oauthErr.Code != "access_denied" {
+		t.Fatalf("expected access_denied error, got %v", err)
+	}
+}
+
+func TestAuthorizeDevicePollsUntilApproved(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.pendingPolls = 2
+
+	var output strings.Builder
+	var waits []time.Duration
+	flow := &Flow{
+		ServerURL:  server.URL + "/mcp",
+		HTTPClient: server.Client(),
+		Output:     &output,
+		wait: func(_ context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			return nil
+		},
+	}
+
+	token, err := flow.AuthorizeDevice(context.Background())
+	if err != nil {
+		t.Fatalf("expected device authorization to succeed: %v", err)
+	}
+
+	if token.AccessToken != "access-1" {
+		t.Fatalf("unexpected token %#v", token)
+	}
+
+	if len(waits) != 3 || waits[0] != time.Second {
+		t.Fatalf("expected three polls a second apart, got %v", waits)
+	}
+
+	if !strings.Contains(output.String(), "enter the code WDJB-MJHT") {
+		t.Fatalf("expected user code to be printed, got %q", output.String())
+	}
+
+	last := server.tokenRequests[len(server.tokenRequests)-1]
+	if last.Get("device_code") != "device-abc" || last.Get("client_id") != "client-123" {
+		t.Fatalf("unexpected token request %v", last)
+	}
+}
+
+func TestAuthorizeDeviceRequiresDeviceEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	flow := &Flow{ServerURL: server.URL + "/mcp", HTTPClient: server.Client(), ClientID: "fixed"}
+	if _, err := flow.AuthorizeDevice(context.Background()); !errors.Is(err, ErrDeviceFlowUnsupported) {
+		t.Fatalf("expected ErrDeviceFlowUnsupported, got %v", err)
+	}
+}
+
+func TestRefreshUsesRecordedClientAndKeepsRefreshToken(t *testing.T) {
+	server := newFakeAuthServer(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	flow := &Flow{ServerURL: server.URL + "/mcp", HTTPClient: server.Client(), Clock: clock.Fixed(now)}
+	token, err := flow.Refresh(context.Background(), Token{
+		AccessToken:   "access-1",
+		RefreshToken:  "refresh-1",
+		Scope:         "read",
+		ClientID:      "client-123",
+		TokenEndpoint: server.URL + "/auth/token",
+	})
+	if err != nil {
+		t.Fatalf("expected refresh to succeed: %v", err)
+	}
+
+	if token.AccessToken != "access-2" || token.RefreshToken != "refresh-1" || token.Scope != "read" {
+		t.Fatalf("expected new access token with the old refresh token and scope, got %#v", token)
+	}
+
+	if token.ClientID != "client-123" || token.TokenEndpoint != server.URL+"/auth/token" {
+		t.Fatalf("expected client and endpoint to be recorded, got %#v", token)
+	}
+
+	if !token.ExpiresAt.Equal(now.Add(2 * time.Hour)) {
+		t.Fatalf("expected expiry two hours from now, got %v", token.ExpiresAt)
+	}
+
+	last := server.tokenRequests[len(server.tokenRequests)-1]
+	if last.Get("grant_type") != "refresh_token" || last.Get("client_id") != "client-123" {
+		t.Fatalf("unexpected token request %v", last)
+	}
+}
+
+func TestRefreshRequiresRefreshToken(t *testing.T) {
+	flow := &Flow{ServerURL: "https://mcp.example.com/mcp"}
+	if _, err := flow.Refresh(context.Background(), Token{AccessToken: "access-1"}); !errors.Is(err, ErrNoRefreshToken) {
+		t.Fatalf("expected ErrNoRefreshToken, got %v", err)
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := clock.Fixed(now)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      bool
+	}{
+		{name: "no expiry"},
+		{name: "valid", expiresAt: now.Add(time.Hour)},
+		{name: "within leeway", expiresAt: now.Add(30 * time.Second), want: true},
+		{name: "past", expiresAt: now.Add(-time.Hour), want: true},
+	}
+
+	for _, tt := range tests {
+		if got := (Token{ExpiresAt: tt.expiresAt}).Expired(c); got != tt.want {
+			t.Errorf("%s: Expired = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+)
+
+// expiryLeeway is how long before its expiry a token already counts as
+// expired, so it is not sent just as it runs out.
+const expiryLeeway = time.Minute
+
+// ErrNoRefreshToken is returned by Refresh for a token that came without a
+// refresh token; only a new sign-in can replace it.
+var ErrNoRefreshToken = errors.New("token has no refresh token")
+
+// Expired reports whether t has expired, or will within a minute, by the
+// current time of c. A token without an expiry never expires.
+func (t Token) Expired(c clock.Clock) bool {
+	if t.ExpiresAt.IsZero() {
+		return false
+	}
+
+	return !clock.Stamp(c).Add(expiryLeeway).Before(t.ExpiresAt)
+}
+
+// Refresh exchanges the refresh token of token for a new access token, at
+// the token endpoint and with the client recorded on token. Endpoints and
+// clients missing from older tokens come from discovery and ClientID. The
+// refresh token and scope are kept when the server does not issue new ones.
+func (f *Flow) Refresh(ctx context.Context, token Token) (Token, error) {
+	if token.RefreshToken == "" {
+		return Token{}, ErrNoRefreshToken
+	}
+
+	metadata := Metadata{TokenEndpoint: token.TokenEndpoint}
+	if metadata.TokenEndpoint == "" {
+		discovered, err := Discover(ctx, f.httpClient(), f.ServerURL)
+		if err != nil {
+			return Token{}, err
+		}
+		metadata = discovered
+	}
+
+	clientID := token.ClientID
+	if clientID == "" {
+		clientID = f.ClientID
+	}
+	if clientID == "" {
+		return Token{}, errors.New("token does not record the client that obtained it; sign in again")
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {token.RefreshToken},
+		"client_id":     {clientID},
+		"resource":      {f.resource()},
+	}
+	if token.ClientSecret != "" {
+		form.Set("client_secret", token.ClientSecret)
+	}
+
+	refreshed, err := f.requestToken(ctx, metadata, form)
+	if err != nil {
+		return Token{}, fmt.Errorf("refresh token: %w", err)
+	}
+
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+	if refreshed.Scope == "" {
+		refreshed.Scope = token.Scope
+	}
+
+	return refreshed, nil
+}
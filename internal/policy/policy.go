@@ -0,0 +1,184 @@
+// Package policy evaluates an organization-provided policy file against the
+// operations mcp-wire is about to apply. Rules are CEL expressions over the
+// pending operation; every rule must hold for the operation to proceed.
+package policy
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"gopkg.in/yaml.v3"
+)
+
+// Operation describes one pending change on one target, as seen by policy
+// rules. Secrets lists credential names only, never their values.
+type Operation struct {
+	Action    string // "install" or "uninstall"
+	Service   string
+	Source    string // "curated" or "registry"
+	Transport string
+	Target    string
+	Scope     string
+	Secrets   []string
+}
+
+// Rule is one entry of a policy file.
+type Rule struct {
+	Name       string `yaml:"name"`
+	Expression string `yaml:"expression"`
+	Message    string `yaml:"message,omitempty"`
+}
+
+type file struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+type compiledRule struct {
+	Rule
+	program cel.Program
+}
+
+// Policy is a compiled policy file.
+type Policy struct {
+	path  string
+	rules []compiledRule
+}
+
+// Violation is a rule an operation did not satisfy.
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+// DeniedError is returned by Evaluate when an operation breaks one or more
+// rules.
+type DeniedError struct {
+	Operation  Operation
+	Path       string
+	Violations []Violation
+}
+
+func (e *DeniedError) Error() string {
+	reasons := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		reasons = append(reasons, fmt.Sprintf("%s (rule %q)", violation.Message, violation.Rule))
+	}
+
+	return fmt.Sprintf("policy %s denies %s of %q on %s: %s", e.Path, e.Operation.Action, e.Operation.Service, e.Operation.Target, strings.Join(reasons, "; "))
+}
+
+// Load reads and compiles the policy file at path. Every expression must
+// compile to a boolean, so a broken policy is reported before anything is
+// applied.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read policy file %q: %w", path, err)
+	}
+
+	var parsed file
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parse policy file %q: %w", path, err)
+	}
+
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &Policy{path: path}
+	for i, rule := range parsed.Rules {
+		rule.Name = strings.TrimSpace(rule.Name)
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+
+		if strings.TrimSpace(rule.Expression) == "" {
+			return nil, fmt.Errorf("policy file %q: rule %q has no expression", path, rule.Name)
+		}
+
+		if strings.TrimSpace(rule.Message) == "" {
+			rule.Message = "operation is not allowed"
+		}
+
+		ast, issues := env.Compile(rule.Expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("policy file %q: rule %q: %w", path, rule.Name, issues.Err())
+		}
+
+		if ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("policy file %q: rule %q must evaluate to a bool, not %s", path, rule.Name, ast.OutputType())
+		}
+
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("policy file %q: rule %q: %w", path, rule.Name, err)
+		}
+
+		policy.rules = append(policy.rules, compiledRule{Rule: rule, program: program})
+	}
+
+	return policy, nil
+}
+
+// Evaluate checks operation against every rule and returns a *DeniedError
+// listing the ones it breaks. A rule that fails to evaluate denies the
+// operation too.
+func (p *Policy) Evaluate(operation Operation) error {
+	if p == nil {
+		return nil
+	}
+
+	secrets := operation.Secrets
+	if secrets == nil {
+		secrets = []string{}
+	}
+
+	activation := map[string]any{
+		"action":    operation.Action,
+		"service":   operation.Service,
+		"source":    operation.Source,
+		"transport": operation.Transport,
+		"target":    operation.Target,
+		"scope":     operation.Scope,
+		"secrets":   secrets,
+	}
+
+	var violations []Violation
+	for _, rule := range p.rules {
+		out, _, err := rule.program.Eval(activation)
+		if err != nil {
+			violations = append(violations, Violation{Rule: rule.Name, Message: fmt.Sprintf("rule could not be evaluated: %v", err)})
+			continue
+		}
+
+		if allowed, ok := out.Value().(bool); !ok || !allowed {
+			violations = append(violations, Violation{Rule: rule.Name, Message: rule.Message})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &DeniedError{Operation: operation, Path: p.path, Violations: violations}
+	}
+
+	return nil
+}
+
+func newEnv() (*cel.Env, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("action", cel.StringType),
+		cel.Variable("service", cel.StringType),
+		cel.Variable("source", cel.StringType),
+		cel.Variable("transport", cel.StringType),
+		cel.Variable("target", cel.StringType),
+		cel.Variable("scope", cel.StringType),
+		cel.Variable("secrets", cel.ListType(cel.StringType)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create policy environment: %w", err)
+	}
+
+	return env, nil
+}
@@ -0,0 +1,83 @@
+package policy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writePolicyFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+
+	return path
+}
+
+func TestEvaluateReportsEveryBrokenRule(t *testing.T) {
+	path := writePolicyFile(t, `rules:
+  - name: no-registry-stdio
+    expression: 'source != "registry" || transport != "stdio"'
+    message: Local servers from the MCP Registry are not allowed.
+  - name: no-system-scope
+    expression: 'scope != "system"'
+  - name: no-aws-keys
+    expression: '!secrets.exists(s, s.startsWith("AWS_"))'
+    message: Services may not use AWS credentials.
+`)
+
+	rules, err := Load(path)
+	if err != nil {
+		t.Fatalf("expected policy to load: %v", err)
+	}
+
+	allowed := Operation{Action: "install", Service: "sentry", Source: "curated", Transport: "sse", Target: "claude", Scope: "user", Secrets: []string{"SENTRY_TOKEN"}}
+	if err := rules.Evaluate(allowed); err != nil {
+		t.Fatalf("expected operation to be allowed: %v", err)
+	}
+
+	denied := Operation{Action: "install", Service: "ai.example/aws", Source: "registry", Transport: "stdio", Target: "codex", Scope: "system", Secrets: []string{"AWS_SECRET_ACCESS_KEY"}}
+	err = rules.Evaluate(denied)
+
+	var deniedErr *DeniedError
+	if !errors.As(err, &deniedErr) {
+		t.Fatalf("expected DeniedError, got %v", err)
+	}
+
+	if len(deniedErr.Violations) != 3 {
+		t.Fatalf("expected three violations, got %#v", deniedErr.Violations)
+	}
+
+	for _, want := range []string{
+		`denies install of "ai.example/aws" on codex`,
+		`Local servers from the MCP Registry are not allowed. (rule "no-registry-stdio")`,
+		`operation is not allowed (rule "no-system-scope")`,
+		`Services may not use AWS credentials. (rule "no-aws-keys")`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in %q", want, err.Error())
+		}
+	}
+}
+
+func TestLoadRejectsBrokenRules(t *testing.T) {
+	tests := map[string]string{
+		"syntax":        "rules:\n  - name: broken\n    expression: 'service =='\n",
+		"not a bool":    "rules:\n  - name: string\n    expression: 'service'\n",
+		"unknown field": "rules:\n  - name: unknown\n    expression: 'owner == \"me\"'\n",
+		"empty":         "rules:\n  - name: empty\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Load(writePolicyFile(t, content)); err == nil {
+				t.Fatal("expected policy to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,382 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const sessionHeader = "Mcp-Session-Id"
+
+// httpStatusError reports a response with an unexpected status.
+func httpStatusError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%w (HTTP %d)", ErrUnauthorized, resp.StatusCode)
+	}
+
+	return fmt.Errorf("HTTP %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+}
+
+// streamableHTTPConn talks to a server over the streamable HTTP transport:
+// every message is POSTed, and responses come back as JSON or as a short
+// event stream.
+type streamableHTTPConn struct {
+	client    *http.Client
+	url       string
+	headers   map[string]string
+	sessionID string
+	protocol  string
+}
+
+func newStreamableHTTP(rawURL string, headers map[string]string) *streamableHTTPConn {
+	return &streamableHTTPConn{client: http.DefaultClient, url: rawURL, headers: headers}
+}
+
+func (c *streamableHTTPConn) post(ctx context.Context, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	c.setHeaders(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, httpStatusError(resp)
+	}
+
+	if sessionID := resp.Header.Get(sessionHeader); sessionID != "" {
+		c.sessionID = sessionID
+	}
+
+	return resp, nil
+}
+
+func (c *streamableHTTPConn) setHeaders(req *http.Request) {
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
+	if c.sessionID != "" {
+		req.Header.Set(sessionHeader, c.sessionID)
+	}
+
+	if c.protocol != "" {
+		req.Header.Set("MCP-Protocol-Version", c.protocol)
+	}
+}
+
+func (c *streamableHTTPConn) call(ctx context.Context, id int, method string, params any) (json.RawMessage, error) {
+	request, err := encodeRequest(id, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.post(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "text/event-stream" {
+		return c.readStreamedResponse(resp.Body, id, method)
+	}
+
+	var msg message
+	if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	if !msg.responseTo(id) {
+		return nil, errors.New("response does not match the request")
+	}
+
+	return c.remember(method, msg)
+}
+
+func (c *streamableHTTPConn) readStreamedResponse(body io.Reader, id int, method string) (json.RawMessage, error) {
+	events := bufio.NewReader(body)
+	for {
+		event, err := readEvent(events)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("stream ended without a response")
+			}
+
+			return nil, err
+		}
+
+		var msg message
+		if json.Unmarshal([]byte(event.data), &msg) != nil || !msg.responseTo(id) {
+			continue
+		}
+
+		return c.remember(method, msg)
+	}
+}
+
+// remember keeps the negotiated protocol version, which later requests
+// must send.
+func (c *streamableHTTPConn) remember(method string, msg message) (json.RawMessage, error) {
+	result, err := msg.outcome()
+	if err != nil || method != "initialize" {
+		return result, err
+	}
+
+	var initialized struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if json.Unmarshal(result, &initialized) == nil {
+		c.protocol = initialized.ProtocolVersion
+	}
+
+	return result, nil
+}
+
+func (c *streamableHTTPConn) notify(ctx context.Context, method string, params any) error {
+	notification, err := encodeNotification(method, params)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.post(ctx, notification)
+	if err != nil {
+		return err
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// close ends the session on servers that issued one.
+func (c *streamableHTTPConn) close() error {
+	if c.sessionID == "" {
+		return nil
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, c.url, nil)
+	if err != nil {
+		return err
+	}
+
+	c.setHeaders(req)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// sseConn talks to a server over the older HTTP+SSE transport: responses
+// arrive on a long-lived event stream, and messages are POSTed to the
+// endpoint the stream announces first.
+type sseConn struct {
+	client   *http.Client
+	headers  map[string]string
+	endpoint string
+	body     io.ReadCloser
+	events   chan sseEvent
+	errs     chan error
+	done     chan struct{}
+}
+
+func dialSSE(ctx context.Context, rawURL string, headers map[string]string) (*sseConn, error) {
+	base, err := url.Parse(rawURL)
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q", rawURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, httpStatusError(resp)
+	}
+
+	c := &sseConn{
+		client:  http.DefaultClient,
+		headers: headers,
+		body:    resp.Body,
+		events:  make(chan sseEvent),
+		errs:    make(chan error, 1),
+		done:    make(chan struct{}),
+	}
+	go c.readEvents()
+
+	select {
+	case <-ctx.Done():
+		c.close()
+		return nil, fmt.Errorf("no endpoint event: %w", ctx.Err())
+	case err := <-c.errs:
+		c.close()
+		return nil, fmt.Errorf("read event stream: %w", err)
+	case event := <-c.events:
+		if event.name != "endpoint" {
+			c.close()
+			return nil, fmt.Errorf("expected an endpoint event, got %q", event.name)
+		}
+
+		endpoint, err := base.Parse(strings.TrimSpace(event.data))
+		if err != nil {
+			c.close()
+			return nil, fmt.Errorf("invalid endpoint %q: %w", event.data, err)
+		}
+
+		c.endpoint = endpoint.String()
+	}
+
+	return c, nil
+}
+
+func (c *sseConn) readEvents() {
+	events := bufio.NewReader(c.body)
+	for {
+		event, err := readEvent(events)
+		if err != nil {
+			c.errs <- err
+			return
+		}
+
+		select {
+		case c.events <- event:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *sseConn) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return httpStatusError(resp)
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func (c *sseConn) call(ctx context.Context, id int, method string, params any) (json.RawMessage, error) {
+	request, err := encodeRequest(id, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.post(ctx, request); err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no response: %w", ctx.Err())
+		case err := <-c.errs:
+			c.errs <- err
+			return nil, fmt.Errorf("event stream closed: %w", err)
+		case event := <-c.events:
+			var msg message
+			if json.Unmarshal([]byte(event.data), &msg) != nil || !msg.responseTo(id) {
+				continue
+			}
+
+			return msg.outcome()
+		}
+	}
+}
+
+func (c *sseConn) notify(ctx context.Context, method string, params any) error {
+	notification, err := encodeNotification(method, params)
+	if err != nil {
+		return err
+	}
+
+	return c.post(ctx, notification)
+}
+
+// close drops the event stream, which also stops the reader goroutine.
+func (c *sseConn) close() error {
+	close(c.done)
+	return c.body.Close()
+}
+
+// sseEvent is one server-sent event.
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readEvent reads the next event from an event stream. Comments and fields
+// other than event and data are skipped; the name defaults to "message".
+func readEvent(r *bufio.Reader) (sseEvent, error) {
+	event := sseEvent{name: "message"}
+	var data []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil && (line == "" || !errors.Is(err, io.EOF)) {
+			return sseEvent{}, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if len(data) == 0 {
+				continue
+			}
+
+			event.data = strings.Join(data, "\n")
+			return event, nil
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Package probe connects to an MCP server the way a target would, performs
+// the initialize exchange and counts the tools it offers, to check that an
+// installed service actually works.
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/app"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+// ProtocolVersion is the MCP protocol revision offered during initialize.
+const ProtocolVersion = "2025-06-18"
+
+// maxToolPages bounds tools/list pagination against servers that keep
+// returning a cursor.
+const maxToolPages = 50
+
+// ErrUnauthorized is returned when a remote server rejects the connection
+// for lack of credentials, for example before OAuth has been completed.
+var ErrUnauthorized = errors.New("server requires authentication")
+
+// Result describes a server that completed the initialize exchange.
+type Result struct {
+	ServerName      string
+	ServerVersion   string
+	ProtocolVersion string
+	Tools           int
+}
+
+// Server starts or connects to svc, with env added to the environment of
+// stdio servers, and reports what it offers. ctx bounds the whole exchange;
+// a stdio server is stopped before Server returns.
+func Server(ctx context.Context, svc service.Service, env map[string]string) (Result, error) {
+	var c conn
+	var err error
+	switch strings.ToLower(strings.TrimSpace(svc.Transport)) {
+	case "stdio":
+		c, err = startStdio(ctx, svc.Command, svc.Args, env)
+	case "sse":
+		c, err = dialSSE(ctx, svc.URL, svc.Headers)
+	case "http", "":
+		if strings.TrimSpace(svc.URL) == "" {
+			return Result{}, errors.New("service has no URL to connect to")
+		}
+		c = newStreamableHTTP(svc.URL, svc.Headers)
+	default:
+		return Result{}, fmt.Errorf("unsupported transport %q", svc.Transport)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	defer c.close()
+
+	s := &session{conn: c}
+	return s.run(ctx)
+}
+
+// conn carries JSON-RPC messages to one server.
+type conn interface {
+	// call sends a request and decodes the result of the matching response.
+	call(ctx context.Context, id int, method string, params any) (json.RawMessage, error)
+	// notify sends a notification, which has no response.
+	notify(ctx context.Context, method string, params any) error
+	close() error
+}
+
+type session struct {
+	conn   conn
+	nextID int
+}
+
+func (s *session) call(ctx context.Context, method string, params any, result any) error {
+	s.nextID++
+	raw, err := s.conn.call(ctx, s.nextID, method, params)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+
+	if err := json.Unmarshal(raw, result); err != nil {
+		return fmt.Errorf("%s: decode result: %w", method, err)
+	}
+
+	return nil
+}
+
+func (s *session) run(ctx context.Context) (Result, error) {
+	var initialized struct {
+		ProtocolVersion string          `json:"protocolVersion"`
+		Capabilities    json.RawMessage `json:"capabilities"`
+		ServerInfo      struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"serverInfo"`
+	}
+
+	err := s.call(ctx, "initialize", map[string]any{
+		"protocolVersion": ProtocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]string{"name": app.Name, "version": app.Version},
+	}, &initialized)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{
+		ServerName:      initialized.ServerInfo.Name,
+		ServerVersion:   initialized.ServerInfo.Version,
+		ProtocolVersion: initialized.ProtocolVersion,
+	}
+
+	if err := s.conn.notify(ctx, "notifications/initialized", nil); err != nil {
+		return result, fmt.Errorf("notifications/initialized: %w", err)
+	}
+
+	var capabilities map[string]json.RawMessage
+	_ = json.Unmarshal(initialized.Capabilities, &capabilities)
+	if _, hasTools := capabilities["tools"]; !hasTools {
+		return result, nil
+	}
+
+	cursor := ""
+	for range maxToolPages {
+		params := map[string]any{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+
+		var page struct {
+			Tools      []json.RawMessage `json:"tools"`
+			NextCursor string            `json:"nextCursor"`
+		}
+		if err := s.call(ctx, "tools/list", params, &page); err != nil {
+			return result, err
+		}
+
+		result.Tools += len(page.Tools)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	return result, nil
+}
+
+// message is any JSON-RPC message a server sends.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("server error %d: %s", e.Code, e.Message)
+}
+
+// responseTo reports whether m answers the request with id.
+func (m message) responseTo(id int) bool {
+	if m.Method != "" || len(m.ID) == 0 {
+		return false
+	}
+
+	return strings.Trim(string(m.ID), `"`) == strconv.Itoa(id)
+}
+
+// outcome returns the result of a response, or its error.
+func (m message) outcome() (json.RawMessage, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+
+	if len(m.Result) == 0 {
+		return json.RawMessage(`{}`), nil
+	}
+
+	return m.Result, nil
+}
+
+func encodeRequest(id int, method string, params any) ([]byte, error) {
+	request := map[string]any{"jsonrpc": "2.0", "id": id, "method": method}
+	if params != nil {
+		request["params"] = params
+	}
+
+	return json.Marshal(request)
+}
+
+func encodeNotification(method string, params any) ([]byte, error) {
+	notification := map[string]any{"jsonrpc": "2.0", "method": method}
+	if params != nil {
+		notification["params"] = params
+	}
+
+	return json.Marshal(notification)
+}
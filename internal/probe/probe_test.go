@@ -0,0 +1,270 @@
+package probe
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+// TestMain lets the test binary act as a stdio MCP server when
+// PROBE_FAKE_SERVER is set, so stdio probes spawn a real process.
+func TestMain(m *testing.M) {
+	switch os.Getenv("PROBE_FAKE_SERVER") {
+	case "":
+		os.Exit(m.Run())
+	case "crash":
+		fmt.Fprintln(os.Stderr, "starting")
+		fmt.Fprintln(os.Stderr, "FAKE_TOKEN is not set")
+		os.Exit(1)
+	default:
+		runFakeStdioServer()
+		os.Exit(0)
+	}
+}
+
+func runFakeStdioServer() {
+	fmt.Println("fake server booting")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var request struct {
+			ID     *int            `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &request) != nil || request.ID == nil {
+			continue
+		}
+
+		response := map[string]any{"jsonrpc": "2.0", "id": *request.ID}
+		response["result"] = fakeResult(request.Method, request.Params, os.Getenv("FAKE_TOKEN"))
+		data, _ := json.Marshal(response)
+		fmt.Println(string(data))
+	}
+}
+
+// fakeResult answers initialize and tools/list, splitting three tools over
+// two pages.
+func fakeResult(method string, params json.RawMessage, serverVersion string) any {
+	switch method {
+	case "initialize":
+		return map[string]any{
+			"protocolVersion": ProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": "fake", "version": serverVersion},
+		}
+	case "tools/list":
+		var listParams struct {
+			Cursor string `json:"cursor"`
+		}
+		_ = json.Unmarshal(params, &listParams)
+		if listParams.Cursor == "" {
+			return map[string]any{"tools": []map[string]string{{"name": "a"}, {"name": "b"}}, "nextCursor": "page-2"}
+		}
+
+		return map[string]any{"tools": []map[string]string{{"name": "c"}}}
+	default:
+		return map[string]any{}
+	}
+}
+
+func probeContext(t *testing.T) context.Context {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestServerStdioCountsToolsAcrossPages(t *testing.T) {
+	t.Setenv("PROBE_FAKE_SERVER", "serve")
+
+	svc := service.Service{Name: "fake", Transport: "stdio", Command: os.Args[0]}
+	result, err := Server(probeContext(t), svc, map[string]string{"FAKE_TOKEN": "1.2.3"})
+	if err != nil {
+		t.Fatalf("expected probe to succeed: %v", err)
+	}
+
+	if result.ServerName != "fake" || result.ServerVersion != "1.2.3" || result.Tools != 3 {
+		t.Fatalf("unexpected result %#v", result)
+	}
+}
+
+func TestServerStdioReportsExitWithStderr(t *testing.T) {
+	t.Setenv("PROBE_FAKE_SERVER", "crash")
+
+	svc := service.Service{Name: "fake", Transport: "stdio", Command: os.Args[0]}
+	_, err := Server(probeContext(t), svc, nil)
+	if err == nil || !strings.Contains(err.Error(), "server exited before answering") || !strings.Contains(err.Error(), "FAKE_TOKEN is not set") {
+		t.Fatalf("expected exit error with the last stderr line, got %v", err)
+	}
+}
+
+func TestServerStdioReportsMissingCommand(t *testing.T) {
+	svc := service.Service{Name: "fake", Transport: "stdio", Command: "mcp-wire-no-such-binary"}
+	_, err := Server(probeContext(t), svc, nil)
+	if err == nil || !strings.Contains(err.Error(), "start mcp-wire-no-such-binary") {
+		t.Fatalf("expected start error, got %v", err)
+	}
+}
+
+func TestServerStreamableHTTP(t *testing.T) {
+	var sawSession, sawProtocol, sawAuth, deleted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = r.Header.Get("Mcp-Session-Id") == "session-1"
+			return
+		}
+
+		sawAuth = r.Header.Get("Authorization") == "Bearer secret"
+		var request struct {
+			ID     *int            `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+
+		if request.Method != "initialize" {
+			sawSession = r.Header.Get("Mcp-Session-Id") == "session-1"
+			sawProtocol = r.Header.Get("MCP-Protocol-Version") == ProtocolVersion
+		}
+
+		if request.ID == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		data, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": *request.ID, "result": fakeResult(request.Method, request.Params, "2.0")})
+		if request.Method == "initialize" {
+			w.Header().Set("Mcp-Session-Id", "session-1")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(data)
+			return
+		}
+
+		// Later responses come as a short event stream, after a
+		// notification the client must skip.
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/message\"}\n\n")
+		fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+	}))
+	defer server.Close()
+
+	svc := service.Service{Name: "fake", Transport: "http", URL: server.URL, Headers: map[string]string{"Authorization": "Bearer secret"}}
+	result, err := Server(probeContext(t), svc, nil)
+	if err != nil {
+		t.Fatalf("expected probe to succeed: %v", err)
+	}
+
+	if result.Tools != 3 || result.ServerVersion != "2.0" {
+		t.Fatalf("unexpected result %#v", result)
+	}
+
+	if !sawSession || !sawProtocol || !sawAuth || !deleted {
+		t.Fatalf("expected session, protocol and auth headers and a closing DELETE, got session=%v protocol=%v auth=%v deleted=%v", sawSession, sawProtocol, sawAuth, deleted)
+	}
+}
+
+func TestServerReportsUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	for _, transport := range []string{"http", "sse"} {
+		_, err := Server(probeContext(t), service.Service{Name: "fake", Transport: transport, URL: server.URL}, nil)
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected %s probe to report ErrUnauthorized, got %v", transport, err)
+		}
+	}
+}
+
+func TestServerLegacySSE(t *testing.T) {
+	responses := make(chan []byte, 4)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /sse", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: endpoint\ndata: /messages?session=1\n\n")
+		w.(http.Flusher).Flush()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case data := <-responses:
+				fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+				w.(http.Flusher).Flush()
+			}
+		}
+	})
+	mux.HandleFunc("POST /messages", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("session") != "1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var request struct {
+			ID     *int            `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &request)
+		w.WriteHeader(http.StatusAccepted)
+
+		if request.ID != nil {
+			data, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": *request.ID, "result": fakeResult(request.Method, request.Params, "3.0")})
+			responses <- data
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	result, err := Server(probeContext(t), service.Service{Name: "fake", Transport: "sse", URL: server.URL + "/sse"}, nil)
+	if err != nil {
+		t.Fatalf("expected probe to succeed: %v", err)
+	}
+
+	if result.Tools != 3 || result.ServerVersion != "3.0" {
+		t.Fatalf("unexpected result %#v", result)
+	}
+}
+
+func TestServerWithoutToolsCapabilitySkipsListing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     *int   `json:"id"`
+			Method string `json:"method"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		if request.ID == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		if request.Method != "initialize" {
+			t.Errorf("unexpected %s request", request.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"protocolVersion":%q,"capabilities":{},"serverInfo":{"name":"bare"}}}`, *request.ID, ProtocolVersion)
+	}))
+	defer server.Close()
+
+	result, err := Server(probeContext(t), service.Service{Name: "bare", Transport: "http", URL: server.URL}, nil)
+	if err != nil || result.ServerName != "bare" || result.Tools != 0 {
+		t.Fatalf("expected bare server to initialize with no tools, got %#v, %v", result, err)
+	}
+}
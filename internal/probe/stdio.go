@@ -0,0 +1,211 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// stderrTailBytes is how much of a stdio server's stderr is kept to explain
+// why it stopped.
+const stderrTailBytes = 2048
+
+// stdioConn talks to a server started as a child process, one JSON-RPC
+// message per line on stdin and stdout.
+type stdioConn struct {
+	cmd    *exec.Cmd
+	cancel context.CancelFunc
+	stdin  io.WriteCloser
+	lines  chan []byte
+	stderr *tailBuffer
+	done   chan struct{}
+}
+
+func startStdio(ctx context.Context, command string, args []string, env map[string]string) (*stdioConn, error) {
+	if strings.TrimSpace(command) == "" {
+		return nil, errors.New("service has no command to start")
+	}
+
+	processCtx, cancel := context.WithCancel(context.Background())
+	cmd := exec.CommandContext(processCtx, command, args...)
+	cmd.Env = os.Environ()
+	for name, value := range env {
+		cmd.Env = append(cmd.Env, name+"="+value)
+	}
+	cmd.WaitDelay = time.Second
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	stderr := &tailBuffer{limit: stderrTailBytes}
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("start %s: %w", command, err)
+	}
+
+	c := &stdioConn{
+		cmd:    cmd,
+		cancel: cancel,
+		stdin:  stdin,
+		lines:  make(chan []byte),
+		stderr: stderr,
+		done:   make(chan struct{}),
+	}
+
+	go c.readLines(stdout)
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.cancel()
+		case <-c.done:
+		}
+	}()
+
+	return c, nil
+}
+
+func (c *stdioConn) readLines(stdout io.Reader) {
+	defer close(c.lines)
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		select {
+		case c.lines <- append([]byte(nil), line...):
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *stdioConn) call(ctx context.Context, id int, method string, params any) (json.RawMessage, error) {
+	request, err := encodeRequest(id, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.write(request); err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no response: %w", ctx.Err())
+		case line, ok := <-c.lines:
+			if !ok {
+				return nil, c.exitError()
+			}
+
+			var msg message
+			if err := json.Unmarshal(line, &msg); err != nil {
+				// Servers sometimes log to stdout; skip what is not JSON-RPC.
+				continue
+			}
+
+			if msg.responseTo(id) {
+				return msg.outcome()
+			}
+		}
+	}
+}
+
+func (c *stdioConn) notify(_ context.Context, method string, params any) error {
+	notification, err := encodeNotification(method, params)
+	if err != nil {
+		return err
+	}
+
+	return c.write(notification)
+}
+
+func (c *stdioConn) write(data []byte) error {
+	if _, err := c.stdin.Write(append(data, '\n')); err != nil {
+		return c.exitError()
+	}
+
+	return nil
+}
+
+// exitError explains why the server stopped answering, with the end of its
+// stderr when it wrote any.
+func (c *stdioConn) exitError() error {
+	// A server that closed stdout but keeps running is stopped after a
+	// moment, so its exit status is reported when it has one.
+	timer := time.AfterFunc(time.Second, c.cancel)
+	waitErr := c.cmd.Wait()
+	timer.Stop()
+
+	reason := "server exited before answering"
+	if waitErr != nil {
+		reason = fmt.Sprintf("server exited before answering (%v)", waitErr)
+	}
+
+	if tail := c.stderr.lastLine(); tail != "" {
+		return fmt.Errorf("%s: %s", reason, tail)
+	}
+
+	return errors.New(reason)
+}
+
+func (c *stdioConn) close() error {
+	close(c.done)
+	_ = c.stdin.Close()
+	c.cancel()
+	_ = c.cmd.Wait()
+
+	return nil
+}
+
+// tailBuffer keeps the last limit bytes written to it.
+type tailBuffer struct {
+	mu    sync.Mutex
+	limit int
+	data  []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data = append(b.data, p...)
+	if len(b.data) > b.limit {
+		b.data = b.data[len(b.data)-b.limit:]
+	}
+
+	return len(p), nil
+}
+
+// lastLine returns the last non-empty line written.
+func (b *tailBuffer) lastLine() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	lines := strings.Split(strings.TrimSpace(string(b.data)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,94 @@
+// Package project locates the directory that project-scoped config is written
+// for. Targets key project scope by a directory, and in monorepos or nested
+// worktrees the working directory is not always the one users expect, so the
+// detection strategy is configurable.
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Strategy selects how the project root is found from a starting directory.
+type Strategy string
+
+const (
+	// StrategyCwd uses the starting directory as is. Targets may still match
+	// an existing project entry in a parent directory.
+	StrategyCwd Strategy = "cwd"
+	// StrategyGit uses the nearest directory holding a .git entry, which is
+	// the worktree root for linked worktrees and submodules.
+	StrategyGit Strategy = "git"
+	// StrategyManifest uses the nearest directory holding a package manifest,
+	// which is the package root inside a monorepo.
+	StrategyManifest Strategy = "manifest"
+)
+
+// Manifests lists the file names StrategyManifest treats as a project root.
+var Manifests = []string{
+	"go.mod",
+	"package.json",
+	"pyproject.toml",
+	"Cargo.toml",
+	"pom.xml",
+	"build.gradle",
+	"build.gradle.kts",
+	"composer.json",
+	"Gemfile",
+	"deno.json",
+	"mix.exs",
+}
+
+// ParseStrategy validates a strategy name. An empty value means StrategyCwd.
+func ParseStrategy(value string) (Strategy, error) {
+	strategy := Strategy(strings.ToLower(strings.TrimSpace(value)))
+	switch strategy {
+	case "":
+		return StrategyCwd, nil
+	case StrategyCwd, StrategyGit, StrategyManifest:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid project root strategy %q (supported: cwd, git, manifest)", value)
+	}
+}
+
+// Root returns the project root for dir under strategy, and whether a project
+// marker was found. When none is found, dir itself is returned.
+func Root(dir string, strategy Strategy) (string, bool) {
+	dir = filepath.Clean(dir)
+
+	switch strategy {
+	case StrategyGit:
+		if root, found := FindUp(dir, ".git"); found {
+			return root, true
+		}
+	case StrategyManifest:
+		if root, found := FindUp(dir, Manifests...); found {
+			return root, true
+		}
+	}
+
+	return dir, false
+}
+
+// FindUp returns the nearest directory at or above dir that contains any of
+// names.
+func FindUp(dir string, names ...string) (string, bool) {
+	current := filepath.Clean(dir)
+	for {
+		for _, name := range names {
+			if _, err := os.Stat(filepath.Join(current, name)); err == nil {
+				return current, true
+			}
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", false
+		}
+
+		current = parent
+	}
+}
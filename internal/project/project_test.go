@@ -0,0 +1,91 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseStrategy(t *testing.T) {
+	cases := map[string]Strategy{
+		"":          StrategyCwd,
+		"cwd":       StrategyCwd,
+		" Git ":     StrategyGit,
+		"manifest":  StrategyManifest,
+		"MANIFEST ": StrategyManifest,
+	}
+
+	for value, want := range cases {
+		got, err := ParseStrategy(value)
+		if err != nil {
+			t.Fatalf("parse %q: %v", value, err)
+		}
+
+		if got != want {
+			t.Fatalf("expected %q for %q, got %q", want, value, got)
+		}
+	}
+
+	if _, err := ParseStrategy("nearest"); err == nil {
+		t.Fatal("expected an error for an unknown strategy")
+	}
+}
+
+func TestRootFindsGitAndManifestRootsInMonorepo(t *testing.T) {
+	repo := t.TempDir()
+	mustMkdir(t, filepath.Join(repo, ".git"))
+
+	pkg := filepath.Join(repo, "packages", "api")
+	nested := filepath.Join(pkg, "src", "handlers")
+	mustMkdir(t, nested)
+	mustWriteFile(t, filepath.Join(pkg, "package.json"))
+
+	if root, found := Root(nested, StrategyGit); !found || root != repo {
+		t.Fatalf("expected git root %q, got %q (found=%v)", repo, root, found)
+	}
+
+	if root, found := Root(nested, StrategyManifest); !found || root != pkg {
+		t.Fatalf("expected manifest root %q, got %q (found=%v)", pkg, root, found)
+	}
+
+	if root, found := Root(nested, StrategyCwd); found || root != nested {
+		t.Fatalf("expected cwd strategy to return %q unmarked, got %q (found=%v)", nested, root, found)
+	}
+}
+
+func TestRootUsesNestedWorktreeRoot(t *testing.T) {
+	repo := t.TempDir()
+	mustMkdir(t, filepath.Join(repo, ".git"))
+
+	worktree := filepath.Join(repo, "worktrees", "feature")
+	mustMkdir(t, worktree)
+	mustWriteFile(t, filepath.Join(worktree, ".git"))
+
+	if root, found := Root(worktree, StrategyGit); !found || root != worktree {
+		t.Fatalf("expected worktree root %q, got %q (found=%v)", worktree, root, found)
+	}
+}
+
+func TestRootFallsBackToDirWithoutMarkers(t *testing.T) {
+	dir := t.TempDir()
+
+	if root, found := Root(dir, StrategyManifest); found || root != dir {
+		t.Fatalf("expected fallback to %q, got %q (found=%v)", dir, root, found)
+	}
+}
+
+func mustMkdir(t *testing.T, path string) {
+	t.Helper()
+
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		t.Fatalf("create %q: %v", path, err)
+	}
+}
+
+func mustWriteFile(t *testing.T, path string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte("{}\n"), 0o644); err != nil {
+		t.Fatalf("write %q: %v", path, err)
+	}
+}
@@ -0,0 +1,103 @@
+package qrcode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonRemainderMatchesStandardExample(t *testing.T) {
+	// "HELLO WORLD" as version 1-M, from the worked example of the standard.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	got := reedSolomonRemainder(data, reedSolomonDivisor(len(want)))
+	if string(got) != string(want) {
+		t.Fatalf("expected error correction %v, got %v", want, got)
+	}
+}
+
+func TestFormatBitsForLevelL(t *testing.T) {
+	for mask, want := range map[int]int{0: 0b111011111000100, 4: 0b110011000101111, 7: 0b110100101110110} {
+		if got := formatBits(mask); got != want {
+			t.Fatalf("mask %d: expected %015b, got %015b", mask, want, got)
+		}
+	}
+}
+
+func TestAlignmentPositions(t *testing.T) {
+	for version, want := range map[int][]int{2: {6, 18}, 7: {6, 22, 38}, 32: {6, 34, 60, 86, 112, 138}, 40: {6, 30, 58, 86, 114, 142, 170}} {
+		got := alignmentPositions(version)
+		if len(got) != len(want) {
+			t.Fatalf("version %d: expected %v, got %v", version, want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("version %d: expected %v, got %v", version, want, got)
+			}
+		}
+	}
+}
+
+func TestEncodePicksSmallestVersion(t *testing.T) {
+	code, err := Encode("https://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 19 bytes need 164 bits, one more codeword than version 1-L holds.
+	if code.Size() != 25 {
+		t.Fatalf("expected a version 2 code (25 modules), got %d", code.Size())
+	}
+
+	// The finder pattern corners are dark, their separators light.
+	last := code.Size() - 1
+	for _, corner := range [][2]int{{0, 0}, {last, 0}, {0, last}} {
+		if !code.Dark(corner[0], corner[1]) {
+			t.Fatalf("expected finder corner %v to be dark", corner)
+		}
+	}
+	if code.Dark(7, 0) || code.Dark(0, 7) {
+		t.Fatal("expected finder separators to be light")
+	}
+	if !code.Dark(8, code.Size()-8) {
+		t.Fatal("expected the dark module next to the bottom-left finder")
+	}
+}
+
+func TestEncodeRejectsTooLongText(t *testing.T) {
+	if _, err := Encode(strings.Repeat("a", 2954)); !errors.Is(err, ErrTooLong) {
+		t.Fatalf("expected ErrTooLong, got %v", err)
+	}
+
+	code, err := Encode(strings.Repeat("a", 2953))
+	if err != nil {
+		t.Fatalf("expected the version 40 capacity to fit: %v", err)
+	}
+	if code.Size() != 177 {
+		t.Fatalf("expected a version 40 code, got size %d", code.Size())
+	}
+}
+
+func TestTerminalDrawsTwoRowsPerLine(t *testing.T) {
+	code, err := Encode("mcp-wire")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(code.Terminal(2), "\n"), "\n")
+	if len(lines) != (code.Size()+4+1)/2 {
+		t.Fatalf("expected %d lines, got %d", (code.Size()+4+1)/2, len(lines))
+	}
+
+	for _, line := range lines {
+		if width := len([]rune(line)); width != code.Size()+4 {
+			t.Fatalf("expected lines %d wide, got %d", code.Size()+4, width)
+		}
+	}
+
+	// The top quiet zone is light and the first finder row dark.
+	if !strings.HasPrefix(lines[0], "██") || !strings.HasPrefix(lines[1], "██ ▄▄▄▄▄ ") {
+		t.Fatalf("unexpected top rows:\n%s\n%s", lines[0], lines[1])
+	}
+}
@@ -0,0 +1,158 @@
+// Package recommend suggests MCP services based on the contents of a project
+// directory (manifests, CI configuration, tool config files).
+package recommend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Recommendation is a suggested service and the evidence behind it.
+type Recommendation struct {
+	Service string
+	Reason  string
+}
+
+// rule maps a project signal to a curated service.
+type rule struct {
+	service string
+	reason  string
+	match   func(dir string) bool
+}
+
+var rules = []rule{
+	{
+		service: "github",
+		reason:  ".github directory found",
+		match:   func(dir string) bool { return isDir(filepath.Join(dir, ".github")) },
+	},
+	{
+		service: "sentry",
+		reason:  "Sentry configuration or SDK dependency found",
+		match: func(dir string) bool {
+			return anyFileExists(dir, ".sentryclirc", "sentry.properties") ||
+				fileContains(filepath.Join(dir, "package.json"), "@sentry/") ||
+				fileContains(filepath.Join(dir, "pyproject.toml"), "sentry-sdk") ||
+				fileContains(filepath.Join(dir, "requirements.txt"), "sentry-sdk")
+		},
+	},
+	{
+		service: "playwright",
+		reason:  "Playwright configuration or dependency found",
+		match: func(dir string) bool {
+			return anyFileExists(dir, "playwright.config.ts", "playwright.config.js", "playwright.config.mjs") ||
+				fileContains(filepath.Join(dir, "package.json"), "@playwright/test") ||
+				fileContains(filepath.Join(dir, "pyproject.toml"), "playwright")
+		},
+	},
+	{
+		service: "context7",
+		reason:  "package manifest found (library docs lookup)",
+		match: func(dir string) bool {
+			return anyFileExists(dir, "package.json", "pyproject.toml", "go.mod", "Cargo.toml")
+		},
+	},
+	{
+		service: "linear",
+		reason:  "Linear references found in .github",
+		match: func(dir string) bool {
+			return dirContains(filepath.Join(dir, ".github"), "linear.app")
+		},
+	},
+	{
+		service: "jira",
+		reason:  "Jira references found in .github",
+		match: func(dir string) bool {
+			return dirContains(filepath.Join(dir, ".github"), "atlassian.net")
+		},
+	},
+}
+
+// Detect inspects dir and returns recommended services in rule order.
+// Services not present in available (when non-nil) are skipped.
+func Detect(dir string, available map[string]bool) []Recommendation {
+	var result []Recommendation
+
+	for _, r := range rules {
+		if available != nil && !available[r.service] {
+			continue
+		}
+
+		if !r.match(dir) {
+			continue
+		}
+
+		result = append(result, Recommendation{Service: r.service, Reason: r.reason})
+	}
+
+	return result
+}
+
+// Names returns just the service names from recommendations.
+func Names(recommendations []Recommendation) []string {
+	names := make([]string, 0, len(recommendations))
+	for _, r := range recommendations {
+		names = append(names, r.Service)
+	}
+
+	return names
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func anyFileExists(dir string, names ...string) bool {
+	for _, name := range names {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err == nil && !info.IsDir() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maxScanBytes bounds how much of a file is read when looking for markers.
+const maxScanBytes = 256 * 1024
+
+func fileContains(path string, needle string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	buf := make([]byte, maxScanBytes)
+	n, _ := file.Read(buf)
+
+	return strings.Contains(string(buf[:n]), needle)
+}
+
+func dirContains(dir string, needle string) bool {
+	if !isDir(dir) {
+		return false
+	}
+
+	found := false
+	_ = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || found {
+			return filepath.SkipDir
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		if fileContains(path, needle) {
+			found = true
+			return filepath.SkipAll
+		}
+
+		return nil
+	})
+
+	return found
+}
@@ -0,0 +1,72 @@
+package recommend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+func TestDetectEmptyDirectory(t *testing.T) {
+	if got := Detect(t.TempDir(), nil); len(got) != 0 {
+		t.Fatalf("expected no recommendations, got %v", got)
+	}
+}
+
+func TestDetectGitHubAndSentry(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".github", "workflows", "ci.yml"), "name: ci\n")
+	writeFile(t, filepath.Join(dir, "package.json"), `{"dependencies":{"@sentry/node":"^8.0.0"}}`)
+
+	names := Names(Detect(dir, nil))
+
+	if strings.Join(names, ",") != "github,sentry,context7" {
+		t.Fatalf("unexpected recommendations %v", names)
+	}
+}
+
+func TestDetectPlaywrightConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "playwright.config.ts"), "export default {}\n")
+
+	names := Names(Detect(dir, nil))
+
+	if len(names) != 1 || names[0] != "playwright" {
+		t.Fatalf("expected playwright, got %v", names)
+	}
+}
+
+func TestDetectTrackerReferencesInGitHubDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".github", "pull_request_template.md"), "Ticket: https://acme.atlassian.net/browse/ABC-1\n")
+
+	names := Names(Detect(dir, nil))
+
+	if strings.Join(names, ",") != "github,jira" {
+		t.Fatalf("unexpected recommendations %v", names)
+	}
+}
+
+func TestDetectSkipsUnavailableServices(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".github", "CODEOWNERS"), "* @team\n")
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example\n")
+
+	names := Names(Detect(dir, map[string]bool{"context7": true}))
+
+	if len(names) != 1 || names[0] != "context7" {
+		t.Fatalf("expected only context7, got %v", names)
+	}
+}
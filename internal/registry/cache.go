@@ -6,14 +6,25 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
 )
 
 const (
 	cacheDirName  = "mcp-wire"
 	cacheFileName = "servers.json"
 	syncPageLimit = 100
+
+	// OfficialName is the name the official MCP Registry goes by next to
+	// the registries configured by the user.
+	OfficialName = "official"
+
+	// statusDeleted is the status of a server removed from the registry.
+	// Incremental syncs receive it so the cache can drop the server.
+	statusDeleted = "deleted"
 )
 
 // SyncMode identifies the type of cache sync currently in progress.
@@ -43,16 +54,23 @@ type ServerLister interface {
 
 // CacheStore is the on-disk cache format.
 type CacheStore struct {
-	LastSynced time.Time        `json:"last_synced"`
-	Servers    []ServerResponse `json:"servers"`
+	LastSynced time.Time `json:"last_synced"`
+	// UpdatedSince is the newest update time the registry reported for a
+	// server it returned. Incremental syncs ask for the changes since then,
+	// in the registry's own clock, so a local clock running ahead of it does
+	// not skip updates.
+	UpdatedSince time.Time        `json:"updated_since,omitempty"`
+	Servers      []ServerResponse `json:"servers"`
 }
 
 // Cache provides local caching and in-memory search over registry servers.
 type Cache struct {
 	path   string
+	origin string
 	client ServerLister
 	store  CacheStore
 	onSync SyncProgressCallback
+	clock  clock.Clock
 }
 
 // NewCache creates a cache backed by the default cache path.
@@ -65,14 +83,32 @@ func NewCacheWithPath(client ServerLister, path string) *Cache {
 	return &Cache{
 		path:   path,
 		client: client,
+		clock:  clock.System,
 	}
 }
 
+// NewCacheFor creates a cache for the registry called name, backed by its
+// own file next to the official registry cache. Servers it returns carry
+// name as their Origin.
+func NewCacheFor(client ServerLister, name string) *Cache {
+	cache := NewCacheWithPath(client, CachePath(name))
+	if name != OfficialName {
+		cache.origin = name
+	}
+
+	return cache
+}
+
 // SetSyncProgressCallback registers a callback that receives sync progress updates.
 func (c *Cache) SetSyncProgressCallback(callback SyncProgressCallback) {
 	c.onSync = callback
 }
 
+// SetClock replaces the clock used to stamp and age the cache.
+func (c *Cache) SetClock(cl clock.Clock) {
+	c.clock = cl
+}
+
 // Load reads the cache from disk into memory.
 //
 // If the file does not exist, the cache starts empty.
@@ -99,21 +135,40 @@ func (c *Cache) Load() error {
 
 // Sync fetches servers from the registry and updates the local cache.
 //
-// If the cache has been synced before, an incremental sync is attempted
-// using updated_since. Otherwise a full paginated sync is performed.
+// If the cache has been synced before, an incremental sync fetches only the
+// servers updated since the newest update already cached, merges them in by
+// name, and drops the ones the registry deleted. Otherwise a full paginated sync is performed, as it
+// is when the last sync time lies in the future: asking for updates since
+// then would miss everything changed in between.
 // On network failure, the stale cache is preserved and the error is returned.
 func (c *Cache) Sync() error {
-	if c.store.LastSynced.IsZero() {
+	if _, ok := clock.Age(c.clock, c.store.LastSynced); !ok {
 		return c.coldSync()
 	}
 
 	return c.incrementalSync()
 }
 
+// SyncFull fetches every server again and replaces the cached ones, which
+// also drops servers the registry no longer lists. On failure the servers
+// cached before are kept and the error is returned.
+func (c *Cache) SyncFull() error {
+	servers := c.store.Servers
+	if err := c.coldSync(); err != nil {
+		c.store.Servers = servers
+		return err
+	}
+
+	return nil
+}
+
 // All returns every cached server.
 func (c *Cache) All() []ServerResponse {
 	result := make([]ServerResponse, len(c.store.Servers))
 	copy(result, c.store.Servers)
+	for i := range result {
+		result[i].Origin = c.origin
+	}
 
 	return result
 }
@@ -132,6 +187,7 @@ func (c *Cache) Search(query string) []ServerResponse {
 		if strings.Contains(strings.ToLower(srv.Server.Name), q) ||
 			strings.Contains(strings.ToLower(srv.Server.Title), q) ||
 			strings.Contains(strings.ToLower(srv.Server.Description), q) {
+			srv.Origin = c.origin
 			matches = append(matches, srv)
 		}
 	}
@@ -144,6 +200,18 @@ func (c *Cache) LastSynced() time.Time {
 	return c.store.LastSynced
 }
 
+// Stale reports whether the cache was last synced more than maxAge ago, was
+// never synced, or carries a sync time in the future.
+func (c *Cache) Stale(maxAge time.Duration) bool {
+	return clock.Expired(c.clock, c.store.LastSynced, maxAge)
+}
+
+// Age returns how long ago the cache was last synced. ok is false when it
+// was never synced or the sync time lies in the future.
+func (c *Cache) Age() (age time.Duration, ok bool) {
+	return clock.Age(c.clock, c.store.LastSynced)
+}
+
 // Count returns the number of cached servers.
 func (c *Cache) Count() int {
 	return len(c.store.Servers)
@@ -163,7 +231,7 @@ func (c *Cache) coldSync() error {
 			return fmt.Errorf("cold sync: %w", err)
 		}
 
-		all = append(all, resp.Servers...)
+		all = append(all, liveServers(resp.Servers)...)
 		pages++
 		c.store.Servers = all
 		c.emitSyncProgress(SyncProgress{
@@ -181,7 +249,8 @@ func (c *Cache) coldSync() error {
 	}
 
 	c.store.Servers = all
-	c.store.LastSynced = time.Now().UTC()
+	c.store.LastSynced = clock.Stamp(c.clock)
+	c.store.UpdatedSince = newestUpdate(time.Time{}, all)
 	c.emitSyncProgress(SyncProgress{
 		Mode:    SyncModeCold,
 		Pages:   pages,
@@ -193,8 +262,14 @@ func (c *Cache) coldSync() error {
 }
 
 func (c *Cache) incrementalSync() error {
-	since := c.store.LastSynced.Format(time.RFC3339)
+	since := c.store.UpdatedSince
+	if since.IsZero() {
+		since = c.store.LastSynced
+	}
+
 	index := c.buildIndex()
+	deleted := make(map[string]bool)
+	newest := c.store.UpdatedSince
 
 	cursor := ""
 	pages := 0
@@ -204,13 +279,21 @@ func (c *Cache) incrementalSync() error {
 		resp, err := c.client.ListServers(ListOptions{
 			Limit:        syncPageLimit,
 			Cursor:       cursor,
-			UpdatedSince: since,
+			UpdatedSince: since.UTC().Format(time.RFC3339Nano),
 		})
 		if err != nil {
 			return fmt.Errorf("incremental sync: %w", err)
 		}
 
+		newest = newestUpdate(newest, resp.Servers)
 		for _, updated := range resp.Servers {
+			if serverDeleted(updated) {
+				deleted[updated.Server.Name] = true
+				updatedCount++
+				continue
+			}
+
+			delete(deleted, updated.Server.Name)
 			if i, ok := index[updated.Server.Name]; ok {
 				c.store.Servers[i] = updated
 			} else {
@@ -235,7 +318,14 @@ func (c *Cache) incrementalSync() error {
 		cursor = resp.Metadata.NextCursor
 	}
 
-	c.store.LastSynced = time.Now().UTC()
+	if len(deleted) > 0 {
+		c.store.Servers = slices.DeleteFunc(c.store.Servers, func(srv ServerResponse) bool {
+			return deleted[srv.Server.Name]
+		})
+	}
+
+	c.store.LastSynced = clock.Stamp(c.clock)
+	c.store.UpdatedSince = newest
 	c.emitSyncProgress(SyncProgress{
 		Mode:    SyncModeIncremental,
 		Pages:   pages,
@@ -246,15 +336,49 @@ func (c *Cache) incrementalSync() error {
 	return c.save()
 }
 
+// liveServers returns servers without the ones the registry deleted.
+func liveServers(servers []ServerResponse) []ServerResponse {
+	live := make([]ServerResponse, 0, len(servers))
+	for _, srv := range servers {
+		if !serverDeleted(srv) {
+			live = append(live, srv)
+		}
+	}
+
+	return live
+}
+
+func serverDeleted(srv ServerResponse) bool {
+	return srv.Meta.Official != nil && srv.Meta.Official.Status == statusDeleted
+}
+
+// newestUpdate returns the latest of newest and the times the registry says
+// servers were last updated, or published when they never were.
+func newestUpdate(newest time.Time, servers []ServerResponse) time.Time {
+	for _, srv := range servers {
+		if srv.Meta.Official == nil {
+			continue
+		}
+
+		updated := srv.Meta.Official.UpdatedAt
+		if updated.IsZero() {
+			updated = srv.Meta.Official.PublishedAt
+		}
+
+		if updated.After(newest) {
+			newest = updated
+		}
+	}
+
+	return newest
+}
+
 func (c *Cache) emitSyncProgress(progress SyncProgress) {
 	if c.onSync == nil {
 		return
 	}
 
-	snapshot := make([]ServerResponse, len(c.store.Servers))
-	copy(snapshot, c.store.Servers)
-
-	c.onSync(progress, snapshot)
+	c.onSync(progress, c.All())
 }
 
 func (c *Cache) buildIndex() map[string]int {
@@ -289,10 +413,21 @@ func DefaultCachePath() string {
 	return defaultCachePath()
 }
 
-// ClearDefaultCache removes the on-disk registry cache file.
-// It returns the cache path and whether a file was removed.
-func ClearDefaultCache() (string, bool, error) {
+// CachePath returns the on-disk path of the cache of the registry called
+// name. The official registry keeps the default path.
+func CachePath(name string) string {
 	path := defaultCachePath()
+	if name == "" || name == OfficialName {
+		return path
+	}
+
+	return filepath.Join(filepath.Dir(path), "servers-"+name+".json")
+}
+
+// ClearCache removes the on-disk cache of the registry called name. It
+// returns the cache path and whether a file was removed.
+func ClearCache(name string) (string, bool, error) {
+	path := CachePath(name)
 
 	if err := os.Remove(path); err != nil {
 		if errors.Is(err, os.ErrNotExist) {
@@ -308,6 +443,12 @@ func ClearDefaultCache() (string, bool, error) {
 	return path, true, nil
 }
 
+// ClearDefaultCache removes the on-disk official registry cache file.
+// It returns the cache path and whether a file was removed.
+func ClearDefaultCache() (string, bool, error) {
+	return ClearCache(OfficialName)
+}
+
 func defaultCachePath() string {
 	cacheDir, err := os.UserCacheDir()
 	if err != nil {
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
 )
 
 // mockLister is a test double for ServerLister.
@@ -115,6 +117,93 @@ func TestIncrementalSyncUsesUpdatedSince(t *testing.T) {
 	}
 }
 
+func TestIncrementalSyncUsesNewestRegistryUpdate(t *testing.T) {
+	updatedAt := time.Date(2026, 10, 1, 9, 30, 15, 500_000_000, time.UTC)
+	updated := sampleServer("ns/existing", "Updated")
+	updated.Meta.Official = &RegistryExtensions{Status: "active", UpdatedAt: updatedAt}
+
+	mock := &mockLister{
+		pages: []ServerListResponse{
+			{Servers: []ServerResponse{updated}, Metadata: Metadata{Count: 1}},
+			{Servers: []ServerResponse{}, Metadata: Metadata{Count: 0}},
+		},
+	}
+
+	cache := NewCacheWithPath(mock, filepath.Join(t.TempDir(), "servers.json"))
+	cache.SetClock(clock.Fixed(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)))
+	cache.store.LastSynced = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	cache.store.Servers = []ServerResponse{sampleServer("ns/existing", "Existing")}
+
+	if err := cache.Sync(); err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+
+	if err := cache.Sync(); err != nil {
+		t.Fatalf("expected second sync to succeed: %v", err)
+	}
+
+	if mock.calls[0].UpdatedSince != "2026-10-01T09:00:00Z" {
+		t.Fatalf("expected the first sync to start from the last sync, got %q", mock.calls[0].UpdatedSince)
+	}
+
+	if mock.calls[1].UpdatedSince != "2026-10-01T09:30:15.5Z" {
+		t.Fatalf("expected the next sync to start from the newest registry update, got %q", mock.calls[1].UpdatedSince)
+	}
+}
+
+func TestIncrementalSyncDropsDeletedServers(t *testing.T) {
+	removed := sampleServer("ns/removed", "Removed")
+	removed.Meta.Official = &RegistryExtensions{Status: "deleted", UpdatedAt: time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)}
+
+	mock := &mockLister{
+		pages: []ServerListResponse{
+			{Servers: []ServerResponse{removed}, Metadata: Metadata{Count: 1}},
+		},
+	}
+
+	cache := NewCacheWithPath(mock, filepath.Join(t.TempDir(), "servers.json"))
+	cache.store.LastSynced = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	cache.store.Servers = []ServerResponse{sampleServer("ns/kept", "Kept"), sampleServer("ns/removed", "Removed")}
+
+	if err := cache.Sync(); err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+
+	if cache.Count() != 1 || cache.All()[0].Server.Name != "ns/kept" {
+		t.Fatalf("expected the deleted server dropped, got %+v", cache.All())
+	}
+}
+
+func TestSyncFromFutureLastSyncedFetchesEverything(t *testing.T) {
+	mock := &mockLister{
+		pages: []ServerListResponse{
+			{Servers: []ServerResponse{sampleServer("ns/fresh", "Fresh")}, Metadata: Metadata{Count: 1}},
+		},
+	}
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewCacheWithPath(mock, filepath.Join(t.TempDir(), "servers.json"))
+	cache.SetClock(clock.Fixed(now))
+	cache.store.LastSynced = now.Add(48 * time.Hour)
+	cache.store.Servers = []ServerResponse{sampleServer("ns/existing", "Existing")}
+
+	if !cache.Stale(24 * time.Hour) {
+		t.Fatal("expected a sync time in the future to be stale")
+	}
+
+	if err := cache.Sync(); err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+
+	if mock.calls[0].UpdatedSince != "" {
+		t.Fatalf("expected a full sync, got updated_since=%q", mock.calls[0].UpdatedSince)
+	}
+
+	if !cache.LastSynced().Equal(now) || cache.Stale(24*time.Hour) {
+		t.Fatalf("expected the cache stamped at %v, got %v", now, cache.LastSynced())
+	}
+}
+
 func TestIncrementalSyncUpdatesExistingServer(t *testing.T) {
 	mock := &mockLister{
 		pages: []ServerListResponse{
@@ -384,6 +473,41 @@ func TestSyncNetworkErrorPreservesStaleCache(t *testing.T) {
 	}
 }
 
+func TestSyncFullReplacesCachedServers(t *testing.T) {
+	mock := &mockLister{
+		pages: []ServerListResponse{
+			{Servers: []ServerResponse{sampleServer("ns/fresh", "Fresh")}, Metadata: Metadata{Count: 1}},
+		},
+	}
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewCacheWithPath(mock, filepath.Join(t.TempDir(), "servers.json"))
+	cache.SetClock(clock.Fixed(now))
+	cache.store.LastSynced = now.Add(-12 * 24 * time.Hour)
+	cache.store.Servers = []ServerResponse{sampleServer("ns/removed", "Removed")}
+
+	if age, ok := cache.Age(); !ok || age != 12*24*time.Hour {
+		t.Fatalf("expected a 12 day old cache, got %v (%v)", age, ok)
+	}
+
+	if err := cache.SyncFull(); err != nil {
+		t.Fatalf("expected full sync to succeed: %v", err)
+	}
+
+	if mock.calls[0].UpdatedSince != "" || cache.Count() != 1 || cache.All()[0].Server.Name != "ns/fresh" {
+		t.Fatalf("expected only the fetched server, got %+v after %+v", cache.All(), mock.calls)
+	}
+
+	mock.err = errors.New("network timeout")
+	if err := cache.SyncFull(); err == nil {
+		t.Fatal("expected error on network failure")
+	}
+
+	if cache.Count() != 1 || !cache.LastSynced().Equal(now) {
+		t.Fatalf("expected the cache kept after a failed full sync, got %d servers", cache.Count())
+	}
+}
+
 func TestColdSyncNetworkErrorOnEmptyCache(t *testing.T) {
 	mock := &mockLister{err: errors.New("connection refused")}
 
@@ -453,3 +577,29 @@ func TestNewCacheUsesDefaultPath(t *testing.T) {
 		t.Fatal("expected default path to be set")
 	}
 }
+
+func TestNewCacheForKeepsRegistriesApart(t *testing.T) {
+	if path := NewCacheFor(nil, OfficialName).path; path != defaultCachePath() {
+		t.Fatalf("expected the official registry to keep the default path, got %q", path)
+	}
+
+	corp := NewCacheFor(&mockLister{
+		pages: []ServerListResponse{
+			{Servers: []ServerResponse{sampleServer("corp/tools", "Internal tools")}, Metadata: Metadata{Count: 1}},
+		},
+	}, "corp")
+	corp.path = filepath.Join(t.TempDir(), filepath.Base(corp.path))
+	if filepath.Base(corp.path) != "servers-corp.json" {
+		t.Fatalf("expected a cache file per registry, got %q", corp.path)
+	}
+
+	if err := corp.Sync(); err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+
+	for _, servers := range [][]ServerResponse{corp.All(), corp.Search("internal")} {
+		if len(servers) != 1 || servers[0].Origin != "corp" {
+			t.Fatalf("expected servers labelled with their registry, got %#v", servers)
+		}
+	}
+}
@@ -91,13 +91,23 @@ func (c *Client) ListServers(opts ListOptions) (*ServerListResponse, error) {
 // The serverName must be in reverse-DNS format (e.g. "io.github.user/server").
 // The slash is URL-encoded automatically.
 func (c *Client) GetServerLatest(serverName string) (*ServerResponse, error) {
+	return c.GetServerVersion(serverName, "latest")
+}
+
+// GetServerVersion returns the details of one published version of a
+// server, such as "1.2.3". Like GetServerLatest, serverName is URL-encoded.
+func (c *Client) GetServerVersion(serverName string, version string) (*ServerResponse, error) {
 	trimmed := strings.TrimSpace(serverName)
 	if trimmed == "" {
 		return nil, fmt.Errorf("server name is required")
 	}
 
-	encoded := url.PathEscape(trimmed)
-	endpoint := fmt.Sprintf("%s/%s/servers/%s/versions/latest", c.baseURL, apiVersion, encoded)
+	trimmedVersion := strings.TrimSpace(version)
+	if trimmedVersion == "" {
+		return nil, fmt.Errorf("server version is required")
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/servers/%s/versions/%s", c.baseURL, apiVersion, url.PathEscape(trimmed), url.PathEscape(trimmedVersion))
 
 	var result ServerResponse
 	if err := c.doGet(endpoint, &result); err != nil {
@@ -107,6 +117,24 @@ func (c *Client) GetServerLatest(serverName string) (*ServerResponse, error) {
 	return &result, nil
 }
 
+// ListServerVersions returns every published version of a server. Like
+// GetServerLatest, serverName is URL-encoded.
+func (c *Client) ListServerVersions(serverName string) (*ServerListResponse, error) {
+	trimmed := strings.TrimSpace(serverName)
+	if trimmed == "" {
+		return nil, fmt.Errorf("server name is required")
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/servers/%s/versions", c.baseURL, apiVersion, url.PathEscape(trimmed))
+
+	var result ServerListResponse
+	if err := c.doGet(endpoint, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 func (c *Client) doGet(endpoint string, target any) error {
 	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
 	if err != nil {
@@ -265,6 +265,58 @@ func TestGetServerLatestRejectsWhitespaceName(t *testing.T) {
 	}
 }
 
+func TestGetServerVersionRequestsThatVersion(t *testing.T) {
+	ts, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.RequestURI, "/servers/io.github.user%2Ftest-server/versions/1.2.3") {
+			t.Fatalf("expected the 1.2.3 version path, got %q", r.RequestURI)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ServerResponse{Server: ServerJSON{Name: "io.github.user/test-server", Version: "1.2.3"}})
+	})
+	defer ts.Close()
+
+	result, err := client.GetServerVersion("io.github.user/test-server", "1.2.3")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if result.Server.Version != "1.2.3" {
+		t.Fatalf("expected version 1.2.3, got %q", result.Server.Version)
+	}
+
+	if _, err := client.GetServerVersion("io.github.user/test-server", " "); err == nil {
+		t.Fatal("expected error for empty version")
+	}
+}
+
+func TestListServerVersionsReturnsEveryVersion(t *testing.T) {
+	ts, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.RequestURI, "/servers/io.github.user%2Ftest-server/versions") {
+			t.Fatalf("expected the versions path, got %q", r.RequestURI)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ServerListResponse{
+			Servers: []ServerResponse{
+				{Server: ServerJSON{Name: "io.github.user/test-server", Version: "1.0.0"}},
+				{Server: ServerJSON{Name: "io.github.user/test-server", Version: "1.1.0"}},
+			},
+			Metadata: Metadata{Count: 2},
+		})
+	})
+	defer ts.Close()
+
+	result, err := client.ListServerVersions("io.github.user/test-server")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(result.Servers) != 2 || result.Servers[1].Server.Version != "1.1.0" {
+		t.Fatalf("unexpected versions %+v", result.Servers)
+	}
+}
+
 func TestAPIErrorParsing(t *testing.T) {
 	ts, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/problem+json")
@@ -0,0 +1,54 @@
+package registry
+
+import "strings"
+
+// Localized returns the server's strings for locale. An exact tag match
+// ("pt-BR") wins over the base language ("pt"); tags are compared without
+// regard to case or to "_" versus "-". Fields with no translation keep the
+// server's default strings.
+func (s ServerJSON) Localized(locale string) Localization {
+	result := Localization{Title: s.Title, Description: s.Description}
+
+	tag := NormalizeLocale(locale)
+	if tag == "" || s.Meta == nil || s.Meta.PublisherProvided == nil {
+		return result
+	}
+
+	var exact, base Localization
+	language, _, _ := strings.Cut(tag, "-")
+	for key, localization := range s.Meta.PublisherProvided.Localizations {
+		switch NormalizeLocale(key) {
+		case tag:
+			exact = localization
+		case language:
+			base = localization
+		}
+	}
+
+	for _, localization := range []Localization{base, exact} {
+		if localization.Title != "" {
+			result.Title = localization.Title
+		}
+		if localization.Description != "" {
+			result.Description = localization.Description
+		}
+	}
+
+	return result
+}
+
+// NormalizeLocale turns a locale tag or POSIX locale name ("de_DE.UTF-8")
+// into a lower-case BCP 47 style tag ("de-de"). It returns an empty string
+// for the "C" and "POSIX" locales, which name no language.
+func NormalizeLocale(locale string) string {
+	tag := strings.TrimSpace(locale)
+	tag, _, _ = strings.Cut(tag, ".")
+	tag, _, _ = strings.Cut(tag, "@")
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+
+	if tag == "c" || tag == "posix" {
+		return ""
+	}
+
+	return tag
+}
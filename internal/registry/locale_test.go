@@ -0,0 +1,45 @@
+package registry
+
+import "testing"
+
+func TestLocalizedPrefersExactTagOverLanguage(t *testing.T) {
+	server := ServerJSON{
+		Title:       "Files",
+		Description: "File access",
+		Meta: &ServerMeta{PublisherProvided: &PublisherMeta{Localizations: map[string]Localization{
+			"pt":    {Title: "Arquivos", Description: "Acesso a arquivos"},
+			"pt_BR": {Description: "Acesso a arquivos (BR)"},
+		}}},
+	}
+
+	got := server.Localized("pt-BR")
+	if got.Title != "Arquivos" || got.Description != "Acesso a arquivos (BR)" {
+		t.Fatalf("unexpected localization: %+v", got)
+	}
+
+	got = server.Localized("pt-PT")
+	if got.Description != "Acesso a arquivos" {
+		t.Fatalf("expected base language fallback, got %+v", got)
+	}
+
+	got = server.Localized("C")
+	if got.Title != "Files" || got.Description != "File access" {
+		t.Fatalf("expected default strings for the C locale, got %+v", got)
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	cases := map[string]string{
+		"de_DE.UTF-8": "de-de",
+		"en_US@euro":  "en-us",
+		" pt-BR ":     "pt-br",
+		"POSIX":       "",
+		"":            "",
+	}
+
+	for input, want := range cases {
+		if got := NormalizeLocale(input); got != want {
+			t.Fatalf("NormalizeLocale(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -18,6 +18,10 @@ type Metadata struct {
 type ServerResponse struct {
 	Server ServerJSON   `json:"server"`
 	Meta   ResponseMeta `json:"_meta"`
+
+	// Origin names the registry the server was read from; it is empty for
+	// the official registry. It is set by the cache, not by the API.
+	Origin string `json:"-"`
 }
 
 // ServerJSON is the server definition as published to the registry.
@@ -32,6 +36,26 @@ type ServerJSON struct {
 	Repository  *Repository `json:"repository,omitempty"`
 	Packages    []Package   `json:"packages,omitempty"`
 	Remotes     []Transport `json:"remotes,omitempty"`
+	Meta        *ServerMeta `json:"_meta,omitempty"`
+}
+
+// ServerMeta holds the extension metadata a publisher attached to a server.
+type ServerMeta struct {
+	PublisherProvided *PublisherMeta `json:"io.modelcontextprotocol.registry/publisher-provided,omitempty"`
+}
+
+// PublisherMeta is the publisher-provided metadata mcp-wire understands.
+type PublisherMeta struct {
+	// Localizations maps a BCP 47 language tag ("de", "pt-BR") to
+	// translated display strings.
+	Localizations map[string]Localization `json:"localizations,omitempty"`
+}
+
+// Localization holds translated display strings for one locale. Empty fields
+// fall back to the server's default strings.
+type Localization struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 // ResponseMeta holds registry-managed metadata.
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ResolveDependencies returns the services that svc depends on, directly or
+// transitively, in the order they should be installed (dependencies before
+// their dependents). svc itself is not included.
+//
+// An error is returned when a dependency is not defined in services or when
+// the dependency graph contains a cycle.
+func ResolveDependencies(services map[string]Service, svc Service) ([]Service, error) {
+	ordered := make([]Service, 0)
+	visited := make(map[string]bool)
+	visiting := map[string]bool{strings.ToLower(svc.Name): true}
+
+	var visit func(current Service, path []string) error
+	visit = func(current Service, path []string) error {
+		for _, depName := range current.DependsOn {
+			dep, found := lookupService(services, depName)
+			if !found {
+				return fmt.Errorf("service %q depends on unknown service %q", current.Name, depName)
+			}
+
+			key := strings.ToLower(dep.Name)
+			if visiting[key] {
+				return fmt.Errorf("dependency cycle detected: %s", strings.Join(append(path, dep.Name), " -> "))
+			}
+
+			if visited[key] {
+				continue
+			}
+
+			visiting[key] = true
+			if err := visit(dep, append(path, dep.Name)); err != nil {
+				return err
+			}
+			visiting[key] = false
+
+			visited[key] = true
+			ordered = append(ordered, dep)
+		}
+
+		return nil
+	}
+
+	if err := visit(svc, []string{svc.Name}); err != nil {
+		return nil, err
+	}
+
+	return ordered, nil
+}
+
+// Dependents returns the sorted names of services that directly depend on
+// the named service.
+func Dependents(services map[string]Service, name string) []string {
+	dependents := make([]string, 0)
+	for _, svc := range services {
+		for _, depName := range svc.DependsOn {
+			if strings.EqualFold(depName, name) {
+				dependents = append(dependents, svc.Name)
+				break
+			}
+		}
+	}
+
+	sort.Strings(dependents)
+	return dependents
+}
+
+func lookupService(services map[string]Service, name string) (Service, bool) {
+	if svc, found := services[name]; found {
+		return svc, true
+	}
+
+	for key, svc := range services {
+		if strings.EqualFold(key, name) {
+			return svc, true
+		}
+	}
+
+	return Service{}, false
+}
+
+// Conflicts returns the sorted names of services known to clash with svc.
+// A conflict declared on either side counts, so only one of the two
+// definitions needs to list it under conflicts_with.
+func Conflicts(services map[string]Service, svc Service) []string {
+	seen := make(map[string]bool)
+	conflicts := make([]string, 0)
+
+	add := func(name string) {
+		key := strings.ToLower(name)
+		if seen[key] || strings.EqualFold(name, svc.Name) {
+			return
+		}
+
+		seen[key] = true
+		conflicts = append(conflicts, name)
+	}
+
+	for _, name := range svc.ConflictsWith {
+		if other, found := lookupService(services, name); found {
+			add(other.Name)
+		} else {
+			add(name)
+		}
+	}
+
+	for _, other := range services {
+		for _, name := range other.ConflictsWith {
+			if strings.EqualFold(name, svc.Name) {
+				add(other.Name)
+				break
+			}
+		}
+	}
+
+	sort.Strings(conflicts)
+	return conflicts
+}
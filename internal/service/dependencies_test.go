@@ -0,0 +1,126 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func dependencyTestServices() map[string]Service {
+	return map[string]Service{
+		"proxy":  {Name: "proxy", Transport: "stdio", Command: "proxy"},
+		"auth":   {Name: "auth", Transport: "stdio", Command: "auth", DependsOn: []string{"proxy"}},
+		"app":    {Name: "app", Transport: "stdio", Command: "app", DependsOn: []string{"auth", "Proxy"}},
+		"broken": {Name: "broken", Transport: "stdio", Command: "broken", DependsOn: []string{"missing"}},
+		"loop-a": {Name: "loop-a", Transport: "stdio", Command: "a", DependsOn: []string{"loop-b"}},
+		"loop-b": {Name: "loop-b", Transport: "stdio", Command: "b", DependsOn: []string{"loop-a"}},
+	}
+}
+
+func dependencyNames(services []Service) []string {
+	names := make([]string, 0, len(services))
+	for _, svc := range services {
+		names = append(names, svc.Name)
+	}
+
+	return names
+}
+
+func TestResolveDependenciesOrdersTransitiveDependencies(t *testing.T) {
+	services := dependencyTestServices()
+
+	deps, err := ResolveDependencies(services, services["app"])
+	if err != nil {
+		t.Fatalf("expected dependencies to resolve: %v", err)
+	}
+
+	want := []string{"proxy", "auth"}
+	if got := dependencyNames(deps); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected install order %v, got %v", want, got)
+	}
+}
+
+func TestResolveDependenciesWithoutDependencies(t *testing.T) {
+	services := dependencyTestServices()
+
+	deps, err := ResolveDependencies(services, services["proxy"])
+	if err != nil {
+		t.Fatalf("expected dependencies to resolve: %v", err)
+	}
+
+	if len(deps) != 0 {
+		t.Fatalf("expected no dependencies, got %v", dependencyNames(deps))
+	}
+}
+
+func TestResolveDependenciesRejectsUnknownService(t *testing.T) {
+	services := dependencyTestServices()
+
+	_, err := ResolveDependencies(services, services["broken"])
+	if err == nil || !strings.Contains(err.Error(), "unknown service \"missing\"") {
+		t.Fatalf("expected unknown dependency error, got %v", err)
+	}
+}
+
+func TestResolveDependenciesRejectsCycles(t *testing.T) {
+	services := dependencyTestServices()
+
+	_, err := ResolveDependencies(services, services["loop-a"])
+	if err == nil || !strings.Contains(err.Error(), "dependency cycle detected") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+}
+
+func TestDependentsListsDirectDependents(t *testing.T) {
+	got := Dependents(dependencyTestServices(), "PROXY")
+	want := []string{"app", "auth"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected dependents %v, got %v", want, got)
+	}
+}
+
+func TestValidateServiceRejectsSelfDependency(t *testing.T) {
+	svc := Service{
+		Name:      "demo-service",
+		Transport: "stdio",
+		Command:   "demo",
+		DependsOn: []string{"Demo-Service"},
+	}
+
+	if err := ValidateService(svc); err == nil {
+		t.Fatal("expected validation error for self dependency")
+	}
+}
+
+func TestConflictsIsSymmetric(t *testing.T) {
+	services := map[string]Service{
+		"browser-a": {Name: "browser-a", Transport: "stdio", Command: "a", ConflictsWith: []string{"Browser-B"}},
+		"browser-b": {Name: "browser-b", Transport: "stdio", Command: "b"},
+		"browser-c": {Name: "browser-c", Transport: "stdio", Command: "c", ConflictsWith: []string{"browser-b"}},
+	}
+
+	got := Conflicts(services, services["browser-b"])
+	want := []string{"browser-a", "browser-c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected conflicts %v, got %v", want, got)
+	}
+
+	got = Conflicts(services, services["browser-a"])
+	want = []string{"browser-b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected conflicts %v, got %v", want, got)
+	}
+}
+
+func TestValidateServiceRejectsSelfConflict(t *testing.T) {
+	svc := Service{
+		Name:          "demo-service",
+		Transport:     "stdio",
+		Command:       "demo",
+		ConflictsWith: []string{"demo-service"},
+	}
+
+	if err := ValidateService(svc); err == nil {
+		t.Fatal("expected validation error for self conflict")
+	}
+}
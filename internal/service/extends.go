@@ -0,0 +1,165 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolveExtends applies extends inheritance to every definition. A service
+// inherits each field it leaves unset from its base, env vars are merged by
+// name, and bases may themselves extend other services. Templates are only
+// used as bases and are left out of the result.
+//
+// An error is returned when a base is not defined or when the extends chain
+// contains a cycle.
+func ResolveExtends(definitions map[string]Service) (map[string]Service, error) {
+	resolved := make(map[string]Service, len(definitions))
+	visiting := make(map[string]bool)
+
+	var resolve func(svc Service, path []string) (Service, error)
+	resolve = func(svc Service, path []string) (Service, error) {
+		key := strings.ToLower(svc.Name)
+		if done, ok := resolved[key]; ok {
+			return done, nil
+		}
+
+		if svc.Extends == "" {
+			resolved[key] = svc
+			return svc, nil
+		}
+
+		base, found := lookupService(definitions, svc.Extends)
+		if !found {
+			return Service{}, fmt.Errorf("service %q extends unknown service %q", svc.Name, svc.Extends)
+		}
+
+		visiting[key] = true
+		if visiting[strings.ToLower(base.Name)] {
+			return Service{}, fmt.Errorf("extends cycle detected: %s", strings.Join(append(path, base.Name), " -> "))
+		}
+
+		resolvedBase, err := resolve(base, append(path, base.Name))
+		if err != nil {
+			return Service{}, err
+		}
+		visiting[key] = false
+
+		merged := mergeService(resolvedBase, svc)
+		resolved[key] = merged
+		return merged, nil
+	}
+
+	services := make(map[string]Service, len(definitions))
+	for name, svc := range definitions {
+		merged, err := resolve(svc, []string{svc.Name})
+		if err != nil {
+			return nil, err
+		}
+
+		if merged.Template {
+			continue
+		}
+
+		services[name] = merged
+	}
+
+	return services, nil
+}
+
+// mergeService returns child with every unset field filled in from base.
+func mergeService(base, child Service) Service {
+	merged := child
+
+	if merged.Description == "" {
+		merged.Description = base.Description
+	}
+
+	if merged.Setup == "" {
+		merged.Setup = base.Setup
+	}
+
+	if merged.DocsURL == "" {
+		merged.DocsURL = base.DocsURL
+	}
+
+	if merged.Transport == "" {
+		merged.Transport = base.Transport
+	}
+
+	if merged.Auth == "" {
+		merged.Auth = base.Auth
+	}
+
+	if merged.URL == "" {
+		merged.URL = base.URL
+	}
+
+	if merged.Command == "" {
+		merged.Command = base.Command
+	}
+
+	if merged.Args == nil {
+		merged.Args = base.Args
+	}
+
+	if merged.DependsOn == nil {
+		merged.DependsOn = base.DependsOn
+	}
+
+	if merged.ConflictsWith == nil {
+		merged.ConflictsWith = base.ConflictsWith
+	}
+
+	if merged.Ports == nil {
+		merged.Ports = base.Ports
+	}
+
+	if merged.Serve == nil {
+		merged.Serve = base.Serve
+	}
+
+	merged.Env = mergeEnv(base.Env, child.Env)
+
+	if len(base.Extra) > 0 {
+		merged.Extra = make(map[string]any, len(base.Extra)+len(child.Extra))
+		for key, value := range base.Extra {
+			merged.Extra[key] = value
+		}
+		for key, value := range child.Extra {
+			merged.Extra[key] = value
+		}
+	}
+
+	return merged
+}
+
+// mergeEnv returns the base env vars with child entries replacing those of the
+// same name and new ones appended, preserving declaration order.
+func mergeEnv(base, child []EnvVar) []EnvVar {
+	if len(base) == 0 {
+		return child
+	}
+
+	merged := make([]EnvVar, 0, len(base)+len(child))
+	overrides := make(map[string]EnvVar, len(child))
+	for _, envVar := range child {
+		overrides[envVar.Name] = envVar
+	}
+
+	for _, envVar := range base {
+		if override, ok := overrides[envVar.Name]; ok {
+			envVar = override
+			delete(overrides, envVar.Name)
+		}
+
+		merged = append(merged, envVar)
+	}
+
+	for _, envVar := range child {
+		if _, pending := overrides[envVar.Name]; pending {
+			merged = append(merged, envVar)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveExtendsInheritsUnsetFields(t *testing.T) {
+	definitions := map[string]Service{
+		"jira-base": {
+			Name:      "jira-base",
+			Template:  true,
+			Setup:     "Create an API token in your Atlassian account settings.",
+			Transport: "stdio",
+			Command:   "npx",
+			Args:      []string{"-y", "mcp-atlassian"},
+			Env: []EnvVar{
+				{Name: "JIRA_URL", Required: true},
+				{Name: "JIRA_TOKEN", Required: true},
+			},
+		},
+		"jira-eu": {
+			Name:        "jira-eu",
+			Extends:     "jira-base",
+			Description: "Jira (EU)",
+			Env: []EnvVar{
+				{Name: "JIRA_URL", Default: "https://eu.example.com"},
+				{Name: "JIRA_PROJECT"},
+			},
+		},
+	}
+
+	services, err := ResolveExtends(definitions)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, found := services["jira-base"]; found {
+		t.Fatal("expected template to be left out of the result")
+	}
+
+	svc := services["jira-eu"]
+	if svc.Command != "npx" || svc.Transport != "stdio" || svc.Description != "Jira (EU)" {
+		t.Fatalf("unexpected merged service %+v", svc)
+	}
+
+	if svc.Template {
+		t.Fatal("expected template flag not to be inherited")
+	}
+
+	if svc.Setup != "Create an API token in your Atlassian account settings." {
+		t.Fatalf("expected setup notes to be inherited, got %q", svc.Setup)
+	}
+
+	want := []EnvVar{
+		{Name: "JIRA_URL", Default: "https://eu.example.com"},
+		{Name: "JIRA_TOKEN", Required: true},
+		{Name: "JIRA_PROJECT"},
+	}
+	if !reflect.DeepEqual(svc.Env, want) {
+		t.Fatalf("expected env %+v, got %+v", want, svc.Env)
+	}
+}
+
+func TestResolveExtendsFollowsChains(t *testing.T) {
+	definitions := map[string]Service{
+		"base":   {Name: "base", Transport: "sse", URL: "https://example.com/sse"},
+		"middle": {Name: "middle", Extends: "base", Auth: "oauth"},
+		"leaf":   {Name: "leaf", Extends: "middle"},
+	}
+
+	services, err := ResolveExtends(definitions)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	leaf := services["leaf"]
+	if leaf.URL != "https://example.com/sse" || leaf.Auth != "oauth" {
+		t.Fatalf("expected leaf to inherit through the chain, got %+v", leaf)
+	}
+
+	if len(services) != 3 {
+		t.Fatalf("expected 3 services, got %d", len(services))
+	}
+}
+
+func TestResolveExtendsDetectsCycles(t *testing.T) {
+	definitions := map[string]Service{
+		"a": {Name: "a", Extends: "b"},
+		"b": {Name: "b", Extends: "a"},
+	}
+
+	_, err := ResolveExtends(definitions)
+	if err == nil || !strings.Contains(err.Error(), "extends cycle detected") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+}
+
+func TestResolveExtendsDetectsSelfExtension(t *testing.T) {
+	_, err := ResolveExtends(map[string]Service{"a": {Name: "a", Extends: "a"}})
+	if err == nil || !strings.Contains(err.Error(), "a -> a") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+}
+
+func TestResolveExtendsRejectsUnknownBase(t *testing.T) {
+	_, err := ResolveExtends(map[string]Service{"a": {Name: "a", Extends: "missing"}})
+	if err == nil || !strings.Contains(err.Error(), `extends unknown service "missing"`) {
+		t.Fatalf("expected unknown base error, got %v", err)
+	}
+}
+
+func TestLoadServicesResolvesExtendsAcrossPaths(t *testing.T) {
+	bundledDir := t.TempDir()
+	userDir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(bundledDir, "base.yaml"), `name: internal-base
+template: true
+transport: sse
+env:
+  - name: INTERNAL_TOKEN
+    required: true
+`)
+	writeTestFile(t, filepath.Join(userDir, "wiki.yaml"), `name: wiki
+extends: internal-base
+description: "Internal wiki"
+url: "https://wiki.example.com/sse"
+`)
+
+	services, err := LoadServices(bundledDir, userDir)
+	if err != nil {
+		t.Fatalf("expected services to load: %v", err)
+	}
+
+	if len(services) != 1 {
+		t.Fatalf("expected only the derived service, got %d", len(services))
+	}
+
+	wiki := services["wiki"]
+	if wiki.Transport != "sse" || len(wiki.Env) != 1 || wiki.Env[0].Name != "INTERNAL_TOKEN" {
+		t.Fatalf("unexpected resolved service %+v", wiki)
+	}
+}
+
+func TestLoadServicesValidatesResolvedDefinitions(t *testing.T) {
+	servicesDir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(servicesDir, "base.yaml"), `name: base
+template: true
+transport: sse
+`)
+	writeTestFile(t, filepath.Join(servicesDir, "child.yaml"), `name: child
+extends: base
+`)
+
+	_, err := LoadServices(servicesDir)
+	if err == nil || !strings.Contains(err.Error(), "child.yaml") || !strings.Contains(err.Error(), "requires url") {
+		t.Fatalf("expected validation error for child, got %v", err)
+	}
+}
+
+func TestResolveExtendsMergesExtra(t *testing.T) {
+	definitions := map[string]Service{
+		"base": {
+			Name: "base", Transport: "sse", URL: "https://example.com/sse",
+			Extra: map[string]any{"timeout": 10, "icon": "base.png"},
+		},
+		"child": {Name: "child", Extends: "base", Extra: map[string]any{"timeout": 30}},
+	}
+
+	services, err := ResolveExtends(definitions)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := map[string]any{"timeout": 30, "icon": "base.png"}
+	if !reflect.DeepEqual(services["child"].Extra, want) {
+		t.Fatalf("expected extra %v, got %v", want, services["child"].Extra)
+	}
+
+	if services["base"].Extra["timeout"] != 10 {
+		t.Fatal("expected base extra to be left untouched")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	bundledservices "github.com/andreagrandi/mcp-wire/services"
@@ -17,10 +18,14 @@ import (
 //  1. services/ relative to the executable
 //  2. services/ relative to the current working directory
 //  3. ~/.config/mcp-wire/services
+//  4. ~/.config/mcp-wire/services.d
+//
+// Definitions may be YAML (.yaml, .yml) or JSON (.json) files.
 //
 // When multiple files define the same service name, the last loaded definition
 // wins. With default paths, this means user-local definitions override bundled
-// ones.
+// ones. Inheritance (extends) is resolved once every definition is loaded, so
+// a user-local service can extend a bundled one.
 func LoadServices(paths ...string) (map[string]Service, error) {
 	loadBundledDefaults := len(paths) == 0
 
@@ -30,8 +35,9 @@ func LoadServices(paths ...string) (map[string]Service, error) {
 	}
 
 	services := make(map[string]Service)
+	sources := make(map[string]string)
 	if loadBundledDefaults {
-		if err := loadEmbeddedServices(services); err != nil {
+		if err := loadEmbeddedServices(services, sources); err != nil {
 			return nil, err
 		}
 	}
@@ -52,7 +58,7 @@ func LoadServices(paths ...string) (map[string]Service, error) {
 		}
 
 		for _, entry := range entries {
-			if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+			if entry.IsDir() || !isServiceDefinitionFile(entry.Name()) {
 				continue
 			}
 
@@ -63,6 +69,30 @@ func LoadServices(paths ...string) (map[string]Service, error) {
 			}
 
 			services[service.Name] = service
+			sources[service.Name] = filePath
+		}
+	}
+
+	return resolveServiceDefinitions(services, sources)
+}
+
+// resolveServiceDefinitions applies inheritance and validates the resulting
+// services, reporting errors against the file that defined them.
+func resolveServiceDefinitions(definitions map[string]Service, sources map[string]string) (map[string]Service, error) {
+	services, err := ResolveExtends(definitions)
+	if err != nil {
+		return nil, fmt.Errorf("resolve service inheritance: %w", err)
+	}
+
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := ValidateService(services[name]); err != nil {
+			return nil, fmt.Errorf("validate service file %q: %w", sources[name], err)
 		}
 	}
 
@@ -90,6 +120,10 @@ func ValidateService(s Service) error {
 		if strings.TrimSpace(s.URL) == "" {
 			return fmt.Errorf("service %q with sse transport requires url", name)
 		}
+	case "websocket":
+		if strings.TrimSpace(s.URL) == "" {
+			return fmt.Errorf("service %q with websocket transport requires url", name)
+		}
 	case "stdio":
 		if strings.TrimSpace(s.Command) == "" {
 			return fmt.Errorf("service %q with stdio transport requires command", name)
@@ -98,6 +132,52 @@ func ValidateService(s Service) error {
 		return fmt.Errorf("service %q has unsupported transport %q", name, s.Transport)
 	}
 
+	templates := append([]string{s.URL}, s.Args...)
+	for _, envVar := range s.Env {
+		templates = append(templates, envVar.Default)
+	}
+	for _, template := range templates {
+		if err := validateTemplate(template); err != nil {
+			return fmt.Errorf("service %q: %w", name, err)
+		}
+	}
+
+	for _, dep := range s.DependsOn {
+		if strings.EqualFold(strings.TrimSpace(dep), name) {
+			return fmt.Errorf("service %q cannot depend on itself", name)
+		}
+	}
+
+	if s.Serve != nil {
+		if err := validateServeConfig(name, s); err != nil {
+			return err
+		}
+	}
+
+	for _, conflict := range s.ConflictsWith {
+		if strings.EqualFold(strings.TrimSpace(conflict), name) {
+			return fmt.Errorf("service %q cannot conflict with itself", name)
+		}
+	}
+
+	return nil
+}
+
+func validateServeConfig(name string, s Service) error {
+	if strings.TrimSpace(s.Serve.URL) == "" {
+		return fmt.Errorf("service %q serve configuration requires url", name)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(s.Serve.Transport)) {
+	case "", "http", "sse":
+	default:
+		return fmt.Errorf("service %q serve configuration has unsupported transport %q", name, s.Serve.Transport)
+	}
+
+	if command, _ := s.ServeCommand(); strings.TrimSpace(command) == "" {
+		return fmt.Errorf("service %q serve configuration requires command", name)
+	}
+
 	return nil
 }
 
@@ -124,7 +204,10 @@ func resolveServicePaths(paths ...string) ([]string, error) {
 		return dedupePaths(loadPaths), nil
 	}
 
-	loadPaths = append(loadPaths, filepath.Join(homeDir, ".config", "mcp-wire", "services"))
+	loadPaths = append(loadPaths,
+		filepath.Join(homeDir, ".config", "mcp-wire", "services"),
+		filepath.Join(homeDir, ".config", "mcp-wire", "services.d"),
+	)
 
 	return dedupePaths(loadPaths), nil
 }
@@ -150,6 +233,17 @@ func dedupePaths(paths []string) []string {
 	return uniquePaths
 }
 
+// isServiceDefinitionFile reports whether name has an extension LoadServices
+// reads. JSON is parsed by the YAML decoder, which accepts it as-is.
+func isServiceDefinitionFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
 func loadServiceFile(path string) (Service, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -159,7 +253,7 @@ func loadServiceFile(path string) (Service, error) {
 	return parseServiceDefinition(path, data)
 }
 
-func loadEmbeddedServices(services map[string]Service) error {
+func loadEmbeddedServices(services map[string]Service, sources map[string]string) error {
 	entries, err := bundledservices.FS.ReadDir(".")
 	if err != nil {
 		return fmt.Errorf("read embedded services: %w", err)
@@ -182,6 +276,7 @@ func loadEmbeddedServices(services map[string]Service) error {
 		}
 
 		services[service.Name] = service
+		sources[service.Name] = "embedded/" + filePath
 	}
 
 	return nil
@@ -196,8 +291,13 @@ func parseServiceDefinition(path string, data []byte) (Service, error) {
 
 	service = normalizeService(service)
 
-	if err := ValidateService(service); err != nil {
-		return Service{}, fmt.Errorf("validate service file %q: %w", path, err)
+	// Definitions that inherit are validated once resolved.
+	if service.Extends == "" && !service.Template {
+		if err := ValidateService(service); err != nil {
+			return Service{}, fmt.Errorf("validate service file %q: %w", path, err)
+		}
+	} else if service.Name == "" {
+		return Service{}, fmt.Errorf("validate service file %q: service name is required", path)
 	}
 
 	return service, nil
@@ -205,15 +305,39 @@ func parseServiceDefinition(path string, data []byte) (Service, error) {
 
 func normalizeService(s Service) Service {
 	s.Name = strings.TrimSpace(s.Name)
+	s.Extends = strings.TrimSpace(s.Extends)
 	s.Description = strings.TrimSpace(s.Description)
 	s.Transport = strings.ToLower(strings.TrimSpace(s.Transport))
 	s.Auth = strings.ToLower(strings.TrimSpace(s.Auth))
 	s.URL = strings.TrimSpace(s.URL)
 	s.Command = strings.TrimSpace(s.Command)
 
+	s.DependsOn = trimNames(s.DependsOn)
+	s.ConflictsWith = trimNames(s.ConflictsWith)
+	s.Ports = trimNames(s.Ports)
+
+	if s.Serve != nil {
+		serve := *s.Serve
+		serve.Command = strings.TrimSpace(serve.Command)
+		serve.Transport = strings.ToLower(strings.TrimSpace(serve.Transport))
+		serve.URL = strings.TrimSpace(serve.URL)
+		s.Serve = &serve
+	}
+
 	return s
 }
 
+func trimNames(names []string) []string {
+	var trimmed []string
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			trimmed = append(trimmed, name)
+		}
+	}
+
+	return trimmed
+}
+
 func expandHome(path string) (string, error) {
 	if path == "~" {
 		return os.UserHomeDir()
@@ -3,6 +3,7 @@ package service
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -54,6 +55,22 @@ func TestValidateServiceRequiresURLForSSE(t *testing.T) {
 	}
 }
 
+func TestValidateServiceRequiresURLForWebSocket(t *testing.T) {
+	service := Service{
+		Name:      "demo-service",
+		Transport: "websocket",
+	}
+
+	if err := ValidateService(service); err == nil {
+		t.Fatal("expected validation error for websocket service without url")
+	}
+
+	service.URL = "wss://example.com/mcp"
+	if err := ValidateService(service); err != nil {
+		t.Fatalf("expected websocket service to validate: %v", err)
+	}
+}
+
 func TestValidateServiceRequiresCommandForStdio(t *testing.T) {
 	service := Service{
 		Name:      "filesystem",
@@ -232,3 +249,116 @@ func writeTestFile(t *testing.T, path string, content string) {
 		t.Fatalf("failed to write test file %q: %v", path, err)
 	}
 }
+
+func TestValidateServiceRequiresURLForServe(t *testing.T) {
+	service := Service{
+		Name:      "local-db",
+		Transport: "stdio",
+		Command:   "local-db",
+		Serve:     &ServeConfig{Args: []string{"--http"}},
+	}
+
+	if err := ValidateService(service); err == nil {
+		t.Fatal("expected validation error for serve without url")
+	}
+}
+
+func TestSupervisedReturnsServeClientDefinition(t *testing.T) {
+	service := Service{
+		Name:      "local-db",
+		Transport: "stdio",
+		Command:   "local-db",
+		Env:       []EnvVar{{Name: "DB_PASSWORD", Required: true}},
+		Ports:     []string{"DB_PORT"},
+		Serve:     &ServeConfig{Args: []string{"--port", "{DB_PORT}"}, URL: "http://127.0.0.1:{DB_PORT}/mcp"},
+	}
+
+	supervised, ok := service.Supervised()
+	if !ok {
+		t.Fatal("expected service with serve configuration to be supervisable")
+	}
+
+	if supervised.Transport != "http" || supervised.URL != "http://127.0.0.1:{DB_PORT}/mcp" {
+		t.Fatalf("unexpected supervised definition: %+v", supervised)
+	}
+
+	if supervised.Command != "" || len(supervised.Env) != 0 {
+		t.Fatalf("expected supervised definition to drop process settings, got %+v", supervised)
+	}
+
+	command, args := service.ServeCommand()
+	if command != "local-db" || len(args) != 2 {
+		t.Fatalf("expected serve command to fall back to service command, got %q %v", command, args)
+	}
+}
+
+func TestLoadServicesReadsYMLAndJSONDefinitions(t *testing.T) {
+	bundledDir := t.TempDir()
+	dropInDir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(bundledDir, "demo-service.yaml"), `name: demo-service
+description: "Bundled demo service"
+transport: sse
+url: "https://bundled.example.com/sse"
+`)
+
+	writeTestFile(t, filepath.Join(dropInDir, "demo-service.json"), `{
+  "name": "demo-service",
+  "description": "Internal demo service",
+  "transport": "http",
+  "url": "https://internal.example.com/mcp",
+  "env": [{"name": "DEMO_TOKEN", "description": "Token", "required": true}]
+}`)
+
+	writeTestFile(t, filepath.Join(dropInDir, "internal-docs.yml"), `name: internal-docs
+description: "Internal docs"
+transport: stdio
+command: uvx
+args: ["internal-docs-mcp"]
+`)
+
+	services, err := LoadServices(bundledDir, dropInDir)
+	if err != nil {
+		t.Fatalf("expected services to load: %v", err)
+	}
+
+	demoService := services["demo-service"]
+	if demoService.Description != "Internal demo service" || demoService.Transport != "http" {
+		t.Fatalf("expected JSON definition to override bundled one, got %#v", demoService)
+	}
+
+	if len(demoService.Env) != 1 || demoService.Env[0].Name != "DEMO_TOKEN" || !demoService.Env[0].Required {
+		t.Fatalf("expected env from JSON definition, got %#v", demoService.Env)
+	}
+
+	if services["internal-docs"].Command != "uvx" {
+		t.Fatalf("expected .yml definition to load, got %#v", services["internal-docs"])
+	}
+}
+
+func TestLoadServicesReturnsErrorForInvalidJSONDefinition(t *testing.T) {
+	servicesDir := t.TempDir()
+	writeTestFile(t, filepath.Join(servicesDir, "broken.json"), `{"name": "broken", "transport": `)
+
+	_, err := LoadServices(servicesDir)
+	if err == nil || !strings.Contains(err.Error(), "broken.json") {
+		t.Fatalf("expected parse error naming the JSON file, got %v", err)
+	}
+}
+
+func TestResolveServicePathsIncludesServicesDropInDirectory(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("USERPROFILE", homeDir)
+
+	paths, err := resolveServicePaths()
+	if err != nil {
+		t.Fatalf("expected resolveServicePaths to succeed: %v", err)
+	}
+
+	userDir := filepath.Join(homeDir, ".config", "mcp-wire", "services")
+	dropInDir := filepath.Join(homeDir, ".config", "mcp-wire", "services.d")
+	if len(paths) < 2 || paths[len(paths)-2] != userDir || paths[len(paths)-1] != dropInDir {
+		t.Fatalf("expected %q then %q as the last service paths, got %v", userDir, dropInDir, paths)
+	}
+}
@@ -2,15 +2,73 @@ package service
 
 // Service represents an MCP server definition loaded from a YAML file.
 type Service struct {
-	Name        string            `yaml:"name"`
-	Description string            `yaml:"description"`
-	Transport   string            `yaml:"transport"` // "http", "sse", or "stdio"
-	Auth        string            `yaml:"auth,omitempty"`
-	URL         string            `yaml:"url,omitempty"`
-	Command     string            `yaml:"command,omitempty"`
-	Args        []string          `yaml:"args,omitempty"`
-	Env         []EnvVar          `yaml:"env,omitempty"`
-	Headers     map[string]string `yaml:"-"`
+	Name          string            `yaml:"name"`
+	Extends       string            `yaml:"extends,omitempty"`  // base service to inherit unset fields from
+	Template      bool              `yaml:"template,omitempty"` // only used as a base; never listed or installed
+	Description   string            `yaml:"description"`
+	Setup         string            `yaml:"setup,omitempty"`    // short setup notes: tokens to create, account tier
+	DocsURL       string            `yaml:"docs_url,omitempty"` // page with the full setup instructions
+	Transport     string            `yaml:"transport"`          // "http", "sse", "websocket", or "stdio"
+	Auth          string            `yaml:"auth,omitempty"`
+	URL           string            `yaml:"url,omitempty"`
+	Command       string            `yaml:"command,omitempty"`
+	Args          []string          `yaml:"args,omitempty"`
+	Env           []EnvVar          `yaml:"env,omitempty"`
+	DependsOn     []string          `yaml:"depends_on,omitempty"`
+	ConflictsWith []string          `yaml:"conflicts_with,omitempty"`
+	Ports         []string          `yaml:"ports,omitempty"` // local port slots, substituted as {NAME}
+	Serve         *ServeConfig      `yaml:"serve,omitempty"`
+	Extra         map[string]any    `yaml:"extra,omitempty"` // passed through verbatim into each target's server entry
+	Headers       map[string]string `yaml:"-"`
+	Pin           string            `yaml:"-"` // registry version requested as name@version
+}
+
+// ServeConfig describes how to run a service as a long-lived local server
+// that targets connect to over the network, instead of each target spawning
+// its own copy.
+type ServeConfig struct {
+	Command   string   `yaml:"command,omitempty"` // defaults to the service command
+	Args      []string `yaml:"args,omitempty"`
+	Transport string   `yaml:"transport,omitempty"` // "http" (default) or "sse"
+	URL       string   `yaml:"url"`
+}
+
+// Supervised returns the client-side definition targets use to connect to a
+// supervised instance of the service. It reports false when the service has
+// no serve configuration.
+func (s Service) Supervised() (Service, bool) {
+	if s.Serve == nil {
+		return s, false
+	}
+
+	transport := s.Serve.Transport
+	if transport == "" {
+		transport = "http"
+	}
+
+	return Service{
+		Name:        s.Name,
+		Description: s.Description,
+		Transport:   transport,
+		URL:         s.Serve.URL,
+		Ports:       s.Ports,
+		Extra:       s.Extra,
+	}, true
+}
+
+// ServeCommand returns the command and arguments used to run the service as
+// a standalone server.
+func (s Service) ServeCommand() (string, []string) {
+	if s.Serve == nil {
+		return s.Command, s.Args
+	}
+
+	command := s.Serve.Command
+	if command == "" {
+		command = s.Command
+	}
+
+	return command, s.Serve.Args
 }
 
 // EnvVar describes an environment variable required by a service.
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// templatePattern matches the placeholders a definition may use: ${NAME},
+// ${NAME:-default} and {NAME}.
+var templatePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}|\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// TemplateLookup returns the value of a placeholder name and whether it is
+// set.
+type TemplateLookup func(name string) (string, bool)
+
+// ExpandTemplate replaces the placeholders in template with values from
+// lookup. A ${NAME} placeholder must resolve: when it is not set and carries
+// no ${NAME:-default}, an error naming it is returned. A {NAME} placeholder
+// that does not resolve is left as written, since braces also appear in
+// literal values such as JSON arguments.
+func ExpandTemplate(template string, lookup TemplateLookup) (string, error) {
+	var missing []string
+
+	expanded := templatePattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		match := templatePattern.FindStringSubmatch(placeholder)
+
+		if name := match[3]; name != "" {
+			if value, ok := lookup(name); ok {
+				return value
+			}
+
+			return placeholder
+		}
+
+		name := match[1]
+		if value, ok := lookup(name); ok && value != "" {
+			return value
+		}
+
+		if strings.Contains(placeholder, ":-") {
+			return match[2]
+		}
+
+		if !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+
+		return placeholder
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%s not set; set %s in the environment or a credential source", quotePlaceholders(missing), strings.Join(missing, ", "))
+	}
+
+	return expanded, nil
+}
+
+// ExpandTemplates returns a copy of s with the placeholders in its URL,
+// headers and args expanded. The error names the service, the field and
+// every required placeholder that did not resolve.
+func (s Service) ExpandTemplates(lookup TemplateLookup) (Service, error) {
+	var err error
+
+	if s.URL, err = ExpandTemplate(s.URL, lookup); err != nil {
+		return Service{}, fmt.Errorf("service %q url: %w", s.Name, err)
+	}
+
+	if s.Headers != nil {
+		headers := make(map[string]string, len(s.Headers))
+		for name, value := range s.Headers {
+			if headers[name], err = ExpandTemplate(value, lookup); err != nil {
+				return Service{}, fmt.Errorf("service %q header %s: %w", s.Name, name, err)
+			}
+		}
+		s.Headers = headers
+	}
+
+	if s.Args != nil {
+		args := make([]string, len(s.Args))
+		for i, arg := range s.Args {
+			if args[i], err = ExpandTemplate(arg, lookup); err != nil {
+				return Service{}, fmt.Errorf("service %q arg %d: %w", s.Name, i+1, err)
+			}
+		}
+		s.Args = args
+	}
+
+	return s, nil
+}
+
+// ExpandEnvDefaults returns a copy of s with the placeholders in its env var
+// defaults expanded, so a default such as "${HOME}/notes" is resolved before
+// it is offered or written.
+func (s Service) ExpandEnvDefaults(lookup TemplateLookup) (Service, error) {
+	if s.Env == nil {
+		return s, nil
+	}
+
+	env := make([]EnvVar, len(s.Env))
+	for i, envVar := range s.Env {
+		value, err := ExpandTemplate(envVar.Default, lookup)
+		if err != nil {
+			return Service{}, fmt.Errorf("service %q default of %s: %w", s.Name, envVar.Name, err)
+		}
+
+		envVar.Default = value
+		env[i] = envVar
+	}
+	s.Env = env
+
+	return s, nil
+}
+
+// validateTemplate reports a ${ that does not open a well-formed
+// placeholder, which would otherwise be written to the target as is.
+func validateTemplate(template string) error {
+	rest := templatePattern.ReplaceAllString(template, "")
+	if strings.Contains(rest, "${") {
+		return fmt.Errorf("malformed placeholder in %q; use ${NAME} or ${NAME:-default}", template)
+	}
+
+	return nil
+}
+
+func quotePlaceholders(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, "${"+name+"}")
+	}
+
+	if len(quoted) == 1 {
+		return quoted[0] + " is"
+	}
+
+	return strings.Join(quoted, ", ") + " are"
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func mapLookup(values map[string]string) TemplateLookup {
+	return func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+}
+
+func TestExpandTemplateResolvesBothPlaceholderForms(t *testing.T) {
+	lookup := mapLookup(map[string]string{"SITE": "acme", "REGION": "eu"})
+
+	got, err := ExpandTemplate("https://{SITE}.${REGION}.example.com/${API_PATH:-mcp}", lookup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != "https://acme.eu.example.com/mcp" {
+		t.Fatalf("unexpected expansion %q", got)
+	}
+}
+
+func TestExpandTemplateKeepsUnresolvedBracePlaceholders(t *testing.T) {
+	got, err := ExpandTemplate(`{"mode":"{MODE}"}`, mapLookup(nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != `{"mode":"{MODE}"}` {
+		t.Fatalf("expected braces to be kept, got %q", got)
+	}
+}
+
+func TestExpandTemplateReportsUnresolvedRequiredPlaceholders(t *testing.T) {
+	_, err := ExpandTemplate("${HOST}:${PORT}/${HOST}", mapLookup(map[string]string{"PORT": ""}))
+	if err == nil {
+		t.Fatal("expected an error for unresolved placeholders")
+	}
+
+	want := "${HOST}, ${PORT} are not set; set HOST, PORT in the environment or a credential source"
+	if err.Error() != want {
+		t.Fatalf("expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestServiceExpandTemplatesNamesFieldAndLeavesOriginalUntouched(t *testing.T) {
+	svc := Service{
+		Name:    "demo",
+		URL:     "https://${HOST}/mcp",
+		Args:    []string{"--token", "{TOKEN}"},
+		Headers: map[string]string{"Authorization": "Bearer {TOKEN}"},
+	}
+
+	expanded, err := svc.ExpandTemplates(mapLookup(map[string]string{"HOST": "api.example.com", "TOKEN": "secret"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expanded.URL != "https://api.example.com/mcp" || expanded.Args[1] != "secret" || expanded.Headers["Authorization"] != "Bearer secret" {
+		t.Fatalf("unexpected expansion: %#v", expanded)
+	}
+
+	if svc.Args[1] != "{TOKEN}" || svc.Headers["Authorization"] != "Bearer {TOKEN}" {
+		t.Fatalf("expected the original definition to be unchanged: %#v", svc)
+	}
+
+	svc.Args = []string{"--dir", "${WORKSPACE}"}
+	_, err = svc.ExpandTemplates(mapLookup(map[string]string{"HOST": "api.example.com"}))
+	if err == nil || !strings.Contains(err.Error(), `service "demo" arg 2: ${WORKSPACE} is not set`) {
+		t.Fatalf("expected an error naming the arg, got %v", err)
+	}
+}
+
+func TestServiceExpandEnvDefaults(t *testing.T) {
+	svc := Service{
+		Name: "notes",
+		Env:  []EnvVar{{Name: "NOTES_DIR", Default: "${HOME}/notes"}, {Name: "NOTES_TOKEN", Required: true}},
+	}
+
+	expanded, err := svc.ExpandEnvDefaults(mapLookup(map[string]string{"HOME": "/home/me"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expanded.Env[0].Default != "/home/me/notes" || svc.Env[0].Default != "${HOME}/notes" {
+		t.Fatalf("unexpected defaults: expanded %q, original %q", expanded.Env[0].Default, svc.Env[0].Default)
+	}
+
+	if _, err := svc.ExpandEnvDefaults(mapLookup(nil)); err == nil || !strings.Contains(err.Error(), `service "notes" default of NOTES_DIR`) {
+		t.Fatalf("expected an error naming the env var, got %v", err)
+	}
+}
+
+func TestValidateServiceRejectsMalformedPlaceholder(t *testing.T) {
+	service := Service{
+		Name:      "demo-service",
+		Transport: "http",
+		URL:       "https://${HOST/mcp",
+	}
+
+	if err := ValidateService(service); err == nil || !strings.Contains(err.Error(), "malformed placeholder") {
+		t.Fatalf("expected a malformed placeholder error, got %v", err)
+	}
+}
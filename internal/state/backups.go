@@ -0,0 +1,243 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	backupsDirName     = "backups"
+	backupManifestName = "backup.json"
+	backupTimeLayout   = "20060102T150405.000000000Z"
+
+	// maxBackupsPerTarget bounds how many backups are kept for each target;
+	// older ones are removed as new ones are taken.
+	maxBackupsPerTarget = 20
+)
+
+// ErrNoBackup is returned by Latest when a target has no backups.
+var ErrNoBackup = errors.New("no backup to roll back to")
+
+// BackupFile is one config file saved in a backup. The copy may contain
+// credentials, so backups are private to the user like the configs they
+// copy.
+type BackupFile struct {
+	Path    string      `json:"path"`
+	Existed bool        `json:"existed"`
+	Name    string      `json:"name,omitempty"`
+	Mode    os.FileMode `json:"mode,omitempty"`
+}
+
+// Backup holds the config files of one target as they were before an
+// operation changed them.
+type Backup struct {
+	Target    string
+	CreatedAt time.Time
+	Files     []BackupFile
+
+	dir string
+}
+
+// BackupStore keeps copies of target config files, taken before mcp-wire
+// writes them, under <dir>/<target>/<timestamp>.
+type BackupStore struct {
+	dir string
+}
+
+// NewBackupStore creates a store rooted at dir.
+//
+// If dir is empty, it defaults to backups inside DefaultDir.
+func NewBackupStore(dir string) *BackupStore {
+	trimmedDir := strings.TrimSpace(dir)
+	if trimmedDir == "" {
+		trimmedDir = filepath.Join(DefaultDir(), backupsDirName)
+	}
+
+	return &BackupStore{dir: trimmedDir}
+}
+
+// Dir returns the directory backups are kept in.
+func (s *BackupStore) Dir() string {
+	return s.dir
+}
+
+// Save copies the file at path into the backup of target for the operation
+// that started at startedAt. Every write of one operation shares a backup,
+// which keeps the content a file had before the first of them; later saves
+// of the same file are ignored. A missing file is recorded so rolling back
+// removes it again.
+func (s *BackupStore) Save(target string, startedAt time.Time, path string) error {
+	backupDir := filepath.Join(s.dir, target, startedAt.UTC().Format(backupTimeLayout))
+	files, err := readBackupManifest(backupDir)
+	if err != nil {
+		return err
+	}
+
+	if slices.ContainsFunc(files, func(file BackupFile) bool { return file.Path == path }) {
+		return nil
+	}
+
+	file := BackupFile{Path: path}
+	info, err := os.Stat(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("stat config file %q: %w", path, err)
+	default:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read config file %q: %w", path, err)
+		}
+
+		if err := os.MkdirAll(backupDir, 0o700); err != nil {
+			return fmt.Errorf("create backup directory %q: %w", backupDir, err)
+		}
+
+		file.Existed = true
+		file.Mode = info.Mode().Perm()
+		file.Name = fmt.Sprintf("%d-%s", len(files)+1, filepath.Base(path))
+		if err := os.WriteFile(filepath.Join(backupDir, file.Name), content, 0o600); err != nil {
+			return fmt.Errorf("write backup of %q: %w", path, err)
+		}
+	}
+
+	if err := writeBackupManifest(backupDir, append(files, file)); err != nil {
+		return err
+	}
+
+	return s.prune(target)
+}
+
+// List returns the backups of target, newest first.
+func (s *BackupStore) List(target string) ([]Backup, error) {
+	targetDir := filepath.Join(s.dir, target)
+	entries, err := os.ReadDir(targetDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read backup directory %q: %w", targetDir, err)
+	}
+
+	var backups []Backup
+	for _, entry := range entries {
+		createdAt, err := time.Parse(backupTimeLayout, entry.Name())
+		if !entry.IsDir() || err != nil {
+			continue
+		}
+
+		backupDir := filepath.Join(targetDir, entry.Name())
+		files, err := readBackupManifest(backupDir)
+		if err != nil {
+			return nil, err
+		}
+
+		backups = append(backups, Backup{Target: target, CreatedAt: createdAt, Files: files, dir: backupDir})
+	}
+
+	slices.SortFunc(backups, func(a, b Backup) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return backups, nil
+}
+
+// Latest returns the newest backup of target, or ErrNoBackup.
+func (s *BackupStore) Latest(target string) (Backup, error) {
+	backups, err := s.List(target)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	if len(backups) == 0 {
+		return Backup{}, ErrNoBackup
+	}
+
+	return backups[0], nil
+}
+
+// Restore writes every file of backup back to where it came from, removes
+// the files that did not exist before, and then deletes the backup, so the
+// next rollback goes one operation further back.
+func (s *BackupStore) Restore(backup Backup) error {
+	for _, file := range backup.Files {
+		snapshot := FileSnapshot{Path: file.Path, Existed: file.Existed, Mode: file.Mode}
+		if file.Existed {
+			content, err := os.ReadFile(filepath.Join(backup.dir, file.Name))
+			if err != nil {
+				return fmt.Errorf("read backup of %q: %w", file.Path, err)
+			}
+
+			snapshot.Content = content
+		}
+
+		if err := restoreSnapshot(snapshot); err != nil {
+			return err
+		}
+	}
+
+	if err := os.RemoveAll(backup.dir); err != nil {
+		return fmt.Errorf("remove backup %q: %w", backup.dir, err)
+	}
+
+	return nil
+}
+
+// prune removes the oldest backups of target beyond maxBackupsPerTarget.
+func (s *BackupStore) prune(target string) error {
+	backups, err := s.List(target)
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range backups[min(len(backups), maxBackupsPerTarget):] {
+		if err := os.RemoveAll(backup.dir); err != nil {
+			return fmt.Errorf("remove backup %q: %w", backup.dir, err)
+		}
+	}
+
+	return nil
+}
+
+func readBackupManifest(backupDir string) ([]BackupFile, error) {
+	manifestPath := filepath.Join(backupDir, backupManifestName)
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read backup manifest %q: %w", manifestPath, err)
+	}
+
+	var files []BackupFile
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, fmt.Errorf("parse backup manifest %q: %w", manifestPath, err)
+	}
+
+	return files, nil
+}
+
+func writeBackupManifest(backupDir string, files []BackupFile) error {
+	if err := os.MkdirAll(backupDir, 0o700); err != nil {
+		return fmt.Errorf("create backup directory %q: %w", backupDir, err)
+	}
+
+	data, err := json.MarshalIndent(files, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal backup manifest: %w", err)
+	}
+
+	data = append(data, '\n')
+
+	manifestPath := filepath.Join(backupDir, backupManifestName)
+	if err := os.WriteFile(manifestPath, data, 0o600); err != nil {
+		return fmt.Errorf("write backup manifest %q: %w", manifestPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package state
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackupStoreKeepsFirstContentPerOperation(t *testing.T) {
+	dir := t.TempDir()
+	store := NewBackupStore(filepath.Join(dir, "backups"))
+
+	configPath := filepath.Join(dir, ".claude.json")
+	createdPath := filepath.Join(dir, "project", ".mcp.json")
+	writeUndoTestFile(t, configPath, `{"mcpServers":{}}`)
+
+	first := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	if err := store.Save("claude", first, configPath); err != nil {
+		t.Fatalf("expected save to succeed: %v", err)
+	}
+
+	writeUndoTestFile(t, configPath, `{"mcpServers":{"jira":{}}}`)
+	for _, path := range []string{configPath, createdPath} {
+		if err := store.Save("claude", first, path); err != nil {
+			t.Fatalf("expected save to succeed: %v", err)
+		}
+	}
+	writeUndoTestFile(t, createdPath, `{"mcpServers":{"jira":{}}}`)
+
+	second := first.Add(time.Hour)
+	if err := store.Save("claude", second, configPath); err != nil {
+		t.Fatalf("expected save to succeed: %v", err)
+	}
+	writeUndoTestFile(t, configPath, `{"mcpServers":{"jira":{},"sentry":{}}}`)
+
+	backups, err := store.List("claude")
+	if err != nil || len(backups) != 2 || !backups[0].CreatedAt.Equal(second) {
+		t.Fatalf("expected two backups, newest first, got %#v, %v", backups, err)
+	}
+
+	latest, err := store.Latest("claude")
+	if err != nil {
+		t.Fatalf("expected latest backup: %v", err)
+	}
+
+	if err := store.Restore(latest); err != nil {
+		t.Fatalf("expected restore to succeed: %v", err)
+	}
+	assertBackupTestFile(t, configPath, `{"mcpServers":{"jira":{}}}`)
+
+	latest, err = store.Latest("claude")
+	if err != nil || !latest.CreatedAt.Equal(first) || len(latest.Files) != 2 {
+		t.Fatalf("expected the first backup with both files next, got %#v, %v", latest, err)
+	}
+
+	if err := store.Restore(latest); err != nil {
+		t.Fatalf("expected restore to succeed: %v", err)
+	}
+	assertBackupTestFile(t, configPath, `{"mcpServers":{}}`)
+
+	if _, err := os.Stat(createdPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the file created by the operation to be removed, got %v", err)
+	}
+
+	if _, err := store.Latest("claude"); !errors.Is(err, ErrNoBackup) {
+		t.Fatalf("expected ErrNoBackup once every backup was restored, got %v", err)
+	}
+}
+
+func TestBackupStorePrunesOldestBackups(t *testing.T) {
+	dir := t.TempDir()
+	store := NewBackupStore(filepath.Join(dir, "backups"))
+	configPath := filepath.Join(dir, "config.toml")
+	writeUndoTestFile(t, configPath, "")
+
+	start := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	for i := range maxBackupsPerTarget + 3 {
+		if err := store.Save("codex", start.Add(time.Duration(i)*time.Minute), configPath); err != nil {
+			t.Fatalf("expected save to succeed: %v", err)
+		}
+	}
+
+	backups, err := store.List("codex")
+	if err != nil || len(backups) != maxBackupsPerTarget {
+		t.Fatalf("expected %d backups to be kept, got %d, %v", maxBackupsPerTarget, len(backups), err)
+	}
+
+	if oldest := backups[len(backups)-1].CreatedAt; !oldest.Equal(start.Add(3 * time.Minute)) {
+		t.Fatalf("expected the oldest backups to be removed, oldest kept is %v", oldest)
+	}
+}
+
+func assertBackupTestFile(t *testing.T, path string, want string) {
+	t.Helper()
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+
+	if string(got) != want {
+		t.Fatalf("expected %s to contain %q, got %q", path, want, got)
+	}
+}
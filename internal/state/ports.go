@@ -0,0 +1,206 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	portsFileName = "ports.json"
+
+	// DefaultPortRangeStart is the first port handed out to local services.
+	DefaultPortRangeStart = 41000
+	// DefaultPortRangeEnd is the last port handed out to local services.
+	DefaultPortRangeEnd = 41999
+)
+
+// PortAllocation records a local port assigned to a named port slot of a
+// service.
+type PortAllocation struct {
+	Service string `json:"service"`
+	Name    string `json:"name"`
+	Port    int    `json:"port"`
+}
+
+type portsDocument struct {
+	Allocations []PortAllocation `json:"allocations"`
+}
+
+// PortStore assigns non-conflicting local ports to services that bind them,
+// and remembers the assignments so reinstalls keep the same ports.
+type PortStore struct {
+	path      string
+	start     int
+	end       int
+	available func(port int) bool
+}
+
+// NewPortStore creates a store backed by the given file.
+//
+// If path is empty, it defaults to ports.json inside DefaultDir.
+func NewPortStore(path string) *PortStore {
+	trimmedPath := strings.TrimSpace(path)
+	if trimmedPath == "" {
+		trimmedPath = filepath.Join(DefaultDir(), portsFileName)
+	}
+
+	return &PortStore{
+		path:      trimmedPath,
+		start:     DefaultPortRangeStart,
+		end:       DefaultPortRangeEnd,
+		available: portAvailable,
+	}
+}
+
+// Path returns the on-disk path of the port allocations file.
+func (s *PortStore) Path() string {
+	return s.path
+}
+
+// List returns all port allocations sorted by service and slot name.
+func (s *PortStore) List() ([]PortAllocation, error) {
+	doc, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	allocations := doc.Allocations
+	sort.Slice(allocations, func(i, j int) bool {
+		if allocations[i].Service != allocations[j].Service {
+			return allocations[i].Service < allocations[j].Service
+		}
+
+		return allocations[i].Name < allocations[j].Name
+	})
+
+	return allocations, nil
+}
+
+// Allocate returns the port assigned to the named slot of a service,
+// assigning the lowest free port in range when none is recorded yet.
+//
+// A port is free when no other allocation holds it and it can be bound on
+// the loopback interface.
+func (s *PortStore) Allocate(serviceName string, name string) (int, error) {
+	trimmedService := strings.TrimSpace(serviceName)
+	trimmedName := strings.TrimSpace(name)
+	if trimmedService == "" || trimmedName == "" {
+		return 0, errors.New("service and port name are required")
+	}
+
+	doc, err := s.read()
+	if err != nil {
+		return 0, err
+	}
+
+	taken := make(map[int]bool, len(doc.Allocations))
+	for _, allocation := range doc.Allocations {
+		if strings.EqualFold(allocation.Service, trimmedService) && strings.EqualFold(allocation.Name, trimmedName) {
+			return allocation.Port, nil
+		}
+
+		taken[allocation.Port] = true
+	}
+
+	for port := s.start; port <= s.end; port++ {
+		if taken[port] || !s.available(port) {
+			continue
+		}
+
+		doc.Allocations = append(doc.Allocations, PortAllocation{
+			Service: trimmedService,
+			Name:    trimmedName,
+			Port:    port,
+		})
+
+		if err := s.write(doc); err != nil {
+			return 0, err
+		}
+
+		return port, nil
+	}
+
+	return 0, fmt.Errorf("no free port in range %d-%d", s.start, s.end)
+}
+
+// Release drops every allocation held by the named service and reports
+// how many were removed.
+func (s *PortStore) Release(serviceName string) (int, error) {
+	doc, err := s.read()
+	if err != nil {
+		return 0, err
+	}
+
+	kept := make([]PortAllocation, 0, len(doc.Allocations))
+	for _, allocation := range doc.Allocations {
+		if strings.EqualFold(allocation.Service, strings.TrimSpace(serviceName)) {
+			continue
+		}
+
+		kept = append(kept, allocation)
+	}
+
+	removed := len(doc.Allocations) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+
+	doc.Allocations = kept
+
+	return removed, s.write(doc)
+}
+
+func (s *PortStore) read() (portsDocument, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return portsDocument{}, nil
+		}
+
+		return portsDocument{}, fmt.Errorf("read port allocations file %q: %w", s.path, err)
+	}
+
+	var doc portsDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return portsDocument{}, fmt.Errorf("parse port allocations file %q: %w", s.path, err)
+	}
+
+	return doc, nil
+}
+
+func (s *PortStore) write(doc portsDocument) error {
+	stateDir := filepath.Dir(s.path)
+	if err := os.MkdirAll(stateDir, 0o700); err != nil {
+		return fmt.Errorf("create state directory %q: %w", stateDir, err)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal port allocations: %w", err)
+	}
+
+	data = append(data, '\n')
+
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("write port allocations file %q: %w", s.path, err)
+	}
+
+	return nil
+}
+
+func portAvailable(port int) bool {
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return false
+	}
+
+	_ = listener.Close()
+	return true
+}
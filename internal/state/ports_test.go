@@ -0,0 +1,110 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func newTestPortStore(t *testing.T, busy ...int) *PortStore {
+	t.Helper()
+
+	busyPorts := make(map[int]bool, len(busy))
+	for _, port := range busy {
+		busyPorts[port] = true
+	}
+
+	store := NewPortStore(filepath.Join(t.TempDir(), "ports.json"))
+	store.available = func(port int) bool { return !busyPorts[port] }
+
+	return store
+}
+
+func TestPortStoreAllocateAssignsDistinctPorts(t *testing.T) {
+	store := newTestPortStore(t)
+
+	first, err := store.Allocate("proxy", "HTTP_PORT")
+	if err != nil {
+		t.Fatalf("expected allocation to succeed: %v", err)
+	}
+
+	second, err := store.Allocate("bridge", "HTTP_PORT")
+	if err != nil {
+		t.Fatalf("expected allocation to succeed: %v", err)
+	}
+
+	if first != DefaultPortRangeStart || second != DefaultPortRangeStart+1 {
+		t.Fatalf("expected sequential ports from range start, got %d and %d", first, second)
+	}
+}
+
+func TestPortStoreAllocateIsStable(t *testing.T) {
+	store := newTestPortStore(t)
+
+	first, err := store.Allocate("proxy", "HTTP_PORT")
+	if err != nil {
+		t.Fatalf("expected allocation to succeed: %v", err)
+	}
+
+	again, err := NewPortStore(store.Path()).Allocate("Proxy", "http_port")
+	if err != nil {
+		t.Fatalf("expected allocation to succeed: %v", err)
+	}
+
+	if first != again {
+		t.Fatalf("expected the same port on reallocation, got %d and %d", first, again)
+	}
+}
+
+func TestPortStoreAllocateSkipsBusyPorts(t *testing.T) {
+	store := newTestPortStore(t, DefaultPortRangeStart, DefaultPortRangeStart+1)
+
+	port, err := store.Allocate("proxy", "HTTP_PORT")
+	if err != nil {
+		t.Fatalf("expected allocation to succeed: %v", err)
+	}
+
+	if port != DefaultPortRangeStart+2 {
+		t.Fatalf("expected first free port %d, got %d", DefaultPortRangeStart+2, port)
+	}
+}
+
+func TestPortStoreAllocateFailsWhenRangeExhausted(t *testing.T) {
+	store := newTestPortStore(t)
+	store.end = store.start
+
+	if _, err := store.Allocate("proxy", "HTTP_PORT"); err != nil {
+		t.Fatalf("expected first allocation to succeed: %v", err)
+	}
+
+	if _, err := store.Allocate("bridge", "HTTP_PORT"); err == nil {
+		t.Fatal("expected allocation to fail when range is exhausted")
+	}
+}
+
+func TestPortStoreReleaseFreesServicePorts(t *testing.T) {
+	store := newTestPortStore(t)
+
+	for _, name := range []string{"HTTP_PORT", "ADMIN_PORT"} {
+		if _, err := store.Allocate("proxy", name); err != nil {
+			t.Fatalf("expected allocation to succeed: %v", err)
+		}
+	}
+
+	removed, err := store.Release("proxy")
+	if err != nil {
+		t.Fatalf("expected release to succeed: %v", err)
+	}
+
+	if removed != 2 {
+		t.Fatalf("expected 2 allocations removed, got %d", removed)
+	}
+
+	allocations, err := store.List()
+	if err != nil {
+		t.Fatalf("expected list to succeed: %v", err)
+	}
+
+	if len(allocations) != 0 {
+		t.Fatalf("expected no allocations, got %v", allocations)
+	}
+}
@@ -0,0 +1,249 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+)
+
+const (
+	// installStateFileName is the lock file that records every install.
+	installStateFileName = "state.json"
+	// legacyProvenanceFileName is where records were kept before state.json.
+	// It is read when state.json does not exist yet and removed on the first
+	// write.
+	legacyProvenanceFileName = "provenance.json"
+	// installStateFormat is the version of the state.json layout.
+	installStateFormat = 1
+)
+
+// Provenance records how a service came to be configured on one target and
+// scope: the command that wrote it, the file it came from, when, by which
+// mcp-wire version, and where each credential was taken from.
+type Provenance struct {
+	Service     string          `json:"service"`
+	Target      string          `json:"target"`
+	Scope       string          `json:"scope"`
+	Command     string          `json:"command"`
+	Source      string          `json:"source,omitempty"`
+	Version     string          `json:"version"`
+	InstalledAt time.Time       `json:"installed_at"`
+	Credentials []CredentialUse `json:"credentials,omitempty"`
+	// Pinned is the registry version requested with name@version. upgrade
+	// leaves pinned services alone.
+	Pinned string `json:"pinned,omitempty"`
+	// Fingerprint is a hash of the entry as it was written, so a later edit
+	// made outside mcp-wire can be detected.
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// CredentialUse names an env var a service was given and where its value was
+// found. Values are never recorded.
+type CredentialUse struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+type provenanceDocument struct {
+	Version int          `json:"version"`
+	Records []Provenance `json:"records"`
+}
+
+// ProvenanceStore keeps one provenance record per service, target and
+// scope in a JSON file, the install state file. A reinstall replaces the
+// record.
+type ProvenanceStore struct {
+	path       string
+	legacyPath string
+	clock      clock.Clock
+}
+
+// NewProvenanceStore creates a store backed by the given file.
+//
+// If path is empty, it defaults to state.json inside DefaultDir. Records
+// still in a provenance.json next to the file are picked up until the first
+// write moves them over.
+func NewProvenanceStore(path string) *ProvenanceStore {
+	trimmedPath := strings.TrimSpace(path)
+	if trimmedPath == "" {
+		trimmedPath = filepath.Join(DefaultDir(), installStateFileName)
+	}
+
+	legacyPath := filepath.Join(filepath.Dir(trimmedPath), legacyProvenanceFileName)
+	if legacyPath == trimmedPath {
+		legacyPath = ""
+	}
+
+	return &ProvenanceStore{path: trimmedPath, legacyPath: legacyPath, clock: clock.System}
+}
+
+// Path returns the on-disk path of the provenance file.
+func (s *ProvenanceStore) Path() string {
+	return s.path
+}
+
+// Record saves record, replacing the one for the same service, target and
+// scope. InstalledAt is set to now when it is zero.
+func (s *ProvenanceStore) Record(record Provenance) error {
+	record.Service = strings.TrimSpace(record.Service)
+	if record.Service == "" {
+		return errors.New("service name is required")
+	}
+
+	if record.InstalledAt.IsZero() {
+		record.InstalledAt = clock.Stamp(s.clock)
+	}
+
+	doc, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	records := make([]Provenance, 0, len(doc.Records)+1)
+	for _, existing := range doc.Records {
+		if !sameProvenanceKey(existing, record.Service, record.Target, record.Scope) {
+			records = append(records, existing)
+		}
+	}
+
+	doc.Records = append(records, record)
+
+	return s.write(doc)
+}
+
+// Remove deletes the record for service on target in scope. A missing
+// record is not an error.
+func (s *ProvenanceStore) Remove(service, target, scope string) error {
+	doc, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	records := make([]Provenance, 0, len(doc.Records))
+	for _, existing := range doc.Records {
+		if !sameProvenanceKey(existing, service, target, scope) {
+			records = append(records, existing)
+		}
+	}
+
+	if len(records) == len(doc.Records) {
+		return nil
+	}
+
+	doc.Records = records
+
+	return s.write(doc)
+}
+
+// ForService returns the records of service, ordered by target and scope.
+func (s *ProvenanceStore) ForService(service string) ([]Provenance, error) {
+	doc, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Provenance
+	for _, record := range doc.Records {
+		if strings.EqualFold(record.Service, strings.TrimSpace(service)) {
+			records = append(records, record)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Target != records[j].Target {
+			return records[i].Target < records[j].Target
+		}
+		return records[i].Scope < records[j].Scope
+	})
+
+	return records, nil
+}
+
+// All returns every record, ordered by service, target and scope.
+func (s *ProvenanceStore) All() ([]Provenance, error) {
+	doc, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	records := append([]Provenance(nil), doc.Records...)
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Service != records[j].Service {
+			return records[i].Service < records[j].Service
+		}
+		if records[i].Target != records[j].Target {
+			return records[i].Target < records[j].Target
+		}
+		return records[i].Scope < records[j].Scope
+	})
+
+	return records, nil
+}
+
+func sameProvenanceKey(record Provenance, service, target, scope string) bool {
+	return strings.EqualFold(record.Service, strings.TrimSpace(service)) &&
+		strings.EqualFold(record.Target, strings.TrimSpace(target)) &&
+		strings.EqualFold(record.Scope, strings.TrimSpace(scope))
+}
+
+func (s *ProvenanceStore) read() (provenanceDocument, error) {
+	path := s.path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && s.legacyPath != "" {
+		path = s.legacyPath
+		data, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return provenanceDocument{}, nil
+		}
+
+		return provenanceDocument{}, fmt.Errorf("read install state file %q: %w", path, err)
+	}
+
+	var doc provenanceDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return provenanceDocument{}, fmt.Errorf("parse install state file %q: %w", path, err)
+	}
+
+	if doc.Version > installStateFormat {
+		return provenanceDocument{}, fmt.Errorf("install state file %q has format version %d; this mcp-wire reads up to %d", path, doc.Version, installStateFormat)
+	}
+
+	return doc, nil
+}
+
+func (s *ProvenanceStore) write(doc provenanceDocument) error {
+	stateDir := filepath.Dir(s.path)
+	if err := os.MkdirAll(stateDir, 0o700); err != nil {
+		return fmt.Errorf("create state directory %q: %w", stateDir, err)
+	}
+
+	doc.Version = installStateFormat
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal install state: %w", err)
+	}
+
+	data = append(data, '\n')
+
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("write install state file %q: %w", s.path, err)
+	}
+
+	if s.legacyPath != "" {
+		if err := os.Remove(s.legacyPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove legacy provenance file %q: %w", s.legacyPath, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+)
+
+func TestProvenanceStoreForServiceEmptyWhenFileMissing(t *testing.T) {
+	store := NewProvenanceStore(filepath.Join(t.TempDir(), "provenance.json"))
+
+	records, err := store.ForService("jira")
+	if err != nil {
+		t.Fatalf("expected records to load: %v", err)
+	}
+
+	if len(records) != 0 {
+		t.Fatalf("expected no records, got %+v", records)
+	}
+}
+
+func TestProvenanceStoreRecordReplacesSameTargetAndScope(t *testing.T) {
+	store := NewProvenanceStore(filepath.Join(t.TempDir(), "nested", "provenance.json"))
+	store.clock = clock.Fixed(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+
+	records := []Provenance{
+		{Service: "jira", Target: "claude", Scope: "user", Command: "install", Version: "1.0.0"},
+		{Service: "jira", Target: "codex", Scope: "user", Command: "install", Version: "1.0.0"},
+		{Service: "Jira", Target: "claude", Scope: "user", Command: "import", Source: "/work/mcp.json", Version: "1.1.0",
+			Credentials: []CredentialUse{{Name: "JIRA_TOKEN", Source: "environment"}}},
+		{Service: "sentry", Target: "claude", Scope: "user", Command: "install", Version: "1.0.0"},
+	}
+	for _, record := range records {
+		if err := store.Record(record); err != nil {
+			t.Fatalf("expected record to succeed: %v", err)
+		}
+	}
+
+	got, err := store.ForService("jira")
+	if err != nil {
+		t.Fatalf("expected records to load: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 records, got %+v", got)
+	}
+
+	if got[0].Target != "claude" || got[0].Command != "import" || got[0].Version != "1.1.0" {
+		t.Fatalf("expected the reinstall to replace the claude record, got %+v", got[0])
+	}
+
+	if len(got[0].Credentials) != 1 || got[0].Credentials[0].Source != "environment" {
+		t.Fatalf("expected credential sources to be kept, got %+v", got[0].Credentials)
+	}
+
+	if !got[0].InstalledAt.Equal(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected InstalledAt to default to now, got %v", got[0].InstalledAt)
+	}
+
+	if got[1].Target != "codex" {
+		t.Fatalf("expected records ordered by target, got %+v", got)
+	}
+}
+
+func TestProvenanceStoreRemove(t *testing.T) {
+	store := NewProvenanceStore(filepath.Join(t.TempDir(), "provenance.json"))
+
+	for _, scope := range []string{"user", "project"} {
+		if err := store.Record(Provenance{Service: "jira", Target: "claude", Scope: scope, Command: "install"}); err != nil {
+			t.Fatalf("expected record to succeed: %v", err)
+		}
+	}
+
+	if err := store.Remove("JIRA", "claude", "project"); err != nil {
+		t.Fatalf("expected remove to succeed: %v", err)
+	}
+
+	if err := store.Remove("missing", "claude", "user"); err != nil {
+		t.Fatalf("expected removing a missing record to succeed: %v", err)
+	}
+
+	got, err := store.ForService("jira")
+	if err != nil {
+		t.Fatalf("expected records to load: %v", err)
+	}
+
+	if len(got) != 1 || got[0].Scope != "user" {
+		t.Fatalf("expected only the user record to remain, got %+v", got)
+	}
+}
+
+func TestProvenanceStoreAllOrdersRecords(t *testing.T) {
+	store := NewProvenanceStore(filepath.Join(t.TempDir(), "provenance.json"))
+
+	for _, record := range []Provenance{
+		{Service: "sentry", Target: "codex", Scope: "user", Fingerprint: "abc"},
+		{Service: "jira", Target: "codex", Scope: "user"},
+		{Service: "jira", Target: "claude", Scope: "user"},
+	} {
+		if err := store.Record(record); err != nil {
+			t.Fatalf("expected record to succeed: %v", err)
+		}
+	}
+
+	got, err := store.All()
+	if err != nil {
+		t.Fatalf("expected records to load: %v", err)
+	}
+
+	if len(got) != 3 || got[0].Target != "claude" || got[1].Target != "codex" || got[2].Service != "sentry" {
+		t.Fatalf("unexpected order %+v", got)
+	}
+
+	if got[2].Fingerprint != "abc" {
+		t.Fatalf("expected fingerprint to round-trip, got %+v", got[2])
+	}
+}
+
+func TestProvenanceStoreRecordRequiresService(t *testing.T) {
+	store := NewProvenanceStore(filepath.Join(t.TempDir(), "provenance.json"))
+
+	if err := store.Record(Provenance{Service: "  ", Target: "claude"}); err == nil {
+		t.Fatal("expected an empty service name to fail")
+	}
+}
+
+func TestProvenanceStoreWritesPrivateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "provenance.json")
+	store := NewProvenanceStore(path)
+
+	if err := store.Record(Provenance{Service: "jira", Target: "claude", Scope: "user"}); err != nil {
+		t.Fatalf("expected record to succeed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected provenance file to exist: %v", err)
+	}
+
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected mode 0600, got %v", info.Mode().Perm())
+	}
+}
+
+func TestProvenanceStoreMovesLegacyProvenanceFile(t *testing.T) {
+	dir := t.TempDir()
+	legacyPath := filepath.Join(dir, "provenance.json")
+	legacy := `{"records": [{"service": "jira", "target": "claude", "scope": "user", "command": "install", "version": "1.0.0"}]}`
+	if err := os.WriteFile(legacyPath, []byte(legacy), 0o600); err != nil {
+		t.Fatalf("write legacy file: %v", err)
+	}
+
+	store := NewProvenanceStore(filepath.Join(dir, "state.json"))
+
+	got, err := store.ForService("jira")
+	if err != nil || len(got) != 1 {
+		t.Fatalf("expected the legacy record to be read, got %+v err=%v", got, err)
+	}
+
+	if err := store.Record(Provenance{Service: "sentry", Target: "codex", Scope: "user"}); err != nil {
+		t.Fatalf("expected record to succeed: %v", err)
+	}
+
+	if _, err := os.Stat(legacyPath); !os.IsNotExist(err) {
+		t.Fatalf("expected the legacy file to be removed, got %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "state.json"))
+	if err != nil {
+		t.Fatalf("read state file: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"version": 1`) || !strings.Contains(string(data), `"jira"`) {
+		t.Fatalf("expected a versioned state file holding both records, got %s", data)
+	}
+}
+
+func TestProvenanceStoreRejectsNewerFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "records": []}`), 0o600); err != nil {
+		t.Fatalf("write state file: %v", err)
+	}
+
+	if _, err := NewProvenanceStore(path).All(); err == nil || !strings.Contains(err.Error(), "format version 99") {
+		t.Fatalf("expected a newer format to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+)
+
+const (
+	recentFileName = "recent.json"
+
+	// MaxRecentServices caps how many services the recent list keeps.
+	MaxRecentServices = 5
+)
+
+// RecentService records when a service was last installed.
+type RecentService struct {
+	Name   string    `json:"name"`
+	UsedAt time.Time `json:"used_at"`
+}
+
+type recentDocument struct {
+	Services []RecentService `json:"services"`
+}
+
+// RecentStore tracks recently installed services in a small JSON file.
+type RecentStore struct {
+	path  string
+	clock clock.Clock
+}
+
+// NewRecentStore creates a store backed by the given file.
+//
+// If path is empty, it defaults to recent.json inside DefaultDir.
+func NewRecentStore(path string) *RecentStore {
+	trimmedPath := strings.TrimSpace(path)
+	if trimmedPath == "" {
+		trimmedPath = filepath.Join(DefaultDir(), recentFileName)
+	}
+
+	return &RecentStore{path: trimmedPath, clock: clock.System}
+}
+
+// Path returns the on-disk path of the recent services file.
+func (s *RecentStore) Path() string {
+	return s.path
+}
+
+// List returns recently installed services, most recent first.
+func (s *RecentStore) List() ([]RecentService, error) {
+	doc, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.Services, nil
+}
+
+// Names returns recently installed service names, most recent first.
+func (s *RecentStore) Names() ([]string, error) {
+	services, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(services))
+	for _, svc := range services {
+		names = append(names, svc.Name)
+	}
+
+	return names, nil
+}
+
+// Record moves the named service to the front of the recent list.
+func (s *RecentStore) Record(name string) error {
+	trimmedName := strings.TrimSpace(name)
+	if trimmedName == "" {
+		return errors.New("service name is required")
+	}
+
+	doc, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	services := []RecentService{{Name: trimmedName, UsedAt: clock.Stamp(s.clock)}}
+	for _, existing := range doc.Services {
+		if strings.EqualFold(existing.Name, trimmedName) {
+			continue
+		}
+
+		services = append(services, existing)
+	}
+
+	if len(services) > MaxRecentServices {
+		services = services[:MaxRecentServices]
+	}
+
+	doc.Services = services
+
+	return s.write(doc)
+}
+
+func (s *RecentStore) read() (recentDocument, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return recentDocument{}, nil
+		}
+
+		return recentDocument{}, fmt.Errorf("read recent services file %q: %w", s.path, err)
+	}
+
+	var doc recentDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return recentDocument{}, fmt.Errorf("parse recent services file %q: %w", s.path, err)
+	}
+
+	return doc, nil
+}
+
+func (s *RecentStore) write(doc recentDocument) error {
+	stateDir := filepath.Dir(s.path)
+	if err := os.MkdirAll(stateDir, 0o700); err != nil {
+		return fmt.Errorf("create state directory %q: %w", stateDir, err)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal recent services: %w", err)
+	}
+
+	data = append(data, '\n')
+
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("write recent services file %q: %w", s.path, err)
+	}
+
+	return nil
+}
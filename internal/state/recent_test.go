@@ -0,0 +1,91 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecentStoreNamesEmptyWhenFileMissing(t *testing.T) {
+	store := NewRecentStore(filepath.Join(t.TempDir(), "recent.json"))
+
+	names, err := store.Names()
+	if err != nil {
+		t.Fatalf("expected names to succeed: %v", err)
+	}
+
+	if len(names) != 0 {
+		t.Fatalf("expected no recent services, got %v", names)
+	}
+}
+
+func TestRecentStoreRecordMovesServiceToFront(t *testing.T) {
+	store := NewRecentStore(filepath.Join(t.TempDir(), "nested", "recent.json"))
+
+	for _, name := range []string{"jira", "sentry", "Jira"} {
+		if err := store.Record(name); err != nil {
+			t.Fatalf("expected record %q to succeed: %v", name, err)
+		}
+	}
+
+	names, err := store.Names()
+	if err != nil {
+		t.Fatalf("expected names to succeed: %v", err)
+	}
+
+	if strings.Join(names, ",") != "Jira,sentry" {
+		t.Fatalf("expected Jira,sentry, got %v", names)
+	}
+}
+
+func TestRecentStoreRecordCapsListLength(t *testing.T) {
+	store := NewRecentStore(filepath.Join(t.TempDir(), "recent.json"))
+
+	for i := 0; i < MaxRecentServices+3; i++ {
+		if err := store.Record(string(rune('a' + i))); err != nil {
+			t.Fatalf("expected record to succeed: %v", err)
+		}
+	}
+
+	names, err := store.Names()
+	if err != nil {
+		t.Fatalf("expected names to succeed: %v", err)
+	}
+
+	if len(names) != MaxRecentServices {
+		t.Fatalf("expected %d names, got %d", MaxRecentServices, len(names))
+	}
+
+	if names[0] != "h" {
+		t.Fatalf("expected most recent service first, got %q", names[0])
+	}
+}
+
+func TestRecentStoreRecordRejectsEmptyName(t *testing.T) {
+	store := NewRecentStore(filepath.Join(t.TempDir(), "recent.json"))
+
+	if err := store.Record("  "); err == nil {
+		t.Fatal("expected empty name to fail")
+	}
+}
+
+func TestRecentStoreReturnsErrorOnInvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recent.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	if _, err := NewRecentStore(path).Names(); err == nil {
+		t.Fatal("expected invalid JSON to fail")
+	}
+}
+
+func TestDefaultDirHonoursXDGStateHome(t *testing.T) {
+	stateHome := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateHome)
+
+	if got := DefaultDir(); got != filepath.Join(stateHome, "mcp-wire") {
+		t.Fatalf("unexpected default dir %q", got)
+	}
+}
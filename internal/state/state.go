@@ -0,0 +1,27 @@
+// Package state persists mcp-wire's own bookkeeping, such as recently used
+// services, under the user's state directory.
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const stateDirName = "mcp-wire"
+
+// DefaultDir returns the directory mcp-wire uses for local state.
+//
+// It honours $XDG_STATE_HOME and otherwise defaults to ~/.local/state/mcp-wire.
+func DefaultDir() string {
+	if xdgStateHome := strings.TrimSpace(os.Getenv("XDG_STATE_HOME")); xdgStateHome != "" {
+		return filepath.Join(xdgStateHome, stateDirName)
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".local", "state", stateDirName)
+	}
+
+	return filepath.Join(homeDir, ".local", "state", stateDirName)
+}
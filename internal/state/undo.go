@@ -0,0 +1,298 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+)
+
+const undoFileName = "undo.json"
+
+// ErrNothingToUndo is returned by Undo when no operation has been recorded.
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// FileSnapshot holds the content a config file had before an operation.
+// The snapshot may contain credentials, so the undo file is private to the
+// user like the configs it copies.
+type FileSnapshot struct {
+	Path    string      `json:"path"`
+	Existed bool        `json:"existed"`
+	Content []byte      `json:"content,omitempty"`
+	Mode    os.FileMode `json:"mode,omitempty"`
+
+	// After is the checksum of the file once the operation finished, or empty
+	// when the operation removed it. Undo refuses to overwrite a file whose
+	// checksum no longer matches, since something else changed it since.
+	After string `json:"after,omitempty"`
+}
+
+// UndoRecord describes the most recent operation that can be undone.
+type UndoRecord struct {
+	Description string         `json:"description"`
+	RecordedAt  time.Time      `json:"recorded_at"`
+	Files       []FileSnapshot `json:"files"`
+}
+
+// UndoStore keeps the prior content of the config files touched by the most
+// recent operation so it can be restored.
+type UndoStore struct {
+	path  string
+	clock clock.Clock
+}
+
+// NewUndoStore creates a store backed by the given file.
+//
+// If path is empty, it defaults to undo.json inside DefaultDir.
+func NewUndoStore(path string) *UndoStore {
+	trimmedPath := strings.TrimSpace(path)
+	if trimmedPath == "" {
+		trimmedPath = filepath.Join(DefaultDir(), undoFileName)
+	}
+
+	return &UndoStore{path: trimmedPath, clock: clock.System}
+}
+
+// Path returns the on-disk path of the undo file.
+func (s *UndoStore) Path() string {
+	return s.path
+}
+
+// SnapshotFiles reads the current content of each path. Missing files are
+// recorded as not existing; duplicate and empty paths are skipped.
+func SnapshotFiles(paths []string) ([]FileSnapshot, error) {
+	seen := make(map[string]struct{}, len(paths))
+	snapshots := make([]FileSnapshot, 0, len(paths))
+
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+
+		if _, exists := seen[path]; exists {
+			continue
+		}
+		seen[path] = struct{}{}
+
+		snapshot := FileSnapshot{Path: path}
+		info, err := os.Stat(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				snapshots = append(snapshots, snapshot)
+				continue
+			}
+
+			return nil, fmt.Errorf("stat config file %q: %w", path, err)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read config file %q: %w", path, err)
+		}
+
+		snapshot.Existed = true
+		snapshot.Content = content
+		snapshot.Mode = info.Mode().Perm()
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// ChangedFiles returns the paths of the snapshots whose file was created,
+// changed, or removed since the snapshot was taken.
+func ChangedFiles(snapshots []FileSnapshot) ([]string, error) {
+	var changed []string
+	for _, snapshot := range snapshots {
+		_, modified, err := snapshotModified(snapshot)
+		if err != nil {
+			return nil, err
+		}
+
+		if modified {
+			changed = append(changed, snapshot.Path)
+		}
+	}
+
+	return changed, nil
+}
+
+// Record saves before as the most recent operation, keeping only the files
+// that changed since the snapshots were taken. When nothing changed the
+// previous record is left in place and Record reports false.
+func (s *UndoStore) Record(description string, before []FileSnapshot) (bool, error) {
+	changed := make([]FileSnapshot, 0, len(before))
+	for _, snapshot := range before {
+		current, modified, err := snapshotModified(snapshot)
+		if err != nil {
+			return false, err
+		}
+
+		if !modified {
+			continue
+		}
+
+		snapshot.After = current
+		changed = append(changed, snapshot)
+	}
+
+	if len(changed) == 0 {
+		return false, nil
+	}
+
+	record := UndoRecord{
+		Description: strings.TrimSpace(description),
+		RecordedAt:  clock.Stamp(s.clock),
+		Files:       changed,
+	}
+
+	return true, s.write(&record)
+}
+
+// Last returns the most recent operation, or nil when there is none.
+func (s *UndoStore) Last() (*UndoRecord, error) {
+	return s.read()
+}
+
+// Undo restores every file of the most recent operation to its prior content
+// and clears the record. It changes nothing when any of the files was
+// modified after the operation.
+func (s *UndoStore) Undo() (*UndoRecord, error) {
+	record, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	if record == nil {
+		return nil, ErrNothingToUndo
+	}
+
+	for _, snapshot := range record.Files {
+		current, _, err := readFileChecksum(snapshot.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if current != snapshot.After {
+			return nil, fmt.Errorf("%s changed after %q; refusing to undo", snapshot.Path, record.Description)
+		}
+	}
+
+	for _, snapshot := range record.Files {
+		if err := restoreSnapshot(snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("remove undo file %q: %w", s.path, err)
+	}
+
+	return record, nil
+}
+
+func restoreSnapshot(snapshot FileSnapshot) error {
+	if !snapshot.Existed {
+		if err := os.Remove(snapshot.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove config file %q: %w", snapshot.Path, err)
+		}
+
+		return nil
+	}
+
+	mode := snapshot.Mode
+	if mode == 0 {
+		mode = 0o600
+	}
+
+	if err := os.MkdirAll(filepath.Dir(snapshot.Path), 0o755); err != nil {
+		return fmt.Errorf("create config directory %q: %w", filepath.Dir(snapshot.Path), err)
+	}
+
+	if err := os.WriteFile(snapshot.Path, snapshot.Content, mode); err != nil {
+		return fmt.Errorf("restore config file %q: %w", snapshot.Path, err)
+	}
+
+	return nil
+}
+
+// snapshotModified returns the checksum the file of snapshot has now and
+// whether it differs from the snapshot.
+func snapshotModified(snapshot FileSnapshot) (string, bool, error) {
+	current, exists, err := readFileChecksum(snapshot.Path)
+	if err != nil {
+		return "", false, err
+	}
+
+	if exists != snapshot.Existed {
+		return current, true, nil
+	}
+
+	return current, exists && current != checksum(snapshot.Content), nil
+}
+
+// readFileChecksum returns the checksum of the file at path and whether it
+// exists. A missing file has an empty checksum.
+func readFileChecksum(path string) (string, bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", false, nil
+		}
+
+		return "", false, fmt.Errorf("read config file %q: %w", path, err)
+	}
+
+	return checksum(content), true, nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *UndoStore) read() (*UndoRecord, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read undo file %q: %w", s.path, err)
+	}
+
+	var record UndoRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("parse undo file %q: %w", s.path, err)
+	}
+
+	return &record, nil
+}
+
+func (s *UndoStore) write(record *UndoRecord) error {
+	stateDir := filepath.Dir(s.path)
+	if err := os.MkdirAll(stateDir, 0o700); err != nil {
+		return fmt.Errorf("create state directory %q: %w", stateDir, err)
+	}
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal undo record: %w", err)
+	}
+
+	data = append(data, '\n')
+
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("write undo file %q: %w", s.path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package state
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUndoStoreRestoresChangedAndCreatedFiles(t *testing.T) {
+	dir := t.TempDir()
+	store := NewUndoStore(filepath.Join(dir, "state", "undo.json"))
+
+	existingPath := filepath.Join(dir, "settings.json")
+	createdPath := filepath.Join(dir, "nested", "config.toml")
+	untouchedPath := filepath.Join(dir, "untouched.json")
+
+	writeUndoTestFile(t, existingPath, `{"mcpServers":{}}`)
+	writeUndoTestFile(t, untouchedPath, `{}`)
+
+	before, err := SnapshotFiles([]string{existingPath, createdPath, untouchedPath, existingPath})
+	if err != nil {
+		t.Fatalf("expected snapshot to succeed: %v", err)
+	}
+
+	if len(before) != 3 {
+		t.Fatalf("expected duplicate paths to be skipped, got %d snapshots", len(before))
+	}
+
+	writeUndoTestFile(t, existingPath, `{"mcpServers":{"jira":{}}}`)
+	writeUndoTestFile(t, createdPath, `[mcp_servers.jira]`)
+
+	recorded, err := store.Record("install jira", before)
+	if err != nil || !recorded {
+		t.Fatalf("expected record to succeed, got recorded=%v err=%v", recorded, err)
+	}
+
+	last, err := store.Last()
+	if err != nil {
+		t.Fatalf("expected last to succeed: %v", err)
+	}
+
+	if last == nil || last.Description != "install jira" || len(last.Files) != 2 {
+		t.Fatalf("expected record of the two changed files, got %#v", last)
+	}
+
+	if _, err := store.Undo(); err != nil {
+		t.Fatalf("expected undo to succeed: %v", err)
+	}
+
+	if content := readUndoTestFile(t, existingPath); content != `{"mcpServers":{}}` {
+		t.Fatalf("expected prior content to be restored, got %q", content)
+	}
+
+	if _, err := os.Stat(createdPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected file created by the operation to be removed, got %v", err)
+	}
+
+	if _, err := store.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("expected nothing to undo after undoing once, got %v", err)
+	}
+}
+
+func TestUndoStoreRecordKeepsPreviousRecordWhenNothingChanged(t *testing.T) {
+	dir := t.TempDir()
+	store := NewUndoStore(filepath.Join(dir, "undo.json"))
+	configPath := filepath.Join(dir, "settings.json")
+
+	before, err := SnapshotFiles([]string{configPath})
+	if err != nil {
+		t.Fatalf("expected snapshot to succeed: %v", err)
+	}
+
+	writeUndoTestFile(t, configPath, `{"a":1}`)
+	if _, err := store.Record("install first", before); err != nil {
+		t.Fatalf("expected record to succeed: %v", err)
+	}
+
+	unchanged, err := SnapshotFiles([]string{configPath})
+	if err != nil {
+		t.Fatalf("expected snapshot to succeed: %v", err)
+	}
+
+	recorded, err := store.Record("install second", unchanged)
+	if err != nil || recorded {
+		t.Fatalf("expected no record for an operation that changed nothing, got recorded=%v err=%v", recorded, err)
+	}
+
+	last, err := store.Last()
+	if err != nil || last == nil || last.Description != "install first" {
+		t.Fatalf("expected previous record to remain, got %#v (err %v)", last, err)
+	}
+}
+
+func TestUndoStoreRefusesWhenFileChangedAfterOperation(t *testing.T) {
+	dir := t.TempDir()
+	store := NewUndoStore(filepath.Join(dir, "undo.json"))
+	configPath := filepath.Join(dir, "settings.json")
+	writeUndoTestFile(t, configPath, `{}`)
+
+	before, err := SnapshotFiles([]string{configPath})
+	if err != nil {
+		t.Fatalf("expected snapshot to succeed: %v", err)
+	}
+
+	writeUndoTestFile(t, configPath, `{"mcpServers":{"jira":{}}}`)
+	if _, err := store.Record("install jira", before); err != nil {
+		t.Fatalf("expected record to succeed: %v", err)
+	}
+
+	writeUndoTestFile(t, configPath, `{"mcpServers":{"jira":{}},"theme":"dark"}`)
+
+	_, err = store.Undo()
+	if err == nil || !strings.Contains(err.Error(), "refusing to undo") {
+		t.Fatalf("expected undo to refuse, got %v", err)
+	}
+
+	if content := readUndoTestFile(t, configPath); !strings.Contains(content, "theme") {
+		t.Fatalf("expected later edit to be preserved, got %q", content)
+	}
+}
+
+func TestChangedFilesListsFilesChangedSinceSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	editedPath := filepath.Join(dir, "settings.json")
+	removedPath := filepath.Join(dir, "config.toml")
+	createdPath := filepath.Join(dir, "opencode.json")
+	untouchedPath := filepath.Join(dir, "untouched.json")
+
+	writeUndoTestFile(t, editedPath, `{}`)
+	writeUndoTestFile(t, removedPath, `[mcp_servers]`)
+	writeUndoTestFile(t, untouchedPath, `{}`)
+
+	before, err := SnapshotFiles([]string{editedPath, removedPath, createdPath, untouchedPath})
+	if err != nil {
+		t.Fatalf("expected snapshot to succeed: %v", err)
+	}
+
+	writeUndoTestFile(t, editedPath, `{"mcpServers":{"jira":{}}}`)
+	writeUndoTestFile(t, createdPath, `{}`)
+	if err := os.Remove(removedPath); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	changed, err := ChangedFiles(before)
+	if err != nil {
+		t.Fatalf("expected changed files to succeed: %v", err)
+	}
+
+	want := []string{editedPath, removedPath, createdPath}
+	if strings.Join(changed, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("expected %v, got %v", want, changed)
+	}
+}
+
+func TestUndoStoreFileIsPrivate(t *testing.T) {
+	dir := t.TempDir()
+	store := NewUndoStore(filepath.Join(dir, "undo.json"))
+	configPath := filepath.Join(dir, "settings.json")
+
+	before, err := SnapshotFiles([]string{configPath})
+	if err != nil {
+		t.Fatalf("expected snapshot to succeed: %v", err)
+	}
+
+	writeUndoTestFile(t, configPath, `{}`)
+	if _, err := store.Record("install jira", before); err != nil {
+		t.Fatalf("expected record to succeed: %v", err)
+	}
+
+	info, err := os.Stat(store.Path())
+	if err != nil {
+		t.Fatalf("expected undo file to exist: %v", err)
+	}
+
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Fatalf("expected undo file mode 0600, got %04o", mode)
+	}
+}
+
+func writeUndoTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create directory for %q: %v", path, err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %q: %v", path, err)
+	}
+}
+
+func readUndoTestFile(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %q: %v", path, err)
+	}
+
+	return string(data)
+}
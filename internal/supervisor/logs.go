@@ -0,0 +1,65 @@
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// MaxLogSize is the size at which a service log is rotated.
+	MaxLogSize = 5 << 20
+	// MaxLogBackups is how many rotated logs are kept per service.
+	MaxLogBackups = 3
+)
+
+// OpenLog opens the service log for appending, rotating it first when it has
+// grown past MaxLogSize. Rotated files are named <service>.log.1 (newest)
+// through <service>.log.<MaxLogBackups> (oldest).
+func (s *Supervisor) OpenLog(serviceName string) (*os.File, error) {
+	logPath := s.LogPath(serviceName)
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o700); err != nil {
+		return nil, fmt.Errorf("create log directory %q: %w", filepath.Dir(logPath), err)
+	}
+
+	if err := rotateLog(logPath, MaxLogSize, MaxLogBackups); err != nil {
+		return nil, err
+	}
+
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open log file %q: %w", logPath, err)
+	}
+
+	return logFile, nil
+}
+
+func rotateLog(path string, maxSize int64, backups int) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("stat log file %q: %w", path, err)
+	}
+
+	if info.Size() < maxSize {
+		return nil
+	}
+
+	for i := backups - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", path, i)
+		to := fmt.Sprintf("%s.%d", path, i+1)
+		if err := os.Rename(from, to); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("rotate log file %q: %w", from, err)
+		}
+	}
+
+	if err := os.Rename(path, path+".1"); err != nil {
+		return fmt.Errorf("rotate log file %q: %w", path, err)
+	}
+
+	return nil
+}
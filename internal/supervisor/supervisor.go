@@ -0,0 +1,233 @@
+// Package supervisor starts long-running local MCP servers in the background
+// and tracks them through small records under the state directory.
+package supervisor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+	"github.com/andreagrandi/mcp-wire/internal/state"
+)
+
+const (
+	runDirName  = "run"
+	logsDirName = "logs"
+)
+
+// ErrNotRunning is returned when a service has no running supervised process.
+var ErrNotRunning = errors.New("service is not running")
+
+// Spec describes a process to start under supervision.
+type Spec struct {
+	Service string
+	Command string
+	Args    []string
+	Env     []string // extra KEY=value entries added to the current environment
+	URL     string
+}
+
+// Process is the persisted record of a supervised process.
+type Process struct {
+	Service   string    `json:"service"`
+	PID       int       `json:"pid"`
+	Command   string    `json:"command"`
+	Args      []string  `json:"args,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	LogPath   string    `json:"log_path"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// Status pairs a process record with whether the process is still alive.
+type Status struct {
+	Process
+	Running bool
+}
+
+// Supervisor manages background processes rooted at a state directory.
+type Supervisor struct {
+	dir   string
+	clock clock.Clock
+	alive func(pid int) bool
+}
+
+// New creates a supervisor rooted at dir.
+//
+// If dir is empty, it defaults to state.DefaultDir.
+func New(dir string) *Supervisor {
+	trimmedDir := strings.TrimSpace(dir)
+	if trimmedDir == "" {
+		trimmedDir = state.DefaultDir()
+	}
+
+	return &Supervisor{dir: trimmedDir, clock: clock.System, alive: processAlive}
+}
+
+// LogPath returns the file a supervised service writes its output to.
+func (s *Supervisor) LogPath(serviceName string) string {
+	return filepath.Join(s.dir, logsDirName, serviceName+".log")
+}
+
+// Start launches spec in the background, detached from the current terminal,
+// with stdout and stderr appended to the service log file.
+func (s *Supervisor) Start(spec Spec) (Process, error) {
+	serviceName := strings.TrimSpace(spec.Service)
+	if serviceName == "" {
+		return Process{}, errors.New("service name is required")
+	}
+
+	if status, found, err := s.Status(serviceName); err != nil {
+		return Process{}, err
+	} else if found && status.Running {
+		return Process{}, fmt.Errorf("service %q is already running (pid %d)", serviceName, status.PID)
+	}
+
+	logFile, err := s.OpenLog(serviceName)
+	if err != nil {
+		return Process{}, err
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(spec.Command, spec.Args...)
+	cmd.Env = append(os.Environ(), spec.Env...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	detach(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return Process{}, fmt.Errorf("start service %q: %w", serviceName, err)
+	}
+
+	process := Process{
+		Service:   serviceName,
+		PID:       cmd.Process.Pid,
+		Command:   spec.Command,
+		Args:      spec.Args,
+		URL:       spec.URL,
+		LogPath:   logFile.Name(),
+		StartedAt: clock.Stamp(s.clock),
+	}
+
+	if err := s.writeRecord(process); err != nil {
+		_ = cmd.Process.Kill()
+		return Process{}, err
+	}
+
+	// Reap the child in the background so it does not linger as a zombie
+	// while this process is still alive.
+	go func() { _ = cmd.Wait() }()
+
+	return process, nil
+}
+
+// Stop terminates the supervised process for a service and removes its record.
+func (s *Supervisor) Stop(serviceName string) error {
+	status, found, err := s.Status(serviceName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return ErrNotRunning
+	}
+
+	if status.Running {
+		if err := terminate(status.PID); err != nil {
+			return fmt.Errorf("stop service %q (pid %d): %w", status.Service, status.PID, err)
+		}
+	}
+
+	if err := os.Remove(s.recordPath(status.Service)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove process record for %q: %w", status.Service, err)
+	}
+
+	if !status.Running {
+		return ErrNotRunning
+	}
+
+	return nil
+}
+
+// Status returns the recorded process for a service, if any.
+func (s *Supervisor) Status(serviceName string) (Status, bool, error) {
+	data, err := os.ReadFile(s.recordPath(serviceName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Status{}, false, nil
+		}
+
+		return Status{}, false, fmt.Errorf("read process record for %q: %w", serviceName, err)
+	}
+
+	var process Process
+	if err := json.Unmarshal(data, &process); err != nil {
+		return Status{}, false, fmt.Errorf("parse process record for %q: %w", serviceName, err)
+	}
+
+	return Status{Process: process, Running: s.alive(process.PID)}, true, nil
+}
+
+// List returns every recorded process sorted by service name.
+func (s *Supervisor) List() ([]Status, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, runDirName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read run directory: %w", err)
+	}
+
+	statuses := make([]Status, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		status, found, err := s.Status(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			statuses = append(statuses, status)
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Service < statuses[j].Service
+	})
+
+	return statuses, nil
+}
+
+func (s *Supervisor) recordPath(serviceName string) string {
+	return filepath.Join(s.dir, runDirName, serviceName+".json")
+}
+
+func (s *Supervisor) writeRecord(process Process) error {
+	path := s.recordPath(process.Service)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create run directory %q: %w", filepath.Dir(path), err)
+	}
+
+	data, err := json.MarshalIndent(process, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal process record: %w", err)
+	}
+
+	data = append(data, '\n')
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write process record %q: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package supervisor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func skipWithoutSleep(t *testing.T) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("supervisor process tests rely on a POSIX sleep command")
+	}
+}
+
+func TestStartStatusStop(t *testing.T) {
+	skipWithoutSleep(t)
+
+	sup := New(t.TempDir())
+
+	process, err := sup.Start(Spec{Service: "demo", Command: "sleep", Args: []string{"30"}, URL: "http://127.0.0.1:41000/mcp"})
+	if err != nil {
+		t.Fatalf("expected start to succeed: %v", err)
+	}
+
+	if process.PID <= 0 {
+		t.Fatalf("expected a pid, got %d", process.PID)
+	}
+
+	status, found, err := sup.Status("demo")
+	if err != nil || !found || !status.Running {
+		t.Fatalf("expected running status, got %+v found=%v err=%v", status, found, err)
+	}
+
+	if status.URL != "http://127.0.0.1:41000/mcp" {
+		t.Fatalf("expected URL to be recorded, got %q", status.URL)
+	}
+
+	if _, err := sup.Start(Spec{Service: "demo", Command: "sleep", Args: []string{"30"}}); err == nil {
+		t.Fatal("expected second start to fail while running")
+	}
+
+	if err := sup.Stop("demo"); err != nil {
+		t.Fatalf("expected stop to succeed: %v", err)
+	}
+
+	if _, found, _ := sup.Status("demo"); found {
+		t.Fatal("expected process record to be removed after stop")
+	}
+}
+
+func TestStartWritesOutputToLogFile(t *testing.T) {
+	skipWithoutSleep(t)
+
+	sup := New(t.TempDir())
+
+	if _, err := sup.Start(Spec{Service: "echo", Command: "sh", Args: []string{"-c", "echo hello from $GREETING"}, Env: []string{"GREETING=mcp"}}); err != nil {
+		t.Fatalf("expected start to succeed: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		data, _ := os.ReadFile(sup.LogPath("echo"))
+		if strings.Contains(string(data), "hello from mcp") {
+			return
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	t.Fatal("expected process output in log file")
+}
+
+func TestStopReturnsErrNotRunningForUnknownService(t *testing.T) {
+	sup := New(t.TempDir())
+
+	if err := sup.Stop("missing"); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("expected ErrNotRunning, got %v", err)
+	}
+}
+
+func TestListReportsStaleRecords(t *testing.T) {
+	sup := New(t.TempDir())
+	sup.alive = func(int) bool { return false }
+
+	if err := sup.writeRecord(Process{Service: "stale", PID: 12345}); err != nil {
+		t.Fatalf("expected record write to succeed: %v", err)
+	}
+
+	statuses, err := sup.List()
+	if err != nil {
+		t.Fatalf("expected list to succeed: %v", err)
+	}
+
+	if len(statuses) != 1 || statuses[0].Service != "stale" || statuses[0].Running {
+		t.Fatalf("expected one stopped record, got %+v", statuses)
+	}
+}
+
+func TestRotateLogKeepsBoundedBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "demo.log")
+
+	for i := 0; i < 5; i++ {
+		if err := os.WriteFile(path, []byte(strings.Repeat("x", 10)), 0o600); err != nil {
+			t.Fatalf("failed to write log: %v", err)
+		}
+
+		if err := rotateLog(path, 10, 2); err != nil {
+			t.Fatalf("expected rotation to succeed: %v", err)
+		}
+	}
+
+	for _, name := range []string{"demo.log.1", "demo.log.2"} {
+		if _, err := os.Stat(filepath.Join(filepath.Dir(path), name)); err != nil {
+			t.Fatalf("expected %s to exist: %v", name, err)
+		}
+	}
+
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("expected no third backup, got %v", err)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected active log to be rotated away, got %v", err)
+	}
+}
+
+func TestRotateLogLeavesSmallLogs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "demo.log")
+	if err := os.WriteFile(path, []byte("small"), 0o600); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	if err := rotateLog(path, 1024, 2); err != nil {
+		t.Fatalf("expected rotation to succeed: %v", err)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected log to stay in place: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package target
+
+import "fmt"
+
+// ConfigBackupSetter is an optional interface for targets that can save a
+// config file before rewriting it. The CLI sets backup to copy the file
+// into the mcp-wire state directory; no backups are taken until it does.
+type ConfigBackupSetter interface {
+	SetConfigBackup(backup func(configPath string) error)
+}
+
+// SetConfigBackup sets the func called with each config file before it is
+// written.
+func (t *ClaudeCodeTarget) SetConfigBackup(backup func(configPath string) error) {
+	t.backup = backup
+}
+
+// SetConfigBackup sets the func called with each config file before it is
+// written.
+func (t *CodexTarget) SetConfigBackup(backup func(configPath string) error) {
+	t.backup = backup
+}
+
+// SetConfigBackup sets the func called with each config file before it is
+// written.
+func (t *OpenCodeTarget) SetConfigBackup(backup func(configPath string) error) {
+	t.backup = backup
+}
+
+// SetConfigBackup sets the func called with each config file before it is
+// written.
+func (t *VSCodeTarget) SetConfigBackup(backup func(configPath string) error) {
+	t.backup = backup
+}
+
+// backupConfigFile saves configPath with backup, when one is set. A failed
+// backup stops the write, so a config is never changed without one.
+func backupConfigFile(backup func(configPath string) error, configPath string) error {
+	if backup == nil {
+		return nil
+	}
+
+	if err := backup(configPath); err != nil {
+		return fmt.Errorf("back up config file %q: %w", configPath, err)
+	}
+
+	return nil
+}
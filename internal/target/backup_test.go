@@ -0,0 +1,67 @@
+package target
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func TestConfigBackupRunsBeforeEveryWrite(t *testing.T) {
+	dir := t.TempDir()
+	targets := []Target{
+		&ClaudeCodeTarget{configPath: filepath.Join(dir, ".claude.json")},
+		&CodexTarget{configPath: filepath.Join(dir, "config.toml")},
+		&OpenCodeTarget{configPath: filepath.Join(dir, "opencode.json")},
+		&VSCodeTarget{configPath: filepath.Join(dir, "settings.json")},
+	}
+	svc := service.Service{Name: "demo", Transport: "sse", URL: "https://example.com/sse"}
+
+	for _, target := range targets {
+		var backedUp []string
+		target.(ConfigBackupSetter).SetConfigBackup(func(configPath string) error {
+			backedUp = append(backedUp, configPath)
+			return nil
+		})
+
+		if err := target.Install(svc, nil); err != nil {
+			t.Fatalf("expected %s install to succeed: %v", target.Slug(), err)
+		}
+
+		if err := target.Uninstall(svc.Name); err != nil {
+			t.Fatalf("expected %s uninstall to succeed: %v", target.Slug(), err)
+		}
+
+		configPath := target.(ConfigPathProvider).ConfigPath()
+		if len(backedUp) != 2 || backedUp[0] != configPath || backedUp[1] != configPath {
+			t.Fatalf("expected %s to back up %s before both writes, got %v", target.Slug(), configPath, backedUp)
+		}
+
+		sandboxed := target.(Sandboxer).Sandbox(t.TempDir())
+		if err := sandboxed.Install(svc, nil); err != nil {
+			t.Fatalf("expected sandboxed %s install to succeed: %v", target.Slug(), err)
+		}
+
+		if len(backedUp) != 2 {
+			t.Fatalf("expected sandboxed %s to take no backups, got %v", target.Slug(), backedUp)
+		}
+	}
+}
+
+func TestConfigBackupFailureStopsWrite(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	target := &CodexTarget{configPath: configPath}
+	target.SetConfigBackup(func(string) error { return errors.New("disk full") })
+
+	err := target.Install(service.Service{Name: "demo", Transport: "sse", URL: "https://example.com/sse"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "back up config file") {
+		t.Fatalf("expected backup error, got %v", err)
+	}
+
+	if _, err := os.Stat(configPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no config to be written without a backup")
+	}
+}
@@ -1,10 +1,10 @@
 package target
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -23,16 +23,26 @@ const (
 // ClaudeCodeTarget manages MCP service configuration for Claude Code.
 type ClaudeCodeTarget struct {
 	configPath          string
+	systemConfigPath    string
+	projectDir          string
+	logDir              string
 	lookPath            func(file string) (string, error)
 	statPath            func(name string) (os.FileInfo, error)
 	binaryNames         []string
 	fallbackBinaryPaths []string
+	keepEmpty           bool
+	markedInstalled     bool
+	backup              func(configPath string) error
+	preview             func(configPath string, data []byte)
+	merge               MergeStrategy
 }
 
 // NewClaudeCodeTarget returns a target instance for Claude Code.
 func NewClaudeCodeTarget() *ClaudeCodeTarget {
 	return &ClaudeCodeTarget{
 		configPath:          defaultClaudeCodeConfigPath(),
+		systemConfigPath:    defaultClaudeCodeSystemConfigPath(),
+		logDir:              defaultClaudeCodeLogDir(),
 		lookPath:            exec.LookPath,
 		statPath:            os.Stat,
 		binaryNames:         []string{claudeCodeBinaryName, "claude-code"},
@@ -55,8 +65,25 @@ func (t *ClaudeCodeTarget) ConfigPath() string {
 	return t.configPath
 }
 
+// SystemConfigPath returns the path of the machine-wide managed MCP config
+// that Claude Code loads for every user.
+func (t *ClaudeCodeTarget) SystemConfigPath() string {
+	return t.systemConfigPath
+}
+
+// SetProjectDir pins the directory project scope applies to. Without it the
+// working directory is used, or the nearest parent that already has a project
+// entry.
+func (t *ClaudeCodeTarget) SetProjectDir(dir string) {
+	t.projectDir = strings.TrimSpace(dir)
+}
+
 // IsInstalled reports whether Claude Code is available via supported install methods.
 func (t *ClaudeCodeTarget) IsInstalled() bool {
+	if t.markedInstalled {
+		return true
+	}
+
 	binaryNames := t.binaryNames
 	if len(binaryNames) == 0 {
 		binaryNames = []string{claudeCodeBinaryName}
@@ -91,7 +118,7 @@ func (t *ClaudeCodeTarget) Install(svc service.Service, resolvedEnv map[string]s
 
 // SupportedScopes returns the scopes supported by Claude Code target operations.
 func (t *ClaudeCodeTarget) SupportedScopes() []ConfigScope {
-	return []ConfigScope{ConfigScopeUser, ConfigScopeProject, ConfigScopeEffective}
+	return []ConfigScope{ConfigScopeUser, ConfigScopeProject, ConfigScopeSystem, ConfigScopeEffective}
 }
 
 // InstallWithScope writes or updates the service configuration in the requested scope.
@@ -101,12 +128,13 @@ func (t *ClaudeCodeTarget) InstallWithScope(svc service.Service, resolvedEnv map
 		return errors.New("service name is required")
 	}
 
-	config, _, err := t.readConfig()
+	configPath, perm := t.scopeConfigFile(scope)
+	config, _, err := t.readConfigFile(configPath)
 	if err != nil {
 		return err
 	}
 
-	mcpServers, err := getMCPServers(config, scope, true)
+	mcpServers, err := t.getMCPServers(config, scope, true)
 	if err != nil {
 		return err
 	}
@@ -116,9 +144,14 @@ func (t *ClaudeCodeTarget) InstallWithScope(svc service.Service, resolvedEnv map
 		return err
 	}
 
-	mcpServers[serviceName] = serverConfig
+	merged, err := mergeServerEntry(t.merge, mcpServers[serviceName], serverConfig)
+	if err != nil {
+		return err
+	}
 
-	return t.writeConfig(config)
+	mcpServers[serviceName] = merged
+
+	return t.writeConfigFile(configPath, config, perm)
 }
 
 // Uninstall removes a service from the target config.
@@ -133,7 +166,8 @@ func (t *ClaudeCodeTarget) UninstallWithScope(serviceName string, scope ConfigSc
 		return errors.New("service name is required")
 	}
 
-	config, exists, err := t.readConfig()
+	configPath, perm := t.scopeConfigFile(scope)
+	config, exists, err := t.readConfigFile(configPath)
 	if err != nil {
 		return err
 	}
@@ -142,7 +176,7 @@ func (t *ClaudeCodeTarget) UninstallWithScope(serviceName string, scope ConfigSc
 		return nil
 	}
 
-	mcpServers, err := getMCPServers(config, scope, false)
+	mcpServers, err := t.getMCPServers(config, scope, false)
 	if err != nil {
 		return err
 	}
@@ -152,8 +186,11 @@ func (t *ClaudeCodeTarget) UninstallWithScope(serviceName string, scope ConfigSc
 	}
 
 	delete(mcpServers, trimmedServiceName)
+	if !t.keepEmpty {
+		t.pruneClaudeMCPServers(config, scope)
+	}
 
-	return t.writeConfig(config)
+	return t.writeConfigFile(configPath, config, perm)
 }
 
 // List returns configured service names from the target config.
@@ -163,24 +200,34 @@ func (t *ClaudeCodeTarget) List() ([]string, error) {
 
 // ListWithScope returns configured service names from the requested scope.
 func (t *ClaudeCodeTarget) ListWithScope(scope ConfigScope) ([]string, error) {
+	serviceNames := make(map[string]struct{})
+
+	// The managed system config lives in its own file, so it is read even
+	// when the user config does not exist yet.
+	if scope == ConfigScopeSystem || scope == ConfigScopeEffective {
+		if err := t.collectClaudeSystemMCPServerNames(serviceNames, scope == ConfigScopeEffective); err != nil {
+			return nil, err
+		}
+	}
+
 	config, exists, err := t.readConfig()
 	if err != nil {
 		return nil, err
 	}
 
 	if !exists {
-		return []string{}, nil
+		config = map[string]any{}
 	}
 
-	serviceNames := make(map[string]struct{})
-
 	switch scope {
+	case ConfigScopeSystem:
+		// Already collected from the managed config above.
 	case ConfigScopeUser:
 		if err := collectClaudeMCPServerNamesFromScope(config, serviceNames, "mcpServers"); err != nil {
 			return nil, err
 		}
 	case ConfigScopeProject:
-		projectMCPServers, err := getClaudeProjectMCPServers(config, false)
+		projectMCPServers, err := getClaudeProjectMCPServers(config, t.projectDir, false)
 		if err != nil {
 			return nil, err
 		}
@@ -193,7 +240,7 @@ func (t *ClaudeCodeTarget) ListWithScope(scope ConfigScope) ([]string, error) {
 			return nil, err
 		}
 
-		projectMCPServers, err := getClaudeProjectMCPServers(config, false)
+		projectMCPServers, err := getClaudeProjectMCPServers(config, t.projectDir, false)
 		if err != nil {
 			return nil, err
 		}
@@ -215,73 +262,218 @@ func (t *ClaudeCodeTarget) ListWithScope(scope ConfigScope) ([]string, error) {
 	return services, nil
 }
 
-func (t *ClaudeCodeTarget) readConfig() (map[string]any, bool, error) {
-	data, err := os.ReadFile(t.configPath)
+// HasProjectEntry reports whether the Claude Code config already has a project
+// entry for the current directory or one of its parents.
+func (t *ClaudeCodeTarget) HasProjectEntry() (bool, error) {
+	config, exists, err := t.readConfig()
+	if err != nil || !exists {
+		return false, err
+	}
+
+	projects, ok := config["projects"].(map[string]any)
+	if !ok {
+		return false, nil
+	}
+
+	projectKey, err := resolveClaudeProjectKey(projects, t.projectDir, false)
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return map[string]any{}, false, nil
-		}
+		return false, err
+	}
 
-		return nil, false, fmt.Errorf("read config file %q: %w", t.configPath, err)
+	return projectKey != "", nil
+}
+
+// ProjectEntries returns the directories the Claude Code config keeps a
+// project entry for, sorted.
+func (t *ClaudeCodeTarget) ProjectEntries() ([]string, error) {
+	config, exists, err := t.readConfig()
+	if err != nil || !exists {
+		return nil, err
 	}
 
-	config := map[string]any{}
-	if len(bytes.TrimSpace(data)) == 0 {
-		return config, true, nil
+	projects, ok := config["projects"].(map[string]any)
+	if !ok {
+		return nil, nil
 	}
 
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, true, fmt.Errorf("parse config file %q: %w", t.configPath, err)
+	entries := make([]string, 0, len(projects))
+	for projectKey := range projects {
+		entries = append(entries, projectKey)
 	}
 
-	return config, true, nil
+	sort.Strings(entries)
+
+	return entries, nil
 }
 
-func (t *ClaudeCodeTarget) writeConfig(config map[string]any) error {
-	configDir := filepath.Dir(t.configPath)
-	if err := os.MkdirAll(configDir, 0o755); err != nil {
-		return fmt.Errorf("create config directory %q: %w", configDir, err)
+// collectClaudeSystemMCPServerNames adds the services from the managed
+// system config. When tolerant is set, a system config the current user is not
+// allowed to read is skipped instead of failing the listing.
+func (t *ClaudeCodeTarget) collectClaudeSystemMCPServerNames(serviceNames map[string]struct{}, tolerant bool) error {
+	systemConfig, exists, err := t.readConfigFile(t.systemConfigPath)
+	if err != nil {
+		if tolerant && errors.Is(err, os.ErrPermission) {
+			return nil
+		}
+
+		return err
 	}
 
-	data, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		return fmt.Errorf("serialize config file %q: %w", t.configPath, err)
+	if !exists {
+		return nil
 	}
 
-	data = append(data, '\n')
+	return collectClaudeMCPServerNamesFromScope(systemConfig, serviceNames, "managed mcpServers")
+}
 
-	if err := os.WriteFile(t.configPath, data, 0o600); err != nil {
-		return fmt.Errorf("write config file %q: %w", t.configPath, err)
+// scopeConfigFile returns the file that holds the given scope and the mode it
+// is written with. The system config must stay readable by every user.
+func (t *ClaudeCodeTarget) scopeConfigFile(scope ConfigScope) (string, os.FileMode) {
+	if scope == ConfigScopeSystem {
+		return t.systemConfigPath, 0o644
 	}
 
-	return nil
+	return t.configPath, 0o600
 }
 
-func defaultClaudeCodeConfigPath() string {
-	homeDir, err := os.UserHomeDir()
+// CheckConfig reads and parses the user and system config files without
+// modifying them.
+func (t *ClaudeCodeTarget) CheckConfig() error {
+	if _, _, err := t.readConfig(); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(t.systemConfigPath) == "" {
+		return nil
+	}
+
+	_, _, err := t.readConfigFile(t.systemConfigPath)
+	return err
+}
+
+func (t *ClaudeCodeTarget) readConfig() (map[string]any, bool, error) {
+	return t.readConfigFile(t.configPath)
+}
+
+// readConfigFile decodes configPath straight from the file. The entries
+// under "projects" stay raw JSON until a scope operation needs one: Claude
+// Code adds an entry for every directory it runs in, so on a long-lived
+// machine they can make up megabytes that mcp-wire never looks at, and an
+// entry that is not touched is written back as it was read.
+func (t *ClaudeCodeTarget) readConfigFile(configPath string) (map[string]any, bool, error) {
+	file, err := os.Open(configPath)
 	if err != nil {
-		return ".claude.json"
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]any{}, false, nil
+		}
+
+		return nil, false, fmt.Errorf("read config file %q: %w", configPath, err)
 	}
+	defer file.Close()
 
-	candidates := []string{
-		filepath.Join(homeDir, ".claude.json"),
-		filepath.Join(homeDir, ".claude", "settings.json"),
+	config, err := decodeClaudeConfig(file)
+	if err != nil {
+		return nil, true, fmt.Errorf("parse config file %q: %w", configPath, err)
 	}
 
-	for _, candidatePath := range candidates {
-		info, err := os.Stat(candidatePath)
-		if err != nil {
-			continue
+	return config, true, nil
+}
+
+// decodeClaudeConfig decodes one JSON object from r, keeping each project
+// entry as a json.RawMessage. An empty input is an empty config.
+func decodeClaudeConfig(r io.Reader) (map[string]any, error) {
+	decoder := json.NewDecoder(r)
+
+	var raw map[string]json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		if errors.Is(err, io.EOF) {
+			return map[string]any{}, nil
 		}
 
-		if info.IsDir() {
-			continue
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("invalid character after top-level value")
+	}
+
+	config := make(map[string]any, len(raw))
+	for key, value := range raw {
+		if key == "projects" {
+			var projects map[string]json.RawMessage
+			if err := json.Unmarshal(value, &projects); err == nil && projects != nil {
+				lazyProjects := make(map[string]any, len(projects))
+				for projectKey, projectConfig := range projects {
+					lazyProjects[projectKey] = projectConfig
+				}
+
+				config[key] = lazyProjects
+				continue
+			}
 		}
 
-		return candidatePath
+		var decoded any
+		if err := json.Unmarshal(value, &decoded); err != nil {
+			return nil, err
+		}
+
+		config[key] = decoded
 	}
 
-	return candidates[0]
+	return config, nil
+}
+
+// claudeProjectConfig returns the config of projects[projectKey], decoding
+// it in place when it is still raw JSON. It reports false for a missing or
+// null entry.
+func claudeProjectConfig(projects map[string]any, projectKey string) (map[string]any, bool, error) {
+	rawProjectConfig, exists := projects[projectKey]
+	if raw, isRaw := rawProjectConfig.(json.RawMessage); isRaw {
+		var decoded any
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return nil, false, fmt.Errorf("invalid config: projects[%q]: %w", projectKey, err)
+		}
+
+		projects[projectKey] = decoded
+		rawProjectConfig = decoded
+	}
+
+	if !exists || rawProjectConfig == nil {
+		return nil, false, nil
+	}
+
+	projectConfig, ok := rawProjectConfig.(map[string]any)
+	if !ok {
+		return nil, false, fmt.Errorf("invalid config: projects[%q] must be an object", projectKey)
+	}
+
+	return projectConfig, true, nil
+}
+
+func (t *ClaudeCodeTarget) writeConfigFile(configPath string, config map[string]any, perm os.FileMode) error {
+	return writeConfigDocument(configPath, jsonConfig, config, perm, t.preview, t.backup)
+}
+
+func defaultClaudeCodeConfigPath() string {
+	return chooseConfigCandidate(claudeCodeConfigCandidates())
+}
+
+// defaultClaudeCodeSystemConfigPath returns where Claude Code looks for
+// administrator-managed MCP servers on the current platform.
+func defaultClaudeCodeSystemConfigPath() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join("/Library", "Application Support", "ClaudeCode", "managed-mcp.json")
+	case "windows":
+		programFiles := os.Getenv("ProgramFiles")
+		if programFiles == "" {
+			programFiles = `C:\Program Files`
+		}
+
+		return filepath.Join(programFiles, "ClaudeCode", "managed-mcp.json")
+	default:
+		return filepath.Join("/etc", "claude-code", "managed-mcp.json")
+	}
 }
 
 func defaultClaudeCodeFallbackBinaryPaths() []string {
@@ -325,12 +517,13 @@ func isExecutableFilePath(path string, statPath func(name string) (os.FileInfo,
 	return info.Mode().Perm()&0o111 != 0
 }
 
-func getMCPServers(config map[string]any, scope ConfigScope, createIfMissing bool) (map[string]any, error) {
+func (t *ClaudeCodeTarget) getMCPServers(config map[string]any, scope ConfigScope, createIfMissing bool) (map[string]any, error) {
 	switch scope {
-	case ConfigScopeUser:
+	case ConfigScopeUser, ConfigScopeSystem:
+		// The managed system file uses the same top-level mcpServers layout.
 		return getClaudeUserMCPServers(config, createIfMissing)
 	case ConfigScopeProject:
-		return getClaudeProjectMCPServers(config, createIfMissing)
+		return getClaudeProjectMCPServers(config, t.projectDir, createIfMissing)
 	default:
 		return nil, fmt.Errorf("unsupported scope %q", scope)
 	}
@@ -397,10 +590,15 @@ func collectClaudeMCPServerNamesFromMCPServers(mcpServers map[string]any, servic
 	return nil
 }
 
-func getClaudeProjectMCPServers(config map[string]any, createIfMissing bool) (map[string]any, error) {
+func getClaudeProjectMCPServers(config map[string]any, projectDir string, createIfMissing bool) (map[string]any, error) {
 	rawProjects, hasProjects := config["projects"]
 	if !hasProjects || rawProjects == nil {
-		return nil, nil
+		if !createIfMissing {
+			return nil, nil
+		}
+
+		rawProjects = map[string]any{}
+		config["projects"] = rawProjects
 	}
 
 	projects, ok := rawProjects.(map[string]any)
@@ -408,7 +606,7 @@ func getClaudeProjectMCPServers(config map[string]any, createIfMissing bool) (ma
 		return nil, errors.New("invalid config: projects must be an object")
 	}
 
-	projectKey, err := resolveClaudeProjectKey(projects, createIfMissing)
+	projectKey, err := resolveClaudeProjectKey(projects, projectDir, createIfMissing)
 	if err != nil {
 		return nil, err
 	}
@@ -417,20 +615,18 @@ func getClaudeProjectMCPServers(config map[string]any, createIfMissing bool) (ma
 		return nil, nil
 	}
 
-	rawProjectConfig, exists := projects[projectKey]
-	if !exists || rawProjectConfig == nil {
+	projectConfig, exists, err := claudeProjectConfig(projects, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
 		if !createIfMissing {
 			return nil, nil
 		}
 
-		projectConfig := map[string]any{}
+		projectConfig = map[string]any{}
 		projects[projectKey] = projectConfig
-		rawProjectConfig = projectConfig
-	}
-
-	projectConfig, ok := rawProjectConfig.(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid config: projects[%q] must be an object", projectKey)
 	}
 
 	rawMCPServers, exists := projectConfig["mcpServers"]
@@ -452,7 +648,11 @@ func getClaudeProjectMCPServers(config map[string]any, createIfMissing bool) (ma
 	return mcpServers, nil
 }
 
-func resolveClaudeProjectKey(projects map[string]any, createIfMissing bool) (string, error) {
+func resolveClaudeProjectKey(projects map[string]any, projectDir string, createIfMissing bool) (string, error) {
+	if projectDir != "" {
+		return resolvePinnedClaudeProjectKey(projects, projectDir, createIfMissing), nil
+	}
+
 	cwd, err := os.Getwd()
 	if err != nil {
 		if createIfMissing {
@@ -515,6 +715,27 @@ func resolveClaudeProjectKey(projects map[string]any, createIfMissing bool) (str
 	return currentWorkingDirectory, nil
 }
 
+// resolvePinnedClaudeProjectKey returns the entry for exactly projectDir. A
+// pinned directory never falls back to a parent entry, so a package inside a
+// monorepo gets its own project config.
+func resolvePinnedClaudeProjectKey(projects map[string]any, projectDir string, createIfMissing bool) string {
+	pinnedPath := normalizePathForMatch(projectDir)
+	for key := range projects {
+		if normalizePathForMatch(strings.TrimSpace(key)) == pinnedPath {
+			return key
+		}
+	}
+
+	if !createIfMissing {
+		return ""
+	}
+
+	projectKey := filepath.Clean(projectDir)
+	projects[projectKey] = map[string]any{}
+
+	return projectKey
+}
+
 func normalizePathForMatch(path string) string {
 	cleanPath := filepath.Clean(path)
 	if cleanPath == "" {
@@ -562,6 +783,19 @@ func buildClaudeCodeServerConfig(svc service.Service, resolvedEnv map[string]str
 	}
 
 	switch transport {
+	case "websocket":
+		url := strings.TrimSpace(svc.URL)
+		if url == "" {
+			return nil, errors.New("websocket service requires url")
+		}
+
+		// Claude Code names the WebSocket transport "ws".
+		serverConfig["type"] = "ws"
+		serverConfig["url"] = url
+
+		if len(svc.Headers) > 0 {
+			serverConfig["headers"] = svc.Headers
+		}
 	case "http":
 		url := strings.TrimSpace(svc.URL)
 		if url == "" {
@@ -603,6 +837,8 @@ func buildClaudeCodeServerConfig(svc service.Service, resolvedEnv map[string]str
 		serverConfig["env"] = env
 	}
 
+	applyServiceExtra(serverConfig, svc)
+
 	return serverConfig, nil
 }
 
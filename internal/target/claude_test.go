@@ -300,6 +300,28 @@ func TestBuildClaudeCodeServerConfigSSEWithHeaders(t *testing.T) {
 	}
 }
 
+func TestBuildClaudeCodeServerConfigWebSocket(t *testing.T) {
+	svc := service.Service{
+		Name:      "ws-service",
+		Transport: "websocket",
+		URL:       "wss://example.com/mcp",
+		Headers:   map[string]string{"Authorization": "Bearer token"},
+	}
+
+	config, err := buildClaudeCodeServerConfig(svc, nil)
+	if err != nil {
+		t.Fatalf("expected build to succeed: %v", err)
+	}
+
+	if config["type"] != "ws" || config["url"] != "wss://example.com/mcp" {
+		t.Fatalf("expected a ws entry for wss://example.com/mcp, got %#v", config)
+	}
+
+	if headers, _ := config["headers"].(map[string]string); headers["Authorization"] != "Bearer token" {
+		t.Fatalf("expected headers to be written, got %#v", config["headers"])
+	}
+}
+
 func TestBuildClaudeCodeServerConfigHTTPWithoutHeaders(t *testing.T) {
 	svc := service.Service{
 		Name:      "no-header-service",
@@ -630,6 +652,69 @@ func TestClaudeCodeTargetInstallUsesUserScopeByDefault(t *testing.T) {
 	}
 }
 
+func TestClaudeCodeTargetSystemScopeUsesManagedConfigFile(t *testing.T) {
+	target := newTestClaudeCodeTarget(t)
+
+	svc := service.Service{Name: "service-a", Transport: "sse", URL: "https://a.example.com"}
+	if err := target.InstallWithScope(svc, nil, ConfigScopeSystem); err != nil {
+		t.Fatalf("expected system install to succeed: %v", err)
+	}
+
+	if _, err := os.Stat(target.configPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected user config to stay untouched, got %v", err)
+	}
+
+	systemConfig := readTargetConfigFile(t, target.systemConfigPath)
+	systemMCPServers := mustMapValue(t, systemConfig["mcpServers"], "mcpServers")
+	if _, ok := systemMCPServers["service-a"]; !ok {
+		t.Fatal("expected service-a to be written in the managed system config")
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(target.systemConfigPath)
+		if err != nil {
+			t.Fatalf("stat system config: %v", err)
+		}
+
+		if info.Mode().Perm() != 0o644 {
+			t.Fatalf("expected system config to be readable by every user, got %v", info.Mode().Perm())
+		}
+	}
+
+	for _, scope := range []ConfigScope{ConfigScopeSystem, ConfigScopeEffective} {
+		names, err := target.ListWithScope(scope)
+		if err != nil {
+			t.Fatalf("list %s scope: %v", scope, err)
+		}
+
+		if len(names) != 1 || names[0] != "service-a" {
+			t.Fatalf("expected service-a in %s scope, got %v", scope, names)
+		}
+	}
+
+	userNames, err := target.ListWithScope(ConfigScopeUser)
+	if err != nil {
+		t.Fatalf("list user scope: %v", err)
+	}
+
+	if len(userNames) != 0 {
+		t.Fatalf("expected system entries to stay out of user scope, got %v", userNames)
+	}
+
+	if err := target.UninstallWithScope("service-a", ConfigScopeSystem); err != nil {
+		t.Fatalf("expected system uninstall to succeed: %v", err)
+	}
+
+	names, err := target.ListWithScope(ConfigScopeSystem)
+	if err != nil {
+		t.Fatalf("list system scope: %v", err)
+	}
+
+	if len(names) != 0 {
+		t.Fatalf("expected no system services after uninstall, got %v", names)
+	}
+}
+
 func TestClaudeCodeTargetListWithScopeUserExcludesProjectEntries(t *testing.T) {
 	projectRoot := t.TempDir()
 	setWorkingDirectory(t, projectRoot)
@@ -724,12 +809,35 @@ func TestDefaultClaudeCodeConfigPathUsesSettingsWhenOnlySettingsExists(t *testin
 	}
 }
 
+func TestClaudeCodeTargetCheckConfigReportsParseErrors(t *testing.T) {
+	target := newTestClaudeCodeTarget(t)
+
+	if err := target.CheckConfig(); err != nil {
+		t.Fatalf("expected missing configs to pass the check: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target.systemConfigPath), 0o755); err != nil {
+		t.Fatalf("failed to create system config directory: %v", err)
+	}
+
+	if err := os.WriteFile(target.systemConfigPath, []byte("{not json"), 0o644); err != nil {
+		t.Fatalf("failed to write system config: %v", err)
+	}
+
+	err := target.CheckConfig()
+	if err == nil || !strings.Contains(err.Error(), "parse config file") {
+		t.Fatalf("expected parse error for broken system config, got %v", err)
+	}
+}
+
 func newTestClaudeCodeTarget(t *testing.T) *ClaudeCodeTarget {
 	t.Helper()
 
-	configPath := filepath.Join(t.TempDir(), ".claude", "settings.json")
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, ".claude", "settings.json")
 	target := NewClaudeCodeTarget()
 	target.configPath = configPath
+	target.systemConfigPath = filepath.Join(tempDir, "system", "managed-mcp.json")
 	target.binaryNames = []string{"claude"}
 	target.fallbackBinaryPaths = nil
 	target.statPath = os.Stat
@@ -805,3 +913,152 @@ func mustMapValue(t *testing.T, value any, path string) map[string]any {
 
 	return mapValue
 }
+
+func TestBuildClaudeCodeServerConfigAppliesExtra(t *testing.T) {
+	svc := service.Service{
+		Name:      "extra-service",
+		Transport: "sse",
+		URL:       "https://example.com/sse",
+		Extra: map[string]any{
+			"timeout":  30000,
+			"disabled": true,
+		},
+	}
+
+	config, err := buildClaudeCodeServerConfig(svc, nil)
+	if err != nil {
+		t.Fatalf("expected build to succeed: %v", err)
+	}
+
+	if config["timeout"] != 30000 || config["disabled"] != true {
+		t.Fatalf("expected extra keys in config, got %#v", config)
+	}
+
+	if config["url"] != "https://example.com/sse" {
+		t.Fatalf("expected generated keys to be kept, got %#v", config)
+	}
+}
+
+func TestClaudeCodeTargetHasProjectEntry(t *testing.T) {
+	projectRoot := t.TempDir()
+	setWorkingDirectory(t, projectRoot)
+
+	target := newTestClaudeCodeTarget(t)
+
+	found, err := target.HasProjectEntry()
+	if err != nil || found {
+		t.Fatalf("expected no project entry without config, got %v (err=%v)", found, err)
+	}
+
+	writeTargetConfigFile(t, target.configPath, map[string]any{
+		"projects": map[string]any{projectRoot: map[string]any{}},
+	})
+
+	found, err = target.HasProjectEntry()
+	if err != nil || !found {
+		t.Fatalf("expected project entry for current directory, got %v (err=%v)", found, err)
+	}
+}
+
+func TestClaudeCodeTargetPinnedProjectDirIgnoresParentEntries(t *testing.T) {
+	repoRoot := t.TempDir()
+	packageDir := filepath.Join(repoRoot, "packages", "api")
+	if err := os.MkdirAll(packageDir, 0o755); err != nil {
+		t.Fatalf("create package dir: %v", err)
+	}
+
+	setWorkingDirectory(t, packageDir)
+
+	target := newTestClaudeCodeTarget(t)
+	writeTargetConfigFile(t, target.configPath, map[string]any{
+		"projects": map[string]any{repoRoot: map[string]any{}},
+	})
+
+	target.SetProjectDir(packageDir)
+
+	svc := service.Service{Name: "service-a", Transport: "sse", URL: "https://a.example.com"}
+	if err := target.InstallWithScope(svc, nil, ConfigScopeProject); err != nil {
+		t.Fatalf("expected project install to succeed: %v", err)
+	}
+
+	projects := mustMapValue(t, readTargetConfigFile(t, target.configPath)["projects"], "projects")
+	packageConfig := mustMapValue(t, projects[packageDir], "projects.<package>")
+	packageMCPServers := mustMapValue(t, packageConfig["mcpServers"], "projects.<package>.mcpServers")
+	if _, ok := packageMCPServers["service-a"]; !ok {
+		t.Fatal("expected service-a under the pinned package directory")
+	}
+
+	rootConfig := mustMapValue(t, projects[repoRoot], "projects.<root>")
+	if _, ok := rootConfig["mcpServers"]; ok {
+		t.Fatal("did not expect the parent project entry to be written")
+	}
+}
+
+func TestClaudeCodeTargetLeavesUntouchedProjectEntriesAsWritten(t *testing.T) {
+	projectDir := t.TempDir()
+	target := newTestClaudeCodeTarget(t)
+	target.SetProjectDir(projectDir)
+
+	original := `{"projects": {"/work/other": {"zeta": 1, "alpha": {"nested": true}}}}`
+	if err := os.MkdirAll(filepath.Dir(target.configPath), 0o755); err != nil {
+		t.Fatalf("create config dir: %v", err)
+	}
+
+	if err := os.WriteFile(target.configPath, []byte(original), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	svc := service.Service{Name: "service-a", Transport: "sse", URL: "https://a.example.com"}
+	if err := target.InstallWithScope(svc, nil, ConfigScopeProject); err != nil {
+		t.Fatalf("expected project install to succeed: %v", err)
+	}
+
+	data, err := os.ReadFile(target.configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+
+	written := string(data)
+	zeta := strings.Index(written, `"zeta": 1`)
+	alpha := strings.Index(written, `"alpha": {`)
+	if zeta < 0 || alpha < 0 || zeta > alpha {
+		t.Fatalf("expected the untouched project entry to keep its key order, got %s", written)
+	}
+
+	if !strings.Contains(written, "https://a.example.com") {
+		t.Fatalf("expected the pinned project entry to be written, got %s", written)
+	}
+}
+
+func TestClaudeCodeTargetRejectsTrailingContent(t *testing.T) {
+	target := newTestClaudeCodeTarget(t)
+	if err := os.MkdirAll(filepath.Dir(target.configPath), 0o755); err != nil {
+		t.Fatalf("create config dir: %v", err)
+	}
+
+	if err := os.WriteFile(target.configPath, []byte(`{"mcpServers": {}} {}`), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	if _, err := target.List(); err == nil || !strings.Contains(err.Error(), "parse config file") {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+}
+
+func TestClaudeCodeTargetProjectEntries(t *testing.T) {
+	target := newTestClaudeCodeTarget(t)
+
+	entries, err := target.ProjectEntries()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("expected no entries without config, got %v (err=%v)", entries, err)
+	}
+
+	writeTargetConfigFile(t, target.configPath, map[string]any{
+		"projects": map[string]any{"/work/b": map[string]any{}, "/work/a": map[string]any{}},
+	})
+
+	entries, err = target.ProjectEntries()
+	if err != nil || strings.Join(entries, ",") != "/work/a,/work/b" {
+		t.Fatalf("expected sorted entries, got %v (err=%v)", entries, err)
+	}
+}
@@ -1,18 +1,14 @@
 package target
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"os/exec"
-	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/andreagrandi/mcp-wire/internal/service"
-	toml "github.com/pelletier/go-toml/v2"
 )
 
 const (
@@ -22,15 +18,23 @@ const (
 
 // CodexTarget manages MCP service configuration for Codex CLI.
 type CodexTarget struct {
-	configPath string
-	lookPath   func(file string) (string, error)
-	runCommand func(name string, args ...string) *exec.Cmd
+	configPath      string
+	profile         string
+	logDir          string
+	lookPath        func(file string) (string, error)
+	runCommand      func(name string, args ...string) *exec.Cmd
+	keepEmpty       bool
+	markedInstalled bool
+	backup          func(configPath string) error
+	preview         func(configPath string, data []byte)
+	merge           MergeStrategy
 }
 
 // NewCodexTarget returns a target instance for Codex CLI.
 func NewCodexTarget() *CodexTarget {
 	return &CodexTarget{
 		configPath: defaultCodexConfigPath(),
+		logDir:     defaultCodexLogDir(),
 		lookPath:   exec.LookPath,
 		runCommand: exec.Command,
 	}
@@ -53,6 +57,10 @@ func (t *CodexTarget) ConfigPath() string {
 
 // IsInstalled reports whether Codex CLI is available in PATH.
 func (t *CodexTarget) IsInstalled() bool {
+	if t.markedInstalled {
+		return true
+	}
+
 	_, err := t.lookPath(codexBinaryName)
 	return err == nil
 }
@@ -69,7 +77,7 @@ func (t *CodexTarget) Install(svc service.Service, resolvedEnv map[string]string
 		return err
 	}
 
-	mcpServers, err := getCodexMCPServers(config, true)
+	mcpServers, err := t.getMCPServers(config, true)
 	if err != nil {
 		return err
 	}
@@ -79,7 +87,12 @@ func (t *CodexTarget) Install(svc service.Service, resolvedEnv map[string]string
 		return err
 	}
 
-	mcpServers[serviceName] = serverConfig
+	merged, err := mergeServerEntry(t.merge, mcpServers[serviceName], serverConfig)
+	if err != nil {
+		return err
+	}
+
+	mcpServers[serviceName] = merged
 
 	return t.writeConfig(config)
 }
@@ -100,7 +113,12 @@ func (t *CodexTarget) Uninstall(serviceName string) error {
 		return nil
 	}
 
-	mcpServers, err := getCodexMCPServers(config, false)
+	parent, err := t.serversParent(config, false)
+	if err != nil {
+		return err
+	}
+
+	mcpServers, err := getCodexMCPServers(parent, false)
 	if err != nil {
 		return err
 	}
@@ -110,6 +128,9 @@ func (t *CodexTarget) Uninstall(serviceName string) error {
 	}
 
 	delete(mcpServers, trimmedServiceName)
+	if !t.keepEmpty {
+		pruneEmptyObject(parent, "mcp_servers")
+	}
 
 	return t.writeConfig(config)
 }
@@ -125,7 +146,7 @@ func (t *CodexTarget) List() ([]string, error) {
 		return []string{}, nil
 	}
 
-	mcpServers, err := getCodexMCPServers(config, false)
+	mcpServers, err := t.getMCPServers(config, false)
 	if err != nil {
 		return nil, err
 	}
@@ -179,55 +200,99 @@ func (t *CodexTarget) Authenticate(serviceName string, stdin io.Reader, stdout i
 	return nil
 }
 
+// CheckConfig reads and parses the config file without modifying it.
+func (t *CodexTarget) CheckConfig() error {
+	_, _, err := t.readConfig()
+	return err
+}
+
 func (t *CodexTarget) readConfig() (map[string]any, bool, error) {
-	data, err := os.ReadFile(t.configPath)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return map[string]any{}, false, nil
-		}
+	return readConfigDocument(t.configPath, tomlConfig)
+}
 
-		return nil, false, fmt.Errorf("read config file %q: %w", t.configPath, err)
-	}
+func (t *CodexTarget) writeConfig(config map[string]any) error {
+	return writeConfigDocument(t.configPath, tomlConfig, config, 0o600, t.preview, t.backup)
+}
 
-	config := map[string]any{}
-	if len(bytes.TrimSpace(data)) == 0 {
-		return config, true, nil
-	}
+// ProfileTarget is an optional interface for targets that can keep MCP
+// servers per named profile instead of in one global table. Codex CLI is the
+// only such target.
+type ProfileTarget interface {
+	SetProfile(name string)
+	Profile() string
+}
 
-	if err := toml.Unmarshal(data, &config); err != nil {
-		return nil, true, fmt.Errorf("parse config file %q: %w", t.configPath, err)
+// SetProfile makes the target manage the MCP servers of the Codex profile
+// name, in [profiles.<name>.mcp_servers], instead of the global
+// [mcp_servers] table. An empty name selects the global table again.
+func (t *CodexTarget) SetProfile(name string) {
+	t.profile = strings.TrimSpace(name)
+}
+
+// Profile returns the Codex profile the target manages, or "" for the
+// global table.
+func (t *CodexTarget) Profile() string {
+	return t.profile
+}
+
+// getMCPServers returns the servers table of the selected profile, or the
+// global one without a profile.
+func (t *CodexTarget) getMCPServers(config map[string]any, createIfMissing bool) (map[string]any, error) {
+	parent, err := t.serversParent(config, createIfMissing)
+	if err != nil {
+		return nil, err
 	}
 
-	return config, true, nil
+	return getCodexMCPServers(parent, createIfMissing)
 }
 
-func (t *CodexTarget) writeConfig(config map[string]any) error {
-	configDir := filepath.Dir(t.configPath)
-	if err := os.MkdirAll(configDir, 0o755); err != nil {
-		return fmt.Errorf("create config directory %q: %w", configDir, err)
+// serversParent returns the table holding mcp_servers: the config itself,
+// or the table of the selected profile. A profile that is not defined is
+// nil, and an error when the caller is about to write to it, so a typo in
+// the profile name does not create a new profile.
+func (t *CodexTarget) serversParent(config map[string]any, mustExist bool) (map[string]any, error) {
+	if t.profile == "" {
+		return config, nil
 	}
 
-	data, err := toml.Marshal(config)
+	profile, err := getCodexProfile(config, t.profile)
 	if err != nil {
-		return fmt.Errorf("serialize config file %q: %w", t.configPath, err)
+		return nil, err
 	}
 
-	data = append(data, '\n')
-
-	if err := os.WriteFile(t.configPath, data, 0o600); err != nil {
-		return fmt.Errorf("write config file %q: %w", t.configPath, err)
+	if profile == nil && mustExist {
+		return nil, fmt.Errorf("codex profile %q is not defined in %s; add a [profiles.%s] table first", t.profile, t.configPath, t.profile)
 	}
 
-	return nil
+	return profile, nil
 }
 
 func defaultCodexConfigPath() string {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return filepath.Join(".codex", "config.toml")
+	return chooseConfigCandidate(codexConfigCandidates())
+}
+
+func getCodexProfile(config map[string]any, name string) (map[string]any, error) {
+	rawProfiles, exists := config["profiles"]
+	if !exists || rawProfiles == nil {
+		return nil, nil
+	}
+
+	profiles, ok := rawProfiles.(map[string]any)
+	if !ok {
+		return nil, errors.New("invalid config: profiles must be a table")
+	}
+
+	rawProfile, exists := profiles[name]
+	if !exists || rawProfile == nil {
+		return nil, nil
 	}
 
-	return filepath.Join(homeDir, ".codex", "config.toml")
+	profile, ok := rawProfile.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid config: profiles.%s must be a table", name)
+	}
+
+	return profile, nil
 }
 
 func getCodexMCPServers(config map[string]any, createIfMissing bool) (map[string]any, error) {
@@ -303,10 +368,14 @@ func buildCodexServerConfig(svc service.Service, resolvedEnv map[string]string)
 		if len(env) > 0 {
 			serverConfig["env"] = env
 		}
+	case "websocket":
+		return nil, errors.New("websocket transport is not supported by Codex CLI")
 	default:
 		return nil, fmt.Errorf("unsupported transport %q", svc.Transport)
 	}
 
+	applyServiceExtra(serverConfig, svc)
+
 	return serverConfig, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/andreagrandi/mcp-wire/internal/service"
@@ -233,6 +234,15 @@ func TestCodexTargetInstallReturnsErrorForInvalidTransport(t *testing.T) {
 	}
 }
 
+func TestCodexTargetInstallRejectsWebSocketService(t *testing.T) {
+	target := newTestCodexTarget(t)
+
+	err := target.Install(service.Service{Name: "demo-service", Transport: "websocket", URL: "wss://example.com/mcp"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "websocket transport is not supported by Codex CLI") {
+		t.Fatalf("expected a websocket error, got %v", err)
+	}
+}
+
 func TestCodexTargetUninstallRemovesService(t *testing.T) {
 	target := newTestCodexTarget(t)
 
@@ -331,6 +341,86 @@ func TestCodexTargetMethodsErrorWhenMCPServersIsNotTable(t *testing.T) {
 	}
 }
 
+func TestCodexTargetProfileKeepsServicesInTheProfileTable(t *testing.T) {
+	target := newTestCodexTarget(t)
+	target.SetProfile("work")
+
+	writeCodexConfigFile(t, target.configPath, map[string]any{
+		"mcp_servers": map[string]any{
+			"global-service": map[string]any{"url": "https://global.example.com/mcp"},
+		},
+		"profiles": map[string]any{
+			"work": map[string]any{"model": "o3"},
+		},
+	})
+
+	if err := target.Install(service.Service{Name: "demo-service", Transport: "sse", URL: "https://example.com/mcp"}, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	config := readCodexConfigFile(t, target.configPath)
+	globalServers := mustMapValue(t, config["mcp_servers"], "mcp_servers")
+	if _, ok := globalServers["demo-service"]; ok {
+		t.Fatal("expected the global table to be left alone")
+	}
+
+	profile := mustMapValue(t, mustMapValue(t, config["profiles"], "profiles")["work"], "profiles.work")
+	if profile["model"] != "o3" {
+		t.Fatalf("expected other profile settings to be kept, got %#v", profile)
+	}
+
+	profileServers := mustMapValue(t, profile["mcp_servers"], "profiles.work.mcp_servers")
+	if _, ok := profileServers["demo-service"]; !ok {
+		t.Fatalf("expected demo-service in the profile, got %#v", profileServers)
+	}
+
+	services, err := target.List()
+	if err != nil {
+		t.Fatalf("expected list to succeed: %v", err)
+	}
+
+	if len(services) != 1 || services[0] != "demo-service" {
+		t.Fatalf("expected only the profile services, got %#v", services)
+	}
+
+	if err := target.Uninstall("demo-service"); err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	config = readCodexConfigFile(t, target.configPath)
+	profile = mustMapValue(t, mustMapValue(t, config["profiles"], "profiles")["work"], "profiles.work")
+	if _, ok := profile["mcp_servers"]; ok {
+		t.Fatalf("expected the emptied profile table to be pruned, got %#v", profile)
+	}
+
+	if _, ok := mustMapValue(t, config["mcp_servers"], "mcp_servers")["global-service"]; !ok {
+		t.Fatal("expected the global service to remain")
+	}
+}
+
+func TestCodexTargetProfileMustBeDefinedToInstall(t *testing.T) {
+	target := newTestCodexTarget(t)
+	target.SetProfile("wrok")
+
+	writeCodexConfigFile(t, target.configPath, map[string]any{
+		"profiles": map[string]any{"work": map[string]any{}},
+	})
+
+	err := target.Install(service.Service{Name: "demo-service", Transport: "sse", URL: "https://example.com/mcp"}, nil)
+	if err == nil || !strings.Contains(err.Error(), `codex profile "wrok" is not defined`) {
+		t.Fatalf("expected an undefined profile error, got %v", err)
+	}
+
+	services, err := target.List()
+	if err != nil || len(services) != 0 {
+		t.Fatalf("expected no services for an undefined profile, got %#v, %v", services, err)
+	}
+
+	if err := target.Uninstall("demo-service"); err != nil {
+		t.Fatalf("expected uninstall from an undefined profile to do nothing: %v", err)
+	}
+}
+
 func TestPickBearerEnvVarPrefersServiceDefinitionOrder(t *testing.T) {
 	svc := service.Service{
 		Env: []service.EnvVar{
@@ -399,3 +489,34 @@ func readCodexConfigFile(t *testing.T, configPath string) map[string]any {
 
 	return config
 }
+
+func TestCodexTargetInstallWritesExtraKeys(t *testing.T) {
+	target := newTestCodexTarget(t)
+
+	svc := service.Service{
+		Name:      "demo-service",
+		Transport: "stdio",
+		Command:   "npx",
+		Extra: map[string]any{
+			"startup_timeout_sec": int64(20),
+			"enabled_tools":       []any{"search"},
+		},
+	}
+
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	config := readCodexConfigFile(t, target.configPath)
+	mcpServers := mustMapValue(t, config["mcp_servers"], "mcp_servers")
+	serviceConfig := mustMapValue(t, mcpServers["demo-service"], "mcp_servers.demo-service")
+
+	if serviceConfig["startup_timeout_sec"] != int64(20) {
+		t.Fatalf("expected integer startup_timeout_sec, got %#v", serviceConfig["startup_timeout_sec"])
+	}
+
+	tools, ok := serviceConfig["enabled_tools"].([]any)
+	if !ok || len(tools) != 1 || tools[0] != "search" {
+		t.Fatalf("expected enabled_tools array, got %#v", serviceConfig["enabled_tools"])
+	}
+}
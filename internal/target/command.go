@@ -0,0 +1,191 @@
+package target
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandUpdater is an optional interface for targets that can rewrite the
+// command line of a configured stdio service in place. Every other field of
+// the entry, including env vars and headers, is kept as it is, so no
+// credentials are needed to change it.
+type CommandUpdater interface {
+	// UpdateServiceCommand passes the command line of the service entry in
+	// scope, the command followed by its args, to update and writes back the
+	// one it returns.
+	UpdateServiceCommand(serviceName string, scope ConfigScope, update func(command []string) []string) error
+}
+
+// EntryCommand returns the command line of a stdio service entry: the
+// command followed by its args. OpenCode keeps both in one "command" array;
+// the other targets use "command" and "args". It returns nil for entries
+// without a command, such as remote services.
+func EntryCommand(entry map[string]any) []string {
+	switch command := entry["command"].(type) {
+	case string:
+		if strings.TrimSpace(command) == "" {
+			return nil
+		}
+
+		args, _ := stringList(entry["args"])
+		return append([]string{command}, args...)
+	case nil:
+		return nil
+	default:
+		commandLine, _ := stringList(command)
+		return commandLine
+	}
+}
+
+// UpdateServiceCommand rewrites the command line of the service in scope.
+func (t *ClaudeCodeTarget) UpdateServiceCommand(serviceName string, scope ConfigScope, update func(command []string) []string) error {
+	configPath, perm := t.scopeConfigFile(scope)
+	config, _, err := t.readConfigFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	mcpServers, err := t.getMCPServers(config, scope, false)
+	if err != nil {
+		return err
+	}
+
+	if err := updateEntryCommand(mcpServers, serviceName, update); err != nil {
+		return err
+	}
+
+	return t.writeConfigFile(configPath, config, perm)
+}
+
+// UpdateServiceCommand rewrites the command line of the service.
+func (t *CodexTarget) UpdateServiceCommand(serviceName string, _ ConfigScope, update func(command []string) []string) error {
+	config, _, err := t.readConfig()
+	if err != nil {
+		return err
+	}
+
+	mcpServers, err := t.getMCPServers(config, false)
+	if err != nil {
+		return err
+	}
+
+	if err := updateEntryCommand(mcpServers, serviceName, update); err != nil {
+		return err
+	}
+
+	return t.writeConfig(config)
+}
+
+// UpdateServiceCommand rewrites the command line of the service in the
+// config file for scope.
+func (t *OpenCodeTarget) UpdateServiceCommand(serviceName string, scope ConfigScope, update func(command []string) []string) error {
+	if scope != ConfigScopeProject {
+		scope = ConfigScopeUser
+	}
+
+	configPath, err := t.scopeConfigFile(scope)
+	if err != nil {
+		return err
+	}
+
+	config, _, err := readConfigDocument(configPath, jsoncConfig)
+	if err != nil {
+		return err
+	}
+
+	mcpEntries, err := getOpenCodeMCPEntries(config, false)
+	if err != nil {
+		return err
+	}
+
+	if err := updateEntryCommand(mcpEntries, serviceName, update); err != nil {
+		return err
+	}
+
+	return t.writeConfigFile(configPath, config)
+}
+
+// UpdateServiceCommand rewrites the command line of the service in the
+// settings file for scope.
+func (t *VSCodeTarget) UpdateServiceCommand(serviceName string, scope ConfigScope, update func(command []string) []string) error {
+	if scope != ConfigScopeProject && scope != ConfigScopeRemote {
+		scope = ConfigScopeUser
+	}
+
+	configPath, err := t.scopeConfigFile(scope)
+	if err != nil {
+		return err
+	}
+
+	config, _, err := readVSCodeSettings(configPath)
+	if err != nil {
+		return err
+	}
+
+	servers, err := getVSCodeMCPServers(config, false)
+	if err != nil {
+		return err
+	}
+
+	if err := updateEntryCommand(servers, serviceName, update); err != nil {
+		return err
+	}
+
+	return t.writeSettings(configPath, config)
+}
+
+// updateEntryCommand replaces the command line of the entry for serviceName
+// with the one update returns, keeping the shape the entry already uses.
+func updateEntryCommand(entries map[string]any, serviceName string, update func(command []string) []string) error {
+	serviceName = strings.TrimSpace(serviceName)
+	entry := serviceEntry(entries, serviceName)
+	if entry == nil {
+		return fmt.Errorf("service %q is not configured", serviceName)
+	}
+
+	current := EntryCommand(entry)
+	if len(current) == 0 {
+		return fmt.Errorf("service %q has no command to update", serviceName)
+	}
+
+	updated := update(append([]string(nil), current...))
+	if len(updated) == 0 {
+		return fmt.Errorf("service %q would be left without a command", serviceName)
+	}
+
+	if _, ok := entry["command"].(string); !ok {
+		entry["command"] = updated
+		return nil
+	}
+
+	entry["command"] = updated[0]
+	if len(updated) > 1 {
+		entry["args"] = updated[1:]
+	} else {
+		delete(entry, "args")
+	}
+
+	return nil
+}
+
+// stringList converts a decoded JSON or TOML array of strings.
+func stringList(value any) ([]string, bool) {
+	switch list := value.(type) {
+	case []string:
+		return append([]string(nil), list...), true
+	case []any:
+		result := make([]string, 0, len(list))
+		for _, item := range list {
+			text, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+
+			result = append(result, text)
+		}
+
+		return result, true
+	default:
+		return nil, false
+	}
+}
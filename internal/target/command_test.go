@@ -0,0 +1,86 @@
+package target
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func TestUpdateServiceCommandKeepsRestOfEntry(t *testing.T) {
+	svc := service.Service{Name: "acme", Transport: "stdio", Command: "npx", Args: []string{"-y", "@acme/mcp@1.0.0"}}
+	env := map[string]string{"ACME_TOKEN": "secret"}
+
+	targets := map[string]interface {
+		Target
+		Explainer
+		CommandUpdater
+	}{
+		"claude":   newTestClaudeCodeTarget(t),
+		"codex":    newTestCodexTarget(t),
+		"opencode": newTestOpenCodeTarget(t),
+		"vscode":   newTestVSCodeTarget(t),
+	}
+
+	for name, target := range targets {
+		t.Run(name, func(t *testing.T) {
+			if err := target.Install(svc, env); err != nil {
+				t.Fatalf("install: %v", err)
+			}
+
+			before, err := target.ExplainService("acme")
+			if err != nil {
+				t.Fatalf("explain: %v", err)
+			}
+			installed, _ := EffectiveLayer(before)
+
+			err = target.UpdateServiceCommand("acme", ConfigScopeUser, func(command []string) []string {
+				command[len(command)-1] = "@acme/mcp@2.0.0"
+				return command
+			})
+			if err != nil {
+				t.Fatalf("update command: %v", err)
+			}
+
+			layers, err := target.ExplainService("acme")
+			if err != nil {
+				t.Fatalf("explain: %v", err)
+			}
+
+			effective, found := EffectiveLayer(layers)
+			if !found {
+				t.Fatal("expected the service to stay configured")
+			}
+
+			want := []string{"npx", "-y", "@acme/mcp@2.0.0"}
+			if got := EntryCommand(effective.Entry); !reflect.DeepEqual(got, want) {
+				t.Fatalf("expected command %v, got %v", want, got)
+			}
+
+			if !reflect.DeepEqual(entryKeys(effective.Entry), entryKeys(installed.Entry)) {
+				t.Fatalf("expected entry keys %v to be kept, got %v", entryKeys(installed.Entry), entryKeys(effective.Entry))
+			}
+
+			if err := target.UpdateServiceCommand("missing", ConfigScopeUser, func(command []string) []string { return command }); err == nil {
+				t.Fatal("expected an error for a service that is not configured")
+			}
+		})
+	}
+}
+
+func TestEntryCommandIgnoresRemoteEntries(t *testing.T) {
+	if got := EntryCommand(map[string]any{"type": "sse", "url": "https://example.com"}); got != nil {
+		t.Fatalf("expected no command for a remote entry, got %v", got)
+	}
+}
+
+func entryKeys(entry map[string]any) []string {
+	keys := make([]string, 0, len(entry))
+	for key := range entry {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,177 @@
+package target
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/tidwall/jsonc"
+	"gopkg.in/yaml.v3"
+)
+
+// configFormat is the syntax of a target config file. Every format decodes
+// the whole file into a generic map and encodes the whole map back, so keys
+// mcp-wire does not manage survive a write. The format belongs to a file, not
+// a target: a target may keep each scope in a file of its own syntax.
+type configFormat interface {
+	decode(data []byte) (map[string]any, error)
+	encode(configPath string, config map[string]any) ([]byte, error)
+}
+
+var (
+	// jsonConfig is strict JSON, written indented.
+	jsonConfig configFormat = jsonConfigFormat{}
+	// jsoncConfig is JSON with comments and trailing commas. Comments around
+	// kept entries survive a write.
+	jsoncConfig configFormat = jsoncConfigFormat{}
+	// tomlConfig is TOML.
+	tomlConfig configFormat = tomlConfigFormat{}
+	// yamlConfig is YAML, written with two-space indentation.
+	yamlConfig configFormat = yamlConfigFormat{}
+)
+
+type jsonConfigFormat struct{}
+
+func (jsonConfigFormat) decode(data []byte) (map[string]any, error) {
+	config := map[string]any{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (jsonConfigFormat) encode(_ string, config map[string]any) ([]byte, error) {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+type jsoncConfigFormat struct{}
+
+func (jsoncConfigFormat) decode(data []byte) (map[string]any, error) {
+	return jsonConfig.decode(jsonc.ToJSON(data))
+}
+
+func (jsoncConfigFormat) encode(configPath string, config map[string]any) ([]byte, error) {
+	return marshalJSONCConfig(configPath, config)
+}
+
+type tomlConfigFormat struct{}
+
+func (tomlConfigFormat) decode(data []byte) (map[string]any, error) {
+	config := map[string]any{}
+	if err := toml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (tomlConfigFormat) encode(_ string, config map[string]any) ([]byte, error) {
+	data, err := toml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+type yamlConfigFormat struct{}
+
+func (yamlConfigFormat) decode(data []byte) (map[string]any, error) {
+	config := map[string]any{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		return nil, errors.New("top level must be a mapping")
+	}
+
+	return config, nil
+}
+
+func (yamlConfigFormat) encode(_ string, config map[string]any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(config); err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// readConfigDocument reads configPath in format. A missing file reads as an
+// empty config and reports false; an empty file reads as an empty config.
+func readConfigDocument(configPath string, format configFormat) (map[string]any, bool, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]any{}, false, nil
+		}
+
+		return nil, false, fmt.Errorf("read config file %q: %w", configPath, err)
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return map[string]any{}, true, nil
+	}
+
+	config, err := format.decode(data)
+	if err != nil {
+		return nil, true, fmt.Errorf("parse config file %q: %w", configPath, err)
+	}
+
+	return config, true, nil
+}
+
+// writeConfigDocument writes config to configPath in format with perm. It
+// applies the hooks every target shares: the content goes to preview instead
+// of the disk when one is set, and otherwise the file is saved with backup
+// before it is replaced.
+func writeConfigDocument(
+	configPath string,
+	format configFormat,
+	config map[string]any,
+	perm os.FileMode,
+	preview func(configPath string, data []byte),
+	backup func(configPath string) error,
+) error {
+	data, err := format.encode(configPath, config)
+	if err != nil {
+		return fmt.Errorf("serialize config file %q: %w", configPath, err)
+	}
+
+	if previewConfigFile(preview, configPath, data) {
+		return nil
+	}
+
+	if err := backupConfigFile(backup, configPath); err != nil {
+		return err
+	}
+
+	configDir := filepath.Dir(configPath)
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		return fmt.Errorf("create config directory %q: %w", configDir, err)
+	}
+
+	if err := os.WriteFile(configPath, data, perm); err != nil {
+		return fmt.Errorf("write config file %q: %w", configPath, err)
+	}
+
+	return nil
+}
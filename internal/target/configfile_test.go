@@ -0,0 +1,119 @@
+package target
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigFormatsKeepUnknownKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  configFormat
+		content string
+	}{
+		{name: "json", format: jsonConfig, content: `{"theme": "dark", "servers": {"a": {"url": "https://a.example.com"}}}`},
+		{name: "jsonc", format: jsoncConfig, content: "{\n  // shared\n  \"theme\": \"dark\",\n  \"servers\": {\"a\": {\"url\": \"https://a.example.com\"}},\n}\n"},
+		{name: "toml", format: tomlConfig, content: "theme = \"dark\"\n\n[servers.a]\nurl = \"https://a.example.com\"\n"},
+		{name: "yaml", format: yamlConfig, content: "theme: dark\nservers:\n  a:\n    url: https://a.example.com\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			config, exists, err := readConfigDocument(configPath, tt.format)
+			if err != nil || !exists {
+				t.Fatalf("expected config to read, got exists=%v err=%v", exists, err)
+			}
+
+			servers, ok := config["servers"].(map[string]any)
+			if !ok {
+				t.Fatalf("expected servers object, got %#v", config["servers"])
+			}
+			servers["b"] = map[string]any{"url": "https://b.example.com"}
+
+			if err := writeConfigDocument(configPath, tt.format, config, 0o600, nil, nil); err != nil {
+				t.Fatalf("expected write to succeed: %v", err)
+			}
+
+			reread, _, err := readConfigDocument(configPath, tt.format)
+			if err != nil {
+				t.Fatalf("expected written config to read: %v", err)
+			}
+
+			if reread["theme"] != "dark" {
+				t.Fatalf("expected unknown key to be kept, got %#v", reread)
+			}
+
+			rereadServers, _ := reread["servers"].(map[string]any)
+			if len(rereadServers) != 2 {
+				t.Fatalf("expected both servers, got %#v", reread["servers"])
+			}
+		})
+	}
+}
+
+func TestReadConfigDocumentMissingAndEmptyFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	config, exists, err := readConfigDocument(filepath.Join(dir, "missing.yaml"), yamlConfig)
+	if err != nil || exists || len(config) != 0 {
+		t.Fatalf("expected missing file to read as empty, got %#v exists=%v err=%v", config, exists, err)
+	}
+
+	emptyPath := filepath.Join(dir, "empty.yaml")
+	if err := os.WriteFile(emptyPath, []byte("\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	config, exists, err = readConfigDocument(emptyPath, yamlConfig)
+	if err != nil || !exists || len(config) != 0 {
+		t.Fatalf("expected empty file to read as empty, got %#v exists=%v err=%v", config, exists, err)
+	}
+}
+
+func TestReadConfigDocumentRejectsNonMappingYAML(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("- a\n- b\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	_, _, err := readConfigDocument(configPath, yamlConfig)
+	if err == nil || !strings.Contains(err.Error(), "parse config file") {
+		t.Fatalf("expected parse error, got %v", err)
+	}
+}
+
+func TestWriteConfigDocumentAppliesHooks(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "nested", "config.yaml")
+
+	var previewed string
+	preview := func(_ string, data []byte) { previewed = string(data) }
+	if err := writeConfigDocument(configPath, yamlConfig, map[string]any{"a": map[string]any{"b": 1}}, 0o600, preview, nil); err != nil {
+		t.Fatalf("expected preview to succeed: %v", err)
+	}
+
+	if previewed != "a:\n  b: 1\n" {
+		t.Fatalf("unexpected previewed content %q", previewed)
+	}
+
+	if _, err := os.Stat(configPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected preview to leave the disk alone, got %v", err)
+	}
+
+	backup := func(string) error { return errors.New("disk full") }
+	err := writeConfigDocument(configPath, yamlConfig, map[string]any{"a": 1}, 0o600, nil, backup)
+	if err == nil || !strings.Contains(err.Error(), "back up config file") {
+		t.Fatalf("expected failed backup to stop the write, got %v", err)
+	}
+
+	if _, err := os.Stat(configPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no write after a failed backup, got %v", err)
+	}
+}
@@ -0,0 +1,317 @@
+package target
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+// Sandboxer is an optional interface for targets that can be redirected to a
+// throwaway directory. The conformance suite only runs against sandboxed
+// copies, so it never touches the real tool configuration.
+type Sandboxer interface {
+	// Sandbox returns a copy of the target that keeps its config and logs
+	// under dir, and takes no config backups or previews.
+	Sandbox(dir string) Target
+}
+
+// Sandbox returns a Claude Code target that writes to dir.
+func (t *ClaudeCodeTarget) Sandbox(dir string) Target {
+	sandboxed := *t
+	sandboxed.configPath = filepath.Join(dir, ".claude.json")
+	sandboxed.systemConfigPath = filepath.Join(dir, "managed-mcp.json")
+	sandboxed.logDir = filepath.Join(dir, "logs")
+	sandboxed.backup = nil
+	sandboxed.preview = nil
+	return &sandboxed
+}
+
+// Sandbox returns a Codex CLI target that writes to dir.
+func (t *CodexTarget) Sandbox(dir string) Target {
+	sandboxed := *t
+	sandboxed.configPath = filepath.Join(dir, "config.toml")
+	sandboxed.logDir = filepath.Join(dir, "log")
+	sandboxed.backup = nil
+	sandboxed.preview = nil
+	return &sandboxed
+}
+
+// Sandbox returns an OpenCode target that writes to dir, with its project
+// directory inside dir so project scope never lands in the working directory.
+func (t *OpenCodeTarget) Sandbox(dir string) Target {
+	sandboxed := *t
+	sandboxed.configPath = filepath.Join(dir, "opencode.json")
+	sandboxed.projectDir = filepath.Join(dir, "project")
+	sandboxed.logDir = filepath.Join(dir, "log")
+	sandboxed.backup = nil
+	sandboxed.preview = nil
+	return &sandboxed
+}
+
+// Sandbox returns a VS Code target that writes to dir, with its workspace
+// folder inside dir so project scope never lands in the working directory.
+func (t *VSCodeTarget) Sandbox(dir string) Target {
+	sandboxed := *t
+	sandboxed.configPath = filepath.Join(dir, "settings.json")
+	sandboxed.projectDir = filepath.Join(dir, "workspace")
+	sandboxed.remoteServerDir = filepath.Join(dir, "vscode-server")
+	sandboxed.backup = nil
+	sandboxed.preview = nil
+	return &sandboxed
+}
+
+// CheckStatus is the outcome of a conformance check.
+type CheckStatus string
+
+const (
+	CheckPass        CheckStatus = "pass"
+	CheckFail        CheckStatus = "fail"
+	CheckUnsupported CheckStatus = "n/a"
+)
+
+// CheckResult reports how a target fared in one conformance check.
+type CheckResult struct {
+	Check  string
+	Status CheckStatus
+	Err    error
+}
+
+// errUnsupported marks a check that does not apply to the target.
+var errUnsupported = errors.New("not supported by target")
+
+type conformanceCheck struct {
+	name string
+	run  func(t Target) error
+}
+
+var conformanceChecks = []conformanceCheck{
+	{name: "install sse", run: checkInstallSSE},
+	{name: "install stdio", run: checkInstallStdio},
+	{name: "env handling", run: checkEnvHandling},
+	{name: "scope behavior", run: checkScopeBehavior},
+	{name: "uninstall idempotency", run: checkUninstallIdempotency},
+	{name: "list correctness", run: checkListCorrectness},
+}
+
+// ConformanceChecks returns the names of the conformance checks, in the order
+// RunConformance reports them.
+func ConformanceChecks() []string {
+	names := make([]string, 0, len(conformanceChecks))
+	for _, check := range conformanceChecks {
+		names = append(names, check.name)
+	}
+
+	return names
+}
+
+// RunConformance runs the conformance suite against sandboxed copies of t,
+// one fresh directory under dir per check. Targets that cannot be sandboxed
+// fail every check, so new targets cannot skip the suite by accident.
+func RunConformance(t Target, dir string) []CheckResult {
+	results := make([]CheckResult, 0, len(conformanceChecks))
+
+	sandboxer, ok := t.(Sandboxer)
+	for i, check := range conformanceChecks {
+		result := CheckResult{Check: check.name, Status: CheckPass}
+
+		if !ok {
+			result.Status = CheckFail
+			result.Err = fmt.Errorf("target %q does not implement Sandboxer", t.Slug())
+			results = append(results, result)
+			continue
+		}
+
+		checkDir := filepath.Join(dir, t.Slug(), fmt.Sprintf("%02d", i))
+		err := os.MkdirAll(checkDir, 0o700)
+		if err == nil {
+			err = check.run(sandboxer.Sandbox(checkDir))
+		}
+
+		switch {
+		case errors.Is(err, errUnsupported):
+			result.Status = CheckUnsupported
+		case err != nil:
+			result.Status = CheckFail
+			result.Err = err
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func conformanceSSEService(name string) service.Service {
+	return service.Service{Name: name, Transport: "sse", URL: "https://example.com/" + name + "/sse"}
+}
+
+func conformanceStdioService(name string) service.Service {
+	return service.Service{Name: name, Transport: "stdio", Command: "npx", Args: []string{"-y", name}}
+}
+
+func checkInstallSSE(t Target) error {
+	if err := t.Install(conformanceSSEService("conformance-sse"), nil); err != nil {
+		return fmt.Errorf("install: %w", err)
+	}
+
+	return expectListed(t, "conformance-sse")
+}
+
+func checkInstallStdio(t Target) error {
+	if err := t.Install(conformanceStdioService("conformance-stdio"), nil); err != nil {
+		return fmt.Errorf("install: %w", err)
+	}
+
+	return expectListed(t, "conformance-stdio")
+}
+
+func checkEnvHandling(t Target) error {
+	provider, ok := t.(ConfigPathProvider)
+	if !ok {
+		return errUnsupported
+	}
+
+	svc := conformanceStdioService("conformance-env")
+	svc.Env = []service.EnvVar{{Name: "CONFORMANCE_TOKEN", Required: true}}
+
+	const value = "conformance-secret-value"
+	if err := t.Install(svc, map[string]string{"CONFORMANCE_TOKEN": value}); err != nil {
+		return fmt.Errorf("install: %w", err)
+	}
+
+	data, err := os.ReadFile(provider.ConfigPath())
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	if !strings.Contains(string(data), "CONFORMANCE_TOKEN") || !strings.Contains(string(data), value) {
+		return errors.New("resolved environment variable was not written to the config")
+	}
+
+	return nil
+}
+
+func checkScopeBehavior(t Target) error {
+	scopedTarget, ok := t.(ScopedTarget)
+	if !ok || !slices.Contains(scopedTarget.SupportedScopes(), ConfigScopeProject) {
+		return errUnsupported
+	}
+
+	if err := scopedTarget.InstallWithScope(conformanceSSEService("conformance-project"), nil, ConfigScopeProject); err != nil {
+		return fmt.Errorf("install in project scope: %w", err)
+	}
+
+	projectNames, err := scopedTarget.ListWithScope(ConfigScopeProject)
+	if err != nil {
+		return fmt.Errorf("list project scope: %w", err)
+	}
+
+	if !slices.Contains(projectNames, "conformance-project") {
+		return errors.New("service installed in project scope is missing from the project list")
+	}
+
+	userNames, err := scopedTarget.ListWithScope(ConfigScopeUser)
+	if err != nil {
+		return fmt.Errorf("list user scope: %w", err)
+	}
+
+	if slices.Contains(userNames, "conformance-project") {
+		return errors.New("service installed in project scope leaked into user scope")
+	}
+
+	if err := scopedTarget.UninstallWithScope("conformance-project", ConfigScopeProject); err != nil {
+		return fmt.Errorf("uninstall from project scope: %w", err)
+	}
+
+	projectNames, err = scopedTarget.ListWithScope(ConfigScopeProject)
+	if err != nil {
+		return fmt.Errorf("list project scope: %w", err)
+	}
+
+	if slices.Contains(projectNames, "conformance-project") {
+		return errors.New("service still listed in project scope after uninstall")
+	}
+
+	return nil
+}
+
+func checkUninstallIdempotency(t Target) error {
+	if err := t.Uninstall("conformance-missing"); err != nil {
+		return fmt.Errorf("uninstall before any config exists: %w", err)
+	}
+
+	if err := t.Install(conformanceSSEService("conformance-uninstall"), nil); err != nil {
+		return fmt.Errorf("install: %w", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := t.Uninstall("conformance-uninstall"); err != nil {
+			return fmt.Errorf("uninstall attempt %d: %w", i+1, err)
+		}
+	}
+
+	names, err := t.List()
+	if err != nil {
+		return fmt.Errorf("list: %w", err)
+	}
+
+	if slices.Contains(names, "conformance-uninstall") {
+		return errors.New("service still listed after uninstall")
+	}
+
+	return nil
+}
+
+func checkListCorrectness(t Target) error {
+	names, err := t.List()
+	if err != nil {
+		return fmt.Errorf("list without config: %w", err)
+	}
+
+	if len(names) != 0 {
+		return fmt.Errorf("expected no services without config, got %v", names)
+	}
+
+	if err := t.Install(conformanceSSEService("conformance-b"), nil); err != nil {
+		return fmt.Errorf("install: %w", err)
+	}
+
+	if err := t.Install(conformanceStdioService("conformance-a"), nil); err != nil {
+		return fmt.Errorf("install: %w", err)
+	}
+
+	// Reinstalling must update in place rather than add a duplicate.
+	if err := t.Install(conformanceStdioService("conformance-a"), nil); err != nil {
+		return fmt.Errorf("reinstall: %w", err)
+	}
+
+	names, err = t.List()
+	if err != nil {
+		return fmt.Errorf("list: %w", err)
+	}
+
+	want := []string{"conformance-a", "conformance-b"}
+	if !slices.Equal(names, want) {
+		return fmt.Errorf("expected sorted list %v, got %v", want, names)
+	}
+
+	return nil
+}
+
+func expectListed(t Target, name string) error {
+	names, err := t.List()
+	if err != nil {
+		return fmt.Errorf("list: %w", err)
+	}
+
+	if !slices.Contains(names, name) {
+		return fmt.Errorf("installed service %q is missing from list %v", name, names)
+	}
+
+	return nil
+}
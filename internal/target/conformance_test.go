@@ -0,0 +1,54 @@
+package target
+
+import (
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func TestRegisteredTargetsPassConformance(t *testing.T) {
+	for _, registered := range AllTargets() {
+		t.Run(registered.Slug(), func(t *testing.T) {
+			for _, result := range RunConformance(registered, t.TempDir()) {
+				if result.Status == CheckFail {
+					t.Errorf("%s: %v", result.Check, result.Err)
+				}
+			}
+		})
+	}
+}
+
+func TestRunConformanceFailsTargetsWithoutSandbox(t *testing.T) {
+	results := RunConformance(unsandboxedTarget{}, t.TempDir())
+
+	if len(results) != len(ConformanceChecks()) {
+		t.Fatalf("expected %d results, got %d", len(ConformanceChecks()), len(results))
+	}
+
+	for _, result := range results {
+		if result.Status != CheckFail {
+			t.Fatalf("expected %s to fail, got %s", result.Check, result.Status)
+		}
+	}
+}
+
+func TestRunConformanceReportsUnsupportedScope(t *testing.T) {
+	results := RunConformance(NewCodexTarget(), t.TempDir())
+
+	for _, result := range results {
+		if result.Check == "scope behavior" && result.Status != CheckUnsupported {
+			t.Fatalf("expected scope behavior to be unsupported for codex, got %s", result.Status)
+		}
+	}
+}
+
+type unsandboxedTarget struct{}
+
+func (unsandboxedTarget) Name() string      { return "Unsandboxed" }
+func (unsandboxedTarget) Slug() string      { return "unsandboxed" }
+func (unsandboxedTarget) IsInstalled() bool { return true }
+func (unsandboxedTarget) Install(service.Service, map[string]string) error {
+	return nil
+}
+func (unsandboxedTarget) Uninstall(string) error  { return nil }
+func (unsandboxedTarget) List() ([]string, error) { return nil, nil }
@@ -0,0 +1,161 @@
+package target
+
+import (
+	"errors"
+	"strings"
+)
+
+// ScopeLayer is the entry a target holds for a service in one config scope.
+type ScopeLayer struct {
+	Scope ConfigScope
+	Path  string         // config file the layer is read from
+	Entry map[string]any // nil when the scope does not define the service
+}
+
+// Explainer is an optional interface for targets that can show every scope
+// layer for a service, ordered from highest to lowest precedence, mirroring
+// how the target itself resolves which entry to use.
+type Explainer interface {
+	ExplainService(serviceName string) ([]ScopeLayer, error)
+}
+
+// EffectiveLayer returns the layer the target uses for the service: the
+// highest-precedence one that defines it.
+func EffectiveLayer(layers []ScopeLayer) (ScopeLayer, bool) {
+	for _, layer := range layers {
+		if layer.Entry != nil {
+			return layer, true
+		}
+	}
+
+	return ScopeLayer{}, false
+}
+
+// ExplainService returns the Claude Code layers for a service. A managed
+// system config takes exclusive control over MCP servers, and a
+// project-scoped entry overrides the user-wide one.
+func (t *ClaudeCodeTarget) ExplainService(serviceName string) ([]ScopeLayer, error) {
+	serviceName = strings.TrimSpace(serviceName)
+	if serviceName == "" {
+		return nil, errors.New("service name is required")
+	}
+
+	systemConfig, _, err := t.readConfigFile(t.systemConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	systemMCPServers, err := t.getMCPServers(systemConfig, ConfigScopeSystem, false)
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := t.readConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	projectMCPServers, err := t.getMCPServers(config, ConfigScopeProject, false)
+	if err != nil {
+		return nil, err
+	}
+
+	userMCPServers, err := t.getMCPServers(config, ConfigScopeUser, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return []ScopeLayer{
+		{Scope: ConfigScopeSystem, Path: t.systemConfigPath, Entry: serviceEntry(systemMCPServers, serviceName)},
+		{Scope: ConfigScopeProject, Path: t.configPath, Entry: serviceEntry(projectMCPServers, serviceName)},
+		{Scope: ConfigScopeUser, Path: t.configPath, Entry: serviceEntry(userMCPServers, serviceName)},
+	}, nil
+}
+
+// ExplainService returns the single user layer Codex reads.
+func (t *CodexTarget) ExplainService(serviceName string) ([]ScopeLayer, error) {
+	config, _, err := t.readConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	mcpServers, err := t.getMCPServers(config, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return []ScopeLayer{
+		{Scope: ConfigScopeUser, Path: t.configPath, Entry: serviceEntry(mcpServers, strings.TrimSpace(serviceName))},
+	}, nil
+}
+
+// ExplainService returns the OpenCode layers for a service. The project
+// config is merged over the user config, so its entry wins.
+func (t *OpenCodeTarget) ExplainService(serviceName string) ([]ScopeLayer, error) {
+	serviceName = strings.TrimSpace(serviceName)
+	if serviceName == "" {
+		return nil, errors.New("service name is required")
+	}
+
+	layers := make([]ScopeLayer, 0, 2)
+	for _, scope := range t.configScopes() {
+		configPath, err := t.scopeConfigFile(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		config, _, err := readConfigDocument(configPath, jsoncConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		mcpEntries, err := getOpenCodeMCPEntries(config, false)
+		if err != nil {
+			return nil, err
+		}
+
+		layers = append(layers, ScopeLayer{Scope: scope, Path: configPath, Entry: serviceEntry(mcpEntries, serviceName)})
+	}
+
+	return layers, nil
+}
+
+// ExplainService returns the VS Code layers for a service. Workspace
+// settings override user settings, as for any other VS Code setting.
+func (t *VSCodeTarget) ExplainService(serviceName string) ([]ScopeLayer, error) {
+	serviceName = strings.TrimSpace(serviceName)
+	if serviceName == "" {
+		return nil, errors.New("service name is required")
+	}
+
+	layers := make([]ScopeLayer, 0, 2)
+	for _, scope := range t.settingsScopes() {
+		configPath, err := t.scopeConfigFile(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		config, _, err := readVSCodeSettings(configPath)
+		if err != nil {
+			return nil, err
+		}
+
+		servers, err := getVSCodeMCPServers(config, false)
+		if err != nil {
+			return nil, err
+		}
+
+		layers = append(layers, ScopeLayer{Scope: scope, Path: configPath, Entry: serviceEntry(servers, serviceName)})
+	}
+
+	return layers, nil
+}
+
+func serviceEntry(entries map[string]any, serviceName string) map[string]any {
+	entry, ok := entries[serviceName].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	return entry
+}
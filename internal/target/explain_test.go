@@ -0,0 +1,104 @@
+package target
+
+import (
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func TestClaudeCodeTargetExplainServiceOrdersLayersByPrecedence(t *testing.T) {
+	projectRoot := t.TempDir()
+	setWorkingDirectory(t, projectRoot)
+
+	target := newTestClaudeCodeTarget(t)
+
+	userService := service.Service{Name: "jira", Transport: "sse", URL: "https://user.example.com"}
+	projectService := service.Service{Name: "jira", Transport: "sse", URL: "https://project.example.com"}
+
+	if err := target.InstallWithScope(userService, nil, ConfigScopeUser); err != nil {
+		t.Fatalf("install user scope: %v", err)
+	}
+
+	if err := target.InstallWithScope(projectService, nil, ConfigScopeProject); err != nil {
+		t.Fatalf("install project scope: %v", err)
+	}
+
+	layers, err := target.ExplainService("jira")
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+
+	if len(layers) != 3 {
+		t.Fatalf("expected three layers, got %d", len(layers))
+	}
+
+	wantScopes := []ConfigScope{ConfigScopeSystem, ConfigScopeProject, ConfigScopeUser}
+	for i, layer := range layers {
+		if layer.Scope != wantScopes[i] {
+			t.Fatalf("expected layer %d to be %q, got %q", i, wantScopes[i], layer.Scope)
+		}
+	}
+
+	if layers[0].Entry != nil {
+		t.Fatalf("expected no system entry, got %#v", layers[0].Entry)
+	}
+
+	effective, found := EffectiveLayer(layers)
+	if !found || effective.Scope != ConfigScopeProject {
+		t.Fatalf("expected project layer to be effective, got %q (found=%v)", effective.Scope, found)
+	}
+
+	if effective.Entry["url"] != "https://project.example.com" {
+		t.Fatalf("expected project URL, got %#v", effective.Entry["url"])
+	}
+
+	if layers[2].Entry["url"] != "https://user.example.com" {
+		t.Fatalf("expected user URL to be reported, got %#v", layers[2].Entry["url"])
+	}
+
+	systemService := service.Service{Name: "jira", Transport: "sse", URL: "https://managed.example.com"}
+	if err := target.InstallWithScope(systemService, nil, ConfigScopeSystem); err != nil {
+		t.Fatalf("install system scope: %v", err)
+	}
+
+	layers, err = target.ExplainService("jira")
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+
+	effective, found = EffectiveLayer(layers)
+	if !found || effective.Scope != ConfigScopeSystem {
+		t.Fatalf("expected managed system layer to win, got %q (found=%v)", effective.Scope, found)
+	}
+}
+
+func TestCodexTargetExplainServiceReturnsUserLayer(t *testing.T) {
+	target := newTestCodexTarget(t)
+
+	layers, err := target.ExplainService("jira")
+	if err != nil {
+		t.Fatalf("explain without config: %v", err)
+	}
+
+	if _, found := EffectiveLayer(layers); found {
+		t.Fatal("expected no effective layer without config")
+	}
+
+	svc := service.Service{Name: "jira", Transport: "stdio", Command: "npx"}
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+
+	layers, err = target.ExplainService("jira")
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+
+	if len(layers) != 1 || layers[0].Scope != ConfigScopeUser || layers[0].Path != target.configPath {
+		t.Fatalf("expected a single user layer for %q, got %#v", target.configPath, layers)
+	}
+
+	if layers[0].Entry["command"] != "npx" {
+		t.Fatalf("expected command to be reported, got %#v", layers[0].Entry)
+	}
+}
@@ -0,0 +1,147 @@
+package target
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CanonicalEntry converts a service entry as one of the targets stores it
+// into the canonical mcp.json server shape: "command", "args" and "env" for
+// local servers, or "type", "url" and "headers" for remote ones. Keys only
+// one tool understands are left out. It returns nil for an entry with
+// neither a command nor a URL.
+func CanonicalEntry(entry map[string]any) map[string]any {
+	canonical := map[string]any{}
+
+	if command := EntryCommand(entry); len(command) > 0 {
+		canonical["command"] = command[0]
+		if len(command) > 1 {
+			canonical["args"] = command[1:]
+		}
+
+		// OpenCode calls the env block "environment".
+		env := stringMap(entry["env"])
+		if env == nil {
+			env = stringMap(entry["environment"])
+		}
+		if len(env) > 0 {
+			canonical["env"] = env
+		}
+
+		return canonical
+	}
+
+	url, _ := entry["url"].(string)
+	if strings.TrimSpace(url) == "" {
+		return nil
+	}
+
+	canonical["url"] = url
+	canonical["type"] = "http"
+	switch entryType, _ := entry["type"].(string); strings.ToLower(entryType) {
+	case "sse":
+		canonical["type"] = "sse"
+	case "ws", "websocket":
+		canonical["type"] = "websocket"
+	}
+
+	// Codex names its header tables http_headers and can take the bearer
+	// token from an env var instead.
+	headers := stringMap(entry["headers"])
+	if headers == nil {
+		headers = stringMap(entry["http_headers"])
+	}
+	if bearer, _ := entry["bearer_token_env_var"].(string); strings.TrimSpace(bearer) != "" {
+		if headers == nil {
+			headers = map[string]string{}
+		}
+		headers["Authorization"] = "Bearer ${" + strings.TrimSpace(bearer) + "}"
+	}
+	if len(headers) > 0 {
+		canonical["headers"] = headers
+	}
+
+	return canonical
+}
+
+// ExportServers returns the canonical entry of every service t configures in
+// scope, keyed by service name. ConfigScopeEffective exports the entry the
+// tool would use for each service.
+func ExportServers(t Target, scope ConfigScope) (map[string]map[string]any, error) {
+	explainer, ok := t.(Explainer)
+	if !ok {
+		return nil, fmt.Errorf("target %q cannot export its configuration", t.Slug())
+	}
+
+	var names []string
+	var err error
+	if scopedTarget, ok := t.(ScopedTarget); ok {
+		names, err = scopedTarget.ListWithScope(scope)
+	} else {
+		names, err = t.List()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(names)
+	servers := make(map[string]map[string]any, len(names))
+	for _, name := range names {
+		layers, err := explainer.ExplainService(name)
+		if err != nil {
+			return nil, err
+		}
+
+		layer, found := EffectiveLayer(layers)
+		if scope != ConfigScopeEffective {
+			layer, found = scopeLayer(layers, scope)
+		}
+		if !found {
+			continue
+		}
+
+		if canonical := CanonicalEntry(layer.Entry); canonical != nil {
+			servers[name] = canonical
+		}
+	}
+
+	return servers, nil
+}
+
+// scopeLayer returns the layer for scope. Targets with a single config
+// report it as the user layer.
+func scopeLayer(layers []ScopeLayer, scope ConfigScope) (ScopeLayer, bool) {
+	for _, layer := range layers {
+		if layer.Scope == scope && layer.Entry != nil {
+			return layer, true
+		}
+	}
+
+	return ScopeLayer{}, false
+}
+
+// stringMap converts a decoded JSON or TOML object of strings. Values that
+// are not strings are skipped.
+func stringMap(value any) map[string]string {
+	switch entries := value.(type) {
+	case map[string]string:
+		result := make(map[string]string, len(entries))
+		for key, text := range entries {
+			result[key] = text
+		}
+
+		return result
+	case map[string]any:
+		result := make(map[string]string, len(entries))
+		for key, item := range entries {
+			if text, ok := item.(string); ok {
+				result[key] = text
+			}
+		}
+
+		return result
+	default:
+		return nil
+	}
+}
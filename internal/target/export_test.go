@@ -0,0 +1,76 @@
+package target
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func TestCanonicalEntryConvertsTargetShapes(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry map[string]any
+		want  map[string]any
+	}{
+		{
+			name:  "opencode local",
+			entry: map[string]any{"type": "local", "enabled": true, "command": []any{"npx", "-y", "pkg"}, "environment": map[string]any{"TOKEN": "x"}},
+			want:  map[string]any{"command": "npx", "args": []string{"-y", "pkg"}, "env": map[string]string{"TOKEN": "x"}},
+		},
+		{
+			name:  "opencode remote",
+			entry: map[string]any{"type": "remote", "url": "https://example.com/mcp", "headers": map[string]any{"X-Key": "k"}},
+			want:  map[string]any{"type": "http", "url": "https://example.com/mcp", "headers": map[string]string{"X-Key": "k"}},
+		},
+		{
+			name:  "codex bearer",
+			entry: map[string]any{"url": "https://example.com/mcp", "bearer_token_env_var": "TOKEN"},
+			want:  map[string]any{"type": "http", "url": "https://example.com/mcp", "headers": map[string]string{"Authorization": "Bearer ${TOKEN}"}},
+		},
+		{
+			name:  "claude sse",
+			entry: map[string]any{"type": "sse", "url": "https://example.com/sse"},
+			want:  map[string]any{"type": "sse", "url": "https://example.com/sse"},
+		},
+		{
+			name:  "empty",
+			entry: map[string]any{"type": "stdio"},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CanonicalEntry(tt.entry)
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("expected nil, got %#v", got)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExportServersReadsSandboxedTarget(t *testing.T) {
+	target := NewOpenCodeTarget().Sandbox(t.TempDir())
+	svc := service.Service{Name: "files", Transport: "stdio", Command: "npx", Args: []string{"-y", "files-mcp"}}
+	if err := target.Install(svc, map[string]string{"ROOT": "/tmp"}); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+
+	servers, err := ExportServers(target, ConfigScopeUser)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	want := map[string]any{"command": "npx", "args": []string{"-y", "files-mcp"}, "env": map[string]string{"ROOT": "/tmp"}}
+	if !reflect.DeepEqual(servers["files"], want) {
+		t.Fatalf("expected %#v, got %#v", want, servers["files"])
+	}
+}
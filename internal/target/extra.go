@@ -0,0 +1,12 @@
+package target
+
+import "github.com/andreagrandi/mcp-wire/internal/service"
+
+// applyServiceExtra copies the service's free-form extra keys into the
+// server config block, so users can set target-specific options mcp-wire
+// does not model. Extra keys win over generated ones.
+func applyServiceExtra(serverConfig map[string]any, svc service.Service) {
+	for key, value := range svc.Extra {
+		serverConfig[key] = value
+	}
+}
@@ -0,0 +1,425 @@
+package target
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/jsonc"
+)
+
+// marshalJSONCConfig serializes config for a config file the tool reads as
+// JSONC. When the file on disk uses comments or trailing commas, config is
+// patched into its current content, so the comments around entries that are
+// kept survive the write. Otherwise config is written as indented JSON.
+func marshalJSONCConfig(configPath string, config map[string]any) ([]byte, error) {
+	original, err := os.ReadFile(configPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read config file %q: %w", configPath, err)
+	}
+
+	if hasJSONCSyntax(original) {
+		return patchJSONC(original, config)
+	}
+
+	return jsonConfig.encode(configPath, config)
+}
+
+// hasJSONCSyntax reports whether data uses comments or trailing commas.
+// jsonc.ToJSON blanks out exactly those and leaves plain JSON as it is.
+func hasJSONCSyntax(data []byte) bool {
+	return len(data) > 0 && !bytes.Equal(jsonc.ToJSON(data), data)
+}
+
+// patchJSONC returns src with its top-level object changed to hold config.
+// Members config keeps are written back with the comments and layout around
+// them, and values config does not change are copied as they were. Removed
+// members go with the comments above them; new members are appended in key
+// order, indented like their siblings.
+func patchJSONC(src []byte, config map[string]any) ([]byte, error) {
+	parser := &jsoncParser{src: src}
+	parser.skipTrivia()
+
+	root, err := parser.value()
+	if err != nil {
+		return nil, err
+	}
+
+	parser.skipTrivia()
+	if parser.pos < len(src) {
+		return nil, fmt.Errorf("unexpected %q after top-level value at offset %d", src[parser.pos], parser.pos)
+	}
+
+	if !root.object {
+		return nil, errors.New("top-level value is not an object")
+	}
+
+	rendered, err := parser.renderObject(root, config, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.Write(src[:root.start])
+	out.WriteString(rendered)
+	out.Write(src[root.end:])
+
+	return out.Bytes(), nil
+}
+
+// jsoncNode is the span of one value in the source. Objects also keep their
+// members, and where the trivia before the closing brace starts.
+type jsoncNode struct {
+	start      int
+	end        int
+	object     bool
+	members    []jsoncMember
+	closeStart int
+}
+
+// jsoncMember is one object member. Its lead is the text from the end of the
+// previous member up to the key, its tail the comment that follows it on the
+// same line.
+type jsoncMember struct {
+	key       string
+	leadStart int
+	keyStart  int
+	value     jsoncNode
+	commaPos  int
+	tailStart int
+	tailEnd   int
+}
+
+type jsoncParser struct {
+	src []byte
+	pos int
+}
+
+// skipTrivia moves past whitespace and comments.
+func (p *jsoncParser) skipTrivia() {
+	for p.pos < len(p.src) {
+		switch {
+		case p.src[p.pos] == ' ' || p.src[p.pos] == '\t' || p.src[p.pos] == '\n' || p.src[p.pos] == '\r':
+			p.pos++
+		case bytes.HasPrefix(p.src[p.pos:], []byte("//")):
+			end := bytes.IndexByte(p.src[p.pos:], '\n')
+			if end < 0 {
+				p.pos = len(p.src)
+				return
+			}
+
+			p.pos += end
+		case bytes.HasPrefix(p.src[p.pos:], []byte("/*")):
+			end := bytes.Index(p.src[p.pos+2:], []byte("*/"))
+			if end < 0 {
+				p.pos = len(p.src)
+				return
+			}
+
+			p.pos += end + 4
+		default:
+			return
+		}
+	}
+}
+
+// sameLineComment returns the end of a comment that starts on the same line
+// as from, or from when there is none.
+func (p *jsoncParser) sameLineComment(from int) int {
+	i := from
+	for i < len(p.src) && (p.src[i] == ' ' || p.src[i] == '\t') {
+		i++
+	}
+
+	rest := p.src[i:]
+	switch {
+	case bytes.HasPrefix(rest, []byte("//")):
+		if end := bytes.IndexByte(rest, '\n'); end >= 0 {
+			return i + end
+		}
+
+		return len(p.src)
+	case bytes.HasPrefix(rest, []byte("/*")):
+		end := bytes.Index(rest[2:], []byte("*/"))
+		if end >= 0 && !bytes.Contains(rest[2:2+end], []byte("\n")) {
+			return i + end + 4
+		}
+	}
+
+	return from
+}
+
+func (p *jsoncParser) value() (jsoncNode, error) {
+	if p.pos >= len(p.src) {
+		return jsoncNode{}, errors.New("unexpected end of input")
+	}
+
+	switch p.src[p.pos] {
+	case '{':
+		return p.objectValue()
+	case '[':
+		return p.arrayValue()
+	case '"':
+		start := p.pos
+		if err := p.stringValue(); err != nil {
+			return jsoncNode{}, err
+		}
+
+		return jsoncNode{start: start, end: p.pos}, nil
+	default:
+		start := p.pos
+		for p.pos < len(p.src) && !strings.ContainsRune(" \t\r\n,:]}/", rune(p.src[p.pos])) {
+			p.pos++
+		}
+
+		if p.pos == start {
+			return jsoncNode{}, fmt.Errorf("unexpected %q at offset %d", p.src[start], start)
+		}
+
+		return jsoncNode{start: start, end: p.pos}, nil
+	}
+}
+
+func (p *jsoncParser) stringValue() error {
+	start := p.pos
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unterminated string at offset %d", start)
+}
+
+func (p *jsoncParser) arrayValue() (jsoncNode, error) {
+	node := jsoncNode{start: p.pos}
+	p.pos++
+
+	for {
+		p.skipTrivia()
+		if p.pos < len(p.src) && p.src[p.pos] == ']' {
+			p.pos++
+			node.end = p.pos
+			return node, nil
+		}
+
+		if _, err := p.value(); err != nil {
+			return jsoncNode{}, err
+		}
+
+		p.skipTrivia()
+		if p.pos >= len(p.src) {
+			return jsoncNode{}, errors.New("unterminated array")
+		}
+
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return jsoncNode{}, fmt.Errorf("unexpected %q in array at offset %d", p.src[p.pos], p.pos)
+		}
+	}
+}
+
+func (p *jsoncParser) objectValue() (jsoncNode, error) {
+	node := jsoncNode{start: p.pos, object: true}
+	p.pos++
+	memberEnd := p.pos
+
+	for {
+		p.skipTrivia()
+		if p.pos >= len(p.src) {
+			return jsoncNode{}, errors.New("unterminated object")
+		}
+
+		if p.src[p.pos] == '}' {
+			node.closeStart = memberEnd
+			p.pos++
+			node.end = p.pos
+			return node, nil
+		}
+
+		member := jsoncMember{leadStart: memberEnd, keyStart: p.pos}
+		if p.src[p.pos] != '"' {
+			return jsoncNode{}, fmt.Errorf("expected object key at offset %d", p.pos)
+		}
+
+		if err := p.stringValue(); err != nil {
+			return jsoncNode{}, err
+		}
+
+		if err := json.Unmarshal(p.src[member.keyStart:p.pos], &member.key); err != nil {
+			return jsoncNode{}, fmt.Errorf("invalid object key at offset %d: %w", member.keyStart, err)
+		}
+
+		p.skipTrivia()
+		if p.pos >= len(p.src) || p.src[p.pos] != ':' {
+			return jsoncNode{}, fmt.Errorf("expected ':' after key %q", member.key)
+		}
+
+		p.pos++
+		p.skipTrivia()
+
+		value, err := p.value()
+		if err != nil {
+			return jsoncNode{}, err
+		}
+
+		member.value = value
+		p.skipTrivia()
+		if p.pos >= len(p.src) {
+			return jsoncNode{}, errors.New("unterminated object")
+		}
+
+		switch p.src[p.pos] {
+		case ',':
+			member.commaPos = p.pos
+			member.tailStart = p.pos + 1
+		case '}':
+			member.commaPos = value.end
+			member.tailStart = value.end
+		default:
+			return jsoncNode{}, fmt.Errorf("unexpected %q after value of %q", p.src[p.pos], member.key)
+		}
+
+		member.tailEnd = p.sameLineComment(member.tailStart)
+		p.pos = member.tailEnd
+		memberEnd = p.pos
+		node.members = append(node.members, member)
+	}
+}
+
+// renderObject writes node with the members of values, keeping the source
+// text of what does not change. indent is the indentation of the line the
+// object starts on.
+func (p *jsoncParser) renderObject(node jsoncNode, values map[string]any, indent string) (string, error) {
+	if len(node.members) == 0 {
+		return marshalJSONCValue(values, indent, "  ")
+	}
+
+	memberIndent := indent + "  "
+	lead := string(p.src[node.members[0].leadStart:node.members[0].keyStart])
+	if newline := strings.LastIndexByte(lead, '\n'); newline >= 0 && strings.TrimLeft(lead[newline+1:], " \t") == "" {
+		memberIndent = lead[newline+1:]
+	}
+
+	unit := "  "
+	if strings.HasPrefix(memberIndent, indent) && len(memberIndent) > len(indent) {
+		unit = memberIndent[len(indent):]
+	}
+
+	type piece struct{ body, tail string }
+	var pieces []piece
+
+	seen := map[string]bool{}
+	for _, member := range node.members {
+		value, keep := values[member.key]
+		if !keep || seen[member.key] {
+			continue
+		}
+
+		seen[member.key] = true
+		rendered, err := p.render(member.value, value, memberIndent, unit)
+		if err != nil {
+			return "", err
+		}
+
+		pieces = append(pieces, piece{
+			body: string(p.src[member.leadStart:member.value.start]) + rendered + string(p.src[member.value.end:member.commaPos]),
+			tail: string(p.src[member.tailStart:member.tailEnd]),
+		})
+	}
+
+	added := make([]string, 0, len(values))
+	for key := range values {
+		if !seen[key] {
+			added = append(added, key)
+		}
+	}
+
+	sort.Strings(added)
+
+	for _, key := range added {
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return "", err
+		}
+
+		rendered, err := marshalJSONCValue(values[key], memberIndent, unit)
+		if err != nil {
+			return "", err
+		}
+
+		pieces = append(pieces, piece{body: "\n" + memberIndent + string(encodedKey) + ": " + rendered})
+	}
+
+	var out strings.Builder
+	out.WriteByte('{')
+	for i, piece := range pieces {
+		out.WriteString(piece.body)
+		if i < len(pieces)-1 {
+			out.WriteByte(',')
+		}
+
+		out.WriteString(piece.tail)
+	}
+
+	out.Write(p.src[node.closeStart:node.end])
+
+	return out.String(), nil
+}
+
+// render writes value in place of node, patching objects member by member
+// and keeping the source text of a value that does not change.
+func (p *jsoncParser) render(node jsoncNode, value any, indent string, unit string) (string, error) {
+	if object, ok := value.(map[string]any); ok && node.object {
+		return p.renderObject(node, object, indent)
+	}
+
+	original := p.src[node.start:node.end]
+	if sameJSONValue(original, value) {
+		return string(original), nil
+	}
+
+	return marshalJSONCValue(value, indent, unit)
+}
+
+// sameJSONValue reports whether the JSONC text original decodes to the
+// same value as value encodes to.
+func sameJSONValue(original []byte, value any) bool {
+	var decoded any
+	if err := json.Unmarshal(jsonc.ToJSON(original), &decoded); err != nil {
+		return false
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	var normalized any
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(decoded, normalized)
+}
+
+func marshalJSONCValue(value any, indent string, unit string) (string, error) {
+	data, err := json.MarshalIndent(value, indent, unit)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,137 @@
+package target
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/tidwall/jsonc"
+)
+
+func TestPatchJSONCKeepsCommentsOfKeptMembers(t *testing.T) {
+	src := `{
+  // Editor preferences
+  "editor.tabSize": 2, // spaces
+  "mcp": {
+    "servers": {
+      // retired
+      "old": {"url": "https://old.example.com"},
+      "docs": {"url": "https://docs.example.com" /* pinned */},
+    },
+  },
+}
+`
+
+	patched, err := patchJSONC([]byte(src), map[string]any{
+		"editor.tabSize": 2,
+		"mcp": map[string]any{
+			"servers": map[string]any{
+				"docs": map[string]any{"url": "https://docs.example.com"},
+				"new":  map[string]any{"url": "https://new.example.com"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected patch to succeed: %v", err)
+	}
+
+	output := string(patched)
+	for _, want := range []string{
+		"// Editor preferences",
+		`"editor.tabSize": 2, // spaces`,
+		`"docs": {"url": "https://docs.example.com" /* pinned */}`,
+		"\n      \"new\": {\n        \"url\": \"https://new.example.com\"\n      }",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in patched output, got %s", want, output)
+		}
+	}
+
+	if strings.Contains(output, "retired") || strings.Contains(output, "old.example.com") {
+		t.Fatalf("expected the removed member to go with its comment, got %s", output)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(jsonc.ToJSON(patched), &decoded); err != nil {
+		t.Fatalf("expected patched output to parse: %v\n%s", err, output)
+	}
+}
+
+func TestPatchJSONCIndentsNewMembersLikeTheirSiblings(t *testing.T) {
+	src := "{\n\t// tabs\n\t\"a\": 1\n}\n"
+
+	patched, err := patchJSONC([]byte(src), map[string]any{"a": 1, "b": map[string]any{"c": true}})
+	if err != nil {
+		t.Fatalf("expected patch to succeed: %v", err)
+	}
+
+	want := "{\n\t// tabs\n\t\"a\": 1,\n\t\"b\": {\n\t\t\"c\": true\n\t}\n}\n"
+	if string(patched) != want {
+		t.Fatalf("unexpected patched output:\n%s\nwant:\n%s", patched, want)
+	}
+}
+
+func TestMarshalJSONCConfigWritesPlainJSONWithoutComments(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(configPath, []byte(`{"b": 1, "a": 2}`), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	data, err := marshalJSONCConfig(configPath, map[string]any{"a": 2, "b": 1})
+	if err != nil {
+		t.Fatalf("expected marshal to succeed: %v", err)
+	}
+
+	if string(data) != "{\n  \"a\": 2,\n  \"b\": 1\n}\n" {
+		t.Fatalf("expected indented JSON, got %s", data)
+	}
+}
+
+func TestOpenCodeTargetUninstallKeepsJSONCComments(t *testing.T) {
+	target := newTestOpenCodeTarget(t)
+	if err := os.MkdirAll(filepath.Dir(target.configPath), 0o755); err != nil {
+		t.Fatalf("create config dir: %v", err)
+	}
+
+	content := `{
+  // shared with the team
+  "theme": "dark",
+  "mcp": {
+    "service-a": {"type": "remote", "url": "https://a.example.com"},
+    "service-b": {"type": "remote", "url": "https://b.example.com"},
+  },
+}
+`
+	if err := os.WriteFile(target.configPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	if err := target.Uninstall("service-a"); err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	data, err := os.ReadFile(target.configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+
+	if !strings.Contains(string(data), "// shared with the team") || strings.Contains(string(data), "service-a") {
+		t.Fatalf("expected service-a removed and comments kept, got %s", data)
+	}
+
+	if err := target.Install(service.Service{Name: "service-c", Transport: "sse", URL: "https://c.example.com"}, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	services, err := target.List()
+	if err != nil {
+		t.Fatalf("expected list to succeed: %v", err)
+	}
+
+	if strings.Join(services, ",") != "service-b,service-c" {
+		t.Fatalf("unexpected services %v", services)
+	}
+}
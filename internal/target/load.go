@@ -0,0 +1,151 @@
+package target
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadSimulator is an optional interface for targets that can resolve a
+// service the way the tool itself does when it starts. It catches entries
+// that were written correctly but to a file or scope the tool never reads.
+type LoadSimulator interface {
+	SimulateLoad(serviceName string) (LoadResult, error)
+}
+
+// LoadResult reports whether a tool would load a service at startup.
+type LoadResult struct {
+	Loaded bool
+	Path   string // config file the entry is loaded from, when Loaded
+	Reason string // why the entry would not be loaded, when not Loaded
+}
+
+// SimulateLoad resolves serviceName the way Claude Code does. A managed
+// system config that defines any MCP servers takes exclusive control, and
+// user and project servers are only read from ~/.claude.json, never from
+// ~/.claude/settings.json.
+func (t *ClaudeCodeTarget) SimulateLoad(serviceName string) (LoadResult, error) {
+	serviceName = strings.TrimSpace(serviceName)
+	if serviceName == "" {
+		return LoadResult{}, errors.New("service name is required")
+	}
+
+	systemConfig, _, err := t.readConfigFile(t.systemConfigPath)
+	if err != nil && !errors.Is(err, os.ErrPermission) {
+		return LoadResult{}, err
+	}
+
+	systemMCPServers, err := t.getMCPServers(systemConfig, ConfigScopeSystem, false)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	if len(systemMCPServers) > 0 {
+		if serviceEntry(systemMCPServers, serviceName) != nil {
+			return LoadResult{Loaded: true, Path: t.systemConfigPath}, nil
+		}
+
+		return LoadResult{Reason: fmt.Sprintf("the managed config %s takes exclusive control of MCP servers and does not define it", t.systemConfigPath)}, nil
+	}
+
+	if filepath.Base(t.configPath) != ".claude.json" {
+		return LoadResult{Reason: fmt.Sprintf("Claude Code reads MCP servers from ~/.claude.json, not %s", t.configPath)}, nil
+	}
+
+	config, _, err := t.readConfig()
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	projectMCPServers, err := t.getMCPServers(config, ConfigScopeProject, false)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	userMCPServers, err := t.getMCPServers(config, ConfigScopeUser, false)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	return loadFromLayers([]ScopeLayer{
+		{Scope: ConfigScopeProject, Path: t.configPath, Entry: serviceEntry(projectMCPServers, serviceName)},
+		{Scope: ConfigScopeUser, Path: t.configPath, Entry: serviceEntry(userMCPServers, serviceName)},
+	}, "Claude Code"), nil
+}
+
+// SimulateLoad resolves serviceName the way Codex CLI does. Codex reads
+// config.toml from CODEX_HOME when it is set, and skips disabled servers.
+func (t *CodexTarget) SimulateLoad(serviceName string) (LoadResult, error) {
+	if codexHome := strings.TrimSpace(os.Getenv("CODEX_HOME")); codexHome != "" {
+		readPath := filepath.Join(codexHome, "config.toml")
+		if filepath.Clean(readPath) != filepath.Clean(t.configPath) {
+			return LoadResult{Reason: fmt.Sprintf("Codex CLI reads %s because CODEX_HOME is set, not %s", readPath, t.configPath)}, nil
+		}
+	}
+
+	layers, err := t.ExplainService(serviceName)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	return loadFromLayers(layers, "Codex CLI"), nil
+}
+
+// SimulateLoad resolves serviceName the way OpenCode does, skipping servers
+// that are disabled.
+func (t *OpenCodeTarget) SimulateLoad(serviceName string) (LoadResult, error) {
+	layers, err := t.ExplainService(serviceName)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	return loadFromLayers(layers, "OpenCode"), nil
+}
+
+// SimulateLoad resolves serviceName the way VS Code does. Workspace settings
+// override user settings, and chat.mcp.enabled set to false turns off every
+// MCP server.
+func (t *VSCodeTarget) SimulateLoad(serviceName string) (LoadResult, error) {
+	layers, err := t.ExplainService(serviceName)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	for _, layer := range layers {
+		config, _, err := readVSCodeSettings(layer.Path)
+		if err != nil {
+			return LoadResult{}, err
+		}
+
+		if enabled, ok := config["chat.mcp.enabled"].(bool); ok {
+			if !enabled {
+				return LoadResult{Reason: fmt.Sprintf("MCP support is turned off by chat.mcp.enabled in %s", layer.Path)}, nil
+			}
+
+			break
+		}
+	}
+
+	return loadFromLayers(layers, "VS Code"), nil
+}
+
+// loadFromLayers returns the effective layer for a service as the one the
+// tool loads, unless the entry there is disabled.
+func loadFromLayers(layers []ScopeLayer, toolName string) LoadResult {
+	layer, ok := EffectiveLayer(layers)
+	if !ok {
+		return LoadResult{Reason: fmt.Sprintf("no config file %s reads defines it", toolName)}
+	}
+
+	if enabled, ok := layer.Entry["enabled"].(bool); ok && !enabled {
+		return LoadResult{Reason: fmt.Sprintf("it is disabled in %s", layer.Path)}
+	}
+
+	if disabled, ok := layer.Entry["disabled"].(bool); ok && disabled {
+		return LoadResult{Reason: fmt.Sprintf("it is disabled in %s", layer.Path)}
+	}
+
+	return LoadResult{Loaded: true, Path: layer.Path}
+}
@@ -0,0 +1,151 @@
+package target
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func TestClaudeCodeTargetSimulateLoadRejectsSettingsJSON(t *testing.T) {
+	target := newTestClaudeCodeTarget(t)
+
+	svc := service.Service{Name: "docs", Transport: "sse", URL: "https://docs.example.com/sse"}
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	result, err := target.SimulateLoad("docs")
+	if err != nil {
+		t.Fatalf("expected simulation to succeed: %v", err)
+	}
+
+	if result.Loaded || !strings.Contains(result.Reason, "reads MCP servers from ~/.claude.json") {
+		t.Fatalf("expected settings.json entry not to load, got %#v", result)
+	}
+}
+
+func TestClaudeCodeTargetSimulateLoadFindsEntryInClaudeJSON(t *testing.T) {
+	target := newTestClaudeCodeTarget(t)
+	target.configPath = filepath.Join(t.TempDir(), ".claude.json")
+
+	svc := service.Service{Name: "docs", Transport: "sse", URL: "https://docs.example.com/sse"}
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	result, err := target.SimulateLoad("docs")
+	if err != nil {
+		t.Fatalf("expected simulation to succeed: %v", err)
+	}
+
+	if !result.Loaded || result.Path != target.configPath {
+		t.Fatalf("expected entry to load from %q, got %#v", target.configPath, result)
+	}
+}
+
+func TestClaudeCodeTargetSimulateLoadHonorsExclusiveManagedConfig(t *testing.T) {
+	target := newTestClaudeCodeTarget(t)
+	target.configPath = filepath.Join(t.TempDir(), ".claude.json")
+
+	writeTargetConfigFile(t, target.systemConfigPath, map[string]any{
+		"mcpServers": map[string]any{
+			"company-docs": map[string]any{"type": "http", "url": "https://docs.example.com/mcp"},
+		},
+	})
+
+	svc := service.Service{Name: "docs", Transport: "sse", URL: "https://docs.example.com/sse"}
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	result, err := target.SimulateLoad("docs")
+	if err != nil {
+		t.Fatalf("expected simulation to succeed: %v", err)
+	}
+
+	if result.Loaded || !strings.Contains(result.Reason, "takes exclusive control") {
+		t.Fatalf("expected managed config to shadow user entry, got %#v", result)
+	}
+}
+
+func TestCodexTargetSimulateLoadReportsCodexHomeMismatch(t *testing.T) {
+	target := newTestCodexTarget(t)
+	codexHome := t.TempDir()
+	t.Setenv("CODEX_HOME", codexHome)
+
+	svc := service.Service{Name: "docs", Transport: "sse", URL: "https://docs.example.com/sse"}
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	result, err := target.SimulateLoad("docs")
+	if err != nil {
+		t.Fatalf("expected simulation to succeed: %v", err)
+	}
+
+	if result.Loaded || !strings.Contains(result.Reason, "because CODEX_HOME is set") {
+		t.Fatalf("expected CODEX_HOME mismatch, got %#v", result)
+	}
+
+	t.Setenv("CODEX_HOME", filepath.Dir(target.configPath))
+
+	result, err = target.SimulateLoad("docs")
+	if err != nil || !result.Loaded {
+		t.Fatalf("expected entry to load when CODEX_HOME matches, got %#v (err %v)", result, err)
+	}
+}
+
+func TestOpenCodeTargetSimulateLoadSkipsDisabledEntry(t *testing.T) {
+	target := newTestOpenCodeTarget(t)
+
+	writeOpenCodeConfigFile(t, target.configPath, map[string]any{
+		"mcp": map[string]any{
+			"docs": map[string]any{"type": "remote", "url": "https://docs.example.com/mcp", "enabled": false},
+		},
+	})
+
+	result, err := target.SimulateLoad("docs")
+	if err != nil {
+		t.Fatalf("expected simulation to succeed: %v", err)
+	}
+
+	if result.Loaded || !strings.Contains(result.Reason, "disabled") {
+		t.Fatalf("expected disabled entry not to load, got %#v", result)
+	}
+
+	result, err = target.SimulateLoad("missing")
+	if err != nil || result.Loaded || !strings.Contains(result.Reason, "no config file OpenCode reads defines it") {
+		t.Fatalf("expected missing entry not to load, got %#v (err %v)", result, err)
+	}
+}
+
+func TestVSCodeTargetSimulateLoadHonorsWorkspaceMCPToggle(t *testing.T) {
+	target := newTestVSCodeTarget(t)
+	workspaceDir := t.TempDir()
+	target.SetProjectDir(workspaceDir)
+
+	svc := service.Service{Name: "docs", Transport: "http", URL: "https://docs.example.com/mcp"}
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	result, err := target.SimulateLoad("docs")
+	if err != nil || !result.Loaded {
+		t.Fatalf("expected entry to load, got %#v (err %v)", result, err)
+	}
+
+	writeTargetConfigFile(t, filepath.Join(workspaceDir, ".vscode", "settings.json"), map[string]any{
+		"chat.mcp.enabled": false,
+	})
+
+	result, err = target.SimulateLoad("docs")
+	if err != nil {
+		t.Fatalf("expected simulation to succeed: %v", err)
+	}
+
+	if result.Loaded || !strings.Contains(result.Reason, "chat.mcp.enabled") {
+		t.Fatalf("expected workspace toggle to turn off MCP, got %#v", result)
+	}
+}
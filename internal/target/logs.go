@@ -0,0 +1,108 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// LogSource is an optional interface for targets that write MCP server logs
+// to known locations.
+type LogSource interface {
+	// LogFiles returns the log files that may mention serviceName, oldest
+	// first. An empty serviceName returns logs for every service. shared
+	// reports whether the files mix output from all services, in which case
+	// callers should filter lines by service name.
+	LogFiles(serviceName string) (files []string, shared bool, err error)
+}
+
+// LogFiles returns Claude Code's per-server MCP logs. Claude Code keeps one
+// directory per project and server, named mcp-logs-<server>.
+func (t *ClaudeCodeTarget) LogFiles(serviceName string) ([]string, bool, error) {
+	serverDir := "mcp-logs-*"
+	if name := strings.TrimSpace(serviceName); name != "" {
+		serverDir = "mcp-logs-" + name
+	}
+
+	files, err := globLogFiles(filepath.Join(t.logDir, "*", serverDir, "*"))
+	return files, false, err
+}
+
+// LogFiles returns the Codex CLI logs, which are shared by all servers.
+func (t *CodexTarget) LogFiles(_ string) ([]string, bool, error) {
+	files, err := globLogFiles(filepath.Join(t.logDir, "*.log"))
+	return files, true, err
+}
+
+// LogFiles returns the OpenCode logs, which are shared by all servers.
+func (t *OpenCodeTarget) LogFiles(_ string) ([]string, bool, error) {
+	files, err := globLogFiles(filepath.Join(t.logDir, "*.log"))
+	return files, true, err
+}
+
+func defaultClaudeCodeLogDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(".cache", "claude-cli-nodejs")
+	}
+
+	if runtime.GOOS == "windows" {
+		return filepath.Join(cacheDir, "claude-cli-nodejs", "Cache")
+	}
+
+	return filepath.Join(cacheDir, "claude-cli-nodejs")
+}
+
+func defaultCodexLogDir() string {
+	return filepath.Join(filepath.Dir(defaultCodexConfigPath()), "log")
+}
+
+func defaultOpenCodeLogDir() string {
+	if dataHome := strings.TrimSpace(os.Getenv("XDG_DATA_HOME")); dataHome != "" {
+		return filepath.Join(dataHome, "opencode", "log")
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".local", "share", "opencode", "log")
+	}
+
+	return filepath.Join(homeDir, ".local", "share", "opencode", "log")
+}
+
+// globLogFiles expands pattern and returns regular files ordered by
+// modification time, oldest first.
+func globLogFiles(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	type logFile struct {
+		path    string
+		modTime int64
+	}
+
+	files := make([]logFile, 0, len(matches))
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		files = append(files, logFile{path: match, modTime: info.ModTime().UnixNano()})
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].modTime < files[j].modTime
+	})
+
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.path)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,75 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeLogFile(t *testing.T, path string, modTime time.Time) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create log dir: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("log\n"), 0o600); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("failed to set log time: %v", err)
+	}
+}
+
+func TestClaudeCodeLogFilesSelectsServiceDirectory(t *testing.T) {
+	logDir := t.TempDir()
+	now := time.Now()
+
+	newer := filepath.Join(logDir, "-home-me-app", "mcp-logs-sentry", "b.txt")
+	older := filepath.Join(logDir, "-home-me-api", "mcp-logs-sentry", "a.txt")
+	other := filepath.Join(logDir, "-home-me-app", "mcp-logs-jira", "c.txt")
+	writeLogFile(t, newer, now)
+	writeLogFile(t, older, now.Add(-time.Hour))
+	writeLogFile(t, other, now)
+
+	target := &ClaudeCodeTarget{logDir: logDir}
+
+	files, shared, err := target.LogFiles("sentry")
+	if err != nil {
+		t.Fatalf("expected log lookup to succeed: %v", err)
+	}
+
+	if shared {
+		t.Fatal("expected Claude Code logs to be per server")
+	}
+
+	if len(files) != 2 || files[0] != older || files[1] != newer {
+		t.Fatalf("expected sentry logs oldest first, got %v", files)
+	}
+}
+
+func TestCodexLogFilesAreShared(t *testing.T) {
+	logDir := t.TempDir()
+	writeLogFile(t, filepath.Join(logDir, "codex-tui.log"), time.Now())
+
+	target := &CodexTarget{logDir: logDir}
+
+	files, shared, err := target.LogFiles("sentry")
+	if err != nil {
+		t.Fatalf("expected log lookup to succeed: %v", err)
+	}
+
+	if !shared || len(files) != 1 {
+		t.Fatalf("expected one shared log file, got %v shared=%v", files, shared)
+	}
+}
+
+func TestOpenCodeLogDirHonoursXDGDataHome(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/tmp/data")
+
+	if got := defaultOpenCodeLogDir(); got != filepath.Join("/tmp/data", "opencode", "log") {
+		t.Fatalf("unexpected OpenCode log dir %q", got)
+	}
+}
@@ -0,0 +1,441 @@
+package target
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+// Names of the merge strategies a target can be set to.
+const (
+	MergeReplace   = "replace"
+	MergeDeep      = "deep-merge"
+	MergeJSONPatch = "json-patch"
+)
+
+// MergeStrategy decides what a target writes for a service it installs,
+// given the entry already in the config, nil when there is none, and the
+// entry mcp-wire generated for the service.
+type MergeStrategy interface {
+	Merge(existing any, generated map[string]any) (any, error)
+}
+
+// MergeStrategySetter is an optional interface for targets whose install
+// can combine the generated entry with the existing one. Targets replace
+// the existing entry until a strategy is set.
+type MergeStrategySetter interface {
+	SetMergeStrategy(strategy MergeStrategy)
+}
+
+// SetMergeStrategy sets how installs combine a service entry with the one
+// already in the config.
+func (t *ClaudeCodeTarget) SetMergeStrategy(strategy MergeStrategy) {
+	t.merge = strategy
+}
+
+// SetMergeStrategy sets how installs combine a service entry with the one
+// already in the config.
+func (t *CodexTarget) SetMergeStrategy(strategy MergeStrategy) {
+	t.merge = strategy
+}
+
+// SetMergeStrategy sets how installs combine a service entry with the one
+// already in the config.
+func (t *OpenCodeTarget) SetMergeStrategy(strategy MergeStrategy) {
+	t.merge = strategy
+}
+
+// SetMergeStrategy sets how installs combine a service entry with the one
+// already in the config.
+func (t *VSCodeTarget) SetMergeStrategy(strategy MergeStrategy) {
+	t.merge = strategy
+}
+
+// NewMergeStrategy returns the strategy called name. patch holds the RFC
+// 6902 operations of the json-patch strategy and must be empty for the
+// others.
+func NewMergeStrategy(name string, patch json.RawMessage) (MergeStrategy, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	hasPatch := len(bytes.TrimSpace(patch)) > 0
+	if hasPatch && name != MergeJSONPatch {
+		return nil, fmt.Errorf("merge strategy %q does not take a patch", name)
+	}
+
+	switch name {
+	case "", MergeReplace:
+		return ReplaceMerge{}, nil
+	case MergeDeep:
+		return DeepMerge{}, nil
+	case MergeJSONPatch:
+		var operations []PatchOperation
+		if hasPatch {
+			decoder := json.NewDecoder(bytes.NewReader(patch))
+			decoder.UseNumber()
+			if err := decoder.Decode(&operations); err != nil {
+				return nil, fmt.Errorf("parse json-patch operations: %w", err)
+			}
+		}
+
+		if len(operations) == 0 {
+			return nil, errors.New("json-patch merge strategy requires a patch")
+		}
+
+		for i, operation := range operations {
+			if err := operation.validate(); err != nil {
+				return nil, fmt.Errorf("json-patch operation %d: %w", i, err)
+			}
+		}
+
+		return JSONPatchMerge{Operations: operations}, nil
+	default:
+		return nil, fmt.Errorf("unknown merge strategy %q (want %s, %s, or %s)", name, MergeReplace, MergeDeep, MergeJSONPatch)
+	}
+}
+
+// ReplaceMerge writes the generated entry in place of the existing one.
+// It is what targets do when no strategy is set.
+type ReplaceMerge struct{}
+
+// Merge returns generated.
+func (ReplaceMerge) Merge(_ any, generated map[string]any) (any, error) {
+	return generated, nil
+}
+
+// DeepMerge keeps the keys of the existing entry that the generated one does
+// not set, such as options added by hand, merging nested objects key by key.
+// Where both set a key to something other than two objects, the generated
+// value wins.
+type DeepMerge struct{}
+
+// Merge returns generated laid over existing.
+func (DeepMerge) Merge(existing any, generated map[string]any) (any, error) {
+	base, ok := existing.(map[string]any)
+	if !ok {
+		return generated, nil
+	}
+
+	return deepMergeObjects(base, patchValue(generated).(map[string]any)), nil
+}
+
+func deepMergeObjects(base map[string]any, overlay map[string]any) map[string]any {
+	merged := maps.Clone(base)
+	for key, value := range overlay {
+		baseObject, baseIsObject := merged[key].(map[string]any)
+		overlayObject, overlayIsObject := value.(map[string]any)
+		if baseIsObject && overlayIsObject {
+			merged[key] = deepMergeObjects(baseObject, overlayObject)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// PatchOperation is one RFC 6902 operation. Paths are JSON pointers into the
+// service entry, so "/env/DEBUG" is the DEBUG variable of the service.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+func (o PatchOperation) validate() error {
+	switch o.Op {
+	case "add", "remove", "replace", "move", "copy", "test":
+	default:
+		return fmt.Errorf("unknown op %q", o.Op)
+	}
+
+	if _, err := splitJSONPointer(o.Path); err != nil {
+		return err
+	}
+
+	if o.Op == "move" || o.Op == "copy" {
+		if _, err := splitJSONPointer(o.From); err != nil {
+			return fmt.Errorf("from: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// JSONPatchMerge applies its operations to the generated entry, which then
+// replaces the existing one. It suits changes the service definitions
+// cannot express, such as dropping a key a target does not accept.
+type JSONPatchMerge struct {
+	Operations []PatchOperation
+}
+
+// Merge returns generated with the operations applied. The patch fails as a
+// whole when one of its operations does, including a failed "test".
+func (m JSONPatchMerge) Merge(_ any, generated map[string]any) (any, error) {
+	var document any = patchValue(generated)
+	for i, operation := range m.Operations {
+		patched, err := applyPatchOperation(document, operation)
+		if err != nil {
+			return nil, fmt.Errorf("json-patch operation %d (%s %s): %w", i, operation.Op, operation.Path, err)
+		}
+
+		document = patched
+	}
+
+	return document, nil
+}
+
+func applyPatchOperation(document any, operation PatchOperation) (any, error) {
+	tokens, err := splitJSONPointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add":
+		return patchAdd(document, tokens, patchValue(operation.Value))
+	case "remove":
+		document, _, err = patchRemove(document, tokens)
+		return document, err
+	case "replace":
+		if document, _, err = patchRemove(document, tokens); err != nil {
+			return nil, err
+		}
+		return patchAdd(document, tokens, patchValue(operation.Value))
+	case "move", "copy":
+		from, err := splitJSONPointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+
+		var value any
+		if operation.Op == "move" {
+			document, value, err = patchRemove(document, from)
+		} else {
+			value, err = patchGet(document, from)
+			value = patchValue(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return patchAdd(document, tokens, value)
+	case "test":
+		value, err := patchGet(document, tokens)
+		if err != nil {
+			return nil, err
+		}
+
+		if !patchValuesEqual(value, patchValue(operation.Value)) {
+			return nil, errors.New("test failed")
+		}
+
+		return document, nil
+	}
+
+	return nil, fmt.Errorf("unknown op %q", operation.Op)
+}
+
+// splitJSONPointer returns the unescaped reference tokens of pointer.
+func splitJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("path %q must start with /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func patchGet(document any, tokens []string) (any, error) {
+	value := document
+	for _, token := range tokens {
+		switch container := value.(type) {
+		case map[string]any:
+			child, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("no value at %q", token)
+			}
+			value = child
+		case []any:
+			index, err := patchArrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			value = container[index]
+		default:
+			return nil, fmt.Errorf("cannot look up %q in a scalar", token)
+		}
+	}
+
+	return value, nil
+}
+
+func patchAdd(document any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	parent, err := patchGet(document, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	last := tokens[len(tokens)-1]
+	switch container := parent.(type) {
+	case map[string]any:
+		container[last] = value
+		return document, nil
+	case []any:
+		index, err := patchArrayIndex(last, len(container), true)
+		if err != nil {
+			return nil, err
+		}
+
+		return patchSetParent(document, tokens[:len(tokens)-1], append(container[:index:index], append([]any{value}, container[index:]...)...))
+	default:
+		return nil, fmt.Errorf("cannot add %q to a scalar", last)
+	}
+}
+
+func patchRemove(document any, tokens []string) (any, any, error) {
+	if len(tokens) == 0 {
+		return nil, document, nil
+	}
+
+	parent, err := patchGet(document, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	last := tokens[len(tokens)-1]
+	switch container := parent.(type) {
+	case map[string]any:
+		value, ok := container[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("no value at %q", last)
+		}
+
+		delete(container, last)
+		return document, value, nil
+	case []any:
+		index, err := patchArrayIndex(last, len(container), false)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		value := container[index]
+		document, err = patchSetParent(document, tokens[:len(tokens)-1], append(container[:index:index], container[index+1:]...))
+		return document, value, err
+	default:
+		return nil, nil, fmt.Errorf("cannot remove %q from a scalar", last)
+	}
+}
+
+// patchSetParent stores array, rebuilt by an add or remove, back at tokens.
+func patchSetParent(document any, tokens []string, array []any) (any, error) {
+	if len(tokens) == 0 {
+		return array, nil
+	}
+
+	return patchAdd(document, tokens, array)
+}
+
+// patchArrayIndex parses token as an index into an array of length n. With
+// forAdd, "-" and n itself name the end of the array.
+func patchArrayIndex(token string, n int, forAdd bool) (int, error) {
+	if forAdd && token == "-" {
+		return n, nil
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+
+	limit := n - 1
+	if forAdd {
+		limit = n
+	}
+
+	if index > limit {
+		return 0, fmt.Errorf("array index %d out of range", index)
+	}
+
+	return index, nil
+}
+
+// patchValue returns a deep copy of value made of map[string]any and []any,
+// as a JSON document holds, so the patch can address every part of it and
+// never changes the caller's maps. Whole numbers become int64 and other
+// numbers float64, which both the JSON and TOML encoders write as expected.
+func patchValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, item := range v {
+			copied[key] = patchValue(item)
+		}
+		return copied
+	case map[string]string:
+		copied := make(map[string]any, len(v))
+		for key, item := range v {
+			copied[key] = item
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(v))
+		for i, item := range v {
+			copied[i] = patchValue(item)
+		}
+		return copied
+	case []string:
+		copied := make([]any, len(v))
+		for i, item := range v {
+			copied[i] = item
+		}
+		return copied
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case int:
+		return int64(v)
+	default:
+		return v
+	}
+}
+
+func patchValuesEqual(a any, b any) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aJSON, bJSON)
+}
+
+// mergeServerEntry combines the generated entry for a service with the
+// existing one using strategy, replacing it when strategy is nil.
+func mergeServerEntry(strategy MergeStrategy, existing any, generated map[string]any) (any, error) {
+	if strategy == nil {
+		return generated, nil
+	}
+
+	merged, err := strategy.Merge(existing, generated)
+	if err != nil {
+		return nil, fmt.Errorf("merge service entry: %w", err)
+	}
+
+	return merged, nil
+}
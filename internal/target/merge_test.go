@@ -0,0 +1,136 @@
+package target
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func TestDeepMergeKeepsKeysAddedByHand(t *testing.T) {
+	existing := map[string]any{
+		"command": "old",
+		"timeout": 30,
+		"env":     map[string]any{"DEBUG": "1", "TOKEN": "old"},
+	}
+	generated := map[string]any{
+		"command": "npx",
+		"env":     map[string]string{"TOKEN": "new"},
+	}
+
+	merged, err := DeepMerge{}.Merge(existing, generated)
+	if err != nil {
+		t.Fatalf("expected merge to succeed: %v", err)
+	}
+
+	want := map[string]any{
+		"command": "npx",
+		"timeout": 30,
+		"env":     map[string]any{"DEBUG": "1", "TOKEN": "new"},
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Fatalf("unexpected merge %#v", merged)
+	}
+
+	if existing["command"] != "old" {
+		t.Fatal("expected merge to leave the existing entry alone")
+	}
+}
+
+func TestJSONPatchMergeAppliesOperations(t *testing.T) {
+	patch := `[
+		{"op": "remove", "path": "/type"},
+		{"op": "add", "path": "/timeout", "value": 30},
+		{"op": "add", "path": "/args/-", "value": "--verbose"},
+		{"op": "test", "path": "/args/0", "value": "-y"},
+		{"op": "move", "from": "/env/TOKEN", "path": "/env/API~1TOKEN"}
+	]`
+	strategy, err := NewMergeStrategy("json-patch", json.RawMessage(patch))
+	if err != nil {
+		t.Fatalf("expected strategy to parse: %v", err)
+	}
+
+	generated := map[string]any{
+		"type": "stdio",
+		"args": []string{"-y", "server"},
+		"env":  map[string]string{"TOKEN": "abc"},
+	}
+
+	merged, err := strategy.Merge(nil, generated)
+	if err != nil {
+		t.Fatalf("expected patch to apply: %v", err)
+	}
+
+	want := map[string]any{
+		"timeout": int64(30),
+		"args":    []any{"-y", "server", "--verbose"},
+		"env":     map[string]any{"API/TOKEN": "abc"},
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Fatalf("unexpected patch result %#v", merged)
+	}
+
+	if generated["type"] != "stdio" {
+		t.Fatal("expected patch to leave the generated entry alone")
+	}
+}
+
+func TestJSONPatchMergeFailsOnFailedTest(t *testing.T) {
+	strategy, err := NewMergeStrategy("json-patch", json.RawMessage(`[{"op":"test","path":"/url","value":"https://other.example.com"}]`))
+	if err != nil {
+		t.Fatalf("expected strategy to parse: %v", err)
+	}
+
+	_, err = strategy.Merge(nil, map[string]any{"url": "https://example.com"})
+	if err == nil || !strings.Contains(err.Error(), "test failed") {
+		t.Fatalf("expected failed test error, got %v", err)
+	}
+}
+
+func TestNewMergeStrategyRejectsInvalidSettings(t *testing.T) {
+	cases := map[string]struct {
+		name  string
+		patch string
+		want  string
+	}{
+		"unknown name":      {name: "overlay", want: "unknown merge strategy"},
+		"patch not allowed": {name: "deep-merge", patch: `[]`, want: "does not take a patch"},
+		"missing patch":     {name: "json-patch", want: "requires a patch"},
+		"unknown op":        {name: "json-patch", patch: `[{"op":"drop","path":"/a"}]`, want: `unknown op "drop"`},
+		"relative path":     {name: "json-patch", patch: `[{"op":"remove","path":"a"}]`, want: "must start with /"},
+	}
+
+	for label, tc := range cases {
+		_, err := NewMergeStrategy(tc.name, json.RawMessage(tc.patch))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %v", label, tc.want, err)
+		}
+	}
+}
+
+func TestInstallUsesMergeStrategy(t *testing.T) {
+	target := newTestCodexTarget(t)
+	writeCodexConfigFile(t, target.configPath, map[string]any{
+		"mcp_servers": map[string]any{
+			"demo": map[string]any{"url": "https://old.example.com", "startup_timeout_sec": 20},
+		},
+	})
+	target.SetMergeStrategy(DeepMerge{})
+
+	svc := service.Service{Name: "demo", Transport: "http", URL: "https://example.com/mcp"}
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	config := readCodexConfigFile(t, target.configPath)
+	entry := mustMapValue(t, mustMapValue(t, config["mcp_servers"], "mcp_servers")["demo"], "demo")
+	if entry["url"] != "https://example.com/mcp" {
+		t.Fatalf("expected generated url to win, got %#v", entry)
+	}
+
+	if _, ok := entry["startup_timeout_sec"]; !ok {
+		t.Fatalf("expected hand-added key to be kept, got %#v", entry)
+	}
+}
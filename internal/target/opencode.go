@@ -1,8 +1,6 @@
 package target
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -12,8 +10,8 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/andreagrandi/mcp-wire/internal/project"
 	"github.com/andreagrandi/mcp-wire/internal/service"
-	"github.com/tidwall/jsonc"
 )
 
 const (
@@ -21,20 +19,32 @@ const (
 	openCodeSlug       = "opencode"
 )
 
+// openCodeProjectConfigNames are the project config files OpenCode reads
+// from a repository, in the order mcp-wire picks an existing one.
+var openCodeProjectConfigNames = []string{"opencode.json", "opencode.jsonc"}
+
 // OpenCodeTarget manages MCP service configuration for OpenCode.
 type OpenCodeTarget struct {
 	configPath          string
+	projectDir          string
+	logDir              string
 	lookPath            func(file string) (string, error)
 	statPath            func(name string) (os.FileInfo, error)
 	runCommand          func(name string, args ...string) *exec.Cmd
 	binaryNames         []string
 	fallbackBinaryPaths []string
+	keepEmpty           bool
+	markedInstalled     bool
+	backup              func(configPath string) error
+	preview             func(configPath string, data []byte)
+	merge               MergeStrategy
 }
 
 // NewOpenCodeTarget returns a target instance for OpenCode.
 func NewOpenCodeTarget() *OpenCodeTarget {
 	return &OpenCodeTarget{
 		configPath:          defaultOpenCodeConfigPath(),
+		logDir:              defaultOpenCodeLogDir(),
 		lookPath:            exec.LookPath,
 		statPath:            os.Stat,
 		runCommand:          exec.Command,
@@ -58,8 +68,19 @@ func (t *OpenCodeTarget) ConfigPath() string {
 	return t.configPath
 }
 
+// SetProjectDir pins the directory project scope applies to. Without it the
+// working directory is used, or the nearest parent that already has an
+// OpenCode project config.
+func (t *OpenCodeTarget) SetProjectDir(dir string) {
+	t.projectDir = strings.TrimSpace(dir)
+}
+
 // IsInstalled reports whether OpenCode is available via supported install methods.
 func (t *OpenCodeTarget) IsInstalled() bool {
+	if t.markedInstalled {
+		return true
+	}
+
 	binaryNames := t.binaryNames
 	if len(binaryNames) == 0 {
 		binaryNames = []string{openCodeBinaryName}
@@ -84,14 +105,36 @@ func (t *OpenCodeTarget) IsInstalled() bool {
 	return false
 }
 
-// Install writes or updates the service configuration in the target config.
+// Install writes or updates the service configuration in the user config.
 func (t *OpenCodeTarget) Install(svc service.Service, resolvedEnv map[string]string) error {
+	return t.InstallWithScope(svc, resolvedEnv, ConfigScopeUser)
+}
+
+// SupportedScopes returns the scopes supported by OpenCode target
+// operations. Project scope is the opencode.json of the repository.
+func (t *OpenCodeTarget) SupportedScopes() []ConfigScope {
+	return []ConfigScope{ConfigScopeUser, ConfigScopeProject, ConfigScopeEffective}
+}
+
+// configScopes returns the config files OpenCode reads servers from,
+// highest precedence first: the project config is merged over the user one.
+func (t *OpenCodeTarget) configScopes() []ConfigScope {
+	return []ConfigScope{ConfigScopeProject, ConfigScopeUser}
+}
+
+// InstallWithScope writes or updates the service configuration in the requested scope.
+func (t *OpenCodeTarget) InstallWithScope(svc service.Service, resolvedEnv map[string]string, scope ConfigScope) error {
 	serviceName := strings.TrimSpace(svc.Name)
 	if serviceName == "" {
 		return errors.New("service name is required")
 	}
 
-	config, _, err := t.readConfig()
+	configPath, err := t.scopeConfigFile(scope)
+	if err != nil {
+		return err
+	}
+
+	config, _, err := readConfigDocument(configPath, jsoncConfig)
 	if err != nil {
 		return err
 	}
@@ -106,19 +149,34 @@ func (t *OpenCodeTarget) Install(svc service.Service, resolvedEnv map[string]str
 		return err
 	}
 
-	mcpDefinitions[serviceName] = serverConfig
+	merged, err := mergeServerEntry(t.merge, mcpDefinitions[serviceName], serverConfig)
+	if err != nil {
+		return err
+	}
 
-	return t.writeConfig(config)
+	mcpDefinitions[serviceName] = merged
+
+	return t.writeConfigFile(configPath, config)
 }
 
-// Uninstall removes a service from the target config.
+// Uninstall removes a service from the user config.
 func (t *OpenCodeTarget) Uninstall(serviceName string) error {
+	return t.UninstallWithScope(serviceName, ConfigScopeUser)
+}
+
+// UninstallWithScope removes a service from the requested scope.
+func (t *OpenCodeTarget) UninstallWithScope(serviceName string, scope ConfigScope) error {
 	trimmedServiceName := strings.TrimSpace(serviceName)
 	if trimmedServiceName == "" {
 		return errors.New("service name is required")
 	}
 
-	config, exists, err := t.readConfig()
+	configPath, err := t.scopeConfigFile(scope)
+	if err != nil {
+		return err
+	}
+
+	config, exists, err := readConfigDocument(configPath, jsoncConfig)
 	if err != nil {
 		return err
 	}
@@ -137,32 +195,56 @@ func (t *OpenCodeTarget) Uninstall(serviceName string) error {
 	}
 
 	delete(mcpDefinitions, trimmedServiceName)
+	if !t.keepEmpty {
+		pruneEmptyObject(config, "mcp")
+	}
 
-	return t.writeConfig(config)
+	return t.writeConfigFile(configPath, config)
 }
 
-// List returns configured service names from the target config.
+// List returns the services OpenCode loads from the user and project configs.
 func (t *OpenCodeTarget) List() ([]string, error) {
-	config, exists, err := t.readConfig()
-	if err != nil {
-		return nil, err
-	}
+	return t.ListWithScope(ConfigScopeEffective)
+}
 
-	if !exists {
-		return []string{}, nil
+// ListWithScope returns configured service names from the requested scope.
+func (t *OpenCodeTarget) ListWithScope(scope ConfigScope) ([]string, error) {
+	var scopes []ConfigScope
+	switch scope {
+	case ConfigScopeUser, ConfigScopeProject:
+		scopes = []ConfigScope{scope}
+	case ConfigScopeEffective:
+		scopes = t.configScopes()
+	default:
+		return nil, fmt.Errorf("unsupported scope %q", scope)
 	}
 
-	mcpDefinitions, err := getOpenCodeMCPEntries(config, false)
-	if err != nil {
-		return nil, err
-	}
+	serviceNames := make(map[string]struct{})
+	for _, listScope := range scopes {
+		configPath, err := t.scopeConfigFile(listScope)
+		if err != nil {
+			return nil, err
+		}
 
-	if mcpDefinitions == nil {
-		return []string{}, nil
+		config, _, err := readConfigDocument(configPath, jsoncConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		mcpDefinitions, err := getOpenCodeMCPEntries(config, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for serviceName := range mcpDefinitions {
+			if trimmedName := strings.TrimSpace(serviceName); trimmedName != "" {
+				serviceNames[trimmedName] = struct{}{}
+			}
+		}
 	}
 
-	services := make([]string, 0, len(mcpDefinitions))
-	for serviceName := range mcpDefinitions {
+	services := make([]string, 0, len(serviceNames))
+	for serviceName := range serviceNames {
 		services = append(services, serviceName)
 	}
 
@@ -171,6 +253,26 @@ func (t *OpenCodeTarget) List() ([]string, error) {
 	return services, nil
 }
 
+// HasProjectEntry reports whether the project for the current directory
+// already has an OpenCode project config.
+func (t *OpenCodeTarget) HasProjectEntry() (bool, error) {
+	configPath, err := t.projectConfigPath()
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(configPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return !info.IsDir(), nil
+}
+
 // Authenticate runs OpenCode OAuth auth for a configured MCP server.
 func (t *OpenCodeTarget) Authenticate(serviceName string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
 	trimmedServiceName := strings.TrimSpace(serviceName)
@@ -206,79 +308,77 @@ func (t *OpenCodeTarget) Authenticate(serviceName string, stdin io.Reader, stdou
 	return nil
 }
 
-func (t *OpenCodeTarget) readConfig() (map[string]any, bool, error) {
-	data, err := os.ReadFile(t.configPath)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return map[string]any{}, false, nil
-		}
-
-		return nil, false, fmt.Errorf("read config file %q: %w", t.configPath, err)
-	}
-
-	config := map[string]any{}
-	if len(bytes.TrimSpace(data)) == 0 {
-		return config, true, nil
-	}
-
-	// OpenCode supports both JSON and JSONC-style content (comments/trailing commas)
-	// in user config files, including files named with a .json extension.
-	data = jsonc.ToJSON(data)
-
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, true, fmt.Errorf("parse config file %q: %w", t.configPath, err)
-	}
+// CheckConfig reads and parses the config file without modifying it.
+func (t *OpenCodeTarget) CheckConfig() error {
+	_, _, err := t.readConfig()
+	return err
+}
 
-	return config, true, nil
+// readConfig reads the config as JSONC: OpenCode accepts comments and
+// trailing commas in user config files, including files named with a .json
+// extension.
+func (t *OpenCodeTarget) readConfig() (map[string]any, bool, error) {
+	return readConfigDocument(t.configPath, jsoncConfig)
 }
 
 func (t *OpenCodeTarget) writeConfig(config map[string]any) error {
-	configDir := filepath.Dir(t.configPath)
-	if err := os.MkdirAll(configDir, 0o755); err != nil {
-		return fmt.Errorf("create config directory %q: %w", configDir, err)
-	}
-
-	data, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		return fmt.Errorf("serialize config file %q: %w", t.configPath, err)
-	}
-
-	data = append(data, '\n')
-
-	if err := os.WriteFile(t.configPath, data, 0o600); err != nil {
-		return fmt.Errorf("write config file %q: %w", t.configPath, err)
-	}
+	return t.writeConfigFile(t.configPath, config)
+}
 
-	return nil
+func (t *OpenCodeTarget) writeConfigFile(configPath string, config map[string]any) error {
+	return writeConfigDocument(configPath, jsoncConfig, config, 0o600, t.preview, t.backup)
 }
 
-func defaultOpenCodeConfigPath() string {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return filepath.Join(".config", "opencode", "opencode.json")
-	}
+// ScopedConfigPath returns the config file that holds scope: the global
+// config, or the opencode.json of the project.
+func (t *OpenCodeTarget) ScopedConfigPath(scope ConfigScope) (string, error) {
+	return t.scopeConfigFile(scope)
+}
 
-	configDir := filepath.Join(homeDir, ".config", "opencode")
-	candidates := []string{
-		filepath.Join(configDir, "opencode.json"),
-		filepath.Join(configDir, "opencode.jsonc"),
-		filepath.Join(configDir, "config.json"),
+// scopeConfigFile returns the config file that holds the given scope.
+func (t *OpenCodeTarget) scopeConfigFile(scope ConfigScope) (string, error) {
+	switch scope {
+	case ConfigScopeUser:
+		return t.configPath, nil
+	case ConfigScopeProject:
+		return t.projectConfigPath()
+	default:
+		return "", fmt.Errorf("unsupported scope %q", scope)
 	}
+}
 
-	for _, candidatePath := range candidates {
-		info, err := os.Stat(candidatePath)
+// projectConfigPath returns the project config for the pinned project
+// directory, or else for the nearest directory at or above the working
+// directory that already has one, falling back to opencode.json in the
+// working directory. An existing opencode.jsonc is used when there is no
+// opencode.json next to it.
+func (t *OpenCodeTarget) projectConfigPath() (string, error) {
+	projectDir := t.projectDir
+	if projectDir == "" {
+		cwd, err := os.Getwd()
 		if err != nil {
-			continue
+			return "", fmt.Errorf("resolve current working directory: %w", err)
 		}
 
-		if info.IsDir() {
-			continue
+		found := false
+		projectDir, found = project.FindUp(cwd, openCodeProjectConfigNames...)
+		if !found {
+			projectDir = cwd
 		}
+	}
 
-		return candidatePath
+	for _, name := range openCodeProjectConfigNames {
+		configPath := filepath.Join(projectDir, name)
+		if _, err := os.Stat(configPath); err == nil {
+			return configPath, nil
+		}
 	}
 
-	return candidates[0]
+	return filepath.Join(projectDir, openCodeProjectConfigNames[0]), nil
+}
+
+func defaultOpenCodeConfigPath() string {
+	return chooseConfigCandidate(openCodeConfigCandidates())
 }
 
 func defaultOpenCodeFallbackBinaryPaths() []string {
@@ -399,10 +499,14 @@ func buildOpenCodeServerConfig(svc service.Service, resolvedEnv map[string]strin
 		if len(environment) > 0 {
 			serverConfig["environment"] = environment
 		}
+	case "websocket":
+		return nil, errors.New("websocket transport is not supported by OpenCode")
 	default:
 		return nil, fmt.Errorf("unsupported transport %q", svc.Transport)
 	}
 
+	applyServiceExtra(serverConfig, svc)
+
 	return serverConfig, nil
 }
 
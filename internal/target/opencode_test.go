@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 
@@ -404,6 +405,112 @@ func TestOpenCodeTargetMethodsErrorWhenMCPIsNotObject(t *testing.T) {
 	}
 }
 
+func TestOpenCodeTargetProjectScopeWritesProjectConfig(t *testing.T) {
+	target := newTestOpenCodeTarget(t)
+	projectDir := t.TempDir()
+	target.SetProjectDir(projectDir)
+
+	svc := service.Service{Name: "docs", Transport: "sse", URL: "https://docs.example.com/sse"}
+	if err := target.InstallWithScope(svc, nil, ConfigScopeProject); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	config := readOpenCodeConfigFile(t, filepath.Join(projectDir, "opencode.json"))
+	if _, ok := mustMapValue(t, config["mcp"], "mcp")["docs"]; !ok {
+		t.Fatalf("expected docs in the project config, got %#v", config)
+	}
+
+	if path, err := target.ScopedConfigPath(ConfigScopeProject); err != nil || path != filepath.Join(projectDir, "opencode.json") {
+		t.Fatalf("expected the project config path, got %q (err %v)", path, err)
+	}
+
+	if _, err := os.Stat(target.configPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the user config to be untouched, got %v", err)
+	}
+
+	if err := target.Install(service.Service{Name: "user-svc", Transport: "sse", URL: "https://user.example.com/sse"}, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	names, err := target.List()
+	if err != nil || !slices.Equal(names, []string{"docs", "user-svc"}) {
+		t.Fatalf("expected merged list, got %v (err %v)", names, err)
+	}
+
+	names, err = target.ListWithScope(ConfigScopeUser)
+	if err != nil || !slices.Equal(names, []string{"user-svc"}) {
+		t.Fatalf("expected only the user service, got %v (err %v)", names, err)
+	}
+
+	found, err := target.HasProjectEntry()
+	if err != nil || !found {
+		t.Fatalf("expected the project config to count as a project entry, got %v (err %v)", found, err)
+	}
+
+	if err := target.UninstallWithScope("docs", ConfigScopeProject); err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	names, err = target.ListWithScope(ConfigScopeProject)
+	if err != nil || len(names) != 0 {
+		t.Fatalf("expected no project services, got %v (err %v)", names, err)
+	}
+}
+
+func TestOpenCodeTargetProjectScopeUsesNearestExistingConfig(t *testing.T) {
+	projectDir := t.TempDir()
+	nestedDir := filepath.Join(projectDir, "packages", "api")
+	if err := os.MkdirAll(nestedDir, 0o755); err != nil {
+		t.Fatalf("failed to create nested directory: %v", err)
+	}
+
+	jsoncPath := filepath.Join(projectDir, "opencode.jsonc")
+	if err := os.WriteFile(jsoncPath, []byte("{\n  // team servers\n  \"mcp\": {},\n}\n"), 0o600); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	setWorkingDirectory(t, nestedDir)
+	target := newTestOpenCodeTarget(t)
+
+	svc := service.Service{Name: "docs", Transport: "sse", URL: "https://docs.example.com/sse"}
+	if err := target.InstallWithScope(svc, nil, ConfigScopeProject); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	data, err := os.ReadFile(jsoncPath)
+	if err != nil || !strings.Contains(string(data), "// team servers") || !strings.Contains(string(data), `"docs"`) {
+		t.Fatalf("expected docs in the existing JSONC config, got %q (err %v)", data, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(nestedDir, "opencode.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("expected no project config to be created in the nested directory")
+	}
+}
+
+func TestOpenCodeTargetExplainServicePrefersProject(t *testing.T) {
+	target := newTestOpenCodeTarget(t)
+	target.SetProjectDir(t.TempDir())
+
+	svc := service.Service{Name: "docs", Transport: "sse", URL: "https://docs.example.com/sse"}
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if err := target.InstallWithScope(svc, nil, ConfigScopeProject); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	layers, err := target.ExplainService("docs")
+	if err != nil {
+		t.Fatalf("expected explain to succeed: %v", err)
+	}
+
+	effective, ok := EffectiveLayer(layers)
+	if !ok || effective.Scope != ConfigScopeProject || len(layers) != 2 {
+		t.Fatalf("expected the project layer to be effective, got %#v", layers)
+	}
+}
+
 func TestOpenCodeTargetCanReadJSONCConfig(t *testing.T) {
 	target := newTestOpenCodeTarget(t)
 	target.configPath = filepath.Join(t.TempDir(), ".config", "opencode", "opencode.jsonc")
@@ -0,0 +1,171 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigCandidate is one location a tool has kept its config file in.
+type ConfigCandidate struct {
+	Path  string
+	Label string // which releases or editions of the tool read this location
+}
+
+// ConfigLocator is an optional interface for targets whose tool has moved
+// its config file between versions. Candidates are ordered newest first; the
+// first one that exists is used, or the first one when none exists yet.
+type ConfigLocator interface {
+	ConfigPathProvider
+
+	// ConfigCandidates returns the known config locations, newest first.
+	ConfigCandidates() []ConfigCandidate
+
+	// SetConfigPath replaces the detected config path, for tools installed
+	// in a location mcp-wire does not know about.
+	SetConfigPath(path string)
+}
+
+// InstallMarker is an optional interface for targets whose tool can be
+// installed somewhere mcp-wire does not look, such as a binary outside PATH
+// and the usual install locations. A marked target reports itself as
+// installed whatever detection finds.
+type InstallMarker interface {
+	MarkInstalled()
+}
+
+// ConfigCandidates returns where Claude Code has kept its config file.
+func (t *ClaudeCodeTarget) ConfigCandidates() []ConfigCandidate {
+	return claudeCodeConfigCandidates()
+}
+
+// SetConfigPath replaces the detected Claude Code config path.
+func (t *ClaudeCodeTarget) SetConfigPath(path string) {
+	t.configPath = strings.TrimSpace(path)
+}
+
+// MarkInstalled makes Claude Code count as installed when it was not detected.
+func (t *ClaudeCodeTarget) MarkInstalled() {
+	t.markedInstalled = true
+}
+
+// ConfigCandidates returns where Codex CLI keeps its config file.
+func (t *CodexTarget) ConfigCandidates() []ConfigCandidate {
+	return codexConfigCandidates()
+}
+
+// SetConfigPath replaces the detected Codex CLI config path.
+func (t *CodexTarget) SetConfigPath(path string) {
+	t.configPath = strings.TrimSpace(path)
+}
+
+// MarkInstalled makes Codex CLI count as installed when it was not detected.
+func (t *CodexTarget) MarkInstalled() {
+	t.markedInstalled = true
+}
+
+// ConfigCandidates returns where OpenCode has kept its config file.
+func (t *OpenCodeTarget) ConfigCandidates() []ConfigCandidate {
+	return openCodeConfigCandidates()
+}
+
+// SetConfigPath replaces the detected OpenCode config path.
+func (t *OpenCodeTarget) SetConfigPath(path string) {
+	t.configPath = strings.TrimSpace(path)
+}
+
+// MarkInstalled makes OpenCode count as installed when it was not detected.
+func (t *OpenCodeTarget) MarkInstalled() {
+	t.markedInstalled = true
+}
+
+// ConfigCandidates returns the user settings files of the VS Code editions.
+func (t *VSCodeTarget) ConfigCandidates() []ConfigCandidate {
+	if t.wslDistro != "" {
+		return windowsVSCodeConfigCandidates(t.windowsProfile)
+	}
+
+	return vsCodeConfigCandidates()
+}
+
+// SetConfigPath replaces the detected VS Code user settings path.
+func (t *VSCodeTarget) SetConfigPath(path string) {
+	t.configPath = strings.TrimSpace(path)
+}
+
+// MarkInstalled makes VS Code count as installed when it was not detected.
+func (t *VSCodeTarget) MarkInstalled() {
+	t.markedInstalled = true
+}
+
+// chooseConfigCandidate returns the first candidate that exists as a file,
+// or the first candidate when none does.
+func chooseConfigCandidate(candidates []ConfigCandidate) string {
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate.Path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		return candidate.Path
+	}
+
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	return candidates[0].Path
+}
+
+func claudeCodeConfigCandidates() []ConfigCandidate {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return []ConfigCandidate{{Path: ".claude.json", Label: "current releases"}}
+	}
+
+	return []ConfigCandidate{
+		{Path: filepath.Join(homeDir, ".claude.json"), Label: "current releases"},
+		{Path: filepath.Join(homeDir, ".claude", "settings.json"), Label: "early releases"},
+	}
+}
+
+// codexConfigCandidates returns config.toml in CODEX_HOME when it is set,
+// since Codex CLI then reads nothing else, or in ~/.codex otherwise.
+func codexConfigCandidates() []ConfigCandidate {
+	if codexHome := strings.TrimSpace(os.Getenv("CODEX_HOME")); codexHome != "" {
+		return []ConfigCandidate{{Path: filepath.Join(codexHome, "config.toml"), Label: "CODEX_HOME is set"}}
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return []ConfigCandidate{{Path: filepath.Join(".codex", "config.toml"), Label: "current releases"}}
+	}
+
+	return []ConfigCandidate{{Path: filepath.Join(homeDir, ".codex", "config.toml"), Label: "current releases"}}
+}
+
+func openCodeConfigCandidates() []ConfigCandidate {
+	configDir := filepath.Join(".config", "opencode")
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		configDir = filepath.Join(homeDir, ".config", "opencode")
+	}
+
+	return []ConfigCandidate{
+		{Path: filepath.Join(configDir, "opencode.json"), Label: "current releases"},
+		{Path: filepath.Join(configDir, "opencode.jsonc"), Label: "current releases, JSONC"},
+		{Path: filepath.Join(configDir, "config.json"), Label: "releases before opencode.json"},
+	}
+}
+
+func vsCodeConfigCandidates() []ConfigCandidate {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = ".config"
+	}
+
+	return []ConfigCandidate{
+		{Path: filepath.Join(configDir, "Code", "User", "settings.json"), Label: "VS Code"},
+		{Path: filepath.Join(configDir, "Code - Insiders", "User", "settings.json"), Label: "VS Code Insiders"},
+		{Path: filepath.Join(configDir, "VSCodium", "User", "settings.json"), Label: "VSCodium"},
+	}
+}
@@ -0,0 +1,78 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChooseConfigCandidatePrefersFirstExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	candidates := []ConfigCandidate{
+		{Path: filepath.Join(dir, "new.json"), Label: "current releases"},
+		{Path: filepath.Join(dir, "old.json"), Label: "early releases"},
+	}
+
+	if got := chooseConfigCandidate(candidates); got != candidates[0].Path {
+		t.Fatalf("expected newest candidate when none exists, got %q", got)
+	}
+
+	if err := os.WriteFile(candidates[1].Path, []byte(`{}`), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	if got := chooseConfigCandidate(candidates); got != candidates[1].Path {
+		t.Fatalf("expected existing older candidate, got %q", got)
+	}
+
+	// A directory at a candidate path is not a config file.
+	if err := os.Mkdir(candidates[0].Path, 0o755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	if got := chooseConfigCandidate(candidates); got != candidates[1].Path {
+		t.Fatalf("expected directories to be skipped, got %q", got)
+	}
+}
+
+func TestCodexConfigCandidatesUseCodexHome(t *testing.T) {
+	codexHome := t.TempDir()
+	t.Setenv("CODEX_HOME", codexHome)
+
+	candidates := codexConfigCandidates()
+	if len(candidates) != 1 || candidates[0].Path != filepath.Join(codexHome, "config.toml") {
+		t.Fatalf("expected only the CODEX_HOME config, got %#v", candidates)
+	}
+}
+
+func TestSetConfigPathOverridesDetectedPath(t *testing.T) {
+	overridePath := filepath.Join(t.TempDir(), "settings.json")
+
+	for _, locator := range []ConfigLocator{NewClaudeCodeTarget(), NewCodexTarget(), NewOpenCodeTarget(), NewVSCodeTarget()} {
+		locator.SetConfigPath(overridePath)
+		if locator.ConfigPath() != overridePath {
+			t.Fatalf("expected override path, got %q", locator.ConfigPath())
+		}
+	}
+}
+
+func TestMarkInstalledOverridesDetection(t *testing.T) {
+	notFound := func(string) (string, error) { return "", os.ErrNotExist }
+	targets := []Target{
+		&ClaudeCodeTarget{lookPath: notFound, statPath: os.Stat},
+		&CodexTarget{lookPath: notFound},
+		&OpenCodeTarget{lookPath: notFound, statPath: os.Stat},
+		&VSCodeTarget{lookPath: notFound, statPath: os.Stat},
+	}
+
+	for _, target := range targets {
+		if target.IsInstalled() {
+			t.Fatalf("expected %s not to be detected", target.Slug())
+		}
+
+		target.(InstallMarker).MarkInstalled()
+		if !target.IsInstalled() {
+			t.Fatalf("expected marked %s to be installed", target.Slug())
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package target
+
+// ConfigPreviewer is an optional interface for targets that can compute a
+// config change without making it. While a preview func is set, every config
+// file the target would write is handed to it with its new content instead
+// of reaching the disk, and no backup is taken. Pass nil to write again.
+type ConfigPreviewer interface {
+	SetConfigPreview(preview func(configPath string, data []byte))
+}
+
+// SetConfigPreview sets the func that receives config writes instead of the
+// disk.
+func (t *ClaudeCodeTarget) SetConfigPreview(preview func(configPath string, data []byte)) {
+	t.preview = preview
+}
+
+// SetConfigPreview sets the func that receives config writes instead of the
+// disk.
+func (t *CodexTarget) SetConfigPreview(preview func(configPath string, data []byte)) {
+	t.preview = preview
+}
+
+// SetConfigPreview sets the func that receives config writes instead of the
+// disk.
+func (t *OpenCodeTarget) SetConfigPreview(preview func(configPath string, data []byte)) {
+	t.preview = preview
+}
+
+// SetConfigPreview sets the func that receives config writes instead of the
+// disk.
+func (t *VSCodeTarget) SetConfigPreview(preview func(configPath string, data []byte)) {
+	t.preview = preview
+}
+
+// previewConfigFile hands data to preview, when one is set, and reports
+// whether it did. The caller must then leave configPath alone.
+func previewConfigFile(preview func(configPath string, data []byte), configPath string, data []byte) bool {
+	if preview == nil {
+		return false
+	}
+
+	preview(configPath, data)
+	return true
+}
@@ -0,0 +1,60 @@
+package target
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func TestConfigPreviewReceivesWritesInsteadOfDisk(t *testing.T) {
+	dir := t.TempDir()
+	targets := []Target{
+		&ClaudeCodeTarget{configPath: filepath.Join(dir, ".claude.json")},
+		&CodexTarget{configPath: filepath.Join(dir, "config.toml")},
+		&OpenCodeTarget{configPath: filepath.Join(dir, "opencode.json")},
+		&VSCodeTarget{configPath: filepath.Join(dir, "settings.json")},
+	}
+	svc := service.Service{Name: "demo", Transport: "sse", URL: "https://example.com/sse"}
+
+	for _, target := range targets {
+		previewed := map[string]string{}
+		var backedUp []string
+		target.(ConfigBackupSetter).SetConfigBackup(func(configPath string) error {
+			backedUp = append(backedUp, configPath)
+			return nil
+		})
+		target.(ConfigPreviewer).SetConfigPreview(func(configPath string, data []byte) {
+			previewed[configPath] = string(data)
+		})
+
+		if err := target.Install(svc, nil); err != nil {
+			t.Fatalf("expected %s preview install to succeed: %v", target.Slug(), err)
+		}
+
+		configPath := target.(ConfigPathProvider).ConfigPath()
+		if !strings.Contains(previewed[configPath], "https://example.com/sse") {
+			t.Fatalf("expected %s to preview the new entry in %s, got %v", target.Slug(), configPath, previewed)
+		}
+
+		if _, err := os.Stat(configPath); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected %s preview to leave %s unwritten", target.Slug(), configPath)
+		}
+
+		if len(backedUp) != 0 {
+			t.Fatalf("expected %s preview to take no backups, got %v", target.Slug(), backedUp)
+		}
+
+		target.(ConfigPreviewer).SetConfigPreview(nil)
+		if err := target.Install(svc, nil); err != nil {
+			t.Fatalf("expected %s install to succeed: %v", target.Slug(), err)
+		}
+
+		if _, err := os.Stat(configPath); err != nil {
+			t.Fatalf("expected %s to write once the preview is cleared: %v", target.Slug(), err)
+		}
+	}
+}
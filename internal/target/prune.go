@@ -0,0 +1,70 @@
+package target
+
+// EmptyPruneSetter is an optional interface for targets that remove
+// structures an uninstall leaves empty, such as an empty mcpServers object
+// or a project entry that only ever held MCP servers. Pruning is on by
+// default; the CLI turns it off when the user asks to keep them.
+type EmptyPruneSetter interface {
+	SetPruneEmpty(enabled bool)
+}
+
+// SetPruneEmpty controls whether uninstall prunes empty structures.
+func (t *ClaudeCodeTarget) SetPruneEmpty(enabled bool) {
+	t.keepEmpty = !enabled
+}
+
+// SetPruneEmpty controls whether uninstall prunes empty structures.
+func (t *CodexTarget) SetPruneEmpty(enabled bool) {
+	t.keepEmpty = !enabled
+}
+
+// SetPruneEmpty controls whether uninstall prunes empty structures.
+func (t *OpenCodeTarget) SetPruneEmpty(enabled bool) {
+	t.keepEmpty = !enabled
+}
+
+// SetPruneEmpty controls whether uninstall prunes empty structures.
+func (t *VSCodeTarget) SetPruneEmpty(enabled bool) {
+	t.keepEmpty = !enabled
+}
+
+// pruneEmptyObject deletes parent[key] when it holds an empty object and
+// reports whether it did.
+func pruneEmptyObject(parent map[string]any, key string) bool {
+	value, ok := parent[key].(map[string]any)
+	if !ok || len(value) > 0 {
+		return false
+	}
+
+	delete(parent, key)
+	return true
+}
+
+// pruneClaudeMCPServers removes the mcpServers object for scope when it is
+// empty. For project scope it then removes the project entry if nothing else
+// is left in it, and the projects object if that was the last entry.
+func (t *ClaudeCodeTarget) pruneClaudeMCPServers(config map[string]any, scope ConfigScope) {
+	if scope != ConfigScopeProject {
+		pruneEmptyObject(config, "mcpServers")
+		return
+	}
+
+	projects, ok := config["projects"].(map[string]any)
+	if !ok {
+		return
+	}
+
+	projectKey, err := resolveClaudeProjectKey(projects, t.projectDir, false)
+	if err != nil || projectKey == "" {
+		return
+	}
+
+	projectConfig, ok, err := claudeProjectConfig(projects, projectKey)
+	if err != nil || !ok || !pruneEmptyObject(projectConfig, "mcpServers") {
+		return
+	}
+
+	if pruneEmptyObject(projects, projectKey) {
+		pruneEmptyObject(config, "projects")
+	}
+}
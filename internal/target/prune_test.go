@@ -0,0 +1,195 @@
+package target
+
+import "testing"
+
+func TestClaudeCodeTargetUninstallPrunesEmptyProjectEntry(t *testing.T) {
+	projectRoot := t.TempDir()
+	otherProject := t.TempDir()
+	setWorkingDirectory(t, projectRoot)
+
+	target := newTestClaudeCodeTarget(t)
+
+	writeTargetConfigFile(t, target.configPath, map[string]any{
+		"projects": map[string]any{
+			projectRoot: map[string]any{
+				"mcpServers": map[string]any{
+					"service-a": map[string]any{"type": "sse", "url": "https://a.example.com"},
+				},
+			},
+			otherProject: map[string]any{"allowedTools": []any{"Bash"}},
+		},
+	})
+
+	if err := target.UninstallWithScope("service-a", ConfigScopeProject); err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	updatedConfig := readTargetConfigFile(t, target.configPath)
+	projects := mustMapValue(t, updatedConfig["projects"], "projects")
+
+	if _, ok := projects[projectRoot]; ok {
+		t.Fatalf("expected empty project entry to be pruned, got %#v", projects)
+	}
+
+	if _, ok := projects[otherProject]; !ok {
+		t.Fatal("expected unrelated project entry to remain")
+	}
+}
+
+func TestClaudeCodeTargetUninstallKeepsProjectEntryWithOtherSettings(t *testing.T) {
+	projectRoot := t.TempDir()
+	setWorkingDirectory(t, projectRoot)
+
+	target := newTestClaudeCodeTarget(t)
+
+	writeTargetConfigFile(t, target.configPath, map[string]any{
+		"projects": map[string]any{
+			projectRoot: map[string]any{
+				"allowedTools": []any{"Bash"},
+				"mcpServers": map[string]any{
+					"service-a": map[string]any{"type": "sse", "url": "https://a.example.com"},
+				},
+			},
+		},
+	})
+
+	if err := target.UninstallWithScope("service-a", ConfigScopeProject); err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	updatedConfig := readTargetConfigFile(t, target.configPath)
+	projects := mustMapValue(t, updatedConfig["projects"], "projects")
+	projectConfig := mustMapValue(t, projects[projectRoot], "projects.<cwd>")
+
+	if _, ok := projectConfig["mcpServers"]; ok {
+		t.Fatal("expected empty project mcpServers to be pruned")
+	}
+
+	if _, ok := projectConfig["allowedTools"]; !ok {
+		t.Fatal("expected other project settings to remain")
+	}
+}
+
+func TestClaudeCodeTargetUninstallPrunesLastProjectEntry(t *testing.T) {
+	projectRoot := t.TempDir()
+	setWorkingDirectory(t, projectRoot)
+
+	target := newTestClaudeCodeTarget(t)
+
+	writeTargetConfigFile(t, target.configPath, map[string]any{
+		"numStartups": float64(3),
+		"projects": map[string]any{
+			projectRoot: map[string]any{
+				"mcpServers": map[string]any{
+					"service-a": map[string]any{"type": "sse", "url": "https://a.example.com"},
+				},
+			},
+		},
+	})
+
+	if err := target.UninstallWithScope("service-a", ConfigScopeProject); err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	updatedConfig := readTargetConfigFile(t, target.configPath)
+	if _, ok := updatedConfig["projects"]; ok {
+		t.Fatalf("expected empty projects object to be pruned, got %#v", updatedConfig)
+	}
+
+	if updatedConfig["numStartups"] != float64(3) {
+		t.Fatal("expected unrelated top-level keys to remain")
+	}
+}
+
+func TestClaudeCodeTargetUninstallPrunesEmptyUserServers(t *testing.T) {
+	target := newTestClaudeCodeTarget(t)
+
+	writeTargetConfigFile(t, target.configPath, map[string]any{
+		"theme": "dark",
+		"mcpServers": map[string]any{
+			"service-a": map[string]any{"type": "sse", "url": "https://a.example.com"},
+		},
+	})
+
+	if err := target.Uninstall("service-a"); err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	updatedConfig := readTargetConfigFile(t, target.configPath)
+	if _, ok := updatedConfig["mcpServers"]; ok {
+		t.Fatal("expected empty mcpServers to be pruned")
+	}
+
+	if updatedConfig["theme"] != "dark" {
+		t.Fatal("expected unrelated keys to remain")
+	}
+}
+
+func TestClaudeCodeTargetUninstallKeepsEmptyStructuresWhenPruningDisabled(t *testing.T) {
+	target := newTestClaudeCodeTarget(t)
+	target.SetPruneEmpty(false)
+
+	writeTargetConfigFile(t, target.configPath, map[string]any{
+		"mcpServers": map[string]any{
+			"service-a": map[string]any{"type": "sse", "url": "https://a.example.com"},
+		},
+	})
+
+	if err := target.Uninstall("service-a"); err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	updatedConfig := readTargetConfigFile(t, target.configPath)
+	mcpServers := mustMapValue(t, updatedConfig["mcpServers"], "mcpServers")
+	if len(mcpServers) != 0 {
+		t.Fatalf("expected empty mcpServers to be kept, got %#v", mcpServers)
+	}
+}
+
+func TestCodexTargetUninstallPrunesEmptyServers(t *testing.T) {
+	target := newTestCodexTarget(t)
+
+	writeCodexConfigFile(t, target.configPath, map[string]any{
+		"model": "o3",
+		"mcp_servers": map[string]any{
+			"service-a": map[string]any{"url": "https://a.example.com/mcp"},
+		},
+	})
+
+	if err := target.Uninstall("service-a"); err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	updatedConfig := readCodexConfigFile(t, target.configPath)
+	if _, ok := updatedConfig["mcp_servers"]; ok {
+		t.Fatal("expected empty mcp_servers table to be pruned")
+	}
+
+	if updatedConfig["model"] != "o3" {
+		t.Fatal("expected unrelated keys to remain")
+	}
+}
+
+func TestOpenCodeTargetUninstallPrunesEmptyEntries(t *testing.T) {
+	target := newTestOpenCodeTarget(t)
+
+	writeOpenCodeConfigFile(t, target.configPath, map[string]any{
+		"theme": "opencode",
+		"mcp": map[string]any{
+			"service-a": map[string]any{"type": "remote", "url": "https://a.example.com/mcp"},
+		},
+	})
+
+	if err := target.Uninstall("service-a"); err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	updatedConfig := readOpenCodeConfigFile(t, target.configPath)
+	if _, ok := updatedConfig["mcp"]; ok {
+		t.Fatal("expected empty mcp object to be pruned")
+	}
+
+	if updatedConfig["theme"] != "opencode" {
+		t.Fatal("expected unrelated keys to remain")
+	}
+}
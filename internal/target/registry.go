@@ -2,11 +2,12 @@ package target
 
 import "strings"
 
-var knownTargets = []Target{
+var knownTargets = append([]Target{
 	NewClaudeCodeTarget(),
 	NewCodexTarget(),
 	NewOpenCodeTarget(),
-}
+	NewVSCodeTarget(),
+}, wslTargets()...)
 
 // AllTargets returns all known targets.
 func AllTargets() []Target {
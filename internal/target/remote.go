@@ -0,0 +1,48 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// vsCodeServerDirNames are the data directories VS Code Server creates in
+// the home directory of a remote machine, dev container or Codespace it is
+// attached to, in the order they are preferred.
+var vsCodeServerDirNames = []string{".vscode-server", ".vscode-server-insiders", ".vscode-remote"}
+
+// remoteSettingsPath returns the machine settings file VS Code Server reads
+// when VS Code attaches to this machine or container.
+func (t *VSCodeTarget) remoteSettingsPath() string {
+	return filepath.Join(t.remoteServerDir, "data", "Machine", "settings.json")
+}
+
+// hasRemoteSettings reports whether VS Code has attached to this machine
+// before, so that servers written to its machine settings are picked up by
+// the remote side. The Windows-side target never has remote settings.
+func (t *VSCodeTarget) hasRemoteSettings() bool {
+	if t.wslDistro != "" || t.remoteServerDir == "" || t.statPath == nil {
+		return false
+	}
+
+	info, err := t.statPath(t.remoteServerDir)
+	return err == nil && info.IsDir()
+}
+
+// defaultVSCodeRemoteServerDir returns the first VS Code Server data
+// directory that exists in the home directory, or the stable one when none
+// does.
+func defaultVSCodeRemoteServerDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	for _, name := range vsCodeServerDirNames {
+		dir := filepath.Join(homeDir, name)
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+
+	return filepath.Join(homeDir, vsCodeServerDirNames[0])
+}
@@ -12,6 +12,8 @@ type ConfigScope string
 const (
 	ConfigScopeUser      ConfigScope = "user"
 	ConfigScopeProject   ConfigScope = "project"
+	ConfigScopeSystem    ConfigScope = "system"
+	ConfigScopeRemote    ConfigScope = "remote"
 	ConfigScopeEffective ConfigScope = "effective"
 )
 
@@ -46,6 +48,27 @@ type ScopedTarget interface {
 	ListWithScope(scope ConfigScope) ([]string, error)
 }
 
+// ProjectEntryReporter is an optional interface for scoped targets that keep
+// per-project entries in their own config and can report whether one already
+// covers the current directory.
+type ProjectEntryReporter interface {
+	HasProjectEntry() (bool, error)
+}
+
+// ProjectEntryLister is an optional interface for targets that keep an entry
+// per project directory in their config, so diagnostics can report a config
+// that keeps growing.
+type ProjectEntryLister interface {
+	ProjectEntries() ([]string, error)
+}
+
+// ProjectDirSetter is an optional interface for scoped targets whose project
+// scope is keyed by a directory. The CLI uses it to apply one project root
+// detection strategy to every such target.
+type ProjectDirSetter interface {
+	SetProjectDir(dir string)
+}
+
 // AuthTarget can perform an interactive authentication flow for a configured service.
 type AuthTarget interface {
 	Authenticate(serviceName string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error
@@ -56,3 +79,26 @@ type AuthTarget interface {
 type ConfigPathProvider interface {
 	ConfigPath() string
 }
+
+// SystemConfigPathProvider is an optional interface for targets that keep a
+// machine-wide config file separate from the one reported by ConfigPath.
+type SystemConfigPathProvider interface {
+	SystemConfigPath() string
+}
+
+// ScopedConfigPathProvider is an optional interface for scoped targets that
+// keep some scopes in files of their own, such as workspace settings or a
+// project config inside the repository. ScopedConfigPath returns the file
+// that holds scope.
+type ScopedConfigPathProvider interface {
+	ScopedConfigPath(scope ConfigScope) (string, error)
+}
+
+// ConfigChecker is an optional interface for targets that can read and parse
+// their config file without modifying it, so diagnostics can report a broken
+// config before an install trips over it.
+type ConfigChecker interface {
+	// CheckConfig returns an error when the config file exists but cannot be
+	// read or parsed. A missing config file is not an error.
+	CheckConfig() error
+}
@@ -0,0 +1,232 @@
+package target
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+// Verifier is an optional interface for targets that can read a service
+// entry back after writing it and confirm it matches what was intended.
+type Verifier interface {
+	// Verify re-reads the target config and returns a *VerificationError
+	// when the entry for svc differs from the one Install would write.
+	Verify(svc service.Service, resolvedEnv map[string]string, scope ConfigScope) error
+}
+
+// VerificationError reports fields of a written service entry that did not
+// survive the round trip through the target config file. Only field paths
+// are reported, never values, because entries may hold credentials.
+type VerificationError struct {
+	Service string
+	Missing bool     // the whole entry could not be read back
+	Dropped []string // fields present in the intended entry but not on disk
+	Changed []string // fields whose value on disk differs from the intended one
+
+	// NotLoaded explains why the tool would not load the entry even though
+	// it was written as intended.
+	NotLoaded string
+}
+
+func (e *VerificationError) Error() string {
+	if e.Missing {
+		return fmt.Sprintf("service %q was not found in the config after writing it", e.Service)
+	}
+
+	if e.NotLoaded != "" {
+		return fmt.Sprintf("service %q was written but would not be loaded: %s", e.Service, e.NotLoaded)
+	}
+
+	parts := make([]string, 0, 2)
+	if len(e.Dropped) > 0 {
+		parts = append(parts, "dropped "+strings.Join(e.Dropped, ", "))
+	}
+
+	if len(e.Changed) > 0 {
+		parts = append(parts, "changed "+strings.Join(e.Changed, ", "))
+	}
+
+	return fmt.Sprintf("config for service %q does not match what was written: %s", e.Service, strings.Join(parts, "; "))
+}
+
+// Verify re-reads the Claude Code config and compares the entry in scope.
+func (t *ClaudeCodeTarget) Verify(svc service.Service, resolvedEnv map[string]string, scope ConfigScope) error {
+	intended, err := buildClaudeCodeServerConfig(svc, resolvedEnv)
+	if err != nil {
+		return err
+	}
+
+	configPath, _ := t.scopeConfigFile(scope)
+	config, _, err := t.readConfigFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	mcpServers, err := t.getMCPServers(config, scope, false)
+	if err != nil {
+		return err
+	}
+
+	return verifyServerConfig(svc.Name, intended, mcpServers)
+}
+
+// Verify re-reads the Codex config and compares the written entry.
+func (t *CodexTarget) Verify(svc service.Service, resolvedEnv map[string]string, _ ConfigScope) error {
+	intended, err := buildCodexServerConfig(svc, resolvedEnv)
+	if err != nil {
+		return err
+	}
+
+	config, _, err := t.readConfig()
+	if err != nil {
+		return err
+	}
+
+	mcpServers, err := t.getMCPServers(config, false)
+	if err != nil {
+		return err
+	}
+
+	return verifyServerConfig(svc.Name, intended, mcpServers)
+}
+
+// Verify re-reads the OpenCode config for scope and compares the written
+// entry.
+func (t *OpenCodeTarget) Verify(svc service.Service, resolvedEnv map[string]string, scope ConfigScope) error {
+	intended, err := buildOpenCodeServerConfig(svc, resolvedEnv)
+	if err != nil {
+		return err
+	}
+
+	if scope != ConfigScopeProject {
+		scope = ConfigScopeUser
+	}
+
+	configPath, err := t.scopeConfigFile(scope)
+	if err != nil {
+		return err
+	}
+
+	config, _, err := readConfigDocument(configPath, jsoncConfig)
+	if err != nil {
+		return err
+	}
+
+	mcpEntries, err := getOpenCodeMCPEntries(config, false)
+	if err != nil {
+		return err
+	}
+
+	return verifyServerConfig(svc.Name, intended, mcpEntries)
+}
+
+// Verify re-reads the VS Code settings for scope and compares the written
+// entry.
+func (t *VSCodeTarget) Verify(svc service.Service, resolvedEnv map[string]string, scope ConfigScope) error {
+	intended, err := t.buildServerConfig(svc, resolvedEnv)
+	if err != nil {
+		return err
+	}
+
+	if scope != ConfigScopeProject && scope != ConfigScopeRemote {
+		scope = ConfigScopeUser
+	}
+
+	configPath, err := t.scopeConfigFile(scope)
+	if err != nil {
+		return err
+	}
+
+	config, _, err := readVSCodeSettings(configPath)
+	if err != nil {
+		return err
+	}
+
+	servers, err := getVSCodeMCPServers(config, false)
+	if err != nil {
+		return err
+	}
+
+	return verifyServerConfig(svc.Name, intended, servers)
+}
+
+// verifyServerConfig compares the intended entry for serviceName with the one
+// found in entries. Keys the target added on its own are ignored.
+func verifyServerConfig(serviceName string, intended map[string]any, entries map[string]any) error {
+	serviceName = strings.TrimSpace(serviceName)
+	verification := &VerificationError{Service: serviceName}
+
+	rawActual, found := entries[serviceName]
+	actual, ok := rawActual.(map[string]any)
+	if !found || !ok {
+		verification.Missing = true
+		return verification
+	}
+
+	// Both sides go through JSON so numbers and nested maps compare the same
+	// regardless of the types the config format decodes them into.
+	canonicalIntended, err := canonicalValue(intended)
+	if err != nil {
+		return fmt.Errorf("verify service %q: %w", serviceName, err)
+	}
+
+	canonicalActual, err := canonicalValue(actual)
+	if err != nil {
+		return fmt.Errorf("verify service %q: %w", serviceName, err)
+	}
+
+	compareConfigValues("", canonicalIntended, canonicalActual, verification)
+	if len(verification.Dropped) == 0 && len(verification.Changed) == 0 {
+		return nil
+	}
+
+	sort.Strings(verification.Dropped)
+	sort.Strings(verification.Changed)
+
+	return verification
+}
+
+func compareConfigValues(path string, intended, actual any, verification *VerificationError) {
+	intendedMap, intendedIsMap := intended.(map[string]any)
+	actualMap, actualIsMap := actual.(map[string]any)
+	if !intendedIsMap || !actualIsMap {
+		if !reflect.DeepEqual(intended, actual) {
+			verification.Changed = append(verification.Changed, path)
+		}
+
+		return
+	}
+
+	for key, intendedValue := range intendedMap {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+
+		actualValue, exists := actualMap[key]
+		if !exists {
+			verification.Dropped = append(verification.Dropped, fieldPath)
+			continue
+		}
+
+		compareConfigValues(fieldPath, intendedValue, actualValue, verification)
+	}
+}
+
+func canonicalValue(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var canonical any
+	if err := json.Unmarshal(data, &canonical); err != nil {
+		return nil, err
+	}
+
+	return canonical, nil
+}
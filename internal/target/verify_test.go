@@ -0,0 +1,115 @@
+package target
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func TestClaudeCodeTargetVerifyPassesAfterInstall(t *testing.T) {
+	target := newTestClaudeCodeTarget(t)
+	svc := service.Service{Name: "demo", Transport: "stdio", Command: "npx", Args: []string{"-y", "demo"}}
+	env := map[string]string{"DEMO_TOKEN": "secret"}
+
+	for _, scope := range []ConfigScope{ConfigScopeUser, ConfigScopeProject} {
+		if err := target.InstallWithScope(svc, env, scope); err != nil {
+			t.Fatalf("install in %s scope: %v", scope, err)
+		}
+
+		if err := target.Verify(svc, env, scope); err != nil {
+			t.Fatalf("expected %s scope entry to verify, got %v", scope, err)
+		}
+	}
+}
+
+func TestOpenCodeTargetVerifyPassesAfterInstallWithNumericExtra(t *testing.T) {
+	target := newTestOpenCodeTarget(t)
+	svc := service.Service{
+		Name:      "demo",
+		Transport: "sse",
+		URL:       "https://example.com/sse",
+		Extra:     map[string]any{"timeout": int64(30), "ratio": 0.5},
+	}
+
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+
+	if err := target.Verify(svc, nil, ConfigScopeUser); err != nil {
+		t.Fatalf("expected entry to verify, got %v", err)
+	}
+}
+
+func TestCodexTargetVerifyFlagsFieldsDroppedByTOML(t *testing.T) {
+	target := newTestCodexTarget(t)
+	svc := service.Service{
+		Name:      "demo",
+		Transport: "stdio",
+		Command:   "npx",
+		Extra:     map[string]any{"startup_timeout_sec": int64(20), "cwd": nil},
+	}
+
+	if err := target.Install(svc, map[string]string{"DEMO_TOKEN": "secret"}); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+
+	err := target.Verify(svc, map[string]string{"DEMO_TOKEN": "secret"}, ConfigScopeUser)
+
+	var verification *VerificationError
+	if !errors.As(err, &verification) {
+		t.Fatalf("expected verification error, got %v", err)
+	}
+
+	if len(verification.Dropped) != 1 || verification.Dropped[0] != "cwd" {
+		t.Fatalf("expected cwd to be reported as dropped, got %#v", verification.Dropped)
+	}
+
+	if len(verification.Changed) != 0 {
+		t.Fatalf("expected no changed fields, got %#v", verification.Changed)
+	}
+}
+
+func TestVerifyReportsChangedFieldsWithoutValues(t *testing.T) {
+	target := newTestClaudeCodeTarget(t)
+	svc := service.Service{Name: "demo", Transport: "stdio", Command: "npx"}
+
+	if err := target.Install(svc, map[string]string{"DEMO_TOKEN": "secret"}); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+
+	err := target.Verify(svc, map[string]string{"DEMO_TOKEN": "rotated"}, ConfigScopeUser)
+	if err == nil {
+		t.Fatal("expected verification to fail")
+	}
+
+	if !strings.Contains(err.Error(), "changed env.DEMO_TOKEN") {
+		t.Fatalf("expected changed field path in error, got %q", err)
+	}
+
+	if strings.Contains(err.Error(), "secret") || strings.Contains(err.Error(), "rotated") {
+		t.Fatalf("expected error to omit values, got %q", err)
+	}
+}
+
+func TestVerifyReportsMissingEntry(t *testing.T) {
+	target := newTestOpenCodeTarget(t)
+	svc := service.Service{Name: "demo", Transport: "sse", URL: "https://example.com/sse"}
+
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+
+	if err := os.Remove(target.configPath); err != nil {
+		t.Fatalf("remove config: %v", err)
+	}
+
+	err := target.Verify(svc, nil, ConfigScopeUser)
+
+	var verification *VerificationError
+	if !errors.As(err, &verification) || !verification.Missing {
+		t.Fatalf("expected missing entry verification error, got %v", err)
+	}
+}
@@ -0,0 +1,519 @@
+package target
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/andreagrandi/mcp-wire/internal/project"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+const (
+	vsCodeBinaryName = "code"
+	vsCodeSlug       = "vscode"
+)
+
+// vsCodeWorkspaceSettings is the workspace settings file, relative to the
+// workspace folder.
+var vsCodeWorkspaceSettings = filepath.Join(".vscode", "settings.json")
+
+// VSCodeTarget manages MCP service configuration for VS Code (GitHub Copilot
+// agent mode), which reads servers from the mcp.servers block of its user
+// and workspace settings.json.
+type VSCodeTarget struct {
+	configPath          string
+	projectDir          string
+	lookPath            func(file string) (string, error)
+	statPath            func(name string) (os.FileInfo, error)
+	binaryNames         []string
+	fallbackBinaryPaths []string
+	keepEmpty           bool
+	markedInstalled     bool
+	backup              func(configPath string) error
+	preview             func(configPath string, data []byte)
+	merge               MergeStrategy
+
+	// remoteServerDir is where VS Code Server keeps its data when VS Code
+	// attaches to this machine or container remotely.
+	remoteServerDir string
+
+	// wslDistro is set for the Windows-side VS Code seen from inside WSL.
+	// Stdio servers are then started through wsl.exe in that distribution,
+	// and only user settings are managed.
+	wslDistro      string
+	windowsProfile string
+}
+
+// NewVSCodeTarget returns a target instance for VS Code.
+func NewVSCodeTarget() *VSCodeTarget {
+	return &VSCodeTarget{
+		configPath:          defaultVSCodeConfigPath(),
+		lookPath:            exec.LookPath,
+		statPath:            os.Stat,
+		binaryNames:         []string{vsCodeBinaryName, "code-insiders"},
+		fallbackBinaryPaths: defaultVSCodeFallbackBinaryPaths(),
+		remoteServerDir:     defaultVSCodeRemoteServerDir(),
+	}
+}
+
+// Name returns the target display name.
+func (t *VSCodeTarget) Name() string {
+	if t.wslDistro != "" {
+		return "VS Code (Windows)"
+	}
+
+	return "VS Code"
+}
+
+// Slug returns the target identifier used in CLI flags.
+func (t *VSCodeTarget) Slug() string {
+	if t.wslDistro != "" {
+		return vsCodeWindowsSlug
+	}
+
+	return vsCodeSlug
+}
+
+// ConfigPath returns the on-disk path of the VS Code user settings file.
+func (t *VSCodeTarget) ConfigPath() string {
+	return t.configPath
+}
+
+// SetProjectDir pins the workspace folder project scope applies to. Without
+// it the working directory is used, or the nearest parent that already has
+// workspace settings.
+func (t *VSCodeTarget) SetProjectDir(dir string) {
+	t.projectDir = strings.TrimSpace(dir)
+}
+
+// IsInstalled reports whether VS Code is available via supported install methods.
+func (t *VSCodeTarget) IsInstalled() bool {
+	if t.markedInstalled {
+		return true
+	}
+
+	// The Windows-side VS Code is only found by its install directory, since
+	// the code shim on the WSL PATH belongs to the remote integration.
+	binaryNames := t.binaryNames
+	if len(binaryNames) == 0 && t.wslDistro == "" {
+		binaryNames = []string{vsCodeBinaryName}
+	}
+
+	for _, binaryName := range binaryNames {
+		if strings.TrimSpace(binaryName) == "" {
+			continue
+		}
+
+		if _, err := t.lookPath(binaryName); err == nil {
+			return true
+		}
+	}
+
+	for _, fallbackPath := range t.fallbackBinaryPaths {
+		if isExecutableFilePath(fallbackPath, t.statPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Install writes or updates the service configuration in the user settings.
+func (t *VSCodeTarget) Install(svc service.Service, resolvedEnv map[string]string) error {
+	return t.InstallWithScope(svc, resolvedEnv, ConfigScopeUser)
+}
+
+// SupportedScopes returns the scopes supported by VS Code target operations.
+// Project scope is the workspace settings file. Remote scope is the machine
+// settings of VS Code Server, offered only where VS Code has attached before.
+func (t *VSCodeTarget) SupportedScopes() []ConfigScope {
+	if t.wslDistro != "" {
+		return []ConfigScope{ConfigScopeUser, ConfigScopeEffective}
+	}
+
+	if t.hasRemoteSettings() {
+		return []ConfigScope{ConfigScopeUser, ConfigScopeProject, ConfigScopeRemote, ConfigScopeEffective}
+	}
+
+	return []ConfigScope{ConfigScopeUser, ConfigScopeProject, ConfigScopeEffective}
+}
+
+// settingsScopes returns the settings files VS Code reads servers from,
+// highest precedence first.
+func (t *VSCodeTarget) settingsScopes() []ConfigScope {
+	if t.wslDistro != "" {
+		return []ConfigScope{ConfigScopeUser}
+	}
+
+	if t.hasRemoteSettings() {
+		return []ConfigScope{ConfigScopeProject, ConfigScopeRemote, ConfigScopeUser}
+	}
+
+	return []ConfigScope{ConfigScopeProject, ConfigScopeUser}
+}
+
+// InstallWithScope writes or updates the service configuration in the requested scope.
+func (t *VSCodeTarget) InstallWithScope(svc service.Service, resolvedEnv map[string]string, scope ConfigScope) error {
+	serviceName := strings.TrimSpace(svc.Name)
+	if serviceName == "" {
+		return errors.New("service name is required")
+	}
+
+	configPath, err := t.scopeConfigFile(scope)
+	if err != nil {
+		return err
+	}
+
+	config, _, err := readVSCodeSettings(configPath)
+	if err != nil {
+		return err
+	}
+
+	servers, err := getVSCodeMCPServers(config, true)
+	if err != nil {
+		return err
+	}
+
+	serverConfig, err := t.buildServerConfig(svc, resolvedEnv)
+	if err != nil {
+		return err
+	}
+
+	merged, err := mergeServerEntry(t.merge, servers[serviceName], serverConfig)
+	if err != nil {
+		return err
+	}
+
+	servers[serviceName] = merged
+
+	return t.writeSettings(configPath, config)
+}
+
+// Uninstall removes a service from the user settings.
+func (t *VSCodeTarget) Uninstall(serviceName string) error {
+	return t.UninstallWithScope(serviceName, ConfigScopeUser)
+}
+
+// UninstallWithScope removes a service from the requested scope.
+func (t *VSCodeTarget) UninstallWithScope(serviceName string, scope ConfigScope) error {
+	trimmedServiceName := strings.TrimSpace(serviceName)
+	if trimmedServiceName == "" {
+		return errors.New("service name is required")
+	}
+
+	configPath, err := t.scopeConfigFile(scope)
+	if err != nil {
+		return err
+	}
+
+	config, exists, err := readVSCodeSettings(configPath)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return nil
+	}
+
+	servers, err := getVSCodeMCPServers(config, false)
+	if err != nil {
+		return err
+	}
+
+	if servers == nil {
+		return nil
+	}
+
+	delete(servers, trimmedServiceName)
+	if !t.keepEmpty {
+		pruneVSCodeMCPServers(config)
+	}
+
+	return t.writeSettings(configPath, config)
+}
+
+// List returns the services VS Code loads from user and workspace settings.
+func (t *VSCodeTarget) List() ([]string, error) {
+	return t.ListWithScope(ConfigScopeEffective)
+}
+
+// ListWithScope returns configured service names from the requested scope.
+func (t *VSCodeTarget) ListWithScope(scope ConfigScope) ([]string, error) {
+	var scopes []ConfigScope
+	switch scope {
+	case ConfigScopeUser, ConfigScopeProject, ConfigScopeRemote:
+		scopes = []ConfigScope{scope}
+	case ConfigScopeEffective:
+		scopes = t.settingsScopes()
+	default:
+		return nil, fmt.Errorf("unsupported scope %q", scope)
+	}
+
+	serviceNames := make(map[string]struct{})
+	for _, listScope := range scopes {
+		configPath, err := t.scopeConfigFile(listScope)
+		if err != nil {
+			return nil, err
+		}
+
+		config, _, err := readVSCodeSettings(configPath)
+		if err != nil {
+			return nil, err
+		}
+
+		servers, err := getVSCodeMCPServers(config, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for serviceName := range servers {
+			if trimmedName := strings.TrimSpace(serviceName); trimmedName != "" {
+				serviceNames[trimmedName] = struct{}{}
+			}
+		}
+	}
+
+	services := make([]string, 0, len(serviceNames))
+	for serviceName := range serviceNames {
+		services = append(services, serviceName)
+	}
+
+	sort.Strings(services)
+
+	return services, nil
+}
+
+// HasProjectEntry reports whether the workspace folder for the current
+// directory already has VS Code workspace settings.
+func (t *VSCodeTarget) HasProjectEntry() (bool, error) {
+	if t.wslDistro != "" {
+		return false, nil
+	}
+
+	workspaceSettings, err := t.workspaceSettingsPath()
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(workspaceSettings)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return !info.IsDir(), nil
+}
+
+// CheckConfig reads and parses the user settings file without modifying it.
+func (t *VSCodeTarget) CheckConfig() error {
+	_, _, err := readVSCodeSettings(t.configPath)
+	return err
+}
+
+// ScopedConfigPath returns the settings file that holds scope: the user,
+// workspace, or VS Code Server machine settings.
+func (t *VSCodeTarget) ScopedConfigPath(scope ConfigScope) (string, error) {
+	return t.scopeConfigFile(scope)
+}
+
+// scopeConfigFile returns the settings file that holds the given scope.
+func (t *VSCodeTarget) scopeConfigFile(scope ConfigScope) (string, error) {
+	switch scope {
+	case ConfigScopeUser:
+		return t.configPath, nil
+	case ConfigScopeProject:
+		if t.wslDistro != "" {
+			return "", fmt.Errorf("unsupported scope %q", scope)
+		}
+
+		return t.workspaceSettingsPath()
+	case ConfigScopeRemote:
+		if !t.hasRemoteSettings() {
+			return "", fmt.Errorf("unsupported scope %q: VS Code Server was not found at %s", scope, t.remoteServerDir)
+		}
+
+		return t.remoteSettingsPath(), nil
+	default:
+		return "", fmt.Errorf("unsupported scope %q", scope)
+	}
+}
+
+// workspaceSettingsPath returns the workspace settings file for the pinned
+// project directory, or else for the nearest directory at or above the
+// working directory that already has one, falling back to the working
+// directory itself.
+func (t *VSCodeTarget) workspaceSettingsPath() (string, error) {
+	if t.projectDir != "" {
+		return filepath.Join(t.projectDir, vsCodeWorkspaceSettings), nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("resolve current working directory: %w", err)
+	}
+
+	workspaceDir, found := project.FindUp(cwd, vsCodeWorkspaceSettings)
+	if !found {
+		workspaceDir = cwd
+	}
+
+	return filepath.Join(workspaceDir, vsCodeWorkspaceSettings), nil
+}
+
+// readVSCodeSettings reads a VS Code settings file. VS Code allows comments
+// and trailing commas in settings.json, so the content is parsed as JSONC.
+func readVSCodeSettings(configPath string) (map[string]any, bool, error) {
+	return readConfigDocument(configPath, jsoncConfig)
+}
+
+func (t *VSCodeTarget) writeSettings(configPath string, config map[string]any) error {
+	return writeConfigDocument(configPath, jsoncConfig, config, 0o600, t.preview, t.backup)
+}
+
+// defaultVSCodeConfigPath returns the user settings file of the first VS
+// Code edition that has one, preferring the stable build.
+func defaultVSCodeConfigPath() string {
+	return chooseConfigCandidate(vsCodeConfigCandidates())
+}
+
+func defaultVSCodeFallbackBinaryPaths() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{
+			filepath.Join("/Applications", "Visual Studio Code.app", "Contents", "Resources", "app", "bin", "code"),
+		}
+	case "windows":
+		localAppData := os.Getenv("LOCALAPPDATA")
+		if localAppData == "" {
+			return nil
+		}
+
+		return []string{
+			filepath.Join(localAppData, "Programs", "Microsoft VS Code", "bin", "code.cmd"),
+		}
+	default:
+		return []string{
+			filepath.Join("/usr", "share", "code", "bin", "code"),
+			filepath.Join("/snap", "bin", "code"),
+		}
+	}
+}
+
+func getVSCodeMCPServers(config map[string]any, createIfMissing bool) (map[string]any, error) {
+	rawMCP, exists := config["mcp"]
+	if !exists || rawMCP == nil {
+		if !createIfMissing {
+			return nil, nil
+		}
+
+		rawMCP = map[string]any{}
+		config["mcp"] = rawMCP
+	}
+
+	mcp, ok := rawMCP.(map[string]any)
+	if !ok {
+		return nil, errors.New("invalid config: mcp must be an object")
+	}
+
+	rawServers, exists := mcp["servers"]
+	if !exists || rawServers == nil {
+		if !createIfMissing {
+			return nil, nil
+		}
+
+		servers := map[string]any{}
+		mcp["servers"] = servers
+
+		return servers, nil
+	}
+
+	servers, ok := rawServers.(map[string]any)
+	if !ok {
+		return nil, errors.New("invalid config: mcp.servers must be an object")
+	}
+
+	return servers, nil
+}
+
+// pruneVSCodeMCPServers removes an empty mcp.servers object, and the mcp
+// object if nothing else is left in it.
+func pruneVSCodeMCPServers(config map[string]any) {
+	mcp, ok := config["mcp"].(map[string]any)
+	if !ok || !pruneEmptyObject(mcp, "servers") {
+		return
+	}
+
+	pruneEmptyObject(config, "mcp")
+}
+
+// buildServerConfig returns the entry Install writes for svc, with stdio
+// servers started inside WSL for the Windows-side VS Code.
+func (t *VSCodeTarget) buildServerConfig(svc service.Service, resolvedEnv map[string]string) (map[string]any, error) {
+	serverConfig, err := buildVSCodeServerConfig(svc, resolvedEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.wslDistro != "" {
+		wrapWSLCommand(serverConfig, t.wslDistro)
+	}
+
+	return serverConfig, nil
+}
+
+func buildVSCodeServerConfig(svc service.Service, resolvedEnv map[string]string) (map[string]any, error) {
+	transport := strings.ToLower(strings.TrimSpace(svc.Transport))
+	if transport == "" {
+		return nil, errors.New("service transport is required")
+	}
+
+	serverConfig := map[string]any{
+		"type": transport,
+	}
+
+	switch transport {
+	case "http", "sse":
+		url := strings.TrimSpace(svc.URL)
+		if url == "" {
+			return nil, fmt.Errorf("%s service requires url", transport)
+		}
+
+		serverConfig["url"] = url
+
+		if len(svc.Headers) > 0 {
+			serverConfig["headers"] = svc.Headers
+		}
+	case "stdio":
+		command := strings.TrimSpace(svc.Command)
+		if command == "" {
+			return nil, errors.New("stdio service requires command")
+		}
+
+		serverConfig["command"] = command
+		if len(svc.Args) > 0 {
+			serverConfig["args"] = svc.Args
+		}
+
+		env := normalizeResolvedEnv(resolvedEnv)
+		if len(env) > 0 {
+			serverConfig["env"] = env
+		}
+	case "websocket":
+		return nil, errors.New("websocket transport is not supported by VS Code")
+	default:
+		return nil, fmt.Errorf("unsupported transport %q", svc.Transport)
+	}
+
+	applyServiceExtra(serverConfig, svc)
+
+	return serverConfig, nil
+}
@@ -0,0 +1,376 @@
+package target
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func TestVSCodeTargetMetadata(t *testing.T) {
+	target := NewVSCodeTarget()
+
+	if target.Name() != "VS Code" {
+		t.Fatalf("expected target name VS Code, got %q", target.Name())
+	}
+
+	if target.Slug() != "vscode" {
+		t.Fatalf("expected target slug vscode, got %q", target.Slug())
+	}
+}
+
+func TestVSCodeTargetIsInstalledTrueWhenInsidersBinaryFound(t *testing.T) {
+	target := newTestVSCodeTarget(t)
+	target.binaryNames = []string{"code", "code-insiders"}
+	target.lookPath = func(file string) (string, error) {
+		if file != "code-insiders" {
+			return "", errors.New("not found")
+		}
+
+		return "/usr/local/bin/code-insiders", nil
+	}
+
+	if !target.IsInstalled() {
+		t.Fatal("expected target to be reported as installed")
+	}
+}
+
+func TestVSCodeTargetIsInstalledFalseWhenBinaryMissing(t *testing.T) {
+	target := newTestVSCodeTarget(t)
+
+	if target.IsInstalled() {
+		t.Fatal("expected target to be reported as not installed")
+	}
+}
+
+func TestVSCodeTargetInstallWritesMCPServersAndPreservesSettings(t *testing.T) {
+	target := newTestVSCodeTarget(t)
+
+	writeTargetConfigFile(t, target.configPath, map[string]any{
+		"editor.fontSize": float64(14),
+		"mcp": map[string]any{
+			"inputs": []any{},
+		},
+	})
+
+	svc := service.Service{
+		Name:      "filesystem",
+		Transport: "stdio",
+		Command:   "npx",
+		Args:      []string{"-y", "@modelcontextprotocol/server-filesystem"},
+	}
+
+	if err := target.Install(svc, map[string]string{"ROOT_DIR": "/tmp"}); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	config := readTargetConfigFile(t, target.configPath)
+	if config["editor.fontSize"] != float64(14) {
+		t.Fatalf("expected unrelated settings to be preserved, got %#v", config)
+	}
+
+	mcp := mustMapValue(t, config["mcp"], "mcp")
+	if _, ok := mcp["inputs"]; !ok {
+		t.Fatal("expected other mcp settings to be preserved")
+	}
+
+	servers := mustMapValue(t, mcp["servers"], "mcp.servers")
+	entry := mustMapValue(t, servers["filesystem"], "mcp.servers.filesystem")
+
+	if entry["type"] != "stdio" || entry["command"] != "npx" {
+		t.Fatalf("unexpected stdio entry %#v", entry)
+	}
+
+	env := mustMapValue(t, entry["env"], "mcp.servers.filesystem.env")
+	if env["ROOT_DIR"] != "/tmp" {
+		t.Fatalf("expected resolved env to be written, got %#v", env)
+	}
+}
+
+func TestVSCodeTargetInstallReadsJSONCSettings(t *testing.T) {
+	target := newTestVSCodeTarget(t)
+
+	settings := `{
+  // Editor preferences
+  "editor.tabSize": 2,
+  /* block comment */
+  "files.autoSave": "afterDelay",
+}
+`
+	if err := os.MkdirAll(filepath.Dir(target.configPath), 0o755); err != nil {
+		t.Fatalf("failed to create config directory: %v", err)
+	}
+
+	if err := os.WriteFile(target.configPath, []byte(settings), 0o600); err != nil {
+		t.Fatalf("failed to write settings: %v", err)
+	}
+
+	svc := service.Service{Name: "docs", Transport: "http", URL: "https://docs.example.com/mcp"}
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("expected install to succeed on JSONC settings: %v", err)
+	}
+
+	config, _, err := readVSCodeSettings(target.configPath)
+	if err != nil {
+		t.Fatalf("expected settings to stay readable: %v", err)
+	}
+
+	if config["editor.tabSize"] != float64(2) || config["files.autoSave"] != "afterDelay" {
+		t.Fatalf("expected existing settings to be preserved, got %#v", config)
+	}
+
+	data, err := os.ReadFile(target.configPath)
+	if err != nil {
+		t.Fatalf("failed to read settings: %v", err)
+	}
+
+	if !strings.Contains(string(data), "// Editor preferences") || !strings.Contains(string(data), "/* block comment */") {
+		t.Fatalf("expected comments to survive the write, got %s", data)
+	}
+
+	names, err := target.ListWithScope(ConfigScopeUser)
+	if err != nil {
+		t.Fatalf("expected list to succeed: %v", err)
+	}
+
+	if !slices.Equal(names, []string{"docs"}) {
+		t.Fatalf("expected docs to be listed, got %v", names)
+	}
+}
+
+func TestVSCodeTargetProjectScopeWritesWorkspaceSettings(t *testing.T) {
+	target := newTestVSCodeTarget(t)
+	workspaceDir := t.TempDir()
+	target.SetProjectDir(workspaceDir)
+
+	svc := service.Service{Name: "docs", Transport: "sse", URL: "https://docs.example.com/sse"}
+	if err := target.InstallWithScope(svc, nil, ConfigScopeProject); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	workspaceSettings := filepath.Join(workspaceDir, ".vscode", "settings.json")
+	if path, err := target.ScopedConfigPath(ConfigScopeProject); err != nil || path != workspaceSettings {
+		t.Fatalf("expected the workspace settings path, got %q (err %v)", path, err)
+	}
+
+	config := readTargetConfigFile(t, workspaceSettings)
+	servers := mustMapValue(t, mustMapValue(t, config["mcp"], "mcp")["servers"], "mcp.servers")
+	if _, ok := servers["docs"]; !ok {
+		t.Fatalf("expected docs in workspace settings, got %#v", servers)
+	}
+
+	if _, err := os.Stat(target.configPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected user settings to be untouched, got %v", err)
+	}
+
+	userNames, err := target.ListWithScope(ConfigScopeUser)
+	if err != nil || len(userNames) != 0 {
+		t.Fatalf("expected no user services, got %v (err %v)", userNames, err)
+	}
+
+	found, err := target.HasProjectEntry()
+	if err != nil || !found {
+		t.Fatalf("expected workspace settings to count as a project entry, got %v (err %v)", found, err)
+	}
+}
+
+func TestVSCodeTargetProjectScopeUsesNearestParentWorkspace(t *testing.T) {
+	workspaceDir := t.TempDir()
+	nestedDir := filepath.Join(workspaceDir, "packages", "api")
+	if err := os.MkdirAll(nestedDir, 0o755); err != nil {
+		t.Fatalf("failed to create nested directory: %v", err)
+	}
+
+	writeTargetConfigFile(t, filepath.Join(workspaceDir, ".vscode", "settings.json"), map[string]any{
+		"mcp": map[string]any{
+			"servers": map[string]any{
+				"docs": map[string]any{"type": "http", "url": "https://docs.example.com/mcp"},
+			},
+		},
+	})
+
+	setWorkingDirectory(t, nestedDir)
+	target := newTestVSCodeTarget(t)
+
+	names, err := target.ListWithScope(ConfigScopeProject)
+	if err != nil {
+		t.Fatalf("expected list to succeed: %v", err)
+	}
+
+	if !slices.Equal(names, []string{"docs"}) {
+		t.Fatalf("expected parent workspace services, got %v", names)
+	}
+
+	if _, err := os.Stat(filepath.Join(nestedDir, ".vscode")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("expected no workspace settings to be created in the nested directory")
+	}
+}
+
+func TestVSCodeTargetListMergesUserAndWorkspaceServers(t *testing.T) {
+	target := newTestVSCodeTarget(t)
+	workspaceDir := t.TempDir()
+	target.SetProjectDir(workspaceDir)
+
+	if err := target.Install(service.Service{Name: "user-svc", Transport: "http", URL: "https://user.example.com"}, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if err := target.InstallWithScope(service.Service{Name: "workspace-svc", Transport: "http", URL: "https://ws.example.com"}, nil, ConfigScopeProject); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	names, err := target.List()
+	if err != nil {
+		t.Fatalf("expected list to succeed: %v", err)
+	}
+
+	if !slices.Equal(names, []string{"user-svc", "workspace-svc"}) {
+		t.Fatalf("expected merged list, got %v", names)
+	}
+}
+
+func TestVSCodeTargetUninstallPrunesEmptyMCPObject(t *testing.T) {
+	target := newTestVSCodeTarget(t)
+
+	writeTargetConfigFile(t, target.configPath, map[string]any{
+		"editor.fontSize": float64(14),
+		"mcp": map[string]any{
+			"servers": map[string]any{
+				"docs": map[string]any{"type": "http", "url": "https://docs.example.com/mcp"},
+			},
+		},
+	})
+
+	if err := target.Uninstall("docs"); err != nil {
+		t.Fatalf("expected uninstall to succeed: %v", err)
+	}
+
+	config := readTargetConfigFile(t, target.configPath)
+	if _, ok := config["mcp"]; ok {
+		t.Fatalf("expected empty mcp object to be pruned, got %#v", config)
+	}
+
+	if config["editor.fontSize"] != float64(14) {
+		t.Fatal("expected unrelated settings to remain")
+	}
+}
+
+func TestVSCodeTargetMethodsErrorWhenServersIsNotObject(t *testing.T) {
+	target := newTestVSCodeTarget(t)
+
+	writeTargetConfigFile(t, target.configPath, map[string]any{
+		"mcp": map[string]any{"servers": "invalid"},
+	})
+
+	_, err := target.ListWithScope(ConfigScopeUser)
+	if err == nil || !strings.Contains(err.Error(), "mcp.servers must be an object") {
+		t.Fatalf("expected invalid servers error, got %v", err)
+	}
+}
+
+func TestVSCodeTargetExplainServicePrefersWorkspace(t *testing.T) {
+	target := newTestVSCodeTarget(t)
+	target.SetProjectDir(t.TempDir())
+
+	svc := service.Service{Name: "docs", Transport: "http", URL: "https://docs.example.com/mcp"}
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	if err := target.InstallWithScope(svc, nil, ConfigScopeProject); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	layers, err := target.ExplainService("docs")
+	if err != nil {
+		t.Fatalf("expected explain to succeed: %v", err)
+	}
+
+	effective, ok := EffectiveLayer(layers)
+	if !ok || effective.Scope != ConfigScopeProject {
+		t.Fatalf("expected workspace layer to be effective, got %#v", effective)
+	}
+}
+
+func TestVSCodeTargetRemoteScopeOnlyWhereVSCodeServerExists(t *testing.T) {
+	target := newTestVSCodeTarget(t)
+
+	if slices.Contains(target.SupportedScopes(), ConfigScopeRemote) {
+		t.Fatal("expected no remote scope without a VS Code Server directory")
+	}
+
+	svc := service.Service{Name: "docs", Transport: "http", URL: "https://docs.example.com/mcp"}
+	if err := target.InstallWithScope(svc, nil, ConfigScopeRemote); err == nil {
+		t.Fatal("expected remote install to fail without a VS Code Server directory")
+	}
+
+	if err := os.MkdirAll(target.remoteServerDir, 0o755); err != nil {
+		t.Fatalf("failed to create VS Code Server directory: %v", err)
+	}
+
+	if !slices.Contains(target.SupportedScopes(), ConfigScopeRemote) {
+		t.Fatal("expected remote scope once VS Code Server has attached")
+	}
+}
+
+func TestVSCodeTargetRemoteScopeWritesMachineSettings(t *testing.T) {
+	target := newTestVSCodeTarget(t)
+	target.SetProjectDir(t.TempDir())
+	if err := os.MkdirAll(target.remoteServerDir, 0o755); err != nil {
+		t.Fatalf("failed to create VS Code Server directory: %v", err)
+	}
+
+	svc := service.Service{Name: "docs", Transport: "http", URL: "https://docs.example.com/mcp"}
+	if err := target.InstallWithScope(svc, nil, ConfigScopeRemote); err != nil {
+		t.Fatalf("expected remote install to succeed: %v", err)
+	}
+
+	machineSettings := filepath.Join(target.remoteServerDir, "data", "Machine", "settings.json")
+	config := readTargetConfigFile(t, machineSettings)
+	servers := mustMapValue(t, mustMapValue(t, config["mcp"], "mcp")["servers"], "mcp.servers")
+	if _, ok := servers["docs"]; !ok {
+		t.Fatalf("expected docs in machine settings, got %#v", servers)
+	}
+
+	if _, err := os.Stat(target.configPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected user settings to be untouched, got %v", err)
+	}
+
+	if err := target.Verify(svc, nil, ConfigScopeRemote); err != nil {
+		t.Fatalf("expected remote entry to verify: %v", err)
+	}
+
+	if err := target.Install(svc, nil); err != nil {
+		t.Fatalf("expected user install to succeed: %v", err)
+	}
+
+	layers, err := target.ExplainService("docs")
+	if err != nil {
+		t.Fatalf("expected explain to succeed: %v", err)
+	}
+
+	effective, ok := EffectiveLayer(layers)
+	if !ok || effective.Scope != ConfigScopeRemote {
+		t.Fatalf("expected remote settings to override user settings, got %#v", effective)
+	}
+}
+
+func newTestVSCodeTarget(t *testing.T) *VSCodeTarget {
+	t.Helper()
+
+	target := NewVSCodeTarget()
+	target.configPath = filepath.Join(t.TempDir(), "Code", "User", "settings.json")
+	target.binaryNames = []string{"code"}
+	target.fallbackBinaryPaths = nil
+	target.statPath = os.Stat
+	target.remoteServerDir = filepath.Join(t.TempDir(), ".vscode-server")
+	target.lookPath = func(_ string) (string, error) {
+		return "", errors.New("not found")
+	}
+
+	return target
+}
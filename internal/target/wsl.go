@@ -0,0 +1,127 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const vsCodeWindowsSlug = "vscode-windows"
+
+// windowsUsersDir is where WSL mounts the Windows user profiles.
+var windowsUsersDir = filepath.Join("/mnt", "c", "Users")
+
+// NewWindowsVSCodeTarget returns a target for the VS Code installed on the
+// Windows side of a WSL machine, whose user profile is mounted at profile.
+// Stdio servers it starts run inside the given WSL distribution.
+func NewWindowsVSCodeTarget(profile string, distro string) *VSCodeTarget {
+	return &VSCodeTarget{
+		configPath:     chooseConfigCandidate(windowsVSCodeConfigCandidates(profile)),
+		statPath:       os.Stat,
+		wslDistro:      distro,
+		windowsProfile: profile,
+		fallbackBinaryPaths: []string{
+			filepath.Join(profile, "AppData", "Local", "Programs", "Microsoft VS Code", "Code.exe"),
+			filepath.Join(profile, "AppData", "Local", "Programs", "Microsoft VS Code Insiders", "Code - Insiders.exe"),
+		},
+	}
+}
+
+// wslTargets returns the Windows-side targets reachable from the WSL
+// distribution mcp-wire runs in, or nothing outside WSL.
+func wslTargets() []Target {
+	distro := strings.TrimSpace(os.Getenv("WSL_DISTRO_NAME"))
+	if distro == "" {
+		return nil
+	}
+
+	profile, ok := findWindowsProfile(windowsUsersDir, os.Getenv("USER"))
+	if !ok {
+		return nil
+	}
+
+	return []Target{NewWindowsVSCodeTarget(profile, distro)}
+}
+
+// findWindowsProfile returns the Windows user profile under usersDir. With
+// several profiles it picks the one named like the Linux user, and gives up
+// when none is.
+func findWindowsProfile(usersDir string, linuxUser string) (string, bool) {
+	entries, err := os.ReadDir(usersDir)
+	if err != nil {
+		return "", false
+	}
+
+	var profiles []string
+	for _, entry := range entries {
+		switch entry.Name() {
+		case "Public", "Default", "Default User", "All Users":
+			continue
+		}
+
+		profile := filepath.Join(usersDir, entry.Name())
+		if info, err := os.Stat(filepath.Join(profile, "AppData", "Roaming")); err != nil || !info.IsDir() {
+			continue
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	if len(profiles) == 1 {
+		return profiles[0], true
+	}
+
+	for _, profile := range profiles {
+		if strings.EqualFold(filepath.Base(profile), strings.TrimSpace(linuxUser)) {
+			return profile, true
+		}
+	}
+
+	return "", false
+}
+
+func windowsVSCodeConfigCandidates(profile string) []ConfigCandidate {
+	roaming := filepath.Join(profile, "AppData", "Roaming")
+
+	return []ConfigCandidate{
+		{Path: filepath.Join(roaming, "Code", "User", "settings.json"), Label: "VS Code on Windows"},
+		{Path: filepath.Join(roaming, "Code - Insiders", "User", "settings.json"), Label: "VS Code Insiders on Windows"},
+	}
+}
+
+// wrapWSLCommand rewrites a stdio entry so a Windows program starts the
+// server inside the WSL distribution through wsl.exe. The command and any
+// Linux paths in its arguments then resolve as they do in the WSL shell.
+// Variables set on wsl.exe only reach the Linux process when listed in
+// WSLENV, so every env name is added there.
+func wrapWSLCommand(serverConfig map[string]any, distro string) {
+	command, ok := serverConfig["command"].(string)
+	if !ok {
+		return
+	}
+
+	args := []string{"-d", distro, "--", command}
+	if commandArgs, ok := serverConfig["args"].([]string); ok {
+		args = append(args, commandArgs...)
+	}
+
+	serverConfig["command"] = "wsl.exe"
+	serverConfig["args"] = args
+
+	env, ok := serverConfig["env"].(map[string]string)
+	if !ok || len(env) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(env))
+	wrappedEnv := make(map[string]string, len(env)+1)
+	for name, value := range env {
+		names = append(names, name)
+		wrappedEnv[name] = value
+	}
+
+	sort.Strings(names)
+	wrappedEnv["WSLENV"] = strings.Join(names, ":")
+	serverConfig["env"] = wrappedEnv
+}
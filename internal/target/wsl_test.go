@@ -0,0 +1,129 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/andreagrandi/mcp-wire/internal/service"
+)
+
+func TestFindWindowsProfilePicksOnlyRealProfile(t *testing.T) {
+	usersDir := t.TempDir()
+	createWindowsProfile(t, usersDir, "Public")
+	want := createWindowsProfile(t, usersDir, "Andrea")
+	if err := os.MkdirAll(filepath.Join(usersDir, "NoAppData"), 0o755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	profile, ok := findWindowsProfile(usersDir, "someone-else")
+	if !ok || profile != want {
+		t.Fatalf("expected %q, got %q (ok=%v)", want, profile, ok)
+	}
+}
+
+func TestFindWindowsProfileMatchesLinuxUserAmongSeveral(t *testing.T) {
+	usersDir := t.TempDir()
+	createWindowsProfile(t, usersDir, "Admin")
+	want := createWindowsProfile(t, usersDir, "Andrea")
+
+	profile, ok := findWindowsProfile(usersDir, "andrea")
+	if !ok || profile != want {
+		t.Fatalf("expected %q, got %q (ok=%v)", want, profile, ok)
+	}
+
+	if _, ok := findWindowsProfile(usersDir, "dev"); ok {
+		t.Fatal("expected no profile when several exist and none matches the Linux user")
+	}
+}
+
+func TestWSLTargetsOnlyInsideWSL(t *testing.T) {
+	usersDir := t.TempDir()
+	createWindowsProfile(t, usersDir, "Andrea")
+
+	originalUsersDir := windowsUsersDir
+	windowsUsersDir = usersDir
+	t.Cleanup(func() { windowsUsersDir = originalUsersDir })
+
+	t.Setenv("WSL_DISTRO_NAME", "")
+	if targets := wslTargets(); len(targets) != 0 {
+		t.Fatalf("expected no Windows targets outside WSL, got %d", len(targets))
+	}
+
+	t.Setenv("WSL_DISTRO_NAME", "Ubuntu")
+	targets := wslTargets()
+	if len(targets) != 1 || targets[0].Slug() != "vscode-windows" || targets[0].Name() != "VS Code (Windows)" {
+		t.Fatalf("expected Windows VS Code target, got %#v", targets)
+	}
+}
+
+func TestWindowsVSCodeTargetRunsStdioServersThroughWSL(t *testing.T) {
+	profile := createWindowsProfile(t, t.TempDir(), "Andrea")
+	target := NewWindowsVSCodeTarget(profile, "Ubuntu")
+
+	wantPath := filepath.Join(profile, "AppData", "Roaming", "Code", "User", "settings.json")
+	if target.ConfigPath() != wantPath {
+		t.Fatalf("expected Windows settings path %q, got %q", wantPath, target.ConfigPath())
+	}
+
+	svc := service.Service{Name: "files", Transport: "stdio", Command: "npx", Args: []string{"-y", "server", "/home/andrea/docs"}}
+	if err := target.Install(svc, map[string]string{"API_TOKEN": "secret", "BASE_URL": "https://x"}); err != nil {
+		t.Fatalf("expected install to succeed: %v", err)
+	}
+
+	config := readTargetConfigFile(t, target.ConfigPath())
+	servers := mustMapValue(t, mustMapValue(t, config["mcp"], "mcp")["servers"], "mcp.servers")
+	entry := mustMapValue(t, servers["files"], "mcp.servers.files")
+
+	if entry["command"] != "wsl.exe" {
+		t.Fatalf("expected wsl.exe command, got %#v", entry["command"])
+	}
+
+	args := make([]string, 0)
+	for _, arg := range entry["args"].([]any) {
+		args = append(args, arg.(string))
+	}
+
+	if !slices.Equal(args, []string{"-d", "Ubuntu", "--", "npx", "-y", "server", "/home/andrea/docs"}) {
+		t.Fatalf("unexpected args %v", args)
+	}
+
+	env := mustMapValue(t, entry["env"], "mcp.servers.files.env")
+	if env["WSLENV"] != "API_TOKEN:BASE_URL" {
+		t.Fatalf("expected env names forwarded through WSLENV, got %#v", env["WSLENV"])
+	}
+
+	if err := target.Verify(svc, map[string]string{"API_TOKEN": "secret", "BASE_URL": "https://x"}, ConfigScopeUser); err != nil {
+		t.Fatalf("expected wrapped entry to verify: %v", err)
+	}
+}
+
+func TestWindowsVSCodeTargetManagesUserSettingsOnly(t *testing.T) {
+	profile := createWindowsProfile(t, t.TempDir(), "Andrea")
+	target := NewWindowsVSCodeTarget(profile, "Ubuntu")
+
+	if slices.Contains(target.SupportedScopes(), ConfigScopeProject) {
+		t.Fatal("expected the Windows-side target not to offer project scope")
+	}
+
+	svc := service.Service{Name: "docs", Transport: "http", URL: "https://docs.example.com/mcp"}
+	if err := target.InstallWithScope(svc, nil, ConfigScopeProject); err == nil {
+		t.Fatal("expected project scope install to fail")
+	}
+
+	if target.IsInstalled() {
+		t.Fatal("expected target not to be installed without Code.exe")
+	}
+}
+
+func createWindowsProfile(t *testing.T, usersDir string, name string) string {
+	t.Helper()
+
+	profile := filepath.Join(usersDir, name)
+	if err := os.MkdirAll(filepath.Join(profile, "AppData", "Roaming"), 0o755); err != nil {
+		t.Fatalf("failed to create profile: %v", err)
+	}
+
+	return profile
+}
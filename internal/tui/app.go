@@ -2,11 +2,16 @@ package tui
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/diagnostics"
+	"github.com/andreagrandi/mcp-wire/internal/flow"
 	"github.com/andreagrandi/mcp-wire/internal/service"
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
 )
@@ -14,13 +19,36 @@ import (
 // Callbacks provides functions that generate output for display in the TUI
 // and configuration flags that control wizard behavior.
 type Callbacks struct {
-	LoadCatalog           func(source string) (*catalog.Catalog, error)
-	RegistrySyncStatus    func() string
-	RefreshRegistryEntry  func(catalog.Entry) catalog.Entry
+	// LoadCatalog returns the catalog for a source. The catalog is shared by
+	// reference and never modified; the service screen calls it each time it
+	// opens, which is when newer registry data shows up.
+	LoadCatalog          func(source string) (*catalog.Catalog, error)
+	RegistrySyncStatus   func() string
+	RefreshRegistryEntry func(catalog.Entry) catalog.Entry
+	// BrowseRegistry fetches a page of live registry results for query,
+	// starting at cursor, for the registry browse screen.
+	BrowseRegistry        func(query, cursor string) (RegistryPage, error)
 	CatalogEntryToService func(catalog.Entry) (service.Service, bool)
 	AllTargets            func() []targetpkg.Target
 	RegistryEnabled       bool
 
+	// ConfigureTarget marks an undetected target as installed and points it
+	// at configPath, or keeps the detected config file when it is empty.
+	ConfigureTarget func(t targetpkg.Target, configPath string) error
+
+	// HiddenTargets returns the slugs of targets the user hid, and
+	// SetTargetHidden hides a target or shows it again.
+	HiddenTargets   func() []string
+	SetTargetHidden func(slug string, hidden bool) error
+
+	// TargetGroups returns groups of target slugs keyed by group name, each
+	// offered as one entry on the target screen.
+	TargetGroups func() map[string][]string
+
+	// RegistryNames returns the names of the enabled registries, in
+	// priority order, for the source screen.
+	RegistryNames func() []string
+
 	// Credential resolution.
 	ResolveCredential func(envName string) (value, source string, found bool)
 	StoreCredential   func(envName, value string) error
@@ -31,14 +59,53 @@ type Callbacks struct {
 	ServiceUsesOAuth func(svc service.Service) bool
 	OAuthManualHint  func(t targetpkg.Target) string
 
+	// PreviewChanges renders the diff an install or uninstall of svc would
+	// apply to each target config, for the preview on the review screen.
+	// scopes holds the scope of each target, by slug.
+	PreviewChanges func(action string, svc service.Service, targets []targetpkg.Target, scopes map[string]targetpkg.ConfigScope) string
+
+	// SuppressedWarnings holds the warning codes the user chose not to see.
+	SuppressedWarnings diagnostics.Suppressions
+
 	// Credential cleanup (post-uninstall).
 	RemoveStoredCredentials func(envNames []string) (int, error)
 
 	// Installed service listing (for uninstall flow).
 	ListInstalledServices func(t targetpkg.Target, scope targetpkg.ConfigScope) ([]string, error)
 
+	// InstalledServices lists every service entry on the installed targets,
+	// one per target and scope, for the manage screen.
+	InstalledServices func() []InstalledService
+
 	// URL opening.
 	OpenURL func(url string) error
+
+	// Clipboard reading, for pasting credentials.
+	ReadClipboard func() (string, error)
+
+	// Recently installed service names, most recent first.
+	RecentServices func() []string
+
+	// Service names recommended for the current project.
+	RecommendedServices func() []string
+
+	// Health dashboard.
+	HealthChecks        func() []HealthCheck
+	ReinstallService    func(name string, t targetpkg.Target) error
+	AuthenticateService func(name string, t targetpkg.Target, stdin io.Reader, stdout, stderr io.Writer) error
+
+	// Undo. BeginUndo snapshots the configs of targets before an apply and
+	// returns a func that records the change under description, reporting
+	// whether anything changed. LastUndo describes the change UndoLast
+	// would revert.
+	BeginUndo func(targets []targetpkg.Target) func(description string) bool
+	LastUndo  func() (description string, ok bool)
+	UndoLast  func() (description string, err error)
+
+	// WatchConfigs snapshots the configs of targets when the review is shown
+	// and returns a func listing those another program changed since, so
+	// apply can ask before writing over a change the review did not see.
+	WatchConfigs func(targets []targetpkg.Target) func() []string
 }
 
 // WizardState holds the accumulated selections across wizard screens.
@@ -50,6 +117,40 @@ type WizardState struct {
 	Scope       targetpkg.ConfigScope // "user" or "project"
 	Service     service.Service       // resolved service definition
 	ResolvedEnv map[string]string     // resolved credential values
+
+	// TargetScopes holds the scope of each target, by slug, when the
+	// targets were given different scopes. Targets it does not list use
+	// Scope.
+	TargetScopes map[string]targetpkg.ConfigScope
+
+	// configsChanged lists the target configs changed on disk since the
+	// review was first shown, or is nil when they are not watched.
+	configsChanged func() []string
+}
+
+// ScopeFor returns the scope the operation uses for t.
+func (s WizardState) ScopeFor(t targetpkg.Target) targetpkg.ConfigScope {
+	if scope, ok := s.TargetScopes[t.Slug()]; ok {
+		return scope
+	}
+
+	return s.Scope
+}
+
+// Scopes returns the scope of every selected target, by slug.
+func (s WizardState) Scopes() map[string]targetpkg.ConfigScope {
+	scopes := make(map[string]targetpkg.ConfigScope, len(s.Targets))
+	for _, t := range s.Targets {
+		scopes[t.Slug()] = s.ScopeFor(t)
+	}
+
+	return scopes
+}
+
+// clearScope forgets the chosen scopes, shared and per target.
+func (s *WizardState) clearScope() {
+	s.Scope = ""
+	s.TargetScopes = nil
 }
 
 // WizardModel is the root Bubble Tea model for the full-screen TUI.
@@ -60,19 +161,33 @@ type WizardModel struct {
 	version   string
 	state     WizardState
 	steps     []BreadcrumbStep
+	session   Session
 	width     int
 	height    int
 }
 
 // NewWizardModel creates a new root model starting at the main menu.
 func NewWizardModel(cb Callbacks, version string) WizardModel {
-	theme := NewTheme()
-	return WizardModel{
-		theme:     theme,
-		screen:    NewMenuScreen(theme),
+	m := WizardModel{
+		theme:     NewTheme(),
 		callbacks: cb,
 		version:   version,
 	}
+	m.screen = m.menuScreen()
+
+	return m
+}
+
+// menuScreen returns the main menu, offering undo when the most recent
+// change can be reverted.
+func (m WizardModel) menuScreen() *MenuScreen {
+	if m.callbacks.LastUndo != nil {
+		if description, ok := m.callbacks.LastUndo(); ok {
+			return NewMenuScreenWithUndo(m.theme, description)
+		}
+	}
+
+	return NewMenuScreen(m.theme)
 }
 
 func (m WizardModel) Init() tea.Cmd {
@@ -112,12 +227,33 @@ func (m WizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case reviewConfirmMsg:
 		return m.handleReviewConfirm(msg)
 
+	case reviewPreviewMsg:
+		return m.handleReviewPreview()
+
+	case conflictChoiceMsg:
+		return m.handleConflictChoice(msg)
+
 	case credentialDoneMsg:
 		return m.handleCredentialDone(msg)
 
 	case applyPostActionMsg:
 		return m.handleApplyPostAction(msg)
 
+	case applyResultMsg:
+		if msg.outcome.Action != "" {
+			m.session = m.session.record(msg.outcome)
+		}
+
+	case healthActionMsg:
+		if msg.outcome != nil {
+			m.session = m.session.record(*msg.outcome)
+		}
+
+	case manageRemovedMsg:
+		for _, outcome := range msg.outcomes {
+			m.session = m.session.record(outcome)
+		}
+
 	case BackMsg:
 		return m.handleBack()
 	}
@@ -177,16 +313,60 @@ func (m WizardModel) handleMenuSelect(msg menuSelectMsg) (tea.Model, tea.Cmd) {
 
 	case "Uninstall service":
 		return m.startUninstallWizard()
+
+	case "Manage installed":
+		return m.showManageScreen()
+
+	case "Health":
+		return m.showHealthScreen()
+
+	case menuItemUndo:
+		return m.undoLastChange()
 	}
 
 	return m, nil
 }
 
+// undoLastChange reverts the most recent change and shows the outcome.
+func (m WizardModel) undoLastChange() (tea.Model, tea.Cmd) {
+	m.state = WizardState{}
+	m.steps = []BreadcrumbStep{
+		{Label: "Undo", Active: true, Visible: true},
+	}
+
+	var content string
+	if m.callbacks.UndoLast == nil {
+		content = "\n" + m.theme.Error.Render("  \u2717 Undo is not available") + "\n"
+	} else if description, err := m.callbacks.UndoLast(); err != nil {
+		content = "\n" + m.theme.Error.Render("  \u2717 Undo failed: "+err.Error()) + "\n"
+	} else {
+		content = "\n" + m.theme.Completed.Render("  \u2713 Undid "+description) + "\n\n" +
+			"  The config files it changed were restored.\n"
+	}
+
+	m.screen = NewOutputScreen(m.theme, content, m.contentHeight())
+	return m, m.screen.Init()
+}
+
+// newSourceScreen creates the source screen, naming the configured
+// registries when the callbacks provide them.
+func (m WizardModel) newSourceScreen() *SourceScreen {
+	screen := NewSourceScreen(m.theme)
+	if m.callbacks.RegistryNames != nil {
+		screen.SetRegistries(m.callbacks.RegistryNames())
+	}
+	if m.callbacks.BrowseRegistry != nil {
+		screen.SetLiveBrowse()
+	}
+
+	return screen
+}
+
 func (m WizardModel) startWizard(action string) (tea.Model, tea.Cmd) {
 	m.state = WizardState{Action: action}
 
 	if m.callbacks.RegistryEnabled {
-		m.screen = NewSourceScreen(m.theme)
+		m.screen = m.newSourceScreen()
 		m.steps = []BreadcrumbStep{
 			{Label: "Source", Active: true, Visible: true},
 		}
@@ -198,6 +378,34 @@ func (m WizardModel) startWizard(action string) (tea.Model, tea.Cmd) {
 	return m.showServiceScreen()
 }
 
+func (m WizardModel) showHealthScreen() (tea.Model, tea.Cmd) {
+	m.state = WizardState{Action: "health"}
+	m.steps = []BreadcrumbStep{
+		{Label: "Health", Active: true, Visible: true},
+	}
+	m.screen = NewHealthScreen(m.theme, HealthCallbacks{
+		HealthChecks:        m.callbacks.HealthChecks,
+		ReinstallService:    m.callbacks.ReinstallService,
+		AuthenticateService: m.callbacks.AuthenticateService,
+		OAuthManualHint:     m.callbacks.OAuthManualHint,
+		StoreCredential:     m.callbacks.StoreCredential,
+	})
+	return m, m.screen.Init()
+}
+
+func (m WizardModel) showManageScreen() (tea.Model, tea.Cmd) {
+	m.state = WizardState{Action: "manage"}
+	m.steps = []BreadcrumbStep{
+		{Label: "Manage installed", Active: true, Visible: true},
+	}
+	m.screen = NewManageScreen(m.theme, ManageCallbacks{
+		InstalledServices: m.callbacks.InstalledServices,
+		UninstallTarget:   m.callbacks.UninstallTarget,
+		BeginUndo:         m.callbacks.BeginUndo,
+	})
+	return m, m.screen.Init()
+}
+
 func (m WizardModel) startUninstallWizard() (tea.Model, tea.Cmd) {
 	m.state = WizardState{Action: "uninstall"}
 	return m.showUninstallTargetScreen()
@@ -213,7 +421,7 @@ func (m WizardModel) showUninstallTargetScreen() (tea.Model, tea.Cmd) {
 		allTargets = m.callbacks.AllTargets()
 	}
 
-	m.screen = NewTargetScreen(m.theme, allTargets, m.state.Targets)
+	m.screen = m.newTargetScreen(allTargets)
 	return m, m.screen.Init()
 }
 
@@ -275,30 +483,73 @@ func (m WizardModel) showServiceScreen() (tea.Model, tea.Cmd) {
 		Label: "Service", Active: true, Visible: true,
 	})
 	m.steps = steps
-	m.screen = NewServiceScreen(
+	if m.state.Source == sourceLive {
+		m.screen = NewRegistryBrowseScreen(m.theme, m.contentHeight(), m.callbacks.BrowseRegistry)
+		return m, m.screen.Init()
+	}
+	serviceScreen := NewServiceScreen(
 		m.theme, m.state.Source, m.contentHeight(),
 		m.callbacks.LoadCatalog, m.callbacks.RegistrySyncStatus,
 	)
+	if m.callbacks.RecentServices != nil {
+		serviceScreen.SetRecent(m.callbacks.RecentServices())
+	}
+	if m.callbacks.RecommendedServices != nil {
+		serviceScreen.SetRecommended(m.callbacks.RecommendedServices())
+	}
+	serviceScreen.SetOpenURL(m.callbacks.OpenURL)
+	m.screen = serviceScreen
 	return m, m.screen.Init()
 }
 
 func (m WizardModel) handleServiceSelect(msg serviceSelectMsg) (tea.Model, tea.Cmd) {
 	m.state.Entry = msg.entry
+	return m.showStep(flow.Next(m.flowState(), flow.StepService))
+}
 
-	// Uninstall: targets already selected, skip trust, go to scope or review.
-	if m.state.Action == "uninstall" {
-		if anyTargetSupportsProjectScope(m.state.Targets) {
-			return m.showUninstallScopeScreen()
-		}
+// flowState describes the wizard state to the flow controller.
+func (m WizardModel) flowState() flow.State {
+	return flow.State{
+		Action:          m.state.Action,
+		RegistryEnabled: m.callbacks.RegistryEnabled,
+		Entry:           m.state.Entry,
+		Targets:         m.state.Targets,
+	}
+}
+
+// showStep shows the screen for a step chosen by the flow controller.
+// Credentials and Apply are entered from the review confirmation, which
+// resolves the service first.
+func (m WizardModel) showStep(step flow.Step) (tea.Model, tea.Cmd) {
+	if step == flow.StepReview && !flow.OffersScopeChoice(m.state.Targets) {
+		// No scope selection needed — default to user scope.
 		m.state.Scope = targetpkg.ConfigScopeUser
-		return m.showReviewScreen()
+		m.state.TargetScopes = nil
 	}
 
-	if registryEntryNeedsConfirmation(msg.entry) {
+	switch step {
+	case flow.StepService:
+		if m.state.Action == flow.ActionUninstall {
+			return m.showInstalledServiceScreen()
+		}
+		return m.showServiceScreen()
+	case flow.StepTrust:
 		return m.showTrustScreen()
+	case flow.StepTargets:
+		if m.state.Action == flow.ActionUninstall {
+			return m.showUninstallTargetScreen()
+		}
+		return m.showTargetScreen()
+	case flow.StepScope:
+		if m.state.Action == flow.ActionUninstall {
+			return m.showUninstallScopeScreen()
+		}
+		return m.showScopeScreen()
+	case flow.StepReview:
+		return m.showReviewScreen()
 	}
 
-	return m.showTargetScreen()
+	return m, nil
 }
 
 func (m WizardModel) handleTrustConfirm(msg trustConfirmMsg) (tea.Model, tea.Cmd) {
@@ -312,7 +563,7 @@ func (m WizardModel) handleTrustConfirm(msg trustConfirmMsg) (tea.Model, tea.Cmd
 		m.state.Entry = m.callbacks.RefreshRegistryEntry(m.state.Entry)
 	}
 
-	return m.showTargetScreen()
+	return m.showStep(flow.Next(m.flowState(), flow.StepTrust))
 }
 
 func (m WizardModel) showTrustScreen() (tea.Model, tea.Cmd) {
@@ -331,7 +582,9 @@ func (m WizardModel) showTrustScreen() (tea.Model, tea.Cmd) {
 		Label: "Trust", Active: true, Visible: true,
 	})
 	m.steps = steps
-	m.screen = NewTrustScreen(m.theme, m.state.Entry)
+	trustScreen := NewTrustScreen(m.theme, m.state.Entry)
+	trustScreen.SetOpenURL(m.callbacks.OpenURL)
+	m.screen = trustScreen
 	return m, m.screen.Init()
 }
 
@@ -357,25 +610,33 @@ func (m WizardModel) showTargetScreen() (tea.Model, tea.Cmd) {
 		allTargets = m.callbacks.AllTargets()
 	}
 
-	m.screen = NewTargetScreen(m.theme, allTargets, m.state.Targets)
+	m.screen = m.newTargetScreen(allTargets)
 	return m, m.screen.Init()
 }
 
-func (m WizardModel) handleTargetSelect(msg targetSelectMsg) (tea.Model, tea.Cmd) {
-	m.state.Targets = msg.targets
+// newTargetScreen builds the target screen, letting undetected targets be
+// configured when the ConfigureTarget callback is set, targets be hidden
+// when the hidden target callbacks are, and groups be picked as one entry.
+func (m WizardModel) newTargetScreen(allTargets []targetpkg.Target) *TargetScreen {
+	screen := NewTargetScreen(m.theme, allTargets, m.state.Targets)
+	if m.callbacks.ConfigureTarget != nil {
+		screen.SetConfigureTarget(m.callbacks.ConfigureTarget)
+	}
 
-	// Uninstall: targets selected first, now show installed services.
-	if m.state.Action == "uninstall" {
-		return m.showInstalledServiceScreen()
+	if m.callbacks.TargetGroups != nil {
+		screen.SetTargetGroups(m.callbacks.TargetGroups())
 	}
 
-	if anyTargetSupportsProjectScope(msg.targets) {
-		return m.showScopeScreen()
+	if m.callbacks.HiddenTargets != nil && m.callbacks.SetTargetHidden != nil {
+		screen.SetHiddenTargets(m.callbacks.HiddenTargets(), m.callbacks.SetTargetHidden)
 	}
 
-	// No scope selection needed — default to user scope.
-	m.state.Scope = targetpkg.ConfigScopeUser
-	return m.showReviewScreen()
+	return screen
+}
+
+func (m WizardModel) handleTargetSelect(msg targetSelectMsg) (tea.Model, tea.Cmd) {
+	m.state.Targets = msg.targets
+	return m.showStep(flow.Next(m.flowState(), flow.StepTargets))
 }
 
 func (m WizardModel) showScopeScreen() (tea.Model, tea.Cmd) {
@@ -398,7 +659,7 @@ func (m WizardModel) showScopeScreen() (tea.Model, tea.Cmd) {
 		Label: "Scope", Active: true, Visible: true,
 	})
 	m.steps = steps
-	m.screen = NewScopeScreen(m.theme, scopedTargetNames(m.state.Targets))
+	m.screen = m.newScopeScreen()
 	return m, m.screen.Init()
 }
 
@@ -409,18 +670,54 @@ func (m WizardModel) showUninstallScopeScreen() (tea.Model, tea.Cmd) {
 		{Label: "Scope", Active: true, Visible: true},
 	}
 	m.steps = steps
-	m.screen = NewScopeScreen(m.theme, scopedTargetNames(m.state.Targets))
+	m.screen = m.newScopeScreen()
 	return m, m.screen.Init()
 }
 
+// newScopeScreen offers remote scope only when a selected target has remote
+// settings on this machine.
+func (m WizardModel) newScopeScreen() Screen {
+	screen := NewScopeScreen(m.theme, scopedTargetNames(m.state.Targets))
+	if flow.SupportsRemoteScope(m.state.Targets) {
+		screen = NewScopeScreenWithRemote(m.theme, scopedTargetNames(m.state.Targets))
+	}
+	screen.SetTargets(m.state.Targets)
+	return screen
+}
+
 func (m WizardModel) handleScopeSelect(msg scopeSelectMsg) (tea.Model, tea.Cmd) {
 	m.state.Scope = msg.scope
-	return m.showReviewScreen()
+	m.state.TargetScopes = msg.targetScopes
+	return m.showStep(flow.Next(m.flowState(), flow.StepScope))
 }
 
 func (m WizardModel) showReviewScreen() (tea.Model, tea.Cmd) {
 	m.steps = m.reviewBreadcrumbs()
-	m.screen = NewReviewScreen(m.theme, m.state, m.callbacks.RegistryEnabled)
+	if m.state.configsChanged == nil && m.callbacks.WatchConfigs != nil {
+		m.state.configsChanged = m.callbacks.WatchConfigs(m.state.Targets)
+	}
+
+	review := NewReviewScreen(m.theme, m.state, m.callbacks.RegistryEnabled)
+	if m.callbacks.PreviewChanges != nil {
+		review.SetPreview()
+	}
+	m.screen = review
+	return m, m.screen.Init()
+}
+
+// handleReviewPreview shows the config diff of the reviewed operation.
+// Nothing is written; Esc returns to the review screen.
+func (m WizardModel) handleReviewPreview() (tea.Model, tea.Cmd) {
+	svc, ok := m.convertEntryToService()
+	if !ok {
+		content := "Cannot resolve service definition.\n" +
+			"No supported install method found for " + m.state.Entry.Name + ".\n"
+		m.screen = NewPreviewScreen(m.theme, content, m.contentHeight())
+		return m, m.screen.Init()
+	}
+
+	content := m.callbacks.PreviewChanges(m.state.Action, svc, m.state.Targets, m.state.Scopes())
+	m.screen = NewPreviewScreen(m.theme, content, m.contentHeight())
 	return m, m.screen.Init()
 }
 
@@ -445,6 +742,12 @@ func (m WizardModel) handleReviewConfirm(msg reviewConfirmMsg) (tea.Model, tea.C
 		return m.showApplyScreen()
 	}
 
+	svc, err := svc.ExpandEnvDefaults(m.templateLookup(svc, nil))
+	if err != nil {
+		return m.showTemplateError(err)
+	}
+	m.state.Service = svc
+
 	// Resolve credentials from existing sources.
 	resolvedEnv, unresolvedVars := m.resolveExistingCredentials(svc)
 	m.state.ResolvedEnv = resolvedEnv
@@ -453,7 +756,15 @@ func (m WizardModel) handleReviewConfirm(msg reviewConfirmMsg) (tea.Model, tea.C
 		return m.showCredentialScreen(unresolvedVars)
 	}
 
-	return m.showApplyScreen()
+	return m.applySubstitutionsAndShowApply()
+}
+
+// showTemplateError reports a placeholder in the service definition that
+// could not be resolved. Nothing has been written yet.
+func (m WizardModel) showTemplateError(err error) (tea.Model, tea.Cmd) {
+	content := "Cannot resolve service definition.\n" + err.Error() + "\n"
+	m.screen = NewOutputScreen(m.theme, content, m.contentHeight())
+	return m, m.screen.Init()
 }
 
 // convertEntryToService converts the selected catalog entry to a service.Service.
@@ -512,26 +823,45 @@ func (m WizardModel) showCredentialScreen(unresolvedVars []service.EnvVar) (tea.
 	})
 	m.steps = steps
 
-	m.screen = NewCredentialScreen(
+	credentialScreen := NewCredentialScreen(
 		m.theme,
 		unresolvedVars,
 		m.state.ResolvedEnv,
 		m.callbacks.StoreCredential,
 		m.callbacks.OpenURL,
 	)
+	credentialScreen.SetReadClipboard(m.callbacks.ReadClipboard)
+	m.screen = credentialScreen
 	return m, m.screen.Init()
 }
 
 func (m WizardModel) handleCredentialDone(msg credentialDoneMsg) (tea.Model, tea.Cmd) {
 	m.state.ResolvedEnv = msg.resolvedEnv
 
-	// Apply registry substitutions to the service.
-	applySubstitutions(&m.state.Service, m.state.ResolvedEnv)
+	return m.applySubstitutionsAndShowApply()
+}
+
+// applySubstitutionsAndShowApply expands the placeholders of the service
+// with the resolved credentials and moves on to the apply screen.
+func (m WizardModel) applySubstitutionsAndShowApply() (tea.Model, tea.Cmd) {
+	svc, err := m.state.Service.ExpandTemplates(m.templateLookup(m.state.Service, m.state.ResolvedEnv))
+	if err != nil {
+		return m.showTemplateError(err)
+	}
+	m.state.Service = svc
 
 	return m.showApplyScreen()
 }
 
 func (m WizardModel) showApplyScreen() (tea.Model, tea.Cmd) {
+	if m.state.configsChanged != nil {
+		if changed := m.state.configsChanged(); len(changed) > 0 {
+			m.screen = NewConflictScreen(m.theme, changed)
+			return m, m.screen.Init()
+		}
+	}
+	m.state.configsChanged = nil
+
 	steps := m.reviewBreadcrumbs()
 	steps = append(steps, BreadcrumbStep{
 		Label: "Apply", Active: true, Visible: true,
@@ -549,11 +879,27 @@ func (m WizardModel) showApplyScreen() (tea.Model, tea.Cmd) {
 			ServiceUsesOAuth:        m.callbacks.ServiceUsesOAuth,
 			OAuthManualHint:         m.callbacks.OAuthManualHint,
 			RemoveStoredCredentials: m.callbacks.RemoveStoredCredentials,
+			BeginUndo:               m.callbacks.BeginUndo,
+			SuppressedWarnings:      m.callbacks.SuppressedWarnings,
 		},
 	)
 	return m, m.screen.Init()
 }
 
+// handleConflictChoice goes on after target configs changed on disk while
+// the review was open: apply writes the change on top of the files as they
+// are now, otherwise the review is shown again against them.
+func (m WizardModel) handleConflictChoice(msg conflictChoiceMsg) (tea.Model, tea.Cmd) {
+	m.state.configsChanged = nil
+	if msg.apply {
+		return m.showApplyScreen()
+	}
+
+	m.state.ResolvedEnv = nil
+	m.state.Service = service.Service{}
+	return m.showReviewScreen()
+}
+
 func (m WizardModel) handleApplyPostAction(msg applyPostActionMsg) (tea.Model, tea.Cmd) {
 	switch msg.action {
 	case "another":
@@ -561,11 +907,13 @@ func (m WizardModel) handleApplyPostAction(msg applyPostActionMsg) (tea.Model, t
 			return m.startUninstallWizard()
 		}
 		return m.startWizard(m.state.Action)
+	case "undo":
+		return m.undoLastChange()
 	case "exit":
 		return m, tea.Quit
 	default:
 		// "menu" or unknown — return to menu.
-		m.screen = NewMenuScreen(m.theme)
+		m.screen = m.menuScreen()
 		m.state = WizardState{}
 		m.steps = nil
 		return m, m.screen.Init()
@@ -574,33 +922,35 @@ func (m WizardModel) handleApplyPostAction(msg applyPostActionMsg) (tea.Model, t
 
 // reviewGoBack navigates back from the review screen to the previous step.
 func (m WizardModel) reviewGoBack() (tea.Model, tea.Cmd) {
-	if anyTargetSupportsProjectScope(m.state.Targets) {
-		m.state.Scope = ""
-		if m.state.Action == "uninstall" {
-			return m.showUninstallScopeScreen()
-		}
-		return m.showScopeScreen()
-	}
-
-	if m.state.Action == "uninstall" {
-		return m.showInstalledServiceScreen()
+	m.state.configsChanged = nil
+	previous := flow.Previous(m.flowState(), flow.StepReview)
+	if previous == flow.StepScope {
+		m.state.clearScope()
 	}
 
-	return m.showTargetScreen()
+	return m.showStep(previous)
 }
 
-// applySubstitutions replaces {VAR} placeholders in the service URL, headers,
-// and args with resolved credential values.
-func applySubstitutions(svc *service.Service, resolvedEnv map[string]string) {
-	for name, value := range resolvedEnv {
-		placeholder := "{" + name + "}"
-		svc.URL = strings.ReplaceAll(svc.URL, placeholder, value)
-		for hdr, tmpl := range svc.Headers {
-			svc.Headers[hdr] = strings.ReplaceAll(tmpl, placeholder, value)
+// templateLookup resolves a placeholder of svc from the resolved
+// credentials, then the credential sources and the OS environment. Port
+// slots are left for the install callback, which allocates them.
+func (m WizardModel) templateLookup(svc service.Service, resolvedEnv map[string]string) service.TemplateLookup {
+	return func(name string) (string, bool) {
+		if value, ok := resolvedEnv[name]; ok {
+			return value, true
 		}
-		for i, arg := range svc.Args {
-			svc.Args[i] = strings.ReplaceAll(arg, placeholder, value)
+
+		if slices.Contains(svc.Ports, name) {
+			return "", false
 		}
+
+		if m.callbacks.ResolveCredential != nil {
+			if value, _, found := m.callbacks.ResolveCredential(name); found {
+				return value, true
+			}
+		}
+
+		return os.LookupEnv(name)
 	}
 }
 
@@ -618,9 +968,9 @@ func (m WizardModel) reviewBreadcrumbs() []BreadcrumbStep {
 			Label: "Service", Value: m.state.Entry.Name,
 			Completed: true, Visible: true,
 		})
-		if m.state.Scope == targetpkg.ConfigScopeProject {
+		if value := scopeBreadcrumbValue(m.state); value != "" {
 			steps = append(steps, BreadcrumbStep{
-				Label: "Scope", Value: "project",
+				Label: "Scope", Value: value,
 				Completed: true, Visible: true,
 			})
 		}
@@ -642,15 +992,34 @@ func (m WizardModel) reviewBreadcrumbs() []BreadcrumbStep {
 		Label: "Targets", Value: targetSummary(m.state.Targets),
 		Completed: true, Visible: true,
 	})
-	if m.state.Scope == targetpkg.ConfigScopeProject {
+	if value := scopeBreadcrumbValue(m.state); value != "" {
 		steps = append(steps, BreadcrumbStep{
-			Label: "Scope", Value: "project",
+			Label: "Scope", Value: value,
 			Completed: true, Visible: true,
 		})
 	}
 	return steps
 }
 
+// scopeBreadcrumbValue returns the scope shown in the breadcrumbs: the
+// shared scope when it is not the default user scope, or each target's
+// scope when they differ.
+func scopeBreadcrumbValue(state WizardState) string {
+	if len(state.TargetScopes) > 0 {
+		parts := make([]string, 0, len(state.Targets))
+		for _, t := range state.Targets {
+			parts = append(parts, t.Slug()+" "+string(state.ScopeFor(t)))
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	if state.Scope == targetpkg.ConfigScopeProject || state.Scope == targetpkg.ConfigScopeRemote {
+		return string(state.Scope)
+	}
+
+	return ""
+}
+
 // targetSummary returns a short label for the selected targets.
 func targetSummary(targets []targetpkg.Target) string {
 	if len(targets) == 0 {
@@ -662,25 +1031,8 @@ func targetSummary(targets []targetpkg.Target) string {
 	return targets[0].Name() + " +" + fmt.Sprintf("%d", len(targets)-1)
 }
 
-// anyTargetSupportsProjectScope checks if any of the given targets support
-// the project config scope.
-func anyTargetSupportsProjectScope(targets []targetpkg.Target) bool {
-	for _, t := range targets {
-		st, ok := t.(targetpkg.ScopedTarget)
-		if !ok {
-			continue
-		}
-		for _, s := range st.SupportedScopes() {
-			if s == targetpkg.ConfigScopeProject {
-				return true
-			}
-		}
-	}
-	return false
-}
-
 // scopedTargetNames returns a comma-separated list of target names that
-// support project scope, for display in the scope selection heading.
+// support project or remote scope, for display in the scope selection heading.
 func scopedTargetNames(targets []targetpkg.Target) string {
 	var names []string
 	for _, t := range targets {
@@ -689,7 +1041,7 @@ func scopedTargetNames(targets []targetpkg.Target) string {
 			continue
 		}
 		for _, s := range st.SupportedScopes() {
-			if s == targetpkg.ConfigScopeProject {
+			if s == targetpkg.ConfigScopeProject || s == targetpkg.ConfigScopeRemote {
 				names = append(names, t.Name())
 				break
 			}
@@ -713,25 +1065,27 @@ func (m WizardModel) handleBack() (tea.Model, tea.Cmd) {
 	case *ReviewScreen:
 		return m.reviewGoBack()
 
+	case *PreviewScreen:
+		return m.showReviewScreen()
+
+	case *ConflictScreen:
+		return m.handleConflictChoice(conflictChoiceMsg{})
+
 	case *ScopeScreen:
-		if m.state.Action == "uninstall" {
-			// Uninstall: back from scope goes to service selection.
-			return m.showInstalledServiceScreen()
-		}
-		// Back from scope goes to target selection, preserving selections.
-		return m.showTargetScreen()
+		// Back from scope keeps the earlier selections.
+		return m.showStep(flow.Previous(m.flowState(), flow.StepScope))
 
 	case *TargetScreen:
 		if m.state.Action == "uninstall" {
 			// Uninstall: target is the first screen, back goes to menu.
-			m.screen = NewMenuScreen(m.theme)
+			m.screen = m.menuScreen()
 			m.state = WizardState{}
 			m.steps = nil
 			return m, m.screen.Init()
 		}
 		// Back from target goes to service selection.
 		m.state.Targets = nil
-		m.state.Scope = ""
+		m.state.clearScope()
 		m.state.Entry = catalog.Entry{}
 		return m.showServiceScreen()
 
@@ -740,7 +1094,7 @@ func (m WizardModel) handleBack() (tea.Model, tea.Cmd) {
 		m.state.Entry = catalog.Entry{}
 		return m.showServiceScreen()
 
-	case *ServiceScreen:
+	case *ServiceScreen, *RegistryBrowseScreen:
 		if m.state.Action == "uninstall" {
 			// Uninstall: back from service goes to target selection.
 			m.state.Entry = catalog.Entry{}
@@ -748,7 +1102,7 @@ func (m WizardModel) handleBack() (tea.Model, tea.Cmd) {
 		}
 		if m.callbacks.RegistryEnabled {
 			// Back to source selection.
-			m.screen = NewSourceScreen(m.theme)
+			m.screen = m.newSourceScreen()
 			m.state.Source = ""
 			m.state.Entry = catalog.Entry{}
 			m.steps = []BreadcrumbStep{
@@ -759,18 +1113,23 @@ func (m WizardModel) handleBack() (tea.Model, tea.Cmd) {
 	}
 
 	// Default: return to menu.
-	m.screen = NewMenuScreen(m.theme)
+	m.screen = m.menuScreen()
 	m.state = WizardState{}
 	m.steps = nil
 	return m, m.screen.Init()
 }
 
+// sourceLive is the source that browses the registry API page by page
+// instead of the cached snapshot.
+const sourceLive = "live"
+
 // sourceValueLabel returns a display label for a source value.
 func sourceValueLabel(source string) string {
 	labels := map[string]string{
 		"curated":  "Curated services",
 		"registry": "Registry services (community)",
 		"all":      "Both (curated + registry)",
+		sourceLive: "Live registry",
 	}
 	if l, ok := labels[source]; ok {
 		return l
@@ -809,9 +1168,19 @@ func padToHeight(content string, targetHeight int) string {
 	return strings.Join(lines, "\n")
 }
 
-// Run starts the full-screen TUI.
-func Run(cb Callbacks, version string) error {
+// Session returns the operations performed so far.
+func (m WizardModel) Session() Session {
+	return m.session
+}
+
+// Run starts the full-screen TUI and returns a summary of the operations
+// performed once the alternate screen has closed.
+func Run(cb Callbacks, version string) (Session, error) {
 	p := tea.NewProgram(NewWizardModel(cb, version), tea.WithAltScreen())
-	_, err := p.Run()
-	return err
+	final, err := p.Run()
+	if model, ok := final.(WizardModel); ok {
+		return model.Session(), err
+	}
+
+	return Session{}, err
 }
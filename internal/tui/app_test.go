@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/flow"
 	"github.com/andreagrandi/mcp-wire/internal/registry"
 	"github.com/andreagrandi/mcp-wire/internal/service"
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
@@ -748,6 +750,39 @@ func TestWizardModel_ScopeSelectGoesToReview(t *testing.T) {
 	assert.Equal(t, targetpkg.ConfigScopeProject, wm.state.Scope)
 }
 
+func TestWizardModel_PerTargetScopeSelectGoesToReview(t *testing.T) {
+	cb := testCallbacksWithTargets(testMockTargetsWithScopes())
+	model := NewWizardModel(cb, "1.0.0")
+	model.height = 20
+
+	updated, _ := model.Update(menuSelectMsg{item: "Install service"})
+	wm := updated.(WizardModel)
+
+	entry := catalog.FromCurated(service.Service{Name: "sentry"})
+	updated, _ = wm.Update(serviceSelectMsg{entry: entry})
+	wm = updated.(WizardModel)
+
+	updated, _ = wm.Update(targetSelectMsg{targets: testMockTargetsWithScopes()})
+	wm = updated.(WizardModel)
+
+	updated, _ = wm.Update(scopeSelectMsg{
+		scope:        targetpkg.ConfigScopeUser,
+		targetScopes: map[string]targetpkg.ConfigScope{"claude": targetpkg.ConfigScopeProject},
+	})
+	wm = updated.(WizardModel)
+
+	_, isReview := wm.screen.(*ReviewScreen)
+	require.True(t, isReview)
+	assert.Equal(t, targetpkg.ConfigScopeProject, wm.state.ScopeFor(testMockTargetsWithScopes()[0]))
+	assert.Equal(t, targetpkg.ConfigScopeUser, wm.state.ScopeFor(testMockTargetsWithScopes()[1]))
+	assert.Equal(t, "claude project, codex user", scopeBreadcrumbValue(wm.state))
+
+	// Going back to the scope screen forgets the per-target choice.
+	updated, _ = wm.Update(BackMsg{})
+	wm = updated.(WizardModel)
+	assert.Nil(t, wm.state.TargetScopes)
+}
+
 func TestWizardModel_BackFromTargetGoesToService(t *testing.T) {
 	model := NewWizardModel(testCallbacks(), "1.0.0")
 	model.height = 20
@@ -814,9 +849,9 @@ func TestWizardModel_TargetSummary(t *testing.T) {
 }
 
 func TestWizardModel_AnyTargetSupportsProjectScope(t *testing.T) {
-	assert.False(t, anyTargetSupportsProjectScope(testMockTargets()))
-	assert.True(t, anyTargetSupportsProjectScope(testMockTargetsWithScopes()))
-	assert.False(t, anyTargetSupportsProjectScope(nil))
+	assert.False(t, flow.SupportsProjectScope(testMockTargets()))
+	assert.True(t, flow.SupportsProjectScope(testMockTargetsWithScopes()))
+	assert.False(t, flow.SupportsProjectScope(nil))
 }
 
 func TestWizardModel_ReviewBreadcrumbNoScope(t *testing.T) {
@@ -1162,6 +1197,50 @@ func TestWizardModel_PreResolvedCredentials_SkipsToApply(t *testing.T) {
 	assert.Equal(t, "pre-resolved-tok", wm.state.ResolvedEnv["SENTRY_TOKEN"])
 }
 
+func TestWizardModel_ExpandsTemplatesBeforeApply(t *testing.T) {
+	cb := testCallbacksWithCredentials()
+	cb.ResolveCredential = func(envName string) (string, string, bool) {
+		if envName == "SENTRY_HOST" {
+			return "sentry.example.com", "env", true
+		}
+		return "", "", false
+	}
+
+	confirm := func() WizardModel {
+		model := NewWizardModel(cb, "1.0.0")
+		model.height = 20
+
+		updated, _ := model.Update(menuSelectMsg{item: "Install service"})
+		wm := updated.(WizardModel)
+
+		svc := service.Service{
+			Name:      "sentry",
+			Transport: "http",
+			URL:       "https://${SENTRY_HOST}/mcp/${SENTRY_ORG}",
+		}
+		updated, _ = wm.Update(serviceSelectMsg{entry: catalog.FromCurated(svc)})
+		wm = updated.(WizardModel)
+
+		updated, _ = wm.Update(targetSelectMsg{targets: testMockTargets()[:1]})
+		wm = updated.(WizardModel)
+
+		updated, _ = wm.Update(reviewConfirmMsg{confirmed: true})
+		return updated.(WizardModel)
+	}
+
+	// SENTRY_ORG is set nowhere, so nothing is applied.
+	wm := confirm()
+	_, isOutput := wm.screen.(*OutputScreen)
+	require.True(t, isOutput)
+	assert.Contains(t, wm.screen.View(), "${SENTRY_ORG} is not set")
+
+	t.Setenv("SENTRY_ORG", "acme")
+	wm = confirm()
+	_, isApply := wm.screen.(*ApplyScreen)
+	require.True(t, isApply)
+	assert.Equal(t, "https://sentry.example.com/mcp/acme", wm.state.Service.URL)
+}
+
 func TestWizardModel_UninstallSkipsCredentials(t *testing.T) {
 	cb := testCallbacksWithCredentials()
 	cb.ListInstalledServices = func(_ targetpkg.Target, _ targetpkg.ConfigScope) ([]string, error) {
@@ -1640,3 +1719,146 @@ func TestWizardModel_UninstallBackFromScopeGoesToService(t *testing.T) {
 	_, isService := wm.screen.(*ServiceScreen)
 	assert.True(t, isService)
 }
+
+func TestWizardModel_MenuOffersUndoAndRunsIt(t *testing.T) {
+	cb := testCallbacks()
+	undone := false
+	cb.LastUndo = func() (string, bool) { return "install sentry", !undone }
+	cb.UndoLast = func() (string, error) {
+		undone = true
+		return "install sentry", nil
+	}
+
+	model := NewWizardModel(cb, "1.0.0")
+	model.height = 20
+	assert.Contains(t, model.View(), "Undo last change")
+	assert.Contains(t, model.View(), "(install sentry)")
+
+	updated, _ := model.Update(menuSelectMsg{item: menuItemUndo})
+	wm := updated.(WizardModel)
+
+	assert.True(t, undone)
+	_, isOutput := wm.screen.(*OutputScreen)
+	require.True(t, isOutput)
+	assert.Contains(t, wm.View(), "Undid install sentry")
+
+	// Any key returns to a menu that no longer offers undo.
+	updated, cmd := wm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	updated, _ = updated.(WizardModel).Update(cmd())
+	assert.NotContains(t, updated.(WizardModel).View(), "Undo last change")
+}
+
+func TestWizardModel_UndoFailureIsShown(t *testing.T) {
+	cb := testCallbacks()
+	cb.LastUndo = func() (string, bool) { return "install sentry", true }
+	cb.UndoLast = func() (string, error) {
+		return "", errors.New("settings.json changed after \"install sentry\"; refusing to undo")
+	}
+
+	model := NewWizardModel(cb, "1.0.0")
+	model.height = 20
+
+	updated, _ := model.Update(applyPostActionMsg{action: "undo"})
+	assert.Contains(t, updated.(WizardModel).View(), "Undo failed: settings.json changed")
+}
+
+func TestWizardModel_ReviewPreviewShowsChangesAndReturns(t *testing.T) {
+	cb := testCallbacks()
+	var previewedAction, previewedService string
+	cb.PreviewChanges = func(action string, svc service.Service, targets []targetpkg.Target, _ map[string]targetpkg.ConfigScope) string {
+		previewedAction = action
+		previewedService = svc.Name
+		return "Claude Code:\n+    \"sentry\": {}\n"
+	}
+	model := NewWizardModel(cb, "1.0.0")
+	model.height = 20
+
+	updated, _ := model.Update(menuSelectMsg{item: "Install service"})
+	wm := updated.(WizardModel)
+	updated, _ = wm.Update(serviceSelectMsg{entry: catalog.FromCurated(service.Service{Name: "sentry"})})
+	wm = updated.(WizardModel)
+	updated, _ = wm.Update(targetSelectMsg{targets: testMockTargets()[:1]})
+	wm = updated.(WizardModel)
+
+	updated, _ = wm.Update(reviewPreviewMsg{})
+	wm = updated.(WizardModel)
+
+	preview, isPreview := wm.screen.(*PreviewScreen)
+	require.True(t, isPreview)
+	assert.Contains(t, preview.View(), `"sentry"`)
+	assert.Equal(t, "install", previewedAction)
+	assert.Equal(t, "sentry", previewedService)
+
+	updated, _ = wm.Update(BackMsg{})
+	wm = updated.(WizardModel)
+
+	_, isReview := wm.screen.(*ReviewScreen)
+	assert.True(t, isReview)
+}
+
+func TestWizardModel_ConfigChangedDuringReviewAsksBeforeApply(t *testing.T) {
+	cb := testCallbacks()
+	watched := 0
+	var changed []string
+	cb.WatchConfigs = func([]targetpkg.Target) func() []string {
+		watched++
+		return func() []string { return changed }
+	}
+	model := NewWizardModel(cb, "1.0.0")
+	model.height = 20
+
+	updated, _ := model.Update(menuSelectMsg{item: "Install service"})
+	wm := updated.(WizardModel)
+	updated, _ = wm.Update(serviceSelectMsg{entry: catalog.FromCurated(service.Service{Name: "context7"})})
+	wm = updated.(WizardModel)
+	updated, _ = wm.Update(targetSelectMsg{targets: testMockTargets()[:1]})
+	wm = updated.(WizardModel)
+	require.Equal(t, 1, watched)
+
+	changed = []string{"/home/user/.claude.json"}
+	updated, _ = wm.Update(reviewConfirmMsg{confirmed: true})
+	wm = updated.(WizardModel)
+
+	conflict, isConflict := wm.screen.(*ConflictScreen)
+	require.True(t, isConflict)
+	assert.Contains(t, conflict.View(), "/home/user/.claude.json")
+
+	// Review again re-reads the configs.
+	updated, _ = wm.Update(conflictChoiceMsg{apply: false})
+	wm = updated.(WizardModel)
+
+	_, isReview := wm.screen.(*ReviewScreen)
+	require.True(t, isReview)
+	assert.Equal(t, 2, watched)
+
+	// Apply anyway writes on top of the current files.
+	updated, _ = wm.Update(reviewConfirmMsg{confirmed: true})
+	wm = updated.(WizardModel)
+	updated, _ = wm.Update(conflictChoiceMsg{apply: true})
+	wm = updated.(WizardModel)
+
+	_, isApply := wm.screen.(*ApplyScreen)
+	assert.True(t, isApply)
+}
+
+func TestWizardModel_UnchangedConfigsGoStraightToApply(t *testing.T) {
+	cb := testCallbacks()
+	cb.WatchConfigs = func([]targetpkg.Target) func() []string {
+		return func() []string { return nil }
+	}
+	model := NewWizardModel(cb, "1.0.0")
+	model.height = 20
+
+	updated, _ := model.Update(menuSelectMsg{item: "Install service"})
+	wm := updated.(WizardModel)
+	updated, _ = wm.Update(serviceSelectMsg{entry: catalog.FromCurated(service.Service{Name: "context7"})})
+	wm = updated.(WizardModel)
+	updated, _ = wm.Update(targetSelectMsg{targets: testMockTargets()[:1]})
+	wm = updated.(WizardModel)
+	updated, _ = wm.Update(reviewConfirmMsg{confirmed: true})
+	wm = updated.(WizardModel)
+
+	_, isApply := wm.screen.(*ApplyScreen)
+	assert.True(t, isApply)
+}
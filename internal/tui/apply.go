@@ -1,11 +1,14 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/andreagrandi/mcp-wire/internal/diagnostics"
+	"github.com/andreagrandi/mcp-wire/internal/flow"
 	"github.com/andreagrandi/mcp-wire/internal/service"
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
 )
@@ -16,11 +19,18 @@ const (
 	applySubStateDone
 )
 
+// maxParallelApplies bounds how many targets are installed or uninstalled
+// at once.
+const maxParallelApplies = 4
+
 // applyResultMsg carries the result of a single target operation.
 type applyResultMsg struct {
 	index    int
 	err      error
 	authHint string
+	verified bool
+	mismatch error // read-back found the written entry differs from intent
+	outcome  Outcome
 }
 
 // applyPostActionMsg is sent when the user picks a post-completion action.
@@ -35,6 +45,8 @@ type targetResult struct {
 	status   string // "pending", "running", "done", "failed"
 	err      error
 	authHint string
+	verified bool
+	mismatch error
 }
 
 // ApplyCallbacks provides functions the apply screen needs to perform operations.
@@ -44,6 +56,10 @@ type ApplyCallbacks struct {
 	ServiceUsesOAuth        func(svc service.Service) bool
 	OAuthManualHint         func(t targetpkg.Target) string
 	RemoveStoredCredentials func(envNames []string) (int, error)
+	BeginUndo               func(targets []targetpkg.Target) func(description string) bool
+
+	// SuppressedWarnings holds the warning codes the user chose not to see.
+	SuppressedWarnings diagnostics.Suppressions
 }
 
 // ApplyScreen shows per-target progress during install/uninstall and
@@ -56,6 +72,8 @@ type ApplyScreen struct {
 	callbacks   ApplyCallbacks
 
 	results  []targetResult
+	next     int // index of the next target to start
+	finished int // targets whose result has arrived
 	subState int // applySubStateRunning, applySubStateCredCleanup, or applySubStateDone
 	cursor   int // cursor for post-completion choices
 	width    int
@@ -63,6 +81,9 @@ type ApplyScreen struct {
 	hasFailures       bool
 	credCleanupCursor int    // 0 = No, 1 = Yes
 	credCleanupMsg    string // result message after credential cleanup
+
+	commitUndo func(description string) bool
+	undoable   bool // the apply changed configs that "Undo" can restore
 }
 
 // NewApplyScreen creates a new apply screen for the given wizard state.
@@ -98,8 +119,24 @@ func (a *ApplyScreen) Init() tea.Cmd {
 		return nil
 	}
 
-	a.results[0].status = "running"
-	return a.dispatchTarget(0)
+	if a.callbacks.BeginUndo != nil {
+		a.commitUndo = a.callbacks.BeginUndo(a.state.Targets)
+	}
+
+	var cmds []tea.Cmd
+	for a.next < len(a.results) && len(cmds) < maxParallelApplies {
+		cmds = append(cmds, a.startNext())
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// startNext marks the next pending target as running and dispatches it.
+func (a *ApplyScreen) startNext() tea.Cmd {
+	idx := a.next
+	a.next++
+	a.results[idx].status = "running"
+	return a.dispatchTarget(idx)
 }
 
 func (a *ApplyScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
@@ -128,6 +165,11 @@ func (a *ApplyScreen) handleResult(msg applyResultMsg) (Screen, tea.Cmd) {
 		return a, nil
 	}
 
+	if status := a.results[msg.index].status; status == "done" || status == "failed" {
+		return a, nil
+	}
+	a.finished++
+
 	if msg.err != nil {
 		a.results[msg.index].status = "failed"
 		a.results[msg.index].err = msg.err
@@ -135,16 +177,24 @@ func (a *ApplyScreen) handleResult(msg applyResultMsg) (Screen, tea.Cmd) {
 	} else {
 		a.results[msg.index].status = "done"
 		a.results[msg.index].authHint = msg.authHint
+		a.results[msg.index].verified = msg.verified
+		a.results[msg.index].mismatch = msg.mismatch
 	}
 
-	// Start next target if any.
-	next := msg.index + 1
-	if next < len(a.results) {
-		a.results[next].status = "running"
-		return a, a.dispatchTarget(next)
+	// Start the next target, if any, in the slot this one freed.
+	if a.next < len(a.results) {
+		return a, a.startNext()
+	}
+
+	if a.finished < len(a.results) {
+		return a, nil
 	}
 
 	// All done.
+	if a.commitUndo != nil {
+		a.undoable = a.commitUndo(a.state.Action + " " + a.svc.Name)
+	}
+
 	if a.shouldShowCredCleanup() {
 		a.subState = applySubStateCredCleanup
 		a.credCleanupCursor = 0 // default to No, matching CLI's [y/N]
@@ -213,21 +263,10 @@ func (a *ApplyScreen) updateCredCleanup(msg tea.KeyMsg) (Screen, tea.Cmd) {
 // shouldShowCredCleanup returns true when the post-uninstall credential
 // removal prompt should be shown.
 func (a *ApplyScreen) shouldShowCredCleanup() bool {
-	if a.state.Action != "uninstall" {
-		return false
-	}
-	if a.hasFailures {
-		return false
-	}
 	if a.callbacks.RemoveStoredCredentials == nil {
 		return false
 	}
-	for _, ev := range a.svc.Env {
-		if strings.TrimSpace(ev.Name) != "" {
-			return true
-		}
-	}
-	return false
+	return flow.OfferCredentialCleanup(a.state.Action, a.svc, a.hasFailures)
 }
 
 // envVarNames returns deduplicated env var names from the service.
@@ -252,7 +291,7 @@ func (a *ApplyScreen) dispatchTarget(idx int) tea.Cmd {
 	svc := a.svc
 	resolvedEnv := a.resolvedEnv
 	target := a.state.Targets[idx]
-	scope := a.state.Scope
+	scope := a.state.ScopeFor(target)
 	action := a.state.Action
 	callbacks := a.callbacks
 
@@ -269,8 +308,21 @@ func (a *ApplyScreen) dispatchTarget(idx int) tea.Cmd {
 			}
 		}
 
+		// A mismatch on read-back means the entry was written, so it is
+		// reported as a warning rather than a failed target.
+		var mismatch error
+		var verification *targetpkg.VerificationError
+		if errors.As(err, &verification) {
+			mismatch = err
+			err = nil
+		}
+
 		var authHint string
+		var verified bool
 		if err == nil && action != "uninstall" {
+			_, canVerify := target.(targetpkg.Verifier)
+			verified = canVerify && mismatch == nil
+
 			if callbacks.ServiceUsesOAuth != nil && callbacks.ServiceUsesOAuth(svc) {
 				if callbacks.OAuthManualHint != nil {
 					authHint = callbacks.OAuthManualHint(target)
@@ -278,10 +330,16 @@ func (a *ApplyScreen) dispatchTarget(idx int) tea.Cmd {
 			}
 		}
 
+		outcome := newOutcome(action, svc.Name, target.Slug(), err)
+		outcome.Scope = string(scope)
+
 		return applyResultMsg{
 			index:    idx,
 			err:      err,
 			authHint: authHint,
+			verified: verified,
+			mismatch: mismatch,
+			outcome:  outcome,
 		}
 	}
 }
@@ -323,11 +381,17 @@ func (a *ApplyScreen) View() string {
 	}
 
 	if a.subState == applySubStateDone {
+		// Read-back mismatches.
+		for _, r := range a.results {
+			if r.mismatch != nil && r.status == "done" {
+				b.WriteString(a.renderWarning(diagnostics.New(diagnostics.VerificationFailed, "%s: %s", r.name, r.mismatch)))
+			}
+		}
+
 		// Auth hints.
 		for _, r := range a.results {
 			if r.authHint != "" && r.status == "done" {
-				b.WriteString(a.theme.Warning.Render(fmt.Sprintf("  [!] %s: %s", r.name, r.authHint)))
-				b.WriteString("\n")
+				b.WriteString(a.renderWarning(diagnostics.New(diagnostics.ManualAuthentication, "%s: %s", r.name, r.authHint)))
 			}
 		}
 
@@ -402,6 +466,11 @@ func (a *ApplyScreen) renderTargetRow(r targetResult) string {
 			statusLabel = "removed"
 		} else {
 			statusLabel = "configured"
+			if r.mismatch != nil {
+				statusLabel = "configured (not verified)"
+			} else if r.verified {
+				statusLabel = "configured (verified)"
+			}
 		}
 	} else if r.status == "failed" && r.err != nil {
 		statusLabel = fmt.Sprintf("failed \u2014 %s", r.err.Error())
@@ -410,15 +479,18 @@ func (a *ApplyScreen) renderTargetRow(r targetResult) string {
 	return fmt.Sprintf("%s %-16s %s", icon, r.name, statusLabel)
 }
 
-func (a *ApplyScreen) equivalentCommand() string {
-	cmd := "mcp-wire " + a.state.Action + " " + a.state.Entry.Name
-	for _, t := range a.state.Targets {
-		cmd += " --target " + t.Slug()
-	}
-	if a.state.Scope == targetpkg.ConfigScopeProject {
-		cmd += " --scope project"
+// renderWarning returns warning as a "[!]" line, or nothing when its code is
+// suppressed.
+func (a *ApplyScreen) renderWarning(warning diagnostics.Warning) string {
+	if a.callbacks.SuppressedWarnings.Suppressed(warning.Code) {
+		return ""
 	}
-	return cmd
+
+	return a.theme.Warning.Render("  [!] "+warning.String()) + "\n"
+}
+
+func (a *ApplyScreen) equivalentCommand() string {
+	return equivalentCommand(a.state)
 }
 
 type postActionChoice struct {
@@ -436,11 +508,15 @@ func (a *ApplyScreen) postActionChoices() []postActionChoice {
 		actionLabel = "Install another"
 	}
 
-	return []postActionChoice{
-		{label: actionLabel, action: "another"},
-		{label: "Back to menu", action: "menu"},
-		{label: "Exit", action: "exit"},
+	choices := []postActionChoice{{label: actionLabel, action: "another"}}
+	if a.undoable {
+		choices = append(choices, postActionChoice{label: menuItemUndo, action: "undo"})
 	}
+
+	return append(choices,
+		postActionChoice{label: "Back to menu", action: "menu"},
+		postActionChoice{label: "Exit", action: "exit"},
+	)
 }
 
 func (a *ApplyScreen) renderPostActionChoices() string {
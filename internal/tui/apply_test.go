@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/diagnostics"
 	"github.com/andreagrandi/mcp-wire/internal/service"
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
 )
@@ -58,7 +59,28 @@ func TestNewApplyScreen(t *testing.T) {
 	assert.Equal(t, "pending", results[1].status)
 }
 
-func TestApplyScreen_Init_StartsFirstTarget(t *testing.T) {
+// applyResults runs the commands Init or Update returned, as the runtime
+// would, and returns the results they produce in target order.
+func applyResults(t *testing.T, cmd tea.Cmd) []applyResultMsg {
+	t.Helper()
+	require.NotNil(t, cmd)
+
+	var results []applyResultMsg
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, batched := range msg {
+			results = append(results, applyResults(t, batched)...)
+		}
+	case applyResultMsg:
+		results = append(results, msg)
+	default:
+		t.Fatalf("unexpected message %T", msg)
+	}
+
+	return results
+}
+
+func TestApplyScreen_Init_StartsTargetsInParallel(t *testing.T) {
 	theme := NewTheme()
 	screen := NewApplyScreen(theme, testApplyState(), testApplyService(), nil, testApplyCallbacks())
 
@@ -67,7 +89,34 @@ func TestApplyScreen_Init_StartsFirstTarget(t *testing.T) {
 
 	results := screen.Results()
 	assert.Equal(t, "running", results[0].status)
-	assert.Equal(t, "pending", results[1].status)
+	assert.Equal(t, "running", results[1].status)
+}
+
+func TestApplyScreen_Init_BoundsRunningTargets(t *testing.T) {
+	theme := NewTheme()
+	state := testApplyState()
+	state.Targets = nil
+	for _, slug := range []string{"a", "b", "c", "d", "e", "f"} {
+		state.Targets = append(state.Targets, &mockTarget{name: slug, slug: slug, installed: true})
+	}
+	screen := NewApplyScreen(theme, state, testApplyService(), nil, testApplyCallbacks())
+
+	results := applyResults(t, screen.Init())
+	require.Len(t, results, maxParallelApplies)
+	assert.Equal(t, "running", screen.Results()[maxParallelApplies-1].status)
+	assert.Equal(t, "pending", screen.Results()[maxParallelApplies].status)
+
+	// A result that arrives out of order frees a slot for the next target.
+	s, cmd := screen.Update(results[2])
+	updated := s.(*ApplyScreen)
+	assert.Equal(t, "done", updated.Results()[2].status)
+	assert.Equal(t, "running", updated.Results()[maxParallelApplies].status)
+	assert.Equal(t, maxParallelApplies, applyResults(t, cmd)[0].index)
+
+	// A repeated result is not counted twice.
+	_, cmd = updated.Update(results[2])
+	assert.Nil(t, cmd)
+	assert.Equal(t, "pending", updated.Results()[maxParallelApplies+1].status)
 }
 
 func TestApplyScreen_Init_EmptyTargets(t *testing.T) {
@@ -86,14 +135,15 @@ func TestApplyScreen_ResultSuccess(t *testing.T) {
 	screen := NewApplyScreen(theme, testApplyState(), testApplyService(), nil, testApplyCallbacks())
 	screen.Init()
 
-	// First target succeeds.
+	// First target succeeds while the second is still running.
 	s, cmd := screen.Update(applyResultMsg{index: 0, err: nil})
 	updated := s.(*ApplyScreen)
 
 	results := updated.Results()
 	assert.Equal(t, "done", results[0].status)
 	assert.Equal(t, "running", results[1].status)
-	assert.NotNil(t, cmd) // dispatches next target
+	assert.Nil(t, cmd) // no target left to start
+	assert.Equal(t, applySubStateRunning, updated.ApplySubState())
 }
 
 func TestApplyScreen_ResultFailure(t *testing.T) {
@@ -109,7 +159,8 @@ func TestApplyScreen_ResultFailure(t *testing.T) {
 	assert.Equal(t, "failed", results[0].status)
 	assert.Equal(t, "file not found", results[0].err.Error())
 	assert.Equal(t, "running", results[1].status)
-	assert.NotNil(t, cmd) // still dispatches next target
+	assert.Nil(t, cmd)
+	assert.Equal(t, applySubStateRunning, updated.ApplySubState())
 }
 
 func TestApplyScreen_AllDone(t *testing.T) {
@@ -426,6 +477,28 @@ func TestApplyScreen_EquivalentCommandShowsScope(t *testing.T) {
 	assert.Contains(t, view, "--scope project")
 }
 
+func TestApplyScreen_DispatchUsesPerTargetScope(t *testing.T) {
+	state := testApplyState()
+	state.TargetScopes = map[string]targetpkg.ConfigScope{"codex": targetpkg.ConfigScopeProject}
+
+	scopes := map[string]targetpkg.ConfigScope{}
+	callbacks := testApplyCallbacks()
+	callbacks.InstallTarget = func(_ service.Service, _ map[string]string, target targetpkg.Target, scope targetpkg.ConfigScope) error {
+		scopes[target.Slug()] = scope
+		return nil
+	}
+	screen := NewApplyScreen(NewTheme(), state, testApplyService(), nil, callbacks)
+
+	screen.dispatchTarget(0)()
+	msg := screen.dispatchTarget(1)().(applyResultMsg)
+
+	assert.Equal(t, map[string]targetpkg.ConfigScope{
+		"claude": targetpkg.ConfigScopeUser,
+		"codex":  targetpkg.ConfigScopeProject,
+	}, scopes)
+	assert.Equal(t, "project", msg.outcome.Scope)
+}
+
 func TestApplyScreen_AuthHintShown(t *testing.T) {
 	theme := NewTheme()
 	callbacks := testApplyCallbacks()
@@ -457,7 +530,9 @@ func TestApplyScreen_DispatchCallsInstall(t *testing.T) {
 			return nil
 		},
 	}
-	screen := NewApplyScreen(theme, testApplyState(), testApplyService(), nil, callbacks)
+	state := testApplyState()
+	state.Targets = state.Targets[:1]
+	screen := NewApplyScreen(theme, state, testApplyService(), nil, callbacks)
 	cmd := screen.Init()
 	require.NotNil(t, cmd)
 
@@ -481,6 +556,7 @@ func TestApplyScreen_DispatchCallsUninstall(t *testing.T) {
 	}
 	state := testApplyState()
 	state.Action = "uninstall"
+	state.Targets = state.Targets[:1]
 	screen := NewApplyScreen(theme, state, testApplyService(), nil, callbacks)
 	cmd := screen.Init()
 	require.NotNil(t, cmd)
@@ -835,3 +911,108 @@ func TestApplyScreen_EnvVarNames(t *testing.T) {
 	names := screen.envVarNames()
 	assert.Equal(t, []string{"SENTRY_AUTH_TOKEN", "SENTRY_ORG"}, names)
 }
+
+func TestApplyScreen_VerificationMismatchIsWarning(t *testing.T) {
+	theme := NewTheme()
+	callbacks := testApplyCallbacks()
+	callbacks.InstallTarget = func(svc service.Service, _ map[string]string, _ targetpkg.Target, _ targetpkg.ConfigScope) error {
+		return &targetpkg.VerificationError{Service: svc.Name, Dropped: []string{"cwd"}}
+	}
+
+	screen := NewApplyScreen(theme, testApplyState(), testApplyService(), nil, callbacks)
+	cmd := screen.Init()
+	require.NotNil(t, cmd)
+
+	msg := applyResults(t, cmd)[0]
+	assert.NoError(t, msg.err)
+	assert.Error(t, msg.mismatch)
+	assert.Empty(t, msg.outcome.Error)
+
+	s, _ := screen.Update(msg)
+	updated := s.(*ApplyScreen)
+	s, _ = updated.Update(applyResultMsg{index: 1, err: nil})
+	updated = s.(*ApplyScreen)
+
+	assert.False(t, updated.hasFailures)
+	view := updated.View()
+	assert.Contains(t, view, "configured (not verified)")
+	assert.Contains(t, view, "dropped cwd")
+}
+
+func TestApplyScreen_SuppressedWarningIsHidden(t *testing.T) {
+	theme := NewTheme()
+	callbacks := testApplyCallbacks()
+	callbacks.SuppressedWarnings = diagnostics.Suppressions{diagnostics.VerificationFailed: true}
+
+	screen := NewApplyScreen(theme, testApplyState(), testApplyService(), nil, callbacks)
+	screen.Init()
+
+	mismatch := &targetpkg.VerificationError{Service: "sentry", Dropped: []string{"cwd"}}
+	s, _ := screen.Update(applyResultMsg{index: 0, mismatch: mismatch})
+	updated := s.(*ApplyScreen)
+	s, _ = updated.Update(applyResultMsg{index: 1, authHint: "run /mcp"})
+	updated = s.(*ApplyScreen)
+
+	view := updated.View()
+	assert.NotContains(t, view, "MW102")
+	assert.NotContains(t, view, "dropped cwd")
+	assert.Contains(t, view, "[!] [MW103]")
+	assert.Contains(t, view, "run /mcp")
+}
+
+func TestApplyScreen_VerifiedTargetLabel(t *testing.T) {
+	theme := NewTheme()
+	screen := NewApplyScreen(theme, testApplyState(), testApplyService(), nil, testApplyCallbacks())
+	screen.Init()
+
+	s, _ := screen.Update(applyResultMsg{index: 0, verified: true})
+	updated := s.(*ApplyScreen)
+
+	assert.Contains(t, updated.View(), "configured (verified)")
+}
+
+func TestApplyScreen_UndoOfferedWhenApplyChangedConfigs(t *testing.T) {
+	theme := NewTheme()
+	callbacks := testApplyCallbacks()
+
+	var snapshotTargets int
+	var recorded string
+	callbacks.BeginUndo = func(targets []targetpkg.Target) func(string) bool {
+		snapshotTargets = len(targets)
+		return func(description string) bool {
+			recorded = description
+			return true
+		}
+	}
+
+	screen := NewApplyScreen(theme, testApplyState(), testApplyService(), nil, callbacks)
+	screen.Init()
+	assert.Equal(t, 2, snapshotTargets)
+
+	screen = finishAllTargets(screen, 2)
+	assert.Equal(t, "install sentry", recorded)
+	assert.Contains(t, screen.View(), "Undo last change")
+
+	// Move to "Undo last change" (index 1).
+	s, _ := screen.Update(tea.KeyMsg{Type: tea.KeyRight})
+	_, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	action, ok := cmd().(applyPostActionMsg)
+	require.True(t, ok)
+	assert.Equal(t, "undo", action.action)
+}
+
+func TestApplyScreen_UndoHiddenWhenNothingChanged(t *testing.T) {
+	theme := NewTheme()
+	callbacks := testApplyCallbacks()
+	callbacks.BeginUndo = func(_ []targetpkg.Target) func(string) bool {
+		return func(string) bool { return false }
+	}
+
+	screen := NewApplyScreen(theme, testApplyState(), testApplyService(), nil, callbacks)
+	screen.Init()
+	screen = finishAllTargets(screen, 2)
+
+	assert.NotContains(t, screen.View(), "Undo last change")
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// conflictChoiceMsg is sent when the user picks how to go on after target
+// configs changed on disk since the review.
+type conflictChoiceMsg struct {
+	apply bool
+}
+
+// ConflictScreen lists the target configs that another program changed
+// while the review was open, and asks whether to review the operation again
+// against the files as they are now or to apply it to them as it stands.
+type ConflictScreen struct {
+	theme  Theme
+	paths  []string
+	cursor int // 0 = Review again, 1 = Apply anyway
+	width  int
+}
+
+// NewConflictScreen creates a screen for the config files in paths.
+func NewConflictScreen(theme Theme, paths []string) *ConflictScreen {
+	return &ConflictScreen{
+		theme: theme,
+		paths: paths,
+	}
+}
+
+func (c *ConflictScreen) Init() tea.Cmd { return nil }
+
+func (c *ConflictScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.width = msg.Width
+		return c, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "left", "h":
+			if c.cursor > 0 {
+				c.cursor--
+			}
+		case "right", "l":
+			if c.cursor < 1 {
+				c.cursor++
+			}
+		case "enter":
+			apply := c.cursor == 1
+			return c, func() tea.Msg {
+				return conflictChoiceMsg{apply: apply}
+			}
+		case "esc":
+			return c, func() tea.Msg { return BackMsg{} }
+		}
+	}
+
+	return c, nil
+}
+
+func (c *ConflictScreen) View() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(c.theme.Warning.Render("  \u26a0 Config changed on disk since the review"))
+	b.WriteString("\n\n")
+
+	for _, path := range c.paths {
+		b.WriteString("  " + path + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString("  Another program wrote these files while the review was open.\n")
+	b.WriteString(c.theme.Dim.Render("  Review again to re-read them, or apply the change on top of their"))
+	b.WriteString("\n")
+	b.WriteString(c.theme.Dim.Render("  current content; their other entries are kept either way."))
+	b.WriteString("\n\n")
+	b.WriteString(c.renderChoices())
+
+	return b.String()
+}
+
+func (c *ConflictScreen) renderChoices() string {
+	labels := []string{"Review again", "Apply anyway"}
+	var parts []string
+
+	for i, label := range labels {
+		if i == c.cursor {
+			if c.width > 0 {
+				parts = append(parts, c.theme.Highlight.Render(" "+label+" "))
+			} else {
+				parts = append(parts, c.theme.Cursor.Render("["+label+"]"))
+			}
+		} else {
+			parts = append(parts, c.theme.Dim.Render(" "+label+" "))
+		}
+	}
+
+	return "  " + strings.Join(parts, "  ")
+}
+
+func (c *ConflictScreen) StatusHints() []KeyHint {
+	return []KeyHint{
+		{Key: "\u2190\u2192", Desc: "choose"},
+		{Key: "Enter", Desc: "confirm"},
+		{Key: "Esc", Desc: "review again"},
+	}
+}
+
+// Cursor returns the current cursor position (for testing).
+func (c *ConflictScreen) Cursor() int {
+	return c.cursor
+}
@@ -13,8 +13,13 @@ import (
 const (
 	credSubStateInput = iota
 	credSubStateSave
+	credSubStatePasted
 )
 
+// pastePreviewLen is how many characters of a pasted value are shown at
+// each end when asking to confirm it.
+const pastePreviewLen = 4
+
 // credentialDoneMsg is sent when all credentials have been resolved.
 type credentialDoneMsg struct {
 	resolvedEnv map[string]string
@@ -30,13 +35,17 @@ type CredentialScreen struct {
 	resolved map[string]string
 
 	textInput textinput.Model
-	subState  int // credSubStateInput or credSubStateSave
+	subState  int // credSubStateInput, credSubStateSave or credSubStatePasted
 
 	saveCursor       int // 0 = No, 1 = Yes
 	lastEnteredValue string
 
+	pastedValue string
+	pasteError  string
+
 	storeCredential func(name, value string) error
 	openURL         func(string) error
+	readClipboard   func() (string, error)
 
 	width int
 }
@@ -73,6 +82,12 @@ func NewCredentialScreen(
 	}
 }
 
+// SetReadClipboard sets how the clipboard is read when the user pastes a
+// value with Ctrl+V.
+func (c *CredentialScreen) SetReadClipboard(readClipboard func() (string, error)) {
+	c.readClipboard = readClipboard
+}
+
 func (c *CredentialScreen) Init() tea.Cmd {
 	return c.textInput.Focus()
 }
@@ -89,6 +104,8 @@ func (c *CredentialScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 			return c.updateInput(msg)
 		case credSubStateSave:
 			return c.updateSave(msg)
+		case credSubStatePasted:
+			return c.updatePasted(msg)
 		}
 	}
 
@@ -103,6 +120,8 @@ func (c *CredentialScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 }
 
 func (c *CredentialScreen) updateInput(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	c.pasteError = ""
+
 	switch msg.String() {
 	case "enter":
 		value := strings.TrimSpace(c.textInput.Value())
@@ -110,17 +129,28 @@ func (c *CredentialScreen) updateInput(msg tea.KeyMsg) (Screen, tea.Cmd) {
 			return c, nil
 		}
 
-		c.lastEnteredValue = value
+		return c.submit(value)
 
-		// If we can store credentials, ask whether to save.
-		if c.storeCredential != nil {
-			c.subState = credSubStateSave
-			c.saveCursor = 1 // default to Yes
+	case "ctrl+v":
+		if c.readClipboard == nil {
+			break
+		}
+
+		value, err := c.readClipboard()
+		if err != nil {
+			c.pasteError = "Cannot read the clipboard: " + err.Error()
 			return c, nil
 		}
 
-		// No store available — accept and advance.
-		return c.acceptAndAdvance()
+		value = strings.TrimSpace(value)
+		if value == "" {
+			c.pasteError = "The clipboard is empty."
+			return c, nil
+		}
+
+		c.pastedValue = value
+		c.subState = credSubStatePasted
+		return c, nil
 
 	case "esc":
 		return c, func() tea.Msg { return BackMsg{} }
@@ -140,6 +170,39 @@ func (c *CredentialScreen) updateInput(msg tea.KeyMsg) (Screen, tea.Cmd) {
 	return c, cmd
 }
 
+// submit takes value as the current credential, asking whether to save it
+// when a credential store is available.
+func (c *CredentialScreen) submit(value string) (Screen, tea.Cmd) {
+	c.lastEnteredValue = value
+
+	// If we can store credentials, ask whether to save.
+	if c.storeCredential != nil {
+		c.subState = credSubStateSave
+		c.saveCursor = 1 // default to Yes
+		return c, nil
+	}
+
+	// No store available — accept and advance.
+	return c.acceptAndAdvance()
+}
+
+// updatePasted handles the confirmation of a value pasted from the
+// clipboard.
+func (c *CredentialScreen) updatePasted(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "y":
+		value := c.pastedValue
+		c.pastedValue = ""
+		return c.submit(value)
+	case "esc", "n":
+		c.pastedValue = ""
+		c.subState = credSubStateInput
+		return c, c.textInput.Focus()
+	}
+
+	return c, nil
+}
+
 func (c *CredentialScreen) updateSave(msg tea.KeyMsg) (Screen, tea.Cmd) {
 	switch msg.String() {
 	case "left", "h":
@@ -224,6 +287,15 @@ func (c *CredentialScreen) View() string {
 	case credSubStateInput:
 		b.WriteString(c.textInput.View())
 		b.WriteString("\n")
+		if c.pasteError != "" {
+			b.WriteString("\n")
+			b.WriteString(c.theme.Warning.Render("  " + c.pasteError))
+			b.WriteString("\n")
+		}
+
+	case credSubStatePasted:
+		b.WriteString(fmt.Sprintf("  Pasted %s (%d characters).\n\n", pastePreview(c.pastedValue), len([]rune(c.pastedValue))))
+		b.WriteString("  Use this value?\n")
 
 	case credSubStateSave:
 		b.WriteString(c.theme.Completed.Render("  Value entered."))
@@ -236,6 +308,18 @@ func (c *CredentialScreen) View() string {
 	return b.String()
 }
 
+// pastePreview shows the first and last few characters of a pasted value,
+// enough to tell whether the right token was copied. Values too short to
+// hide anything in between are masked entirely.
+func pastePreview(value string) string {
+	runes := []rune(value)
+	if len(runes) <= pastePreviewLen*3 {
+		return strings.Repeat("*", 8)
+	}
+
+	return string(runes[:pastePreviewLen]) + "…" + string(runes[len(runes)-pastePreviewLen:])
+}
+
 func (c *CredentialScreen) renderSaveChoices() string {
 	labels := []string{"No", "Yes"}
 	var parts []string
@@ -264,11 +348,22 @@ func (c *CredentialScreen) StatusHints() []KeyHint {
 		}
 	}
 
+	if c.subState == credSubStatePasted {
+		return []KeyHint{
+			{Key: "Enter", Desc: "use value"},
+			{Key: "Esc", Desc: "discard"},
+		}
+	}
+
 	hints := []KeyHint{
 		{Key: "Enter", Desc: "submit"},
 		{Key: "Esc", Desc: "back"},
 	}
 
+	if c.readClipboard != nil {
+		hints = append(hints, KeyHint{Key: "Ctrl+V", Desc: "paste"})
+	}
+
 	ev := c.envVars[c.current]
 	if strings.TrimSpace(ev.SetupURL) != "" {
 		hints = append(hints, KeyHint{Key: "Ctrl+O", Desc: "open URL"})
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -417,6 +418,58 @@ func TestCredentialScreen_NoDescriptionFormat(t *testing.T) {
 	assert.NotContains(t, view, "()")
 }
 
+func TestCredentialScreen_PasteAsksToConfirmTrimmedValue(t *testing.T) {
+	theme := NewTheme()
+	screen := NewCredentialScreen(theme, testSingleEnvVar(), nil, nil, nil)
+	screen.SetReadClipboard(func() (string, error) {
+		return "  ghp_abcdefghijklmnopqrstuvwxyz\n", nil
+	})
+
+	assert.Contains(t, hintDescs(screen.StatusHints()), "paste")
+
+	s, _ := screen.Update(tea.KeyMsg{Type: tea.KeyCtrlV})
+	updated := s.(*CredentialScreen)
+	assert.Equal(t, credSubStatePasted, updated.SubState())
+
+	view := updated.View()
+	assert.Contains(t, view, "Pasted ghp_…wxyz (30 characters).")
+	assert.NotContains(t, view, "abcdefghijklmnopqrstuv")
+
+	_, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	done, ok := cmd().(credentialDoneMsg)
+	require.True(t, ok)
+	assert.Equal(t, "ghp_abcdefghijklmnopqrstuvwxyz", done.resolvedEnv["TOKEN"])
+}
+
+func TestCredentialScreen_PasteDiscardReturnsToInput(t *testing.T) {
+	theme := NewTheme()
+	screen := NewCredentialScreen(theme, testSingleEnvVar(), nil, nil, nil)
+	screen.SetReadClipboard(func() (string, error) { return "short", nil })
+
+	screen.Update(tea.KeyMsg{Type: tea.KeyCtrlV})
+	assert.Contains(t, screen.View(), "Pasted ******** (5 characters).")
+
+	s, _ := screen.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	updated := s.(*CredentialScreen)
+	assert.Equal(t, credSubStateInput, updated.SubState())
+	assert.Empty(t, updated.Resolved())
+}
+
+func TestCredentialScreen_PasteReportsUnreadableClipboard(t *testing.T) {
+	theme := NewTheme()
+	screen := NewCredentialScreen(theme, testSingleEnvVar(), nil, nil, nil)
+	screen.SetReadClipboard(func() (string, error) { return "", errors.New("no clipboard utility") })
+
+	screen.Update(tea.KeyMsg{Type: tea.KeyCtrlV})
+	assert.Equal(t, credSubStateInput, screen.SubState())
+	assert.Contains(t, screen.View(), "Cannot read the clipboard: no clipboard utility")
+
+	screen.SetReadClipboard(func() (string, error) { return " \n", nil })
+	screen.Update(tea.KeyMsg{Type: tea.KeyCtrlV})
+	assert.Contains(t, screen.View(), "The clipboard is empty.")
+}
+
 // typeText simulates typing a string into a credential screen's textinput.
 func typeText(screen *CredentialScreen, text string) {
 	for _, r := range text {
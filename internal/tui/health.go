@@ -0,0 +1,381 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// HealthLevel grades a health check.
+type HealthLevel int
+
+const (
+	HealthOK HealthLevel = iota
+	HealthWarn
+	HealthFail
+)
+
+// HealthCheck is one row of the health dashboard. Rows with an empty Service
+// describe the target itself (detection, config file).
+type HealthCheck struct {
+	Service     string
+	Target      targetpkg.Target
+	Level       HealthLevel
+	Detail      string
+	Credentials []string // env vars the user can update from the dashboard
+	OAuth       bool
+}
+
+// HealthCallbacks provides the data and remediation actions the health
+// screen needs.
+type HealthCallbacks struct {
+	HealthChecks        func() []HealthCheck
+	ReinstallService    func(name string, t targetpkg.Target) error
+	AuthenticateService func(name string, t targetpkg.Target, stdin io.Reader, stdout, stderr io.Writer) error
+	OAuthManualHint     func(t targetpkg.Target) string
+	StoreCredential     func(envName, value string) error
+}
+
+// healthLoadedMsg carries freshly computed health checks.
+type healthLoadedMsg struct {
+	checks []HealthCheck
+}
+
+// healthActionMsg reports the outcome of a remediation action.
+type healthActionMsg struct {
+	message string
+	err     error
+	outcome *Outcome // set for operations that change a target config
+}
+
+// HealthScreen shows per-service, per-target health with one-key fixes.
+type HealthScreen struct {
+	theme     Theme
+	callbacks HealthCallbacks
+	checks    []HealthCheck
+	cursor    int
+	width     int
+	loading   bool
+	message   string
+	failed    bool
+
+	editing   bool
+	editEnv   string
+	textInput textinput.Model
+}
+
+// NewHealthScreen creates the health dashboard screen.
+func NewHealthScreen(theme Theme, callbacks HealthCallbacks) *HealthScreen {
+	ti := textinput.New()
+	ti.Prompt = "  Value: "
+	ti.EchoMode = textinput.EchoPassword
+	ti.EchoCharacter = '*'
+
+	return &HealthScreen{
+		theme:     theme,
+		callbacks: callbacks,
+		loading:   true,
+		textInput: ti,
+	}
+}
+
+func (h *HealthScreen) Init() tea.Cmd {
+	return h.loadChecks()
+}
+
+func (h *HealthScreen) loadChecks() tea.Cmd {
+	fn := h.callbacks.HealthChecks
+	return func() tea.Msg {
+		if fn == nil {
+			return healthLoadedMsg{}
+		}
+		return healthLoadedMsg{checks: fn()}
+	}
+}
+
+func (h *HealthScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h.width = msg.Width
+		return h, nil
+
+	case healthLoadedMsg:
+		h.checks = msg.checks
+		h.loading = false
+		if h.cursor >= len(h.checks) {
+			h.cursor = max(len(h.checks)-1, 0)
+		}
+		return h, nil
+
+	case healthActionMsg:
+		h.failed = msg.err != nil
+		if msg.err != nil {
+			h.message = msg.err.Error()
+		} else {
+			h.message = msg.message
+		}
+		h.loading = true
+		return h, h.loadChecks()
+
+	case tea.KeyMsg:
+		if h.editing {
+			return h.updateEditing(msg)
+		}
+		return h.updateList(msg)
+	}
+
+	return h, nil
+}
+
+func (h *HealthScreen) updateList(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if h.cursor > 0 {
+			h.cursor--
+		}
+	case "down", "j":
+		if h.cursor < len(h.checks)-1 {
+			h.cursor++
+		}
+	case "esc":
+		return h, func() tea.Msg { return BackMsg{} }
+	case "r":
+		return h, h.reinstall()
+	case "a":
+		return h, h.reauthenticate()
+	case "c":
+		return h, h.startCredentialEdit()
+	}
+
+	return h, nil
+}
+
+func (h *HealthScreen) updateEditing(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		h.editing = false
+		h.textInput.Blur()
+		return h, nil
+	case "enter":
+		value := strings.TrimSpace(h.textInput.Value())
+		envName := h.editEnv
+		h.editing = false
+		h.textInput.Blur()
+		if value == "" || h.callbacks.StoreCredential == nil {
+			return h, nil
+		}
+		store := h.callbacks.StoreCredential
+		return h, func() tea.Msg {
+			if err := store(envName, value); err != nil {
+				return healthActionMsg{err: fmt.Errorf("store %s: %w", envName, err)}
+			}
+			return healthActionMsg{message: envName + " updated."}
+		}
+	}
+
+	var cmd tea.Cmd
+	h.textInput, cmd = h.textInput.Update(msg)
+	return h, cmd
+}
+
+func (h *HealthScreen) selected() (HealthCheck, bool) {
+	if h.loading || h.cursor < 0 || h.cursor >= len(h.checks) {
+		return HealthCheck{}, false
+	}
+	return h.checks[h.cursor], true
+}
+
+func (h *HealthScreen) reinstall() tea.Cmd {
+	check, ok := h.selected()
+	if !ok || check.Service == "" || h.callbacks.ReinstallService == nil {
+		return nil
+	}
+
+	reinstall := h.callbacks.ReinstallService
+	return func() tea.Msg {
+		err := reinstall(check.Service, check.Target)
+		outcome := newOutcome("reinstall", check.Service, check.Target.Slug(), err)
+		if err != nil {
+			return healthActionMsg{
+				err:     fmt.Errorf("reinstall %s on %s: %w", check.Service, check.Target.Name(), err),
+				outcome: &outcome,
+			}
+		}
+		return healthActionMsg{
+			message: fmt.Sprintf("Reinstalled %s on %s.", check.Service, check.Target.Name()),
+			outcome: &outcome,
+		}
+	}
+}
+
+func (h *HealthScreen) reauthenticate() tea.Cmd {
+	check, ok := h.selected()
+	if !ok || check.Service == "" || !check.OAuth {
+		return nil
+	}
+
+	if _, supportsAuth := check.Target.(targetpkg.AuthTarget); !supportsAuth || h.callbacks.AuthenticateService == nil {
+		hint := "Automatic OAuth is not supported by " + check.Target.Name() + "."
+		if h.callbacks.OAuthManualHint != nil {
+			if manual := h.callbacks.OAuthManualHint(check.Target); manual != "" {
+				hint = manual
+			}
+		}
+		h.message = hint
+		h.failed = false
+		return nil
+	}
+
+	authenticate := h.callbacks.AuthenticateService
+	return tea.Exec(&authExecCommand{
+		run: func(stdin io.Reader, stdout, stderr io.Writer) error {
+			return authenticate(check.Service, check.Target, stdin, stdout, stderr)
+		},
+	}, func(err error) tea.Msg {
+		if err != nil {
+			return healthActionMsg{err: fmt.Errorf("authenticate %s on %s: %w", check.Service, check.Target.Name(), err)}
+		}
+		return healthActionMsg{message: fmt.Sprintf("Authenticated %s on %s.", check.Service, check.Target.Name())}
+	})
+}
+
+func (h *HealthScreen) startCredentialEdit() tea.Cmd {
+	check, ok := h.selected()
+	if !ok || len(check.Credentials) == 0 {
+		return nil
+	}
+
+	h.editing = true
+	h.editEnv = check.Credentials[0]
+	h.textInput.SetValue("")
+	return h.textInput.Focus()
+}
+
+func (h *HealthScreen) View() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+
+	if h.loading && len(h.checks) == 0 {
+		b.WriteString(h.theme.Dim.Render("  Checking targets and services..."))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	if len(h.checks) == 0 {
+		b.WriteString(h.theme.Dim.Render("  No installed targets found."))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	for i, check := range h.checks {
+		subject := check.Target.Name()
+		if check.Service != "" {
+			subject = check.Service + " @ " + check.Target.Name()
+		}
+
+		line := fmt.Sprintf("%s %s", healthIndicator(check.Level), subject)
+		if i == h.cursor {
+			label := "  \u276f " + line
+			if h.width > 0 {
+				b.WriteString(h.theme.Highlight.Width(h.width).Render(label))
+			} else {
+				b.WriteString(h.theme.Cursor.Render(label))
+			}
+		} else {
+			b.WriteString("    " + h.levelStyle(check.Level).Render(line))
+		}
+		b.WriteString("\n")
+
+		if check.Detail != "" {
+			b.WriteString(h.theme.Dim.Render("      " + check.Detail))
+			b.WriteString("\n")
+		}
+	}
+
+	if h.editing {
+		b.WriteString("\n")
+		b.WriteString("  Update " + h.editEnv + "\n")
+		b.WriteString(h.textInput.View())
+		b.WriteString("\n")
+	}
+
+	if h.message != "" {
+		b.WriteString("\n")
+		if h.failed {
+			b.WriteString(h.theme.Error.Render("  " + h.message))
+		} else {
+			b.WriteString(h.theme.Completed.Render("  " + h.message))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (h *HealthScreen) levelStyle(level HealthLevel) lipgloss.Style {
+	switch level {
+	case HealthFail:
+		return h.theme.Error
+	case HealthWarn:
+		return h.theme.Warning
+	default:
+		return h.theme.Normal
+	}
+}
+
+func healthIndicator(level HealthLevel) string {
+	switch level {
+	case HealthFail:
+		return "\u2717"
+	case HealthWarn:
+		return "!"
+	default:
+		return "\u2713"
+	}
+}
+
+func (h *HealthScreen) StatusHints() []KeyHint {
+	if h.editing {
+		return []KeyHint{
+			{Key: "Enter", Desc: "save"},
+			{Key: "Esc", Desc: "cancel"},
+		}
+	}
+
+	return []KeyHint{
+		{Key: "\u2191\u2193", Desc: "move"},
+		{Key: "r", Desc: "reinstall"},
+		{Key: "a", Desc: "re-auth"},
+		{Key: "c", Desc: "credential"},
+		{Key: "Esc", Desc: "back"},
+	}
+}
+
+// Checks returns the loaded health checks (for testing).
+func (h *HealthScreen) Checks() []HealthCheck {
+	return h.checks
+}
+
+// authExecCommand adapts an interactive authentication callback to
+// tea.ExecCommand so it runs with the terminal released from the TUI.
+type authExecCommand struct {
+	run    func(stdin io.Reader, stdout, stderr io.Writer) error
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (c *authExecCommand) Run() error {
+	return c.run(c.stdin, c.stdout, c.stderr)
+}
+
+func (c *authExecCommand) SetStdin(r io.Reader)  { c.stdin = r }
+func (c *authExecCommand) SetStdout(w io.Writer) { c.stdout = w }
+func (c *authExecCommand) SetStderr(w io.Writer) { c.stderr = w }
@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func testHealthChecks() []HealthCheck {
+	claude := &mockTarget{name: "Claude Code", slug: "claude", installed: true}
+	codex := &mockTarget{name: "Codex", slug: "codex", installed: true}
+
+	return []HealthCheck{
+		{Service: "jira", Target: claude, Level: HealthFail, Detail: "missing credential: JIRA_TOKEN", Credentials: []string{"JIRA_TOKEN"}},
+		{Service: "sentry", Target: claude, Level: HealthOK, Detail: "configured (OAuth)", OAuth: true},
+		{Target: codex, Level: HealthOK, Detail: "no services configured"},
+	}
+}
+
+func loadedHealthScreen(t *testing.T, callbacks HealthCallbacks) *HealthScreen {
+	t.Helper()
+
+	checks := testHealthChecks()
+	if callbacks.HealthChecks == nil {
+		callbacks.HealthChecks = func() []HealthCheck { return checks }
+	}
+
+	screen := NewHealthScreen(NewTheme(), callbacks)
+	msg := screen.Init()()
+	s, _ := screen.Update(msg)
+	return s.(*HealthScreen)
+}
+
+func TestHealthScreen_LoadsAndRendersChecks(t *testing.T) {
+	screen := loadedHealthScreen(t, HealthCallbacks{})
+
+	require.Len(t, screen.Checks(), 3)
+	view := screen.View()
+	assert.Contains(t, view, "✗ jira @ Claude Code")
+	assert.Contains(t, view, "missing credential: JIRA_TOKEN")
+	assert.Contains(t, view, "✓ Codex")
+}
+
+func TestHealthScreen_ReinstallReportsResultAndReloads(t *testing.T) {
+	var reinstalled string
+	screen := loadedHealthScreen(t, HealthCallbacks{
+		ReinstallService: func(name string, _ targetpkg.Target) error {
+			reinstalled = name
+			return nil
+		},
+	})
+
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	require.NotNil(t, cmd)
+
+	s, reload := screen.Update(cmd())
+	screen = s.(*HealthScreen)
+
+	assert.Equal(t, "jira", reinstalled)
+	assert.NotNil(t, reload)
+	assert.Contains(t, screen.View(), "Reinstalled jira on Claude Code.")
+}
+
+func TestHealthScreen_ReinstallFailureShowsError(t *testing.T) {
+	screen := loadedHealthScreen(t, HealthCallbacks{
+		ReinstallService: func(string, targetpkg.Target) error { return errors.New("write failed") },
+	})
+
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	s, _ := screen.Update(cmd())
+
+	assert.Contains(t, s.View(), "write failed")
+}
+
+func TestHealthScreen_UpdateCredential(t *testing.T) {
+	stored := map[string]string{}
+	screen := loadedHealthScreen(t, HealthCallbacks{
+		StoreCredential: func(envName, value string) error {
+			stored[envName] = value
+			return nil
+		},
+	})
+
+	s, _ := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	screen = s.(*HealthScreen)
+	assert.Contains(t, screen.View(), "Update JIRA_TOKEN")
+
+	s, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("secret")})
+	screen = s.(*HealthScreen)
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	screen.Update(cmd())
+
+	assert.Equal(t, "secret", stored["JIRA_TOKEN"])
+}
+
+func TestHealthScreen_ReauthShowsManualHintForUnsupportedTarget(t *testing.T) {
+	screen := loadedHealthScreen(t, HealthCallbacks{
+		OAuthManualHint: func(targetpkg.Target) string { return "Run /mcp to authenticate." },
+	})
+
+	s, _ := screen.Update(tea.KeyMsg{Type: tea.KeyDown})
+	screen = s.(*HealthScreen)
+	s, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+
+	assert.Nil(t, cmd)
+	assert.Contains(t, s.View(), "Run /mcp to authenticate.")
+}
+
+func TestHealthScreen_EscGoesBack(t *testing.T) {
+	screen := loadedHealthScreen(t, HealthCallbacks{})
+
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.NotNil(t, cmd)
+	assert.IsType(t, BackMsg{}, cmd())
+}
+
+func TestWizardModel_HealthMenuShowsHealthScreen(t *testing.T) {
+	model := NewWizardModel(testCallbacks(), "1.0.0")
+
+	updated, _ := model.Update(menuSelectMsg{item: "Health"})
+	wm := updated.(WizardModel)
+
+	_, isHealth := wm.screen.(*HealthScreen)
+	assert.True(t, isHealth)
+	require.Len(t, wm.steps, 1)
+	assert.Equal(t, "Health", wm.steps[0].Label)
+}
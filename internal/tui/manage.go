@@ -0,0 +1,349 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// InstalledService is one service entry in a target config, as the manage
+// screen lists it.
+type InstalledService struct {
+	Name      string
+	Target    targetpkg.Target
+	Scope     targetpkg.ConfigScope
+	Transport string // "stdio", "http", "sse", ...; empty when unknown
+}
+
+// ManageCallbacks provides the entries the manage screen lists and how they
+// are removed.
+type ManageCallbacks struct {
+	InstalledServices func() []InstalledService
+	UninstallTarget   func(name string, t targetpkg.Target, scope targetpkg.ConfigScope) error
+	BeginUndo         func(targets []targetpkg.Target) func(description string) bool
+}
+
+// manageLoadedMsg carries the installed services, freshly read.
+type manageLoadedMsg struct {
+	entries []InstalledService
+}
+
+// manageRemovedMsg reports the outcome of removing the selected entries.
+type manageRemovedMsg struct {
+	removed  int
+	failures []string
+	outcomes []Outcome
+}
+
+// ManageScreen lists the services installed on each target with their
+// transport and scope, and removes the ones the user selects.
+type ManageScreen struct {
+	theme      Theme
+	callbacks  ManageCallbacks
+	entries    []InstalledService
+	selected   map[int]bool
+	cursor     int
+	width      int
+	loading    bool
+	confirming bool
+	message    string
+	failed     bool
+}
+
+// NewManageScreen creates the screen that manages installed services.
+func NewManageScreen(theme Theme, callbacks ManageCallbacks) *ManageScreen {
+	return &ManageScreen{
+		theme:     theme,
+		callbacks: callbacks,
+		selected:  map[int]bool{},
+		loading:   true,
+	}
+}
+
+func (s *ManageScreen) Init() tea.Cmd {
+	return s.loadEntries()
+}
+
+func (s *ManageScreen) loadEntries() tea.Cmd {
+	fn := s.callbacks.InstalledServices
+	return func() tea.Msg {
+		if fn == nil {
+			return manageLoadedMsg{}
+		}
+		return manageLoadedMsg{entries: fn()}
+	}
+}
+
+func (s *ManageScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		return s, nil
+
+	case manageLoadedMsg:
+		s.entries = msg.entries
+		s.selected = map[int]bool{}
+		s.loading = false
+		if s.cursor >= len(s.entries) {
+			s.cursor = max(len(s.entries)-1, 0)
+		}
+		return s, nil
+
+	case manageRemovedMsg:
+		s.failed = len(msg.failures) > 0
+		if s.failed {
+			s.message = "Could not remove " + strings.Join(msg.failures, "; ")
+		} else {
+			s.message = fmt.Sprintf("Removed %d %s.", msg.removed, plural(msg.removed, "entry", "entries"))
+		}
+		s.loading = true
+		return s, s.loadEntries()
+
+	case tea.KeyMsg:
+		if s.confirming {
+			return s.updateConfirming(msg)
+		}
+		return s.updateList(msg)
+	}
+
+	return s, nil
+}
+
+func (s *ManageScreen) updateList(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+	case "down", "j":
+		if s.cursor < len(s.entries)-1 {
+			s.cursor++
+		}
+	case " ":
+		if !s.loading && s.cursor < len(s.entries) {
+			s.selected[s.cursor] = !s.selected[s.cursor]
+		}
+	case "a":
+		all := len(s.selectedIndexes()) < len(s.entries)
+		for i := range s.entries {
+			s.selected[i] = all
+		}
+	case "enter", "d":
+		if s.loading || len(s.entries) == 0 {
+			return s, nil
+		}
+		if len(s.selectedIndexes()) == 0 {
+			s.selected[s.cursor] = true
+		}
+		s.confirming = true
+		s.message = ""
+	case "esc":
+		return s, func() tea.Msg { return BackMsg{} }
+	}
+
+	return s, nil
+}
+
+func (s *ManageScreen) updateConfirming(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		s.confirming = false
+		s.loading = true
+		return s, s.remove()
+	case "n", "esc":
+		s.confirming = false
+	}
+
+	return s, nil
+}
+
+// selectedIndexes returns the positions of the selected entries, in list
+// order.
+func (s *ManageScreen) selectedIndexes() []int {
+	var indexes []int
+	for i := range s.entries {
+		if s.selected[i] {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// remove uninstalls the selected entries one after another and records the
+// change for undo, as the apply screen does.
+func (s *ManageScreen) remove() tea.Cmd {
+	var entries []InstalledService
+	for _, i := range s.selectedIndexes() {
+		entries = append(entries, s.entries[i])
+	}
+
+	callbacks := s.callbacks
+	return func() tea.Msg {
+		var commitUndo func(string) bool
+		if callbacks.BeginUndo != nil {
+			commitUndo = callbacks.BeginUndo(entryTargets(entries))
+		}
+
+		var result manageRemovedMsg
+		var names []string
+		for _, entry := range entries {
+			var err error
+			if callbacks.UninstallTarget == nil {
+				err = errors.New("removal is not available")
+			} else {
+				err = callbacks.UninstallTarget(entry.Name, entry.Target, entry.Scope)
+			}
+
+			outcome := newOutcome("uninstall", entry.Name, entry.Target.Slug(), err)
+			outcome.Scope = string(entry.Scope)
+			result.outcomes = append(result.outcomes, outcome)
+
+			if err != nil {
+				result.failures = append(result.failures, fmt.Sprintf("%s from %s: %v", entry.Name, entry.Target.Name(), err))
+				continue
+			}
+
+			result.removed++
+			if !slices.Contains(names, entry.Name) {
+				names = append(names, entry.Name)
+			}
+		}
+
+		if commitUndo != nil && len(names) > 0 {
+			commitUndo("uninstall " + strings.Join(names, ", "))
+		}
+
+		return result
+	}
+}
+
+// entryTargets returns the targets of entries, each once.
+func entryTargets(entries []InstalledService) []targetpkg.Target {
+	seen := map[string]bool{}
+	var targets []targetpkg.Target
+	for _, entry := range entries {
+		if seen[entry.Target.Slug()] {
+			continue
+		}
+		seen[entry.Target.Slug()] = true
+		targets = append(targets, entry.Target)
+	}
+	return targets
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+func (s *ManageScreen) View() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+
+	if s.loading && len(s.entries) == 0 {
+		b.WriteString(s.theme.Dim.Render("  Reading target configs..."))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	if len(s.entries) == 0 {
+		b.WriteString(s.theme.Dim.Render("  No services are installed on the detected targets."))
+		b.WriteString("\n")
+		s.writeMessage(&b)
+		return b.String()
+	}
+
+	nameWidth := 0
+	for _, entry := range s.entries {
+		nameWidth = max(nameWidth, len(entry.Name))
+	}
+
+	var lastTarget string
+	for i, entry := range s.entries {
+		if slug := entry.Target.Slug(); slug != lastTarget {
+			if lastTarget != "" {
+				b.WriteString("\n")
+			}
+			lastTarget = slug
+			b.WriteString("  " + s.theme.Active.Render(entry.Target.Name()) + "\n")
+		}
+
+		check := "[ ]"
+		if s.selected[i] {
+			check = "[x]"
+		}
+
+		transport := entry.Transport
+		if transport == "" {
+			transport = "unknown"
+		}
+
+		line := fmt.Sprintf("%s %-*s  %-6s  %s", check, nameWidth, entry.Name, transport, entry.Scope)
+		if i == s.cursor {
+			label := "  \u276f " + line
+			if s.width > 0 {
+				b.WriteString(s.theme.Highlight.Width(s.width).Render(label))
+			} else {
+				b.WriteString(s.theme.Cursor.Render(label))
+			}
+		} else {
+			b.WriteString("    " + line)
+		}
+		b.WriteString("\n")
+	}
+
+	if s.confirming {
+		count := len(s.selectedIndexes())
+		b.WriteString("\n")
+		b.WriteString(s.theme.Warning.Render(fmt.Sprintf("  Remove %d %s? (y/n)", count, plural(count, "entry", "entries"))))
+		b.WriteString("\n")
+	}
+
+	s.writeMessage(&b)
+
+	return b.String()
+}
+
+func (s *ManageScreen) writeMessage(b *strings.Builder) {
+	if s.message == "" {
+		return
+	}
+
+	b.WriteString("\n")
+	if s.failed {
+		b.WriteString(s.theme.Error.Render("  " + s.message))
+	} else {
+		b.WriteString(s.theme.Completed.Render("  " + s.message))
+	}
+	b.WriteString("\n")
+}
+
+func (s *ManageScreen) StatusHints() []KeyHint {
+	if s.confirming {
+		return []KeyHint{
+			{Key: "y", Desc: "remove"},
+			{Key: "n", Desc: "cancel"},
+		}
+	}
+
+	return []KeyHint{
+		{Key: "\u2191\u2193", Desc: "move"},
+		{Key: "Space", Desc: "toggle"},
+		{Key: "a", Desc: "all"},
+		{Key: "Enter", Desc: "remove"},
+		{Key: "Esc", Desc: "back"},
+	}
+}
+
+// Entries returns the listed services (for testing).
+func (s *ManageScreen) Entries() []InstalledService {
+	return s.entries
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+func testInstalledServices() []InstalledService {
+	claude := &mockTarget{name: "Claude Code", slug: "claude", installed: true}
+	codex := &mockTarget{name: "Codex", slug: "codex", installed: true}
+
+	return []InstalledService{
+		{Name: "jira", Target: claude, Scope: targetpkg.ConfigScopeUser, Transport: "stdio"},
+		{Name: "sentry", Target: claude, Scope: targetpkg.ConfigScopeProject, Transport: "http"},
+		{Name: "sentry", Target: codex, Scope: targetpkg.ConfigScopeUser, Transport: "http"},
+	}
+}
+
+func loadedManageScreen(t *testing.T, callbacks ManageCallbacks) *ManageScreen {
+	t.Helper()
+
+	if callbacks.InstalledServices == nil {
+		entries := testInstalledServices()
+		callbacks.InstalledServices = func() []InstalledService { return entries }
+	}
+
+	screen := NewManageScreen(NewTheme(), callbacks)
+	s, _ := screen.Update(screen.Init()())
+	return s.(*ManageScreen)
+}
+
+func TestManageScreen_ListsServicesPerTarget(t *testing.T) {
+	screen := loadedManageScreen(t, ManageCallbacks{})
+
+	require.Len(t, screen.Entries(), 3)
+	view := screen.View()
+	assert.Contains(t, view, "Claude Code")
+	assert.Contains(t, view, "Codex")
+	assert.Contains(t, view, "[ ] jira    stdio   user")
+	assert.Contains(t, view, "[ ] sentry  http    project")
+}
+
+func TestManageScreen_RemovesSelectedEntries(t *testing.T) {
+	type removal struct {
+		name, target string
+		scope        targetpkg.ConfigScope
+	}
+	var removed []removal
+	var undoDescription string
+	screen := loadedManageScreen(t, ManageCallbacks{
+		UninstallTarget: func(name string, target targetpkg.Target, scope targetpkg.ConfigScope) error {
+			removed = append(removed, removal{name, target.Slug(), scope})
+			return nil
+		},
+		BeginUndo: func(targets []targetpkg.Target) func(string) bool {
+			assert.Len(t, targets, 2)
+			return func(description string) bool {
+				undoDescription = description
+				return true
+			}
+		},
+	})
+
+	screen.Update(tea.KeyMsg{Type: tea.KeyDown})
+	screen.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	screen.Update(tea.KeyMsg{Type: tea.KeyDown})
+	screen.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, screen.View(), "Remove 2 entries? (y/n)")
+
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	require.NotNil(t, cmd)
+
+	msg := cmd()
+	result, ok := msg.(manageRemovedMsg)
+	require.True(t, ok)
+	assert.Equal(t, []removal{
+		{"sentry", "claude", targetpkg.ConfigScopeProject},
+		{"sentry", "codex", targetpkg.ConfigScopeUser},
+	}, removed)
+	assert.Equal(t, "uninstall sentry", undoDescription)
+	require.Len(t, result.outcomes, 2)
+	assert.Equal(t, "project", result.outcomes[0].Scope)
+
+	screen.Update(msg)
+	assert.Contains(t, screen.View(), "Removed 2 entries.")
+}
+
+func TestManageScreen_EnterWithoutSelectionRemovesCurrentEntry(t *testing.T) {
+	var removed []string
+	screen := loadedManageScreen(t, ManageCallbacks{
+		UninstallTarget: func(name string, _ targetpkg.Target, _ targetpkg.ConfigScope) error {
+			removed = append(removed, name)
+			return errors.New("permission denied")
+		},
+	})
+
+	screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	screen.Update(cmd())
+	assert.Equal(t, []string{"jira"}, removed)
+	assert.Contains(t, screen.View(), "Could not remove jira from Claude Code: permission denied")
+}
+
+func TestManageScreen_CancelKeepsEntries(t *testing.T) {
+	screen := loadedManageScreen(t, ManageCallbacks{
+		UninstallTarget: func(string, targetpkg.Target, targetpkg.ConfigScope) error {
+			t.Fatal("expected nothing to be removed")
+			return nil
+		},
+	})
+
+	screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	assert.Nil(t, cmd)
+	assert.NotContains(t, screen.View(), "(y/n)")
+}
+
+func TestWizardModel_ManageInstalledRecordsRemovals(t *testing.T) {
+	cb := testCallbacks()
+	cb.InstalledServices = testInstalledServices
+	cb.UninstallTarget = func(string, targetpkg.Target, targetpkg.ConfigScope) error { return nil }
+	model := NewWizardModel(cb, "1.0.0")
+
+	updated, cmd := model.Update(menuSelectMsg{item: "Manage installed"})
+	wm := updated.(WizardModel)
+	_, isManage := wm.screen.(*ManageScreen)
+	require.True(t, isManage)
+
+	updated, _ = wm.Update(cmd())
+	wm = updated.(WizardModel)
+	updated, _ = wm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	wm = updated.(WizardModel)
+	updated, cmd = wm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	wm = updated.(WizardModel)
+
+	updated, _ = wm.Update(cmd())
+	wm = updated.(WizardModel)
+	require.Len(t, wm.Session().Outcomes, 1)
+	assert.Equal(t, Outcome{Action: "uninstall", Service: "jira", Target: "claude", Scope: "user"}, wm.Session().Outcomes[0])
+}
@@ -9,19 +9,35 @@ import (
 var menuItems = []string{
 	"Install service",
 	"Uninstall service",
+	"Manage installed",
+	"Health",
 	"Exit",
 }
 
+const menuItemUndo = "Undo last change"
+
 // MenuScreen is the main menu of the TUI wizard.
 type MenuScreen struct {
-	theme  Theme
-	cursor int
-	width  int
+	theme      Theme
+	items      []string
+	undoDetail string // describes the change "Undo last change" reverts
+	cursor     int
+	width      int
 }
 
 // NewMenuScreen creates a new main menu screen.
 func NewMenuScreen(theme Theme) *MenuScreen {
-	return &MenuScreen{theme: theme}
+	return &MenuScreen{theme: theme, items: menuItems}
+}
+
+// NewMenuScreenWithUndo creates a main menu that also offers to undo the
+// most recent change, described by detail.
+func NewMenuScreenWithUndo(theme Theme, detail string) *MenuScreen {
+	items := make([]string, 0, len(menuItems)+1)
+	items = append(items, menuItems[:len(menuItems)-1]...)
+	items = append(items, menuItemUndo, menuItems[len(menuItems)-1])
+
+	return &MenuScreen{theme: theme, items: items, undoDetail: detail}
 }
 
 func (m *MenuScreen) Init() tea.Cmd { return nil }
@@ -39,11 +55,11 @@ func (m *MenuScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 				m.cursor--
 			}
 		case "down", "j":
-			if m.cursor < len(menuItems)-1 {
+			if m.cursor < len(m.items)-1 {
 				m.cursor++
 			}
 		case "enter":
-			item := menuItems[m.cursor]
+			item := m.items[m.cursor]
 			return m, func() tea.Msg {
 				return menuSelectMsg{item: item}
 			}
@@ -60,7 +76,7 @@ func (m *MenuScreen) View() string {
 
 	b.WriteString("\n")
 
-	for i, item := range menuItems {
+	for i, item := range m.items {
 		if i == m.cursor {
 			label := "  \u276f " + item
 			if m.width > 0 {
@@ -73,6 +89,9 @@ func (m *MenuScreen) View() string {
 		} else {
 			b.WriteString("    " + item)
 		}
+		if item == menuItemUndo && m.undoDetail != "" {
+			b.WriteString(m.theme.Dim.Render(" (" + m.undoDetail + ")"))
+		}
 		b.WriteString("\n")
 	}
 
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -133,3 +134,13 @@ func TestMenuScreen_StatusHints(t *testing.T) {
 	assert.Contains(t, hintKeys, "select")
 	assert.Contains(t, hintKeys, "quit")
 }
+
+func TestMenuScreen_WithUndoInsertsItemBeforeExit(t *testing.T) {
+	theme := NewTheme()
+	menu := NewMenuScreenWithUndo(theme, "install sentry")
+
+	view := menu.View()
+	assert.Contains(t, view, "Undo last change")
+	assert.Contains(t, view, "(install sentry)")
+	assert.Less(t, strings.Index(view, "Undo last change"), strings.Index(view, "Exit"))
+}
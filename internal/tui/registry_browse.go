@@ -0,0 +1,298 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/andreagrandi/mcp-wire/internal/catalog"
+)
+
+// registrySearchDelay is how long typing must pause before the search is
+// sent to the registry, so each keystroke does not cost a request.
+const registrySearchDelay = 300 * time.Millisecond
+
+// RegistryPage is one page of live registry results. NextCursor is empty on
+// the last page.
+type RegistryPage struct {
+	Entries    []catalog.Entry
+	NextCursor string
+}
+
+// registrySearchMsg fires once typing paused; it is ignored when the query
+// changed again since.
+type registrySearchMsg struct {
+	seq int
+}
+
+// registryPageMsg carries a fetched page. Pages of a superseded query are
+// dropped by seq.
+type registryPageMsg struct {
+	seq    int
+	append bool
+	page   RegistryPage
+	err    error
+}
+
+// RegistryBrowseScreen pages through the live registry. Unlike the service
+// screen, which filters the cached snapshot, it queries the registry API
+// for every search and loads the next page when the cursor reaches the end
+// of the list.
+type RegistryBrowseScreen struct {
+	theme      Theme
+	search     textinput.Model
+	entries    []catalog.Entry
+	nextCursor string
+	seq        int // incremented whenever the query changes
+	cursor     int
+	offset     int
+	viewHeight int
+	width      int
+	loading    bool
+	loadErr    error
+	fetchFn    func(query, cursor string) (RegistryPage, error)
+}
+
+// NewRegistryBrowseScreen creates a screen that fetches pages with fetchFn.
+func NewRegistryBrowseScreen(theme Theme, viewHeight int, fetchFn func(query, cursor string) (RegistryPage, error)) *RegistryBrowseScreen {
+	ti := textinput.New()
+	ti.Prompt = "  Search: "
+	ti.Placeholder = "type to search the registry..."
+	ti.CharLimit = 100
+	ti.Focus()
+
+	return &RegistryBrowseScreen{
+		theme:      theme,
+		search:     ti,
+		viewHeight: viewHeight,
+		loading:    true,
+		fetchFn:    fetchFn,
+	}
+}
+
+func (s *RegistryBrowseScreen) Init() tea.Cmd {
+	return tea.Batch(s.search.Focus(), s.fetchCmd("", false))
+}
+
+// fetchCmd fetches the first page for the current query, or the next page
+// when appending.
+func (s *RegistryBrowseScreen) fetchCmd(cursor string, appendPage bool) tea.Cmd {
+	fetchFn := s.fetchFn
+	query := strings.TrimSpace(s.search.Value())
+	seq := s.seq
+	return func() tea.Msg {
+		if fetchFn == nil {
+			return registryPageMsg{seq: seq, append: appendPage}
+		}
+		page, err := fetchFn(query, cursor)
+		return registryPageMsg{seq: seq, append: appendPage, page: page, err: err}
+	}
+}
+
+func (s *RegistryBrowseScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.viewHeight = contentHeightFromTerminal(msg.Height)
+		s.width = msg.Width
+		return s, nil
+
+	case registrySearchMsg:
+		if msg.seq != s.seq {
+			return s, nil
+		}
+		s.loading = true
+		return s, s.fetchCmd("", false)
+
+	case registryPageMsg:
+		if msg.seq != s.seq {
+			return s, nil
+		}
+		s.loading = false
+		s.loadErr = msg.err
+		if msg.err != nil {
+			return s, nil
+		}
+		if msg.append {
+			s.entries = append(s.entries, msg.page.Entries...)
+		} else {
+			s.entries = msg.page.Entries
+			s.cursor = 0
+			s.offset = 0
+		}
+		s.nextCursor = msg.page.NextCursor
+		return s, nil
+
+	case tea.KeyMsg:
+		return s.handleKey(msg)
+	}
+
+	var cmd tea.Cmd
+	s.search, cmd = s.search.Update(msg)
+	return s, cmd
+}
+
+func (s *RegistryBrowseScreen) handleKey(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case "up":
+		if s.cursor > 0 {
+			s.cursor--
+			s.ensureVisible()
+		}
+		return s, nil
+	case "down":
+		if s.cursor < len(s.entries)-1 {
+			s.cursor++
+			s.ensureVisible()
+		}
+		return s, s.loadMoreIfAtEnd()
+	case "enter":
+		if s.cursor < len(s.entries) {
+			entry := s.entries[s.cursor]
+			return s, func() tea.Msg {
+				return serviceSelectMsg{entry: entry}
+			}
+		}
+		return s, nil
+	case "esc":
+		return s, func() tea.Msg { return BackMsg{} }
+	}
+
+	prevValue := s.search.Value()
+	var cmd tea.Cmd
+	s.search, cmd = s.search.Update(msg)
+	if s.search.Value() == prevValue {
+		return s, cmd
+	}
+
+	// A new query supersedes pages still in flight for the old one.
+	s.seq++
+	seq := s.seq
+	debounce := tea.Tick(registrySearchDelay, func(time.Time) tea.Msg {
+		return registrySearchMsg{seq: seq}
+	})
+	return s, tea.Batch(cmd, debounce)
+}
+
+// loadMoreIfAtEnd fetches the next page once the cursor is on the last
+// loaded entry.
+func (s *RegistryBrowseScreen) loadMoreIfAtEnd() tea.Cmd {
+	if s.loading || s.nextCursor == "" || s.cursor < len(s.entries)-1 {
+		return nil
+	}
+
+	s.loading = true
+	return s.fetchCmd(s.nextCursor, true)
+}
+
+func (s *RegistryBrowseScreen) maxVisibleEntries() int {
+	lines := s.viewHeight - serviceHeaderLines
+	if lines < 3 {
+		return 1
+	}
+	return lines / 3
+}
+
+func (s *RegistryBrowseScreen) ensureVisible() {
+	maxVisible := s.maxVisibleEntries()
+	if s.cursor >= s.offset+maxVisible {
+		s.offset = s.cursor - maxVisible + 1
+	}
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+}
+
+func (s *RegistryBrowseScreen) View() string {
+	var b strings.Builder
+
+	b.WriteString(s.search.View())
+	b.WriteString("\n")
+	b.WriteString(s.countLine())
+	b.WriteString("\n")
+
+	if s.loadErr != nil {
+		b.WriteString("\n")
+		b.WriteString(s.theme.Error.Render("  Error: " + s.loadErr.Error()))
+		return b.String()
+	}
+
+	if len(s.entries) == 0 {
+		b.WriteString("\n")
+		if s.loading {
+			b.WriteString(s.theme.Dim.Render("  Loading..."))
+		} else {
+			b.WriteString(s.theme.Dim.Render("  No matching servers"))
+		}
+		return b.String()
+	}
+
+	end := s.offset + s.maxVisibleEntries()
+	if end > len(s.entries) {
+		end = len(s.entries)
+	}
+
+	b.WriteString("\n")
+
+	for i := s.offset; i < end; i++ {
+		entry := s.entries[i]
+		if i == s.cursor {
+			label := "  \u276f " + entry.Name
+			if s.width > 0 {
+				b.WriteString(s.theme.Highlight.Width(s.width).Render(label))
+			} else {
+				b.WriteString(s.theme.Cursor.Render(label))
+			}
+		} else {
+			b.WriteString("    " + entry.Name)
+		}
+		b.WriteString("\n")
+
+		if desc := entry.Description(); desc != "" {
+			b.WriteString(s.theme.Dim.Render("      " + desc))
+		}
+		b.WriteString("\n")
+		b.WriteString(s.theme.Dim.Render("      " + serviceMetaLine(entry)))
+		b.WriteString("\n")
+	}
+
+	switch {
+	case s.loading:
+		b.WriteString(s.theme.Dim.Render("  \u25bc loading more\u2026"))
+	case s.nextCursor != "":
+		b.WriteString(s.theme.Dim.Render("  \u25bc more on the registry"))
+	default:
+		b.WriteString(s.theme.Dim.Render("          \u2014 end of results \u2014"))
+	}
+
+	return b.String()
+}
+
+func (s *RegistryBrowseScreen) countLine() string {
+	if s.loading && len(s.entries) == 0 {
+		return s.theme.Dim.Render("  Querying the registry...")
+	}
+
+	count := itoa(len(s.entries)) + " servers"
+	if s.nextCursor != "" {
+		count += " loaded"
+	}
+
+	return s.theme.Dim.Render("  Live registry · " + count)
+}
+
+func (s *RegistryBrowseScreen) StatusHints() []KeyHint {
+	return []KeyHint{
+		{Key: "\u2191\u2193", Desc: "move"},
+		{Key: "Enter", Desc: "select"},
+		{Key: "type", Desc: "to search"},
+		{Key: "Esc", Desc: "back"},
+	}
+}
+
+// Testing accessors.
+
+func (s *RegistryBrowseScreen) Entries() []catalog.Entry { return s.entries }
+func (s *RegistryBrowseScreen) CursorPos() int           { return s.cursor }
+func (s *RegistryBrowseScreen) IsLoading() bool          { return s.loading }
@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/registry"
+)
+
+func liveRegistryEntry(name string) catalog.Entry {
+	return catalog.FromRegistry(registry.ServerResponse{
+		Server: registry.ServerJSON{Name: name, Description: name + " server"},
+	})
+}
+
+// fakeRegistryPages serves two pages for an empty query and one page for
+// any other, recording each request.
+type fakeRegistryPages struct {
+	requests [][2]string
+}
+
+func (f *fakeRegistryPages) fetch(query, cursor string) (RegistryPage, error) {
+	f.requests = append(f.requests, [2]string{query, cursor})
+	switch {
+	case query != "":
+		return RegistryPage{Entries: []catalog.Entry{liveRegistryEntry("io.example/" + query)}}, nil
+	case cursor == "":
+		return RegistryPage{Entries: []catalog.Entry{liveRegistryEntry("io.example/a"), liveRegistryEntry("io.example/b")}, NextCursor: "page-2"}, nil
+	default:
+		return RegistryPage{Entries: []catalog.Entry{liveRegistryEntry("io.example/c")}}, nil
+	}
+}
+
+// runRegistryCmd runs a fetch cmd and feeds the page back to the screen.
+func runRegistryCmd(t *testing.T, screen *RegistryBrowseScreen, cmd tea.Cmd) *RegistryBrowseScreen {
+	t.Helper()
+	require.NotNil(t, cmd)
+
+	page, ok := cmd().(registryPageMsg)
+	require.True(t, ok, "expected a registry page to be fetched")
+
+	s, _ := screen.Update(page)
+	return s.(*RegistryBrowseScreen)
+}
+
+func loadedRegistryBrowseScreen(t *testing.T, pages *fakeRegistryPages) *RegistryBrowseScreen {
+	t.Helper()
+	screen := NewRegistryBrowseScreen(NewTheme(), 20, pages.fetch)
+	return runRegistryCmd(t, screen, screen.fetchCmd("", false))
+}
+
+func TestRegistryBrowseScreen_Init(t *testing.T) {
+	screen := NewRegistryBrowseScreen(NewTheme(), 20, nil)
+
+	assert.True(t, screen.IsLoading())
+	assert.NotNil(t, screen.Init())
+	assert.Contains(t, screen.View(), "Loading")
+}
+
+func TestRegistryBrowseScreen_LoadsFirstPage(t *testing.T) {
+	pages := &fakeRegistryPages{}
+	screen := loadedRegistryBrowseScreen(t, pages)
+
+	assert.False(t, screen.IsLoading())
+	require.Len(t, screen.Entries(), 2)
+	assert.Equal(t, [][2]string{{"", ""}}, pages.requests)
+	assert.Contains(t, screen.View(), "more on the registry")
+}
+
+func TestRegistryBrowseScreen_LoadsNextPageAtEnd(t *testing.T) {
+	pages := &fakeRegistryPages{}
+	screen := loadedRegistryBrowseScreen(t, pages)
+
+	s, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyDown})
+	screen = s.(*RegistryBrowseScreen)
+	assert.Equal(t, 1, screen.CursorPos())
+	assert.True(t, screen.IsLoading())
+
+	screen = runRegistryCmd(t, screen, cmd)
+	require.Len(t, screen.Entries(), 3)
+	assert.Equal(t, "page-2", pages.requests[1][1])
+	assert.Equal(t, 1, screen.CursorPos(), "appending keeps the cursor")
+	assert.Contains(t, screen.View(), "end of results")
+
+	// The last page has no cursor, so reaching the end fetches nothing.
+	s, _ = screen.Update(tea.KeyMsg{Type: tea.KeyDown})
+	s, cmd = s.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Nil(t, cmd)
+	assert.Equal(t, 2, s.(*RegistryBrowseScreen).CursorPos())
+}
+
+func TestRegistryBrowseScreen_SearchIsDebounced(t *testing.T) {
+	pages := &fakeRegistryPages{}
+	screen := loadedRegistryBrowseScreen(t, pages)
+
+	s, _ := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	screen = s.(*RegistryBrowseScreen)
+
+	// The tick of the first keystroke is stale and fetches nothing.
+	s, cmd := screen.Update(registrySearchMsg{seq: 1})
+	assert.Nil(t, cmd)
+
+	s, cmd = s.Update(registrySearchMsg{seq: 2})
+	screen = runRegistryCmd(t, s.(*RegistryBrowseScreen), cmd)
+
+	require.Len(t, screen.Entries(), 1)
+	assert.Equal(t, "io.example/gh", screen.Entries()[0].Name)
+	assert.Equal(t, [2]string{"gh", ""}, pages.requests[len(pages.requests)-1])
+}
+
+func TestRegistryBrowseScreen_DropsStalePages(t *testing.T) {
+	screen := loadedRegistryBrowseScreen(t, &fakeRegistryPages{})
+
+	s, _ := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	s, _ = s.Update(registryPageMsg{seq: 0, page: RegistryPage{Entries: []catalog.Entry{liveRegistryEntry("stale")}}})
+
+	assert.Len(t, s.(*RegistryBrowseScreen).Entries(), 2)
+}
+
+func TestRegistryBrowseScreen_ShowsFetchError(t *testing.T) {
+	screen := NewRegistryBrowseScreen(NewTheme(), 20, func(string, string) (RegistryPage, error) {
+		return RegistryPage{}, errors.New("registry unreachable")
+	})
+	screen = runRegistryCmd(t, screen, screen.fetchCmd("", false))
+
+	assert.Contains(t, screen.View(), "registry unreachable")
+}
+
+func TestRegistryBrowseScreen_EnterSelectsEntry(t *testing.T) {
+	screen := loadedRegistryBrowseScreen(t, &fakeRegistryPages{})
+
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(serviceSelectMsg)
+	require.True(t, ok)
+	assert.Equal(t, "io.example/a", msg.entry.Name)
+	assert.Equal(t, catalog.SourceRegistry, msg.entry.Source)
+}
+
+func TestWizardModel_LiveSourceShowsRegistryBrowseScreen(t *testing.T) {
+	cb := testCallbacksWithRegistry()
+	cb.BrowseRegistry = (&fakeRegistryPages{}).fetch
+	model := NewWizardModel(cb, "1.0.0")
+	model.height = 20
+
+	updated, _ := model.Update(menuSelectMsg{item: "Install service"})
+	wm := updated.(WizardModel)
+
+	source, ok := wm.screen.(*SourceScreen)
+	require.True(t, ok)
+	assert.Contains(t, source.View(), "Browse live registry")
+
+	updated, _ = wm.Update(sourceSelectMsg{source: sourceLive})
+	wm = updated.(WizardModel)
+
+	_, isBrowse := wm.screen.(*RegistryBrowseScreen)
+	require.True(t, isBrowse)
+
+	// A selection continues to the trust screen like any registry entry.
+	updated, _ = wm.Update(serviceSelectMsg{entry: liveRegistryEntry("io.example/a")})
+	wm = updated.(WizardModel)
+	_, isTrust := wm.screen.(*TrustScreen)
+	require.True(t, isTrust)
+
+	// Back from trust returns to the live browse screen.
+	updated, _ = wm.Update(BackMsg{})
+	wm = updated.(WizardModel)
+	_, isBrowse = wm.screen.(*RegistryBrowseScreen)
+	assert.True(t, isBrowse)
+
+	// Back from the browse screen returns to the source screen.
+	updated, _ = wm.Update(BackMsg{})
+	wm = updated.(WizardModel)
+	_, isSource := wm.screen.(*SourceScreen)
+	assert.True(t, isSource)
+}
+
+func TestSourceScreen_LiveOptionOnlyWhenEnabled(t *testing.T) {
+	model := NewWizardModel(testCallbacksWithRegistry(), "1.0.0")
+	updated, _ := model.Update(menuSelectMsg{item: "Install service"})
+
+	assert.NotContains(t, updated.(WizardModel).screen.View(), "Browse live registry")
+}
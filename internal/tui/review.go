@@ -5,6 +5,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/andreagrandi/mcp-wire/internal/flow"
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
 )
 
@@ -13,6 +14,9 @@ type reviewConfirmMsg struct {
 	confirmed bool
 }
 
+// reviewPreviewMsg is sent when the user asks to preview the config changes.
+type reviewPreviewMsg struct{}
+
 // ReviewScreen shows a summary of all wizard selections and offers
 // Apply/Cancel before proceeding.
 type ReviewScreen struct {
@@ -21,6 +25,7 @@ type ReviewScreen struct {
 	registryEnabled bool
 	cursor          int // 0 = Cancel, 1 = Apply
 	width           int
+	canPreview      bool
 }
 
 // NewReviewScreen creates a review screen summarising the wizard state.
@@ -33,6 +38,11 @@ func NewReviewScreen(theme Theme, state WizardState, registryEnabled bool) *Revi
 	}
 }
 
+// SetPreview offers the "p" key, which previews the config changes.
+func (r *ReviewScreen) SetPreview() {
+	r.canPreview = true
+}
+
 func (r *ReviewScreen) Init() tea.Cmd { return nil }
 
 func (r *ReviewScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
@@ -56,6 +66,10 @@ func (r *ReviewScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 			return r, func() tea.Msg {
 				return reviewConfirmMsg{confirmed: confirmed}
 			}
+		case "p":
+			if r.canPreview {
+				return r, func() tea.Msg { return reviewPreviewMsg{} }
+			}
 		case "esc":
 			return r, func() tea.Msg { return BackMsg{} }
 		}
@@ -79,10 +93,16 @@ func (r *ReviewScreen) View() string {
 	b.WriteString(r.summaryLine("Service", r.serviceLabel()))
 	b.WriteString(r.summaryLine("Targets", r.targetNames()))
 
-	if anyTargetSupportsProjectScope(r.state.Targets) {
+	if len(r.state.TargetScopes) > 0 {
+		b.WriteString(r.summaryLine("Scope", r.targetScopesLabel()))
+	} else if flow.OffersScopeChoice(r.state.Targets) {
 		b.WriteString(r.summaryLine("Scope", scopeLabel(r.state.Scope)))
 	}
 
+	if note := r.attachNote(); note != "" {
+		b.WriteString(r.theme.Dim.Render("  "+note) + "\n")
+	}
+
 	if r.state.Action != "uninstall" {
 		b.WriteString(r.summaryLine("Credentials", "prompt as needed"))
 	}
@@ -125,15 +145,80 @@ func (r *ReviewScreen) targetNames() string {
 	return strings.Join(names, ", ")
 }
 
+// targetScopesLabel lists the scope of each target, for targets given
+// different scopes.
+func (r *ReviewScreen) targetScopesLabel() string {
+	parts := make([]string, 0, len(r.state.Targets))
+	for _, t := range r.state.Targets {
+		parts = append(parts, t.Name()+" "+strings.ToLower(scopeName(r.state.ScopeFor(t))))
+	}
+	return strings.Join(parts, ", ")
+}
+
 func (r *ReviewScreen) equivalentCommand() string {
-	cmd := "mcp-wire " + r.state.Action + " " + r.state.Entry.Name
+	return equivalentCommand(r.state)
+}
+
+// equivalentCommand returns the CLI command that performs the operation in
+// state. Targets with different scopes need one command per scope, chained
+// with &&.
+func equivalentCommand(state WizardState) string {
+	var scopes []targetpkg.ConfigScope
+	targetsByScope := map[targetpkg.ConfigScope][]targetpkg.Target{}
+	for _, t := range state.Targets {
+		scope := state.ScopeFor(t)
+		if _, seen := targetsByScope[scope]; !seen {
+			scopes = append(scopes, scope)
+		}
+		targetsByScope[scope] = append(targetsByScope[scope], t)
+	}
+
+	if len(scopes) == 0 {
+		scopes = []targetpkg.ConfigScope{state.Scope}
+	}
+
+	commands := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		cmd := "mcp-wire " + state.Action + " " + state.Entry.Name
+		for _, t := range targetsByScope[scope] {
+			cmd += " --target " + t.Slug()
+		}
+		switch scope {
+		case targetpkg.ConfigScopeProject:
+			cmd += " --scope project"
+		case targetpkg.ConfigScopeRemote:
+			cmd += " --scope remote"
+		}
+		commands = append(commands, cmd)
+	}
+
+	return strings.Join(commands, " && ")
+}
+
+// attachNote explains which VS Code reads the chosen settings when a target
+// has remote settings on this machine, since user settings written here are
+// not read by a VS Code attached from elsewhere.
+func (r *ReviewScreen) attachNote() string {
+	var remoteTarget targetpkg.Target
 	for _, t := range r.state.Targets {
-		cmd += " --target " + t.Slug()
+		if flow.SupportsRemoteScope([]targetpkg.Target{t}) {
+			remoteTarget = t
+			break
+		}
+	}
+
+	if remoteTarget == nil {
+		return ""
 	}
-	if r.state.Scope == targetpkg.ConfigScopeProject {
-		cmd += " --scope project"
+
+	switch r.state.ScopeFor(remoteTarget) {
+	case targetpkg.ConfigScopeRemote:
+		return "VS Code loads these servers when it attaches to this machine; stdio servers run here."
+	case targetpkg.ConfigScopeUser:
+		return "VS Code attached from another machine does not read user settings here; choose Remote for that."
+	default:
+		return ""
 	}
-	return cmd
 }
 
 func (r *ReviewScreen) renderChoices() string {
@@ -160,11 +245,30 @@ func (r *ReviewScreen) renderChoices() string {
 }
 
 func (r *ReviewScreen) StatusHints() []KeyHint {
-	return []KeyHint{
+	hints := []KeyHint{
 		{Key: "\u2190\u2192", Desc: "choose"},
 		{Key: "Enter", Desc: "confirm"},
-		{Key: "Esc", Desc: "back"},
 	}
+	if r.canPreview {
+		hints = append(hints, KeyHint{Key: "p", Desc: "preview changes"})
+	}
+	return append(hints, KeyHint{Key: "Esc", Desc: "back"})
+}
+
+// PreviewScreen shows the config diff the reviewed operation would apply,
+// scrollable like the output screen. Esc returns to the review screen.
+type PreviewScreen struct {
+	*OutputScreen
+}
+
+// NewPreviewScreen creates a preview screen showing content.
+func NewPreviewScreen(theme Theme, content string, viewHeight int) *PreviewScreen {
+	return &PreviewScreen{OutputScreen: NewOutputScreen(theme, content, viewHeight)}
+}
+
+func (p *PreviewScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	_, cmd := p.OutputScreen.Update(msg)
+	return p, cmd
 }
 
 // Cursor returns the current cursor position (for testing).
@@ -172,6 +276,19 @@ func (r *ReviewScreen) Cursor() int {
 	return r.cursor
 }
 
+// scopeName returns the short display name of a config scope.
+func scopeName(scope targetpkg.ConfigScope) string {
+	if scope == remoteScopeOption.Value {
+		return remoteScopeOption.Label
+	}
+	for _, opt := range scopeOptions {
+		if opt.Value == scope {
+			return opt.Label
+		}
+	}
+	return string(scope)
+}
+
 // scopeLabel returns a display string for a config scope.
 func scopeLabel(scope targetpkg.ConfigScope) string {
 	switch scope {
@@ -179,6 +296,8 @@ func scopeLabel(scope targetpkg.ConfigScope) string {
 		return "Project (current directory only)"
 	case targetpkg.ConfigScopeUser:
 		return "User (for targets that support it)"
+	case targetpkg.ConfigScopeRemote:
+		return "Remote (VS Code Server settings on this machine)"
 	default:
 		return string(scope)
 	}
@@ -207,6 +207,27 @@ func TestReviewScreen_ViewShowsScopeForSupportedTargets(t *testing.T) {
 	assert.Contains(t, view, "Project (current directory only)")
 }
 
+func TestReviewScreen_ViewExplainsRemoteAttachBehavior(t *testing.T) {
+	theme := NewTheme()
+	state := testReviewState()
+	state.Targets = []targetpkg.Target{
+		&mockTarget{
+			name: "VS Code", slug: "vscode", installed: true,
+			scopes: []targetpkg.ConfigScope{targetpkg.ConfigScopeUser, targetpkg.ConfigScopeProject, targetpkg.ConfigScopeRemote},
+		},
+	}
+
+	state.Scope = targetpkg.ConfigScopeRemote
+	view := NewReviewScreen(theme, state, false).View()
+	assert.Contains(t, view, "Remote (VS Code Server settings on this machine)")
+	assert.Contains(t, view, "loads these servers when it attaches to this machine")
+	assert.Contains(t, view, "--scope remote")
+
+	state.Scope = targetpkg.ConfigScopeUser
+	view = NewReviewScreen(theme, state, false).View()
+	assert.Contains(t, view, "does not read user settings here; choose Remote")
+}
+
 func TestReviewScreen_ViewHidesScopeForUnsupportedTargets(t *testing.T) {
 	theme := NewTheme()
 	screen := NewReviewScreen(theme, testReviewState(), false)
@@ -317,3 +338,29 @@ func TestReviewScreen_ViewShowsServiceWithEmDash(t *testing.T) {
 	view := screen.View()
 	assert.Contains(t, view, "sentry \u2014 Error tracking")
 }
+
+func TestReviewScreen_PreviewKeyNeedsPreview(t *testing.T) {
+	theme := NewTheme()
+	screen := NewReviewScreen(theme, testReviewState(), false)
+
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	assert.Nil(t, cmd)
+
+	screen.SetPreview()
+	_, cmd = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	require.NotNil(t, cmd)
+	assert.Equal(t, reviewPreviewMsg{}, cmd())
+}
+
+func TestReviewScreen_ViewShowsPerTargetScopes(t *testing.T) {
+	state := testReviewState()
+	state.TargetScopes = map[string]targetpkg.ConfigScope{
+		"claude": targetpkg.ConfigScopeProject,
+		"codex":  targetpkg.ConfigScopeUser,
+	}
+	screen := NewReviewScreen(NewTheme(), state, false)
+
+	view := screen.View()
+	assert.Contains(t, view, "Claude Code project, Codex user")
+	assert.Contains(t, view, "mcp-wire install sentry --target claude --scope project && mcp-wire install sentry --target codex")
+}
@@ -5,6 +5,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/andreagrandi/mcp-wire/internal/flow"
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
 )
 
@@ -20,18 +21,75 @@ var scopeOptions = []scopeOption{
 	{Label: "Project", Description: "only for the current directory", Value: targetpkg.ConfigScopeProject},
 }
 
-// ScopeScreen lets the user choose between user and project scope.
+// remoteScopeOption is offered when a target has remote settings on this
+// machine, such as VS Code Server inside a dev container.
+var remoteScopeOption = scopeOption{
+	Label:       "Remote",
+	Description: "for VS Code attached to this machine or container",
+	Value:       targetpkg.ConfigScopeRemote,
+}
+
+// scopeTargetRow is one target in per-target mode, with the scopes it
+// offers and the one chosen.
+type scopeTargetRow struct {
+	target  targetpkg.Target
+	options []scopeOption
+	choice  int
+}
+
+// ScopeScreen lets the user choose between user and project scope, and
+// remote scope where available. When several selected targets offer a
+// choice, "t" switches to picking a scope for each of them.
 type ScopeScreen struct {
 	theme       Theme
 	targetNames string
+	options     []scopeOption
 	cursor      int
 	width       int
+	rows        []scopeTargetRow
+	perTarget   bool
+	rowCursor   int
 }
 
 // NewScopeScreen creates a new scope selection screen.
 // targetNames is a human-readable list of targets that support scopes (e.g. "Claude Code").
 func NewScopeScreen(theme Theme, targetNames string) *ScopeScreen {
-	return &ScopeScreen{theme: theme, targetNames: targetNames}
+	return &ScopeScreen{theme: theme, targetNames: targetNames, options: scopeOptions}
+}
+
+// NewScopeScreenWithRemote creates a scope selection screen that also offers
+// remote scope.
+func NewScopeScreenWithRemote(theme Theme, targetNames string) *ScopeScreen {
+	options := append(append([]scopeOption(nil), scopeOptions...), remoteScopeOption)
+	return &ScopeScreen{theme: theme, targetNames: targetNames, options: options}
+}
+
+// SetTargets offers per-target scopes for targets, when at least two of
+// them have more than the user scope to choose from.
+func (s *ScopeScreen) SetTargets(targets []targetpkg.Target) {
+	var rows []scopeTargetRow
+	for _, t := range targets {
+		single := []targetpkg.Target{t}
+		if !flow.OffersScopeChoice(single) {
+			continue
+		}
+
+		options := []scopeOption{scopeOptions[0]}
+		if flow.SupportsProjectScope(single) {
+			options = append(options, scopeOptions[1])
+		}
+		if flow.SupportsRemoteScope(single) {
+			options = append(options, remoteScopeOption)
+		}
+
+		rows = append(rows, scopeTargetRow{target: t, options: options})
+	}
+
+	if len(rows) < 2 {
+		rows = nil
+	}
+
+	s.rows = rows
 }
 
 func (s *ScopeScreen) Init() tea.Cmd { return nil }
@@ -43,17 +101,26 @@ func (s *ScopeScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 		return s, nil
 
 	case tea.KeyMsg:
+		if msg.String() == "t" && len(s.rows) > 0 {
+			s.togglePerTarget()
+			return s, nil
+		}
+
+		if s.perTarget {
+			return s.updatePerTarget(msg)
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			if s.cursor > 0 {
 				s.cursor--
 			}
 		case "down", "j":
-			if s.cursor < len(scopeOptions)-1 {
+			if s.cursor < len(s.options)-1 {
 				s.cursor++
 			}
 		case "enter":
-			opt := scopeOptions[s.cursor]
+			opt := s.options[s.cursor]
 			return s, func() tea.Msg {
 				return scopeSelectMsg{scope: opt.Value}
 			}
@@ -65,7 +132,76 @@ func (s *ScopeScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 	return s, nil
 }
 
+// togglePerTarget switches between one scope for all targets and a scope
+// for each. Each target starts on the shared choice when it offers it.
+func (s *ScopeScreen) togglePerTarget() {
+	s.perTarget = !s.perTarget
+	if !s.perTarget {
+		return
+	}
+
+	shared := s.options[s.cursor].Value
+	for i := range s.rows {
+		s.rows[i].choice = 0
+		for j, opt := range s.rows[i].options {
+			if opt.Value == shared {
+				s.rows[i].choice = j
+			}
+		}
+	}
+}
+
+func (s *ScopeScreen) updatePerTarget(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if s.rowCursor > 0 {
+			s.rowCursor--
+		}
+	case "down", "j":
+		if s.rowCursor < len(s.rows)-1 {
+			s.rowCursor++
+		}
+	case "left", "h":
+		row := &s.rows[s.rowCursor]
+		row.choice = (row.choice + len(row.options) - 1) % len(row.options)
+	case "right", "l":
+		row := &s.rows[s.rowCursor]
+		row.choice = (row.choice + 1) % len(row.options)
+	case "enter":
+		selected := s.perTargetSelection()
+		return s, func() tea.Msg { return selected }
+	case "esc":
+		return s, func() tea.Msg { return BackMsg{} }
+	}
+
+	return s, nil
+}
+
+// perTargetSelection returns the chosen scopes. When every target got the
+// same one it is sent as the shared scope.
+func (s *ScopeScreen) perTargetSelection() scopeSelectMsg {
+	scopes := make(map[string]targetpkg.ConfigScope, len(s.rows))
+	mixed := false
+	for _, row := range s.rows {
+		scope := row.options[row.choice].Value
+		scopes[row.target.Slug()] = scope
+		if scope != s.rows[0].options[s.rows[0].choice].Value {
+			mixed = true
+		}
+	}
+
+	if !mixed {
+		return scopeSelectMsg{scope: s.rows[0].options[s.rows[0].choice].Value}
+	}
+
+	return scopeSelectMsg{scope: targetpkg.ConfigScopeUser, targetScopes: scopes}
+}
+
 func (s *ScopeScreen) View() string {
+	if s.perTarget {
+		return s.viewPerTarget()
+	}
+
 	var b strings.Builder
 
 	b.WriteString("\n")
@@ -75,7 +211,7 @@ func (s *ScopeScreen) View() string {
 	}
 	b.WriteString(heading + ":\n\n")
 
-	for i, opt := range scopeOptions {
+	for i, opt := range s.options {
 		desc := s.theme.Dim.Render(opt.Description)
 		if i == s.cursor {
 			label := "  \u276f " + opt.Label
@@ -96,12 +232,59 @@ func (s *ScopeScreen) View() string {
 	return b.String()
 }
 
+func (s *ScopeScreen) viewPerTarget() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString("  Install scope per target:\n\n")
+
+	nameWidth := 0
+	for _, row := range s.rows {
+		nameWidth = max(nameWidth, len(row.target.Name()))
+	}
+
+	for i, row := range s.rows {
+		opt := row.options[row.choice]
+		name := row.target.Name() + strings.Repeat(" ", nameWidth-len(row.target.Name()))
+		choice := "\u2039 " + opt.Label + " \u203a"
+		if i == s.rowCursor {
+			label := "  \u276f " + name + "    " + choice
+			if s.width > 0 {
+				b.WriteString(s.theme.Highlight.Width(s.width).Render(label + "    " + opt.Description))
+			} else {
+				b.WriteString(s.theme.Cursor.Render(label) + "    " + s.theme.Dim.Render(opt.Description))
+			}
+		} else {
+			b.WriteString("    " + name + "    " + choice + "    " + s.theme.Dim.Render(opt.Description))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(s.theme.Dim.Render("  Targets without scope support will use their default behavior."))
+
+	return b.String()
+}
+
 func (s *ScopeScreen) StatusHints() []KeyHint {
-	return []KeyHint{
+	if s.perTarget {
+		return []KeyHint{
+			{Key: "\u2191\u2193", Desc: "move"},
+			{Key: "\u2190\u2192", Desc: "change scope"},
+			{Key: "Enter", Desc: "select"},
+			{Key: "t", Desc: "same scope for all"},
+			{Key: "Esc", Desc: "back"},
+		}
+	}
+
+	hints := []KeyHint{
 		{Key: "\u2191\u2193", Desc: "move"},
 		{Key: "Enter", Desc: "select"},
-		{Key: "Esc", Desc: "back"},
 	}
+	if len(s.rows) > 0 {
+		hints = append(hints, KeyHint{Key: "t", Desc: "scope per target"})
+	}
+	return append(hints, KeyHint{Key: "Esc", Desc: "back"})
 }
 
 // Cursor returns the current cursor position (for testing).
@@ -104,6 +104,30 @@ func TestScopeScreen_EnterSelectsProject(t *testing.T) {
 	assert.Equal(t, targetpkg.ConfigScopeProject, sel.scope)
 }
 
+func TestScopeScreenWithRemote_EnterSelectsRemote(t *testing.T) {
+	theme := NewTheme()
+	screen := NewScopeScreenWithRemote(theme, "VS Code")
+	assert.Contains(t, screen.View(), "for VS Code attached to this machine or container")
+
+	var s Screen = screen
+	for i := 0; i < 10; i++ {
+		s, _ = s.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	_, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	sel, ok := cmd().(scopeSelectMsg)
+	require.True(t, ok)
+	assert.Equal(t, targetpkg.ConfigScopeRemote, sel.scope)
+}
+
+func TestScopeScreen_ViewHidesRemoteByDefault(t *testing.T) {
+	theme := NewTheme()
+	screen := NewScopeScreen(theme, "Claude Code")
+
+	assert.NotContains(t, screen.View(), "Remote")
+}
+
 func TestScopeScreen_EscSendsBack(t *testing.T) {
 	theme := NewTheme()
 	screen := NewScopeScreen(theme, "Claude Code")
@@ -174,3 +198,65 @@ func TestScopeScreen_WindowSizeMsg(t *testing.T) {
 	updated := s.(*ScopeScreen)
 	assert.Equal(t, 80, updated.width)
 }
+
+func testPerTargetScopeTargets() []targetpkg.Target {
+	return []targetpkg.Target{
+		&mockTarget{
+			name: "Claude Code", slug: "claude", installed: true,
+			scopes: []targetpkg.ConfigScope{targetpkg.ConfigScopeUser, targetpkg.ConfigScopeProject},
+		},
+		&mockTarget{
+			name: "Codex", slug: "codex", installed: true,
+			scopes: []targetpkg.ConfigScope{targetpkg.ConfigScopeUser, targetpkg.ConfigScopeProject},
+		},
+	}
+}
+
+func TestScopeScreen_PerTargetNeedsTwoScopedTargets(t *testing.T) {
+	screen := NewScopeScreen(NewTheme(), "Claude Code, Codex")
+	screen.SetTargets(testMockTargetsWithScopes())
+
+	s, _ := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	updated := s.(*ScopeScreen)
+
+	assert.NotContains(t, updated.View(), "Install scope per target")
+	assert.Len(t, updated.StatusHints(), 3)
+}
+
+func TestScopeScreen_PerTargetSelectsEachScope(t *testing.T) {
+	screen := NewScopeScreen(NewTheme(), "Claude Code, Codex")
+	screen.SetTargets(testPerTargetScopeTargets())
+
+	var s Screen = screen
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	assert.Contains(t, s.View(), "Install scope per target")
+
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyDown})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRight})
+	_, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(scopeSelectMsg)
+	require.True(t, ok)
+	assert.Equal(t, targetpkg.ConfigScopeUser, msg.scope)
+	assert.Equal(t, map[string]targetpkg.ConfigScope{
+		"claude": targetpkg.ConfigScopeUser,
+		"codex":  targetpkg.ConfigScopeProject,
+	}, msg.targetScopes)
+}
+
+func TestScopeScreen_PerTargetSameScopeIsShared(t *testing.T) {
+	screen := NewScopeScreen(NewTheme(), "Claude Code, Codex")
+	screen.SetTargets(testPerTargetScopeTargets())
+
+	var s Screen = screen
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyDown})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	_, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(scopeSelectMsg)
+	require.True(t, ok)
+	assert.Equal(t, targetpkg.ConfigScopeProject, msg.scope)
+	assert.Nil(t, msg.targetScopes)
+}
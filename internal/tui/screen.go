@@ -64,7 +64,10 @@ type targetSelectMsg struct {
 	targets []targetpkg.Target
 }
 
-// scopeSelectMsg is sent when a scope is selected.
+// scopeSelectMsg is sent when a scope is selected. targetScopes is set when
+// the selected targets were given different scopes; scope then applies to
+// the targets it does not list.
 type scopeSelectMsg struct {
-	scope targetpkg.ConfigScope
+	scope        targetpkg.ConfigScope
+	targetScopes map[string]targetpkg.ConfigScope
 }
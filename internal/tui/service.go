@@ -12,6 +12,10 @@ import (
 
 const serviceHeaderLines = 3 // search input + count line + blank
 
+// serviceSetupMaxLines caps how many lines of setup notes the setup pane
+// shows, so long notes cannot push the list off screen.
+const serviceSetupMaxLines = 4
+
 // catalogLoadedMsg is sent when the catalog finishes loading.
 type catalogLoadedMsg struct {
 	catalog *catalog.Catalog
@@ -27,8 +31,9 @@ type syncStatusMsg struct {
 type ServiceScreen struct {
 	theme        Theme
 	search       textinput.Model
-	cat          *catalog.Catalog
+	cat          *catalog.Catalog // shared snapshot, kept until the screen reopens
 	filtered     []catalog.Entry
+	browse       []catalog.Entry // catalog in empty-search order, built once per load
 	cursor       int
 	offset       int
 	viewHeight   int
@@ -41,6 +46,9 @@ type ServiceScreen struct {
 	loadErr      error
 	loadFn       func(string) (*catalog.Catalog, error)
 	syncFn       func() string
+	recent       []string
+	recommended  []string
+	openURL      func(string) error
 }
 
 // NewServiceScreen creates a new service selection screen.
@@ -63,6 +71,27 @@ func NewServiceScreen(theme Theme, source string, viewHeight int, loadFn func(st
 	}
 }
 
+// SetRecent sets the recently installed service names (most recent first).
+// When the search is empty, matching entries are listed first as a "recent"
+// group.
+func (s *ServiceScreen) SetRecent(names []string) {
+	s.recent = names
+	s.browse = nil
+}
+
+// SetRecommended sets the service names recommended for the current project.
+// When the search is empty, matching entries are listed after the recent
+// group and tagged as recommended.
+func (s *ServiceScreen) SetRecommended(names []string) {
+	s.recommended = names
+	s.browse = nil
+}
+
+// SetOpenURL sets how the docs link of the highlighted service is opened.
+func (s *ServiceScreen) SetOpenURL(openURL func(string) error) {
+	s.openURL = openURL
+}
+
 func (s *ServiceScreen) Init() tea.Cmd {
 	focusCmd := s.search.Focus()
 	cmds := []tea.Cmd{focusCmd, s.loadCatalogCmd()}
@@ -105,6 +134,7 @@ func (s *ServiceScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 			return s, nil
 		}
 		s.cat = msg.catalog
+		s.browse = nil
 		s.showMetadata = catalogHasMetadata(msg.catalog)
 		s.applyFilter()
 		return s, nil
@@ -156,6 +186,13 @@ func (s *ServiceScreen) handleKey(msg tea.KeyMsg) (Screen, tea.Cmd) {
 		return s, nil
 	case "esc":
 		return s, func() tea.Msg { return BackMsg{} }
+	case "ctrl+o":
+		if entry, ok := s.currentEntry(); ok && s.openURL != nil {
+			if _, url := entryLink(entry); url != "" {
+				_ = s.openURL(url)
+			}
+		}
+		return s, nil
 	}
 
 	// All other keys go to search input.
@@ -175,11 +212,74 @@ func (s *ServiceScreen) applyFilter() {
 		s.offset = 0
 		return
 	}
-	s.filtered = s.cat.Search(s.search.Value())
+	if strings.TrimSpace(s.search.Value()) == "" {
+		if s.browse == nil {
+			s.browse = s.recentFirst(s.cat.All())
+		}
+		s.filtered = s.browse
+	} else {
+		s.filtered = s.cat.Search(s.search.Value())
+	}
 	s.cursor = 0
 	s.offset = 0
 }
 
+// recentFirst moves entries matching the recent list to the top, in recent
+// order, followed by recommended entries, leaving the remaining entries in
+// their original order.
+func (s *ServiceScreen) recentFirst(entries []catalog.Entry) []catalog.Entry {
+	if len(s.recent) == 0 && len(s.recommended) == 0 {
+		return entries
+	}
+
+	byName := make(map[string]int, len(entries))
+	for i, e := range entries {
+		byName[strings.ToLower(e.Name)] = i
+	}
+
+	pinned := append(append([]string{}, s.recent...), s.recommended...)
+	picked := make(map[int]bool, len(pinned))
+	ordered := make([]catalog.Entry, 0, len(entries))
+	for _, name := range pinned {
+		i, ok := byName[strings.ToLower(name)]
+		if !ok || picked[i] {
+			continue
+		}
+		picked[i] = true
+		ordered = append(ordered, entries[i])
+	}
+
+	for i, e := range entries {
+		if !picked[i] {
+			ordered = append(ordered, e)
+		}
+	}
+
+	return ordered
+}
+
+// isRecent reports whether the entry is in the recent list.
+func (s *ServiceScreen) isRecent(entry catalog.Entry) bool {
+	return s.pinnedIn(s.recent, entry)
+}
+
+// isRecommended reports whether the entry is in the recommended list.
+func (s *ServiceScreen) isRecommended(entry catalog.Entry) bool {
+	return s.pinnedIn(s.recommended, entry)
+}
+
+func (s *ServiceScreen) pinnedIn(names []string, entry catalog.Entry) bool {
+	if strings.TrimSpace(s.search.Value()) != "" {
+		return false
+	}
+	for _, name := range names {
+		if strings.EqualFold(name, entry.Name) {
+			return true
+		}
+	}
+	return false
+}
+
 // entryLines is the number of rendered lines each service occupies: name and
 // description, plus a metadata line when metadata is available.
 func (s *ServiceScreen) entryLines() int {
@@ -190,7 +290,7 @@ func (s *ServiceScreen) entryLines() int {
 }
 
 func (s *ServiceScreen) maxVisibleEntries() int {
-	lines := s.viewHeight - serviceHeaderLines
+	lines := s.viewHeight - serviceHeaderLines - s.setupPaneLines()
 	per := s.entryLines()
 	if lines < per {
 		return 1
@@ -264,8 +364,15 @@ func (s *ServiceScreen) View() string {
 			}
 		}
 
+		recentTag := ""
+		if s.isRecent(entry) {
+			recentTag = "  \u21ba recent"
+		} else if s.isRecommended(entry) {
+			recentTag = "  \u2605 recommended"
+		}
+
 		if i == s.cursor {
-			label := "  \u276f " + name
+			label := "  \u276f " + name + recentTag
 			if s.width > 0 {
 				b.WriteString(s.theme.Highlight.Width(s.width).Render(label))
 			} else {
@@ -273,6 +380,9 @@ func (s *ServiceScreen) View() string {
 			}
 		} else {
 			b.WriteString("    " + name)
+			if recentTag != "" {
+				b.WriteString(s.theme.Dim.Render(recentTag))
+			}
 		}
 		b.WriteString("\n")
 
@@ -298,9 +408,60 @@ func (s *ServiceScreen) View() string {
 		b.WriteString(s.theme.Dim.Render("          \u2014 end of results \u2014"))
 	}
 
+	if lines := s.setupLines(); len(lines) > 0 {
+		entry, _ := s.currentEntry()
+		b.WriteString("\n\n")
+		b.WriteString(s.theme.Title.Render("  Setup: " + entry.DisplayName()))
+		for _, line := range lines {
+			b.WriteString("\n  " + line)
+		}
+	}
+
 	return b.String()
 }
 
+// currentEntry returns the highlighted entry.
+func (s *ServiceScreen) currentEntry() (catalog.Entry, bool) {
+	if s.cursor < 0 || s.cursor >= len(s.filtered) {
+		return catalog.Entry{}, false
+	}
+	return s.filtered[s.cursor], true
+}
+
+// setupLines returns the setup pane shown under the list for the highlighted
+// service: its setup notes, cut to serviceSetupMaxLines, and its docs link.
+func (s *ServiceScreen) setupLines() []string {
+	entry, ok := s.currentEntry()
+	if !ok {
+		return nil
+	}
+
+	var lines []string
+	for _, line := range strings.Split(entry.SetupNotes(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > serviceSetupMaxLines {
+		lines = append(lines[:serviceSetupMaxLines-1], lines[serviceSetupMaxLines-1]+" \u2026")
+	}
+
+	if docsURL := entry.DocsURL(); docsURL != "" {
+		lines = append(lines, "Docs: "+docsURL)
+	}
+	return lines
+}
+
+// setupPaneLines is the height of the setup pane: a blank line and a heading
+// above its lines, or nothing when the highlighted service has no notes.
+func (s *ServiceScreen) setupPaneLines() int {
+	lines := s.setupLines()
+	if len(lines) == 0 {
+		return 0
+	}
+	return len(lines) + 2
+}
+
 func (s *ServiceScreen) countLine() string {
 	if s.loading {
 		return s.theme.Dim.Render("  Loading catalog...")
@@ -347,12 +508,17 @@ func (s *ServiceScreen) StatusHints() []KeyHint {
 			{Key: "Esc", Desc: "back"},
 		}
 	}
-	return []KeyHint{
+	hints := []KeyHint{
 		{Key: "\u2191\u2193", Desc: "move"},
 		{Key: "Enter", Desc: "select"},
 		{Key: "type", Desc: "to filter"},
-		{Key: "Esc", Desc: "back"},
 	}
+	if entry, ok := s.currentEntry(); ok {
+		if desc, url := entryLink(entry); url != "" {
+			hints = append(hints, KeyHint{Key: "Ctrl+O", Desc: desc})
+		}
+	}
+	return append(hints, KeyHint{Key: "Esc", Desc: "back"})
 }
 
 // serviceMetaLine builds a compact, dot-separated metadata summary for an
@@ -360,7 +526,7 @@ func (s *ServiceScreen) StatusHints() []KeyHint {
 func serviceMetaLine(entry catalog.Entry) string {
 	parts := make([]string, 0, 4)
 	if entry.Source != "" {
-		parts = append(parts, string(entry.Source))
+		parts = append(parts, entry.SourceLabel())
 	}
 	if transport := entry.Transport(); transport != "" {
 		parts = append(parts, transport)
@@ -372,6 +538,22 @@ func serviceMetaLine(entry catalog.Entry) string {
 	return strings.Join(parts, " · ")
 }
 
+// entryLink returns the page Ctrl+O opens for an entry, with its hint: the
+// setup docs of a curated service, or a registry server's website, falling
+// back to its repository.
+func entryLink(entry catalog.Entry) (string, string) {
+	if url := entry.DocsURL(); url != "" {
+		return "open docs", url
+	}
+	if url := entry.WebsiteURL(); url != "" {
+		return "open website", url
+	}
+	if url := entry.RepositoryURL(); url != "" {
+		return "open repo", url
+	}
+	return "", ""
+}
+
 // catalogHasMetadata reports whether any entry carries curated or registry
 // detail. Name-only entries (e.g. the uninstall picker) carry none, so the
 // metadata line is suppressed for them.
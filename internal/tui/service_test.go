@@ -511,3 +511,160 @@ func TestServiceMetaLine(t *testing.T) {
 		assert.Equal(t, "registry · stdio · package · none", serviceMetaLine(entry))
 	})
 }
+
+func TestServiceScreen_RecentEntriesListedFirst(t *testing.T) {
+	theme := NewTheme()
+	screen := NewServiceScreen(theme, "curated", 20, nil, nil)
+	screen.SetRecent([]string{"gamma", "missing", "Beta"})
+	s, _ := screen.Update(catalogLoadedMsg{catalog: testServiceCatalog()})
+	ss := s.(*ServiceScreen)
+
+	filtered := ss.Filtered()
+	require.Len(t, filtered, 5)
+	assert.Equal(t, "gamma", filtered[0].Name)
+	assert.Equal(t, "beta", filtered[1].Name)
+	assert.Equal(t, "alpha", filtered[2].Name)
+	assert.Contains(t, ss.View(), "recent")
+}
+
+func TestServiceScreen_RecentIgnoredWhileSearching(t *testing.T) {
+	theme := NewTheme()
+	screen := NewServiceScreen(theme, "curated", 20, nil, nil)
+	screen.SetRecent([]string{"epsilon"})
+	s, _ := screen.Update(catalogLoadedMsg{catalog: testServiceCatalog()})
+	ss := s.(*ServiceScreen)
+
+	s, _ = ss.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	ss = s.(*ServiceScreen)
+
+	filtered := ss.Filtered()
+	require.NotEmpty(t, filtered)
+	assert.Equal(t, "alpha", filtered[0].Name)
+	assert.NotContains(t, ss.View(), "recent")
+}
+
+func TestServiceScreen_RecommendedAfterRecent(t *testing.T) {
+	theme := NewTheme()
+	screen := NewServiceScreen(theme, "curated", 20, nil, nil)
+	screen.SetRecent([]string{"gamma"})
+	screen.SetRecommended([]string{"delta", "gamma"})
+	s, _ := screen.Update(catalogLoadedMsg{catalog: testServiceCatalog()})
+	ss := s.(*ServiceScreen)
+
+	filtered := ss.Filtered()
+	require.Len(t, filtered, 5)
+	assert.Equal(t, "gamma", filtered[0].Name)
+	assert.Equal(t, "delta", filtered[1].Name)
+	assert.Contains(t, ss.View(), "recommended")
+}
+
+func TestServiceScreen_ClearingSearchReusesBrowseOrder(t *testing.T) {
+	theme := NewTheme()
+	screen := NewServiceScreen(theme, "curated", 20, nil, nil)
+	screen.SetRecent([]string{"delta"})
+	s, _ := screen.Update(catalogLoadedMsg{catalog: testServiceCatalog()})
+	screen = s.(*ServiceScreen)
+
+	browse := screen.Filtered()
+	require.Len(t, browse, 5)
+	assert.Equal(t, "delta", browse[0].Name)
+
+	s, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("al")})
+	screen = s.(*ServiceScreen)
+	require.Len(t, screen.Filtered(), 1)
+
+	s, _ = screen.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	screen = s.(*ServiceScreen)
+
+	cleared := screen.Filtered()
+	require.Len(t, cleared, 5)
+	assert.Same(t, &browse[0], &cleared[0], "expected the browse order to be built once per load")
+}
+
+func setupServiceScreen(t *testing.T, viewHeight int) *ServiceScreen {
+	t.Helper()
+	curated := []catalog.Entry{
+		catalog.FromCurated(service.Service{
+			Name:        "alpha",
+			Description: "Alpha service",
+			Setup:       "Create a read-only API token.\nNeeds a Team plan or above.",
+			DocsURL:     "https://docs.example.com/alpha",
+		}),
+		catalog.FromCurated(service.Service{Name: "beta", Description: "Beta service"}),
+	}
+	screen := NewServiceScreen(NewTheme(), "curated", viewHeight, nil, nil)
+	s, _ := screen.Update(catalogLoadedMsg{catalog: catalog.Merge(curated, nil)})
+	return s.(*ServiceScreen)
+}
+
+func TestServiceScreen_ViewShowsSetupNotesForHighlightedService(t *testing.T) {
+	screen := setupServiceScreen(t, 20)
+
+	view := screen.View()
+	assert.Contains(t, view, "Setup: alpha")
+	assert.Contains(t, view, "Create a read-only API token.")
+	assert.Contains(t, view, "Needs a Team plan or above.")
+	assert.Contains(t, view, "Docs: https://docs.example.com/alpha")
+	assert.Contains(t, screen.StatusHints(), KeyHint{Key: "Ctrl+O", Desc: "open docs"})
+
+	var s Screen = screen
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyDown})
+	updated := s.(*ServiceScreen)
+
+	assert.NotContains(t, updated.View(), "Setup:")
+	assert.NotContains(t, updated.StatusHints(), KeyHint{Key: "Ctrl+O", Desc: "open docs"})
+}
+
+func TestServiceScreen_SetupPaneReservesListSpace(t *testing.T) {
+	screen := setupServiceScreen(t, 14)
+
+	// 14 lines, minus the header and a five-line setup pane, leave room for
+	// two three-line entries.
+	assert.Equal(t, 2, screen.maxVisibleEntries())
+}
+
+func TestServiceScreen_CtrlOOpensDocs(t *testing.T) {
+	screen := setupServiceScreen(t, 20)
+
+	var opened string
+	screen.SetOpenURL(func(url string) error {
+		opened = url
+		return nil
+	})
+
+	var s Screen = screen
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+
+	assert.Equal(t, "https://docs.example.com/alpha", opened)
+	assert.Empty(t, s.(*ServiceScreen).search.Value())
+}
+
+func TestServiceScreen_CtrlOOpensRegistryWebsiteOrRepo(t *testing.T) {
+	withWebsite := catalog.FromRegistry(registry.ServerResponse{Server: registry.ServerJSON{
+		Name:       "ns/a-site",
+		WebsiteURL: "https://site.example.com",
+		Repository: &registry.Repository{URL: "https://github.com/example/site"},
+	}})
+	withRepo := catalog.FromRegistry(registry.ServerResponse{Server: registry.ServerJSON{
+		Name:       "ns/b-repo",
+		Repository: &registry.Repository{URL: "https://github.com/example/repo"},
+	}})
+
+	screen := NewServiceScreen(NewTheme(), "registry", 20, nil, nil)
+	s, _ := screen.Update(catalogLoadedMsg{catalog: catalog.Merge(nil, []catalog.Entry{withWebsite, withRepo})})
+
+	var opened []string
+	s.(*ServiceScreen).SetOpenURL(func(url string) error {
+		opened = append(opened, url)
+		return nil
+	})
+
+	assert.Contains(t, s.StatusHints(), KeyHint{Key: "Ctrl+O", Desc: "open website"})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Contains(t, s.StatusHints(), KeyHint{Key: "Ctrl+O", Desc: "open repo"})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+
+	assert.Equal(t, []string{"https://site.example.com", "https://github.com/example/repo"}, opened)
+}
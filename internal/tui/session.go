@@ -0,0 +1,57 @@
+package tui
+
+// Outcome records the result of one operation performed during a TUI session.
+type Outcome struct {
+	Action  string `json:"action"` // "install", "uninstall", or "reinstall"
+	Service string `json:"service"`
+	Target  string `json:"target"` // target slug
+	// Scope is the config scope the operation applied to, when the target
+	// was given one.
+	Scope string `json:"scope,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Failed reports whether the operation failed.
+func (o Outcome) Failed() bool {
+	return o.Error != ""
+}
+
+// Session summarises the operations performed during a TUI session. When the
+// same operation is repeated (for example "Retry failed"), only the latest
+// outcome is kept.
+type Session struct {
+	Outcomes []Outcome `json:"operations"`
+}
+
+// Failures returns the outcomes of operations that failed.
+func (s Session) Failures() []Outcome {
+	var failed []Outcome
+	for _, o := range s.Outcomes {
+		if o.Failed() {
+			failed = append(failed, o)
+		}
+	}
+
+	return failed
+}
+
+func (s Session) record(o Outcome) Session {
+	outcomes := make([]Outcome, 0, len(s.Outcomes)+1)
+	for _, existing := range s.Outcomes {
+		if existing.Action == o.Action && existing.Service == o.Service && existing.Target == o.Target && existing.Scope == o.Scope {
+			continue
+		}
+		outcomes = append(outcomes, existing)
+	}
+
+	return Session{Outcomes: append(outcomes, o)}
+}
+
+func newOutcome(action, service, target string, err error) Outcome {
+	o := Outcome{Action: action, Service: service, Target: target}
+	if err != nil {
+		o.Error = err.Error()
+	}
+
+	return o
+}
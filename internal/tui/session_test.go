@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_RecordKeepsLatestOutcome(t *testing.T) {
+	var session Session
+	session = session.record(newOutcome("install", "sentry", "claude", errors.New("boom")))
+	session = session.record(newOutcome("install", "jira", "claude", nil))
+	session = session.record(newOutcome("install", "sentry", "claude", nil))
+
+	require.Len(t, session.Outcomes, 2)
+	assert.Equal(t, "jira", session.Outcomes[0].Service)
+	assert.Equal(t, "sentry", session.Outcomes[1].Service)
+	assert.Empty(t, session.Failures())
+}
+
+func TestSession_RecordKeepsEachScope(t *testing.T) {
+	user := newOutcome("install", "sentry", "claude", nil)
+	user.Scope = "user"
+	project := newOutcome("install", "sentry", "claude", nil)
+	project.Scope = "project"
+
+	var session Session
+	session = session.record(user)
+	session = session.record(project)
+
+	require.Len(t, session.Outcomes, 2)
+}
+
+func TestSession_Failures(t *testing.T) {
+	var session Session
+	session = session.record(newOutcome("install", "sentry", "claude", nil))
+	session = session.record(newOutcome("install", "sentry", "codex", errors.New("write failed")))
+
+	failures := session.Failures()
+	require.Len(t, failures, 1)
+	assert.Equal(t, "codex", failures[0].Target)
+	assert.Equal(t, "write failed", failures[0].Error)
+}
+
+func TestWizardModel_RecordsApplyOutcomes(t *testing.T) {
+	model := NewWizardModel(testCallbacks(), "1.0.0")
+
+	updated, _ := model.Update(applyResultMsg{
+		index:   0,
+		err:     errors.New("denied"),
+		outcome: newOutcome("install", "sentry", "claude", errors.New("denied")),
+	})
+	wm := updated.(WizardModel)
+
+	failures := wm.Session().Failures()
+	require.Len(t, failures, 1)
+	assert.Equal(t, Outcome{Action: "install", Service: "sentry", Target: "claude", Error: "denied"}, failures[0])
+}
+
+func TestWizardModel_RecordsHealthReinstallOutcome(t *testing.T) {
+	model := NewWizardModel(testCallbacks(), "1.0.0")
+	outcome := newOutcome("reinstall", "jira", "codex", nil)
+
+	updated, _ := model.Update(healthActionMsg{message: "ok", outcome: &outcome})
+	wm := updated.(WizardModel)
+
+	require.Len(t, wm.Session().Outcomes, 1)
+	assert.Empty(t, wm.Session().Failures())
+}
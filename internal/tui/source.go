@@ -3,6 +3,7 @@ package tui
 import (
 	"strings"
 
+	"github.com/andreagrandi/mcp-wire/internal/registry"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -19,16 +20,52 @@ var sourceOptions = []sourceOption{
 	{Label: "Both", Description: "curated + registry combined", Value: "all"},
 }
 
+// liveSourceOption pages through the registry API instead of the cached
+// snapshot. It is offered only when the TUI can reach the registry.
+var liveSourceOption = sourceOption{Label: "Browse live registry", Description: "search the MCP Registry as it is now", Value: sourceLive}
+
 // SourceScreen lets the user choose the service source.
 type SourceScreen struct {
-	theme  Theme
-	cursor int
-	width  int
+	theme      Theme
+	options    []sourceOption
+	cursor     int
+	width      int
+	registries []string
 }
 
 // NewSourceScreen creates a new source selection screen.
 func NewSourceScreen(theme Theme) *SourceScreen {
-	return &SourceScreen{theme: theme}
+	return &SourceScreen{theme: theme, options: sourceOptions}
+}
+
+// SetLiveBrowse adds the option to browse the live registry.
+func (s *SourceScreen) SetLiveBrowse() {
+	s.options = append(append([]sourceOption{}, sourceOptions...), liveSourceOption)
+}
+
+// SetRegistries names the registries registry services come from, in
+// priority order, so the screen can list them when there is more than the
+// official one.
+func (s *SourceScreen) SetRegistries(names []string) {
+	s.registries = names
+}
+
+// description returns the description of opt, naming the registries when
+// more than the official one is configured.
+func (s *SourceScreen) description(opt sourceOption) string {
+	if len(s.registries) == 0 || (len(s.registries) == 1 && s.registries[0] == registry.OfficialName) {
+		return opt.Description
+	}
+
+	names := strings.Join(s.registries, ", ")
+	switch opt.Value {
+	case "registry":
+		return "MCP servers from " + names
+	case "all":
+		return "curated + " + names + " combined"
+	default:
+		return opt.Description
+	}
 }
 
 func (s *SourceScreen) Init() tea.Cmd { return nil }
@@ -46,11 +83,11 @@ func (s *SourceScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 				s.cursor--
 			}
 		case "down", "j":
-			if s.cursor < len(sourceOptions)-1 {
+			if s.cursor < len(s.options)-1 {
 				s.cursor++
 			}
 		case "enter":
-			opt := sourceOptions[s.cursor]
+			opt := s.options[s.cursor]
 			return s, func() tea.Msg {
 				return sourceSelectMsg{source: opt.Value}
 			}
@@ -68,12 +105,13 @@ func (s *SourceScreen) View() string {
 	b.WriteString("\n")
 	b.WriteString("  Where should mcp-wire look for services?\n\n")
 
-	for i, opt := range sourceOptions {
-		desc := s.theme.Dim.Render(opt.Description)
+	for i, opt := range s.options {
+		description := s.description(opt)
+		desc := s.theme.Dim.Render(description)
 		if i == s.cursor {
 			label := "  \u276f " + opt.Label
 			if s.width > 0 {
-				b.WriteString(s.theme.Highlight.Width(s.width).Render(label + "    " + opt.Description))
+				b.WriteString(s.theme.Highlight.Width(s.width).Render(label + "    " + description))
 			} else {
 				b.WriteString(s.theme.Cursor.Render(label) + "    " + desc)
 			}
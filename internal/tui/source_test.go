@@ -155,6 +155,19 @@ func TestSourceScreen_ViewContainsDescriptions(t *testing.T) {
 	assert.Contains(t, view, "curated + registry combined")
 }
 
+func TestSourceScreen_ViewNamesConfiguredRegistries(t *testing.T) {
+	theme := NewTheme()
+	screen := NewSourceScreen(theme)
+
+	screen.SetRegistries([]string{"official"})
+	assert.Contains(t, screen.View(), "community-published MCP servers")
+
+	screen.SetRegistries([]string{"corp", "official"})
+	view := screen.View()
+	assert.Contains(t, view, "MCP servers from corp, official")
+	assert.Contains(t, view, "curated + corp, official combined")
+}
+
 func TestSourceScreen_ViewContainsQuestionHeader(t *testing.T) {
 	theme := NewTheme()
 	screen := NewSourceScreen(theme)
@@ -2,9 +2,11 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	targetpkg "github.com/andreagrandi/mcp-wire/internal/target"
@@ -15,14 +17,50 @@ type targetItem struct {
 	target    targetpkg.Target
 	installed bool
 	checked   bool
+	hidden    bool
+}
+
+// targetGroupItem is a group of targets, from the targets.groups setting,
+// selected together as one entry.
+type targetGroupItem struct {
+	name  string
+	slugs []string
+}
+
+// targetConfiguredMsg reports the result of marking an undetected target
+// as installed.
+type targetConfiguredMsg struct {
+	slug string
+	err  error
+}
+
+// targetHiddenMsg reports the result of hiding a target or showing it again.
+type targetHiddenMsg struct {
+	slug   string
+	hidden bool
+	err    error
 }
 
 // TargetScreen shows a multi-select checkbox list of targets.
 type TargetScreen struct {
 	theme  Theme
+	groups []targetGroupItem
 	items  []targetItem
-	cursor int
+	cursor int // row under the cursor: groups come first, then items
 	width  int
+
+	// configure marks an undetected target as installed with the given
+	// config file. When nil, undetected targets cannot be configured.
+	configure func(t targetpkg.Target, configPath string) error
+	editing   bool
+	pathInput textinput.Model
+	message   string
+	failed    bool
+
+	// setHidden persists whether a target is hidden. When nil, targets
+	// cannot be hidden from this screen.
+	setHidden  func(slug string, hidden bool) error
+	showHidden bool
 }
 
 // NewTargetScreen creates a target multi-select screen.
@@ -60,12 +98,71 @@ func NewTargetScreen(theme Theme, allTargets []targetpkg.Target, preSelected []t
 		}
 	}
 
+	ti := textinput.New()
+	ti.Prompt = "  Config file: "
+	ti.CharLimit = 500
+
 	return &TargetScreen{
-		theme: theme,
-		items: items,
+		theme:     theme,
+		items:     items,
+		pathInput: ti,
+	}
+}
+
+// SetTargetGroups lists groups of targets above the targets, so all of a
+// group's installed targets can be selected with one keystroke. Group
+// members that are not known targets are ignored.
+func (t *TargetScreen) SetTargetGroups(groups map[string][]string) {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	t.groups = nil
+	for _, name := range names {
+		var slugs []string
+		for _, slug := range groups[name] {
+			if t.itemIndex(slug) >= 0 {
+				slugs = append(slugs, slug)
+			}
+		}
+
+		if len(slugs) > 0 {
+			t.groups = append(t.groups, targetGroupItem{name: name, slugs: slugs})
+		}
+	}
+
+	t.cursor = 0
+	if !t.visible(t.cursor) {
+		t.moveCursorDown()
 	}
 }
 
+// SetHiddenTargets hides the targets named in hidden, which the user chose
+// not to see, and lets them hide or show targets by pressing h. Hidden
+// targets stay listed, dimmed, while H is toggled on.
+func (t *TargetScreen) SetHiddenTargets(hidden []string, setHidden func(slug string, hidden bool) error) {
+	t.setHidden = setHidden
+	for i := range t.items {
+		if slices.Contains(hidden, t.items[i].target.Slug()) {
+			t.items[i].hidden = true
+			t.items[i].checked = false
+		}
+	}
+
+	t.cursor = 0
+	if !t.visible(t.cursor) {
+		t.moveCursorDown()
+	}
+}
+
+// SetConfigureTarget lets the user mark an undetected target as installed
+// and give its config file, by pressing c on it.
+func (t *TargetScreen) SetConfigureTarget(configure func(t targetpkg.Target, configPath string) error) {
+	t.configure = configure
+}
+
 func (t *TargetScreen) Init() tea.Cmd { return nil }
 
 func (t *TargetScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
@@ -74,7 +171,19 @@ func (t *TargetScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 		t.width = msg.Width
 		return t, nil
 
+	case targetConfiguredMsg:
+		t.applyConfigured(msg)
+		return t, nil
+
+	case targetHiddenMsg:
+		t.applyHidden(msg)
+		return t, nil
+
 	case tea.KeyMsg:
+		if t.editing {
+			return t.updateEditing(msg)
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			t.moveCursorUp()
@@ -86,6 +195,12 @@ func (t *TargetScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 			t.selectAllInstalled()
 		case "n":
 			t.selectNone()
+		case "c":
+			return t, t.startConfigure()
+		case "h":
+			return t, t.toggleHidden()
+		case "H":
+			t.toggleShowHidden()
 		case "enter":
 			return t.confirm()
 		case "esc":
@@ -96,27 +211,237 @@ func (t *TargetScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 	return t, nil
 }
 
+func (t *TargetScreen) updateEditing(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		t.editing = false
+		t.pathInput.Blur()
+		return t, nil
+	case "enter":
+		t.editing = false
+		t.pathInput.Blur()
+		item := t.itemAt(t.cursor)
+		if item == nil || t.configure == nil {
+			return t, nil
+		}
+
+		configure := t.configure
+		target := item.target
+		configPath := strings.TrimSpace(t.pathInput.Value())
+		return t, func() tea.Msg {
+			return targetConfiguredMsg{slug: target.Slug(), err: configure(target, configPath)}
+		}
+	}
+
+	var cmd tea.Cmd
+	t.pathInput, cmd = t.pathInput.Update(msg)
+	return t, cmd
+}
+
+// startConfigure opens the config file prompt for the undetected target
+// under the cursor, prefilled with the detected path.
+func (t *TargetScreen) startConfigure() tea.Cmd {
+	item := t.itemAt(t.cursor)
+	if t.configure == nil || item == nil || item.installed || item.hidden {
+		return nil
+	}
+
+	value := ""
+	if provider, ok := item.target.(targetpkg.ConfigPathProvider); ok {
+		value = provider.ConfigPath()
+	}
+
+	t.pathInput.SetValue(value)
+	t.pathInput.CursorEnd()
+	t.editing = true
+	t.message = ""
+	return t.pathInput.Focus()
+}
+
+// applyConfigured selects a target once it has been marked as installed.
+func (t *TargetScreen) applyConfigured(msg targetConfiguredMsg) {
+	for i := range t.items {
+		if t.items[i].target.Slug() != msg.slug {
+			continue
+		}
+
+		if msg.err != nil {
+			t.failed = true
+			t.message = fmt.Sprintf("Could not configure %s: %v", t.items[i].target.Name(), msg.err)
+			return
+		}
+
+		t.items[i].installed = true
+		t.items[i].checked = true
+		t.failed = false
+		t.message = t.items[i].target.Name() + " marked as installed."
+		return
+	}
+}
+
+// toggleHidden hides the target under the cursor, or shows it again when
+// it is hidden.
+func (t *TargetScreen) toggleHidden() tea.Cmd {
+	item := t.itemAt(t.cursor)
+	if t.setHidden == nil || item == nil || !t.visible(t.cursor) {
+		return nil
+	}
+
+	setHidden := t.setHidden
+	slug := item.target.Slug()
+	hidden := !item.hidden
+	return func() tea.Msg {
+		return targetHiddenMsg{slug: slug, hidden: hidden, err: setHidden(slug, hidden)}
+	}
+}
+
+// applyHidden updates a target once hiding or showing it was saved. A
+// target that is hidden is also deselected.
+func (t *TargetScreen) applyHidden(msg targetHiddenMsg) {
+	for i := range t.items {
+		if t.items[i].target.Slug() != msg.slug {
+			continue
+		}
+
+		name := t.items[i].target.Name()
+		if msg.err != nil {
+			t.failed = true
+			t.message = fmt.Sprintf("Could not update %s: %v", name, msg.err)
+			return
+		}
+
+		t.items[i].hidden = msg.hidden
+		t.failed = false
+		if msg.hidden {
+			t.items[i].checked = false
+			t.message = name + " hidden; press H to show hidden targets."
+		} else {
+			t.message = name + " is shown again."
+		}
+
+		if !t.visible(t.cursor) {
+			t.moveCursorDown()
+			if !t.visible(t.cursor) {
+				t.moveCursorUp()
+			}
+		}
+		return
+	}
+}
+
+func (t *TargetScreen) toggleShowHidden() {
+	if t.setHidden == nil {
+		return
+	}
+
+	t.showHidden = !t.showHidden
+	if !t.visible(t.cursor) {
+		t.moveCursorDown()
+		if !t.visible(t.cursor) {
+			t.moveCursorUp()
+		}
+	}
+}
+
+// itemAt returns the target item shown at row, or nil for a group row.
+func (t *TargetScreen) itemAt(row int) *targetItem {
+	i := row - len(t.groups)
+	if i < 0 || i >= len(t.items) {
+		return nil
+	}
+
+	return &t.items[i]
+}
+
+// groupAt returns the group shown at row, or nil for a target row.
+func (t *TargetScreen) groupAt(row int) *targetGroupItem {
+	if row < 0 || row >= len(t.groups) {
+		return nil
+	}
+
+	return &t.groups[row]
+}
+
+func (t *TargetScreen) itemIndex(slug string) int {
+	for i := range t.items {
+		if t.items[i].target.Slug() == slug {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// groupMembers returns the indexes of the items a group selects: its
+// installed targets that are not hidden.
+func (t *TargetScreen) groupMembers(group *targetGroupItem) []int {
+	var members []int
+	for _, slug := range group.slugs {
+		i := t.itemIndex(slug)
+		if i >= 0 && t.items[i].installed && !t.items[i].hidden {
+			members = append(members, i)
+		}
+	}
+
+	return members
+}
+
+// visible reports whether the row is listed. Group rows always are.
+func (t *TargetScreen) visible(row int) bool {
+	if t.groupAt(row) != nil {
+		return true
+	}
+
+	item := t.itemAt(row)
+	return item != nil && (t.showHidden || !item.hidden)
+}
+
 func (t *TargetScreen) moveCursorUp() {
-	if t.cursor > 0 {
-		t.cursor--
+	for i := t.cursor - 1; i >= 0; i-- {
+		if t.visible(i) {
+			t.cursor = i
+			return
+		}
 	}
 }
 
 func (t *TargetScreen) moveCursorDown() {
-	if t.cursor < len(t.items)-1 {
-		t.cursor++
+	for i := t.cursor + 1; i < len(t.groups)+len(t.items); i++ {
+		if t.visible(i) {
+			t.cursor = i
+			return
+		}
 	}
 }
 
 func (t *TargetScreen) toggleCurrent() {
-	if t.cursor >= 0 && t.cursor < len(t.items) && t.items[t.cursor].installed {
-		t.items[t.cursor].checked = !t.items[t.cursor].checked
+	if group := t.groupAt(t.cursor); group != nil {
+		t.toggleGroup(group)
+		return
+	}
+
+	if item := t.itemAt(t.cursor); item != nil && item.installed && !item.hidden {
+		item.checked = !item.checked
+	}
+}
+
+// toggleGroup selects every member of a group, or deselects them all when
+// they already are.
+func (t *TargetScreen) toggleGroup(group *targetGroupItem) {
+	members := t.groupMembers(group)
+	allChecked := true
+	for _, i := range members {
+		allChecked = allChecked && t.items[i].checked
+	}
+
+	for _, i := range members {
+		t.items[i].checked = !allChecked
 	}
 }
 
 func (t *TargetScreen) selectAllInstalled() {
 	for i := range t.items {
-		if t.items[i].installed {
+		if t.items[i].installed && !t.items[i].hidden {
 			t.items[i].checked = true
 		}
 	}
@@ -156,7 +481,21 @@ func (t *TargetScreen) View() string {
 	b.WriteString("\n")
 	b.WriteString("  Select targets:\n\n")
 
-	for i, item := range t.items {
+	for row := range t.groups {
+		b.WriteString(t.renderGroupRow(row))
+		b.WriteString("\n")
+	}
+	if len(t.groups) > 0 {
+		b.WriteString("\n")
+	}
+
+	for row := len(t.groups); row < len(t.groups)+len(t.items); row++ {
+		if !t.visible(row) {
+			continue
+		}
+
+		item := t.items[row-len(t.groups)]
+
 		check := "[ ]"
 		if item.checked {
 			check = "[x]"
@@ -164,10 +503,23 @@ func (t *TargetScreen) View() string {
 
 		label := item.target.Name() + " (" + item.target.Slug() + ")"
 
-		if !item.installed {
-			line := "    " + check + " " + label + " \u2014 not installed"
-			b.WriteString(t.theme.Dim.Render(line))
-		} else if i == t.cursor {
+		if item.hidden {
+			prefix := "    "
+			suffix := " \u2014 hidden"
+			if row == t.cursor {
+				prefix = "  \u276f "
+				suffix += " (h to show)"
+			}
+			b.WriteString(t.theme.Dim.Render(prefix + check + " " + label + suffix))
+		} else if !item.installed {
+			prefix := "    "
+			suffix := " \u2014 not installed"
+			if row == t.cursor && t.configure != nil {
+				prefix = "  \u276f "
+				suffix += " (c to configure)"
+			}
+			b.WriteString(t.theme.Dim.Render(prefix + check + " " + label + suffix))
+		} else if row == t.cursor {
 			line := "  \u276f " + check + " " + label
 			if t.width > 0 {
 				b.WriteString(t.theme.Highlight.Width(t.width).Render(line))
@@ -185,6 +537,22 @@ func (t *TargetScreen) View() string {
 		b.WriteString("\n")
 	}
 
+	if t.editing {
+		b.WriteString("\n")
+		b.WriteString(t.pathInput.View())
+		b.WriteString("\n")
+	}
+
+	if t.message != "" {
+		b.WriteString("\n")
+		if t.failed {
+			b.WriteString(t.theme.Error.Render("  " + t.message))
+		} else {
+			b.WriteString(t.theme.Completed.Render("  " + t.message))
+		}
+		b.WriteString("\n")
+	}
+
 	count := len(t.selectedTargets())
 	b.WriteString("\n")
 	if count == 0 {
@@ -196,15 +564,79 @@ func (t *TargetScreen) View() string {
 	return b.String()
 }
 
+// renderGroupRow shows a group with its selectable targets. The box is
+// checked when all of them are selected and shows "-" when some are.
+func (t *TargetScreen) renderGroupRow(row int) string {
+	group := &t.groups[row]
+	members := t.groupMembers(group)
+
+	checked := 0
+	names := make([]string, 0, len(members))
+	for _, i := range members {
+		names = append(names, t.items[i].target.Name())
+		if t.items[i].checked {
+			checked++
+		}
+	}
+
+	check := "[ ]"
+	switch {
+	case len(members) > 0 && checked == len(members):
+		check = "[x]"
+	case checked > 0:
+		check = "[-]"
+	}
+
+	prefix := "    "
+	if row == t.cursor {
+		prefix = "  \u276f "
+	}
+
+	if len(members) == 0 {
+		return t.theme.Dim.Render(prefix + check + " @" + group.name + " \u2014 no installed targets")
+	}
+
+	line := prefix + check + " @" + group.name + " (" + strings.Join(names, ", ") + ")"
+	if row == t.cursor {
+		if t.width > 0 {
+			return t.theme.Highlight.Width(t.width).Render(line)
+		}
+		return t.theme.Cursor.Render(line)
+	}
+
+	return line
+}
+
 func (t *TargetScreen) StatusHints() []KeyHint {
-	return []KeyHint{
+	if t.editing {
+		return []KeyHint{
+			{Key: "Enter", Desc: "save"},
+			{Key: "Esc", Desc: "cancel"},
+		}
+	}
+
+	hints := []KeyHint{
 		{Key: "\u2191\u2193", Desc: "move"},
 		{Key: "Space", Desc: "toggle"},
 		{Key: "a", Desc: "all"},
 		{Key: "n", Desc: "none"},
-		{Key: "Enter", Desc: "confirm"},
-		{Key: "Esc", Desc: "back"},
 	}
+	if t.configure != nil {
+		hints = append(hints, KeyHint{Key: "c", Desc: "configure"})
+	}
+	if t.setHidden != nil {
+		hints = append(hints, KeyHint{Key: "h", Desc: "hide"})
+		if t.showHidden {
+			hints = append(hints, KeyHint{Key: "H", Desc: "hide hidden"})
+		} else {
+			hints = append(hints, KeyHint{Key: "H", Desc: "show hidden"})
+		}
+	}
+
+	return append(hints,
+		KeyHint{Key: "Enter", Desc: "confirm"},
+		KeyHint{Key: "Esc", Desc: "back"},
+	)
 }
 
 // Cursor returns the current cursor position (for testing).
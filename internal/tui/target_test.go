@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -207,6 +208,148 @@ func TestTargetScreen_SpaceIgnoresNotInstalled(t *testing.T) {
 	assert.False(t, lastItem.checked)
 }
 
+func TestTargetScreen_ConfigureMarksUndetectedTargetInstalled(t *testing.T) {
+	theme := NewTheme()
+	screen := NewTargetScreen(theme, testTargets(), nil)
+
+	var configured targetpkg.Target
+	var configuredPath string
+	screen.SetConfigureTarget(func(target targetpkg.Target, configPath string) error {
+		configured = target
+		configuredPath = configPath
+		return nil
+	})
+
+	var s Screen = screen
+	for i := 0; i < len(screen.Items())-1; i++ {
+		s, _ = s.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	assert.Contains(t, s.View(), "c to configure")
+
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	for _, r := range "/opt/opencode.json" {
+		s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.Contains(t, s.View(), "Config file: ")
+
+	s, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	s, _ = s.Update(cmd())
+
+	require.NotNil(t, configured)
+	assert.Equal(t, "opencode", configured.Slug())
+	assert.Equal(t, "/opt/opencode.json", configuredPath)
+
+	updated := s.(*TargetScreen)
+	lastItem := updated.Items()[len(updated.Items())-1]
+	assert.True(t, lastItem.installed)
+	assert.True(t, lastItem.checked)
+	assert.Contains(t, updated.View(), "OpenCode marked as installed.")
+}
+
+func TestTargetScreen_ConfigureNeedsCallback(t *testing.T) {
+	theme := NewTheme()
+	screen := NewTargetScreen(theme, testTargets(), nil)
+
+	var s Screen = screen
+	for i := 0; i < len(screen.Items())-1; i++ {
+		s, _ = s.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+
+	_, cmd := s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	assert.Nil(t, cmd)
+	assert.NotContains(t, s.View(), "Config file: ")
+}
+
+func TestTargetScreen_HiddenTargetsAreLeftOutUntilShown(t *testing.T) {
+	theme := NewTheme()
+	screen := NewTargetScreen(theme, testTargets(), testTargets())
+
+	saved := map[string]bool{}
+	screen.SetHiddenTargets([]string{"claude"}, func(slug string, hidden bool) error {
+		saved[slug] = hidden
+		return nil
+	})
+
+	assert.NotContains(t, screen.View(), "Claude Code")
+	assert.Equal(t, 1, screen.Cursor(), "cursor should skip the hidden target")
+
+	var s Screen = screen
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	for _, target := range s.(*TargetScreen).selectedTargets() {
+		assert.NotEqual(t, "claude", target.Slug())
+	}
+
+	s, cmd := s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	require.NotNil(t, cmd)
+	s, _ = s.Update(cmd())
+	assert.True(t, saved["codex"])
+	assert.Contains(t, s.View(), "Codex hidden; press H to show hidden targets.")
+	assert.Equal(t, 2, s.(*TargetScreen).Cursor())
+
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	assert.Contains(t, s.View(), "Claude Code (claude) \u2014 hidden")
+
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyUp})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyUp})
+	s, cmd = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	require.NotNil(t, cmd)
+	s, _ = s.Update(cmd())
+	assert.False(t, saved["claude"])
+	assert.Contains(t, s.View(), "Claude Code is shown again.")
+}
+
+func TestTargetScreen_HideNeedsCallback(t *testing.T) {
+	theme := NewTheme()
+	screen := NewTargetScreen(theme, testTargets(), nil)
+
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	assert.Nil(t, cmd)
+	for _, hint := range screen.StatusHints() {
+		assert.NotEqual(t, "h", hint.Key)
+	}
+}
+
+func TestTargetScreen_GroupSelectsInstalledMembers(t *testing.T) {
+	theme := NewTheme()
+	screen := NewTargetScreen(theme, testTargets(), []targetpkg.Target{})
+	screen.SetTargetGroups(map[string][]string{
+		"editors": {"claude", "opencode", "unknown"},
+		"empty":   {"unknown"},
+		"clis":    {"codex"},
+	})
+
+	view := screen.View()
+	assert.Contains(t, view, "[ ] @clis (Codex)")
+	assert.Contains(t, view, "[ ] @editors (Claude Code)")
+	assert.NotContains(t, view, "@empty")
+	assert.Less(t, strings.Index(view, "@editors"), strings.Index(view, "Claude Code (claude)"))
+
+	var s Screen = screen
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyDown})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+
+	selected := s.(*TargetScreen).selectedTargets()
+	require.Len(t, selected, 1)
+	assert.Equal(t, "claude", selected[0].Slug())
+	assert.Contains(t, s.View(), "[x] @editors (Claude Code)")
+
+	// Selecting a group again deselects its targets.
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	assert.Empty(t, s.(*TargetScreen).selectedTargets())
+}
+
+func TestTargetScreen_GroupShowsPartialSelection(t *testing.T) {
+	theme := NewTheme()
+	screen := NewTargetScreen(theme, testTargets(), []targetpkg.Target{testTargets()[0]})
+	screen.SetTargetGroups(map[string][]string{"all": {"claude", "codex"}})
+
+	assert.Contains(t, screen.View(), "[-] @all (Claude Code, Codex)")
+
+	s, _ := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	assert.Len(t, s.(*TargetScreen).selectedTargets(), 2)
+}
+
 func TestTargetScreen_SelectAllInstalled(t *testing.T) {
 	theme := NewTheme()
 	// Start with nothing selected.
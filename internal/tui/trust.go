@@ -16,10 +16,11 @@ type trustConfirmMsg struct {
 // TrustScreen displays registry entry metadata and asks for explicit
 // confirmation before proceeding with installation.
 type TrustScreen struct {
-	theme  Theme
-	entry  catalog.Entry
-	cursor int // 0 = No, 1 = Yes
-	width  int
+	theme   Theme
+	entry   catalog.Entry
+	cursor  int // 0 = No, 1 = Yes
+	width   int
+	openURL func(string) error
 }
 
 // NewTrustScreen creates a trust warning screen for the given entry.
@@ -30,6 +31,12 @@ func NewTrustScreen(theme Theme, entry catalog.Entry) *TrustScreen {
 	}
 }
 
+// SetOpenURL sets how the entry's repository and website are opened, so the
+// project can be inspected before it is trusted.
+func (t *TrustScreen) SetOpenURL(openURL func(string) error) {
+	t.openURL = openURL
+}
+
 func (t *TrustScreen) Init() tea.Cmd { return nil }
 
 func (t *TrustScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
@@ -55,12 +62,22 @@ func (t *TrustScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 			}
 		case "esc":
 			return t, func() tea.Msg { return BackMsg{} }
+		case "r":
+			t.open(t.entry.RepositoryURL())
+		case "w":
+			t.open(t.entry.WebsiteURL())
 		}
 	}
 
 	return t, nil
 }
 
+func (t *TrustScreen) open(url string) {
+	if url != "" && t.openURL != nil {
+		_ = t.openURL(url)
+	}
+}
+
 func (t *TrustScreen) View() string {
 	var b strings.Builder
 
@@ -127,6 +144,10 @@ func (t *TrustScreen) View() string {
 		b.WriteString(t.metaLine("Repo", repoURL))
 	}
 
+	if websiteURL := t.entry.WebsiteURL(); websiteURL != "" {
+		b.WriteString(t.metaLine("Website", websiteURL))
+	}
+
 	// Caution text.
 	b.WriteString("\n")
 	b.WriteString(t.theme.Warning.Render("  Registry services are community-published. Review before proceeding."))
@@ -163,20 +184,20 @@ func (t *TrustScreen) renderChoices() string {
 }
 
 func (t *TrustScreen) StatusHints() []KeyHint {
-	return []KeyHint{
+	hints := []KeyHint{
 		{Key: "\u2190\u2192", Desc: "choose"},
 		{Key: "Enter", Desc: "confirm"},
-		{Key: "Esc", Desc: "back"},
 	}
+	if t.openURL != nil && t.entry.RepositoryURL() != "" {
+		hints = append(hints, KeyHint{Key: "r", Desc: "open repo"})
+	}
+	if t.openURL != nil && t.entry.WebsiteURL() != "" {
+		hints = append(hints, KeyHint{Key: "w", Desc: "open website"})
+	}
+	return append(hints, KeyHint{Key: "Esc", Desc: "back"})
 }
 
 // Cursor returns the current cursor position (for testing).
 func (t *TrustScreen) Cursor() int {
 	return t.cursor
 }
-
-// registryEntryNeedsConfirmation returns true if the entry requires a trust
-// confirmation screen before proceeding.
-func registryEntryNeedsConfirmation(entry catalog.Entry) bool {
-	return entry.Source == catalog.SourceRegistry
-}
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/flow"
 	"github.com/andreagrandi/mcp-wire/internal/registry"
 	"github.com/andreagrandi/mcp-wire/internal/service"
 )
@@ -303,8 +304,45 @@ func TestTrustScreen_WindowResize(t *testing.T) {
 
 func TestRegistryEntryNeedsConfirmation(t *testing.T) {
 	curated := catalog.FromCurated(service.Service{Name: "sentry"})
-	assert.False(t, registryEntryNeedsConfirmation(curated))
+	assert.False(t, flow.NeedsTrust(curated))
 
 	reg := testRegistryEntry()
-	assert.True(t, registryEntryNeedsConfirmation(reg))
+	assert.True(t, flow.NeedsTrust(reg))
+}
+
+func TestTrustScreen_OpensRepositoryAndWebsite(t *testing.T) {
+	entry := testRegistryEntry()
+	entry.Registry.Server.WebsiteURL = "https://svc.example.com"
+
+	screen := NewTrustScreen(NewTheme(), entry)
+	var opened []string
+	screen.SetOpenURL(func(url string) error {
+		opened = append(opened, url)
+		return nil
+	})
+
+	assert.Contains(t, screen.View(), "https://svc.example.com")
+	assert.Contains(t, screen.StatusHints(), KeyHint{Key: "r", Desc: "open repo"})
+	assert.Contains(t, screen.StatusHints(), KeyHint{Key: "w", Desc: "open website"})
+
+	screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+
+	assert.Equal(t, []string{"https://github.com/example/svc", "https://svc.example.com"}, opened)
+	assert.Equal(t, 0, screen.Cursor())
+}
+
+func TestTrustScreen_OpenKeysHiddenWithoutLinks(t *testing.T) {
+	screen := NewTrustScreen(NewTheme(), testRegistryEntryWithPackage())
+	screen.SetOpenURL(func(string) error {
+		t.Fatal("expected nothing to be opened")
+		return nil
+	})
+
+	screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+
+	for _, hint := range screen.StatusHints() {
+		assert.NotEqual(t, "r", hint.Key)
+		assert.NotEqual(t, "w", hint.Key)
+	}
 }
@@ -0,0 +1,196 @@
+// Package webhook posts install, uninstall and auth events to a URL
+// configured by the user, so platform teams can track MCP adoption and
+// enforce policy centrally.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/andreagrandi/mcp-wire/internal/app"
+	"github.com/andreagrandi/mcp-wire/internal/clock"
+)
+
+const (
+	// SignatureHeader carries the hex HMAC-SHA256 of the request body,
+	// prefixed with "sha256=", when a secret is configured.
+	SignatureHeader = "X-MCP-Wire-Signature"
+	// EventHeader carries the event type.
+	EventHeader = "X-MCP-Wire-Event"
+
+	defaultTimeout  = 5 * time.Second
+	defaultAttempts = 3
+	defaultBackoff  = 500 * time.Millisecond
+	queueSize       = 64
+)
+
+// Event types.
+const (
+	EventInstall   = "install"
+	EventUninstall = "uninstall"
+	EventAuth      = "auth"
+)
+
+// Event describes one operation on one target. It never carries credential
+// values.
+type Event struct {
+	Type    string    `json:"event"`
+	Service string    `json:"service"`
+	Target  string    `json:"target"`
+	Scope   string    `json:"scope,omitempty"`
+	Status  string    `json:"status"`
+	Time    time.Time `json:"timestamp"`
+	Version string    `json:"mcp_wire_version"`
+}
+
+// Emitter delivers events in the background. Emit never blocks: events are
+// queued and posted one at a time, and a delivery that fails with a network
+// error, 429 or 5xx response is retried with backoff.
+type Emitter struct {
+	url        string
+	secret     []byte
+	httpClient *http.Client
+	attempts   int
+	backoff    time.Duration
+	clock      clock.Clock
+
+	queue     chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu      sync.Mutex
+	dropped int
+	failed  int
+}
+
+// NewEmitter creates an emitter posting to url and starts its worker. An
+// empty secret sends events unsigned.
+func NewEmitter(url string, secret string) *Emitter {
+	e := &Emitter{
+		url:        url,
+		secret:     []byte(secret),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		attempts:   defaultAttempts,
+		backoff:    defaultBackoff,
+		clock:      clock.System,
+		queue:      make(chan Event, queueSize),
+		done:       make(chan struct{}),
+	}
+
+	go e.run()
+
+	return e
+}
+
+// Emit queues event for delivery. When the queue is full the event is
+// dropped rather than slowing the operation down.
+func (e *Emitter) Emit(event Event) {
+	if event.Time.IsZero() {
+		event.Time = clock.Stamp(e.clock)
+	}
+
+	if event.Version == "" {
+		event.Version = app.Version
+	}
+
+	select {
+	case e.queue <- event:
+	default:
+		e.mu.Lock()
+		e.dropped++
+		e.mu.Unlock()
+	}
+}
+
+// Close stops accepting events and waits up to timeout for the queued ones
+// to be delivered. It reports how many events were dropped or failed, and
+// whether it gave up waiting.
+func (e *Emitter) Close(timeout time.Duration) (undelivered int, timedOut bool) {
+	e.closeOnce.Do(func() { close(e.queue) })
+
+	select {
+	case <-e.done:
+	case <-time.After(timeout):
+		timedOut = true
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.dropped + e.failed, timedOut
+}
+
+func (e *Emitter) run() {
+	defer close(e.done)
+
+	for event := range e.queue {
+		if err := e.deliver(event); err != nil {
+			e.mu.Lock()
+			e.failed++
+			e.mu.Unlock()
+		}
+	}
+}
+
+func (e *Emitter) deliver(event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encode webhook event: %w", err)
+	}
+
+	backoff := e.backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := e.post(event.Type, body)
+		if err == nil || !retry || attempt >= e.attempts {
+			return err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post sends one delivery attempt and reports whether a failure is worth
+// retrying.
+func (e *Emitter) post(eventType string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("mcp-wire/%s", app.Version))
+	req.Header.Set(EventHeader, eventType)
+	if len(e.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(e.secret, body))
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("post webhook event: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("post webhook event: unexpected status %d", resp.StatusCode)
+}
+
+// Sign returns the signature header value for body: "sha256=" followed by
+// the hex HMAC-SHA256 of body keyed with secret.
+func Sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
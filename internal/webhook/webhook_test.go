@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newRecordingServer(t *testing.T, statuses ...int) (*httptest.Server, func() []recordedRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		requests = append(requests, recordedRequest{header: r.Header.Clone(), body: body})
+		status := http.StatusNoContent
+		if len(requests) <= len(statuses) {
+			status = statuses[len(requests)-1]
+		}
+		mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]recordedRequest(nil), requests...)
+	}
+}
+
+func TestEmitterSignsEvents(t *testing.T) {
+	server, requests := newRecordingServer(t)
+
+	emitter := NewEmitter(server.URL, "s3cret")
+	emitter.Emit(Event{Type: EventInstall, Service: "sentry", Target: "claude", Scope: "user", Status: "configured"})
+	if undelivered, timedOut := emitter.Close(5 * time.Second); undelivered != 0 || timedOut {
+		t.Fatalf("expected event delivered, got undelivered=%d timedOut=%v", undelivered, timedOut)
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("expected one request, got %d", len(got))
+	}
+
+	if signature := got[0].header.Get(SignatureHeader); signature != Sign([]byte("s3cret"), got[0].body) {
+		t.Fatalf("unexpected signature %q", signature)
+	}
+
+	if eventType := got[0].header.Get(EventHeader); eventType != EventInstall {
+		t.Fatalf("unexpected event header %q", eventType)
+	}
+
+	var event Event
+	if err := json.Unmarshal(got[0].body, &event); err != nil {
+		t.Fatalf("failed to decode %q: %v", got[0].body, err)
+	}
+
+	if event.Service != "sentry" || event.Target != "claude" || event.Status != "configured" || event.Time.IsZero() || event.Version == "" {
+		t.Fatalf("unexpected event %#v", event)
+	}
+}
+
+func TestEmitterRetriesServerErrorsOnly(t *testing.T) {
+	server, requests := newRecordingServer(t, http.StatusServiceUnavailable, http.StatusOK, http.StatusBadRequest)
+
+	emitter := NewEmitter(server.URL, "")
+	emitter.backoff = time.Millisecond
+	emitter.Emit(Event{Type: EventUninstall, Service: "sentry", Target: "codex", Status: "removed"})
+	emitter.Emit(Event{Type: EventAuth, Service: "sentry", Target: "codex", Status: "authenticated"})
+
+	undelivered, _ := emitter.Close(5 * time.Second)
+	if undelivered != 1 {
+		t.Fatalf("expected the rejected event to be undelivered, got %d", undelivered)
+	}
+
+	got := requests()
+	if len(got) != 3 {
+		t.Fatalf("expected a retry for 503 and none for 400, got %d requests", len(got))
+	}
+
+	if signature := got[0].header.Get(SignatureHeader); signature != "" {
+		t.Fatalf("expected unsigned event without a secret, got %q", signature)
+	}
+}
+
+func TestEmitDoesNotBlockOnSlowEndpoint(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	emitter := NewEmitter(server.URL, "")
+	start := time.Now()
+	for range queueSize * 2 {
+		emitter.Emit(Event{Type: EventInstall, Service: "sentry", Target: "claude", Status: "configured"})
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected Emit not to block, took %s", elapsed)
+	}
+
+	if _, timedOut := emitter.Close(10 * time.Millisecond); !timedOut {
+		t.Fatal("expected Close to give up on the slow endpoint")
+	}
+}
@@ -0,0 +1,111 @@
+package mcpwire_test
+
+import "github.com/andreagrandi/mcp-wire/pkg/mcpwire"
+
+// This file pins the public API from outside the package. The types in
+// mcpwire are aliases of internal ones, so a change to an internal type
+// changes the API too; one that breaks callers or implementers stops this
+// file from compiling. Extend it whenever the API grows.
+
+// Functions and variables.
+var (
+	_ func(...string) (map[string]mcpwire.Service, error)                                 = mcpwire.LoadServices
+	_ func(mcpwire.Service) error                                                         = mcpwire.ValidateService
+	_ func() []mcpwire.Target                                                             = mcpwire.AllTargets
+	_ func() []mcpwire.Target                                                             = mcpwire.InstalledTargets
+	_ func(string) (mcpwire.Target, bool)                                                 = mcpwire.FindTarget
+	_ func(...mcpwire.CredentialSource) *mcpwire.CredentialResolver                       = mcpwire.NewCredentialResolver
+	_ func() *mcpwire.CredentialResolver                                                  = mcpwire.DefaultCredentialResolver
+	_ func() mcpwire.CredentialSource                                                     = mcpwire.NewEnvCredentialSource
+	_ func(string) mcpwire.CredentialSource                                               = mcpwire.NewFileCredentialSource
+	_ func() *mcpwire.RegistryClient                                                      = mcpwire.NewRegistryClient
+	_ func(string) *mcpwire.RegistryClient                                                = mcpwire.NewRegistryClientWithBaseURL
+	_ func(map[string]mcpwire.Service, []mcpwire.RegistryServer) *mcpwire.Catalog         = mcpwire.NewCatalog
+	_ func(mcpwire.Service, *mcpwire.CredentialResolver) (map[string]string, error)       = mcpwire.ResolveEnv
+	_ func(mcpwire.Target, mcpwire.ConfigScope) bool                                      = mcpwire.SupportsScope
+	_ func(mcpwire.Target, mcpwire.Service, map[string]string, mcpwire.ConfigScope) error = mcpwire.Install
+	_ func(mcpwire.Target, string, mcpwire.ConfigScope) error                             = mcpwire.Uninstall
+	_ func(mcpwire.Target, mcpwire.ConfigScope) ([]string, error)                         = mcpwire.List
+	_ func(mcpwire.Target, string) (mcpwire.Target, bool)                                 = mcpwire.Sandbox
+
+	_ error = mcpwire.ErrNotSupported
+	_ error = mcpwire.ErrMissingCredential
+
+	_ = []mcpwire.ConfigScope{mcpwire.ScopeUser, mcpwire.ScopeProject, mcpwire.ScopeSystem, mcpwire.ScopeRemote}
+)
+
+// Methods of the concrete types.
+var (
+	_ func(*mcpwire.CredentialResolver, string) (string, string, bool)               = (*mcpwire.CredentialResolver).Resolve
+	_ func(*mcpwire.RegistryClient, string) (*mcpwire.RegistryServer, error)         = (*mcpwire.RegistryClient).GetServerLatest
+	_ func(*mcpwire.RegistryClient, string, string) (*mcpwire.RegistryServer, error) = (*mcpwire.RegistryClient).GetServerVersion
+	_ func(*mcpwire.Catalog) []mcpwire.CatalogEntry                                  = (*mcpwire.Catalog).All
+	_ func(*mcpwire.Catalog, string) []mcpwire.CatalogEntry                          = (*mcpwire.Catalog).Search
+	_ func(*mcpwire.Catalog, string) (mcpwire.CatalogEntry, bool)                    = (*mcpwire.Catalog).Find
+	_ func(*mcpwire.Catalog) int                                                     = (*mcpwire.Catalog).Count
+)
+
+// Struct fields. Keyed literals keep compiling when fields are added, and
+// stop when one is removed, renamed or retyped.
+var (
+	_ = mcpwire.Service{
+		Name:          "",
+		Extends:       "",
+		Template:      false,
+		Description:   "",
+		Setup:         "",
+		DocsURL:       "",
+		Transport:     "",
+		Auth:          "",
+		URL:           "",
+		Command:       "",
+		Args:          []string{},
+		Env:           []mcpwire.EnvVar{{Name: "", Description: "", Required: false, Default: "", SetupURL: "", SetupHint: ""}},
+		DependsOn:     []string{},
+		ConflictsWith: []string{},
+		Ports:         []string{},
+		Serve:         &mcpwire.ServeConfig{Command: "", Args: []string{}, Transport: "", URL: ""},
+		Extra:         map[string]any{},
+		Headers:       map[string]string{},
+		Pin:           "",
+	}
+
+	_ = mcpwire.CatalogEntry{
+		Name:     "",
+		Curated:  &mcpwire.Service{},
+		Registry: &mcpwire.RegistryServer{},
+	}
+)
+
+// apiTarget and apiScopedTarget implement exactly the documented methods, so
+// adding a method to Target or ScopedTarget, which breaks every outside
+// implementation, fails here.
+type apiTarget struct{}
+
+func (apiTarget) Name() string                                     { return "" }
+func (apiTarget) Slug() string                                     { return "" }
+func (apiTarget) IsInstalled() bool                                { return false }
+func (apiTarget) Install(mcpwire.Service, map[string]string) error { return nil }
+func (apiTarget) Uninstall(string) error                           { return nil }
+func (apiTarget) List() ([]string, error)                          { return nil, nil }
+
+type apiScopedTarget struct{ apiTarget }
+
+func (apiScopedTarget) SupportedScopes() []mcpwire.ConfigScope { return nil }
+func (apiScopedTarget) InstallWithScope(mcpwire.Service, map[string]string, mcpwire.ConfigScope) error {
+	return nil
+}
+func (apiScopedTarget) UninstallWithScope(string, mcpwire.ConfigScope) error { return nil }
+func (apiScopedTarget) ListWithScope(mcpwire.ConfigScope) ([]string, error)  { return nil, nil }
+
+type apiCredentialSource struct{}
+
+func (apiCredentialSource) Name() string               { return "" }
+func (apiCredentialSource) Get(string) (string, bool)  { return "", false }
+func (apiCredentialSource) Store(string, string) error { return nil }
+
+var (
+	_ mcpwire.Target           = apiTarget{}
+	_ mcpwire.ScopedTarget     = apiScopedTarget{}
+	_ mcpwire.CredentialSource = apiCredentialSource{}
+)
@@ -0,0 +1,119 @@
+package mcpwire
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// ErrMissingCredential is wrapped by ResolveEnv when a required environment
+// variable has no value in any credential source.
+var ErrMissingCredential = errors.New("missing required credential")
+
+// ResolveEnv looks up every environment variable svc declares. Optional
+// variables without a value fall back to their default or are left out.
+func ResolveEnv(svc Service, resolver *CredentialResolver) (map[string]string, error) {
+	resolved := make(map[string]string, len(svc.Env))
+
+	for _, envVar := range svc.Env {
+		if value, _, found := resolver.Resolve(envVar.Name); found {
+			resolved[envVar.Name] = value
+			continue
+		}
+
+		if envVar.Default != "" {
+			resolved[envVar.Name] = envVar.Default
+			continue
+		}
+
+		if envVar.Required {
+			return nil, fmt.Errorf("%w %s for service %q", ErrMissingCredential, envVar.Name, svc.Name)
+		}
+	}
+
+	return resolved, nil
+}
+
+// SupportsScope reports whether t can install into scope. Every target
+// supports ScopeUser.
+func SupportsScope(t Target, scope ConfigScope) bool {
+	scopedTarget, ok := t.(ScopedTarget)
+	if !ok {
+		return scope == ScopeUser
+	}
+
+	for _, supported := range scopedTarget.SupportedScopes() {
+		if supported == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Install writes svc into t in the given scope. An empty scope means
+// ScopeUser.
+func Install(t Target, svc Service, resolvedEnv map[string]string, scope ConfigScope) error {
+	if scope == "" {
+		scope = ScopeUser
+	}
+
+	if !SupportsScope(t, scope) {
+		return fmt.Errorf("target %s does not support %s scope", t.Slug(), scope)
+	}
+
+	if scopedTarget, ok := t.(ScopedTarget); ok {
+		return scopedTarget.InstallWithScope(svc, resolvedEnv, scope)
+	}
+
+	return t.Install(svc, resolvedEnv)
+}
+
+// Uninstall removes the service named serviceName from t in the given scope.
+// An empty scope means ScopeUser.
+func Uninstall(t Target, serviceName string, scope ConfigScope) error {
+	if scope == "" {
+		scope = ScopeUser
+	}
+
+	if !SupportsScope(t, scope) {
+		return fmt.Errorf("target %s does not support %s scope", t.Slug(), scope)
+	}
+
+	if scopedTarget, ok := t.(ScopedTarget); ok {
+		return scopedTarget.UninstallWithScope(serviceName, scope)
+	}
+
+	return t.Uninstall(serviceName)
+}
+
+// List returns the names of the services configured in t for the given
+// scope. An empty scope means ScopeUser.
+func List(t Target, scope ConfigScope) ([]string, error) {
+	if scope == "" {
+		scope = ScopeUser
+	}
+
+	if !SupportsScope(t, scope) {
+		return nil, fmt.Errorf("target %s does not support %s scope", t.Slug(), scope)
+	}
+
+	if scopedTarget, ok := t.(ScopedTarget); ok {
+		return scopedTarget.ListWithScope(scope)
+	}
+
+	return t.List()
+}
+
+// Sandbox returns a copy of t that keeps its config under dir, for tests that
+// must not touch the real tool configuration. It reports false when t cannot
+// be redirected.
+func Sandbox(t Target, dir string) (Target, bool) {
+	sandboxer, ok := t.(target.Sandboxer)
+	if !ok {
+		return nil, false
+	}
+
+	return sandboxer.Sandbox(dir), true
+}
@@ -0,0 +1,139 @@
+// Package mcpwire is the stable Go API for embedding mcp-wire. It exposes the
+// service definitions, targets, credential sources, registry client and
+// catalog the CLI is built on, so other tools can install and uninstall MCP
+// services without shelling out to the mcp-wire binary.
+//
+// The types below are aliases of the ones the CLI uses, so values move freely
+// between this package and any target or source it returns. Only what is
+// declared here is covered by compatibility guarantees, and api_test.go pins
+// it, including the fields and methods the aliases bring along.
+package mcpwire
+
+import (
+	"github.com/andreagrandi/mcp-wire/internal/catalog"
+	"github.com/andreagrandi/mcp-wire/internal/credential"
+	"github.com/andreagrandi/mcp-wire/internal/registry"
+	"github.com/andreagrandi/mcp-wire/internal/service"
+	"github.com/andreagrandi/mcp-wire/internal/target"
+)
+
+// Service is an MCP server definition: what to install.
+type Service = service.Service
+
+// EnvVar describes an environment variable a service needs at install time.
+type EnvVar = service.EnvVar
+
+// ServeConfig describes how to run a service as a long-lived local server.
+type ServeConfig = service.ServeConfig
+
+// Target is an AI coding tool mcp-wire can write service entries into.
+type Target = target.Target
+
+// ScopedTarget is a target that can install into more than one scope.
+type ScopedTarget = target.ScopedTarget
+
+// ConfigScope selects which of a target's config files an operation uses.
+type ConfigScope = target.ConfigScope
+
+const (
+	ScopeUser    ConfigScope = target.ConfigScopeUser
+	ScopeProject ConfigScope = target.ConfigScopeProject
+	ScopeSystem  ConfigScope = target.ConfigScopeSystem
+	ScopeRemote  ConfigScope = target.ConfigScopeRemote
+)
+
+// CredentialSource is a place credential values are looked up in and, when
+// supported, saved to.
+type CredentialSource = credential.Source
+
+// CredentialResolver looks credentials up in an ordered list of sources.
+type CredentialResolver = credential.Resolver
+
+// RegistryClient talks to the MCP Registry API.
+type RegistryClient = registry.Client
+
+// RegistryServer is a server entry returned by the MCP Registry.
+type RegistryServer = registry.ServerResponse
+
+// Catalog is a merged, searchable view of curated services and registry
+// servers.
+type Catalog = catalog.Catalog
+
+// CatalogEntry is one service in a Catalog, curated or from the registry.
+type CatalogEntry = catalog.Entry
+
+// ErrNotSupported is returned by credential sources that cannot save values.
+var ErrNotSupported = credential.ErrNotSupported
+
+// LoadServices loads service definitions from the given directories. With no
+// paths it loads the bundled services followed by the user's own, the same
+// set the CLI offers.
+func LoadServices(paths ...string) (map[string]Service, error) {
+	return service.LoadServices(paths...)
+}
+
+// ValidateService reports the first problem with a service definition.
+func ValidateService(svc Service) error {
+	return service.ValidateService(svc)
+}
+
+// AllTargets returns every target mcp-wire supports.
+func AllTargets() []Target {
+	return target.AllTargets()
+}
+
+// InstalledTargets returns the targets available on this system.
+func InstalledTargets() []Target {
+	return target.InstalledTargets()
+}
+
+// FindTarget looks up a target by slug, such as "claude" or "codex".
+func FindTarget(slug string) (Target, bool) {
+	return target.FindTarget(slug)
+}
+
+// NewCredentialResolver returns a resolver that checks sources in order.
+func NewCredentialResolver(sources ...CredentialSource) *CredentialResolver {
+	return credential.NewResolver(sources...)
+}
+
+// DefaultCredentialResolver returns a resolver over the sources the CLI
+// reads: the environment, the OS keychain when one is available, and the
+// mcp-wire credentials file.
+func DefaultCredentialResolver() *CredentialResolver {
+	sources := []CredentialSource{credential.NewEnvSource()}
+	if keychain := credential.NewKeychainSource(); keychain.Available() {
+		sources = append(sources, keychain)
+	}
+	sources = append(sources, credential.RegisteredSources()...)
+	sources = append(sources, credential.NewFileSource(""))
+
+	return credential.NewResolver(sources...)
+}
+
+// NewEnvCredentialSource returns a source that reads the process environment.
+func NewEnvCredentialSource() CredentialSource {
+	return credential.NewEnvSource()
+}
+
+// NewFileCredentialSource returns a source backed by a credentials file. An
+// empty path uses the default ~/.config/mcp-wire/credentials.
+func NewFileCredentialSource(path string) CredentialSource {
+	return credential.NewFileSource(path)
+}
+
+// NewRegistryClient returns a client for the public MCP Registry.
+func NewRegistryClient() *RegistryClient {
+	return registry.NewClient()
+}
+
+// NewRegistryClientWithBaseURL returns a client for a registry at baseURL.
+func NewRegistryClientWithBaseURL(baseURL string) *RegistryClient {
+	return registry.NewClientWithBaseURL(baseURL)
+}
+
+// NewCatalog merges curated services and registry servers into a catalog.
+// Curated services win when both define the same name.
+func NewCatalog(services map[string]Service, servers []RegistryServer) *Catalog {
+	return catalog.Merge(catalog.FromCuratedMap(services), catalog.FromRegistrySlice(servers))
+}
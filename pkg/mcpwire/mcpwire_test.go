@@ -0,0 +1,111 @@
+package mcpwire
+
+import (
+	"errors"
+	"testing"
+)
+
+type mapSource map[string]string
+
+func (s mapSource) Name() string { return "map" }
+
+func (s mapSource) Get(envName string) (string, bool) {
+	value, ok := s[envName]
+	return value, ok
+}
+
+func (s mapSource) Store(string, string) error { return ErrNotSupported }
+
+func sandboxedTarget(t *testing.T, slug string) Target {
+	t.Helper()
+
+	found, ok := FindTarget(slug)
+	if !ok {
+		t.Fatalf("expected target %q to be registered", slug)
+	}
+
+	sandboxed, ok := Sandbox(found, t.TempDir())
+	if !ok {
+		t.Fatalf("expected target %q to support sandboxing", slug)
+	}
+
+	return sandboxed
+}
+
+func TestInstallListUninstallRoundTrip(t *testing.T) {
+	tgt := sandboxedTarget(t, "claude")
+	svc := Service{Name: "example", Transport: "sse", URL: "https://example.com/sse"}
+
+	if err := Install(tgt, svc, nil, ""); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+
+	names, err := List(tgt, ScopeUser)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(names) != 1 || names[0] != "example" {
+		t.Fatalf("expected [example], got %v", names)
+	}
+
+	if err := Uninstall(tgt, "example", ScopeUser); err != nil {
+		t.Fatalf("uninstall: %v", err)
+	}
+
+	names, err = List(tgt, ScopeUser)
+	if err != nil {
+		t.Fatalf("list after uninstall: %v", err)
+	}
+	if len(names) != 0 {
+		t.Fatalf("expected no services, got %v", names)
+	}
+}
+
+func TestInstallRejectsUnsupportedScope(t *testing.T) {
+	tgt := sandboxedTarget(t, "codex")
+	svc := Service{Name: "example", Transport: "sse", URL: "https://example.com/sse"}
+
+	if err := Install(tgt, svc, nil, ScopeSystem); err == nil {
+		t.Fatal("expected an error for an unsupported scope")
+	}
+}
+
+func TestResolveEnv(t *testing.T) {
+	svc := Service{
+		Name: "example",
+		Env: []EnvVar{
+			{Name: "TOKEN", Required: true},
+			{Name: "REGION", Default: "eu"},
+			{Name: "OPTIONAL"},
+		},
+	}
+
+	resolved, err := ResolveEnv(svc, NewCredentialResolver(mapSource{"TOKEN": "secret"}))
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if resolved["TOKEN"] != "secret" || resolved["REGION"] != "eu" {
+		t.Fatalf("unexpected resolved env: %v", resolved)
+	}
+	if _, ok := resolved["OPTIONAL"]; ok {
+		t.Fatalf("expected OPTIONAL to be left out, got %v", resolved)
+	}
+
+	_, err = ResolveEnv(svc, NewCredentialResolver(mapSource{}))
+	if !errors.Is(err, ErrMissingCredential) {
+		t.Fatalf("expected ErrMissingCredential, got %v", err)
+	}
+}
+
+func TestNewCatalogPrefersCurated(t *testing.T) {
+	curated := map[string]Service{"example": {Name: "example", Description: "curated"}}
+
+	cat := NewCatalog(curated, nil)
+	entry, ok := cat.Find("example")
+	if !ok {
+		t.Fatal("expected example in catalog")
+	}
+	if entry.Description() != "curated" {
+		t.Fatalf("expected curated entry, got %q", entry.Description())
+	}
+}